@@ -163,6 +163,19 @@ type SpiSystemDevicer interface {
 	Close() error
 }
 
+// OneWireSystemDevicer is the interface to a 1-wire device at system level, e.g. the Linux w1 subsystem. The device
+// is accessed by the attributes of the kernel driver for its family.
+type OneWireSystemDevicer interface {
+	// ID returns the identifier of the device in the form "family-serial", e.g. "28-0000075f5ef1".
+	ID() string
+	// ReadData reads the content of the given attribute of the device.
+	ReadData(attribute string) ([]byte, error)
+	// WriteData writes the given data to the given attribute of the device.
+	WriteData(attribute string, data []byte) error
+	// Close the 1-wire connection.
+	Close() error
+}
+
 // BusOperations are functions provided by a bus device, e.g. SPI, i2c.
 type BusOperations interface {
 	// ReadByteData reads a byte from the given register of bus device.
@@ -19,6 +19,12 @@ const (
 	Value = "value"
 	// Vibration event
 	Vibration = "vibration"
+	// ThresholdAbove event
+	ThresholdAbove = "thresholdAbove"
+	// ThresholdBelow event
+	ThresholdBelow = "thresholdBelow"
+	// RateOfChange event
+	RateOfChange = "rateOfChange"
 )
 
 // AnalogReader interface represents an Adaptor which has AnalogRead capabilities
@@ -0,0 +1,49 @@
+package aio
+
+import (
+	"sort"
+)
+
+// AnalogSensorCalibrationPoint is a pair of a read raw value and the related real value
+type AnalogSensorCalibrationPoint struct {
+	Raw   int
+	Value float64
+}
+
+// AnalogSensorMultiPointScaler creates a scaler function from a calibration table, which interpolates
+// linear between the points. Values outside of the table are limited to the first and last point.
+// The points can be given in any order. Without points the scaling is 1:1.
+func AnalogSensorMultiPointScaler(points []AnalogSensorCalibrationPoint) func(input int) (value float64) {
+	table := append([]AnalogSensorCalibrationPoint{}, points...)
+	sort.Slice(table, func(i, j int) bool { return table[i].Raw < table[j].Raw })
+
+	return func(input int) (value float64) {
+		if len(table) == 0 {
+			return float64(input)
+		}
+		if input <= table[0].Raw {
+			return table[0].Value
+		}
+		last := table[len(table)-1]
+		if input >= last.Raw {
+			return last.Value
+		}
+		i := sort.Search(len(table), func(i int) bool { return table[i].Raw >= input })
+		lo, hi := table[i-1], table[i]
+		return lo.Value + (hi.Value-lo.Value)*float64(input-lo.Raw)/float64(hi.Raw-lo.Raw)
+	}
+}
+
+// AnalogSensorPolynomialScaler creates a scaler function from the coefficients of a polynomial,
+// starting with the constant term: value = c[0] + c[1]*input + c[2]*input^2 + ...
+func AnalogSensorPolynomialScaler(coefficients ...float64) func(input int) (value float64) {
+	coeff := append([]float64{}, coefficients...)
+	return func(input int) (value float64) {
+		// Horner's method
+		x := float64(input)
+		for i := len(coeff) - 1; i >= 0; i-- {
+			value = value*x + coeff[i]
+		}
+		return value
+	}
+}
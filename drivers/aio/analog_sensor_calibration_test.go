@@ -0,0 +1,38 @@
+package aio
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestAnalogSensorMultiPointScaler(t *testing.T) {
+	points := []AnalogSensorCalibrationPoint{{Raw: 100, Value: 10}, {Raw: 0, Value: 0}, {Raw: 200, Value: 50}}
+	var tests = map[string]struct {
+		input int
+		want  float64
+	}{
+		"below_table": {input: -5, want: 0},
+		"first_point": {input: 0, want: 0},
+		"first_range": {input: 50, want: 5},
+		"mid_point":   {input: 100, want: 10},
+		"last_range":  {input: 150, want: 30},
+		"above_table": {input: 250, want: 50},
+	}
+	scaler := AnalogSensorMultiPointScaler(points)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gobottest.Assert(t, scaler(tt.input), tt.want)
+		})
+	}
+	// no points
+	gobottest.Assert(t, AnalogSensorMultiPointScaler(nil)(42), 42.0)
+}
+
+func TestAnalogSensorPolynomialScaler(t *testing.T) {
+	scaler := AnalogSensorPolynomialScaler(1, 2, 3)
+	gobottest.Assert(t, scaler(0), 1.0)
+	gobottest.Assert(t, scaler(2), 17.0)
+	gobottest.Assert(t, scaler(-1), 2.0)
+	gobottest.Assert(t, AnalogSensorPolynomialScaler()(5), 0.0)
+}
@@ -1,6 +1,8 @@
 package aio
 
 import (
+	"math"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -15,9 +17,27 @@ type AnalogSensorDriver struct {
 	connection AnalogReader
 	gobot.Eventer
 	gobot.Commander
-	rawValue int
-	value    float64
-	scale    func(input int) (value float64)
+	rawValue        int
+	value           float64
+	scale           func(input int) (value float64)
+	filters         AnalogSensorFilterChain
+	thresholds      []*analogSensorThreshold
+	rateLimit       float64
+	changeThreshold float64
+	mutex           *sync.Mutex
+}
+
+// AnalogSensorThresholdEvent is the data of the events ThresholdAbove and ThresholdBelow
+type AnalogSensorThresholdEvent struct {
+	Level float64
+	Value float64
+}
+
+type analogSensorThreshold struct {
+	level      float64
+	hysteresis float64
+	above      bool
+	known      bool
 }
 
 // NewAnalogSensorDriver returns a new AnalogSensorDriver with a polling interval of
@@ -41,6 +61,7 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *Anal
 		interval:   10 * time.Millisecond,
 		halt:       make(chan bool),
 		scale:      func(input int) (value float64) { return float64(input) },
+		mutex:      &sync.Mutex{},
 	}
 
 	if len(v) > 0 {
@@ -50,6 +71,9 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *Anal
 	d.AddEvent(Data)
 	d.AddEvent(Value)
 	d.AddEvent(Error)
+	d.AddEvent(ThresholdAbove)
+	d.AddEvent(ThresholdBelow)
+	d.AddEvent(RateOfChange)
 
 	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
 		val, err := d.Read()
@@ -69,6 +93,9 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *Anal
 //	Data int - Event is emitted on change and represents the current raw reading from the sensor.
 //	Value float64 - Event is emitted on change and represents the current reading from the sensor.
 //	Error error - Event is emitted on error reading from the sensor.
+//	ThresholdAbove AnalogSensorThresholdEvent - Event is emitted when the value rises above a threshold.
+//	ThresholdBelow AnalogSensorThresholdEvent - Event is emitted when the value falls below a threshold.
+//	RateOfChange float64 - Event is emitted when the change per second exceeds the limit.
 func (a *AnalogSensorDriver) Start() (err error) {
 	if a.interval == 0 {
 		// cyclic reading deactivated
@@ -76,22 +103,35 @@ func (a *AnalogSensorDriver) Start() (err error) {
 	}
 	var oldRawValue = 0
 	var oldValue = 0.0
+	var lastValue float64
+	var lastTime time.Time
 	go func() {
 		timer := time.NewTimer(a.interval)
 		timer.Stop()
 		for {
-			_, err := a.Read()
+			value, err := a.Read()
 			if err != nil {
 				a.Publish(a.Event(Error), err)
 			} else {
-				if a.rawValue != oldRawValue && a.rawValue != -1 {
-					a.Publish(a.Event(Data), a.rawValue)
-					oldRawValue = a.rawValue
+				a.mutex.Lock()
+				rawValue := a.rawValue
+				changeThreshold := a.changeThreshold
+				a.mutex.Unlock()
+				if rawValue != oldRawValue && rawValue != -1 {
+					a.Publish(a.Event(Data), rawValue)
+					oldRawValue = rawValue
+				}
+				if value != oldValue && value != -1 && math.Abs(value-oldValue) >= changeThreshold {
+					a.Publish(a.Event(Value), value)
+					oldValue = value
 				}
-				if a.value != oldValue && a.value != -1 {
-					a.Publish(a.Event(Value), a.value)
-					oldValue = a.value
+				now := time.Now()
+				if !lastTime.IsZero() {
+					a.checkRateOfChange((value-lastValue)/now.Sub(lastTime).Seconds())
 				}
+				lastValue = value
+				lastTime = now
+				a.checkThresholds(value)
 			}
 
 			timer.Reset(a.interval)
@@ -128,12 +168,18 @@ func (a *AnalogSensorDriver) Pin() string { return a.pin }
 // Connection returns the AnalogSensorDrivers Connection
 func (a *AnalogSensorDriver) Connection() gobot.Connection { return a.connection.(gobot.Connection) }
 
-// Read returns the current reading from the sensor
+// Read returns the current reading from the sensor, which is scaled and filtered
 func (a *AnalogSensorDriver) Read() (val float64, err error) {
-	if a.rawValue, err = a.ReadRaw(); err != nil {
+	rawValue, err := a.ReadRaw()
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.rawValue = rawValue
+	if err != nil {
 		return
 	}
-	a.value = a.scale(a.rawValue)
+	a.value = a.filters.Filter(a.scale(a.rawValue))
 	return a.value, nil
 }
 
@@ -144,19 +190,116 @@ func (a *AnalogSensorDriver) ReadRaw() (val int, err error) {
 
 // SetScaler substitute the default 1:1 return value function by a new scaling function
 func (a *AnalogSensorDriver) SetScaler(scaler func(int) float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
 	a.scale = scaler
 }
 
+// AddFilter appends the filters to the signal conditioning chain, which is applied to the scaled value
+// in the given order, e.g. a median filter to remove spikes followed by a moving average.
+func (a *AnalogSensorDriver) AddFilter(filters ...AnalogSensorFilter) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.filters = append(a.filters, filters...)
+}
+
+// ClearFilters removes all filters
+func (a *AnalogSensorDriver) ClearFilters() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.filters = nil
+}
+
+// AddThreshold adds a level, which emits the event ThresholdAbove when the value rises above level+hysteresis
+// and ThresholdBelow when the value falls below level-hysteresis. No event is emitted for the first value.
+func (a *AnalogSensorDriver) AddThreshold(level float64, hysteresis float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.thresholds = append(a.thresholds, &analogSensorThreshold{level: level, hysteresis: math.Abs(hysteresis)})
+}
+
+// ClearThresholds removes all threshold levels
+func (a *AnalogSensorDriver) ClearThresholds() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.thresholds = nil
+}
+
+// SetRateOfChangeLimit sets the limit for the change of the value per second, which emits the event
+// RateOfChange when exceeded in any direction. Zero disables the event (default).
+func (a *AnalogSensorDriver) SetRateOfChangeLimit(limit float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.rateLimit = math.Abs(limit)
+}
+
+// SetChangeThreshold sets the minimum change of the value against the last published value, which is
+// needed to emit the next Value event. The default is zero, so each change is published.
+func (a *AnalogSensorDriver) SetChangeThreshold(delta float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.changeThreshold = math.Abs(delta)
+}
+
 // Value returns the last read value from the sensor
 func (a *AnalogSensorDriver) Value() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
 	return a.value
 }
 
 // RawValue returns the last read raw value from the sensor
 func (a *AnalogSensorDriver) RawValue() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
 	return a.rawValue
 }
 
+func (a *AnalogSensorDriver) checkThresholds(value float64) {
+	a.mutex.Lock()
+	var events []string
+	var data []AnalogSensorThresholdEvent
+	for _, t := range a.thresholds {
+		switch {
+		case !t.known:
+			t.above = value > t.level
+			t.known = true
+		case !t.above && value > t.level+t.hysteresis:
+			t.above = true
+			events = append(events, ThresholdAbove)
+			data = append(data, AnalogSensorThresholdEvent{Level: t.level, Value: value})
+		case t.above && value < t.level-t.hysteresis:
+			t.above = false
+			events = append(events, ThresholdBelow)
+			data = append(data, AnalogSensorThresholdEvent{Level: t.level, Value: value})
+		}
+	}
+	a.mutex.Unlock()
+
+	for i, event := range events {
+		a.Publish(a.Event(event), data[i])
+	}
+}
+
+func (a *AnalogSensorDriver) checkRateOfChange(rate float64) {
+	a.mutex.Lock()
+	limit := a.rateLimit
+	a.mutex.Unlock()
+
+	if limit > 0 && math.Abs(rate) > limit {
+		a.Publish(a.Event(RateOfChange), rate)
+	}
+}
+
 // AnalogSensorLinearScaler creates a linear scaler function from the given values.
 func AnalogSensorLinearScaler(fromMin, fromMax int, toMin, toMax float64) func(input int) (value float64) {
 	m := (toMax - toMin) / float64(fromMax-fromMin)
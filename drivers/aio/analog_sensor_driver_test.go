@@ -3,6 +3,7 @@ package aio
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -187,3 +188,115 @@ func TestAnalogSensorDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestAnalogSensorDriverFilter(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriver(a, "1")
+	d.SetScaler(AnalogSensorLinearScaler(0, 100, 0, 10))
+	d.AddFilter(NewMovingAverageFilter(2))
+
+	raw := 20
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		return raw, nil
+	})
+	val, _ := d.Read()
+	gobottest.Assert(t, val, 2.0)
+	raw = 40
+	val, _ = d.Read()
+	gobottest.Assert(t, val, 3.0)
+	gobottest.Assert(t, d.RawValue(), 40)
+
+	d.ClearFilters()
+	val, _ = d.Read()
+	gobottest.Assert(t, val, 4.0)
+}
+
+func TestAnalogSensorDriverThresholds(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriver(a, "1")
+	d.AddThreshold(50, 5)
+
+	above := make(chan AnalogSensorThresholdEvent, 10)
+	below := make(chan AnalogSensorThresholdEvent, 10)
+	_ = d.On(d.Event(ThresholdAbove), func(v interface{}) {
+		above <- v.(AnalogSensorThresholdEvent)
+	})
+	_ = d.On(d.Event(ThresholdBelow), func(v interface{}) {
+		below <- v.(AnalogSensorThresholdEvent)
+	})
+
+	for _, v := range []float64{40, 52, 56, 53, 48, 44} {
+		d.checkThresholds(v)
+	}
+	gobottest.Assert(t, <-above, AnalogSensorThresholdEvent{Level: 50, Value: 56})
+	gobottest.Assert(t, <-below, AnalogSensorThresholdEvent{Level: 50, Value: 44})
+
+	d.ClearThresholds()
+	d.checkThresholds(60)
+	select {
+	case <-above:
+		t.Errorf("AnalogSensor Event \"ThresholdAbove\" should not published")
+	case <-below:
+		t.Errorf("AnalogSensor Event \"ThresholdBelow\" should not published")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAnalogSensorDriverRateOfChange(t *testing.T) {
+	sem := make(chan float64, 1)
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriver(a, "1")
+	d.SetRateOfChangeLimit(1000)
+	_ = d.Once(d.Event(RateOfChange), func(v interface{}) {
+		sem <- v.(float64)
+	})
+
+	d.checkRateOfChange(-500)
+	d.checkRateOfChange(-2000)
+	select {
+	case rate := <-sem:
+		gobottest.Assert(t, rate, -2000.0)
+	case <-time.After(1 * time.Second):
+		t.Errorf("AnalogSensor Event \"RateOfChange\" was not published")
+	}
+}
+
+func TestAnalogSensorDriverChangeThreshold(t *testing.T) {
+	values := make(chan float64, 10)
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriver(a, "1", 5*time.Millisecond)
+	d.SetChangeThreshold(10)
+	_ = d.On(d.Event(Value), func(v interface{}) {
+		values <- v.(float64)
+	})
+
+	raw := 100
+	mtx := sync.Mutex{}
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return raw, nil
+	})
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, <-values, 100.0)
+
+	mtx.Lock()
+	raw = 105
+	mtx.Unlock()
+	select {
+	case v := <-values:
+		t.Errorf("AnalogSensor Event \"Value\" should not published for %f", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	mtx.Lock()
+	raw = 111
+	mtx.Unlock()
+	select {
+	case v := <-values:
+		gobottest.Assert(t, v, 111.0)
+	case <-time.After(1 * time.Second):
+		t.Errorf("AnalogSensor Event \"Value\" was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,166 @@
+package aio
+
+import (
+	"math"
+	"sort"
+)
+
+// AnalogSensorFilter is a stage of the signal conditioning, see AnalogSensorDriver.AddFilter().
+// The filters can be used also without the driver, e.g. for the values of an ADC driver.
+type AnalogSensorFilter interface {
+	// Filter adds a new input value and returns the filtered value
+	Filter(input float64) float64
+	// Reset clears the history of the filter
+	Reset()
+}
+
+// AnalogSensorFilterChain applies all filters in the given order
+type AnalogSensorFilterChain []AnalogSensorFilter
+
+// Filter implements the AnalogSensorFilter interface.
+func (c AnalogSensorFilterChain) Filter(input float64) float64 {
+	for _, f := range c {
+		input = f.Filter(input)
+	}
+	return input
+}
+
+// Reset implements the AnalogSensorFilter interface.
+func (c AnalogSensorFilterChain) Reset() {
+	for _, f := range c {
+		f.Reset()
+	}
+}
+
+// MovingAverageFilter returns the average of the last values
+type MovingAverageFilter struct {
+	size   int
+	values []float64
+	sum    float64
+}
+
+// NewMovingAverageFilter creates a new filter with the given window size
+func NewMovingAverageFilter(size int) *MovingAverageFilter {
+	if size < 1 {
+		size = 1
+	}
+	return &MovingAverageFilter{size: size}
+}
+
+// Filter implements the AnalogSensorFilter interface.
+func (f *MovingAverageFilter) Filter(input float64) float64 {
+	f.values = append(f.values, input)
+	f.sum += input
+	if len(f.values) > f.size {
+		f.sum -= f.values[0]
+		f.values = f.values[1:]
+	}
+	return f.sum / float64(len(f.values))
+}
+
+// Reset implements the AnalogSensorFilter interface.
+func (f *MovingAverageFilter) Reset() {
+	f.values = nil
+	f.sum = 0
+}
+
+// MedianFilter returns the median of the last values, which removes single spikes
+type MedianFilter struct {
+	size   int
+	values []float64
+}
+
+// NewMedianFilter creates a new filter with the given window size, an odd size is recommended
+func NewMedianFilter(size int) *MedianFilter {
+	if size < 1 {
+		size = 1
+	}
+	return &MedianFilter{size: size}
+}
+
+// Filter implements the AnalogSensorFilter interface.
+func (f *MedianFilter) Filter(input float64) float64 {
+	f.values = append(f.values, input)
+	if len(f.values) > f.size {
+		f.values = f.values[1:]
+	}
+	sorted := append([]float64{}, f.values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// Reset implements the AnalogSensorFilter interface.
+func (f *MedianFilter) Reset() {
+	f.values = nil
+}
+
+// ExponentialFilter is a low pass filter with exponential weighting of the history
+type ExponentialFilter struct {
+	alpha  float64
+	value  float64
+	primed bool
+}
+
+// NewExponentialFilter creates a new filter with the given smoothing factor (0..1). Smaller values
+// result in stronger smoothing, 1 disables the filter.
+func NewExponentialFilter(alpha float64) *ExponentialFilter {
+	return &ExponentialFilter{alpha: math.Max(0, math.Min(1, alpha))}
+}
+
+// Filter implements the AnalogSensorFilter interface.
+func (f *ExponentialFilter) Filter(input float64) float64 {
+	if !f.primed {
+		f.value = input
+		f.primed = true
+		return input
+	}
+	f.value += f.alpha * (input - f.value)
+	return f.value
+}
+
+// Reset implements the AnalogSensorFilter interface.
+func (f *ExponentialFilter) Reset() {
+	f.primed = false
+}
+
+// KalmanFilter is a simple one-dimensional Kalman filter for a constant or slowly changing value
+type KalmanFilter struct {
+	processNoise     float64
+	measurementNoise float64
+	estimate         float64
+	errorCovariance  float64
+	primed           bool
+}
+
+// NewKalmanFilter creates a new filter. The process noise is the expected variance of the real
+// value between two readings, the measurement noise is the variance of the sensor readings.
+// A higher ratio of measurement noise to process noise results in stronger smoothing.
+func NewKalmanFilter(processNoise, measurementNoise float64) *KalmanFilter {
+	return &KalmanFilter{processNoise: processNoise, measurementNoise: measurementNoise}
+}
+
+// Filter implements the AnalogSensorFilter interface.
+func (f *KalmanFilter) Filter(input float64) float64 {
+	if !f.primed {
+		f.estimate = input
+		f.errorCovariance = f.measurementNoise
+		f.primed = true
+		return input
+	}
+	// predict
+	f.errorCovariance += f.processNoise
+	// update
+	gain := f.errorCovariance / (f.errorCovariance + f.measurementNoise)
+	f.estimate += gain * (input - f.estimate)
+	f.errorCovariance *= 1 - gain
+	return f.estimate
+}
+
+// Reset implements the AnalogSensorFilter interface.
+func (f *KalmanFilter) Reset() {
+	f.primed = false
+}
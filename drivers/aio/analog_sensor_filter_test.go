@@ -0,0 +1,55 @@
+package aio
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func applyAnalogSensorFilter(f AnalogSensorFilter, inputs ...float64) []float64 {
+	var got []float64
+	for _, in := range inputs {
+		got = append(got, f.Filter(in))
+	}
+	return got
+}
+
+func TestMovingAverageFilter(t *testing.T) {
+	f := NewMovingAverageFilter(3)
+	gobottest.Assert(t, applyAnalogSensorFilter(f, 3, 6, 9, 12), []float64{3, 4.5, 6, 9})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+}
+
+func TestMedianFilter(t *testing.T) {
+	f := NewMedianFilter(3)
+	gobottest.Assert(t, applyAnalogSensorFilter(f, 5, 7, 100, 6, 5), []float64{5, 6, 7, 7, 6})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+}
+
+func TestExponentialFilter(t *testing.T) {
+	f := NewExponentialFilter(0.5)
+	gobottest.Assert(t, applyAnalogSensorFilter(f, 10, 20, 20), []float64{10, 15, 17.5})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(1), 1.0)
+	// limited to 1
+	gobottest.Assert(t, applyAnalogSensorFilter(NewExponentialFilter(3), 1, 5), []float64{1, 5})
+}
+
+func TestKalmanFilter(t *testing.T) {
+	f := NewKalmanFilter(0, 1)
+	// with no process noise the estimate is the average of all values
+	gobottest.Assert(t, applyAnalogSensorFilter(f, 10, 20, 30), []float64{10, 15, 20})
+	f.Reset()
+	gobottest.Assert(t, f.Filter(4), 4.0)
+}
+
+func TestAnalogSensorFilterChain(t *testing.T) {
+	c := AnalogSensorFilterChain{NewMedianFilter(3), NewMovingAverageFilter(2)}
+	gobottest.Assert(t, applyAnalogSensorFilter(c, 4, 4, 100, 4), []float64{4, 4, 4, 4})
+	c.Reset()
+	gobottest.Assert(t, c.Filter(8), 8.0)
+	// empty chain
+	gobottest.Assert(t, AnalogSensorFilterChain(nil).Filter(3), 3.0)
+}
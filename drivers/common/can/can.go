@@ -0,0 +1,129 @@
+package can
+
+import (
+	"fmt"
+)
+
+const (
+	// MaxStandardID is the highest identifier of a frame in standard format (11 bit)
+	MaxStandardID = 0x7FF
+	// MaxExtendedID is the highest identifier of a frame in extended format (29 bit)
+	MaxExtendedID = 0x1FFFFFFF
+	// MaxDataLength is the maximum count of data bytes of a classic CAN frame
+	MaxDataLength = 8
+)
+
+// Frame is a classic CAN data or remote frame
+type Frame struct {
+	ID       uint32
+	Extended bool // 29 bit identifier
+	Remote   bool // remote transmission request, the data is empty but the length is given by DLC
+	DLC      uint8
+	Data     []byte
+}
+
+// NewFrame creates a new data frame, identifiers above MaxStandardID are in extended format
+func NewFrame(id uint32, data ...byte) Frame {
+	return Frame{ID: id, Extended: id > MaxStandardID, DLC: uint8(len(data)), Data: data}
+}
+
+// Validate checks the identifier and the length of the frame
+func (f Frame) Validate() error {
+	if f.Extended && f.ID > MaxExtendedID || !f.Extended && f.ID > MaxStandardID {
+		return fmt.Errorf("identifier 0x%X is out of range", f.ID)
+	}
+	if f.DLC > MaxDataLength || len(f.Data) > MaxDataLength {
+		return fmt.Errorf("data length %d is out of range", len(f.Data))
+	}
+	if !f.Remote && int(f.DLC) != len(f.Data) {
+		return fmt.Errorf("data length code %d differs from data length %d", f.DLC, len(f.Data))
+	}
+	return nil
+}
+
+// String returns the frame in the format of the candump tool, e.g. "123#DEADBEEF" or "12345678#R2"
+func (f Frame) String() string {
+	var s string
+	if f.Extended {
+		s = fmt.Sprintf("%08X#", f.ID)
+	} else {
+		s = fmt.Sprintf("%03X#", f.ID)
+	}
+	if f.Remote {
+		return fmt.Sprintf("%sR%d", s, f.DLC)
+	}
+	return fmt.Sprintf("%s%X", s, f.Data)
+}
+
+// Filter passes frames, whose identifier equals the identifier of the filter in all bits of the mask. The format
+// (standard or extended) needs to match too.
+type Filter struct {
+	ID       uint32
+	Mask     uint32
+	Extended bool
+}
+
+// NewFilter creates a filter for exactly the given identifier, identifiers above MaxStandardID are in extended
+// format
+func NewFilter(id uint32) Filter {
+	if id > MaxStandardID {
+		return Filter{ID: id, Mask: MaxExtendedID, Extended: true}
+	}
+	return Filter{ID: id, Mask: MaxStandardID}
+}
+
+// Match returns true, if the frame passes the filter
+func (f Filter) Match(frame Frame) bool {
+	return f.Extended == frame.Extended && (frame.ID^f.ID)&f.Mask == 0
+}
+
+// ErrorState is the fault confinement state of a CAN controller
+type ErrorState uint8
+
+const (
+	// ErrorActive is the normal state, the controller takes part in the communication without restrictions
+	ErrorActive ErrorState = iota
+	// ErrorWarning means at least one of the error counters has reached 96
+	ErrorWarning
+	// ErrorPassive means at least one of the error counters has reached 128, the controller sends no active
+	// error frames anymore
+	ErrorPassive
+	// BusOff means the transmit error counter has reached 256, the controller is disconnected from the bus
+	BusOff
+)
+
+var errorStateNames = map[ErrorState]string{
+	ErrorActive:  "error active",
+	ErrorWarning: "error warning",
+	ErrorPassive: "error passive",
+	BusOff:       "bus off",
+}
+
+// String returns the name of the state
+func (s ErrorState) String() string {
+	if n, ok := errorStateNames[s]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// ErrorCounters contains the error counters of the controller and the count of lost frames
+type ErrorCounters struct {
+	Transmit uint8 // transmit error counter (TEC)
+	Receive  uint8 // receive error counter (REC)
+	State    ErrorState
+	// Overflows is the count of frames, which were lost by the controller because all receive buffers were full
+	Overflows uint64
+	// Dropped is the count of frames, which were not delivered because the channel of a receiver was full
+	Dropped uint64
+}
+
+// CanBus is the interface of CAN bus controllers
+type CanBus interface {
+	// Send transmits the frame
+	Send(frame Frame) error
+	// Receive creates a new receiver for all frames, which match one of the filters, or all frames without filter
+	Receive(filters ...Filter) *Receiver
+	// ErrorCounters returns the error counters of the controller
+	ErrorCounters() (ErrorCounters, error)
+}
@@ -0,0 +1,70 @@
+package can
+
+import (
+	"fmt"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestFrameValidate(t *testing.T) {
+	var tests = map[string]struct {
+		frame   Frame
+		wantErr error
+	}{
+		"standard":        {frame: NewFrame(0x7FF, 1, 2, 3)},
+		"extended":        {frame: NewFrame(0x1FFFFFFF)},
+		"remote":          {frame: Frame{ID: 0x123, Remote: true, DLC: 8}},
+		"standard_id_err": {frame: Frame{ID: 0x800}, wantErr: fmt.Errorf("identifier 0x800 is out of range")},
+		"extended_id_err": {
+			frame:   Frame{ID: 0x20000000, Extended: true},
+			wantErr: fmt.Errorf("identifier 0x20000000 is out of range"),
+		},
+		"length_err": {
+			frame:   NewFrame(0x01, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+			wantErr: fmt.Errorf("data length 9 is out of range"),
+		},
+		"dlc_err": {
+			frame:   Frame{ID: 0x01, DLC: 2, Data: []byte{1}},
+			wantErr: fmt.Errorf("data length code 2 differs from data length 1"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			gobottest.Assert(t, tc.frame.Validate(), tc.wantErr)
+		})
+	}
+}
+
+func TestFrameString(t *testing.T) {
+	gobottest.Assert(t, NewFrame(0x123, 0xDE, 0xAD, 0xBE, 0xEF).String(), "123#DEADBEEF")
+	gobottest.Assert(t, Frame{ID: 0x1234, Extended: true, Remote: true, DLC: 2}.String(), "00001234#R2")
+}
+
+func TestFilterMatch(t *testing.T) {
+	var tests = map[string]struct {
+		filter Filter
+		frame  Frame
+		want   bool
+	}{
+		"exact":             {filter: NewFilter(0x123), frame: NewFrame(0x123), want: true},
+		"exact_differs":     {filter: NewFilter(0x123), frame: NewFrame(0x124)},
+		"mask":              {filter: Filter{ID: 0x120, Mask: 0x7F0}, frame: NewFrame(0x12F), want: true},
+		"mask_differs":      {filter: Filter{ID: 0x120, Mask: 0x7F0}, frame: NewFrame(0x130)},
+		"extended":          {filter: NewFilter(0x18FEF100), frame: NewFrame(0x18FEF100, 1), want: true},
+		"format_differs":    {filter: Filter{ID: 0x123, Mask: 0x7FF, Extended: true}, frame: NewFrame(0x123)},
+		"all_standard_ones": {filter: Filter{}, frame: NewFrame(0x7FF), want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			gobottest.Assert(t, tc.filter.Match(tc.frame), tc.want)
+		})
+	}
+}
+
+func TestErrorStateString(t *testing.T) {
+	gobottest.Assert(t, ErrorPassive.String(), "error passive")
+	gobottest.Assert(t, ErrorState(10).String(), "unknown")
+}
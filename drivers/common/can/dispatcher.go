@@ -0,0 +1,124 @@
+package can
+
+import (
+	"sync"
+)
+
+// DefaultReceiveBufferSize is the count of frames, which are buffered by the channel of a receiver
+const DefaultReceiveBufferSize = 64
+
+// Receiver delivers the received frames, which match one of its filters
+type Receiver struct {
+	// C delivers the frames, it is closed by Close() or when the bus is closed
+	C          <-chan Frame
+	c          chan Frame
+	filters    []Filter
+	dispatcher *Dispatcher
+}
+
+// Close stops the delivery of frames and closes the channel C
+func (r *Receiver) Close() {
+	r.dispatcher.remove(r)
+}
+
+func (r *Receiver) match(frame Frame) bool {
+	if len(r.filters) == 0 {
+		return true
+	}
+	for _, f := range r.filters {
+		if f.Match(frame) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatcher delivers received frames to the receivers without blocking, a frame is dropped for a receiver, if
+// its channel is full. It is used by the implementations of CanBus.
+type Dispatcher struct {
+	bufferSize int
+	receivers  []*Receiver
+	dropped    uint64
+	closed     bool
+	mutex      *sync.Mutex
+}
+
+// NewDispatcher creates a new dispatcher, the channel of each receiver buffers the given count of frames
+func NewDispatcher(bufferSize int) *Dispatcher {
+	return &Dispatcher{bufferSize: bufferSize, mutex: &sync.Mutex{}}
+}
+
+// Receive creates a new receiver for the frames, which match one of the filters, or all frames without filter.
+// The channel of the receiver is closed immediately, if the dispatcher is closed.
+func (d *Dispatcher) Receive(filters ...Filter) *Receiver {
+	c := make(chan Frame, d.bufferSize)
+	r := &Receiver{C: c, c: c, filters: append([]Filter{}, filters...), dispatcher: d}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.closed {
+		close(c)
+		return r
+	}
+	d.receivers = append(d.receivers, r)
+	return r
+}
+
+// Dispatch delivers the frame to all matching receivers
+func (d *Dispatcher) Dispatch(frame Frame) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, r := range d.receivers {
+		if !r.match(frame) {
+			continue
+		}
+		select {
+		case r.c <- frame:
+		default:
+			d.dropped++
+		}
+	}
+}
+
+// Dropped returns the count of frames, which were dropped because the channel of a receiver was full
+func (d *Dispatcher) Dropped() uint64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.dropped
+}
+
+// Close closes the channels of all receivers, new receivers are closed immediately
+func (d *Dispatcher) Close() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, r := range d.receivers {
+		close(r.c)
+	}
+	d.receivers = nil
+	d.closed = true
+}
+
+// Reopen allows new receivers after Close(), e.g. on restart of the bus
+func (d *Dispatcher) Reopen() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.closed = false
+}
+
+func (d *Dispatcher) remove(r *Receiver) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for i, rr := range d.receivers {
+		if rr == r {
+			d.receivers = append(d.receivers[:i], d.receivers[i+1:]...)
+			close(r.c)
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package can
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestDispatcher(t *testing.T) {
+	// arrange
+	d := NewDispatcher(2)
+	all := d.Receive()
+	filtered := d.Receive(NewFilter(0x100), Filter{ID: 0x200, Mask: 0x700})
+	// act
+	d.Dispatch(NewFrame(0x100, 1))
+	d.Dispatch(NewFrame(0x300, 2))
+	d.Dispatch(NewFrame(0x2AB, 3))
+	// assert
+	gobottest.Assert(t, (<-all.C).ID, uint32(0x100))
+	gobottest.Assert(t, (<-all.C).ID, uint32(0x300))
+	gobottest.Assert(t, len(all.C), 0)
+	gobottest.Assert(t, (<-filtered.C).ID, uint32(0x100))
+	gobottest.Assert(t, (<-filtered.C).ID, uint32(0x2AB))
+	gobottest.Assert(t, d.Dropped(), uint64(1))
+}
+
+func TestDispatcherClose(t *testing.T) {
+	// arrange
+	d := NewDispatcher(1)
+	r1 := d.Receive()
+	r2 := d.Receive()
+	// act
+	r1.Close()
+	d.Dispatch(NewFrame(0x01))
+	d.Close()
+	r3 := d.Receive()
+	// assert
+	_, ok := <-r1.C
+	gobottest.Assert(t, ok, false)
+	_, ok = <-r2.C
+	gobottest.Assert(t, ok, true)
+	_, ok = <-r2.C
+	gobottest.Assert(t, ok, false)
+	_, ok = <-r3.C
+	gobottest.Assert(t, ok, false)
+	// closing twice is ignored
+	r2.Close()
+	d.Reopen()
+	r4 := d.Receive()
+	d.Dispatch(NewFrame(0x02))
+	gobottest.Assert(t, len(r4.C), 1)
+}
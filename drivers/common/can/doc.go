@@ -0,0 +1,10 @@
+/*
+Package can provides the common interface for CAN bus controllers like the MCP2515 and the SocketCAN adaptor of
+Linux, together with a dispatcher for filtered receive channels and a virtual bus for tests.
+
+Frames are sent by Send(). Received frames are delivered by the channel of a Receiver, which only passes frames
+matching one of its filters, so different parts of an application can receive different messages from the same
+bus. The VirtualBus connects any number of interfaces in memory, like the "vcan" interfaces of Linux, so
+applications can be tested without hardware.
+*/
+package can // import "gobot.io/x/gobot/drivers/common/can"
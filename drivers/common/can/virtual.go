@@ -0,0 +1,83 @@
+package can
+
+import (
+	"fmt"
+	"sync"
+)
+
+// VirtualBus connects any number of interfaces in memory, like the "vcan" interfaces of Linux. A frame sent by an
+// interface is received by all other interfaces of the bus, but not by the sender itself. There is no
+// arbitration and no error handling, so the error counters are always zero.
+type VirtualBus struct {
+	interfaces []*VirtualInterface
+	mutex      *sync.Mutex
+}
+
+// VirtualInterface is an interface of a virtual bus, it implements the CanBus interface
+type VirtualInterface struct {
+	name       string
+	bus        *VirtualBus
+	dispatcher *Dispatcher
+	closed     bool
+}
+
+// NewVirtualBus creates a new virtual bus without interfaces
+func NewVirtualBus() *VirtualBus {
+	return &VirtualBus{mutex: &sync.Mutex{}}
+}
+
+// NewInterface creates a new interface, which is connected to the bus
+func (b *VirtualBus) NewInterface(name string) *VirtualInterface {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	i := &VirtualInterface{name: name, bus: b, dispatcher: NewDispatcher(DefaultReceiveBufferSize)}
+	b.interfaces = append(b.interfaces, i)
+	return i
+}
+
+// Name returns the name of the interface
+func (i *VirtualInterface) Name() string { return i.name }
+
+// Send transmits the frame to all other interfaces of the bus
+func (i *VirtualInterface) Send(frame Frame) error {
+	if err := frame.Validate(); err != nil {
+		return err
+	}
+
+	i.bus.mutex.Lock()
+	defer i.bus.mutex.Unlock()
+
+	if i.closed {
+		return fmt.Errorf("interface %s is closed", i.name)
+	}
+	for _, other := range i.bus.interfaces {
+		if other != i && !other.closed {
+			// the data of the frame is copied for each receiver, like by a real bus
+			f := frame
+			f.Data = append([]byte(nil), frame.Data...)
+			other.dispatcher.Dispatch(f)
+		}
+	}
+	return nil
+}
+
+// Receive creates a new receiver for the frames sent by other interfaces, which match one of the filters, or all
+// frames without filter
+func (i *VirtualInterface) Receive(filters ...Filter) *Receiver {
+	return i.dispatcher.Receive(filters...)
+}
+
+// ErrorCounters returns the count of dropped frames, all other counters are always zero
+func (i *VirtualInterface) ErrorCounters() (ErrorCounters, error) {
+	return ErrorCounters{Dropped: i.dispatcher.Dropped()}, nil
+}
+
+// Close disconnects the interface from the bus and closes all receivers
+func (i *VirtualInterface) Close() {
+	i.bus.mutex.Lock()
+	defer i.bus.mutex.Unlock()
+
+	i.closed = true
+	i.dispatcher.Close()
+}
@@ -0,0 +1,53 @@
+package can
+
+import (
+	"fmt"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// must implement the CanBus interface
+var _ CanBus = (*VirtualInterface)(nil)
+
+func TestVirtualBus(t *testing.T) {
+	// arrange
+	bus := NewVirtualBus()
+	vcan0 := bus.NewInterface("vcan0")
+	vcan1 := bus.NewInterface("vcan1")
+	vcan2 := bus.NewInterface("vcan2")
+	r0 := vcan0.Receive()
+	r1 := vcan1.Receive(NewFilter(0x10))
+	r2 := vcan2.Receive()
+	data := []byte{1, 2}
+	// act
+	err := vcan0.Send(NewFrame(0x10, data...))
+	data[0] = 9
+	_ = vcan2.Send(NewFrame(0x11))
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, vcan0.Name(), "vcan0")
+	gobottest.Assert(t, <-r1.C, NewFrame(0x10, 1, 2))
+	gobottest.Assert(t, <-r2.C, NewFrame(0x10, 1, 2))
+	gobottest.Assert(t, <-r0.C, NewFrame(0x11))
+	gobottest.Assert(t, len(r0.C), 0)
+	gobottest.Assert(t, len(r1.C), 0)
+	gobottest.Assert(t, len(r2.C), 0)
+	counters, err := vcan1.ErrorCounters()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, counters, ErrorCounters{})
+}
+
+func TestVirtualBusSendError(t *testing.T) {
+	// arrange
+	bus := NewVirtualBus()
+	vcan0 := bus.NewInterface("vcan0")
+	r := vcan0.Receive()
+	// act & assert
+	gobottest.Assert(t, vcan0.Send(NewFrame(0x10, 1, 2, 3, 4, 5, 6, 7, 8, 9)),
+		fmt.Errorf("data length 9 is out of range"))
+	vcan0.Close()
+	_, ok := <-r.C
+	gobottest.Assert(t, ok, false)
+	gobottest.Assert(t, vcan0.Send(NewFrame(0x10)), fmt.Errorf("interface vcan0 is closed"))
+}
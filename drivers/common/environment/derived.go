@@ -0,0 +1,65 @@
+package environment
+
+import "math"
+
+// StandardSeaLevelPressure is the pressure of the international standard atmosphere at sea level in [Pa]
+const StandardSeaLevelPressure = 101325.0
+
+// coefficients of the Magnus formula, valid for -45..60 °C over water
+const (
+	magnusA = 17.62
+	magnusB = 243.12 // [°C]
+)
+
+// DewPoint returns the dew point in [°C] for the temperature in [°C] and the relative humidity in [%],
+// calculated by the Magnus formula.
+func DewPoint(temperature float64, humidity float64) float64 {
+	if humidity <= 0 {
+		return math.Inf(-1)
+	}
+	gamma := math.Log(humidity/100) + magnusA*temperature/(magnusB+temperature)
+	return magnusB * gamma / (magnusA - gamma)
+}
+
+// AbsoluteHumidity returns the mass of water vapor in [g/m³] for the temperature in [°C] and the relative
+// humidity in [%].
+func AbsoluteHumidity(temperature float64, humidity float64) float64 {
+	// saturation vapor pressure in [hPa] by the Magnus formula
+	saturation := 6.112 * math.Exp(magnusA*temperature/(magnusB+temperature))
+	// ideal gas law with the specific gas constant of water vapor (461.5 J/(kg·K))
+	return saturation * humidity * 2.1674 / (273.15 + temperature)
+}
+
+// HeatIndex returns the apparent temperature in [°C] for the temperature in [°C] and the relative humidity
+// in [%], calculated by the algorithm of the US National Weather Service.
+func HeatIndex(temperature float64, humidity float64) float64 {
+	t := temperature*9/5 + 32
+
+	// simple formula, which is used for low heat index values
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + humidity*0.094)
+	if (hi+t)/2 >= 80 {
+		// regression of Rothfusz
+		hi = -42.379 + 2.04901523*t + 10.14333127*humidity - 0.22475541*t*humidity -
+			0.00683783*t*t - 0.05481717*humidity*humidity + 0.00122874*t*t*humidity +
+			0.00085282*t*humidity*humidity - 0.00000199*t*t*humidity*humidity
+		switch {
+		case humidity < 13 && t >= 80 && t <= 112:
+			hi -= (13 - humidity) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case humidity > 85 && t >= 80 && t <= 87:
+			hi += (humidity - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return (hi - 32) * 5 / 9
+}
+
+// SeaLevelPressure returns the pressure reduced to sea level in [Pa] for the pressure in [Pa], which is
+// measured at the given altitude in [m] (international barometric formula).
+func SeaLevelPressure(pressure float64, altitude float64) float64 {
+	return pressure / math.Pow(1-altitude/44330, 5.255)
+}
+
+// Altitude returns the altitude in [m] for the measured pressure and the pressure at sea level, both in [Pa]
+// (international barometric formula). Use StandardSeaLevelPressure, if the current value is not known.
+func Altitude(pressure float64, seaLevelPressure float64) float64 {
+	return 44330 * (1 - math.Pow(pressure/seaLevelPressure, 1/5.255))
+}
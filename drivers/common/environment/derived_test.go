@@ -0,0 +1,66 @@
+package environment
+
+import (
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func assertNear(t *testing.T, got float64, want float64, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("got %v, want %v (±%v)", got, want, tolerance)
+	}
+}
+
+func TestDewPoint(t *testing.T) {
+	var tests = map[string]struct {
+		temperature float64
+		humidity    float64
+		want        float64
+	}{
+		"saturated":    {temperature: 20, humidity: 100, want: 20},
+		"room":         {temperature: 25, humidity: 50, want: 13.85},
+		"cold and dry": {temperature: 0, humidity: 30, want: -15.5},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertNear(t, DewPoint(tc.temperature, tc.humidity), tc.want, 0.1)
+		})
+	}
+	gobottest.Assert(t, math.IsInf(DewPoint(20, 0), -1), true)
+}
+
+func TestAbsoluteHumidity(t *testing.T) {
+	assertNear(t, AbsoluteHumidity(20, 100), 17.3, 0.1)
+	assertNear(t, AbsoluteHumidity(25, 50), 11.5, 0.1)
+	gobottest.Assert(t, AbsoluteHumidity(25, 0), 0.0)
+}
+
+func TestHeatIndex(t *testing.T) {
+	var tests = map[string]struct {
+		temperature float64
+		humidity    float64
+		want        float64
+	}{
+		"simple formula": {temperature: 20, humidity: 50, want: 19.4},
+		"rothfusz":       {temperature: 32, humidity: 60, want: 37.1},
+		"low humidity":   {temperature: 35, humidity: 10, want: 31.8},
+		"high humidity":  {temperature: 29, humidity: 90, want: 37.2},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertNear(t, HeatIndex(tc.temperature, tc.humidity), tc.want, 0.2)
+		})
+	}
+}
+
+func TestSeaLevelPressureAndAltitude(t *testing.T) {
+	gobottest.Assert(t, SeaLevelPressure(StandardSeaLevelPressure, 0), StandardSeaLevelPressure)
+	gobottest.Assert(t, Altitude(StandardSeaLevelPressure, StandardSeaLevelPressure), 0.0)
+	assertNear(t, Altitude(89875, StandardSeaLevelPressure), 1000, 1)
+	assertNear(t, SeaLevelPressure(89875, 1000), StandardSeaLevelPressure, 10)
+	// both directions are consistent
+	assertNear(t, Altitude(95000, SeaLevelPressure(95000, 540)), 540, 1e-6)
+}
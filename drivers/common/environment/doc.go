@@ -0,0 +1,10 @@
+/*
+Package environment provides a common view on environmental sensors like BME280, BMP180, BMP280, BMP388,
+SHT2x, SHT3x, TH02 and CCS811, together with derived values like dew point, absolute humidity, heat index
+and the conversion between pressure and altitude.
+
+All values are in common units: temperature in [°C], pressure in [Pa], relative humidity in [%],
+eCO2 in [ppm] and TVOC in [ppb]. A Sampler reads a set of sensors periodically, publishes the merged
+reading and feeds the temperature and humidity to sensors, which need it for compensation (e.g. CCS811).
+*/
+package environment // import "gobot.io/x/gobot/drivers/common/environment"
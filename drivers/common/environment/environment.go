@@ -0,0 +1,93 @@
+package environment
+
+import "time"
+
+// Reading contains the values of one or more environmental sensors. Values, which are not available, are nil.
+type Reading struct {
+	Time        time.Time
+	Temperature *float64 // [°C]
+	Pressure    *float64 // [Pa]
+	Humidity    *float64 // relative humidity [%]
+	ECO2        *float64 // equivalent CO2 [ppm]
+	TVOC        *float64 // total volatile organic compounds [ppb]
+}
+
+// Environmental is implemented by drivers of environmental sensors
+type Environmental interface {
+	// ReadEnvironment measures all available values of the sensor
+	ReadEnvironment() (Reading, error)
+}
+
+// Compensated is implemented by drivers of sensors, which need the ambient temperature and humidity for
+// an accurate measurement, e.g. the CCS811
+type Compensated interface {
+	// SetEnvironmentalData sets the relative humidity [%] and the temperature [°C]
+	SetEnvironmentalData(humidity float64, temperature float64) error
+}
+
+// Value returns a pointer to the value, intended to be used for the fields of Reading
+func Value(v float64) *float64 { return &v }
+
+// Merge adds the values of the other reading, which are not available in this reading. The latest time is used.
+func (r Reading) Merge(o Reading) Reading {
+	if o.Time.After(r.Time) {
+		r.Time = o.Time
+	}
+	if r.Temperature == nil {
+		r.Temperature = o.Temperature
+	}
+	if r.Pressure == nil {
+		r.Pressure = o.Pressure
+	}
+	if r.Humidity == nil {
+		r.Humidity = o.Humidity
+	}
+	if r.ECO2 == nil {
+		r.ECO2 = o.ECO2
+	}
+	if r.TVOC == nil {
+		r.TVOC = o.TVOC
+	}
+	return r
+}
+
+// DewPoint returns the dew point in [°C], if temperature and humidity are available
+func (r Reading) DewPoint() (float64, bool) {
+	if r.Temperature == nil || r.Humidity == nil {
+		return 0, false
+	}
+	return DewPoint(*r.Temperature, *r.Humidity), true
+}
+
+// AbsoluteHumidity returns the absolute humidity in [g/m³], if temperature and humidity are available
+func (r Reading) AbsoluteHumidity() (float64, bool) {
+	if r.Temperature == nil || r.Humidity == nil {
+		return 0, false
+	}
+	return AbsoluteHumidity(*r.Temperature, *r.Humidity), true
+}
+
+// HeatIndex returns the apparent temperature in [°C], if temperature and humidity are available
+func (r Reading) HeatIndex() (float64, bool) {
+	if r.Temperature == nil || r.Humidity == nil {
+		return 0, false
+	}
+	return HeatIndex(*r.Temperature, *r.Humidity), true
+}
+
+// SeaLevelPressure returns the pressure reduced to sea level in [Pa] for the given altitude in [m], if
+// the pressure is available
+func (r Reading) SeaLevelPressure(altitude float64) (float64, bool) {
+	if r.Pressure == nil {
+		return 0, false
+	}
+	return SeaLevelPressure(*r.Pressure, altitude), true
+}
+
+// Altitude returns the altitude in [m] for the given sea level pressure in [Pa], if the pressure is available
+func (r Reading) Altitude(seaLevelPressure float64) (float64, bool) {
+	if r.Pressure == nil {
+		return 0, false
+	}
+	return Altitude(*r.Pressure, seaLevelPressure), true
+}
@@ -0,0 +1,57 @@
+package environment
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestReadingMerge(t *testing.T) {
+	t1 := time.Unix(100, 0)
+	t2 := time.Unix(200, 0)
+	a := Reading{Time: t1, Temperature: Value(21), Pressure: Value(100000)}
+	b := Reading{Time: t2, Temperature: Value(22), Humidity: Value(40), ECO2: Value(400), TVOC: Value(10)}
+
+	r := a.Merge(b)
+	gobottest.Assert(t, r.Time, t2)
+	gobottest.Assert(t, *r.Temperature, 21.0)
+	gobottest.Assert(t, *r.Pressure, 100000.0)
+	gobottest.Assert(t, *r.Humidity, 40.0)
+	gobottest.Assert(t, *r.ECO2, 400.0)
+	gobottest.Assert(t, *r.TVOC, 10.0)
+	// the original reading is unchanged
+	gobottest.Assert(t, a.Humidity == nil, true)
+	gobottest.Assert(t, a.Time, t1)
+}
+
+func TestReadingDerivedValues(t *testing.T) {
+	var empty Reading
+	_, ok := empty.DewPoint()
+	gobottest.Assert(t, ok, false)
+	_, ok = empty.AbsoluteHumidity()
+	gobottest.Assert(t, ok, false)
+	_, ok = empty.HeatIndex()
+	gobottest.Assert(t, ok, false)
+	_, ok = empty.SeaLevelPressure(100)
+	gobottest.Assert(t, ok, false)
+	_, ok = empty.Altitude(StandardSeaLevelPressure)
+	gobottest.Assert(t, ok, false)
+
+	r := Reading{Temperature: Value(25), Humidity: Value(50), Pressure: Value(95000)}
+	v, ok := r.DewPoint()
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, v, DewPoint(25, 50))
+	v, ok = r.AbsoluteHumidity()
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, v, AbsoluteHumidity(25, 50))
+	v, ok = r.HeatIndex()
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, v, HeatIndex(25, 50))
+	v, ok = r.SeaLevelPressure(540)
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, v, SeaLevelPressure(95000, 540))
+	v, ok = r.Altitude(StandardSeaLevelPressure)
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, v, Altitude(95000, StandardSeaLevelPressure))
+}
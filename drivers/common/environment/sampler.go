@@ -0,0 +1,120 @@
+package environment
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+const (
+	// Data is the event name, which is published with the merged Reading of all sensors after each sample
+	Data = "data"
+	// Error is the event name, which is published when reading a sensor or setting the compensation fails
+	Error = "error"
+)
+
+// Sampler reads a set of environmental sensors periodically and publishes the merged reading by the event
+// "data". If a value is available from more than one sensor, the value of the first sensor is used.
+//
+// Sensors, which implement the Compensated interface (e.g. CCS811), are read after all other sensors and
+// get the temperature and humidity of the other sensors before reading.
+type Sampler struct {
+	sensors  []Environmental
+	interval time.Duration
+	last     Reading
+	loop     *ticker.Loop
+	mutex    *sync.Mutex
+	gobot.Eventer
+}
+
+// NewSampler creates a new sampler for the sensors, which are read with the given interval
+func NewSampler(interval time.Duration, sensors ...Environmental) *Sampler {
+	s := &Sampler{
+		sensors:  sensors,
+		interval: interval,
+		loop:     ticker.NewLoop(),
+		mutex:    &sync.Mutex{},
+		Eventer:  gobot.NewEventer(),
+	}
+	s.AddEvent(Data)
+	s.AddEvent(Error)
+	return s
+}
+
+// Reading returns the result of the last sample
+func (s *Sampler) Reading() Reading {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.last
+}
+
+// Sample reads all sensors once and returns the merged reading. The reading contains the values of all
+// successful sensors, also if an error is returned. The events are published by the sample loop only.
+func (s *Sampler) Sample() (Reading, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var result Reading
+	var firstErr error
+	keepErr := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	var compensated []Environmental
+	for _, sensor := range s.sensors {
+		if _, ok := sensor.(Compensated); ok {
+			compensated = append(compensated, sensor)
+			continue
+		}
+		r, err := sensor.ReadEnvironment()
+		if err != nil {
+			keepErr(err)
+			continue
+		}
+		result = result.Merge(r)
+	}
+
+	for _, sensor := range compensated {
+		if result.Temperature != nil && result.Humidity != nil {
+			if err := sensor.(Compensated).SetEnvironmentalData(*result.Humidity, *result.Temperature); err != nil {
+				keepErr(err)
+			}
+		}
+		r, err := sensor.ReadEnvironment()
+		if err != nil {
+			keepErr(err)
+			continue
+		}
+		result = result.Merge(r)
+	}
+
+	if result.Time.IsZero() {
+		result.Time = time.Now()
+	}
+	s.last = result
+	return result, firstErr
+}
+
+// Start starts to sample the sensors with the interval and to publish the readings by the event "data".
+func (s *Sampler) Start() {
+	s.loop.Start(s.interval, s.publish)
+}
+
+// Stop stops the sample loop
+func (s *Sampler) Stop() {
+	s.loop.Stop()
+}
+
+// publish samples the sensors and publishes the reading, also the partial reading on errors
+func (s *Sampler) publish() {
+	r, err := s.Sample()
+	if err != nil {
+		s.Publish(Error, err)
+	}
+	s.Publish(Data, r)
+}
@@ -0,0 +1,132 @@
+package environment
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type samplerTestSensor struct {
+	reading Reading
+	err     error
+	reads   int
+	mutex   sync.Mutex
+}
+
+func (s *samplerTestSensor) ReadEnvironment() (Reading, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.reads++
+	return s.reading, s.err
+}
+
+type samplerTestCompensatedSensor struct {
+	samplerTestSensor
+	humidity    *float64
+	temperature *float64
+	setErr      error
+}
+
+func (s *samplerTestCompensatedSensor) SetEnvironmentalData(humidity float64, temperature float64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.humidity = Value(humidity)
+	s.temperature = Value(temperature)
+	return s.setErr
+}
+
+func TestSamplerSample(t *testing.T) {
+	gas := &samplerTestCompensatedSensor{samplerTestSensor: samplerTestSensor{
+		reading: Reading{ECO2: Value(450), TVOC: Value(20)}}}
+	bmp := &samplerTestSensor{reading: Reading{Temperature: Value(21.5), Pressure: Value(98000)}}
+	sht := &samplerTestSensor{reading: Reading{Temperature: Value(22), Humidity: Value(45)}}
+	s := NewSampler(time.Second, gas, bmp, sht)
+
+	r, err := s.Sample()
+	gobottest.Assert(t, err, nil)
+	// the first sensor wins
+	gobottest.Assert(t, *r.Temperature, 21.5)
+	gobottest.Assert(t, *r.Pressure, 98000.0)
+	gobottest.Assert(t, *r.Humidity, 45.0)
+	gobottest.Assert(t, *r.ECO2, 450.0)
+	gobottest.Assert(t, *r.TVOC, 20.0)
+	gobottest.Assert(t, r.Time.IsZero(), false)
+	// compensation is fed before the gas sensor is read
+	gobottest.Assert(t, *gas.humidity, 45.0)
+	gobottest.Assert(t, *gas.temperature, 21.5)
+	gobottest.Assert(t, s.Reading(), r)
+}
+
+func TestSamplerSampleErrors(t *testing.T) {
+	gas := &samplerTestCompensatedSensor{samplerTestSensor: samplerTestSensor{
+		reading: Reading{ECO2: Value(450)}}, setErr: errors.New("set error")}
+	bmp := &samplerTestSensor{err: errors.New("read error")}
+	sht := &samplerTestSensor{reading: Reading{Temperature: Value(22), Humidity: Value(45)}}
+	s := NewSampler(time.Second, bmp, sht, gas)
+
+	r, err := s.Sample()
+	// the first error is returned, but all successful values are available
+	gobottest.Assert(t, err, errors.New("read error"))
+	gobottest.Assert(t, r.Pressure == nil, true)
+	gobottest.Assert(t, *r.Temperature, 22.0)
+	gobottest.Assert(t, *r.ECO2, 450.0)
+}
+
+func TestSamplerNoCompensationWithoutHumidity(t *testing.T) {
+	gas := &samplerTestCompensatedSensor{}
+	bmp := &samplerTestSensor{reading: Reading{Temperature: Value(21.5)}}
+	s := NewSampler(time.Second, bmp, gas)
+
+	_, err := s.Sample()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, gas.humidity == nil, true)
+	gobottest.Assert(t, gas.reads, 1)
+}
+
+func TestSamplerStartStop(t *testing.T) {
+	sensor := &samplerTestSensor{reading: Reading{Temperature: Value(20)}}
+	s := NewSampler(5*time.Millisecond, sensor)
+	data := make(chan Reading, 10)
+	_ = s.On(Data, func(v interface{}) {
+		select {
+		case data <- v.(Reading):
+		default:
+		}
+	})
+
+	s.Start()
+	select {
+	case r := <-data:
+		gobottest.Assert(t, *r.Temperature, 20.0)
+	case <-time.After(time.Second):
+		t.Fatal("no data event was published")
+	}
+	s.Stop()
+	s.Stop()
+}
+
+func TestSamplerErrorEvent(t *testing.T) {
+	sensor := &samplerTestSensor{err: errors.New("read error")}
+	s := NewSampler(5*time.Millisecond, sensor)
+	errs := make(chan error, 10)
+	_ = s.On(Error, func(v interface{}) {
+		select {
+		case errs <- v.(error):
+		default:
+		}
+	})
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Fatal("no error event was published")
+	}
+}
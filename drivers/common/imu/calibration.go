@@ -0,0 +1,166 @@
+package imu
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Calibration corrects the values of a sensor by: corrected = (raw - Offset) * Scale
+type Calibration struct {
+	Offset Vector
+	Scale  Vector
+}
+
+// NoCalibration returns a calibration, which does not change the values
+func NoCalibration() Calibration {
+	return Calibration{Scale: Vector{X: 1, Y: 1, Z: 1}}
+}
+
+// Apply returns the corrected value
+func (c Calibration) Apply(v Vector) Vector {
+	return v.Sub(c.Offset).Mul(c.Scale)
+}
+
+// CalibrateGyroscope measures the bias of the gyroscope by averaging the given number of samples. The
+// sensor must not be moved during the measurement.
+func CalibrateGyroscope(g Gyroscope, samples int, interval time.Duration) (Calibration, error) {
+	bias, err := average(samples, interval, g.ReadAngularRate)
+	if err != nil {
+		return NoCalibration(), err
+	}
+	c := NoCalibration()
+	c.Offset = bias
+	return c, nil
+}
+
+// AccelerometerCalibrator calculates offset and scale of an accelerometer by the 6-position method.
+// The sensor is placed still with each axis pointing up and down, in any order, and a measurement is
+// taken by Measure() for each position.
+type AccelerometerCalibrator struct {
+	accel     Accelerometer
+	positions []Vector
+}
+
+// NewAccelerometerCalibrator creates a new 6-position calibrator for the accelerometer
+func NewAccelerometerCalibrator(a Accelerometer) *AccelerometerCalibrator {
+	return &AccelerometerCalibrator{accel: a}
+}
+
+// Measure averages the given number of samples for the current position
+func (c *AccelerometerCalibrator) Measure(samples int, interval time.Duration) error {
+	v, err := average(samples, interval, c.accel.ReadAcceleration)
+	if err != nil {
+		return err
+	}
+	c.AddPosition(v)
+	return nil
+}
+
+// AddPosition adds an already averaged measurement of one position
+func (c *AccelerometerCalibrator) AddPosition(v Vector) {
+	c.positions = append(c.positions, v)
+}
+
+// Calibration returns the result, after all 6 positions are measured. For each axis the extremes of
+// all positions are expected to be +1g and -1g.
+func (c *AccelerometerCalibrator) Calibration() (Calibration, error) {
+	if len(c.positions) < 6 {
+		return NoCalibration(), errors.New("the 6-position calibration needs a measurement for each position")
+	}
+	min, max := extremes(c.positions)
+	calib := Calibration{
+		Offset: max.Add(min).Scale(0.5),
+		Scale: Vector{
+			X: 2 * StandardGravity / (max.X - min.X),
+			Y: 2 * StandardGravity / (max.Y - min.Y),
+			Z: 2 * StandardGravity / (max.Z - min.Z),
+		},
+	}
+	if !calib.valid() {
+		return NoCalibration(), errors.New("an axis was not measured in both directions")
+	}
+	return calib, nil
+}
+
+// MagnetometerCalibrator calculates the hard iron offset and the soft iron scale of a magnetometer. While
+// the sensor is rotated in all directions, Add() or Measure() is called repeatedly.
+type MagnetometerCalibrator struct {
+	mag     Magnetometer
+	samples []Vector
+}
+
+// NewMagnetometerCalibrator creates a new hard and soft iron calibrator for the magnetometer
+func NewMagnetometerCalibrator(m Magnetometer) *MagnetometerCalibrator {
+	return &MagnetometerCalibrator{mag: m}
+}
+
+// Measure reads one sample
+func (c *MagnetometerCalibrator) Measure() error {
+	s, err := c.mag.ReadMagneticField()
+	if err != nil {
+		return err
+	}
+	c.Add(s.Vector)
+	return nil
+}
+
+// Add adds an already read sample
+func (c *MagnetometerCalibrator) Add(v Vector) {
+	c.samples = append(c.samples, v)
+}
+
+// Calibration returns the result. The hard iron offset is the center of the extremes of each axis. The
+// soft iron effect is approximated by scaling each axis to the average radius.
+func (c *MagnetometerCalibrator) Calibration() (Calibration, error) {
+	if len(c.samples) < 2 {
+		return NoCalibration(), errors.New("not enough samples for the magnetometer calibration")
+	}
+	min, max := extremes(c.samples)
+	radius := max.Sub(min).Scale(0.5)
+	avg := (radius.X + radius.Y + radius.Z) / 3
+	calib := Calibration{
+		Offset: max.Add(min).Scale(0.5),
+		Scale:  Vector{X: avg / radius.X, Y: avg / radius.Y, Z: avg / radius.Z},
+	}
+	if !calib.valid() {
+		return NoCalibration(), errors.New("the sensor was not rotated in all directions")
+	}
+	return calib, nil
+}
+
+func (c Calibration) valid() bool {
+	for _, f := range []float64{c.Scale.X, c.Scale.Y, c.Scale.Z} {
+		if math.IsInf(f, 0) || math.IsNaN(f) || f <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func average(samples int, interval time.Duration, read func() (Sample, error)) (Vector, error) {
+	if samples < 1 {
+		samples = 1
+	}
+	var sum Vector
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		s, err := read()
+		if err != nil {
+			return Vector{}, err
+		}
+		sum = sum.Add(s.Vector)
+	}
+	return sum.Scale(1 / float64(samples)), nil
+}
+
+func extremes(values []Vector) (Vector, Vector) {
+	min, max := values[0], values[0]
+	for _, v := range values[1:] {
+		min = Vector{X: math.Min(min.X, v.X), Y: math.Min(min.Y, v.Y), Z: math.Min(min.Z, v.Z)}
+		max = Vector{X: math.Max(max.X, v.X), Y: math.Max(max.Y, v.Y), Z: math.Max(max.Z, v.Z)}
+	}
+	return min, max
+}
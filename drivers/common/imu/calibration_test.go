@@ -0,0 +1,72 @@
+package imu
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestCalibrateGyroscope(t *testing.T) {
+	// arrange
+	g := &imuTestSensor{values: []Vector{{X: 0.1, Y: -0.2, Z: 0}, {X: 0.3, Y: 0, Z: 0.2}}}
+	// act
+	c, err := CalibrateGyroscope(g, 2, 0)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(c.Offset.X-0.2) < 1e-12, true)
+	gobottest.Assert(t, math.Abs(c.Offset.Y+0.1) < 1e-12, true)
+	gobottest.Assert(t, math.Abs(c.Offset.Z-0.1) < 1e-12, true)
+	gobottest.Assert(t, c.Scale, Vector{X: 1, Y: 1, Z: 1})
+	// read error
+	_, err = CalibrateGyroscope(&imuTestSensor{err: errors.New("read error")}, 2, 0)
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestAccelerometerCalibrator(t *testing.T) {
+	// arrange: offset of 0.5 and gain of 2 for x-axis, offset -1 for z-axis
+	g := StandardGravity
+	c := NewAccelerometerCalibrator(&imuTestSensor{values: []Vector{{X: 2*g + 0.5, Y: 0, Z: -1}}})
+	gobottest.Assert(t, c.Measure(1, 0), nil)
+	c.AddPosition(Vector{X: -2*g + 0.5, Y: 0, Z: -1})
+	c.AddPosition(Vector{X: 0.5, Y: g, Z: -1})
+	c.AddPosition(Vector{X: 0.5, Y: -g, Z: -1})
+	c.AddPosition(Vector{X: 0.5, Y: 0, Z: g - 1})
+	// act: not enough positions
+	_, err := c.Calibration()
+	gobottest.Assert(t, err.Error(), "the 6-position calibration needs a measurement for each position")
+	c.AddPosition(Vector{X: 0.5, Y: 0, Z: -g - 1})
+	calib, err := c.Calibration()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, calib.Offset, Vector{X: 0.5, Y: 0, Z: -1})
+	gobottest.Assert(t, calib.Scale, Vector{X: 0.5, Y: 1, Z: 1})
+	gobottest.Assert(t, calib.Apply(Vector{X: 2*g + 0.5, Y: 0, Z: -1}), Vector{X: g, Y: 0, Z: 0})
+}
+
+func TestMagnetometerCalibrator(t *testing.T) {
+	// arrange: hard iron offset (10, -5, 0), ellipsoid with radius (20, 40, 30)
+	c := NewMagnetometerCalibrator(&imuTestSensor{values: []Vector{{X: 30, Y: -5, Z: 0}}})
+	gobottest.Assert(t, c.Measure(), nil)
+	c.Add(Vector{X: -10, Y: -5, Z: 0})
+	c.Add(Vector{X: 10, Y: 35, Z: 0})
+	c.Add(Vector{X: 10, Y: -45, Z: 0})
+	c.Add(Vector{X: 10, Y: -5, Z: 30})
+	c.Add(Vector{X: 10, Y: -5, Z: -30})
+	// act
+	calib, err := c.Calibration()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, calib.Offset, Vector{X: 10, Y: -5, Z: 0})
+	gobottest.Assert(t, calib.Scale, Vector{X: 1.5, Y: 0.75, Z: 1})
+	gobottest.Assert(t, calib.Apply(Vector{X: 30, Y: -5, Z: 0}), Vector{X: 30, Y: 0, Z: 0})
+}
+
+func TestMagnetometerCalibratorNotRotated(t *testing.T) {
+	c := NewMagnetometerCalibrator(nil)
+	c.Add(Vector{X: 1, Y: 2, Z: 3})
+	c.Add(Vector{X: 2, Y: 3, Z: 3})
+	_, err := c.Calibration()
+	gobottest.Assert(t, err.Error(), "the sensor was not rotated in all directions")
+}
@@ -0,0 +1,13 @@
+/*
+Package imu provides a common view on inertial sensors like MPU6050, ADXL345, L3GD20H, HMC5883L and
+MMA7660, including calibration routines and sensor fusion.
+
+All values are in SI units: accelerations in [m/s²], angular rates in [rad/s] and magnetic fields
+in [µT]. Each value is stamped with the time of reading. Any combination of an accelerometer, a
+gyroscope and a magnetometer can be composed to an IMU by NewCombined(), which can be fed to a
+Madgwick or Mahony filter by a Fusion, to get the orientation as quaternion or roll, pitch and yaw.
+
+Drivers with a FIFO deliver high rate readings by a Stream, the timestamps of each sample are calculated
+by a SampleClock.
+*/
+package imu // import "gobot.io/x/gobot/drivers/common/imu"
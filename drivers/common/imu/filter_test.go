@@ -0,0 +1,72 @@
+package imu
+
+import (
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestFilterFollowsGravity(t *testing.T) {
+	// sensor is rolled by 0.4 rad and pitched by 0.2 rad, the filters should converge to this angles
+	wantRoll, wantPitch := 0.4, 0.2
+	accel := Vector{
+		X: -math.Sin(wantPitch),
+		Y: math.Sin(wantRoll) * math.Cos(wantPitch),
+		Z: math.Cos(wantRoll) * math.Cos(wantPitch),
+	}.Scale(StandardGravity)
+	var tests = map[string]Filter{
+		"madgwick": NewMadgwick(0.5),
+		"mahony":   NewMahony(2, 0.1),
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 2000; i++ {
+				f.Update(Vector{}, accel, nil, 0.01)
+			}
+			roll, pitch, _ := f.Quaternion().Euler()
+			gobottest.Assert(t, math.Abs(roll-wantRoll) < 0.01, true)
+			gobottest.Assert(t, math.Abs(pitch-wantPitch) < 0.01, true)
+			f.Reset()
+			gobottest.Assert(t, f.Quaternion(), Identity())
+		})
+	}
+}
+
+func TestFilterIntegratesGyroscope(t *testing.T) {
+	// level sensor, rotated around z-axis by 1 rad/s for 1 s
+	var tests = map[string]Filter{
+		"madgwick": NewMadgwick(0.1),
+		"mahony":   NewMahony(1, 0),
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				f.Update(Vector{Z: 1}, Vector{Z: StandardGravity}, nil, 0.01)
+			}
+			roll, pitch, yaw := f.Quaternion().Euler()
+			gobottest.Assert(t, math.Abs(roll) < 1e-6, true)
+			gobottest.Assert(t, math.Abs(pitch) < 1e-6, true)
+			gobottest.Assert(t, math.Abs(yaw-1) < 1e-3, true)
+		})
+	}
+}
+
+func TestFilterFollowsMagneticField(t *testing.T) {
+	// level sensor, heading 0.5 rad: the horizontal field points to -yaw in sensor frame
+	wantYaw := 0.5
+	mag := Vector{X: 20 * math.Cos(wantYaw), Y: -20 * math.Sin(wantYaw), Z: -40}
+	var tests = map[string]Filter{
+		"madgwick": NewMadgwick(0.5),
+		"mahony":   NewMahony(2, 0),
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 3000; i++ {
+				f.Update(Vector{}, Vector{Z: StandardGravity}, &mag, 0.01)
+			}
+			_, _, yaw := f.Quaternion().Euler()
+			gobottest.Assert(t, math.Abs(yaw-wantYaw) < 0.01, true)
+		})
+	}
+}
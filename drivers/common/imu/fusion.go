@@ -0,0 +1,133 @@
+package imu
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+const (
+	// Orientation is the event name, which is published with the OrientationData after each update
+	Orientation = "orientation"
+	// Error is the event name, which is published when reading the IMU fails
+	Error = "error"
+)
+
+// OrientationData is the result of the sensor fusion
+type OrientationData struct {
+	Time       time.Time
+	Quaternion Quaternion
+	Roll       float64 // [rad]
+	Pitch      float64 // [rad]
+	Yaw        float64 // [rad]
+}
+
+// Fusion reads the IMU with a configurable rate and feeds the values to the filter. The result is
+// published by the event "orientation", errors by the event "error".
+//
+// Without gyroscope the angular rate is assumed to be zero, so the orientation follows the gravity
+// (and the magnetic field) with the speed of the filter gain.
+type Fusion struct {
+	imu      IMU
+	filter   Filter
+	interval time.Duration
+	last     time.Time
+	current  OrientationData
+	loop     *ticker.Loop
+	mutex    *sync.Mutex
+	gobot.Eventer
+}
+
+// NewFusion creates a new sensor fusion for the IMU, which is updated with the given rate in [Hz].
+func NewFusion(imu IMU, filter Filter, rate float64) *Fusion {
+	if rate <= 0 {
+		rate = 100
+	}
+	f := &Fusion{
+		imu:      imu,
+		filter:   filter,
+		interval: time.Duration(float64(time.Second) / rate),
+		current:  OrientationData{Quaternion: Identity()},
+		loop:     ticker.NewLoop(),
+		mutex:    &sync.Mutex{},
+		Eventer:  gobot.NewEventer(),
+	}
+	f.AddEvent(Orientation)
+	f.AddEvent(Error)
+	return f
+}
+
+// Interval returns the time between two updates
+func (f *Fusion) Interval() time.Duration { return f.interval }
+
+// Orientation returns the result of the last update
+func (f *Fusion) Orientation() OrientationData {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.current
+}
+
+// Reset sets the orientation back to identity
+func (f *Fusion) Reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.filter.Reset()
+	f.last = time.Time{}
+	f.current = OrientationData{Quaternion: Identity()}
+}
+
+// Update reads the IMU once and feeds the filter. The time step is taken from the timestamps of the
+// readings, for the first reading the configured interval is used. Without Start() this can be used
+// to drive the filter by the data ready interrupt of the IMU.
+func (f *Fusion) Update() (OrientationData, error) {
+	r, err := f.imu.Read()
+	if err != nil {
+		return OrientationData{}, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	dt := f.interval.Seconds()
+	if !f.last.IsZero() && r.Time.After(f.last) {
+		dt = r.Time.Sub(f.last).Seconds()
+	}
+	f.last = r.Time
+
+	var gyro, accel Vector
+	if r.AngularRate != nil {
+		gyro = *r.AngularRate
+	}
+	if r.Acceleration != nil {
+		accel = *r.Acceleration
+	}
+	f.filter.Update(gyro, accel, r.MagneticField, dt)
+
+	q := f.filter.Quaternion()
+	roll, pitch, yaw := q.Euler()
+	f.current = OrientationData{Time: r.Time, Quaternion: q, Roll: roll, Pitch: pitch, Yaw: yaw}
+	return f.current, nil
+}
+
+// Start starts to update the filter with the configured rate and to publish the event "orientation".
+func (f *Fusion) Start() {
+	f.loop.Start(f.interval, f.update)
+}
+
+// Stop stops the update loop
+func (f *Fusion) Stop() {
+	f.loop.Stop()
+}
+
+func (f *Fusion) update() {
+	o, err := f.Update()
+	if err != nil {
+		f.Publish(Error, err)
+		return
+	}
+	f.Publish(Orientation, o)
+}
@@ -0,0 +1,73 @@
+package imu
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestNewFusion(t *testing.T) {
+	f := NewFusion(NewCombined(nil, nil, nil), NewMadgwick(0.1), 50)
+	gobottest.Assert(t, f.Interval(), 20*time.Millisecond)
+	gobottest.Assert(t, f.Orientation().Quaternion, Identity())
+	gobottest.Assert(t, NewFusion(nil, nil, 0).Interval(), 10*time.Millisecond)
+}
+
+func TestFusionUpdate(t *testing.T) {
+	// arrange: rotate by 1 rad/s around z-axis, readings are 100 ms apart
+	t0 := time.Unix(100, 0)
+	gyro := &imuTestSensor{
+		values: []Vector{{Z: 1}},
+		times:  []time.Time{t0, t0.Add(100 * time.Millisecond), t0.Add(200 * time.Millisecond)},
+	}
+	f := NewFusion(NewCombined(nil, gyro, nil), NewMahony(1, 0), 1000)
+	// act
+	for i := 0; i < 3; i++ {
+		_, err := f.Update()
+		gobottest.Assert(t, err, nil)
+	}
+	// assert: 1 ms (first interval) + 2 x 100 ms
+	o := f.Orientation()
+	gobottest.Assert(t, o.Time, t0.Add(200*time.Millisecond))
+	gobottest.Assert(t, math.Abs(o.Yaw-0.201) < 1e-3, true)
+	f.Reset()
+	gobottest.Assert(t, f.Orientation().Yaw, 0.0)
+}
+
+func TestFusionStartStop(t *testing.T) {
+	// arrange
+	accel := &imuTestSensor{values: []Vector{{Z: StandardGravity}}}
+	f := NewFusion(NewCombined(accel, nil, nil), NewMadgwick(0.1), 1000)
+	got := make(chan OrientationData, 1)
+	_ = f.Once(Orientation, func(data interface{}) { got <- data.(OrientationData) })
+	// act
+	f.Start()
+	defer f.Stop()
+	// assert
+	select {
+	case o := <-got:
+		gobottest.Assert(t, o.Quaternion, Identity())
+	case <-time.After(time.Second):
+		t.Errorf("orientation event was not published")
+	}
+}
+
+func TestFusionError(t *testing.T) {
+	// arrange
+	f := NewFusion(NewCombined(&imuTestSensor{err: errors.New("read error")}, nil, nil), NewMadgwick(0.1), 1000)
+	got := make(chan error, 1)
+	_ = f.Once(Error, func(data interface{}) { got <- data.(error) })
+	// act
+	f.Start()
+	defer f.Stop()
+	// assert
+	select {
+	case err := <-got:
+		gobottest.Assert(t, err, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("error event was not published")
+	}
+}
@@ -0,0 +1,162 @@
+package imu
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// StandardGravity is used to convert from [g] to [m/s²]
+const StandardGravity = 9.80665
+
+// Vector is a value for the 3 axis
+type Vector struct {
+	X, Y, Z float64
+}
+
+// Add returns the sum of both vectors
+func (v Vector) Add(o Vector) Vector { return Vector{X: v.X + o.X, Y: v.Y + o.Y, Z: v.Z + o.Z} }
+
+// Sub returns the difference of both vectors
+func (v Vector) Sub(o Vector) Vector { return Vector{X: v.X - o.X, Y: v.Y - o.Y, Z: v.Z - o.Z} }
+
+// Mul returns the vector with each axis multiplied by the corresponding axis of the other vector
+func (v Vector) Mul(o Vector) Vector { return Vector{X: v.X * o.X, Y: v.Y * o.Y, Z: v.Z * o.Z} }
+
+// Scale returns the vector multiplied by the factor
+func (v Vector) Scale(f float64) Vector { return Vector{X: v.X * f, Y: v.Y * f, Z: v.Z * f} }
+
+// Norm returns the length of the vector
+func (v Vector) Norm() float64 { return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z) }
+
+// Sample is a value of a sensor together with the time of reading
+type Sample struct {
+	Vector
+	Time time.Time
+}
+
+// Accelerometer is implemented by drivers, which measure the acceleration in [m/s²]
+type Accelerometer interface {
+	ReadAcceleration() (Sample, error)
+}
+
+// Gyroscope is implemented by drivers, which measure the angular rate in [rad/s]
+type Gyroscope interface {
+	ReadAngularRate() (Sample, error)
+}
+
+// Magnetometer is implemented by drivers, which measure the magnetic field in [µT]
+type Magnetometer interface {
+	ReadMagneticField() (Sample, error)
+}
+
+// Reading contains the values of all sensors of an IMU, which are read at once. Values of not
+// available sensors are nil.
+type Reading struct {
+	Time          time.Time
+	Acceleration  *Vector
+	AngularRate   *Vector
+	MagneticField *Vector
+}
+
+// IMU is the common interface of an inertial measurement unit
+type IMU interface {
+	Read() (Reading, error)
+}
+
+// Combined composes an IMU from any combination of an accelerometer, a gyroscope and a magnetometer.
+// The calibration of each sensor is applied on reading.
+type Combined struct {
+	accel      Accelerometer
+	gyro       Gyroscope
+	mag        Magnetometer
+	accelCalib Calibration
+	gyroCalib  Calibration
+	magCalib   Calibration
+	mutex      *sync.Mutex
+}
+
+// NewCombined creates a new IMU for the given sensors, a nil value means the sensor is not available.
+// One driver can be passed for multiple sensors, e.g. the MPU6050 as accelerometer and gyroscope.
+func NewCombined(accel Accelerometer, gyro Gyroscope, mag Magnetometer) *Combined {
+	return &Combined{
+		accel:      accel,
+		gyro:       gyro,
+		mag:        mag,
+		accelCalib: NoCalibration(),
+		gyroCalib:  NoCalibration(),
+		magCalib:   NoCalibration(),
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// SetAccelerometerCalibration sets the calibration, which is applied to the accelerometer values
+func (c *Combined) SetAccelerometerCalibration(calib Calibration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.accelCalib = calib
+}
+
+// SetGyroscopeCalibration sets the calibration, which is applied to the gyroscope values
+func (c *Combined) SetGyroscopeCalibration(calib Calibration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.gyroCalib = calib
+}
+
+// SetMagnetometerCalibration sets the calibration, which is applied to the magnetometer values
+func (c *Combined) SetMagnetometerCalibration(calib Calibration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.magCalib = calib
+}
+
+// Read implements the IMU interface. The time of the reading is the latest time of all samples.
+func (c *Combined) Read() (Reading, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var r Reading
+	if c.accel == nil && c.gyro == nil && c.mag == nil {
+		return r, errors.New("no sensor available")
+	}
+	if c.accel != nil {
+		s, err := c.accel.ReadAcceleration()
+		if err != nil {
+			return r, err
+		}
+		v := c.accelCalib.Apply(s.Vector)
+		r.Acceleration = &v
+		r.Time = latest(r.Time, s.Time)
+	}
+	if c.gyro != nil {
+		s, err := c.gyro.ReadAngularRate()
+		if err != nil {
+			return r, err
+		}
+		v := c.gyroCalib.Apply(s.Vector)
+		r.AngularRate = &v
+		r.Time = latest(r.Time, s.Time)
+	}
+	if c.mag != nil {
+		s, err := c.mag.ReadMagneticField()
+		if err != nil {
+			return r, err
+		}
+		v := c.magCalib.Apply(s.Vector)
+		r.MagneticField = &v
+		r.Time = latest(r.Time, s.Time)
+	}
+	return r, nil
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,71 @@
+package imu
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type imuTestSensor struct {
+	values []Vector
+	times  []time.Time
+	idx    int
+	err    error
+}
+
+func (s *imuTestSensor) next() (Sample, error) {
+	if s.err != nil {
+		return Sample{}, s.err
+	}
+	i := s.idx
+	s.idx++
+	smp := Sample{Vector: s.values[len(s.values)-1], Time: time.Now()}
+	if i < len(s.values) {
+		smp.Vector = s.values[i]
+	}
+	if i < len(s.times) {
+		smp.Time = s.times[i]
+	}
+	return smp, nil
+}
+
+func (s *imuTestSensor) ReadAcceleration() (Sample, error)  { return s.next() }
+func (s *imuTestSensor) ReadAngularRate() (Sample, error)   { return s.next() }
+func (s *imuTestSensor) ReadMagneticField() (Sample, error) { return s.next() }
+
+func TestVector(t *testing.T) {
+	v := Vector{X: 3, Y: 4, Z: 0}
+	gobottest.Assert(t, v.Norm(), 5.0)
+	gobottest.Assert(t, v.Add(Vector{X: 1, Y: 1, Z: 1}), Vector{X: 4, Y: 5, Z: 1})
+	gobottest.Assert(t, v.Sub(Vector{X: 1, Y: 1, Z: 1}), Vector{X: 2, Y: 3, Z: -1})
+	gobottest.Assert(t, v.Mul(Vector{X: 2, Y: 0.5, Z: 1}), Vector{X: 6, Y: 2, Z: 0})
+	gobottest.Assert(t, v.Scale(2), Vector{X: 6, Y: 8, Z: 0})
+}
+
+func TestCombinedRead(t *testing.T) {
+	// arrange
+	t0 := time.Unix(100, 0)
+	accel := &imuTestSensor{values: []Vector{{X: 1, Y: 2, Z: 3}}, times: []time.Time{t0}}
+	mag := &imuTestSensor{values: []Vector{{X: 10, Y: 20, Z: 30}}, times: []time.Time{t0.Add(time.Millisecond)}}
+	c := NewCombined(accel, nil, mag)
+	c.SetMagnetometerCalibration(Calibration{Offset: Vector{X: 10}, Scale: Vector{X: 1, Y: 2, Z: 1}})
+	// act
+	r, err := c.Read()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, *r.Acceleration, Vector{X: 1, Y: 2, Z: 3})
+	gobottest.Assert(t, r.AngularRate == nil, true)
+	gobottest.Assert(t, *r.MagneticField, Vector{X: 0, Y: 40, Z: 30})
+	gobottest.Assert(t, r.Time, t0.Add(time.Millisecond))
+}
+
+func TestCombinedReadError(t *testing.T) {
+	c := NewCombined(nil, &imuTestSensor{err: errors.New("read error")}, nil)
+	_, err := c.Read()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	_, err = NewCombined(nil, nil, nil).Read()
+	gobottest.Assert(t, err, errors.New("no sensor available"))
+}
@@ -0,0 +1,106 @@
+package imu
+
+import "math"
+
+// Madgwick is the gradient descent orientation filter by Sebastian Madgwick
+type Madgwick struct {
+	// Beta is the filter gain, higher values mean faster correction but more noise
+	Beta float64
+	q    Quaternion
+}
+
+// NewMadgwick creates a new filter with the given gain, a typical value is 0.1
+func NewMadgwick(beta float64) *Madgwick {
+	return &Madgwick{Beta: beta, q: Identity()}
+}
+
+// Quaternion implements the Filter interface
+func (m *Madgwick) Quaternion() Quaternion { return m.q }
+
+// Reset implements the Filter interface
+func (m *Madgwick) Reset() { m.q = Identity() }
+
+// Update implements the Filter interface
+func (m *Madgwick) Update(gyro Vector, accel Vector, mag *Vector, dt float64) {
+	q0, q1, q2, q3 := m.q.W, m.q.X, m.q.Y, m.q.Z
+	gx, gy, gz := gyro.X, gyro.Y, gyro.Z
+
+	// rate of change of quaternion from gyroscope
+	qDot0 := 0.5 * (-q1*gx - q2*gy - q3*gz)
+	qDot1 := 0.5 * (q0*gx + q2*gz - q3*gy)
+	qDot2 := 0.5 * (q0*gy - q1*gz + q3*gx)
+	qDot3 := 0.5 * (q0*gz + q1*gy - q2*gx)
+
+	if a, ok := normalize(accel); ok {
+		var s0, s1, s2, s3 float64
+		var mn Vector
+		withMag := false
+		if mag != nil {
+			mn, withMag = normalize(*mag)
+		}
+		if withMag {
+			s0, s1, s2, s3 = m.gradientMARG(a, mn)
+		} else {
+			s0, s1, s2, s3 = m.gradientIMU(a)
+		}
+		if n := math.Sqrt(s0*s0 + s1*s1 + s2*s2 + s3*s3); n > 0 {
+			qDot0 -= m.Beta * s0 / n
+			qDot1 -= m.Beta * s1 / n
+			qDot2 -= m.Beta * s2 / n
+			qDot3 -= m.Beta * s3 / n
+		}
+	}
+
+	m.q = Quaternion{W: q0 + qDot0*dt, X: q1 + qDot1*dt, Y: q2 + qDot2*dt, Z: q3 + qDot3*dt}.normalized()
+}
+
+// gradientIMU returns the corrective step by the gravity only
+func (m *Madgwick) gradientIMU(a Vector) (float64, float64, float64, float64) {
+	q0, q1, q2, q3 := m.q.W, m.q.X, m.q.Y, m.q.Z
+	ax, ay, az := a.X, a.Y, a.Z
+
+	_2q0, _2q1, _2q2, _2q3 := 2*q0, 2*q1, 2*q2, 2*q3
+	_4q0, _4q1, _4q2 := 4*q0, 4*q1, 4*q2
+	_8q1, _8q2 := 8*q1, 8*q2
+	q0q0, q1q1, q2q2, q3q3 := q0*q0, q1*q1, q2*q2, q3*q3
+
+	s0 := _4q0*q2q2 + _2q2*ax + _4q0*q1q1 - _2q1*ay
+	s1 := _4q1*q3q3 - _2q3*ax + 4*q0q0*q1 - _2q0*ay - _4q1 + _8q1*q1q1 + _8q1*q2q2 + _4q1*az
+	s2 := 4*q0q0*q2 + _2q0*ax + _4q2*q3q3 - _2q3*ay - _4q2 + _8q2*q1q1 + _8q2*q2q2 + _4q2*az
+	s3 := 4*q1q1*q3 - _2q1*ax + 4*q2q2*q3 - _2q2*ay
+	return s0, s1, s2, s3
+}
+
+// gradientMARG returns the corrective step by the gravity and the magnetic field
+func (m *Madgwick) gradientMARG(a Vector, mag Vector) (float64, float64, float64, float64) {
+	q0, q1, q2, q3 := m.q.W, m.q.X, m.q.Y, m.q.Z
+	ax, ay, az := a.X, a.Y, a.Z
+	mx, my, mz := mag.X, mag.Y, mag.Z
+
+	_2q0mx, _2q0my, _2q0mz, _2q1mx := 2*q0*mx, 2*q0*my, 2*q0*mz, 2*q1*mx
+	_2q0, _2q1, _2q2, _2q3 := 2*q0, 2*q1, 2*q2, 2*q3
+	_2q0q2, _2q2q3 := 2*q0*q2, 2*q2*q3
+	q0q0, q0q1, q0q2, q0q3 := q0*q0, q0*q1, q0*q2, q0*q3
+	q1q1, q1q2, q1q3 := q1*q1, q1*q2, q1*q3
+	q2q2, q2q3, q3q3 := q2*q2, q2*q3, q3*q3
+
+	// reference direction of the earth's magnetic field
+	hx := mx*q0q0 - _2q0my*q3 + _2q0mz*q2 + mx*q1q1 + _2q1*my*q2 + _2q1*mz*q3 - mx*q2q2 - mx*q3q3
+	hy := _2q0mx*q3 + my*q0q0 - _2q0mz*q1 + _2q1mx*q2 - my*q1q1 + my*q2q2 + _2q2*mz*q3 - my*q3q3
+	_2bx := math.Sqrt(hx*hx + hy*hy)
+	_2bz := -_2q0mx*q2 + _2q0my*q1 + mz*q0q0 + _2q1mx*q3 - mz*q1q1 + _2q2*my*q3 - mz*q2q2 + mz*q3q3
+	_4bx, _4bz := 2*_2bx, 2*_2bz
+
+	fx := _2bx*(0.5-q2q2-q3q3) + _2bz*(q1q3-q0q2) - mx
+	fy := _2bx*(q1q2-q0q3) + _2bz*(q0q1+q2q3) - my
+	fz := _2bx*(q0q2+q1q3) + _2bz*(0.5-q1q1-q2q2) - mz
+	gx := 2*q1q3 - _2q0q2 - ax
+	gy := 2*q0q1 + _2q2q3 - ay
+	gz := 1 - 2*q1q1 - 2*q2q2 - az
+
+	s0 := -_2q2*gx + _2q1*gy - _2bz*q2*fx + (-_2bx*q3+_2bz*q1)*fy + _2bx*q2*fz
+	s1 := _2q3*gx + _2q0*gy - 4*q1*gz + _2bz*q3*fx + (_2bx*q2+_2bz*q0)*fy + (_2bx*q3-_4bz*q1)*fz
+	s2 := -_2q0*gx + _2q3*gy - 4*q2*gz + (-_4bx*q2-_2bz*q0)*fx + (_2bx*q1+_2bz*q3)*fy + (_2bx*q0-_4bz*q2)*fz
+	s3 := _2q1*gx + _2q2*gy + (-_4bx*q3+_2bz*q1)*fx + (-_2bx*q0+_2bz*q2)*fy + _2bx*q1*fz
+	return s0, s1, s2, s3
+}
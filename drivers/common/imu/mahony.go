@@ -0,0 +1,87 @@
+package imu
+
+import "math"
+
+// Mahony is the complementary filter by Robert Mahony, which corrects the gyroscope by a PI controller
+type Mahony struct {
+	// Kp is the proportional gain
+	Kp float64
+	// Ki is the integral gain, 0 disables the integral feedback
+	Ki       float64
+	q        Quaternion
+	integral Vector
+}
+
+// NewMahony creates a new filter with the given gains, typical values are 1.0 and 0.0
+func NewMahony(kp, ki float64) *Mahony {
+	return &Mahony{Kp: kp, Ki: ki, q: Identity()}
+}
+
+// Quaternion implements the Filter interface
+func (m *Mahony) Quaternion() Quaternion { return m.q }
+
+// Reset implements the Filter interface
+func (m *Mahony) Reset() {
+	m.q = Identity()
+	m.integral = Vector{}
+}
+
+// Update implements the Filter interface
+func (m *Mahony) Update(gyro Vector, accel Vector, mag *Vector, dt float64) {
+	q0, q1, q2, q3 := m.q.W, m.q.X, m.q.Y, m.q.Z
+	g := gyro
+
+	if a, ok := normalize(accel); ok {
+		q0q0, q0q1, q0q2, q0q3 := q0*q0, q0*q1, q0*q2, q0*q3
+		q1q1, q1q2, q1q3 := q1*q1, q1*q2, q1*q3
+		q2q2, q2q3, q3q3 := q2*q2, q2*q3, q3*q3
+
+		// estimated direction of gravity, error is the cross product to the measured direction
+		halfvx := q1q3 - q0q2
+		halfvy := q0q1 + q2q3
+		halfvz := q0q0 - 0.5 + q3q3
+		e := Vector{
+			X: a.Y*halfvz - a.Z*halfvy,
+			Y: a.Z*halfvx - a.X*halfvz,
+			Z: a.X*halfvy - a.Y*halfvx,
+		}
+
+		var mn Vector
+		withMag := false
+		if mag != nil {
+			mn, withMag = normalize(*mag)
+		}
+		if withMag {
+			// reference direction of the earth's magnetic field
+			hx := 2 * (mn.X*(0.5-q2q2-q3q3) + mn.Y*(q1q2-q0q3) + mn.Z*(q1q3+q0q2))
+			hy := 2 * (mn.X*(q1q2+q0q3) + mn.Y*(0.5-q1q1-q3q3) + mn.Z*(q2q3-q0q1))
+			bx := math.Sqrt(hx*hx + hy*hy)
+			bz := 2 * (mn.X*(q1q3-q0q2) + mn.Y*(q2q3+q0q1) + mn.Z*(0.5-q1q1-q2q2))
+
+			halfwx := bx*(0.5-q2q2-q3q3) + bz*(q1q3-q0q2)
+			halfwy := bx*(q1q2-q0q3) + bz*(q0q1+q2q3)
+			halfwz := bx*(q0q2+q1q3) + bz*(0.5-q1q1-q2q2)
+			e = e.Add(Vector{
+				X: mn.Y*halfwz - mn.Z*halfwy,
+				Y: mn.Z*halfwx - mn.X*halfwz,
+				Z: mn.X*halfwy - mn.Y*halfwx,
+			})
+		}
+
+		if m.Ki > 0 {
+			m.integral = m.integral.Add(e.Scale(2 * m.Ki * dt))
+			g = g.Add(m.integral)
+		} else {
+			m.integral = Vector{}
+		}
+		g = g.Add(e.Scale(2 * m.Kp))
+	}
+
+	g = g.Scale(0.5 * dt)
+	m.q = Quaternion{
+		W: q0 - q1*g.X - q2*g.Y - q3*g.Z,
+		X: q1 + q0*g.X + q2*g.Z - q3*g.Y,
+		Y: q2 + q0*g.Y - q1*g.Z + q3*g.X,
+		Z: q3 + q0*g.Z + q1*g.Y - q2*g.X,
+	}.normalized()
+}
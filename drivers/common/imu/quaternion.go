@@ -0,0 +1,48 @@
+package imu
+
+import "math"
+
+// Quaternion describes an orientation, W is the scalar part
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// Identity returns the quaternion of no rotation
+func Identity() Quaternion { return Quaternion{W: 1} }
+
+// Euler returns roll (around x), pitch (around y) and yaw (around z) in [rad], using the aerospace
+// sequence z-y-x.
+func (q Quaternion) Euler() (roll float64, pitch float64, yaw float64) {
+	roll = math.Atan2(q.W*q.X+q.Y*q.Z, 0.5-q.X*q.X-q.Y*q.Y)
+	sinPitch := 2 * (q.W*q.Y - q.X*q.Z)
+	pitch = math.Asin(math.Max(-1, math.Min(1, sinPitch)))
+	yaw = math.Atan2(q.X*q.Y+q.W*q.Z, 0.5-q.Y*q.Y-q.Z*q.Z)
+	return roll, pitch, yaw
+}
+
+func (q Quaternion) normalized() Quaternion {
+	n := math.Sqrt(q.W*q.W + q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+	if n == 0 {
+		return Identity()
+	}
+	return Quaternion{W: q.W / n, X: q.X / n, Y: q.Y / n, Z: q.Z / n}
+}
+
+// Filter is the interface of a sensor fusion algorithm
+type Filter interface {
+	// Update integrates the angular rate in [rad/s] over dt in [s] and corrects the drift by the
+	// direction of gravity and optional by the magnetic field (nil means not available).
+	Update(gyro Vector, accel Vector, mag *Vector, dt float64)
+	// Quaternion returns the current orientation
+	Quaternion() Quaternion
+	// Reset sets the orientation back to identity
+	Reset()
+}
+
+func normalize(v Vector) (Vector, bool) {
+	n := v.Norm()
+	if n == 0 {
+		return v, false
+	}
+	return v.Scale(1 / n), true
+}
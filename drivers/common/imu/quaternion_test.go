@@ -0,0 +1,33 @@
+package imu
+
+import (
+	"math"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestQuaternionEuler(t *testing.T) {
+	var tests = map[string]struct {
+		angle     float64
+		axis      Vector
+		wantRoll  float64
+		wantPitch float64
+		wantYaw   float64
+	}{
+		"identity": {axis: Vector{X: 1}},
+		"roll":     {angle: 0.5, axis: Vector{X: 1}, wantRoll: 0.5},
+		"pitch":    {angle: -0.3, axis: Vector{Y: 1}, wantPitch: -0.3},
+		"yaw":      {angle: 2, axis: Vector{Z: 1}, wantYaw: 2},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := math.Sin(tc.angle / 2)
+			q := Quaternion{W: math.Cos(tc.angle / 2), X: tc.axis.X * s, Y: tc.axis.Y * s, Z: tc.axis.Z * s}
+			roll, pitch, yaw := q.Euler()
+			gobottest.Assert(t, math.Abs(roll-tc.wantRoll) < 1e-9, true)
+			gobottest.Assert(t, math.Abs(pitch-tc.wantPitch) < 1e-9, true)
+			gobottest.Assert(t, math.Abs(yaw-tc.wantYaw) < 1e-9, true)
+		})
+	}
+}
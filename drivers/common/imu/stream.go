@@ -0,0 +1,140 @@
+package imu
+
+import (
+	"sync"
+	"time"
+)
+
+// Stream delivers the readings of a sensor, which is drained in bursts, e.g. from a FIFO. Readings are
+// dropped, if the channel is full. Dropped readings and FIFO overflows of the device are counted.
+type Stream struct {
+	// C delivers the readings, it is closed by Stop()
+	C         <-chan Reading
+	c         chan Reading
+	onStop    func()
+	dropped   int
+	overflows int
+	err       error
+	stopped   bool
+	mutex     *sync.Mutex
+}
+
+// NewStream creates a new stream with the given channel capacity. The stop function is called once by
+// Stop() and is intended for the driver to disable the streaming on the device.
+func NewStream(size int, stop func()) *Stream {
+	c := make(chan Reading, size)
+	return &Stream{C: c, c: c, onStop: stop, mutex: &sync.Mutex{}}
+}
+
+// Push delivers the reading without blocking, it is counted as dropped if the channel is full
+func (s *Stream) Push(r Reading) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return
+	}
+	select {
+	case s.c <- r:
+	default:
+		s.dropped++
+	}
+}
+
+// ReportOverflow counts an overflow of the device, which means readings are lost
+func (s *Stream) ReportOverflow() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.overflows++
+}
+
+// ReportError stores the last error on draining the device
+func (s *Stream) ReportError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.err = err
+}
+
+// Dropped returns the number of readings, which were dropped because the channel was full
+func (s *Stream) Dropped() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.dropped
+}
+
+// Overflows returns the number of overflows of the device
+func (s *Stream) Overflows() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.overflows
+}
+
+// Err returns the last error on draining the device
+func (s *Stream) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.err
+}
+
+// Stop stops the streaming and closes the channel, further calls are ignored
+func (s *Stream) Stop() {
+	if s.Close() && s.onStop != nil {
+		s.onStop()
+	}
+}
+
+// Close closes the channel without calling the stop function, e.g. when the driver is halted. It returns
+// false, if the stream was already closed.
+func (s *Stream) Close() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return false
+	}
+	s.stopped = true
+	close(s.c)
+	return true
+}
+
+// SampleClock calculates the timestamps of samples, which are produced by the device with a constant
+// rate and read in bursts. The timestamps are continued from the previous burst, as long as they do
+// not differ by more than one period from the time of reading. Otherwise, e.g. after an overflow, the
+// newest sample is assumed to be produced at the time of reading.
+type SampleClock struct {
+	period time.Duration
+	last   time.Time
+}
+
+// NewSampleClock creates a new clock for the given sample period of the device
+func NewSampleClock(period time.Duration) *SampleClock {
+	return &SampleClock{period: period}
+}
+
+// Period returns the sample period
+func (c *SampleClock) Period() time.Duration { return c.period }
+
+// Reset forces a resynchronization on the next burst, e.g. after an overflow of the device
+func (c *SampleClock) Reset() { c.last = time.Time{} }
+
+// Stamp returns the timestamps of n samples, which are read at the given time, oldest first
+func (c *SampleClock) Stamp(n int, readTime time.Time) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	newest := c.last.Add(time.Duration(n) * c.period)
+	if c.last.IsZero() || newest.After(readTime) || readTime.Sub(newest) > c.period {
+		c.last = readTime.Add(-time.Duration(n) * c.period)
+	}
+	stamps := make([]time.Time, n)
+	for i := range stamps {
+		stamps[i] = c.last.Add(time.Duration(i+1) * c.period)
+	}
+	c.last = stamps[n-1]
+	return stamps
+}
@@ -0,0 +1,61 @@
+package imu
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestStream(t *testing.T) {
+	// arrange
+	stops := 0
+	s := NewStream(2, func() { stops++ })
+	// act
+	for i := 0; i < 3; i++ {
+		s.Push(Reading{Time: time.Unix(int64(i), 0)})
+	}
+	s.ReportOverflow()
+	s.ReportError(errors.New("read error"))
+	// assert
+	gobottest.Assert(t, (<-s.C).Time, time.Unix(0, 0))
+	gobottest.Assert(t, (<-s.C).Time, time.Unix(1, 0))
+	gobottest.Assert(t, s.Dropped(), 1)
+	gobottest.Assert(t, s.Overflows(), 1)
+	gobottest.Assert(t, s.Err(), errors.New("read error"))
+	s.Stop()
+	s.Stop()
+	s.Push(Reading{})
+	_, ok := <-s.C
+	gobottest.Assert(t, ok, false)
+	gobottest.Assert(t, stops, 1)
+	gobottest.Assert(t, s.Close(), false)
+}
+
+func TestStreamClose(t *testing.T) {
+	stops := 0
+	s := NewStream(1, func() { stops++ })
+	gobottest.Assert(t, s.Close(), true)
+	s.Stop()
+	gobottest.Assert(t, stops, 0)
+}
+
+func TestSampleClock(t *testing.T) {
+	// arrange
+	period := 10 * time.Millisecond
+	c := NewSampleClock(period)
+	t0 := time.Unix(100, 0)
+	// act & assert: first burst ends at the read time
+	gobottest.Assert(t, c.Stamp(2, t0), []time.Time{t0.Add(-10 * time.Millisecond), t0})
+	// continued, although the read is delayed by 3 ms
+	gobottest.Assert(t, c.Stamp(3, t0.Add(33*time.Millisecond)),
+		[]time.Time{t0.Add(10 * time.Millisecond), t0.Add(20 * time.Millisecond), t0.Add(30 * time.Millisecond)})
+	// resynchronized, because a sample is missing
+	gobottest.Assert(t, c.Stamp(1, t0.Add(55*time.Millisecond)), []time.Time{t0.Add(55 * time.Millisecond)})
+	// resynchronized after reset
+	c.Reset()
+	gobottest.Assert(t, c.Stamp(1, t0.Add(60*time.Millisecond)), []time.Time{t0.Add(60 * time.Millisecond)})
+	gobottest.Assert(t, c.Stamp(0, t0), []time.Time(nil))
+	gobottest.Assert(t, c.Period(), period)
+}
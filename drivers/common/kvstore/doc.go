@@ -0,0 +1,12 @@
+/*
+Package kvstore provides a small key-value store for non-volatile memories like EEPROM and FRAM, e.g. to keep
+calibration data on the board itself.
+
+The memory is divided into a number of equal slots. Each write of the store goes to the next slot, so the
+write cycles are spread over the whole memory (wear leveling). Each slot starts with a header containing a
+sequence number, the length of the data and a CRC32 checksum. On load the valid slot with the highest sequence
+number is used, so an interrupted write (e.g. by a power loss) falls back to the last complete content.
+
+Any driver implementing the Storage interface can be used, e.g. i2c.EEPROMDriver or i2c.FRAMDriver.
+*/
+package kvstore // import "gobot.io/x/gobot/drivers/common/kvstore"
@@ -0,0 +1,300 @@
+package kvstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+	"sync"
+)
+
+const (
+	slotMagic      = "GBKV"
+	slotHeaderSize = 14 // magic (4), sequence (4), length (2), crc (4)
+	maxKeyLength   = 255
+	maxValueLength = 65535
+)
+
+// ErrKeyNotFound is returned by GetJSON() for an unknown key
+var ErrKeyNotFound = errors.New("key not found")
+
+// Storage is a non-volatile memory with random access
+type Storage interface {
+	io.ReaderAt
+	io.WriterAt
+	Size() int64
+}
+
+// Store is a wear leveled key-value store on a Storage
+type Store struct {
+	mutex    sync.Mutex
+	storage  Storage
+	slots    int
+	slotSize int64
+	current  int // slot of the last write, -1 if there is none
+	sequence uint32
+	values   map[string][]byte
+}
+
+// NewStore creates a store with the given count of slots on the storage and loads the last valid content.
+// Each slot must be able to hold the whole content of the store. With only one slot there is no wear leveling
+// and an interrupted write will lose the content, so at least 2 slots are recommended.
+func NewStore(storage Storage, slots int) (*Store, error) {
+	if slots < 1 {
+		return nil, errors.New("at least one slot is needed")
+	}
+	slotSize := storage.Size() / int64(slots)
+	if slotSize <= slotHeaderSize {
+		return nil, fmt.Errorf("the storage of %d bytes is too small for %d slots", storage.Size(), slots)
+	}
+	s := &Store{
+		storage:  storage,
+		slots:    slots,
+		slotSize: slotSize,
+		current:  -1,
+		values:   make(map[string][]byte),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Capacity returns the maximum size of the encoded content, which is 1 byte per key length, 2 bytes per value
+// length plus the lengths of all keys and values.
+func (s *Store) Capacity() int {
+	c := s.slotSize - slotHeaderSize
+	if c > maxValueLength {
+		c = maxValueLength
+	}
+	return int(c)
+}
+
+// Get returns a copy of the value for the key and whether the key exists.
+func (s *Store) Get(key string) ([]byte, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	v, ok := s.values[key]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, v...), true
+}
+
+// Set stores the value for the key. Nothing is written, if the value is unchanged.
+func (s *Store) Set(key string, value []byte) error {
+	if len(key) == 0 || len(key) > maxKeyLength {
+		return fmt.Errorf("the key length must be between 1 and %d", maxKeyLength)
+	}
+	if len(value) > maxValueLength {
+		return fmt.Errorf("the value length must not exceed %d", maxValueLength)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	old, ok := s.values[key]
+	if ok && string(old) == string(value) {
+		return nil
+	}
+	s.values[key] = append([]byte{}, value...)
+	if err := s.save(); err != nil {
+		if ok {
+			s.values[key] = old
+		} else {
+			delete(s.values, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes the key. Nothing is written, if the key does not exist.
+func (s *Store) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	old, ok := s.values[key]
+	if !ok {
+		return nil
+	}
+	delete(s.values, key)
+	if err := s.save(); err != nil {
+		s.values[key] = old
+		return err
+	}
+	return nil
+}
+
+// Keys returns all keys in sorted order.
+func (s *Store) Keys() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.sortedKeys()
+}
+
+// Clear removes all keys.
+func (s *Store) Clear() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	old := s.values
+	s.values = make(map[string][]byte)
+	if err := s.save(); err != nil {
+		s.values = old
+		return err
+	}
+	return nil
+}
+
+// SetJSON stores the JSON encoding of v for the key, e.g. a calibration.
+func (s *Store) SetJSON(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.Set(key, data)
+}
+
+// GetJSON decodes the value of the key into v. ErrKeyNotFound is returned for an unknown key.
+func (s *Store) GetJSON(key string, v interface{}) error {
+	data, ok := s.Get(key)
+	if !ok {
+		return ErrKeyNotFound
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (s *Store) sortedKeys() []string {
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// load reads all slots and decodes the valid one with the highest sequence number
+func (s *Store) load() error {
+	var payload []byte
+	for slot := 0; slot < s.slots; slot++ {
+		seq, data, ok, err := s.readSlot(slot)
+		if err != nil {
+			return err
+		}
+		if !ok || (s.current >= 0 && int32(seq-s.sequence) <= 0) {
+			continue
+		}
+		s.current = slot
+		s.sequence = seq
+		payload = data
+	}
+	if s.current < 0 {
+		return nil
+	}
+	values, err := decode(payload)
+	if err != nil {
+		return err
+	}
+	s.values = values
+	return nil
+}
+
+// readSlot returns the sequence number and the data of the slot, ok is false for an invalid slot
+func (s *Store) readSlot(slot int) (seq uint32, data []byte, ok bool, err error) {
+	offset := int64(slot) * s.slotSize
+	header := make([]byte, slotHeaderSize)
+	if _, err := s.storage.ReadAt(header, offset); err != nil {
+		return 0, nil, false, err
+	}
+	if string(header[0:4]) != slotMagic {
+		return 0, nil, false, nil
+	}
+	seq = binary.LittleEndian.Uint32(header[4:8])
+	length := int(binary.LittleEndian.Uint16(header[8:10]))
+	if length > s.Capacity() {
+		return 0, nil, false, nil
+	}
+	data = make([]byte, length)
+	if _, err := s.storage.ReadAt(data, offset+slotHeaderSize); err != nil {
+		return 0, nil, false, err
+	}
+	if checksum(header[4:10], data) != binary.LittleEndian.Uint32(header[10:14]) {
+		return 0, nil, false, nil
+	}
+	return seq, data, true, nil
+}
+
+// save writes the content to the next slot, the header is written last, so the slot is only valid, when the
+// data is written completely
+func (s *Store) save() error {
+	data := s.encode()
+	if len(data) > s.Capacity() {
+		return fmt.Errorf("the content of %d bytes exceeds the capacity of %d bytes", len(data), s.Capacity())
+	}
+	slot := (s.current + 1) % s.slots
+	seq := s.sequence + 1
+
+	header := make([]byte, slotHeaderSize)
+	copy(header[0:4], slotMagic)
+	binary.LittleEndian.PutUint32(header[4:8], seq)
+	binary.LittleEndian.PutUint16(header[8:10], uint16(len(data)))
+	binary.LittleEndian.PutUint32(header[10:14], checksum(header[4:10], data))
+
+	offset := int64(slot) * s.slotSize
+	if len(data) > 0 {
+		if _, err := s.storage.WriteAt(data, offset+slotHeaderSize); err != nil {
+			return err
+		}
+	}
+	if _, err := s.storage.WriteAt(header, offset); err != nil {
+		return err
+	}
+	s.current = slot
+	s.sequence = seq
+	return nil
+}
+
+// encode returns the content as sequence of key length (1 byte), key, value length (2 bytes LE) and value
+func (s *Store) encode() []byte {
+	var data []byte
+	for _, k := range s.sortedKeys() {
+		v := s.values[k]
+		data = append(data, byte(len(k)))
+		data = append(data, k...)
+		data = append(data, byte(len(v)), byte(len(v)>>8))
+		data = append(data, v...)
+	}
+	return data
+}
+
+func decode(data []byte) (map[string][]byte, error) {
+	values := make(map[string][]byte)
+	for pos := 0; pos < len(data); {
+		keyLen := int(data[pos])
+		pos++
+		if pos+keyLen+2 > len(data) {
+			return nil, errors.New("corrupted content")
+		}
+		key := string(data[pos : pos+keyLen])
+		pos += keyLen
+		valLen := int(binary.LittleEndian.Uint16(data[pos : pos+2]))
+		pos += 2
+		if pos+valLen > len(data) {
+			return nil, errors.New("corrupted content")
+		}
+		values[key] = append([]byte{}, data[pos:pos+valLen]...)
+		pos += valLen
+	}
+	return values, nil
+}
+
+func checksum(header []byte, data []byte) uint32 {
+	crc := crc32.ChecksumIEEE(header)
+	return crc32.Update(crc, crc32.IEEETable, data)
+}
@@ -0,0 +1,158 @@
+package kvstore
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// testStorage is a memory with a limit of writable bytes to simulate a power loss
+type testStorage struct {
+	mem        []byte
+	writes     int
+	writeLimit int
+}
+
+func newTestStorage(size int) *testStorage {
+	return &testStorage{mem: make([]byte, size), writeLimit: -1}
+}
+
+func (m *testStorage) Size() int64 { return int64(len(m.mem)) }
+
+func (m *testStorage) ReadAt(p []byte, off int64) (int, error) {
+	return copy(p, m.mem[off:]), nil
+}
+
+func (m *testStorage) WriteAt(p []byte, off int64) (int, error) {
+	for i, v := range p {
+		if m.writeLimit == 0 {
+			return i, errors.New("power loss")
+		}
+		if m.writeLimit > 0 {
+			m.writeLimit--
+		}
+		m.mem[int(off)+i] = v
+	}
+	m.writes++
+	return len(p), nil
+}
+
+func TestNewStore(t *testing.T) {
+	s, err := NewStore(newTestStorage(256), 4)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, s.Capacity(), 64-slotHeaderSize)
+	gobottest.Assert(t, len(s.Keys()), 0)
+
+	_, err = NewStore(newTestStorage(256), 0)
+	gobottest.Assert(t, err, errors.New("at least one slot is needed"))
+	_, err = NewStore(newTestStorage(16), 2)
+	gobottest.Assert(t, err, errors.New("the storage of 16 bytes is too small for 2 slots"))
+}
+
+func TestStoreSetGet(t *testing.T) {
+	m := newTestStorage(512)
+	s, _ := NewStore(m, 4)
+
+	gobottest.Assert(t, s.Set("b", []byte{1, 2, 3}), nil)
+	gobottest.Assert(t, s.Set("a", []byte("hello")), nil)
+	v, ok := s.Get("b")
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, v, []byte{1, 2, 3})
+	gobottest.Assert(t, s.Keys(), []string{"a", "b"})
+	_, ok = s.Get("c")
+	gobottest.Assert(t, ok, false)
+
+	// unchanged values are not written
+	writes := m.writes
+	gobottest.Assert(t, s.Set("a", []byte("hello")), nil)
+	gobottest.Assert(t, m.writes, writes)
+
+	gobottest.Assert(t, s.Delete("b"), nil)
+	gobottest.Assert(t, s.Keys(), []string{"a"})
+
+	// reload from the storage
+	s, err := NewStore(m, 4)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, s.Keys(), []string{"a"})
+	v, _ = s.Get("a")
+	gobottest.Assert(t, v, []byte("hello"))
+
+	gobottest.Assert(t, s.Clear(), nil)
+	s, _ = NewStore(m, 4)
+	gobottest.Assert(t, len(s.Keys()), 0)
+}
+
+func TestStoreSetErrors(t *testing.T) {
+	s, _ := NewStore(newTestStorage(64), 2)
+	gobottest.Assert(t, s.Set("", []byte{1}), errors.New("the key length must be between 1 and 255"))
+	err := s.Set("key", make([]byte, 20))
+	gobottest.Assert(t, err, errors.New("the content of 26 bytes exceeds the capacity of 18 bytes"))
+	_, ok := s.Get("key")
+	gobottest.Assert(t, ok, false)
+}
+
+func TestStoreWearLeveling(t *testing.T) {
+	m := newTestStorage(256)
+	s, _ := NewStore(m, 4)
+	for i := 0; i < 6; i++ {
+		gobottest.Assert(t, s.Set("count", []byte{byte(i)}), nil)
+	}
+	// the writes are rotated over all slots, the 6th write is in the 2nd slot
+	gobottest.Assert(t, s.current, 1)
+	gobottest.Assert(t, s.sequence, uint32(6))
+	for slot := 0; slot < 4; slot++ {
+		gobottest.Assert(t, string(m.mem[slot*64:slot*64+4]), slotMagic)
+	}
+
+	s, _ = NewStore(m, 4)
+	gobottest.Assert(t, s.current, 1)
+	v, _ := s.Get("count")
+	gobottest.Assert(t, v, []byte{5})
+}
+
+func TestStorePowerLoss(t *testing.T) {
+	m := newTestStorage(256)
+	s, _ := NewStore(m, 2)
+	gobottest.Assert(t, s.Set("cal", []byte{1, 2, 3, 4}), nil)
+
+	// the data is written, but the header is not complete
+	m.writeLimit = 14
+	gobottest.Assert(t, s.Set("cal", []byte{5, 6, 7, 8, 9, 10}), errors.New("power loss"))
+	v, _ := s.Get("cal")
+	gobottest.Assert(t, v, []byte{1, 2, 3, 4})
+
+	m.writeLimit = -1
+	s, _ = NewStore(m, 2)
+	v, _ = s.Get("cal")
+	gobottest.Assert(t, v, []byte{1, 2, 3, 4})
+}
+
+func TestStoreCorruption(t *testing.T) {
+	m := newTestStorage(256)
+	s, _ := NewStore(m, 2)
+	gobottest.Assert(t, s.Set("cal", []byte{1}), nil)
+	gobottest.Assert(t, s.Set("cal", []byte{2}), nil)
+
+	// a changed byte in the data of the newest slot is detected by the checksum
+	m.mem[128+slotHeaderSize+5] ^= 0xFF
+	s, _ = NewStore(m, 2)
+	v, _ := s.Get("cal")
+	gobottest.Assert(t, v, []byte{1})
+	gobottest.Assert(t, s.current, 0)
+}
+
+func TestStoreJSON(t *testing.T) {
+	type calibration struct {
+		Offset [3]float64
+		Scale  float64
+	}
+	s, _ := NewStore(newTestStorage(1024), 2)
+	want := calibration{Offset: [3]float64{0.5, -1.25, 3}, Scale: 1.5}
+	gobottest.Assert(t, s.SetJSON("imu", want), nil)
+
+	var got calibration
+	gobottest.Assert(t, s.GetJSON("imu", &got), nil)
+	gobottest.Assert(t, got, want)
+	gobottest.Assert(t, s.GetJSON("unknown", &got), ErrKeyNotFound)
+}
@@ -0,0 +1,140 @@
+package ledstrip
+
+import (
+	"image/color"
+	"math"
+)
+
+// BlendMode defines how the pixels of a layer are combined with the pixels below
+type BlendMode int
+
+// Supported blend modes
+const (
+	// BlendNormal covers the pixels below, the alpha value of the pixel is used as coverage
+	BlendNormal BlendMode = iota
+	// BlendAdd adds the pixels, e.g. for sparkles over a background
+	BlendAdd
+	// BlendMultiply darkens the pixels below, e.g. for a mask
+	BlendMultiply
+	// BlendMax takes the brighter value of each channel
+	BlendMax
+)
+
+// HSV converts the hue (0..360 degree), saturation (0..1) and value (0..1) to an opaque RGBA color.
+func HSV(h, s, v float64) color.RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = clamp(s, 0, 1)
+	v = clamp(v, 0, 1)
+
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.RGBA{R: toByte((r + m) * 255), G: toByte((g + m) * 255), B: toByte((b + m) * 255), A: 0xff}
+}
+
+// Kelvin returns the opaque RGBA color of a black body with the given color temperature. The valid
+// range is 1000..40000 Kelvin, e.g. 2700 is a warm white and 6500 is daylight.
+func Kelvin(k float64) color.RGBA {
+	// approximation by Tanner Helland
+	t := clamp(k, 1000, 40000) / 100
+
+	var r, g, b float64
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+	return color.RGBA{R: toByte(r), G: toByte(g), B: toByte(b), A: 0xff}
+}
+
+// Scale returns the color with all channels multiplied by the given factor (0..1), the alpha value is kept.
+func Scale(c color.RGBA, f float64) color.RGBA {
+	f = clamp(f, 0, 1)
+	return color.RGBA{R: toByte(float64(c.R) * f), G: toByte(float64(c.G) * f), B: toByte(float64(c.B) * f), A: c.A}
+}
+
+// Gamma is a lookup table to correct the non linear brightness perception of the human eye.
+type Gamma [256]uint8
+
+// NewGamma creates the lookup table for the given gamma value, a typical value for LEDs is 2.2..2.8.
+func NewGamma(gamma float64) *Gamma {
+	var g Gamma
+	for i := range g {
+		g[i] = toByte(math.Pow(float64(i)/255, gamma) * 255)
+	}
+	return &g
+}
+
+// Correct applies the gamma correction to the color channels, the alpha value is kept.
+func (g *Gamma) Correct(c color.RGBA) color.RGBA {
+	return color.RGBA{R: g[c.R], G: g[c.G], B: g[c.B], A: c.A}
+}
+
+// Blend combines the top color with the base color by the given mode. The opacity (0..1) scales
+// the influence of the top color. The result is opaque.
+func Blend(base, top color.RGBA, mode BlendMode, opacity float64) color.RGBA {
+	opacity = clamp(opacity, 0, 1)
+	a := opacity * float64(top.A) / 255
+
+	var r, g, b float64
+	switch mode {
+	case BlendAdd:
+		r = float64(base.R) + float64(top.R)*a
+		g = float64(base.G) + float64(top.G)*a
+		b = float64(base.B) + float64(top.B)*a
+	case BlendMultiply:
+		r = mix(float64(base.R), float64(base.R)*float64(top.R)/255, a)
+		g = mix(float64(base.G), float64(base.G)*float64(top.G)/255, a)
+		b = mix(float64(base.B), float64(base.B)*float64(top.B)/255, a)
+	case BlendMax:
+		r = mix(float64(base.R), math.Max(float64(base.R), float64(top.R)), a)
+		g = mix(float64(base.G), math.Max(float64(base.G), float64(top.G)), a)
+		b = mix(float64(base.B), math.Max(float64(base.B), float64(top.B)), a)
+	default:
+		r = mix(float64(base.R), float64(top.R), a)
+		g = mix(float64(base.G), float64(top.G), a)
+		b = mix(float64(base.B), float64(top.B), a)
+	}
+	return color.RGBA{R: toByte(r), G: toByte(g), B: toByte(b), A: 0xff}
+}
+
+func mix(a, b, f float64) float64 {
+	return a + (b-a)*f
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
+
+func toByte(v float64) uint8 {
+	return uint8(clamp(math.Round(v), 0, 255))
+}
@@ -0,0 +1,86 @@
+package ledstrip
+
+import (
+	"image/color"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestHSV(t *testing.T) {
+	var tests = map[string]struct {
+		h, s, v float64
+		want    color.RGBA
+	}{
+		"red":         {h: 0, s: 1, v: 1, want: color.RGBA{255, 0, 0, 255}},
+		"green":       {h: 120, s: 1, v: 1, want: color.RGBA{0, 255, 0, 255}},
+		"blue":        {h: 240, s: 1, v: 1, want: color.RGBA{0, 0, 255, 255}},
+		"yellow":      {h: 60, s: 1, v: 1, want: color.RGBA{255, 255, 0, 255}},
+		"wrap_around": {h: 480, s: 1, v: 1, want: color.RGBA{0, 255, 0, 255}},
+		"negative":    {h: -120, s: 1, v: 1, want: color.RGBA{0, 0, 255, 255}},
+		"white":       {h: 77, s: 0, v: 1, want: color.RGBA{255, 255, 255, 255}},
+		"half_red":    {h: 0, s: 1, v: 0.5, want: color.RGBA{128, 0, 0, 255}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gobottest.Assert(t, HSV(tc.h, tc.s, tc.v), tc.want)
+		})
+	}
+}
+
+func TestKelvin(t *testing.T) {
+	warm := Kelvin(2700)
+	gobottest.Assert(t, warm.R, uint8(255))
+	gobottest.Assert(t, warm.G > warm.B, true)
+
+	day := Kelvin(6600)
+	gobottest.Assert(t, day.R, uint8(255))
+	gobottest.Assert(t, day.B, uint8(255))
+
+	cold := Kelvin(20000)
+	gobottest.Assert(t, cold.B, uint8(255))
+	gobottest.Assert(t, cold.R < cold.B, true)
+
+	gobottest.Assert(t, Kelvin(100), Kelvin(1000))
+}
+
+func TestGamma(t *testing.T) {
+	g := NewGamma(2.2)
+	gobottest.Assert(t, g[0], uint8(0))
+	gobottest.Assert(t, g[255], uint8(255))
+	gobottest.Assert(t, g[128], uint8(56))
+	gobottest.Assert(t, g.Correct(color.RGBA{255, 128, 0, 7}), color.RGBA{255, 56, 0, 7})
+
+	linear := NewGamma(1)
+	gobottest.Assert(t, linear[100], uint8(100))
+}
+
+func TestBlend(t *testing.T) {
+	base := color.RGBA{100, 200, 50, 255}
+	top := color.RGBA{200, 100, 255, 255}
+	var tests = map[string]struct {
+		mode    BlendMode
+		top     color.RGBA
+		opacity float64
+		want    color.RGBA
+	}{
+		"normal":             {mode: BlendNormal, top: top, opacity: 1, want: top},
+		"normal_half":        {mode: BlendNormal, top: top, opacity: 0.5, want: color.RGBA{150, 150, 153, 255}},
+		"normal_transparent": {mode: BlendNormal, top: color.RGBA{200, 100, 255, 0}, opacity: 1, want: base},
+		"add":                {mode: BlendAdd, top: top, opacity: 1, want: color.RGBA{255, 255, 255, 255}},
+		"add_half":           {mode: BlendAdd, top: color.RGBA{100, 0, 0, 255}, opacity: 0.5, want: color.RGBA{150, 200, 50, 255}},
+		"multiply":           {mode: BlendMultiply, top: color.RGBA{255, 0, 128, 255}, opacity: 1, want: color.RGBA{100, 0, 25, 255}},
+		"max":                {mode: BlendMax, top: top, opacity: 1, want: color.RGBA{200, 200, 255, 255}},
+		"no_opacity":         {mode: BlendAdd, top: top, opacity: 0, want: base},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gobottest.Assert(t, Blend(base, tc.top, tc.mode, tc.opacity), tc.want)
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	gobottest.Assert(t, Scale(color.RGBA{200, 100, 10, 9}, 0.5), color.RGBA{100, 50, 5, 9})
+	gobottest.Assert(t, Scale(color.RGBA{200, 100, 10, 9}, 2), color.RGBA{200, 100, 10, 9})
+}
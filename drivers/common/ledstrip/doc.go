@@ -0,0 +1,9 @@
+/*
+Package ledstrip provides an effects engine for addressable LED strips and matrices, like the APA102 and
+WS2812 drivers of the spi package.
+
+The engine renders a stack of layers with a limited frame rate. Each layer runs an effect on the whole strip
+or on a segment of it, e.g. one row of a LED matrix (see Matrix), and is blended onto the layers below.
+Helpers for HSV, color temperature and gamma correction are included.
+*/
+package ledstrip // import "gobot.io/x/gobot/drivers/common/ledstrip"
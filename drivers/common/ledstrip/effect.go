@@ -0,0 +1,154 @@
+package ledstrip
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Effect renders one frame of an animation. The frame contains one pixel per LED of the strip or segment
+// and is cleared (transparent black) before each call. The time t is the elapsed time since the start
+// of the engine. The alpha value of each pixel is used as coverage on blending, see Blend().
+type Effect interface {
+	Render(t time.Duration, frame []color.RGBA)
+}
+
+// EffectFunc is an adapter to use a function as Effect
+type EffectFunc func(t time.Duration, frame []color.RGBA)
+
+// Render implements the Effect interface.
+func (f EffectFunc) Render(t time.Duration, frame []color.RGBA) { f(t, frame) }
+
+// Solid fills the frame with the given color.
+func Solid(c color.RGBA) Effect {
+	return EffectFunc(func(_ time.Duration, frame []color.RGBA) {
+		for i := range frame {
+			frame[i] = c
+		}
+	})
+}
+
+// Rainbow shows the full hue circle over the frame, which is shifted once per period.
+func Rainbow(period time.Duration) Effect {
+	return EffectFunc(func(t time.Duration, frame []color.RGBA) {
+		shift := 0.0
+		if period > 0 {
+			shift = 360 * float64(t%period) / float64(period)
+		}
+		for i := range frame {
+			frame[i] = HSV(shift+360*float64(i)/float64(len(frame)), 1, 1)
+		}
+	})
+}
+
+// Chase moves a dot with a fading tail of the given length with the given speed (LEDs per second)
+// over the frame. All other pixels are transparent, so the chase can be put on top of other layers.
+func Chase(c color.RGBA, length int, speed float64) Effect {
+	if length < 1 {
+		length = 1
+	}
+	return EffectFunc(func(t time.Duration, frame []color.RGBA) {
+		n := len(frame)
+		if n == 0 {
+			return
+		}
+		head := int(math.Floor(t.Seconds()*speed)) % n
+		if head < 0 {
+			head += n
+		}
+		for k := 0; k < length && k < n; k++ {
+			i := (head - k + n) % n
+			frame[i] = color.RGBA{R: c.R, G: c.G, B: c.B, A: toByte(255 * float64(length-k) / float64(length))}
+		}
+	})
+}
+
+// Breathe fades the color smoothly in and out, one breath takes the given period.
+func Breathe(c color.RGBA, period time.Duration) Effect {
+	return EffectFunc(func(t time.Duration, frame []color.RGBA) {
+		level := 1.0
+		if period > 0 {
+			level = (1 - math.Cos(2*math.Pi*float64(t%period)/float64(period))) / 2
+		}
+		v := Scale(c, level)
+		for i := range frame {
+			frame[i] = v
+		}
+	})
+}
+
+// FireEffect simulates flames, starting at the first pixel of the frame. The simulation is not
+// based on time, so the speed depends on the frame rate of the engine.
+type FireEffect struct {
+	cooling  int
+	sparking int
+	heat     []int
+	rnd      *rand.Rand
+	mutex    *sync.Mutex
+}
+
+// Fire creates a new fire effect. Cooling (typical 20..100) defines how fast the flames cool down,
+// so higher values result in shorter flames. Sparking (0..255, typical 50..200) is the chance of new
+// sparks, higher values result in a more active fire.
+func Fire(cooling int, sparking int) *FireEffect {
+	return &FireEffect{
+		cooling:  cooling,
+		sparking: sparking,
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		mutex:    &sync.Mutex{},
+	}
+}
+
+// Render implements the Effect interface.
+func (f *FireEffect) Render(_ time.Duration, frame []color.RGBA) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	n := len(frame)
+	if n == 0 {
+		return
+	}
+	if len(f.heat) != n {
+		f.heat = make([]int, n)
+	}
+
+	// cool down every cell a little
+	for i := range f.heat {
+		f.heat[i] -= f.rnd.Intn(f.cooling*10/n + 2)
+		if f.heat[i] < 0 {
+			f.heat[i] = 0
+		}
+	}
+	// heat drifts up and diffuses
+	for k := n - 1; k >= 2; k-- {
+		f.heat[k] = (f.heat[k-1] + 2*f.heat[k-2]) / 3
+	}
+	// ignite new sparks near the bottom
+	if f.rnd.Intn(255) < f.sparking {
+		y := f.rnd.Intn(int(math.Min(7, float64(n))))
+		f.heat[y] += 160 + f.rnd.Intn(96)
+		if f.heat[y] > 255 {
+			f.heat[y] = 255
+		}
+	}
+
+	for i, h := range f.heat {
+		frame[i] = heatColor(h)
+	}
+}
+
+// heatColor maps the heat (0..255) to black, red, yellow and white
+func heatColor(heat int) color.RGBA {
+	t := heat * 191 / 255
+	ramp := uint8((t & 0x3f) << 2)
+	switch {
+	case t > 0x80:
+		return color.RGBA{R: 255, G: 255, B: ramp, A: 0xff}
+	case t > 0x40:
+		return color.RGBA{R: 255, G: ramp, B: 0, A: 0xff}
+	default:
+		return color.RGBA{R: ramp, G: 0, B: 0, A: 0xff}
+	}
+}
@@ -0,0 +1,77 @@
+package ledstrip
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestSolid(t *testing.T) {
+	frame := make([]color.RGBA, 3)
+	Solid(color.RGBA{1, 2, 3, 255}).Render(0, frame)
+	gobottest.Assert(t, frame, []color.RGBA{{1, 2, 3, 255}, {1, 2, 3, 255}, {1, 2, 3, 255}})
+}
+
+func TestRainbow(t *testing.T) {
+	frame := make([]color.RGBA, 3)
+	e := Rainbow(3 * time.Second)
+	e.Render(0, frame)
+	gobottest.Assert(t, frame, []color.RGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}})
+	// shifted by one LED after 1/3 of the period
+	e.Render(time.Second, frame)
+	gobottest.Assert(t, frame, []color.RGBA{{0, 255, 0, 255}, {0, 0, 255, 255}, {255, 0, 0, 255}})
+}
+
+func TestChase(t *testing.T) {
+	c := color.RGBA{10, 20, 30, 255}
+	e := Chase(c, 2, 2)
+	frame := make([]color.RGBA, 4)
+	e.Render(1500*time.Millisecond, frame)
+	// head at 3, tail at 2
+	gobottest.Assert(t, frame, []color.RGBA{{}, {}, {10, 20, 30, 128}, {10, 20, 30, 255}})
+
+	frame = make([]color.RGBA, 4)
+	e.Render(2*time.Second, frame)
+	// wrapped around
+	gobottest.Assert(t, frame, []color.RGBA{{10, 20, 30, 255}, {}, {}, {10, 20, 30, 128}})
+}
+
+func TestBreathe(t *testing.T) {
+	c := color.RGBA{200, 100, 0, 255}
+	e := Breathe(c, 2*time.Second)
+	frame := make([]color.RGBA, 2)
+	e.Render(0, frame)
+	gobottest.Assert(t, frame[0], color.RGBA{0, 0, 0, 255})
+	e.Render(time.Second, frame)
+	gobottest.Assert(t, frame[1], c)
+	e.Render(500*time.Millisecond, frame)
+	gobottest.Assert(t, frame[0], color.RGBA{100, 50, 0, 255})
+}
+
+func TestFire(t *testing.T) {
+	e := Fire(55, 255)
+	frame := make([]color.RGBA, 20)
+	lit := false
+	for i := 0; i < 20; i++ {
+		e.Render(0, frame)
+		for _, c := range frame {
+			gobottest.Assert(t, c.A, uint8(255))
+			gobottest.Assert(t, c.B <= c.G && c.G <= c.R, true)
+			if c.R > 0 {
+				lit = true
+			}
+		}
+	}
+	gobottest.Assert(t, lit, true)
+	// resize is possible
+	e.Render(0, make([]color.RGBA, 5))
+}
+
+func TestHeatColor(t *testing.T) {
+	gobottest.Assert(t, heatColor(0), color.RGBA{0, 0, 0, 255})
+	gobottest.Assert(t, heatColor(80), color.RGBA{236, 0, 0, 255})
+	gobottest.Assert(t, heatColor(150), color.RGBA{255, 192, 0, 255})
+	gobottest.Assert(t, heatColor(255), color.RGBA{255, 255, 252, 255})
+}
@@ -0,0 +1,166 @@
+package ledstrip
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+// Error is the event name, which is published when drawing a frame fails
+const Error = "error"
+
+// Strip is the interface of an addressable LED strip, e.g. spi.APA102Driver or spi.WS2812Driver
+type Strip interface {
+	// Len returns the number of LEDs
+	Len() int
+	// SetRGBA sets the color of the ith LED, which is transferred by the next Draw()
+	SetRGBA(i int, c color.RGBA)
+	// Draw transfers all colors to the strip
+	Draw() error
+}
+
+// Layer is one level of the effect stack of the engine
+type Layer struct {
+	Effect Effect
+	// Blend defines how the layer is combined with the layers below
+	Blend BlendMode
+	// Opacity (0..1) scales the influence of the layer
+	Opacity float64
+	// Segment contains the strip indexes, the effect is rendered to. Nil means the whole strip.
+	Segment []int
+}
+
+// NewLayer creates a new opaque layer for the whole strip
+func NewLayer(e Effect) *Layer {
+	return &Layer{Effect: e, Blend: BlendNormal, Opacity: 1}
+}
+
+// Engine renders a stack of layers to a LED strip with a limited frame rate. Errors on drawing are
+// published by the event "error".
+type Engine struct {
+	strip    Strip
+	interval time.Duration
+	gamma    *Gamma
+	layers   []*Layer
+	started  time.Time
+	loop     *ticker.Loop
+	mutex    *sync.Mutex
+	gobot.Eventer
+}
+
+// NewEngine creates a new engine for the strip, which renders not more than the given frames per second.
+// A gamma correction of 2.2 is applied by default.
+func NewEngine(strip Strip, fps int) *Engine {
+	if fps < 1 {
+		fps = 1
+	}
+	e := &Engine{
+		strip:    strip,
+		interval: time.Second / time.Duration(fps),
+		gamma:    NewGamma(2.2),
+		loop:     ticker.NewLoop(),
+		mutex:    &sync.Mutex{},
+		Eventer:  gobot.NewEventer(),
+	}
+	e.AddEvent(Error)
+	return e
+}
+
+// SetGamma replaces the gamma correction, nil disables the correction.
+func (e *Engine) SetGamma(g *Gamma) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.gamma = g
+}
+
+// AddLayer puts the layer on top of the stack
+func (e *Engine) AddLayer(l *Layer) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.layers = append(e.layers, l)
+}
+
+// RemoveLayer removes the layer from the stack
+func (e *Engine) RemoveLayer(l *Layer) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for i, layer := range e.layers {
+		if layer == l {
+			e.layers = append(e.layers[:i], e.layers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Start starts the render loop at the time 0 of the effects, so a running effect begins again.
+func (e *Engine) Start() {
+	e.loop.Stop()
+
+	e.mutex.Lock()
+	e.started = time.Now()
+	e.mutex.Unlock()
+
+	e.loop.Start(e.interval, e.renderFrame)
+}
+
+// Stop stops the render loop, the strip keeps the last frame.
+func (e *Engine) Stop() {
+	e.loop.Stop()
+}
+
+// Render renders the layers for the given time and draws the result to the strip. The render loop calls this
+// for each frame, a direct call is useful for single frames, e.g. in tests or for a static scene.
+//
+// The alpha value of the colors passed to the strip is 0, which means the default brightness for
+// the APA102 driver.
+func (e *Engine) Render(t time.Duration) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	n := e.strip.Len()
+	frame := make([]color.RGBA, n)
+	for _, l := range e.layers {
+		if l.Effect == nil {
+			continue
+		}
+		if l.Segment == nil {
+			buf := make([]color.RGBA, n)
+			l.Effect.Render(t, buf)
+			for i, c := range buf {
+				frame[i] = Blend(frame[i], c, l.Blend, l.Opacity)
+			}
+			continue
+		}
+		buf := make([]color.RGBA, len(l.Segment))
+		l.Effect.Render(t, buf)
+		for i, idx := range l.Segment {
+			if idx >= 0 && idx < n {
+				frame[idx] = Blend(frame[idx], buf[i], l.Blend, l.Opacity)
+			}
+		}
+	}
+
+	for i, c := range frame {
+		if e.gamma != nil {
+			c = e.gamma.Correct(c)
+		}
+		c.A = 0
+		e.strip.SetRGBA(i, c)
+	}
+	return e.strip.Draw()
+}
+
+func (e *Engine) renderFrame() {
+	e.mutex.Lock()
+	t := time.Since(e.started)
+	e.mutex.Unlock()
+	if err := e.Render(t); err != nil {
+		e.Publish(Error, err)
+	}
+}
@@ -0,0 +1,107 @@
+package ledstrip
+
+import (
+	"errors"
+	"image/color"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type ledstripTestStrip struct {
+	vals    []color.RGBA
+	draws   int
+	drawErr error
+	mutex   sync.Mutex
+}
+
+func newLedstripTestStrip(n int) *ledstripTestStrip {
+	return &ledstripTestStrip{vals: make([]color.RGBA, n)}
+}
+
+func (s *ledstripTestStrip) Len() int                    { return len(s.vals) }
+func (s *ledstripTestStrip) SetRGBA(i int, c color.RGBA) { s.vals[i] = c }
+func (s *ledstripTestStrip) Draw() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.draws++
+	return s.drawErr
+}
+
+func (s *ledstripTestStrip) drawCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.draws
+}
+
+func TestEngineRender(t *testing.T) {
+	s := newLedstripTestStrip(4)
+	e := NewEngine(s, 30)
+	e.SetGamma(nil)
+	e.AddLayer(NewLayer(Solid(color.RGBA{0, 0, 100, 255})))
+	add := &Layer{Effect: Solid(color.RGBA{50, 0, 0, 255}), Blend: BlendAdd, Opacity: 1, Segment: []int{1, 3, 7}}
+	e.AddLayer(add)
+
+	gobottest.Assert(t, e.Render(0), nil)
+	gobottest.Assert(t, s.draws, 1)
+	gobottest.Assert(t, s.vals, []color.RGBA{{0, 0, 100, 0}, {50, 0, 100, 0}, {0, 0, 100, 0}, {50, 0, 100, 0}})
+
+	e.RemoveLayer(add)
+	gobottest.Assert(t, e.Render(0), nil)
+	gobottest.Assert(t, s.vals[1], color.RGBA{0, 0, 100, 0})
+}
+
+func TestEngineGamma(t *testing.T) {
+	s := newLedstripTestStrip(1)
+	e := NewEngine(s, 30)
+	e.AddLayer(NewLayer(Solid(color.RGBA{128, 255, 0, 255})))
+
+	gobottest.Assert(t, e.Render(0), nil)
+	gobottest.Assert(t, s.vals[0], color.RGBA{56, 255, 0, 0})
+}
+
+func TestEngineMatrixSegment(t *testing.T) {
+	m := NewMatrix(3, 2, true)
+	s := newLedstripTestStrip(m.Len())
+	e := NewEngine(s, 30)
+	e.SetGamma(nil)
+	l := NewLayer(EffectFunc(func(_ time.Duration, frame []color.RGBA) {
+		for i := range frame {
+			frame[i] = color.RGBA{uint8(i + 1), 0, 0, 255}
+		}
+	}))
+	l.Segment = m.Row(1)
+	e.AddLayer(l)
+
+	gobottest.Assert(t, e.Render(0), nil)
+	gobottest.Assert(t, s.vals, []color.RGBA{{}, {}, {}, {3, 0, 0, 0}, {2, 0, 0, 0}, {1, 0, 0, 0}})
+}
+
+func TestEngineStartStop(t *testing.T) {
+	s := newLedstripTestStrip(2)
+	s.drawErr = errors.New("draw error")
+	e := NewEngine(s, 100)
+	sem := make(chan bool, 1)
+	_ = e.On(Error, func(data interface{}) {
+		select {
+		case sem <- true:
+		default:
+		}
+	})
+
+	e.Start()
+	select {
+	case <-sem:
+	case <-time.After(time.Second):
+		t.Errorf("error event was not published")
+	}
+	e.Stop()
+
+	count := s.drawCount()
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, s.drawCount() <= count+1, true)
+	// frame rate is limited
+	gobottest.Assert(t, count < 100, true)
+}
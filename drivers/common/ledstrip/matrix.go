@@ -0,0 +1,54 @@
+package ledstrip
+
+// Matrix maps the positions of a 2D LED matrix to the indexes of the underlying strip. The strip starts
+// at the upper left corner and runs row by row. For serpentine (zigzag) wiring every second row runs
+// from right to left.
+type Matrix struct {
+	Width      int
+	Height     int
+	Serpentine bool
+}
+
+// NewMatrix creates a new mapping for a matrix with the given size
+func NewMatrix(width, height int, serpentine bool) *Matrix {
+	return &Matrix{Width: width, Height: height, Serpentine: serpentine}
+}
+
+// Len returns the number of LEDs of the matrix
+func (m *Matrix) Len() int { return m.Width * m.Height }
+
+// Index returns the strip index of the LED at the given position, -1 is returned for positions
+// outside of the matrix.
+func (m *Matrix) Index(x, y int) int {
+	if x < 0 || y < 0 || x >= m.Width || y >= m.Height {
+		return -1
+	}
+	if m.Serpentine && y%2 == 1 {
+		x = m.Width - 1 - x
+	}
+	return y*m.Width + x
+}
+
+// Row returns the segment of the given row, ordered from left to right
+func (m *Matrix) Row(y int) []int {
+	return m.Rect(0, y, m.Width, 1)
+}
+
+// Column returns the segment of the given column, ordered from top to bottom
+func (m *Matrix) Column(x int) []int {
+	return m.Rect(x, 0, 1, m.Height)
+}
+
+// Rect returns the segment of the given rectangle, ordered row by row. Positions outside of the
+// matrix are skipped.
+func (m *Matrix) Rect(x, y, w, h int) []int {
+	var seg []int
+	for j := y; j < y+h; j++ {
+		for i := x; i < x+w; i++ {
+			if idx := m.Index(i, j); idx >= 0 {
+				seg = append(seg, idx)
+			}
+		}
+	}
+	return seg
+}
@@ -0,0 +1,29 @@
+package ledstrip
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestMatrixIndex(t *testing.T) {
+	m := NewMatrix(4, 3, false)
+	gobottest.Assert(t, m.Len(), 12)
+	gobottest.Assert(t, m.Index(0, 0), 0)
+	gobottest.Assert(t, m.Index(3, 1), 7)
+	gobottest.Assert(t, m.Index(4, 1), -1)
+	gobottest.Assert(t, m.Index(0, -1), -1)
+
+	m = NewMatrix(4, 3, true)
+	gobottest.Assert(t, m.Index(0, 1), 7)
+	gobottest.Assert(t, m.Index(3, 1), 4)
+	gobottest.Assert(t, m.Index(1, 2), 9)
+}
+
+func TestMatrixSegments(t *testing.T) {
+	m := NewMatrix(3, 3, true)
+	gobottest.Assert(t, m.Row(1), []int{5, 4, 3})
+	gobottest.Assert(t, m.Column(0), []int{0, 5, 6})
+	gobottest.Assert(t, m.Rect(1, 1, 5, 5), []int{4, 3, 7, 8})
+	gobottest.Assert(t, len(m.Row(3)), 0)
+}
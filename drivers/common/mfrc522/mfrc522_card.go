@@ -0,0 +1,483 @@
+package mfrc522
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// CardType is the type of a PICC, derived from the SAK (select acknowledge)
+type CardType uint8
+
+const (
+	// CardUnknown is used for all not supported SAK values
+	CardUnknown CardType = iota
+	// CardMifareMini is a MIFARE Classic with 320 bytes (5 sectors)
+	CardMifareMini
+	// CardMifare1K is a MIFARE Classic with 1 KByte (16 sectors)
+	CardMifare1K
+	// CardMifare4K is a MIFARE Classic with 4 KByte (40 sectors)
+	CardMifare4K
+	// CardMifareUltralight is a MIFARE Ultralight or NTAG21x, which is accessed by pages of 4 bytes
+	CardMifareUltralight
+	// CardMifarePlus is a MIFARE Plus in security level 2
+	CardMifarePlus
+	// CardISO14443_4 is a PICC compliant to ISO/IEC 14443-4, e.g. DESFire or MIFARE Plus in security level 3
+	CardISO14443_4
+)
+
+var cardTypeNames = map[CardType]string{
+	CardUnknown:          "unknown",
+	CardMifareMini:       "MIFARE Mini",
+	CardMifare1K:         "MIFARE Classic 1K",
+	CardMifare4K:         "MIFARE Classic 4K",
+	CardMifareUltralight: "MIFARE Ultralight or NTAG",
+	CardMifarePlus:       "MIFARE Plus",
+	CardISO14443_4:       "ISO/IEC 14443-4",
+}
+
+// String returns the name of the card type
+func (t CardType) String() string {
+	return cardTypeNames[t]
+}
+
+// Card contains the information of a selected PICC
+type Card struct {
+	UID  []byte // 4, 7 or 10 bytes
+	ATQA []byte // answer to request
+	SAK  uint8  // select acknowledge
+}
+
+// Type returns the card type derived from the SAK, see NXP AN10833
+func (c *Card) Type() CardType {
+	switch c.SAK & 0x7F {
+	case 0x09:
+		return CardMifareMini
+	case 0x08:
+		return CardMifare1K
+	case 0x18:
+		return CardMifare4K
+	case 0x00:
+		return CardMifareUltralight
+	case 0x10, 0x11:
+		return CardMifarePlus
+	case 0x20:
+		return CardISO14443_4
+	}
+	return CardUnknown
+}
+
+// UIDString returns the UID as hex string, e.g. "04A1B2C3D4E580"
+func (c *Card) UIDString() string {
+	return strings.ToUpper(fmt.Sprintf("%x", c.UID))
+}
+
+// Sectors returns the count of sectors for MIFARE Classic cards, otherwise 0
+func (c *Card) Sectors() int {
+	switch c.Type() {
+	case CardMifareMini:
+		return 5
+	case CardMifare1K:
+		return 16
+	case CardMifare4K:
+		return 40
+	}
+	return 0
+}
+
+// KeyType selects the key for authentication of MIFARE Classic sectors
+type KeyType uint8
+
+const (
+	// KeyA is used for authentication with key A
+	KeyA KeyType = piccCommandMFRegAUTHRegKEYRegA
+	// KeyB is used for authentication with key B
+	KeyB KeyType = piccCommandMFRegAUTHRegKEYRegB
+)
+
+// Key is a MIFARE Classic key
+type Key [6]byte
+
+// DefaultKey is the key of all sectors for new cards
+var DefaultKey = Key{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
+// SectorFirstBlock returns the address of the first block of the given MIFARE Classic sector. The first 32 sectors
+// consist of 4 blocks, the sectors 32..39 of MIFARE Classic 4K consist of 16 blocks.
+func SectorFirstBlock(sector uint8) uint8 {
+	if sector < 32 {
+		return sector * 4
+	}
+	return 128 + (sector-32)*16
+}
+
+// SectorTrailerBlock returns the address of the sector trailer of the given MIFARE Classic sector.
+func SectorTrailerBlock(sector uint8) uint8 {
+	return SectorFirstBlock(sector) + uint8(sectorBlocks(sector)) - 1
+}
+
+// BlockSector returns the MIFARE Classic sector of the given block address.
+func BlockSector(block uint8) uint8 {
+	if block < 128 {
+		return block / 4
+	}
+	return 32 + (block-128)/16
+}
+
+// IsTrailerBlock returns true, if the given block address is a sector trailer.
+func IsTrailerBlock(block uint8) bool {
+	return SectorTrailerBlock(BlockSector(block)) == block
+}
+
+func sectorBlocks(sector uint8) int {
+	if sector < 32 {
+		return 4
+	}
+	return 16
+}
+
+// AccessBits contains the access conditions (C1 C2 C3) of a MIFARE Classic sector as values 0..7 with C1 as most
+// significant bit. The index 0..2 is used for the data blocks (or block groups in sectors with 16 blocks) and
+// index 3 for the sector trailer. Please refer to the MIFARE Classic datasheet for the meaning of the values.
+type AccessBits [4]uint8
+
+// TransportAccessBits is the configuration of new cards: key A or B can read and write all data blocks, key A can
+// write key A, access bits and key B
+var TransportAccessBits = AccessBits{0, 0, 0, 1}
+
+// Encode returns the 3 bytes representation of the access bits for the sector trailer.
+func (a AccessBits) Encode() [3]byte {
+	var c1, c2, c3 uint8
+	for i, v := range a {
+		c1 |= (v >> 2 & 1) << i
+		c2 |= (v >> 1 & 1) << i
+		c3 |= (v & 1) << i
+	}
+	return [3]byte{^c2<<4 | ^c1&0x0F, c1<<4 | ^c3&0x0F, c3<<4 | c2}
+}
+
+// DecodeAccessBits returns the access bits of the 3 bytes from the sector trailer. An error is returned, if the
+// inverted bits do not match.
+func DecodeAccessBits(data [3]byte) (AccessBits, error) {
+	c1 := data[1] >> 4
+	c2 := data[2] & 0x0F
+	c3 := data[2] >> 4
+	if ^data[0]&0x0F != c1 || ^data[0]>>4 != c2 || ^data[1]&0x0F != c3 {
+		return AccessBits{}, fmt.Errorf("access bits %X are inconsistent", data)
+	}
+	var a AccessBits
+	for i := range a {
+		a[i] = (c1>>i&1)<<2 | (c2>>i&1)<<1 | c3>>i&1
+	}
+	return a, nil
+}
+
+// SectorTrailer is the content of the last block of a MIFARE Classic sector
+type SectorTrailer struct {
+	KeyA     Key
+	Access   AccessBits
+	UserData uint8 // general purpose byte, 0x69 for new cards
+	KeyB     Key
+}
+
+// Bytes returns the 16 bytes of the sector trailer block.
+func (t SectorTrailer) Bytes() []byte {
+	data := make([]byte, 16)
+	copy(data, t.KeyA[:])
+	access := t.Access.Encode()
+	copy(data[6:], access[:])
+	data[9] = t.UserData
+	copy(data[10:], t.KeyB[:])
+	return data
+}
+
+// ParseSectorTrailer creates the sector trailer from the 16 bytes of the block. Please note, key A is always read as
+// zeros, key B too, if not readable by the access conditions.
+func ParseSectorTrailer(data []byte) (SectorTrailer, error) {
+	var t SectorTrailer
+	if len(data) != 16 {
+		return t, fmt.Errorf("sector trailer needs 16 bytes, but has %d", len(data))
+	}
+	access, err := DecodeAccessBits([3]byte{data[6], data[7], data[8]})
+	if err != nil {
+		return t, err
+	}
+	copy(t.KeyA[:], data[:6])
+	t.Access = access
+	t.UserData = data[9]
+	copy(t.KeyB[:], data[10:])
+	return t, nil
+}
+
+// EncodeValueBlock returns the 16 bytes of a MIFARE Classic value block with the given value and address byte.
+func EncodeValueBlock(value int32, address uint8) []byte {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint32(data[0:], uint32(value))
+	binary.LittleEndian.PutUint32(data[4:], ^uint32(value))
+	binary.LittleEndian.PutUint32(data[8:], uint32(value))
+	data[12], data[13], data[14], data[15] = address, ^address, address, ^address
+	return data
+}
+
+// DecodeValueBlock returns the value and address byte of a MIFARE Classic value block. An error is returned, if
+// the block has not the format of a value block.
+func DecodeValueBlock(data []byte) (int32, uint8, error) {
+	if len(data) != 16 {
+		return 0, 0, fmt.Errorf("value block needs 16 bytes, but has %d", len(data))
+	}
+	v := binary.LittleEndian.Uint32(data[0:])
+	if binary.LittleEndian.Uint32(data[4:]) != ^v || binary.LittleEndian.Uint32(data[8:]) != v ||
+		data[13] != ^data[12] || data[14] != data[12] || data[15] != ^data[12] {
+		return 0, 0, fmt.Errorf("block %X is not a value block", data)
+	}
+	return int32(v), data[12], nil
+}
+
+// SelectCard wakes up a card in range (also a halted one) and selects it. The card keeps selected until HaltCard()
+// is called or another card is selected.
+func (d *MFRC522Common) SelectCard() (*Card, error) {
+	if err := d.writeByteData(regTxMode, rxtxModeRegReset); err != nil {
+		return nil, err
+	}
+	if err := d.writeByteData(regRxMode, rxtxModeRegReset); err != nil {
+		return nil, err
+	}
+	if err := d.writeByteData(regModWidth, modWidthRegReset); err != nil {
+		return nil, err
+	}
+
+	answer := []byte{0x00, 0x00}
+	if err := d.piccRequest(piccCommandWakeUpA, answer); err != nil {
+		return nil, err
+	}
+	uid, sak, err := d.piccActivate()
+	if err != nil {
+		return nil, err
+	}
+	return &Card{UID: uid, ATQA: answer, SAK: sak}, nil
+}
+
+// HaltCard sets the selected card to state HALT and stops the encrypted communication after authentication.
+func (d *MFRC522Common) HaltCard() error {
+	if err := d.piccHalt(); err != nil {
+		return err
+	}
+	return d.stopCrypto1()
+}
+
+// Transaction executes the given function exclusively. This is needed for a sequence of card operations, e.g.
+// select, authenticate and read, when polling is active.
+func (d *MFRC522Common) Transaction(fn func() error) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return fn()
+}
+
+// Authenticate authenticates the sector of the given block of a selected MIFARE Classic card with the given key.
+// The authentication is valid until another sector is authenticated or the card is halted.
+func (d *MFRC522Common) Authenticate(card *Card, block uint8, keyType KeyType, key Key) error {
+	return d.piccAuthenticateWithKey(uint8(keyType), block, key[:], card.UID)
+}
+
+// ReadBlock reads the 16 bytes of a block. For MIFARE Classic the sector needs to be authenticated before. For
+// MIFARE Ultralight and NTAG the block is the page address and 4 pages are read.
+func (d *MFRC522Common) ReadBlock(block uint8) ([]byte, error) {
+	return d.piccRead(block)
+}
+
+// WriteBlock writes 16 bytes to a block of an authenticated MIFARE Classic sector.
+func (d *MFRC522Common) WriteBlock(block uint8, data []byte) error {
+	return d.piccWrite(block, data)
+}
+
+// ReadSector authenticates the given sector of a MIFARE Classic card and reads all blocks of it, including the
+// sector trailer.
+func (d *MFRC522Common) ReadSector(card *Card, sector uint8, keyType KeyType, key Key) ([][]byte, error) {
+	first := SectorFirstBlock(sector)
+	if err := d.Authenticate(card, first, keyType, key); err != nil {
+		return nil, err
+	}
+	blocks := make([][]byte, sectorBlocks(sector))
+	for i := range blocks {
+		data, err := d.piccRead(first + uint8(i))
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = data
+	}
+	return blocks, nil
+}
+
+// WriteSectorTrailer writes the keys and access bits of an authenticated MIFARE Classic sector. Be careful, wrong
+// keys or access bits can make the sector inaccessible forever.
+func (d *MFRC522Common) WriteSectorTrailer(sector uint8, trailer SectorTrailer) error {
+	return d.piccWrite(SectorTrailerBlock(sector), trailer.Bytes())
+}
+
+// WriteValueBlock formats a block of an authenticated MIFARE Classic sector as value block.
+func (d *MFRC522Common) WriteValueBlock(block uint8, value int32, address uint8) error {
+	return d.piccWrite(block, EncodeValueBlock(value, address))
+}
+
+// ReadValueBlock reads the value and address byte of a value block of an authenticated MIFARE Classic sector.
+func (d *MFRC522Common) ReadValueBlock(block uint8) (int32, uint8, error) {
+	data, err := d.piccRead(block)
+	if err != nil {
+		return 0, 0, err
+	}
+	return DecodeValueBlock(data)
+}
+
+// Increment adds the delta to the value of the block and stores the result in the internal data register of the
+// card. Use Transfer() to write the result to a block.
+func (d *MFRC522Common) Increment(block uint8, delta uint32) error {
+	return d.piccValueOperation(piccCommandMFRegINCREMENT, block, delta)
+}
+
+// Decrement subtracts the delta from the value of the block and stores the result in the internal data register of
+// the card. Use Transfer() to write the result to a block.
+func (d *MFRC522Common) Decrement(block uint8, delta uint32) error {
+	return d.piccValueOperation(piccCommandMFRegDECREMENT, block, delta)
+}
+
+// Restore copies the value of the block to the internal data register of the card. Use Transfer() to write the
+// value to another block, e.g. for a backup.
+func (d *MFRC522Common) Restore(block uint8) error {
+	return d.piccValueOperation(piccCommandMFRegRESTORE, block, 0)
+}
+
+// Transfer writes the internal data register of the card to the block.
+func (d *MFRC522Common) Transfer(block uint8) error {
+	return d.piccCommandWithAck([]byte{piccCommandMFRegTRANSFER, block})
+}
+
+// AddValue increments or decrements the value block by the given delta and writes the result back to the block.
+func (d *MFRC522Common) AddValue(block uint8, delta int32) error {
+	var err error
+	if delta < 0 {
+		err = d.Decrement(block, uint32(-int64(delta)))
+	} else {
+		err = d.Increment(block, uint32(delta))
+	}
+	if err != nil {
+		return err
+	}
+	return d.Transfer(block)
+}
+
+// ReadPages reads 4 pages (16 bytes) of a MIFARE Ultralight or NTAG card, starting at the given page.
+func (d *MFRC522Common) ReadPages(page uint8) ([]byte, error) {
+	return d.piccRead(page)
+}
+
+// WritePage writes one page (4 bytes) of a MIFARE Ultralight or NTAG card.
+func (d *MFRC522Common) WritePage(page uint8, data []byte) error {
+	if len(data) != 4 {
+		return fmt.Errorf("the page to write needs to be exactly 4 bytes long, but has %d bytes", len(data))
+	}
+	return d.piccCommandWithAck(append([]byte{piccCommandULRegWRITE, page}, data...))
+}
+
+// TagVersion is the answer of the GET_VERSION command of NTAG21x and MIFARE Ultralight EV1
+type TagVersion struct {
+	Vendor      uint8
+	ProductType uint8
+	Subtype     uint8
+	Major       uint8
+	Minor       uint8
+	StorageSize uint8
+	Protocol    uint8
+}
+
+var ntagNames = map[uint8]string{0x0F: "NTAG213", 0x11: "NTAG215", 0x13: "NTAG216"}
+
+// Name returns the name of the tag, e.g. "NTAG215".
+func (v *TagVersion) Name() string {
+	if v.ProductType == 0x04 {
+		if n, ok := ntagNames[v.StorageSize]; ok {
+			return n
+		}
+		return "NTAG"
+	}
+	if v.ProductType == 0x03 {
+		return "MIFARE Ultralight EV1"
+	}
+	return "unknown"
+}
+
+// ReadTagVersion reads the version of a selected NTAG21x or MIFARE Ultralight EV1.
+func (d *MFRC522Common) ReadTagVersion() (*TagVersion, error) {
+	command := []byte{piccCommandGetVersion}
+	crcResult := []byte{0x00, 0x00}
+	if err := d.calculateCRC(command, crcResult); err != nil {
+		return nil, err
+	}
+	command = append(command, crcResult...)
+	backData := make([]byte, 10) // 8 bytes version and 2 bytes CRC
+	if err := d.communicateWithPICC(commandRegTransceive, command, backData, 0, true); err != nil {
+		return nil, err
+	}
+	return &TagVersion{Vendor: backData[1], ProductType: backData[2], Subtype: backData[3], Major: backData[4],
+		Minor: backData[5], StorageSize: backData[6], Protocol: backData[7]}, nil
+}
+
+// ReadNDEF reads the NDEF message of a selected MIFARE Ultralight or NTAG card.
+func (d *MFRC522Common) ReadNDEF() ([]NDEFRecord, error) {
+	size, err := d.ndefDataAreaSize()
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	for page := ndefFirstPage; len(data) < size && page < 256; page += 4 {
+		pages, err := d.piccRead(uint8(page))
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, pages...)
+		if msg, complete, err := findNDEFMessage(data); complete {
+			if err != nil {
+				return nil, err
+			}
+			return DecodeNDEFMessage(msg)
+		}
+	}
+	return nil, fmt.Errorf("no NDEF message found")
+}
+
+// WriteNDEF writes the records as NDEF message to a selected MIFARE Ultralight or NTAG card. The card must be
+// formatted for NDEF by the capability container.
+func (d *MFRC522Common) WriteNDEF(records ...NDEFRecord) error {
+	size, err := d.ndefDataAreaSize()
+	if err != nil {
+		return err
+	}
+	msg, err := EncodeNDEFMessage(records...)
+	if err != nil {
+		return err
+	}
+	data := wrapNDEFMessage(msg)
+	if len(data) > size {
+		return fmt.Errorf("NDEF message with %d bytes is too long for data area of %d bytes", len(data), size)
+	}
+	for len(data)%4 != 0 {
+		data = append(data, 0x00)
+	}
+	for i := 0; i < len(data); i += 4 {
+		if err := d.WritePage(uint8(ndefFirstPage+i/4), data[i:i+4]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ndefDataAreaSize reads the capability container (page 3) and returns the size of the data area
+func (d *MFRC522Common) ndefDataAreaSize() (int, error) {
+	pages, err := d.piccRead(ndefCapabilityContainerPage)
+	if err != nil {
+		return 0, err
+	}
+	if pages[0] != ndefMagicNumber {
+		return 0, fmt.Errorf("card is not formatted for NDEF, capability container: %X", pages[:4])
+	}
+	return int(pages[2]) * 8, nil
+}
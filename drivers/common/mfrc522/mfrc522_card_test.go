@@ -0,0 +1,187 @@
+package mfrc522
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ CardReader = (*MFRC522Common)(nil)
+
+func TestCardType(t *testing.T) {
+	var tests = map[string]struct {
+		sak         uint8
+		wantType    CardType
+		wantSectors int
+	}{
+		"mini":       {sak: 0x09, wantType: CardMifareMini, wantSectors: 5},
+		"classic1k":  {sak: 0x08, wantType: CardMifare1K, wantSectors: 16},
+		"classic4k":  {sak: 0x18, wantType: CardMifare4K, wantSectors: 40},
+		"ultralight": {sak: 0x00, wantType: CardMifareUltralight},
+		"plus":       {sak: 0x11, wantType: CardMifarePlus},
+		"desfire":    {sak: 0x20, wantType: CardISO14443_4},
+		"unknown":    {sak: 0x44, wantType: CardUnknown},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c := &Card{SAK: tc.sak}
+			// act & assert
+			gobottest.Assert(t, c.Type(), tc.wantType)
+			gobottest.Assert(t, c.Sectors(), tc.wantSectors)
+		})
+	}
+}
+
+func TestCardUIDString(t *testing.T) {
+	c := &Card{UID: []byte{0x04, 0xa1, 0xb2, 0xc3, 0xd4, 0xe5, 0x80}}
+	gobottest.Assert(t, c.UIDString(), "04A1B2C3D4E580")
+	gobottest.Assert(t, c.Type().String(), "MIFARE Ultralight or NTAG")
+}
+
+func TestSectorGeometry(t *testing.T) {
+	gobottest.Assert(t, SectorFirstBlock(1), uint8(4))
+	gobottest.Assert(t, SectorTrailerBlock(1), uint8(7))
+	gobottest.Assert(t, SectorFirstBlock(31), uint8(124))
+	gobottest.Assert(t, SectorTrailerBlock(31), uint8(127))
+	gobottest.Assert(t, SectorFirstBlock(32), uint8(128))
+	gobottest.Assert(t, SectorTrailerBlock(32), uint8(143))
+	gobottest.Assert(t, SectorTrailerBlock(39), uint8(255))
+	gobottest.Assert(t, BlockSector(6), uint8(1))
+	gobottest.Assert(t, BlockSector(143), uint8(32))
+	gobottest.Assert(t, BlockSector(144), uint8(33))
+	gobottest.Assert(t, IsTrailerBlock(11), true)
+	gobottest.Assert(t, IsTrailerBlock(12), false)
+	gobottest.Assert(t, IsTrailerBlock(143), true)
+	gobottest.Assert(t, IsTrailerBlock(131), false)
+}
+
+func TestAccessBits(t *testing.T) {
+	var tests = map[string]struct {
+		access AccessBits
+		want   [3]byte
+	}{
+		"transport": {access: TransportAccessBits, want: [3]byte{0xFF, 0x07, 0x80}},
+		"read_only_key_a_b": {
+			access: AccessBits{2, 2, 2, 3},
+			want:   [3]byte{0x0F, 0x07, 0x8F},
+		},
+		"value_blocks": {
+			access: AccessBits{6, 6, 0, 3},
+			want:   [3]byte{0x4C, 0x37, 0x8B},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := tc.access.Encode()
+			decoded, err := DecodeAccessBits(got)
+			// assert
+			gobottest.Assert(t, got, tc.want)
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, decoded, tc.access)
+		})
+	}
+}
+
+func TestDecodeAccessBitsInconsistent(t *testing.T) {
+	_, err := DecodeAccessBits([3]byte{0xFF, 0x07, 0x81})
+	gobottest.Assert(t, err.Error(), "access bits FF0781 are inconsistent")
+}
+
+func TestSectorTrailer(t *testing.T) {
+	// arrange
+	trailer := SectorTrailer{
+		KeyA:     Key{0xA0, 0xA1, 0xA2, 0xA3, 0xA4, 0xA5},
+		Access:   TransportAccessBits,
+		UserData: 0x69,
+		KeyB:     DefaultKey,
+	}
+	want := []byte{0xA0, 0xA1, 0xA2, 0xA3, 0xA4, 0xA5, 0xFF, 0x07, 0x80, 0x69, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	// act
+	got := trailer.Bytes()
+	parsed, err := ParseSectorTrailer(got)
+	// assert
+	gobottest.Assert(t, got, want)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, parsed, trailer)
+	_, err = ParseSectorTrailer(got[:15])
+	gobottest.Assert(t, err.Error(), "sector trailer needs 16 bytes, but has 15")
+}
+
+func TestValueBlock(t *testing.T) {
+	// arrange
+	want := []byte{0x9C, 0xFF, 0xFF, 0xFF, 0x63, 0x00, 0x00, 0x00, 0x9C, 0xFF, 0xFF, 0xFF, 0x05, 0xFA, 0x05, 0xFA}
+	// act
+	got := EncodeValueBlock(-100, 5)
+	value, address, err := DecodeValueBlock(got)
+	// assert
+	gobottest.Assert(t, got, want)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, value, int32(-100))
+	gobottest.Assert(t, address, uint8(5))
+	got[4] = 0x00
+	_, _, err = DecodeValueBlock(got)
+	gobottest.Refute(t, err, nil)
+}
+
+func Test_piccUIDPart(t *testing.T) {
+	var tests = map[string]struct {
+		backData []byte
+		want     []byte
+		wantErr  string
+	}{
+		"complete": {
+			backData: []byte{0x11, 0x22, 0x33, 0x44, 0x44},
+			want:     []byte{0x11, 0x22, 0x33, 0x44},
+		},
+		"cascade_tag": {
+			backData: []byte{0x88, 0x04, 0x33, 0x44, 0xFB},
+			want:     []byte{0x04, 0x33, 0x44},
+		},
+		"bcc_mismatch": {
+			backData: []byte{0x11, 0x22, 0x33, 0x44, 0x00},
+			wantErr:  "BCC mismatch, expected 44 actual 00",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := piccUIDPart(tc.backData)
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+				gobottest.Assert(t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	// arrange
+	d, c := initTestMFRC522CommonWithStubbedConnector()
+	// read: simulate idle interrupt, bit framing register, no error
+	c.simRead = []byte{0x10, 0x00, 0x00}
+	card := &Card{UID: []byte{0x04, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66}, SAK: 0x08}
+	key := Key{0xA0, 0xA1, 0xA2, 0xA3, 0xA4, 0xA5}
+	// act
+	err := d.Authenticate(card, 6, KeyB, key)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.fifoWritten, []byte{0x61, 0x06, 0xA0, 0xA1, 0xA2, 0xA3, 0xA4, 0xA5, 0x33, 0x44, 0x55, 0x66})
+}
+
+func TestWritePageWrongSize(t *testing.T) {
+	d, _ := initTestMFRC522CommonWithStubbedConnector()
+	err := d.WritePage(4, []byte{1, 2, 3})
+	gobottest.Assert(t, err.Error(), "the page to write needs to be exactly 4 bytes long, but has 3 bytes")
+}
+
+func TestTagVersionName(t *testing.T) {
+	gobottest.Assert(t, (&TagVersion{ProductType: 0x04, StorageSize: 0x11}).Name(), "NTAG215")
+	gobottest.Assert(t, (&TagVersion{ProductType: 0x04, StorageSize: 0x0E}).Name(), "NTAG")
+	gobottest.Assert(t, (&TagVersion{ProductType: 0x03, StorageSize: 0x0B}).Name(), "MIFARE Ultralight EV1")
+	gobottest.Assert(t, (&TagVersion{}).Name(), "unknown")
+}
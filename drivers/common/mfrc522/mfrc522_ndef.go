@@ -0,0 +1,264 @@
+package mfrc522
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// NDEF (NFC Data Exchange Format) on NFC Forum Type 2 tags like MIFARE Ultralight and NTAG21x
+// see https://nfc-forum.org/ and https://www.nxp.com/docs/en/application-note/AN1305.pdf
+const (
+	ndefCapabilityContainerPage = 3
+	ndefFirstPage               = 4
+	ndefMagicNumber             = 0xE1
+
+	ndefTLVNull       = 0x00
+	ndefTLVMessage    = 0x03
+	ndefTLVTerminator = 0xFE
+
+	ndefFlagMB = 0x80 // message begin
+	ndefFlagME = 0x40 // message end
+	ndefFlagCF = 0x20 // chunk flag
+	ndefFlagSR = 0x10 // short record
+	ndefFlagIL = 0x08 // ID length present
+)
+
+// NDEFTypeNameFormat describes the structure of the type of a NDEF record
+type NDEFTypeNameFormat uint8
+
+const (
+	// NDEFEmpty is used for empty records
+	NDEFEmpty NDEFTypeNameFormat = 0x00
+	// NDEFWellKnown is used for NFC Forum well-known types, e.g. "T" for text or "U" for URI
+	NDEFWellKnown NDEFTypeNameFormat = 0x01
+	// NDEFMedia is used for media types according to RFC 2046, e.g. "text/plain"
+	NDEFMedia NDEFTypeNameFormat = 0x02
+	// NDEFAbsoluteURI is used for types as absolute URI according to RFC 3986
+	NDEFAbsoluteURI NDEFTypeNameFormat = 0x03
+	// NDEFExternal is used for NFC Forum external types, e.g. "android.com:pkg"
+	NDEFExternal NDEFTypeNameFormat = 0x04
+	// NDEFUnknown is used for records with an unknown type
+	NDEFUnknown NDEFTypeNameFormat = 0x05
+)
+
+// NDEFRecord is one record of a NDEF message
+type NDEFRecord struct {
+	TNF     NDEFTypeNameFormat
+	Type    []byte
+	ID      []byte
+	Payload []byte
+}
+
+// URI identifier codes of the URI record type definition
+var ndefURIPrefixes = []string{"", "http://www.", "https://www.", "http://", "https://", "tel:", "mailto:",
+	"ftp://anonymous:anonymous@", "ftp://ftp.", "ftps://", "sftp://", "smb://", "nfs://", "ftp://", "dav://", "news:",
+	"telnet://", "imap:", "rtsp://", "urn:", "pop:", "sip:", "sips:", "tftp:", "btspp://", "btl2cap://", "btgoep://",
+	"tcpobex://", "irdaobex://", "file://", "urn:epc:id:", "urn:epc:tag:", "urn:epc:pat:", "urn:epc:raw:",
+	"urn:epc:", "urn:nfc:"}
+
+// NewTextRecord creates a well-known text record with the given language code (e.g. "en") and UTF-8 text.
+func NewTextRecord(language string, text string) NDEFRecord {
+	payload := []byte{byte(len(language) & 0x3F)}
+	payload = append(payload, language...)
+	payload = append(payload, text...)
+	return NDEFRecord{TNF: NDEFWellKnown, Type: []byte("T"), Payload: payload}
+}
+
+// NewURIRecord creates a well-known URI record, the longest known prefix is abbreviated.
+func NewURIRecord(uri string) NDEFRecord {
+	code := 0
+	for i, prefix := range ndefURIPrefixes {
+		if strings.HasPrefix(uri, prefix) && len(prefix) > len(ndefURIPrefixes[code]) {
+			code = i
+		}
+	}
+	payload := append([]byte{byte(code)}, uri[len(ndefURIPrefixes[code]):]...)
+	return NDEFRecord{TNF: NDEFWellKnown, Type: []byte("U"), Payload: payload}
+}
+
+// NewMediaRecord creates a record with the given media type, e.g. "application/json".
+func NewMediaRecord(mediaType string, payload []byte) NDEFRecord {
+	return NDEFRecord{TNF: NDEFMedia, Type: []byte(mediaType), Payload: payload}
+}
+
+// Text returns the language and text of a well-known text record.
+func (r NDEFRecord) Text() (string, string, error) {
+	if r.TNF != NDEFWellKnown || string(r.Type) != "T" {
+		return "", "", fmt.Errorf("record is not a text record")
+	}
+	if len(r.Payload) < 1 {
+		return "", "", fmt.Errorf("text record has no payload")
+	}
+	if r.Payload[0]&0x80 != 0 {
+		return "", "", fmt.Errorf("UTF-16 text records are not supported")
+	}
+	langLen := int(r.Payload[0] & 0x3F)
+	if len(r.Payload) < 1+langLen {
+		return "", "", fmt.Errorf("text record with language length %d is too short", langLen)
+	}
+	return string(r.Payload[1 : 1+langLen]), string(r.Payload[1+langLen:]), nil
+}
+
+// URI returns the URI of a well-known URI record.
+func (r NDEFRecord) URI() (string, error) {
+	if r.TNF != NDEFWellKnown || string(r.Type) != "U" {
+		return "", fmt.Errorf("record is not an URI record")
+	}
+	if len(r.Payload) < 1 {
+		return "", fmt.Errorf("URI record has no payload")
+	}
+	if int(r.Payload[0]) >= len(ndefURIPrefixes) {
+		return "", fmt.Errorf("unknown URI identifier code 0x%02X", r.Payload[0])
+	}
+	return ndefURIPrefixes[r.Payload[0]] + string(r.Payload[1:]), nil
+}
+
+// EncodeNDEFMessage creates a NDEF message from the given records.
+func EncodeNDEFMessage(records ...NDEFRecord) ([]byte, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("a NDEF message needs at least one record")
+	}
+	var msg []byte
+	for i, r := range records {
+		if r.TNF > NDEFUnknown {
+			return nil, fmt.Errorf("unsupported type name format %d", r.TNF)
+		}
+		if len(r.Type) > 255 || len(r.ID) > 255 {
+			return nil, fmt.Errorf("type or ID of record %d is too long", i)
+		}
+		header := uint8(r.TNF)
+		if i == 0 {
+			header |= ndefFlagMB
+		}
+		if i == len(records)-1 {
+			header |= ndefFlagME
+		}
+		if len(r.Payload) < 256 {
+			header |= ndefFlagSR
+		}
+		if len(r.ID) > 0 {
+			header |= ndefFlagIL
+		}
+		msg = append(msg, header, byte(len(r.Type)))
+		if header&ndefFlagSR != 0 {
+			msg = append(msg, byte(len(r.Payload)))
+		} else {
+			msg = append(msg, 0, 0, 0, 0)
+			binary.BigEndian.PutUint32(msg[len(msg)-4:], uint32(len(r.Payload)))
+		}
+		if len(r.ID) > 0 {
+			msg = append(msg, byte(len(r.ID)))
+		}
+		msg = append(msg, r.Type...)
+		msg = append(msg, r.ID...)
+		msg = append(msg, r.Payload...)
+	}
+	return msg, nil
+}
+
+// DecodeNDEFMessage returns the records of a NDEF message. Chunked records are not supported.
+func DecodeNDEFMessage(msg []byte) ([]NDEFRecord, error) {
+	var records []NDEFRecord
+	pos := 0
+	for pos < len(msg) {
+		header := msg[pos]
+		if header&ndefFlagCF != 0 {
+			return nil, fmt.Errorf("chunked records are not supported")
+		}
+		if len(records) == 0 && header&ndefFlagMB == 0 {
+			return nil, fmt.Errorf("message begin flag is missing")
+		}
+		pos++
+		lengths := 2 // type length and short payload length
+		if header&ndefFlagSR == 0 {
+			lengths += 3
+		}
+		if header&ndefFlagIL != 0 {
+			lengths++
+		}
+		if pos+lengths > len(msg) {
+			return nil, fmt.Errorf("record %d is truncated", len(records))
+		}
+		typeLen := int(msg[pos])
+		pos++
+		var payloadLen int
+		if header&ndefFlagSR != 0 {
+			payloadLen = int(msg[pos])
+			pos++
+		} else {
+			payloadLen = int(binary.BigEndian.Uint32(msg[pos:]))
+			pos += 4
+		}
+		idLen := 0
+		if header&ndefFlagIL != 0 {
+			idLen = int(msg[pos])
+			pos++
+		}
+		if payloadLen < 0 || pos+typeLen+idLen+payloadLen > len(msg) {
+			return nil, fmt.Errorf("record %d is truncated", len(records))
+		}
+		r := NDEFRecord{TNF: NDEFTypeNameFormat(header & 0x07)}
+		r.Type = append([]byte{}, msg[pos:pos+typeLen]...)
+		pos += typeLen
+		if idLen > 0 {
+			r.ID = append([]byte{}, msg[pos:pos+idLen]...)
+			pos += idLen
+		}
+		r.Payload = append([]byte{}, msg[pos:pos+payloadLen]...)
+		pos += payloadLen
+		records = append(records, r)
+		if header&ndefFlagME != 0 {
+			return records, nil
+		}
+	}
+	return nil, fmt.Errorf("message end flag is missing")
+}
+
+// wrapNDEFMessage creates the NDEF message TLV followed by a terminator TLV
+func wrapNDEFMessage(msg []byte) []byte {
+	data := []byte{ndefTLVMessage}
+	if len(msg) < 0xFF {
+		data = append(data, byte(len(msg)))
+	} else {
+		data = append(data, 0xFF, byte(len(msg)>>8), byte(len(msg)))
+	}
+	data = append(data, msg...)
+	return append(data, ndefTLVTerminator)
+}
+
+// findNDEFMessage searches the NDEF message TLV in the data area, "complete" is false if more data is needed
+func findNDEFMessage(data []byte) (msg []byte, complete bool, err error) {
+	pos := 0
+	for pos < len(data) {
+		tag := data[pos]
+		switch tag {
+		case ndefTLVNull:
+			pos++
+			continue
+		case ndefTLVTerminator:
+			return nil, true, fmt.Errorf("no NDEF message found")
+		}
+		if pos+1 >= len(data) {
+			return nil, false, nil
+		}
+		length := int(data[pos+1])
+		start := pos + 2
+		if length == 0xFF {
+			if pos+3 >= len(data) {
+				return nil, false, nil
+			}
+			length = int(data[pos+2])<<8 | int(data[pos+3])
+			start = pos + 4
+		}
+		if start+length > len(data) {
+			return nil, false, nil
+		}
+		if tag == ndefTLVMessage {
+			return data[start : start+length], true, nil
+		}
+		// skip other TLVs, e.g. lock control and memory control
+		pos = start + length
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,163 @@
+package mfrc522
+
+import (
+	"bytes"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestNDEFTextRecord(t *testing.T) {
+	// arrange
+	want := []byte{0xD1, 0x01, 0x08, 'T', 0x02, 'e', 'n', 'H', 'e', 'l', 'l', 'o'}
+	// act
+	msg, err := EncodeNDEFMessage(NewTextRecord("en", "Hello"))
+	records, errDecode := DecodeNDEFMessage(msg)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, msg, want)
+	gobottest.Assert(t, errDecode, nil)
+	gobottest.Assert(t, len(records), 1)
+	lang, text, err := records[0].Text()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, lang, "en")
+	gobottest.Assert(t, text, "Hello")
+	_, err = records[0].URI()
+	gobottest.Assert(t, err.Error(), "record is not an URI record")
+}
+
+func TestNDEFURIRecord(t *testing.T) {
+	var tests = map[string]struct {
+		uri      string
+		wantCode byte
+	}{
+		"https":     {uri: "https://gobot.io", wantCode: 0x04},
+		"https_www": {uri: "https://www.gobot.io", wantCode: 0x02},
+		"tel":       {uri: "tel:+49123", wantCode: 0x05},
+		"unknown":   {uri: "gobot://robot", wantCode: 0x00},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			r := NewURIRecord(tc.uri)
+			got, err := r.URI()
+			// assert
+			gobottest.Assert(t, r.Payload[0], tc.wantCode)
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got, tc.uri)
+		})
+	}
+}
+
+func TestNDEFMessageMultipleRecords(t *testing.T) {
+	// arrange
+	long := bytes.Repeat([]byte{0x42}, 300)
+	records := []NDEFRecord{
+		NewURIRecord("https://gobot.io"),
+		{TNF: NDEFExternal, Type: []byte("gobot.io:test"), ID: []byte("id1"), Payload: []byte{1, 2, 3}},
+		NewMediaRecord("application/octet-stream", long),
+	}
+	// act
+	msg, err := EncodeNDEFMessage(records...)
+	got, errDecode := DecodeNDEFMessage(msg)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, msg[0], uint8(0x91))                                // MB, SR, well known
+	gobottest.Assert(t, msg[13], uint8(0x1C))                               // SR, IL, external
+	gobottest.Assert(t, msg[36]&(ndefFlagME|ndefFlagSR), uint8(ndefFlagME)) // ME, long record
+	gobottest.Assert(t, errDecode, nil)
+	gobottest.Assert(t, got, records)
+}
+
+func TestDecodeNDEFMessageErrors(t *testing.T) {
+	var tests = map[string]struct {
+		msg     []byte
+		wantErr string
+	}{
+		"no_message_begin": {
+			msg:     []byte{0x51, 0x01, 0x00, 'T'},
+			wantErr: "message begin flag is missing",
+		},
+		"no_message_end": {
+			msg:     []byte{0x91, 0x01, 0x00, 'T'},
+			wantErr: "message end flag is missing",
+		},
+		"truncated": {
+			msg:     []byte{0xD1, 0x01, 0x08, 'T', 0x02},
+			wantErr: "record 0 is truncated",
+		},
+		"chunked": {
+			msg:     []byte{0xF1, 0x01, 0x00, 'T'},
+			wantErr: "chunked records are not supported",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			_, err := DecodeNDEFMessage(tc.msg)
+			// assert
+			gobottest.Assert(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestEncodeNDEFMessageErrors(t *testing.T) {
+	_, err := EncodeNDEFMessage()
+	gobottest.Assert(t, err.Error(), "a NDEF message needs at least one record")
+	_, err = EncodeNDEFMessage(NDEFRecord{TNF: 7})
+	gobottest.Assert(t, err.Error(), "unsupported type name format 7")
+}
+
+func Test_wrapNDEFMessage(t *testing.T) {
+	gobottest.Assert(t, wrapNDEFMessage([]byte{0xD1, 0x01}), []byte{0x03, 0x02, 0xD1, 0x01, 0xFE})
+	long := wrapNDEFMessage(make([]byte, 0x123))
+	gobottest.Assert(t, long[:4], []byte{0x03, 0xFF, 0x01, 0x23})
+	gobottest.Assert(t, len(long), 0x123+5)
+}
+
+func Test_findNDEFMessage(t *testing.T) {
+	var tests = map[string]struct {
+		data         []byte
+		wantMsg      []byte
+		wantComplete bool
+		wantErr      string
+	}{
+		"message": {
+			data:         []byte{0x03, 0x02, 0xD1, 0x01, 0xFE, 0x00},
+			wantMsg:      []byte{0xD1, 0x01},
+			wantComplete: true,
+		},
+		"skip_null_and_lock_control": {
+			data:         []byte{0x00, 0x01, 0x03, 0xA0, 0x0C, 0x34, 0x03, 0x01, 0xD1, 0xFE},
+			wantMsg:      []byte{0xD1},
+			wantComplete: true,
+		},
+		"long_format": {
+			data:         append([]byte{0x03, 0xFF, 0x00, 0x02, 0xD1, 0x01}, 0xFE),
+			wantMsg:      []byte{0xD1, 0x01},
+			wantComplete: true,
+		},
+		"incomplete": {
+			data: []byte{0x03, 0x10, 0xD1, 0x01},
+		},
+		"terminator_only": {
+			data:         []byte{0xFE, 0x00},
+			wantComplete: true,
+			wantErr:      "no NDEF message found",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			msg, complete, err := findNDEFMessage(tc.data)
+			// assert
+			gobottest.Assert(t, complete, tc.wantComplete)
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+				gobottest.Assert(t, msg, tc.wantMsg)
+			}
+		})
+	}
+}
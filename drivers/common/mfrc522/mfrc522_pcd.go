@@ -2,6 +2,7 @@ package mfrc522
 
 import (
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -13,6 +14,13 @@ const (
 	initTime = 50 * time.Millisecond
 	// at least 5 ms are needed after switch on, see AN10834
 	antennaOnTime = 10 * time.Millisecond
+	// tries to wait for the end of communication with the PICC
+	communicateMaxTries = 5
+)
+
+var (
+	errTimerInterrupt = fmt.Errorf("the timer interrupt occurred")
+	errNoData         = fmt.Errorf("no data available after %d tries", communicateMaxTries)
 )
 
 type busConnection interface {
@@ -35,6 +43,7 @@ var versions = map[uint8]string{0x12: "Counterfeit", 0x88: "FM17522", 0x89: "FM1
 type MFRC522Common struct {
 	connection      busConnection
 	firstCardAccess bool
+	mutex           sync.Mutex
 }
 
 // NewMFRC522Common creates a new Gobot Driver for MFRC522 RFID with specified bus connection
@@ -197,9 +206,8 @@ func (d *MFRC522Common) communicateWithPICC(command uint8, sendData []byte, back
 
 	// Wait for the command to complete. On initialization the TAuto flag in TMode register is set. This means the timer
 	// automatically starts when the PCD stops transmitting.
-	const maxTries = 5
 	i := 0
-	for ; i < maxTries; i++ {
+	for ; i < communicateMaxTries; i++ {
 		irqs, err := d.readByteData(regComIrq)
 		if err != nil {
 			return err
@@ -209,7 +217,7 @@ func (d *MFRC522Common) communicateWithPICC(command uint8, sendData []byte, back
 			break
 		}
 		if irqs&comIrqRegTimerIRqBit == comIrqRegTimerIRqBit {
-			return fmt.Errorf("the timer interrupt occurred")
+			return errTimerInterrupt
 		}
 		time.Sleep(time.Millisecond)
 	}
@@ -218,8 +226,8 @@ func (d *MFRC522Common) communicateWithPICC(command uint8, sendData []byte, back
 		return err
 	}
 
-	if i >= maxTries {
-		return fmt.Errorf("no data available after %d tries", maxTries)
+	if i >= communicateMaxTries {
+		return errNoData
 	}
 
 	errorRegValue, err := d.readByteData(regError)
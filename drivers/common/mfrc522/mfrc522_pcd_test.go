@@ -7,11 +7,12 @@ import (
 )
 
 type busConnMock struct {
-	written []byte
-	readIdx int
-	simRead []byte
-	fifoIdx int
-	simFifo []byte
+	written     []byte
+	readIdx     int
+	simRead     []byte
+	fifoIdx     int
+	simFifo     []byte
+	fifoWritten []byte
 }
 
 func (c *busConnMock) ReadByteData(reg uint8) (uint8, error) {
@@ -35,6 +36,9 @@ func (c *busConnMock) ReadByteData(reg uint8) (uint8, error) {
 func (c *busConnMock) WriteByteData(reg uint8, data byte) error {
 	c.written = append(c.written, reg)
 	c.written = append(c.written, data)
+	if reg == regFIFOData {
+		c.fifoWritten = append(c.fifoWritten, data)
+	}
 	return nil
 }
 
@@ -37,6 +37,8 @@ const (
 	// The commands used for MIFARE Ultralight (from http://www.nxp.com/documents/dataRegsheet/MF0ICU1.pdf, Section 8.6)
 	// The piccCommandMFRegREAD and piccCommandMFRegWRITE can also be used for MIFARE Ultralight.
 	piccCommandULRegWRITE = 0xA2 // Writes one 4 byte page to the PICC.
+	// Reads the version of NTAG21x and MIFARE Ultralight EV1 (from https://www.nxp.com/docs/en/data-sheet/NTAG213_215_216.pdf, Section 10.1)
+	piccCommandGetVersion = 0x60
 )
 
 const piccReadWriteAuthBlock = uint8(11)
@@ -85,7 +87,7 @@ func (d *MFRC522Common) ReadText() (string, error) {
 		return "", err
 	}
 
-	uid, err := d.piccActivate()
+	uid, _, err := d.piccActivate()
 	if err != nil {
 		return "", err
 	}
@@ -130,7 +132,7 @@ func (d *MFRC522Common) WriteText(text string) error {
 		return err
 	}
 
-	uid, err := d.piccActivate()
+	uid, _, err := d.piccActivate()
 	if err != nil {
 		return err
 	}
@@ -255,13 +257,22 @@ func (d *MFRC522Common) piccRead(block uint8) ([]byte, error) {
 }
 
 func (d *MFRC522Common) piccAuthenticate(address uint8, key []byte, uid []byte) error {
+	return d.piccAuthenticateWithKey(piccCommandMFRegAUTHRegKEYRegA, address, key, uid)
+}
+
+// the command selects key A or B, for UIDs with more than 4 bytes the last 4 bytes are used, see section 3.2.5
+// "MIFARE Classic Authentication" in https://www.nxp.com/docs/en/application-note/AN10927.pdf
+func (d *MFRC522Common) piccAuthenticateWithKey(command uint8, address uint8, key []byte, uid []byte) error {
 	if piccDebug {
 		fmt.Println("-authenticate-")
 	}
+	if len(uid) < 4 {
+		return fmt.Errorf("the UID needs at least 4 bytes, but has %d bytes", len(uid))
+	}
 
-	buf := []byte{piccCommandMFRegAUTHRegKEYRegA, address}
+	buf := []byte{command, address}
 	buf = append(buf, key...)
-	buf = append(buf, uid...)
+	buf = append(buf, uid[len(uid)-4:]...)
 
 	if err := d.communicateWithPICC(commandRegMFAuthent, buf, []byte{}, 0, false); err != nil {
 		return err
@@ -274,12 +285,12 @@ func (d *MFRC522Common) piccAuthenticate(address uint8, key []byte, uid []byte)
 // "Protocol Parameter Selection" (PPS).
 // see "Card Activation" in https://www.nxp.com/docs/en/application-note/AN10834.pdf.
 // note: the card needs to be in ready state, e.g. by a request or wake up is done before
-func (d *MFRC522Common) piccActivate() ([]byte, error) {
+func (d *MFRC522Common) piccActivate() ([]byte, uint8, error) {
 	if err := d.clearRegisterBitMask(regColl, collRegValuesAfterCollBit); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if err := d.writeByteData(regBitFraming, bitFramingRegReset); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// start cascade level 1 (0x93) for return:
@@ -298,7 +309,7 @@ func (d *MFRC522Common) piccActivate() ([]byte, error) {
 	var uid []byte
 	var sak uint8
 
-	for cascadeLevel := 1; cascadeLevel < 3; cascadeLevel++ {
+	for cascadeLevel := 1; cascadeLevel <= 3; cascadeLevel++ {
 		var piccCommand uint8
 		switch cascadeLevel {
 		case 1:
@@ -308,7 +319,7 @@ func (d *MFRC522Common) piccActivate() ([]byte, error) {
 		case 3:
 			piccCommand = piccCommandCascadeLevel3
 		default:
-			return nil, fmt.Errorf("unknown cascade level %d", cascadeLevel)
+			return nil, 0, fmt.Errorf("unknown cascade level %d", cascadeLevel)
 		}
 
 		if piccDebug {
@@ -320,30 +331,18 @@ func (d *MFRC522Common) piccActivate() ([]byte, error) {
 		sendForAnticol := []byte{piccCommand, numValidBits}
 		backData := []byte{0x00, 0x00, 0x00, 0x00, 0x00} // 4 bytes CT/UID and BCC
 		if err := d.communicateWithPICC(commandRegTransceive, sendForAnticol, backData, txLastBits, false); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		// TODO: no real anticollision check yet
 
-		// check BCC
-		bcc := byte(0)
-		for _, v := range backData[:4] {
-			bcc = bcc ^ v
-		}
-		if bcc != backData[4] {
-			return nil, fmt.Errorf(fmt.Sprintf("BCC mismatch, expected %02x actual %02x", bcc, backData[4]))
+		uidPart, err := piccUIDPart(backData)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		if backData[0] == piccCascadeTag {
-			uid = append(uid, backData[1:3]...)
-			if piccDebug {
-				fmt.Printf("next cascade is needed after SAK, uid: %v", uid)
-			}
-		} else {
-			uid = append(uid, backData[:4]...)
-			if piccDebug {
-				fmt.Printf("backData: %v, uid: %v\n", backData, uid)
-			}
+		uid = append(uid, uidPart...)
+		if piccDebug {
+			fmt.Printf("backData: %v, uid: %v\n", backData, uid)
 		}
 
 		if piccDebug {
@@ -354,21 +353,17 @@ func (d *MFRC522Common) piccActivate() ([]byte, error) {
 		sendCommand = append(sendCommand, backData...) // uid including BCC
 		crcResult := []byte{0x00, 0x00}
 		if err := d.calculateCRC(sendCommand, crcResult); err != nil {
-			return uid, err
+			return uid, 0, err
 		}
 		sendCommand = append(sendCommand, crcResult...)
 		sakData := []byte{0x00, 0x00, 0x00}
 		if err := d.communicateWithPICC(commandRegTransceive, sendCommand, sakData, txLastBits, false); err != nil {
-			return nil, err
-		}
-		bcc = byte(0)
-		for _, v := range sakData[:2] {
-			bcc = bcc ^ v
+			return nil, 0, err
 		}
 		if piccDebug {
 			fmt.Printf("sak data: %v\n", sakData)
 		}
-		if sakData[0] != piccUIDNotComplete {
+		if sakData[0]&piccUIDNotComplete == 0 {
 			sak = sakData[0]
 			break
 		}
@@ -381,7 +376,59 @@ func (d *MFRC522Common) piccActivate() ([]byte, error) {
 		d.firstCardAccess = false
 		fmt.Printf("card '%s' selected\n", piccCardFromSak[sak])
 	}
-	return uid, nil
+	return uid, sak, nil
+}
+
+// piccUIDPart checks the BCC of the anticollision answer and returns the contained part of the UID, which is 3
+// bytes after a cascade tag and 4 bytes otherwise
+func piccUIDPart(backData []byte) ([]byte, error) {
+	bcc := byte(0)
+	for _, v := range backData[:4] {
+		bcc = bcc ^ v
+	}
+	if bcc != backData[4] {
+		return nil, fmt.Errorf("BCC mismatch, expected %02x actual %02x", bcc, backData[4])
+	}
+	if backData[0] == piccCascadeTag {
+		return backData[1:4], nil
+	}
+	return backData[:4], nil
+}
+
+// piccCommandWithAck sends the command with CRC and checks the 4 bit ACK of the PICC
+func (d *MFRC522Common) piccCommandWithAck(command []byte) error {
+	crcResult := []byte{0x00, 0x00}
+	if err := d.calculateCRC(command, crcResult); err != nil {
+		return err
+	}
+	sendData := append(append([]byte{}, command...), crcResult...)
+	backData := make([]byte, 1)
+	if err := d.communicateWithPICC(commandRegTransceive, sendData, backData, 0, false); err != nil {
+		return err
+	}
+	if backData[0]&0x0F != piccWriteAck {
+		return fmt.Errorf("NAK 0x%X received for command 0x%02X", backData[0], command[0])
+	}
+	return nil
+}
+
+// piccValueOperation executes the MIFARE Classic two step commands for increment, decrement and restore, the PICC
+// does not answer on the second step
+func (d *MFRC522Common) piccValueOperation(command uint8, block uint8, value uint32) error {
+	if err := d.piccCommandWithAck([]byte{command, block}); err != nil {
+		return err
+	}
+	data := []byte{byte(value), byte(value >> 8), byte(value >> 16), byte(value >> 24)}
+	crcResult := []byte{0x00, 0x00}
+	if err := d.calculateCRC(data, crcResult); err != nil {
+		return err
+	}
+	data = append(data, crcResult...)
+	err := d.communicateWithPICC(commandRegTransceive, data, []byte{}, 0, false)
+	if err != nil && err != errTimerInterrupt && err != errNoData {
+		return err
+	}
+	return nil
 }
 
 func (d *MFRC522Common) piccRequest(reqMode uint8, answer []byte) error {
@@ -0,0 +1,138 @@
+package mfrc522
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+const (
+	// CardArrived is the event name, which is published with the *Card when a card comes into range
+	CardArrived = "card-arrived"
+	// CardRemoved is the event name, which is published with the *Card when a card leaves the range
+	CardRemoved = "card-removed"
+	// Error is the event name, which is published when the card handler fails
+	Error = "error"
+
+	// a card is treated as removed after this count of polls without answer, because single requests can fail
+	pollerMissesForRemoval = 2
+)
+
+// CardReader is the interface used by the Poller, implemented by MFRC522Common
+type CardReader interface {
+	SelectCard() (*Card, error)
+	HaltCard() error
+	Transaction(fn func() error) error
+}
+
+// Poller polls a reader for cards in range and publishes the events "card-arrived" and "card-removed".
+type Poller struct {
+	reader  CardReader
+	handler func(*Card) error
+	current *Card
+	misses  int
+	loop    *ticker.Loop
+	mutex   *sync.Mutex
+	gobot.Eventer
+}
+
+// NewPoller creates a new poller for the given card reader.
+func NewPoller(reader CardReader) *Poller {
+	p := &Poller{
+		reader:  reader,
+		loop:    ticker.NewLoop(),
+		mutex:   &sync.Mutex{},
+		Eventer: gobot.NewEventer(),
+	}
+	p.AddEvent(CardArrived)
+	p.AddEvent(CardRemoved)
+	p.AddEvent(Error)
+	return p
+}
+
+// SetCardHandler sets a function, which is called on arrival of a card while the card is still selected. Card
+// operations like reading and writing can be done in this function without interruption by polling. A returned
+// error is published by the event "error".
+func (p *Poller) SetCardHandler(handler func(*Card) error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.handler = handler
+}
+
+// Card returns the card in range, nil if there is none.
+func (p *Poller) Card() *Card {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.current
+}
+
+// Poll checks once for a card in range and publishes the events of a changed card.
+func (p *Poller) Poll() {
+	p.mutex.Lock()
+	handler := p.handler
+	current := p.current
+	p.mutex.Unlock()
+
+	var handlerErr error
+	var card *Card
+	_ = p.reader.Transaction(func() error {
+		var err error
+		if card, err = p.reader.SelectCard(); err != nil {
+			// no card in range or no answer
+			card = nil
+			return nil
+		}
+		if handler != nil && !sameCard(card, current) {
+			handlerErr = handler(card)
+		}
+		return p.reader.HaltCard()
+	})
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if card == nil {
+		if p.current != nil {
+			p.misses++
+			if p.misses >= pollerMissesForRemoval {
+				p.Publish(CardRemoved, p.current)
+				p.current = nil
+			}
+		}
+		return
+	}
+
+	p.misses = 0
+	if !sameCard(card, p.current) {
+		if p.current != nil {
+			p.Publish(CardRemoved, p.current)
+		}
+		p.current = card
+		p.Publish(CardArrived, card)
+	}
+	if handlerErr != nil {
+		p.Publish(Error, handlerErr)
+	}
+}
+
+// StartPolling starts to poll for cards with the given interval.
+func (p *Poller) StartPolling(interval time.Duration) {
+	p.loop.Start(interval, p.Poll)
+}
+
+// StopPolling stops the polling loop, a running poll is finished before return.
+func (p *Poller) StopPolling() {
+	p.loop.Stop()
+}
+
+func sameCard(a, b *Card) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return bytes.Equal(a.UID, b.UID)
+}
@@ -0,0 +1,129 @@
+package mfrc522
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// cardReaderMock returns the cards of the queue, nil means no card in range
+type cardReaderMock struct {
+	cards  []*Card
+	halted int
+	mutex  sync.Mutex
+}
+
+func (r *cardReaderMock) SelectCard() (*Card, error) {
+	if len(r.cards) == 0 {
+		return nil, errors.New("no card")
+	}
+	card := r.cards[0]
+	if len(r.cards) > 1 {
+		r.cards = r.cards[1:]
+	}
+	if card == nil {
+		return nil, errors.New("no card")
+	}
+	return card, nil
+}
+
+func (r *cardReaderMock) HaltCard() error {
+	r.halted++
+	return nil
+}
+
+func (r *cardReaderMock) Transaction(fn func() error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return fn()
+}
+
+func collectPollerEvents(p *Poller) func() []string {
+	var events []string
+	mutex := &sync.Mutex{}
+	for _, name := range []string{CardArrived, CardRemoved, Error} {
+		name := name
+		_ = p.On(name, func(data interface{}) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch v := data.(type) {
+			case *Card:
+				events = append(events, name+":"+v.UIDString())
+			case error:
+				events = append(events, name+":"+v.Error())
+			}
+		})
+	}
+	// the order of different events is not deterministic
+	return func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		sorted := append([]string{}, events...)
+		sort.Strings(sorted)
+		return sorted
+	}
+}
+
+func TestPollerPoll(t *testing.T) {
+	// arrange
+	card1 := &Card{UID: []byte{0x01, 0x02, 0x03, 0x04}}
+	card2 := &Card{UID: []byte{0x05, 0x06, 0x07, 0x08}}
+	// card1 arrives, one missing answer is ignored, card1 is replaced by card2, card2 is removed
+	r := &cardReaderMock{cards: []*Card{card1, nil, card1, card2, nil, nil}}
+	p := NewPoller(r)
+	events := collectPollerEvents(p)
+	var handled []string
+	p.SetCardHandler(func(c *Card) error {
+		handled = append(handled, c.UIDString())
+		return nil
+	})
+	// act
+	for i := 0; i < 6; i++ {
+		p.Poll()
+	}
+	time.Sleep(10 * time.Millisecond)
+	// assert
+	gobottest.Assert(t, events(), []string{"card-arrived:01020304", "card-arrived:05060708",
+		"card-removed:01020304", "card-removed:05060708"})
+	gobottest.Assert(t, handled, []string{"01020304", "05060708"})
+	gobottest.Assert(t, r.halted, 3)
+	gobottest.Assert(t, p.Card() == nil, true)
+}
+
+func TestPollerHandlerError(t *testing.T) {
+	// arrange
+	r := &cardReaderMock{cards: []*Card{{UID: []byte{0x11, 0x22, 0x33, 0x44}}}}
+	p := NewPoller(r)
+	events := collectPollerEvents(p)
+	p.SetCardHandler(func(c *Card) error { return errors.New("read failed") })
+	// act
+	p.Poll()
+	p.Poll()
+	time.Sleep(10 * time.Millisecond)
+	// assert
+	gobottest.Assert(t, events(), []string{"card-arrived:11223344", "error:read failed"})
+	gobottest.Assert(t, p.Card().UIDString(), "11223344")
+}
+
+func TestPollerStartStop(t *testing.T) {
+	// arrange
+	r := &cardReaderMock{cards: []*Card{{UID: []byte{0x11, 0x22, 0x33, 0x44}}}}
+	p := NewPoller(r)
+	arrived := make(chan bool, 1)
+	_ = p.Once(CardArrived, func(data interface{}) { arrived <- true })
+	// act
+	p.StartPolling(time.Millisecond)
+	defer p.StopPolling()
+	// assert
+	select {
+	case <-arrived:
+	case <-time.After(time.Second):
+		t.Errorf("card-arrived was not published")
+	}
+	p.StopPolling()
+	p.StopPolling()
+}
@@ -0,0 +1,8 @@
+/*
+Package mono provides a 1-bit framebuffer with drawing primitives and bitmap fonts, which is shared
+by the drivers of monochrome displays, like SSD1306 and MAX7219.
+
+The framebuffer implements draw.Image, so also the functions of the "image/draw" package can be used.
+Drivers transfer only the changed region to the display, see Framebuffer.Changed().
+*/
+package mono // import "gobot.io/x/gobot/drivers/common/mono"
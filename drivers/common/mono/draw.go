@@ -0,0 +1,91 @@
+package mono
+
+// Line draws a line from (x0, y0) to (x1, y1), both end points included (Bresenham's algorithm).
+func (f *Framebuffer) Line(x0, y0, x1, y1 int, on bool) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		f.SetPixel(x0, y0, on)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// Rect draws the outline of a rectangle with the upper left corner at (x, y).
+func (f *Framebuffer) Rect(x, y, w, h int, on bool) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	f.Line(x, y, x+w-1, y, on)
+	f.Line(x, y+h-1, x+w-1, y+h-1, on)
+	f.Line(x, y, x, y+h-1, on)
+	f.Line(x+w-1, y, x+w-1, y+h-1, on)
+}
+
+// FillRect draws a filled rectangle with the upper left corner at (x, y).
+func (f *Framebuffer) FillRect(x, y, w, h int, on bool) {
+	for j := y; j < y+h; j++ {
+		for i := x; i < x+w; i++ {
+			f.SetPixel(i, j, on)
+		}
+	}
+}
+
+// Circle draws the outline of a circle with center (x0, y0) and radius r (midpoint algorithm).
+func (f *Framebuffer) Circle(x0, y0, r int, on bool) {
+	x, y := r, 0
+	e := 1 - r
+	for x >= y {
+		f.SetPixel(x0+x, y0+y, on)
+		f.SetPixel(x0+y, y0+x, on)
+		f.SetPixel(x0-y, y0+x, on)
+		f.SetPixel(x0-x, y0+y, on)
+		f.SetPixel(x0-x, y0-y, on)
+		f.SetPixel(x0-y, y0-x, on)
+		f.SetPixel(x0+y, y0-x, on)
+		f.SetPixel(x0+x, y0-y, on)
+		y++
+		if e < 0 {
+			e += 2*y + 1
+		} else {
+			x--
+			e += 2*(y-x) + 1
+		}
+	}
+}
+
+// FillCircle draws a filled circle with center (x0, y0) and radius r.
+func (f *Framebuffer) FillCircle(x0, y0, r int, on bool) {
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			if x*x+y*y <= r*r+r {
+				f.SetPixel(x0+x, y0+y, on)
+			}
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
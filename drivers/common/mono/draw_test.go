@@ -0,0 +1,60 @@
+package mono
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func countPixels(f *Framebuffer) int {
+	count := 0
+	for _, on := range f.back {
+		if on {
+			count++
+		}
+	}
+	return count
+}
+
+func TestLine(t *testing.T) {
+	f := NewFramebuffer(8, 8)
+	f.Line(0, 0, 7, 7, true)
+	gobottest.Assert(t, countPixels(f), 8)
+	for i := 0; i < 8; i++ {
+		gobottest.Assert(t, f.Pixel(i, i), true)
+	}
+
+	f.Clear()
+	f.Line(6, 2, 1, 2, true)
+	gobottest.Assert(t, countPixels(f), 6)
+	gobottest.Assert(t, f.Pixel(1, 2), true)
+	gobottest.Assert(t, f.Pixel(6, 2), true)
+}
+
+func TestRect(t *testing.T) {
+	f := NewFramebuffer(8, 8)
+	f.Rect(1, 1, 4, 3, true)
+	gobottest.Assert(t, countPixels(f), 10)
+	gobottest.Assert(t, f.Pixel(2, 2), false)
+
+	f.FillRect(1, 1, 4, 3, true)
+	gobottest.Assert(t, countPixels(f), 12)
+
+	f.Clear()
+	f.Rect(0, 0, 0, 3, true)
+	gobottest.Assert(t, countPixels(f), 0)
+}
+
+func TestCircle(t *testing.T) {
+	f := NewFramebuffer(16, 16)
+	f.Circle(8, 8, 4, true)
+	gobottest.Assert(t, f.Pixel(12, 8), true)
+	gobottest.Assert(t, f.Pixel(4, 8), true)
+	gobottest.Assert(t, f.Pixel(8, 12), true)
+	gobottest.Assert(t, f.Pixel(8, 4), true)
+	gobottest.Assert(t, f.Pixel(8, 8), false)
+
+	f.FillCircle(8, 8, 4, true)
+	gobottest.Assert(t, f.Pixel(8, 8), true)
+	gobottest.Assert(t, f.Pixel(12, 12), false)
+}
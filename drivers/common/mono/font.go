@@ -0,0 +1,114 @@
+package mono
+
+import (
+	"image"
+	"strings"
+	"unicode"
+)
+
+// Font is a fixed width bitmap font. Each glyph is stored row by row, the most significant
+// of the used bits is the left column.
+type Font struct {
+	Width     int
+	Height    int
+	first     rune
+	upperOnly bool
+	glyphs    [][]byte
+}
+
+// Advance returns the horizontal space of one character, including one column spacing
+func (fnt *Font) Advance() int { return fnt.Width + 1 }
+
+// LineHeight returns the vertical space of one line, including one row spacing
+func (fnt *Font) LineHeight() int { return fnt.Height + 1 }
+
+// TextWidth returns the width in pixel of the given single line text, without the trailing spacing.
+func (fnt *Font) TextWidth(text string) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return n*fnt.Advance() - 1
+}
+
+// glyph returns the glyph for the rune, a question mark is used for unknown runes
+func (fnt *Font) glyph(r rune) []byte {
+	if fnt.upperOnly {
+		r = unicode.ToUpper(r)
+	}
+	i := int(r - fnt.first)
+	if i < 0 || i >= len(fnt.glyphs) {
+		i = int('?' - fnt.first)
+	}
+	return fnt.glyphs[i]
+}
+
+// DrawText draws a single line text with the upper left corner at (x, y) and returns the x position
+// for the next character.
+func (f *Framebuffer) DrawText(x, y int, text string, fnt *Font, on bool) int {
+	for _, r := range text {
+		glyph := fnt.glyph(r)
+		for row, bits := range glyph {
+			for col := 0; col < fnt.Width; col++ {
+				if bits&(1<<uint(fnt.Width-1-col)) != 0 {
+					f.SetPixel(x+col, y+row, on)
+				}
+			}
+		}
+		x += fnt.Advance()
+	}
+	return x
+}
+
+// DrawTextWrapped draws the text into the given rectangle, wrapped at word boundaries. Lines, which
+// do not fit into the rectangle, are skipped. The number of drawn lines is returned.
+func (f *Framebuffer) DrawTextWrapped(r image.Rectangle, text string, fnt *Font, on bool) int {
+	lines := WrapText(text, fnt, r.Dx())
+	y := r.Min.Y
+	count := 0
+	for _, line := range lines {
+		if y+fnt.Height > r.Max.Y {
+			break
+		}
+		f.DrawText(r.Min.X, y, line, fnt, on)
+		y += fnt.LineHeight()
+		count++
+	}
+	return count
+}
+
+// WrapText splits the text into lines, which fit into the given width in pixel. The text is split
+// at spaces and line feeds, words longer than a line are split hard.
+func WrapText(text string, fnt *Font, width int) []string {
+	maxChars := (width + 1) / fnt.Advance()
+	if maxChars < 1 {
+		maxChars = 1
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for len([]rune(word)) > maxChars {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				runes := []rune(word)
+				lines = append(lines, string(runes[:maxChars]))
+				word = string(runes[maxChars:])
+			}
+			switch {
+			case line == "":
+				line = word
+			case len([]rune(line))+1+len([]rune(word)) <= maxChars:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
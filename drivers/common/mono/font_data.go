@@ -0,0 +1,182 @@
+package mono
+
+// Font5x7 is a 5x7 pixel font with all printable ASCII characters.
+var Font5x7 = &Font{
+	Width:     5,
+	Height:    7,
+	first:     ' ',
+	upperOnly: false,
+	glyphs: [][]byte{
+		{0b00000, 0b00000, 0b00000, 0b00000, 0b00000, 0b00000, 0b00000}, // ' '
+		{0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00000, 0b00100}, // '!'
+		{0b01010, 0b01010, 0b01010, 0b00000, 0b00000, 0b00000, 0b00000}, // '"'
+		{0b01010, 0b01010, 0b11111, 0b01010, 0b11111, 0b01010, 0b01010}, // '#'
+		{0b00100, 0b01111, 0b10100, 0b01110, 0b00101, 0b11110, 0b00100}, // '$'
+		{0b11000, 0b11001, 0b00010, 0b00100, 0b01000, 0b10011, 0b00011}, // '%'
+		{0b01100, 0b10010, 0b10100, 0b01000, 0b10101, 0b10010, 0b01101}, // '&'
+		{0b01100, 0b00100, 0b01000, 0b00000, 0b00000, 0b00000, 0b00000}, // '\''
+		{0b00010, 0b00100, 0b01000, 0b01000, 0b01000, 0b00100, 0b00010}, // '('
+		{0b01000, 0b00100, 0b00010, 0b00010, 0b00010, 0b00100, 0b01000}, // ')'
+		{0b00000, 0b00100, 0b10101, 0b01110, 0b10101, 0b00100, 0b00000}, // '*'
+		{0b00000, 0b00100, 0b00100, 0b11111, 0b00100, 0b00100, 0b00000}, // '+'
+		{0b00000, 0b00000, 0b00000, 0b00000, 0b01100, 0b00100, 0b01000}, // ','
+		{0b00000, 0b00000, 0b00000, 0b11111, 0b00000, 0b00000, 0b00000}, // '-'
+		{0b00000, 0b00000, 0b00000, 0b00000, 0b00000, 0b01100, 0b01100}, // '.'
+		{0b00000, 0b00001, 0b00010, 0b00100, 0b01000, 0b10000, 0b00000}, // '/'
+		{0b01110, 0b10001, 0b10011, 0b10101, 0b11001, 0b10001, 0b01110}, // '0'
+		{0b00100, 0b01100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110}, // '1'
+		{0b01110, 0b10001, 0b00001, 0b00010, 0b00100, 0b01000, 0b11111}, // '2'
+		{0b11111, 0b00010, 0b00100, 0b00010, 0b00001, 0b10001, 0b01110}, // '3'
+		{0b00010, 0b00110, 0b01010, 0b10010, 0b11111, 0b00010, 0b00010}, // '4'
+		{0b11111, 0b10000, 0b11110, 0b00001, 0b00001, 0b10001, 0b01110}, // '5'
+		{0b00110, 0b01000, 0b10000, 0b11110, 0b10001, 0b10001, 0b01110}, // '6'
+		{0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b01000, 0b01000}, // '7'
+		{0b01110, 0b10001, 0b10001, 0b01110, 0b10001, 0b10001, 0b01110}, // '8'
+		{0b01110, 0b10001, 0b10001, 0b01111, 0b00001, 0b00010, 0b01100}, // '9'
+		{0b00000, 0b01100, 0b01100, 0b00000, 0b01100, 0b01100, 0b00000}, // ':'
+		{0b00000, 0b01100, 0b01100, 0b00000, 0b01100, 0b00100, 0b01000}, // ';'
+		{0b00010, 0b00100, 0b01000, 0b10000, 0b01000, 0b00100, 0b00010}, // '<'
+		{0b00000, 0b00000, 0b11111, 0b00000, 0b11111, 0b00000, 0b00000}, // '='
+		{0b01000, 0b00100, 0b00010, 0b00001, 0b00010, 0b00100, 0b01000}, // '>'
+		{0b01110, 0b10001, 0b00001, 0b00010, 0b00100, 0b00000, 0b00100}, // '?'
+		{0b01110, 0b10001, 0b00001, 0b01101, 0b10101, 0b10101, 0b01110}, // '@'
+		{0b01110, 0b10001, 0b10001, 0b11111, 0b10001, 0b10001, 0b10001}, // 'A'
+		{0b11110, 0b10001, 0b10001, 0b11110, 0b10001, 0b10001, 0b11110}, // 'B'
+		{0b01110, 0b10001, 0b10000, 0b10000, 0b10000, 0b10001, 0b01110}, // 'C'
+		{0b11100, 0b10010, 0b10001, 0b10001, 0b10001, 0b10010, 0b11100}, // 'D'
+		{0b11111, 0b10000, 0b10000, 0b11110, 0b10000, 0b10000, 0b11111}, // 'E'
+		{0b11111, 0b10000, 0b10000, 0b11110, 0b10000, 0b10000, 0b10000}, // 'F'
+		{0b01110, 0b10001, 0b10000, 0b10111, 0b10001, 0b10001, 0b01111}, // 'G'
+		{0b10001, 0b10001, 0b10001, 0b11111, 0b10001, 0b10001, 0b10001}, // 'H'
+		{0b01110, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110}, // 'I'
+		{0b00111, 0b00010, 0b00010, 0b00010, 0b00010, 0b10010, 0b01100}, // 'J'
+		{0b10001, 0b10010, 0b10100, 0b11000, 0b10100, 0b10010, 0b10001}, // 'K'
+		{0b10000, 0b10000, 0b10000, 0b10000, 0b10000, 0b10000, 0b11111}, // 'L'
+		{0b10001, 0b11011, 0b10101, 0b10101, 0b10001, 0b10001, 0b10001}, // 'M'
+		{0b10001, 0b10001, 0b11001, 0b10101, 0b10011, 0b10001, 0b10001}, // 'N'
+		{0b01110, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01110}, // 'O'
+		{0b11110, 0b10001, 0b10001, 0b11110, 0b10000, 0b10000, 0b10000}, // 'P'
+		{0b01110, 0b10001, 0b10001, 0b10001, 0b10101, 0b10010, 0b01101}, // 'Q'
+		{0b11110, 0b10001, 0b10001, 0b11110, 0b10100, 0b10010, 0b10001}, // 'R'
+		{0b01111, 0b10000, 0b10000, 0b01110, 0b00001, 0b00001, 0b11110}, // 'S'
+		{0b11111, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100}, // 'T'
+		{0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01110}, // 'U'
+		{0b10001, 0b10001, 0b10001, 0b10001, 0b10001, 0b01010, 0b00100}, // 'V'
+		{0b10001, 0b10001, 0b10001, 0b10101, 0b10101, 0b10101, 0b01010}, // 'W'
+		{0b10001, 0b10001, 0b01010, 0b00100, 0b01010, 0b10001, 0b10001}, // 'X'
+		{0b10001, 0b10001, 0b10001, 0b01010, 0b00100, 0b00100, 0b00100}, // 'Y'
+		{0b11111, 0b00001, 0b00010, 0b00100, 0b01000, 0b10000, 0b11111}, // 'Z'
+		{0b01110, 0b01000, 0b01000, 0b01000, 0b01000, 0b01000, 0b01110}, // '['
+		{0b00000, 0b10000, 0b01000, 0b00100, 0b00010, 0b00001, 0b00000}, // '\\'
+		{0b01110, 0b00010, 0b00010, 0b00010, 0b00010, 0b00010, 0b01110}, // ']'
+		{0b00100, 0b01010, 0b10001, 0b00000, 0b00000, 0b00000, 0b00000}, // '^'
+		{0b00000, 0b00000, 0b00000, 0b00000, 0b00000, 0b00000, 0b11111}, // '_'
+		{0b01000, 0b00100, 0b00010, 0b00000, 0b00000, 0b00000, 0b00000}, // '`'
+		{0b00000, 0b00000, 0b01110, 0b00001, 0b01111, 0b10001, 0b01111}, // 'a'
+		{0b10000, 0b10000, 0b10110, 0b11001, 0b10001, 0b10001, 0b11110}, // 'b'
+		{0b00000, 0b00000, 0b01110, 0b10000, 0b10000, 0b10001, 0b01110}, // 'c'
+		{0b00001, 0b00001, 0b01101, 0b10011, 0b10001, 0b10001, 0b01111}, // 'd'
+		{0b00000, 0b00000, 0b01110, 0b10001, 0b11111, 0b10000, 0b01110}, // 'e'
+		{0b00110, 0b01001, 0b01000, 0b11100, 0b01000, 0b01000, 0b01000}, // 'f'
+		{0b00000, 0b01111, 0b10001, 0b10001, 0b01111, 0b00001, 0b01110}, // 'g'
+		{0b10000, 0b10000, 0b10110, 0b11001, 0b10001, 0b10001, 0b10001}, // 'h'
+		{0b00100, 0b00000, 0b01100, 0b00100, 0b00100, 0b00100, 0b01110}, // 'i'
+		{0b00010, 0b00000, 0b00110, 0b00010, 0b00010, 0b10010, 0b01100}, // 'j'
+		{0b10000, 0b10000, 0b10010, 0b10100, 0b11000, 0b10100, 0b10010}, // 'k'
+		{0b01100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b01110}, // 'l'
+		{0b00000, 0b00000, 0b11010, 0b10101, 0b10101, 0b10001, 0b10001}, // 'm'
+		{0b00000, 0b00000, 0b10110, 0b11001, 0b10001, 0b10001, 0b10001}, // 'n'
+		{0b00000, 0b00000, 0b01110, 0b10001, 0b10001, 0b10001, 0b01110}, // 'o'
+		{0b00000, 0b00000, 0b11110, 0b10001, 0b11110, 0b10000, 0b10000}, // 'p'
+		{0b00000, 0b00000, 0b01101, 0b10011, 0b01111, 0b00001, 0b00001}, // 'q'
+		{0b00000, 0b00000, 0b10110, 0b11001, 0b10000, 0b10000, 0b10000}, // 'r'
+		{0b00000, 0b00000, 0b01110, 0b10000, 0b01110, 0b00001, 0b11110}, // 's'
+		{0b01000, 0b01000, 0b11100, 0b01000, 0b01000, 0b01001, 0b00110}, // 't'
+		{0b00000, 0b00000, 0b10001, 0b10001, 0b10001, 0b10011, 0b01101}, // 'u'
+		{0b00000, 0b00000, 0b10001, 0b10001, 0b10001, 0b01010, 0b00100}, // 'v'
+		{0b00000, 0b00000, 0b10001, 0b10001, 0b10101, 0b10101, 0b01010}, // 'w'
+		{0b00000, 0b00000, 0b10001, 0b01010, 0b00100, 0b01010, 0b10001}, // 'x'
+		{0b00000, 0b00000, 0b10001, 0b10001, 0b01111, 0b00001, 0b01110}, // 'y'
+		{0b00000, 0b00000, 0b11111, 0b00010, 0b00100, 0b01000, 0b11111}, // 'z'
+		{0b00010, 0b00100, 0b00100, 0b01000, 0b00100, 0b00100, 0b00010}, // '{'
+		{0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100, 0b00100}, // '|'
+		{0b01000, 0b00100, 0b00100, 0b00010, 0b00100, 0b00100, 0b01000}, // '}'
+		{0b00000, 0b00000, 0b01000, 0b10101, 0b00010, 0b00000, 0b00000}, // '~'
+	},
+}
+
+// Font3x5 is a tiny 3x5 pixel font with upper case letters, digits and the most common punctuation.
+// Lower case letters are shown as upper case letters.
+var Font3x5 = &Font{
+	Width:     3,
+	Height:    5,
+	first:     ' ',
+	upperOnly: true,
+	glyphs: [][]byte{
+		{0b000, 0b000, 0b000, 0b000, 0b000}, // ' '
+		{0b010, 0b010, 0b010, 0b000, 0b010}, // '!'
+		{0b101, 0b101, 0b000, 0b000, 0b000}, // '"'
+		{0b101, 0b111, 0b101, 0b111, 0b101}, // '#'
+		{0b011, 0b110, 0b010, 0b011, 0b110}, // '$'
+		{0b101, 0b001, 0b010, 0b100, 0b101}, // '%'
+		{0b010, 0b101, 0b010, 0b101, 0b011}, // '&'
+		{0b010, 0b010, 0b000, 0b000, 0b000}, // '\''
+		{0b001, 0b010, 0b010, 0b010, 0b001}, // '('
+		{0b100, 0b010, 0b010, 0b010, 0b100}, // ')'
+		{0b000, 0b101, 0b010, 0b101, 0b000}, // '*'
+		{0b000, 0b010, 0b111, 0b010, 0b000}, // '+'
+		{0b000, 0b000, 0b000, 0b010, 0b100}, // ','
+		{0b000, 0b000, 0b111, 0b000, 0b000}, // '-'
+		{0b000, 0b000, 0b000, 0b000, 0b010}, // '.'
+		{0b001, 0b001, 0b010, 0b100, 0b100}, // '/'
+		{0b111, 0b101, 0b101, 0b101, 0b111}, // '0'
+		{0b010, 0b110, 0b010, 0b010, 0b111}, // '1'
+		{0b111, 0b001, 0b111, 0b100, 0b111}, // '2'
+		{0b111, 0b001, 0b111, 0b001, 0b111}, // '3'
+		{0b101, 0b101, 0b111, 0b001, 0b001}, // '4'
+		{0b111, 0b100, 0b111, 0b001, 0b111}, // '5'
+		{0b111, 0b100, 0b111, 0b101, 0b111}, // '6'
+		{0b111, 0b001, 0b001, 0b010, 0b010}, // '7'
+		{0b111, 0b101, 0b111, 0b101, 0b111}, // '8'
+		{0b111, 0b101, 0b111, 0b001, 0b111}, // '9'
+		{0b000, 0b010, 0b000, 0b010, 0b000}, // ':'
+		{0b000, 0b010, 0b000, 0b010, 0b100}, // ';'
+		{0b001, 0b010, 0b100, 0b010, 0b001}, // '<'
+		{0b000, 0b111, 0b000, 0b111, 0b000}, // '='
+		{0b100, 0b010, 0b001, 0b010, 0b100}, // '>'
+		{0b111, 0b001, 0b011, 0b000, 0b010}, // '?'
+		{0b111, 0b101, 0b111, 0b100, 0b111}, // '@'
+		{0b010, 0b101, 0b111, 0b101, 0b101}, // 'A'
+		{0b110, 0b101, 0b110, 0b101, 0b110}, // 'B'
+		{0b011, 0b100, 0b100, 0b100, 0b011}, // 'C'
+		{0b110, 0b101, 0b101, 0b101, 0b110}, // 'D'
+		{0b111, 0b100, 0b110, 0b100, 0b111}, // 'E'
+		{0b111, 0b100, 0b110, 0b100, 0b100}, // 'F'
+		{0b011, 0b100, 0b101, 0b101, 0b011}, // 'G'
+		{0b101, 0b101, 0b111, 0b101, 0b101}, // 'H'
+		{0b111, 0b010, 0b010, 0b010, 0b111}, // 'I'
+		{0b001, 0b001, 0b001, 0b101, 0b010}, // 'J'
+		{0b101, 0b101, 0b110, 0b101, 0b101}, // 'K'
+		{0b100, 0b100, 0b100, 0b100, 0b111}, // 'L'
+		{0b101, 0b111, 0b111, 0b101, 0b101}, // 'M'
+		{0b110, 0b101, 0b101, 0b101, 0b101}, // 'N'
+		{0b010, 0b101, 0b101, 0b101, 0b010}, // 'O'
+		{0b110, 0b101, 0b110, 0b100, 0b100}, // 'P'
+		{0b010, 0b101, 0b101, 0b110, 0b011}, // 'Q'
+		{0b110, 0b101, 0b110, 0b101, 0b101}, // 'R'
+		{0b011, 0b100, 0b010, 0b001, 0b110}, // 'S'
+		{0b111, 0b010, 0b010, 0b010, 0b010}, // 'T'
+		{0b101, 0b101, 0b101, 0b101, 0b111}, // 'U'
+		{0b101, 0b101, 0b101, 0b101, 0b010}, // 'V'
+		{0b101, 0b101, 0b111, 0b111, 0b101}, // 'W'
+		{0b101, 0b101, 0b010, 0b101, 0b101}, // 'X'
+		{0b101, 0b101, 0b010, 0b010, 0b010}, // 'Y'
+		{0b111, 0b001, 0b010, 0b100, 0b111}, // 'Z'
+		{0b110, 0b100, 0b100, 0b100, 0b110}, // '['
+		{0b100, 0b100, 0b010, 0b001, 0b001}, // '\\'
+		{0b011, 0b001, 0b001, 0b001, 0b011}, // ']'
+		{0b010, 0b101, 0b000, 0b000, 0b000}, // '^'
+		{0b000, 0b000, 0b000, 0b000, 0b111}, // '_'
+		{0b100, 0b010, 0b000, 0b000, 0b000}, // '`'
+	},
+}
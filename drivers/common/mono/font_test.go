@@ -0,0 +1,54 @@
+package mono
+
+import (
+	"image"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestFonts(t *testing.T) {
+	gobottest.Assert(t, len(Font5x7.glyphs), 95)
+	gobottest.Assert(t, len(Font3x5.glyphs), 65)
+	for _, fnt := range []*Font{Font5x7, Font3x5} {
+		for _, g := range fnt.glyphs {
+			gobottest.Assert(t, len(g), fnt.Height)
+		}
+	}
+	// lower case is mapped to upper case for the tiny font
+	gobottest.Assert(t, Font3x5.glyph('a'), Font3x5.glyph('A'))
+	// unknown runes are shown as '?'
+	gobottest.Assert(t, Font5x7.glyph('ä'), Font5x7.glyph('?'))
+	gobottest.Assert(t, Font3x5.glyph('~'), Font3x5.glyph('?'))
+}
+
+func TestDrawText(t *testing.T) {
+	f := NewFramebuffer(16, 8)
+	x := f.DrawText(1, 0, "-|", Font3x5, true)
+	gobottest.Assert(t, x, 9)
+	// '-' is the middle row
+	gobottest.Assert(t, f.Pixel(1, 2), true)
+	gobottest.Assert(t, f.Pixel(3, 2), true)
+	gobottest.Assert(t, f.Pixel(1, 1), false)
+	// '|' is unknown for this font, so '?' is used
+	gobottest.Assert(t, f.Pixel(5, 0), true)
+	gobottest.Assert(t, f.Pixel(6, 4), true)
+	gobottest.Assert(t, Font5x7.TextWidth("ab"), 11)
+	gobottest.Assert(t, Font5x7.TextWidth(""), 0)
+}
+
+func TestWrapText(t *testing.T) {
+	// 4 chars per line
+	lines := WrapText("ab cd efghijk\nl", Font3x5, 15)
+	gobottest.Assert(t, lines, []string{"ab", "cd", "efgh", "ijk", "l"})
+	lines = WrapText("a b c", Font3x5, 15)
+	gobottest.Assert(t, lines, []string{"a b", "c"})
+}
+
+func TestDrawTextWrapped(t *testing.T) {
+	f := NewFramebuffer(16, 12)
+	count := f.DrawTextWrapped(image.Rect(0, 0, 16, 12), "aa bb cc", Font3x5, true)
+	gobottest.Assert(t, count, 2)
+	gobottest.Assert(t, f.Pixel(1, 0), true)
+	gobottest.Assert(t, f.Pixel(1, 6), true)
+}
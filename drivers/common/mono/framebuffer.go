@@ -0,0 +1,236 @@
+package mono
+
+import (
+	"image"
+	"image/color"
+)
+
+// Bit is the color of a monochrome pixel, true means the pixel is lit.
+type Bit bool
+
+// Colors of a monochrome pixel
+const (
+	Off Bit = false
+	On  Bit = true
+)
+
+// RGBA implements the color.Color interface.
+func (b Bit) RGBA() (r, g, bl, a uint32) {
+	if b {
+		return 0xffff, 0xffff, 0xffff, 0xffff
+	}
+	return 0, 0, 0, 0xffff
+}
+
+// BitModel converts any color to a Bit, by using a threshold of the half luminance. Transparent colors are Off.
+var BitModel = color.ModelFunc(func(c color.Color) color.Color {
+	if b, ok := c.(Bit); ok {
+		return b
+	}
+	r, g, b, a := c.RGBA()
+	if a < 0x8000 {
+		return Off
+	}
+	// ITU-R 601-2 luma, same as used by color.GrayModel
+	y := (19595*r + 38470*g + 7471*b + 1<<15) >> 16
+	return Bit(y >= 0x8000)
+})
+
+// Rotation is the clockwise rotation of the framebuffer content on the physical display
+type Rotation int
+
+// Supported rotations
+const (
+	Rotate0 Rotation = iota
+	Rotate90
+	Rotate180
+	Rotate270
+)
+
+// Framebuffer is a 1-bit image, which implements the draw.Image interface, so it can be used
+// with the "image/draw" package and as target for the drawing functions of this package.
+//
+// The framebuffer is double buffered: all drawing is done to the back buffer and Changed() reports
+// the physical region, which differs from the content last committed to the display. Drivers
+// transfer only this region and call Commit() afterwards.
+type Framebuffer struct {
+	width    int // physical
+	height   int // physical
+	back     []bool
+	front    []bool
+	dirty    image.Rectangle // physical region, which was touched since last commit
+	rotation Rotation
+	mirrorH  bool
+	mirrorV  bool
+}
+
+// NewFramebuffer creates a framebuffer with the physical size of the display. All pixels are
+// considered as changed, so the first flush transfers the whole content.
+func NewFramebuffer(width, height int) *Framebuffer {
+	f := &Framebuffer{
+		width:  width,
+		height: height,
+		back:   make([]bool, width*height),
+		front:  make([]bool, width*height),
+	}
+	f.Invalidate()
+	return f
+}
+
+// SetRotation sets the rotation, which is applied to all following drawing operations.
+// A rotation by 90 or 270 degree swaps width and height of Bounds().
+func (f *Framebuffer) SetRotation(r Rotation) { f.rotation = r }
+
+// SetMirror enables or disables the mirroring in x (horizontal) and y (vertical) direction,
+// which is applied to all following drawing operations.
+func (f *Framebuffer) SetMirror(horizontal bool, vertical bool) {
+	f.mirrorH = horizontal
+	f.mirrorV = vertical
+}
+
+// PhysicalSize returns the size of the display, not affected by rotation
+func (f *Framebuffer) PhysicalSize() (int, int) { return f.width, f.height }
+
+// ColorModel implements the image.Image interface.
+func (f *Framebuffer) ColorModel() color.Model { return BitModel }
+
+// Bounds implements the image.Image interface and returns the logical size, which depends on the rotation.
+func (f *Framebuffer) Bounds() image.Rectangle {
+	if f.rotation == Rotate90 || f.rotation == Rotate270 {
+		return image.Rect(0, 0, f.height, f.width)
+	}
+	return image.Rect(0, 0, f.width, f.height)
+}
+
+// At implements the image.Image interface.
+func (f *Framebuffer) At(x, y int) color.Color { return Bit(f.Pixel(x, y)) }
+
+// Set implements the draw.Image interface.
+func (f *Framebuffer) Set(x, y int, c color.Color) {
+	f.SetPixel(x, y, bool(BitModel.Convert(c).(Bit)))
+}
+
+// Pixel returns the state of the pixel at the logical position, outside pixels are off
+func (f *Framebuffer) Pixel(x, y int) bool {
+	px, py, ok := f.physical(x, y)
+	if !ok {
+		return false
+	}
+	return f.back[py*f.width+px]
+}
+
+// SetPixel switches the pixel at the logical position, outside pixels are ignored
+func (f *Framebuffer) SetPixel(x, y int, on bool) {
+	px, py, ok := f.physical(x, y)
+	if !ok {
+		return
+	}
+	f.setPhysical(px, py, on)
+}
+
+// Clear switches off all pixels
+func (f *Framebuffer) Clear() { f.Fill(false) }
+
+// Fill switches all pixels to the given state
+func (f *Framebuffer) Fill(on bool) {
+	for i := range f.back {
+		f.back[i] = on
+	}
+	f.dirty = image.Rect(0, 0, f.width, f.height)
+}
+
+// Invalidate marks the whole display as changed, e.g. after the display was reset
+func (f *Framebuffer) Invalidate() {
+	for i := range f.back {
+		f.front[i] = !f.back[i]
+	}
+	f.dirty = image.Rect(0, 0, f.width, f.height)
+}
+
+// Changed returns the smallest physical rectangle, which contains all pixels differing from the
+// last committed content. The rectangle is empty, if nothing was changed.
+func (f *Framebuffer) Changed() image.Rectangle {
+	var r image.Rectangle
+	for y := f.dirty.Min.Y; y < f.dirty.Max.Y; y++ {
+		for x := f.dirty.Min.X; x < f.dirty.Max.X; x++ {
+			i := y*f.width + x
+			if f.back[i] != f.front[i] {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+// Commit marks the given physical region as transferred to the display.
+func (f *Framebuffer) Commit(r image.Rectangle) {
+	r = r.Intersect(image.Rect(0, 0, f.width, f.height))
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		copy(f.front[y*f.width+r.Min.X:y*f.width+r.Max.X], f.back[y*f.width+r.Min.X:y*f.width+r.Max.X])
+	}
+	f.dirty = f.Changed()
+}
+
+// PageBytes returns the content of the physical columns x0..x1-1 in the given page, as used by
+// page oriented controllers like the SSD1306. A page consists of 8 rows, each byte is one column
+// with the top row in the least significant bit.
+func (f *Framebuffer) PageBytes(page int, x0 int, x1 int) []byte {
+	data := make([]byte, 0, x1-x0)
+	for x := x0; x < x1; x++ {
+		var b byte
+		for bit := 0; bit < 8; bit++ {
+			y := page*8 + bit
+			if y < f.height && f.back[y*f.width+x] {
+				b |= 1 << uint(bit)
+			}
+		}
+		data = append(data, b)
+	}
+	return data
+}
+
+// RowBytes returns the content of the given physical row, as used by row oriented controllers like
+// the MAX7219. Each byte contains 8 columns with the left column in the most significant bit, starting
+// at column x0, which should be a multiple of 8. The number of bytes is (x1-x0+7)/8.
+func (f *Framebuffer) RowBytes(y int, x0 int, x1 int) []byte {
+	data := make([]byte, (x1-x0+7)/8)
+	for x := x0; x < x1 && x < f.width; x++ {
+		if f.back[y*f.width+x] {
+			data[(x-x0)/8] |= 0x80 >> uint((x-x0)%8)
+		}
+	}
+	return data
+}
+
+func (f *Framebuffer) setPhysical(px, py int, on bool) {
+	i := py*f.width + px
+	if f.back[i] == on {
+		return
+	}
+	f.back[i] = on
+	f.dirty = f.dirty.Union(image.Rect(px, py, px+1, py+1))
+}
+
+// physical converts the logical to the physical position
+func (f *Framebuffer) physical(x, y int) (int, int, bool) {
+	b := f.Bounds()
+	if x < 0 || y < 0 || x >= b.Max.X || y >= b.Max.Y {
+		return 0, 0, false
+	}
+	if f.mirrorH {
+		x = b.Max.X - 1 - x
+	}
+	if f.mirrorV {
+		y = b.Max.Y - 1 - y
+	}
+	switch f.rotation {
+	case Rotate90:
+		return f.width - 1 - y, x, true
+	case Rotate180:
+		return f.width - 1 - x, f.height - 1 - y, true
+	case Rotate270:
+		return y, f.height - 1 - x, true
+	default:
+		return x, y, true
+	}
+}
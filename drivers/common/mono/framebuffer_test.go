@@ -0,0 +1,100 @@
+package mono
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ draw.Image = (*Framebuffer)(nil)
+
+func TestBitModel(t *testing.T) {
+	gobottest.Assert(t, BitModel.Convert(color.White), On)
+	gobottest.Assert(t, BitModel.Convert(color.Black), Off)
+	gobottest.Assert(t, BitModel.Convert(color.Gray{Y: 0x90}), On)
+	gobottest.Assert(t, BitModel.Convert(color.Gray{Y: 0x70}), Off)
+	gobottest.Assert(t, BitModel.Convert(color.Transparent), Off)
+	gobottest.Assert(t, BitModel.Convert(On), On)
+}
+
+func TestFramebufferSetAt(t *testing.T) {
+	f := NewFramebuffer(16, 8)
+	gobottest.Assert(t, f.Bounds(), image.Rect(0, 0, 16, 8))
+	f.Set(3, 4, color.White)
+	gobottest.Assert(t, f.At(3, 4), On)
+	gobottest.Assert(t, f.At(4, 4), Off)
+	// outside is ignored
+	f.SetPixel(16, 0, true)
+	gobottest.Assert(t, f.Pixel(16, 0), false)
+
+	draw.Draw(f, image.Rect(0, 0, 2, 2), image.NewUniform(color.White), image.Point{}, draw.Src)
+	gobottest.Assert(t, f.Pixel(1, 1), true)
+	f.Clear()
+	gobottest.Assert(t, f.Pixel(1, 1), false)
+}
+
+func TestFramebufferRotationMirror(t *testing.T) {
+	var tests = map[string]struct {
+		rotation Rotation
+		mirrorH  bool
+		mirrorV  bool
+		bounds   image.Rectangle
+		px       int
+		py       int
+	}{
+		"0":        {rotation: Rotate0, bounds: image.Rect(0, 0, 16, 8), px: 1, py: 2},
+		"90":       {rotation: Rotate90, bounds: image.Rect(0, 0, 8, 16), px: 13, py: 1},
+		"180":      {rotation: Rotate180, bounds: image.Rect(0, 0, 16, 8), px: 14, py: 5},
+		"270":      {rotation: Rotate270, bounds: image.Rect(0, 0, 8, 16), px: 2, py: 6},
+		"mirror_h": {mirrorH: true, bounds: image.Rect(0, 0, 16, 8), px: 14, py: 2},
+		"mirror_v": {mirrorV: true, bounds: image.Rect(0, 0, 16, 8), px: 1, py: 5},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := NewFramebuffer(16, 8)
+			f.SetRotation(tc.rotation)
+			f.SetMirror(tc.mirrorH, tc.mirrorV)
+			gobottest.Assert(t, f.Bounds(), tc.bounds)
+			f.SetPixel(1, 2, true)
+			gobottest.Assert(t, f.Pixel(1, 2), true)
+			gobottest.Assert(t, f.back[tc.py*16+tc.px], true)
+		})
+	}
+}
+
+func TestFramebufferChangedCommit(t *testing.T) {
+	f := NewFramebuffer(16, 16)
+	gobottest.Assert(t, f.Changed(), image.Rect(0, 0, 16, 16))
+	f.Commit(image.Rect(0, 0, 16, 16))
+	gobottest.Assert(t, f.Changed().Empty(), true)
+
+	f.SetPixel(3, 4, true)
+	f.SetPixel(10, 6, true)
+	gobottest.Assert(t, f.Changed(), image.Rect(3, 4, 11, 7))
+
+	// a reverted change is not reported
+	f.SetPixel(10, 6, false)
+	gobottest.Assert(t, f.Changed(), image.Rect(3, 4, 4, 5))
+
+	f.Commit(image.Rect(0, 0, 8, 8))
+	gobottest.Assert(t, f.Changed().Empty(), true)
+
+	f.Invalidate()
+	gobottest.Assert(t, f.Changed(), image.Rect(0, 0, 16, 16))
+}
+
+func TestFramebufferPageAndRowBytes(t *testing.T) {
+	f := NewFramebuffer(16, 16)
+	f.SetPixel(0, 0, true)
+	f.SetPixel(0, 7, true)
+	f.SetPixel(1, 9, true)
+	f.SetPixel(9, 0, true)
+
+	gobottest.Assert(t, f.PageBytes(0, 0, 2), []byte{0x81, 0x00})
+	gobottest.Assert(t, f.PageBytes(1, 0, 2), []byte{0x00, 0x02})
+	gobottest.Assert(t, f.RowBytes(0, 0, 16), []byte{0x80, 0x40})
+	gobottest.Assert(t, f.RowBytes(9, 0, 8), []byte{0x40})
+}
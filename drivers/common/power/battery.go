@@ -0,0 +1,78 @@
+package power
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// CurvePoint is one point of a discharge curve
+type CurvePoint struct {
+	Voltage       float64 // [V]
+	StateOfCharge float64 // [%]
+}
+
+// DischargeCurve maps the voltage of a battery to the state of charge. Values between the points are
+// interpolated linearly.
+type DischargeCurve struct {
+	points []CurvePoint
+}
+
+// NewDischargeCurve creates a new discharge curve, at least 2 points are needed. The order of the points
+// does not matter.
+func NewDischargeCurve(points ...CurvePoint) (*DischargeCurve, error) {
+	if len(points) < 2 {
+		return nil, errors.New("a discharge curve needs at least 2 points")
+	}
+	c := &DischargeCurve{points: append([]CurvePoint{}, points...)}
+	sort.Slice(c.points, func(i, j int) bool { return c.points[i].Voltage < c.points[j].Voltage })
+	for i := 1; i < len(c.points); i++ {
+		if c.points[i].Voltage == c.points[i-1].Voltage {
+			return nil, errors.New("the voltages of a discharge curve must be unique")
+		}
+	}
+	return c, nil
+}
+
+// LiIonCurve returns a typical discharge curve of one Li-Ion or LiPo cell (4.2V..3.0V)
+func LiIonCurve() *DischargeCurve {
+	c, _ := NewDischargeCurve(
+		CurvePoint{4.20, 100}, CurvePoint{4.10, 90}, CurvePoint{4.00, 80}, CurvePoint{3.92, 70},
+		CurvePoint{3.85, 60}, CurvePoint{3.79, 50}, CurvePoint{3.75, 40}, CurvePoint{3.71, 30},
+		CurvePoint{3.67, 20}, CurvePoint{3.61, 10}, CurvePoint{3.45, 5}, CurvePoint{3.00, 0},
+	)
+	return c
+}
+
+// LeadAcidCurve returns a typical discharge curve of a 12V lead acid battery in rest (12.7V..11.8V)
+func LeadAcidCurve() *DischargeCurve {
+	c, _ := NewDischargeCurve(
+		CurvePoint{12.70, 100}, CurvePoint{12.50, 90}, CurvePoint{12.42, 80}, CurvePoint{12.32, 70},
+		CurvePoint{12.20, 60}, CurvePoint{12.06, 50}, CurvePoint{11.90, 40}, CurvePoint{11.75, 30},
+		CurvePoint{11.58, 20}, CurvePoint{11.31, 10}, CurvePoint{10.50, 0},
+	)
+	return c
+}
+
+// Cells returns a new curve for the given number of cells in series, e.g. Cells(3) for a 3S LiPo pack
+func (c *DischargeCurve) Cells(n int) *DischargeCurve {
+	scaled := &DischargeCurve{points: make([]CurvePoint, len(c.points))}
+	for i, p := range c.points {
+		scaled.points[i] = CurvePoint{Voltage: p.Voltage * float64(n), StateOfCharge: p.StateOfCharge}
+	}
+	return scaled
+}
+
+// StateOfCharge returns the state of charge in [%] for the given voltage, limited to the range of the curve
+func (c *DischargeCurve) StateOfCharge(voltage float64) float64 {
+	i := sort.Search(len(c.points), func(i int) bool { return c.points[i].Voltage >= voltage })
+	if i == 0 {
+		return c.points[0].StateOfCharge
+	}
+	if i == len(c.points) {
+		return c.points[len(c.points)-1].StateOfCharge
+	}
+	lo, hi := c.points[i-1], c.points[i]
+	soc := lo.StateOfCharge + (voltage-lo.Voltage)/(hi.Voltage-lo.Voltage)*(hi.StateOfCharge-lo.StateOfCharge)
+	return math.Max(0, math.Min(100, soc))
+}
@@ -0,0 +1,52 @@
+package power
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestNewDischargeCurve(t *testing.T) {
+	_, err := NewDischargeCurve(CurvePoint{4.2, 100})
+	gobottest.Assert(t, err, errors.New("a discharge curve needs at least 2 points"))
+	_, err = NewDischargeCurve(CurvePoint{4.2, 100}, CurvePoint{4.2, 90})
+	gobottest.Assert(t, err, errors.New("the voltages of a discharge curve must be unique"))
+
+	// the order does not matter
+	c, err := NewDischargeCurve(CurvePoint{4.0, 100}, CurvePoint{3.0, 0})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.StateOfCharge(3.5), 50.0)
+}
+
+func TestDischargeCurveStateOfCharge(t *testing.T) {
+	c := LiIonCurve()
+	var tests = map[string]struct {
+		voltage float64
+		want    float64
+	}{
+		"full":         {voltage: 4.2, want: 100},
+		"above range":  {voltage: 4.35, want: 100},
+		"empty":        {voltage: 3.0, want: 0},
+		"below range":  {voltage: 2.5, want: 0},
+		"on point":     {voltage: 3.79, want: 50},
+		"interpolated": {voltage: 4.05, want: 85},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gobottest.Assert(t, c.StateOfCharge(tc.voltage) > tc.want-1e-9, true)
+			gobottest.Assert(t, c.StateOfCharge(tc.voltage) < tc.want+1e-9, true)
+		})
+	}
+}
+
+func TestDischargeCurveCells(t *testing.T) {
+	c := LiIonCurve().Cells(3)
+	gobottest.Assert(t, c.StateOfCharge(12.61), 100.0)
+	soc := c.StateOfCharge(11.37)
+	gobottest.Assert(t, soc > 49.99 && soc < 50.01, true)
+	gobottest.Assert(t, c.StateOfCharge(9.0), 0.0)
+	// the original curve is unchanged
+	gobottest.Assert(t, LiIonCurve().StateOfCharge(4.2), 100.0)
+	gobottest.Assert(t, LeadAcidCurve().StateOfCharge(12.7), 100.0)
+}
@@ -0,0 +1,12 @@
+/*
+Package power provides a power monitor for current and voltage sensors like INA219, INA226 and INA3221.
+
+The Monitor samples all channels of a sensor periodically, integrates the energy [Wh] and the charge [mAh],
+tracks minimum, maximum and average of voltage, current and power and publishes alerts for under-voltage
+and over-current. Alerts of the chip itself (e.g. the critical and warning alerts of the INA3221) are
+published too, if the driver implements the AlertSource interface. For battery powered devices the state
+of charge can be estimated by a DischargeCurve.
+
+Voltages are in [V] (the shunt voltage in [mV]), currents in [mA] and power in [mW].
+*/
+package power // import "gobot.io/x/gobot/drivers/common/power"
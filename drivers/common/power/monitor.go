@@ -0,0 +1,230 @@
+package power
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+const (
+	// Data is the event name, which is published with the measurements ([]Measurement) of all channels after
+	// each sample
+	Data = "data"
+	// Alert is the event name, which is published with the AlertData when a limit is exceeded
+	Alert = "alert"
+	// Error is the event name, which is published when the sensor can not be read
+	Error = "error"
+)
+
+type monitorChannel struct {
+	stats        Statistics
+	last         *Measurement
+	underVoltage float64 // [V], 0 means disabled
+	overCurrent  float64 // [mA], 0 means disabled
+	underActive  bool
+	overActive   bool
+	curve        *DischargeCurve
+}
+
+// Monitor samples all channels of a sensor periodically and accumulates the values. The alerts for
+// under-voltage and over-current are published once when the limit is exceeded and again after the
+// value was back in the valid range.
+type Monitor struct {
+	sensor   Sensor
+	interval time.Duration
+	channels []*monitorChannel
+	loop     *ticker.Loop
+	mutex    *sync.Mutex
+	gobot.Eventer
+}
+
+// NewMonitor creates a new monitor for the sensor, which is sampled with the given interval
+func NewMonitor(sensor Sensor, interval time.Duration) *Monitor {
+	m := &Monitor{
+		sensor:   sensor,
+		interval: interval,
+		channels: make([]*monitorChannel, sensor.Channels()),
+		loop:     ticker.NewLoop(),
+		mutex:    &sync.Mutex{},
+		Eventer:  gobot.NewEventer(),
+	}
+	for i := range m.channels {
+		m.channels[i] = &monitorChannel{}
+	}
+	m.AddEvent(Data)
+	m.AddEvent(Alert)
+	m.AddEvent(Error)
+	return m
+}
+
+// SetUnderVoltageAlert sets the limit of the bus voltage in [V] for the given channel, 0 disables the alert
+func (m *Monitor) SetUnderVoltageAlert(channel int, volts float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	c, err := m.channel(channel)
+	if err != nil {
+		return err
+	}
+	c.underVoltage = volts
+	c.underActive = false
+	return nil
+}
+
+// SetOverCurrentAlert sets the limit of the current in [mA] for the given channel, 0 disables the alert
+func (m *Monitor) SetOverCurrentAlert(channel int, current float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	c, err := m.channel(channel)
+	if err != nil {
+		return err
+	}
+	c.overCurrent = current
+	c.overActive = false
+	return nil
+}
+
+// SetBattery sets the discharge curve of the battery, which is measured by the given channel. A nil value
+// removes the battery.
+func (m *Monitor) SetBattery(channel int, curve *DischargeCurve) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	c, err := m.channel(channel)
+	if err != nil {
+		return err
+	}
+	c.curve = curve
+	return nil
+}
+
+// StateOfCharge returns the estimated state of charge in [%] of the battery, based on the load voltage of the
+// last measurement. False is returned, if no battery was set or nothing was measured yet.
+func (m *Monitor) StateOfCharge(channel int) (float64, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	c, err := m.channel(channel)
+	if err != nil || c.curve == nil || c.last == nil {
+		return 0, false
+	}
+	return c.curve.StateOfCharge(c.last.LoadVoltage()), true
+}
+
+// Statistics returns the accumulated values of the given channel
+func (m *Monitor) Statistics(channel int) Statistics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	c, err := m.channel(channel)
+	if err != nil {
+		return Statistics{}
+	}
+	return c.stats
+}
+
+// Reset clears the accumulated values of all channels, the limits and batteries are kept
+func (m *Monitor) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, c := range m.channels {
+		c.stats = Statistics{}
+		c.last = nil
+	}
+}
+
+// Sample reads all channels once, accumulates the values and publishes the alerts. The measurements of all
+// successful channels are returned, also if an error occurred. A manual call between the samples of the loop
+// shortens the time base of the energy accounting for the next sample.
+func (m *Monitor) Sample() ([]Measurement, error) {
+	var measurements []Measurement
+	var alerts []AlertData
+	var firstErr error
+
+	m.mutex.Lock()
+	for i, c := range m.channels {
+		meas, err := m.sensor.ReadChannel(i + 1)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if meas.Time.IsZero() {
+			meas.Time = time.Now()
+		}
+		meas.Channel = i + 1
+		c.stats.add(meas, c.last)
+		c.last = &meas
+		measurements = append(measurements, meas)
+		alerts = append(alerts, c.checkLimits(meas)...)
+	}
+	m.mutex.Unlock()
+
+	if source, ok := m.sensor.(AlertSource); ok {
+		chipAlerts, err := source.ReadAlerts()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		alerts = append(alerts, chipAlerts...)
+	}
+
+	for _, alert := range alerts {
+		m.Publish(Alert, alert)
+	}
+	return measurements, firstErr
+}
+
+// Start starts to sample the sensor with the interval and to publish the measurements by the event "data".
+func (m *Monitor) Start() {
+	m.loop.Start(m.interval, m.publish)
+}
+
+// Stop stops the sample loop, the accumulated values are kept
+func (m *Monitor) Stop() {
+	m.loop.Stop()
+}
+
+func (m *Monitor) publish() {
+	measurements, err := m.Sample()
+	if err != nil {
+		m.Publish(Error, err)
+	}
+	if len(measurements) > 0 {
+		m.Publish(Data, measurements)
+	}
+}
+
+func (m *Monitor) channel(channel int) (*monitorChannel, error) {
+	if channel < 1 || channel > len(m.channels) {
+		return nil, errors.New("channel out of range")
+	}
+	return m.channels[channel-1], nil
+}
+
+// checkLimits returns the alerts, which are raised by the measurement
+func (c *monitorChannel) checkLimits(meas Measurement) []AlertData {
+	var alerts []AlertData
+	if c.underVoltage > 0 {
+		active := meas.BusVoltage < c.underVoltage
+		if active && !c.underActive {
+			alerts = append(alerts, AlertData{Time: meas.Time, Channel: meas.Channel, Kind: UnderVoltage,
+				Value: meas.BusVoltage, Limit: c.underVoltage})
+		}
+		c.underActive = active
+	}
+	if c.overCurrent > 0 {
+		active := meas.Current > c.overCurrent
+		if active && !c.overActive {
+			alerts = append(alerts, AlertData{Time: meas.Time, Channel: meas.Channel, Kind: OverCurrent,
+				Value: meas.Current, Limit: c.overCurrent})
+		}
+		c.overActive = active
+	}
+	return alerts
+}
@@ -0,0 +1,192 @@
+package power
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type monitorTestSensor struct {
+	values []Measurement // per channel
+	err    error
+	mutex  sync.Mutex
+}
+
+func (s *monitorTestSensor) Channels() int { return len(s.values) }
+
+func (s *monitorTestSensor) ReadChannel(channel int) (Measurement, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return Measurement{}, s.err
+	}
+	return s.values[channel-1], nil
+}
+
+func (s *monitorTestSensor) set(channel int, m Measurement) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.values[channel-1] = m
+}
+
+type monitorTestAlertSensor struct {
+	monitorTestSensor
+	alerts []AlertData
+}
+
+func (s *monitorTestAlertSensor) ReadAlerts() ([]AlertData, error) {
+	return s.alerts, nil
+}
+
+func collectAlerts(m *Monitor) chan AlertData {
+	alerts := make(chan AlertData, 10)
+	_ = m.On(Alert, func(v interface{}) { alerts <- v.(AlertData) })
+	return alerts
+}
+
+func receiveAlerts(t *testing.T, alerts chan AlertData, n int) []AlertData {
+	var got []AlertData
+	for i := 0; i < n; i++ {
+		select {
+		case a := <-alerts:
+			got = append(got, a)
+		case <-time.After(time.Second):
+			t.Fatalf("only %d of %d alerts were published", len(got), n)
+		}
+	}
+	select {
+	case a := <-alerts:
+		t.Errorf("unexpected alert %v", a)
+	case <-time.After(20 * time.Millisecond):
+	}
+	return got
+}
+
+func TestMonitorSample(t *testing.T) {
+	start := time.Unix(1000, 0)
+	s := &monitorTestSensor{values: make([]Measurement, 2)}
+	m := NewMonitor(s, time.Second)
+
+	s.set(1, Measurement{Time: start, BusVoltage: 12, Current: 1000})
+	s.set(2, Measurement{Time: start, BusVoltage: 5, Current: 200})
+	got, err := m.Sample()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(got), 2)
+	gobottest.Assert(t, got[1].Channel, 2)
+
+	s.set(1, Measurement{Time: start.Add(30 * time.Minute), BusVoltage: 12, Current: 1000})
+	s.set(2, Measurement{Time: start.Add(30 * time.Minute), BusVoltage: 5, Current: 200})
+	_, err = m.Sample()
+	gobottest.Assert(t, err, nil)
+
+	st := m.Statistics(1)
+	gobottest.Assert(t, st.Samples, 2)
+	gobottest.Assert(t, st.Charge, 500.0)
+	gobottest.Assert(t, st.Energy, 6.0)
+	gobottest.Assert(t, m.Statistics(2).Charge, 100.0)
+	gobottest.Assert(t, m.Statistics(3), Statistics{})
+
+	m.Reset()
+	gobottest.Assert(t, m.Statistics(1), Statistics{})
+}
+
+func TestMonitorSampleError(t *testing.T) {
+	s := &monitorTestSensor{values: make([]Measurement, 1), err: errors.New("read error")}
+	m := NewMonitor(s, time.Second)
+	got, err := m.Sample()
+	gobottest.Assert(t, err, errors.New("read error"))
+	gobottest.Assert(t, len(got), 0)
+	gobottest.Assert(t, m.Statistics(1).Samples, 0)
+}
+
+func TestMonitorLimitAlerts(t *testing.T) {
+	s := &monitorTestSensor{values: make([]Measurement, 1)}
+	m := NewMonitor(s, time.Second)
+	alerts := collectAlerts(m)
+	gobottest.Assert(t, m.SetUnderVoltageAlert(1, 11), nil)
+	gobottest.Assert(t, m.SetOverCurrentAlert(1, 2000), nil)
+	gobottest.Assert(t, m.SetOverCurrentAlert(2, 2000), errors.New("channel out of range"))
+
+	// valid values
+	s.set(1, Measurement{BusVoltage: 12, Current: 1000})
+	_, _ = m.Sample()
+	// under-voltage, raised once
+	s.set(1, Measurement{BusVoltage: 10.5, Current: 1000})
+	_, _ = m.Sample()
+	_, _ = m.Sample()
+	// over-current and back to valid voltage
+	s.set(1, Measurement{BusVoltage: 12, Current: 2500})
+	_, _ = m.Sample()
+	// under-voltage again
+	s.set(1, Measurement{BusVoltage: 10, Current: 1000})
+	_, _ = m.Sample()
+
+	got := receiveAlerts(t, alerts, 3)
+	gobottest.Assert(t, got[0].Kind, UnderVoltage)
+	gobottest.Assert(t, got[0].Value, 10.5)
+	gobottest.Assert(t, got[0].Limit, 11.0)
+	gobottest.Assert(t, got[1].Kind, OverCurrent)
+	gobottest.Assert(t, got[1].Value, 2500.0)
+	gobottest.Assert(t, got[2].Kind, UnderVoltage)
+}
+
+func TestMonitorChipAlerts(t *testing.T) {
+	s := &monitorTestAlertSensor{
+		monitorTestSensor: monitorTestSensor{values: make([]Measurement, 3)},
+		alerts:            []AlertData{{Channel: 2, Kind: Critical, Limit: 500}},
+	}
+	m := NewMonitor(s, time.Second)
+	alerts := collectAlerts(m)
+	_, err := m.Sample()
+	gobottest.Assert(t, err, nil)
+
+	got := receiveAlerts(t, alerts, 1)
+	gobottest.Assert(t, got[0].Kind, Critical)
+	gobottest.Assert(t, got[0].Channel, 2)
+}
+
+func TestMonitorStateOfCharge(t *testing.T) {
+	s := &monitorTestSensor{values: make([]Measurement, 1)}
+	m := NewMonitor(s, time.Second)
+	_, ok := m.StateOfCharge(1)
+	gobottest.Assert(t, ok, false)
+
+	gobottest.Assert(t, m.SetBattery(1, LiIonCurve()), nil)
+	_, ok = m.StateOfCharge(1)
+	gobottest.Assert(t, ok, false)
+
+	// the shunt voltage is added
+	s.set(1, Measurement{BusVoltage: 3.78, ShuntVoltage: 10})
+	_, _ = m.Sample()
+	soc, ok := m.StateOfCharge(1)
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, soc > 49.99 && soc < 50.01, true)
+}
+
+func TestMonitorStartStop(t *testing.T) {
+	s := &monitorTestSensor{values: []Measurement{{BusVoltage: 5, Current: 100}}}
+	m := NewMonitor(s, 5*time.Millisecond)
+	data := make(chan []Measurement, 10)
+	_ = m.On(Data, func(v interface{}) {
+		select {
+		case data <- v.([]Measurement):
+		default:
+		}
+	})
+
+	m.Start()
+	select {
+	case got := <-data:
+		gobottest.Assert(t, got[0].BusVoltage, 5.0)
+		gobottest.Assert(t, got[0].Time.IsZero(), false)
+	case <-time.After(time.Second):
+		t.Fatal("no data event was published")
+	}
+	m.Stop()
+	m.Stop()
+}
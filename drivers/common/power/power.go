@@ -0,0 +1,57 @@
+package power
+
+import "time"
+
+// Measurement contains the values of one channel of a sensor
+type Measurement struct {
+	Time         time.Time
+	Channel      int
+	BusVoltage   float64 // [V]
+	ShuntVoltage float64 // [mV]
+	Current      float64 // [mA]
+}
+
+// LoadVoltage returns the sum of bus and shunt voltage in [V]. For a high side shunt this is the voltage
+// of the source, e.g. the battery.
+func (m Measurement) LoadVoltage() float64 { return m.BusVoltage + m.ShuntVoltage/1000 }
+
+// Power returns the power of the load in [mW]
+func (m Measurement) Power() float64 { return m.BusVoltage * m.Current }
+
+// Sensor is implemented by drivers of current and voltage sensors
+type Sensor interface {
+	// Channels returns the number of channels, which are numbered starting with 1
+	Channels() int
+	// ReadChannel measures the given channel
+	ReadChannel(channel int) (Measurement, error)
+}
+
+// AlertKind is the reason of an alert
+type AlertKind string
+
+const (
+	// UnderVoltage is raised when the bus voltage is below the limit
+	UnderVoltage AlertKind = "undervoltage"
+	// OverCurrent is raised when the current is above the limit
+	OverCurrent AlertKind = "overcurrent"
+	// Critical is raised by the critical alert function of the chip
+	Critical AlertKind = "critical"
+	// Warning is raised by the warning alert function of the chip
+	Warning AlertKind = "warning"
+)
+
+// AlertData is the value of the alert event. For alerts of the chip the value is not known and the limit is
+// only known, if the driver keeps track of it.
+type AlertData struct {
+	Time    time.Time
+	Channel int
+	Kind    AlertKind
+	Value   float64 // [V] for UnderVoltage, [mA] otherwise
+	Limit   float64 // [V] for UnderVoltage, [mA] otherwise
+}
+
+// AlertSource is implemented by drivers, which support the alert functions of the chip
+type AlertSource interface {
+	// ReadAlerts returns the pending alerts of the chip, normally the flags are cleared by reading
+	ReadAlerts() ([]AlertData, error)
+}
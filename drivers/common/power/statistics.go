@@ -0,0 +1,54 @@
+package power
+
+import (
+	"math"
+	"time"
+)
+
+// Stats contains minimum, maximum and average of a value
+type Stats struct {
+	Min     float64
+	Max     float64
+	Average float64
+}
+
+func (s *Stats) add(v float64, count int) {
+	if count == 1 {
+		*s = Stats{Min: v, Max: v, Average: v}
+		return
+	}
+	s.Min = math.Min(s.Min, v)
+	s.Max = math.Max(s.Max, v)
+	s.Average += (v - s.Average) / float64(count)
+}
+
+// Statistics contains the accumulated values of one channel since the start or the last reset
+type Statistics struct {
+	Samples    int
+	Duration   time.Duration // time between the first and the last sample
+	BusVoltage Stats         // [V]
+	Current    Stats         // [mA]
+	Power      Stats         // [mW]
+	Energy     float64       // [Wh]
+	Charge     float64       // [mAh]
+}
+
+// add accumulates the measurement, energy and charge are integrated by the trapezoidal rule
+func (s *Statistics) add(m Measurement, last *Measurement) {
+	s.Samples++
+	s.BusVoltage.add(m.BusVoltage, s.Samples)
+	s.Current.add(m.Current, s.Samples)
+	s.Power.add(m.Power(), s.Samples)
+
+	if last == nil {
+		return
+	}
+	dt := m.Time.Sub(last.Time)
+	if dt <= 0 {
+		return
+	}
+	s.Duration += dt
+	hours := dt.Hours()
+	s.Energy += (m.Power() + last.Power()) / 2 / 1000 * hours
+	s.Charge += (m.Current + last.Current) / 2 * hours
+}
@@ -0,0 +1,39 @@
+package power
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestMeasurement(t *testing.T) {
+	m := Measurement{BusVoltage: 12, ShuntVoltage: 50, Current: 500}
+	gobottest.Assert(t, m.LoadVoltage(), 12.05)
+	gobottest.Assert(t, m.Power(), 6000.0)
+}
+
+func TestStatisticsAdd(t *testing.T) {
+	start := time.Unix(1000, 0)
+	var s Statistics
+	m1 := Measurement{Time: start, BusVoltage: 5, Current: 100}
+	s.add(m1, nil)
+	gobottest.Assert(t, s.Samples, 1)
+	gobottest.Assert(t, s.Energy, 0.0)
+	gobottest.Assert(t, s.BusVoltage, Stats{Min: 5, Max: 5, Average: 5})
+
+	// one hour later with 300mA: trapezoid gives 200mAh and 1Wh
+	m2 := Measurement{Time: start.Add(time.Hour), BusVoltage: 5, Current: 300}
+	s.add(m2, &m1)
+	gobottest.Assert(t, s.Samples, 2)
+	gobottest.Assert(t, s.Duration, time.Hour)
+	gobottest.Assert(t, s.Charge, 200.0)
+	gobottest.Assert(t, s.Energy, 1.0)
+	gobottest.Assert(t, s.Current, Stats{Min: 100, Max: 300, Average: 200})
+	gobottest.Assert(t, s.Power, Stats{Min: 500, Max: 1500, Average: 1000})
+
+	// samples with the same time are counted, but not integrated
+	s.add(m2, &m2)
+	gobottest.Assert(t, s.Samples, 3)
+	gobottest.Assert(t, s.Charge, 200.0)
+}
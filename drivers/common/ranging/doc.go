@@ -0,0 +1,8 @@
+/*
+Package ranging provides a common interface for distance sensors like VL53L0X, VL53L1X and LIDARLite and a Sampler,
+which publishes the measurements of a sensor periodically.
+
+Each measurement contains the distance in [mm] and information about the signal quality, so consumers can decide
+whether to trust the value. Distances of measurements with a status other than Valid should not be used.
+*/
+package ranging // import "gobot.io/x/gobot/drivers/common/ranging"
@@ -0,0 +1,61 @@
+package ranging
+
+import "time"
+
+// Status describes the quality of a measurement
+type Status uint8
+
+const (
+	// Valid is the status of a measurement without any failure
+	Valid Status = iota
+	// SigmaFail means the estimated standard deviation of the distance is too high, e.g. by much ambient light
+	SigmaFail
+	// SignalFail means the returned signal is too weak, e.g. no target or the target is too far away
+	SignalFail
+	// OutOfRange means the target is outside the measurable range of the current mode
+	OutOfRange
+	// WrapAround means the target is beyond the unambiguous range and the distance may be wrong
+	WrapAround
+	// HardwareFail means a failure of the sensor itself, e.g. of the laser or the PLL
+	HardwareFail
+)
+
+var statusNames = map[Status]string{
+	Valid:        "valid",
+	SigmaFail:    "sigma fail",
+	SignalFail:   "signal fail",
+	OutOfRange:   "out of range",
+	WrapAround:   "wrap around",
+	HardwareFail: "hardware fail",
+}
+
+// String returns the name of the status
+func (s Status) String() string {
+	if n, ok := statusNames[s]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// Measurement is the result of one ranging
+type Measurement struct {
+	Time     time.Time
+	Distance float64 // [mm]
+	Status   Status
+	// Signal is the strength of the returned signal, this is the signal rate in [MCPS] for VL53Lxx and the received
+	// signal strength (0..255) for LIDARLite
+	Signal float64
+	// Ambient is the ambient light rate in [MCPS], 0 if not supported by the sensor
+	Ambient float64
+}
+
+// Valid returns true, if the measurement has no failure
+func (m Measurement) Valid() bool {
+	return m.Status == Valid
+}
+
+// RangeFinder is the interface of distance sensors
+type RangeFinder interface {
+	// ReadRange does a measurement, or reads the next measurement in continuous mode
+	ReadRange() (Measurement, error)
+}
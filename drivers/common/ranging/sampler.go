@@ -0,0 +1,99 @@
+package ranging
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+const (
+	// Distance is the event name, which is published with the Measurement after each sample
+	Distance = "distance"
+	// Error is the event name, which is published when reading the sensor fails
+	Error = "error"
+)
+
+// Sampler reads a range finder periodically and publishes the measurements by the event "distance". Measurements
+// with a status other than Valid are published too, so the consumer can handle the missing target.
+type Sampler struct {
+	finder    RangeFinder
+	interval  time.Duration
+	validOnly bool
+	last      Measurement
+	loop      *ticker.Loop
+	mutex     *sync.Mutex
+	gobot.Eventer
+}
+
+// NewSampler creates a new sampler for the range finder, which is read with the given interval
+func NewSampler(finder RangeFinder, interval time.Duration) *Sampler {
+	s := &Sampler{
+		finder:   finder,
+		interval: interval,
+		loop:     ticker.NewLoop(),
+		mutex:    &sync.Mutex{},
+		Eventer:  gobot.NewEventer(),
+	}
+	s.AddEvent(Distance)
+	s.AddEvent(Error)
+	return s
+}
+
+// SetValidOnly suppresses the publishing of measurements with a status other than Valid
+func (s *Sampler) SetValidOnly(validOnly bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.validOnly = validOnly
+}
+
+// Measurement returns the result of the last sample
+func (s *Sampler) Measurement() Measurement {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.last
+}
+
+// Sample reads the range finder once and keeps the result as last measurement. No event is published.
+func (s *Sampler) Sample() (Measurement, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	m, err := s.finder.ReadRange()
+	if err != nil {
+		return m, err
+	}
+	if m.Time.IsZero() {
+		m.Time = time.Now()
+	}
+	s.last = m
+	return m, nil
+}
+
+// Start starts to read the range finder with the interval and to publish the measurements by the event "distance".
+func (s *Sampler) Start() {
+	s.loop.Start(s.interval, s.publish)
+}
+
+// Stop stops the sample loop, no measurement is published after return
+func (s *Sampler) Stop() {
+	s.loop.Stop()
+}
+
+func (s *Sampler) publish() {
+	m, err := s.Sample()
+	if err != nil {
+		s.Publish(Error, err)
+		return
+	}
+	s.mutex.Lock()
+	validOnly := s.validOnly
+	s.mutex.Unlock()
+	if validOnly && !m.Valid() {
+		return
+	}
+	s.Publish(Distance, m)
+}
@@ -0,0 +1,113 @@
+package ranging
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type samplerTestFinder struct {
+	measurements []Measurement
+	err          error
+	reads        int
+	mutex        sync.Mutex
+}
+
+func (f *samplerTestFinder) ReadRange() (Measurement, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	m := f.measurements[f.reads%len(f.measurements)]
+	f.reads++
+	return m, f.err
+}
+
+func TestStatusString(t *testing.T) {
+	gobottest.Assert(t, Valid.String(), "valid")
+	gobottest.Assert(t, SignalFail.String(), "signal fail")
+	gobottest.Assert(t, Status(99).String(), "unknown")
+	gobottest.Assert(t, Measurement{Status: Valid}.Valid(), true)
+	gobottest.Assert(t, Measurement{Status: OutOfRange}.Valid(), false)
+}
+
+func TestSamplerSample(t *testing.T) {
+	f := &samplerTestFinder{measurements: []Measurement{{Distance: 123, Status: Valid, Signal: 2.5}}}
+	s := NewSampler(f, time.Second)
+	m, err := s.Sample()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.Distance, 123.0)
+	gobottest.Assert(t, m.Time.IsZero(), false)
+	gobottest.Assert(t, s.Measurement(), m)
+
+	f.err = errors.New("read error")
+	_, err = s.Sample()
+	gobottest.Assert(t, err, errors.New("read error"))
+	// the last valid sample is kept
+	gobottest.Assert(t, s.Measurement(), m)
+}
+
+func TestSamplerEvents(t *testing.T) {
+	f := &samplerTestFinder{measurements: []Measurement{
+		{Distance: 100, Status: Valid},
+		{Distance: 8190, Status: SignalFail},
+	}}
+	s := NewSampler(f, 2*time.Millisecond)
+	distances := make(chan Measurement, 10)
+	_ = s.On(Distance, func(data interface{}) {
+		select {
+		case distances <- data.(Measurement):
+		default:
+		}
+	})
+
+	s.Start()
+	defer s.Stop()
+	first := receiveMeasurement(t, distances)
+	second := receiveMeasurement(t, distances)
+	gobottest.Assert(t, first.Valid() != second.Valid(), true)
+
+	// only valid measurements, events published before the setting was active are dropped
+	s.SetValidOnly(true)
+	time.Sleep(20 * time.Millisecond)
+	for len(distances) > 0 {
+		<-distances
+	}
+	for i := 0; i < 3; i++ {
+		m := receiveMeasurement(t, distances)
+		gobottest.Assert(t, m.Distance, 100.0)
+	}
+}
+
+func TestSamplerErrorEvent(t *testing.T) {
+	f := &samplerTestFinder{measurements: []Measurement{{}}, err: errors.New("read error")}
+	s := NewSampler(f, 2*time.Millisecond)
+	errs := make(chan error, 10)
+	_ = s.On(Error, func(data interface{}) {
+		select {
+		case errs <- data.(error):
+		default:
+		}
+	})
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("no error event received")
+	}
+}
+
+func receiveMeasurement(t *testing.T, ch chan Measurement) Measurement {
+	select {
+	case m := <-ch:
+		return m
+	case <-time.After(time.Second):
+		t.Errorf("no distance event received")
+		return Measurement{}
+	}
+}
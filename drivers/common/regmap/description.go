@@ -0,0 +1,232 @@
+package regmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ByteOrder describes the order of bytes for registers with more than one byte
+type ByteOrder string
+
+const (
+	// BigEndian means the most significant byte is stored at the register address (default)
+	BigEndian ByteOrder = "big"
+	// LittleEndian means the least significant byte is stored at the register address
+	LittleEndian ByteOrder = "little"
+)
+
+// Access describes whether a register or field can be read and written
+type Access string
+
+const (
+	// ReadWrite is the access mode for registers and fields, which can be read and written (default)
+	ReadWrite Access = "rw"
+	// ReadOnly is the access mode for registers and fields, which can only be read, e.g. measurement results
+	ReadOnly Access = "ro"
+	// WriteOnly is the access mode for registers and fields, which can only be written, e.g. commands
+	WriteOnly Access = "wo"
+)
+
+// Field describes a bitfield of a register
+type Field struct {
+	Name string `json:"name"`
+	// Bit is the position of the least significant bit of the field
+	Bit uint8 `json:"bit"`
+	// Width is the count of bits, 0 means 1
+	Width uint8 `json:"width,omitempty"`
+	// Signed fields are interpreted as two's complement
+	Signed bool `json:"signed,omitempty"`
+	// Scale and Offset convert the raw value to the physical value by "raw * Scale + Offset", a scale of 0 means 1
+	Scale  float64 `json:"scale,omitempty"`
+	Offset float64 `json:"offset,omitempty"`
+	Unit   string  `json:"unit,omitempty"`
+	// Enum contains names for raw values
+	Enum map[string]uint64 `json:"enum,omitempty"`
+	// Access defaults to the access of the register
+	Access      Access `json:"access,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Register describes a register of a device
+type Register struct {
+	Name    string `json:"name"`
+	Address uint8  `json:"address"`
+	// Size is the count of bytes (1..8), 0 means 1
+	Size int `json:"size,omitempty"`
+	// ByteOrder defaults to the byte order of the description
+	ByteOrder ByteOrder `json:"byteOrder,omitempty"`
+	// Access defaults to ReadWrite
+	Access Access `json:"access,omitempty"`
+	// Reset is the value after power on, used as base for modifying fields of write only registers
+	Reset       uint64  `json:"reset,omitempty"`
+	Fields      []Field `json:"fields,omitempty"`
+	Description string  `json:"description,omitempty"`
+}
+
+// Description describes all registers of a device
+type Description struct {
+	Name string `json:"name"`
+	// Address is the default i2c address of the device, 0 if not applicable
+	Address int `json:"address,omitempty"`
+	// ByteOrder defaults to BigEndian
+	ByteOrder ByteOrder  `json:"byteOrder,omitempty"`
+	Registers []Register `json:"registers"`
+}
+
+// Parse creates a description from JSON data and validates it.
+func Parse(data []byte) (*Description, error) {
+	var desc Description
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return nil, fmt.Errorf("invalid register map description: %v", err)
+	}
+	if err := desc.Validate(); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+// Load reads a JSON description from the given reader and validates it.
+func Load(r io.Reader) (*Description, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// LoadFile reads a JSON description from the given file and validates it.
+func LoadFile(path string) (*Description, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Validate checks the description for duplicate names, unknown values and fields, which do not fit into the
+// register.
+func (d *Description) Validate() error {
+	if err := validateByteOrder(d.ByteOrder); err != nil {
+		return fmt.Errorf("description '%s': %v", d.Name, err)
+	}
+	if len(d.Registers) == 0 {
+		return fmt.Errorf("description '%s' has no registers", d.Name)
+	}
+	names := map[string]bool{}
+	for _, r := range d.Registers {
+		if r.Name == "" {
+			return fmt.Errorf("register at address 0x%02X has no name", r.Address)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate register '%s'", r.Name)
+		}
+		names[r.Name] = true
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("register '%s': %v", r.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r Register) validate() error {
+	if r.Size < 0 || r.Size > 8 {
+		return fmt.Errorf("size %d is not in range 1..8", r.Size)
+	}
+	if err := validateByteOrder(r.ByteOrder); err != nil {
+		return err
+	}
+	if err := validateAccess(r.Access); err != nil {
+		return err
+	}
+	if r.Reset&^r.mask() != 0 {
+		return fmt.Errorf("reset value 0x%X does not fit into %d bytes", r.Reset, r.size())
+	}
+	names := map[string]bool{}
+	for _, f := range r.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("field at bit %d has no name", f.Bit)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("duplicate field '%s'", f.Name)
+		}
+		names[f.Name] = true
+		if int(f.Bit)+int(f.width()) > r.size()*8 {
+			return fmt.Errorf("field '%s' with bits %d..%d exceeds %d bits", f.Name, f.Bit, int(f.Bit)+int(f.width())-1,
+				r.size()*8)
+		}
+		if err := validateAccess(f.Access); err != nil {
+			return fmt.Errorf("field '%s': %v", f.Name, err)
+		}
+		if f.Access != "" && r.access() != ReadWrite && f.Access != r.access() {
+			return fmt.Errorf("field '%s': access '%s' not possible for register with access '%s'", f.Name, f.Access,
+				r.access())
+		}
+		for n, v := range f.Enum {
+			if v&^f.mask() != 0 {
+				return fmt.Errorf("field '%s': enum value '%s'=%d does not fit into %d bits", f.Name, n, v, f.width())
+			}
+		}
+	}
+	return nil
+}
+
+func validateByteOrder(o ByteOrder) error {
+	switch o {
+	case "", BigEndian, LittleEndian:
+		return nil
+	}
+	return fmt.Errorf("unknown byte order '%s'", o)
+}
+
+func validateAccess(a Access) error {
+	switch a {
+	case "", ReadWrite, ReadOnly, WriteOnly:
+		return nil
+	}
+	return fmt.Errorf("unknown access '%s'", a)
+}
+
+func (r Register) size() int {
+	if r.Size == 0 {
+		return 1
+	}
+	return r.Size
+}
+
+func (r Register) mask() uint64 {
+	if r.size() == 8 {
+		return ^uint64(0)
+	}
+	return 1<<(uint(r.size())*8) - 1
+}
+
+func (r Register) access() Access {
+	if r.Access == "" {
+		return ReadWrite
+	}
+	return r.Access
+}
+
+func (f Field) width() uint8 {
+	if f.Width == 0 {
+		return 1
+	}
+	return f.Width
+}
+
+func (f Field) mask() uint64 {
+	if f.width() >= 64 {
+		return ^uint64(0)
+	}
+	return 1<<f.width() - 1
+}
+
+func (f Field) scale() float64 {
+	if f.Scale == 0 {
+		return 1
+	}
+	return f.Scale
+}
@@ -0,0 +1,27 @@
+/*
+Package regmap provides access to the registers of a device by a declarative description instead of hand-coded
+register constants and bit manipulation.
+
+A device is described by its registers with address, size in bytes, byte order and access mode. Each register can
+be divided into bitfields with a bit position, width, signedness, scale factor, offset and named enumeration values.
+The description is bus independent and can be created by Go structs or loaded from a JSON file, e.g.:
+
+	{
+	  "name": "MCP9808",
+	  "address": 24,
+	  "registers": [
+	    {"name": "CONFIG", "address": 1, "size": 2, "fields": [
+	      {"name": "SHDN", "bit": 8},
+	      {"name": "HYST", "bit": 9, "width": 2, "enum": {"0C": 0, "1.5C": 1, "3C": 2, "6C": 3}}
+	    ]},
+	    {"name": "TA", "address": 5, "size": 2, "access": "ro", "fields": [
+	      {"name": "TEMP", "bit": 0, "width": 13, "signed": true, "scale": 0.0625, "unit": "°C"}
+	    ]}
+	  ]
+	}
+
+A Map binds the description to any gobot.BusOperations, e.g. an i2c or SPI connection, and provides read, write
+and read-modify-write access to registers and fields by name. Fields can be addressed by "REGISTER.FIELD" or just by
+"FIELD", if the field name is unique over all registers.
+*/
+package regmap // import "gobot.io/x/gobot/drivers/common/regmap"
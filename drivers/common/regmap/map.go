@@ -0,0 +1,445 @@
+package regmap
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+// Option is the type for applying options to a map
+type Option func(*Map)
+
+// Map provides the access to the registers of a device by a description.
+type Map struct {
+	bus       gobot.BusOperations
+	desc      *Description
+	registers map[string]*Register
+	fields    map[string]fieldRef
+	shadow    map[string]uint64
+	readFlag  uint8
+	writeFlag uint8
+	mutex     sync.Mutex
+}
+
+type fieldRef struct {
+	register *Register
+	field    *Field
+}
+
+// WithReadFlag sets bits, which are added to the register address for reading, e.g. 0x80 for many SPI devices.
+func WithReadFlag(flag uint8) Option {
+	return func(m *Map) { m.readFlag = flag }
+}
+
+// WithWriteFlag sets bits, which are added to the register address for writing.
+func WithWriteFlag(flag uint8) Option {
+	return func(m *Map) { m.writeFlag = flag }
+}
+
+// New creates a new register map for the given bus and description, which is validated before.
+func New(bus gobot.BusOperations, desc *Description, options ...Option) (*Map, error) {
+	if err := desc.Validate(); err != nil {
+		return nil, err
+	}
+	m := &Map{
+		bus:       bus,
+		desc:      desc,
+		registers: map[string]*Register{},
+		fields:    map[string]fieldRef{},
+		shadow:    map[string]uint64{},
+	}
+	for _, option := range options {
+		option(m)
+	}
+
+	// short names of fields are only usable, if unique
+	shortNames := map[string]int{}
+	for i := range desc.Registers {
+		for _, f := range desc.Registers[i].Fields {
+			shortNames[f.Name]++
+		}
+	}
+	for i := range desc.Registers {
+		r := &desc.Registers[i]
+		m.registers[r.Name] = r
+		m.shadow[r.Name] = r.Reset
+		for j := range r.Fields {
+			ref := fieldRef{register: r, field: &r.Fields[j]}
+			m.fields[r.Name+"."+r.Fields[j].Name] = ref
+			if shortNames[r.Fields[j].Name] == 1 {
+				if _, ok := m.registers[r.Fields[j].Name]; !ok {
+					m.fields[r.Fields[j].Name] = ref
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// Description returns the description of the map.
+func (m *Map) Description() *Description {
+	return m.desc
+}
+
+// Registers returns the names of all registers in order of the description.
+func (m *Map) Registers() []string {
+	names := make([]string, len(m.desc.Registers))
+	for i, r := range m.desc.Registers {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// Fields returns the full names ("REGISTER.FIELD") of all fields in order of the description.
+func (m *Map) Fields() []string {
+	var names []string
+	for _, r := range m.desc.Registers {
+		for _, f := range r.Fields {
+			names = append(names, r.Name+"."+f.Name)
+		}
+	}
+	return names
+}
+
+// Read reads the raw value of the given register or field.
+func (m *Map) Read(name string) (uint64, error) {
+	if _, ok := m.registers[name]; ok {
+		return m.ReadRegister(name)
+	}
+	return m.ReadField(name)
+}
+
+// Write writes the raw value to the given register or field.
+func (m *Map) Write(name string, val uint64) error {
+	if _, ok := m.registers[name]; ok {
+		return m.WriteRegister(name, val)
+	}
+	return m.WriteField(name, val)
+}
+
+// ReadRegister reads the raw value of the given register.
+func (m *Map) ReadRegister(name string) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	r, err := m.register(name)
+	if err != nil {
+		return 0, err
+	}
+	if r.access() == WriteOnly {
+		return 0, fmt.Errorf("register '%s' is write only", name)
+	}
+	return m.read(r)
+}
+
+// WriteRegister writes the raw value to the given register.
+func (m *Map) WriteRegister(name string, val uint64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	r, err := m.register(name)
+	if err != nil {
+		return err
+	}
+	if r.access() == ReadOnly {
+		return fmt.Errorf("register '%s' is read only", name)
+	}
+	if val&^r.mask() != 0 {
+		return fmt.Errorf("value 0x%X does not fit into register '%s'", val, name)
+	}
+	return m.write(r, val)
+}
+
+// ReadField reads the raw value of the given field.
+func (m *Map) ReadField(name string) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ref, err := m.readableField(name)
+	if err != nil {
+		return 0, err
+	}
+	val, err := m.read(ref.register)
+	if err != nil {
+		return 0, err
+	}
+	return val >> ref.field.Bit & ref.field.mask(), nil
+}
+
+// WriteField writes the raw value to the given field. The other bits of the register are read before and not
+// changed, except for write only registers, where the last written value or reset value is used.
+func (m *Map) WriteField(name string, val uint64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ref, err := m.writableField(name)
+	if err != nil {
+		return err
+	}
+	return m.modify(ref.register, map[*Field]uint64{ref.field: val})
+}
+
+// Update writes the given raw values to the fields of one register with a single read-modify-write cycle. The field
+// names are given without the register name.
+func (m *Map) Update(register string, values map[string]uint64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	r, err := m.register(register)
+	if err != nil {
+		return err
+	}
+	changes := map[*Field]uint64{}
+	for name, val := range values {
+		ref, err := m.writableField(r.Name + "." + name)
+		if err != nil {
+			return err
+		}
+		changes[ref.field] = val
+	}
+	return m.modify(r, changes)
+}
+
+// ReadValue reads the given field and returns the physical value, which is the raw value converted by signedness,
+// scale and offset.
+func (m *Map) ReadValue(name string) (float64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ref, err := m.readableField(name)
+	if err != nil {
+		return 0, err
+	}
+	val, err := m.read(ref.register)
+	if err != nil {
+		return 0, err
+	}
+	f := ref.field
+	raw := val >> f.Bit & f.mask()
+	var num float64
+	if f.Signed && raw&(1<<(f.width()-1)) != 0 {
+		num = float64(int64(raw | ^f.mask()))
+	} else {
+		num = float64(raw)
+	}
+	return num*f.scale() + f.Offset, nil
+}
+
+// WriteValue converts the given physical value to the raw value by offset, scale and signedness and writes it to
+// the field. An error is returned, if the value is outside the range of the field.
+func (m *Map) WriteValue(name string, val float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ref, err := m.writableField(name)
+	if err != nil {
+		return err
+	}
+	f := ref.field
+	num := math.Round((val - f.Offset) / f.scale())
+	min, max := 0.0, float64(f.mask())
+	if f.Signed {
+		max = float64(f.mask() >> 1)
+		min = -max - 1
+	}
+	if math.IsNaN(num) || num < min || num > max {
+		return fmt.Errorf("value %v is out of range for field '%s'", val, name)
+	}
+	var raw uint64
+	if num < 0 {
+		raw = uint64(int64(num)) & f.mask()
+	} else {
+		raw = uint64(num)
+	}
+	return m.modify(ref.register, map[*Field]uint64{f: raw})
+}
+
+// ReadEnum reads the given field and returns the name of the value. An error is returned, if the value has no name.
+func (m *Map) ReadEnum(name string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ref, err := m.readableField(name)
+	if err != nil {
+		return "", err
+	}
+	val, err := m.read(ref.register)
+	if err != nil {
+		return "", err
+	}
+	raw := val >> ref.field.Bit & ref.field.mask()
+	for _, n := range enumNames(ref.field) {
+		if ref.field.Enum[n] == raw {
+			return n, nil
+		}
+	}
+	return "", fmt.Errorf("value %d of field '%s' has no name", raw, name)
+}
+
+// WriteEnum writes the value with the given name to the field.
+func (m *Map) WriteEnum(name string, enum string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ref, err := m.writableField(name)
+	if err != nil {
+		return err
+	}
+	raw, ok := ref.field.Enum[enum]
+	if !ok {
+		return fmt.Errorf("unknown value '%s' for field '%s', possible values: %s", enum, name,
+			strings.Join(enumNames(ref.field), ", "))
+	}
+	return m.modify(ref.register, map[*Field]uint64{ref.field: raw})
+}
+
+// Dump reads the raw values of all readable registers.
+func (m *Map) Dump() (map[string]uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	values := map[string]uint64{}
+	for i := range m.desc.Registers {
+		r := &m.desc.Registers[i]
+		if r.access() == WriteOnly {
+			continue
+		}
+		val, err := m.read(r)
+		if err != nil {
+			return nil, err
+		}
+		values[r.Name] = val
+	}
+	return values, nil
+}
+
+func (m *Map) register(name string) (*Register, error) {
+	r, ok := m.registers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown register '%s'", name)
+	}
+	return r, nil
+}
+
+func (m *Map) field(name string) (fieldRef, error) {
+	ref, ok := m.fields[name]
+	if !ok {
+		return fieldRef{}, fmt.Errorf("unknown field '%s'", name)
+	}
+	return ref, nil
+}
+
+func (m *Map) readableField(name string) (fieldRef, error) {
+	ref, err := m.field(name)
+	if err != nil {
+		return ref, err
+	}
+	if fieldAccess(ref) == WriteOnly {
+		return ref, fmt.Errorf("field '%s' is write only", name)
+	}
+	return ref, nil
+}
+
+func (m *Map) writableField(name string) (fieldRef, error) {
+	ref, err := m.field(name)
+	if err != nil {
+		return ref, err
+	}
+	if fieldAccess(ref) == ReadOnly {
+		return ref, fmt.Errorf("field '%s' is read only", name)
+	}
+	return ref, nil
+}
+
+// modify applies the raw values of the fields to the register, a read is skipped if all bits are written or the
+// register is write only
+func (m *Map) modify(r *Register, changes map[*Field]uint64) error {
+	var mask uint64
+	for f, val := range changes {
+		if val&^f.mask() != 0 {
+			return fmt.Errorf("value %d does not fit into field '%s.%s'", val, r.Name, f.Name)
+		}
+		mask |= f.mask() << f.Bit
+	}
+
+	val := m.shadow[r.Name]
+	if r.access() != WriteOnly && mask != r.mask() {
+		var err error
+		if val, err = m.read(r); err != nil {
+			return err
+		}
+	}
+	val &^= mask
+	for f, v := range changes {
+		val |= v << f.Bit
+	}
+	return m.write(r, val)
+}
+
+func (m *Map) read(r *Register) (uint64, error) {
+	address := r.Address | m.readFlag
+	if r.size() == 1 {
+		val, err := m.bus.ReadByteData(address)
+		return uint64(val), err
+	}
+	buf := make([]byte, r.size())
+	if err := m.bus.ReadBlockData(address, buf); err != nil {
+		return 0, err
+	}
+	var val uint64
+	for i := range buf {
+		val |= uint64(buf[i]) << (8 * uint(m.byteIndex(r, i)))
+	}
+	return val, nil
+}
+
+func (m *Map) write(r *Register, val uint64) error {
+	address := r.Address | m.writeFlag
+	var err error
+	if r.size() == 1 {
+		err = m.bus.WriteByteData(address, uint8(val))
+	} else {
+		buf := make([]byte, r.size())
+		for i := range buf {
+			buf[i] = byte(val >> (8 * uint(m.byteIndex(r, i))))
+		}
+		err = m.bus.WriteBlockData(address, buf)
+	}
+	if err != nil {
+		return err
+	}
+	m.shadow[r.Name] = val
+	return nil
+}
+
+// byteIndex returns the significance of the byte at the given position of the register
+func (m *Map) byteIndex(r *Register, pos int) int {
+	order := r.ByteOrder
+	if order == "" {
+		order = m.desc.ByteOrder
+	}
+	if order == LittleEndian {
+		return pos
+	}
+	return r.size() - 1 - pos
+}
+
+func fieldAccess(ref fieldRef) Access {
+	if ref.field.Access != "" {
+		return ref.field.Access
+	}
+	return ref.register.access()
+}
+
+func enumNames(f *Field) []string {
+	var names []string
+	for n := range f.Enum {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,409 @@
+package regmap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// busMock is a register memory, which records the accessed addresses
+type busMock struct {
+	mem     [256]byte
+	reads   []uint8
+	writes  []uint8
+	readErr error
+}
+
+func (b *busMock) ReadByteData(reg uint8) (uint8, error) {
+	b.reads = append(b.reads, reg)
+	return b.mem[reg], b.readErr
+}
+
+func (b *busMock) ReadBlockData(reg uint8, data []byte) error {
+	b.reads = append(b.reads, reg)
+	copy(data, b.mem[reg:])
+	return b.readErr
+}
+
+func (b *busMock) WriteByteData(reg uint8, val uint8) error {
+	b.writes = append(b.writes, reg)
+	b.mem[reg] = val
+	return nil
+}
+
+func (b *busMock) WriteBlockData(reg uint8, data []byte) error {
+	b.writes = append(b.writes, reg)
+	copy(b.mem[reg:], data)
+	return nil
+}
+
+func (b *busMock) WriteByte(val byte) error     { return errors.New("not supported") }
+func (b *busMock) WriteBytes(data []byte) error { return errors.New("not supported") }
+
+const testDescription = `{
+  "name": "TEST",
+  "address": 24,
+  "registers": [
+    {"name": "CONFIG", "address": 1, "size": 2, "fields": [
+      {"name": "SHDN", "bit": 8},
+      {"name": "HYST", "bit": 9, "width": 2, "enum": {"0C": 0, "1.5C": 1, "3C": 2, "6C": 3}},
+      {"name": "MODE", "bit": 0, "width": 3}
+    ]},
+    {"name": "TA", "address": 5, "size": 2, "access": "ro", "fields": [
+      {"name": "TEMP", "bit": 0, "width": 13, "signed": true, "scale": 0.0625, "unit": "°C"}
+    ]},
+    {"name": "OFFSET", "address": 8, "size": 3, "byteOrder": "little", "fields": [
+      {"name": "VALUE", "bit": 4, "width": 16, "signed": true, "scale": 0.5, "offset": 10}
+    ]},
+    {"name": "CMD", "address": 16, "access": "wo", "reset": 128, "fields": [
+      {"name": "RESET", "bit": 0},
+      {"name": "MODE", "bit": 1, "width": 2}
+    ]}
+  ]
+}`
+
+func initTestMap(t *testing.T, options ...Option) (*Map, *busMock) {
+	desc, err := Parse([]byte(testDescription))
+	gobottest.Assert(t, err, nil)
+	b := &busMock{}
+	m, err := New(b, desc, options...)
+	gobottest.Assert(t, err, nil)
+	return m, b
+}
+
+func TestParse(t *testing.T) {
+	// act
+	desc, err := Parse([]byte(testDescription))
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, desc.Name, "TEST")
+	gobottest.Assert(t, desc.Address, 24)
+	gobottest.Assert(t, len(desc.Registers), 4)
+	gobottest.Assert(t, desc.Registers[1].Access, ReadOnly)
+	gobottest.Assert(t, desc.Registers[0].Fields[1].Enum["3C"], uint64(2))
+}
+
+func TestLoadFile(t *testing.T) {
+	// act
+	_, err := LoadFile("/not/existing/description.json")
+	// assert
+	gobottest.Refute(t, err, nil)
+}
+
+func TestValidate(t *testing.T) {
+	var tests = map[string]struct {
+		desc    Description
+		wantErr string
+	}{
+		"no_registers": {
+			desc:    Description{Name: "X"},
+			wantErr: "has no registers",
+		},
+		"byte_order": {
+			desc:    Description{ByteOrder: "middle", Registers: []Register{{Name: "A"}}},
+			wantErr: "unknown byte order 'middle'",
+		},
+		"register_name": {
+			desc:    Description{Registers: []Register{{Address: 3}}},
+			wantErr: "register at address 0x03 has no name",
+		},
+		"duplicate_register": {
+			desc:    Description{Registers: []Register{{Name: "A"}, {Name: "A", Address: 1}}},
+			wantErr: "duplicate register 'A'",
+		},
+		"size": {
+			desc:    Description{Registers: []Register{{Name: "A", Size: 9}}},
+			wantErr: "size 9 is not in range 1..8",
+		},
+		"access": {
+			desc:    Description{Registers: []Register{{Name: "A", Access: "x"}}},
+			wantErr: "unknown access 'x'",
+		},
+		"reset": {
+			desc:    Description{Registers: []Register{{Name: "A", Reset: 0x100}}},
+			wantErr: "reset value 0x100 does not fit into 1 bytes",
+		},
+		"duplicate_field": {
+			desc:    Description{Registers: []Register{{Name: "A", Fields: []Field{{Name: "F"}, {Name: "F", Bit: 1}}}}},
+			wantErr: "duplicate field 'F'",
+		},
+		"field_exceeds": {
+			desc:    Description{Registers: []Register{{Name: "A", Fields: []Field{{Name: "F", Bit: 6, Width: 3}}}}},
+			wantErr: "field 'F' with bits 6..8 exceeds 8 bits",
+		},
+		"field_access": {
+			desc: Description{Registers: []Register{{Name: "A", Access: ReadOnly,
+				Fields: []Field{{Name: "F", Access: WriteOnly}}}}},
+			wantErr: "field 'F': access 'wo' not possible for register with access 'ro'",
+		},
+		"enum": {
+			desc: Description{Registers: []Register{{Name: "A",
+				Fields: []Field{{Name: "F", Width: 2, Enum: map[string]uint64{"big": 4}}}}}},
+			wantErr: "enum value 'big'=4 does not fit into 2 bits",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			err := tc.desc.Validate()
+			// assert
+			gobottest.Refute(t, err, nil)
+			gobottest.Assert(t, strings.Contains(err.Error(), tc.wantErr), true)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	// arrange
+	desc := &Description{}
+	// act
+	m, err := New(&busMock{}, desc)
+	// assert
+	gobottest.Refute(t, err, nil)
+	gobottest.Assert(t, m == nil, true)
+}
+
+func TestMapNames(t *testing.T) {
+	// arrange
+	m, _ := initTestMap(t)
+	// act & assert
+	gobottest.Assert(t, m.Registers(), []string{"CONFIG", "TA", "OFFSET", "CMD"})
+	gobottest.Assert(t, m.Fields(), []string{"CONFIG.SHDN", "CONFIG.HYST", "CONFIG.MODE", "TA.TEMP", "OFFSET.VALUE",
+		"CMD.RESET", "CMD.MODE"})
+	gobottest.Assert(t, m.Description().Name, "TEST")
+}
+
+func TestMapReadRegister(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	b.mem[1] = 0x12
+	b.mem[2] = 0x34
+	b.mem[8] = 0x56
+	b.mem[9] = 0x34
+	b.mem[10] = 0x12
+	// act
+	config, err1 := m.ReadRegister("CONFIG")
+	offset, err2 := m.ReadRegister("OFFSET")
+	_, err3 := m.ReadRegister("CMD")
+	_, err4 := m.ReadRegister("NONE")
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, config, uint64(0x1234))
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, offset, uint64(0x123456))
+	gobottest.Assert(t, err3.Error(), "register 'CMD' is write only")
+	gobottest.Assert(t, err4.Error(), "unknown register 'NONE'")
+}
+
+func TestMapWriteRegister(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	// act
+	err1 := m.WriteRegister("OFFSET", 0xABCDEF)
+	err2 := m.WriteRegister("TA", 1)
+	err3 := m.WriteRegister("CONFIG", 0x10000)
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, b.mem[8:11], []byte{0xEF, 0xCD, 0xAB})
+	gobottest.Assert(t, err2.Error(), "register 'TA' is read only")
+	gobottest.Assert(t, err3.Error(), "value 0x10000 does not fit into register 'CONFIG'")
+}
+
+func TestMapReadField(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	b.mem[1] = 0x05 // HYST=2, SHDN=1
+	b.mem[2] = 0x06 // MODE=6
+	// act
+	shdn, err1 := m.ReadField("SHDN")
+	hyst, err2 := m.ReadField("CONFIG.HYST")
+	mode, err3 := m.ReadField("CONFIG.MODE")
+	_, err4 := m.ReadField("MODE")
+	_, err5 := m.ReadField("CMD.RESET")
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, shdn, uint64(1))
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, hyst, uint64(2))
+	gobottest.Assert(t, err3, nil)
+	gobottest.Assert(t, mode, uint64(6))
+	gobottest.Assert(t, err4.Error(), "unknown field 'MODE'")
+	gobottest.Assert(t, err5.Error(), "field 'CMD.RESET' is write only")
+}
+
+func TestMapWriteField(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	b.mem[1] = 0xFF
+	b.mem[2] = 0xFF
+	// act
+	err := m.WriteField("HYST", 1)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.mem[1:3], []byte{0xFB, 0xFF})
+	gobottest.Assert(t, b.reads, []uint8{1})
+	gobottest.Assert(t, m.WriteField("HYST", 4).Error(), "value 4 does not fit into field 'CONFIG.HYST'")
+	gobottest.Assert(t, m.WriteField("TEMP", 4).Error(), "field 'TEMP' is read only")
+}
+
+func TestMapWriteFieldWriteOnly(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	// act
+	err1 := m.WriteField("CMD.MODE", 3)
+	err2 := m.WriteField("RESET", 1)
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, len(b.reads), 0)
+	gobottest.Assert(t, b.mem[16], uint8(0x87))
+}
+
+func TestMapWriteFieldFullRegister(t *testing.T) {
+	// arrange
+	desc := &Description{Registers: []Register{{Name: "A", Fields: []Field{{Name: "F", Width: 8}}}}}
+	b := &busMock{}
+	m, _ := New(b, desc)
+	// act
+	err := m.WriteField("F", 0xAA)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(b.reads), 0)
+	gobottest.Assert(t, b.mem[0], uint8(0xAA))
+}
+
+func TestMapUpdate(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	b.mem[2] = 0xF8
+	// act
+	err := m.Update("CONFIG", map[string]uint64{"SHDN": 1, "HYST": 3, "MODE": 5})
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, b.mem[1:3], []byte{0x07, 0xFD})
+	gobottest.Assert(t, len(b.reads), 1)
+	gobottest.Assert(t, len(b.writes), 1)
+	gobottest.Assert(t, m.Update("CONFIG", map[string]uint64{"TEMP": 1}).Error(), "unknown field 'CONFIG.TEMP'")
+	gobottest.Assert(t, m.Update("NONE", nil).Error(), "unknown register 'NONE'")
+}
+
+func TestMapReadWriteByName(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	// act
+	err1 := m.Write("CONFIG", 0x0102)
+	err2 := m.Write("CONFIG.MODE", 7)
+	reg, err3 := m.Read("CONFIG")
+	hyst, err4 := m.Read("HYST")
+	_, err5 := m.Read("NONE")
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, b.mem[1:3], []byte{0x01, 0x07})
+	gobottest.Assert(t, err3, nil)
+	gobottest.Assert(t, reg, uint64(0x0107))
+	gobottest.Assert(t, err4, nil)
+	gobottest.Assert(t, hyst, uint64(0))
+	gobottest.Assert(t, err5.Error(), "unknown field 'NONE'")
+}
+
+func TestMapReadValue(t *testing.T) {
+	var tests = map[string]struct {
+		mem   []byte
+		field string
+		want  float64
+	}{
+		"positive": {
+			mem:   []byte{0x01, 0x94},
+			field: "TEMP",
+			want:  25.25,
+		},
+		"negative": {
+			mem:   []byte{0x1F, 0x60},
+			field: "TEMP",
+			want:  -10,
+		},
+		"ignore_upper_bits": {
+			mem:   []byte{0xE1, 0x94},
+			field: "TEMP",
+			want:  25.25,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			m, b := initTestMap(t)
+			copy(b.mem[5:], tc.mem)
+			// act
+			got, err := m.ReadValue(tc.field)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got, tc.want)
+		})
+	}
+}
+
+func TestMapWriteValue(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	b.mem[8] = 0x0F
+	b.mem[10] = 0xF0
+	// act
+	err1 := m.WriteValue("VALUE", 0)
+	got, err2 := m.ReadValue("VALUE")
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, b.mem[8:11], []byte{0xCF, 0xFE, 0xFF}) // -20 = 0xFFEC
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, got, 0.0)
+	gobottest.Assert(t, m.WriteValue("VALUE", 16394).Error(), "value 16394 is out of range for field 'VALUE'")
+	gobottest.Assert(t, m.WriteValue("VALUE", -16374), nil)
+	gobottest.Assert(t, m.WriteValue("VALUE", -16375).Error(), "value -16375 is out of range for field 'VALUE'")
+	gobottest.Assert(t, m.WriteValue("SHDN", -1).Error(), "value -1 is out of range for field 'SHDN'")
+}
+
+func TestMapEnum(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	// act
+	err1 := m.WriteEnum("HYST", "3C")
+	name, err2 := m.ReadEnum("HYST")
+	err3 := m.WriteEnum("HYST", "9C")
+	_, err4 := m.ReadEnum("SHDN")
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, b.mem[1], uint8(0x04))
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, name, "3C")
+	gobottest.Assert(t, err3.Error(), "unknown value '9C' for field 'HYST', possible values: 0C, 1.5C, 3C, 6C")
+	gobottest.Assert(t, err4.Error(), "value 0 of field 'SHDN' has no name")
+}
+
+func TestMapFlags(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t, WithReadFlag(0x80), WithWriteFlag(0x40))
+	// act
+	_ = m.WriteField("HYST", 1)
+	// assert
+	gobottest.Assert(t, b.reads, []uint8{0x81})
+	gobottest.Assert(t, b.writes, []uint8{0x41})
+}
+
+func TestMapDump(t *testing.T) {
+	// arrange
+	m, b := initTestMap(t)
+	b.mem[6] = 0x10
+	// act
+	got, err := m.Dump()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got, map[string]uint64{"CONFIG": 0, "TA": 0x10, "OFFSET": 0})
+	// arrange
+	b.readErr = errors.New("read error")
+	// act
+	_, err = m.Dump()
+	// assert
+	gobottest.Assert(t, err.Error(), "read error")
+}
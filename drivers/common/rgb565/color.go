@@ -0,0 +1,59 @@
+package rgb565
+
+import "image/color"
+
+// Color is a 16 bit colour with 5 bits red, 6 bits green and 5 bits blue, red in the most significant bits.
+type Color uint16
+
+// Some often used colours
+const (
+	Black   Color = 0x0000
+	White   Color = 0xFFFF
+	Red     Color = 0xF800
+	Green   Color = 0x07E0
+	Blue    Color = 0x001F
+	Yellow  Color = 0xFFE0
+	Cyan    Color = 0x07FF
+	Magenta Color = 0xF81F
+)
+
+// New creates a colour from 8 bit values of red, green and blue. The lower bits are dropped.
+func New(r, g, b uint8) Color {
+	return Color(uint16(r&0xF8)<<8 | uint16(g&0xFC)<<3 | uint16(b)>>3)
+}
+
+// RGB returns the 8 bit values of red, green and blue. The lower bits are filled with the upper bits, so
+// white is converted to 0xFF for all components.
+func (c Color) RGB() (r, g, b uint8) {
+	r5 := uint8(c>>11) & 0x1F
+	g6 := uint8(c>>5) & 0x3F
+	b5 := uint8(c) & 0x1F
+	return r5<<3 | r5>>2, g6<<2 | g6>>4, b5<<3 | b5>>2
+}
+
+// RGBA implements the color.Color interface.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	r8, g8, b8 := c.RGB()
+	r = uint32(r8)
+	r |= r << 8
+	g = uint32(g8)
+	g |= g << 8
+	b = uint32(b8)
+	b |= b << 8
+	return r, g, b, 0xFFFF
+}
+
+// Model converts any color to a Color, transparent colors are converted to black. Colours with alpha are
+// converted with the premultiplied values, which means they are blended with black.
+var Model = color.ModelFunc(func(c color.Color) color.Color {
+	return FromColor(c)
+})
+
+// FromColor converts any color to a Color, see Model.
+func FromColor(c color.Color) Color {
+	if v, ok := c.(Color); ok {
+		return v
+	}
+	r, g, b, _ := c.RGBA()
+	return New(uint8(r>>8), uint8(g>>8), uint8(b>>8))
+}
@@ -0,0 +1,40 @@
+package rgb565
+
+import (
+	"image/color"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestColor(t *testing.T) {
+	var tests = map[string]struct {
+		color   color.Color
+		want    Color
+		wantRGB [3]uint8
+	}{
+		"white":       {color: color.White, want: White, wantRGB: [3]uint8{0xFF, 0xFF, 0xFF}},
+		"black":       {color: color.Black, want: Black},
+		"red":         {color: color.RGBA{R: 0xFF, A: 0xFF}, want: Red, wantRGB: [3]uint8{0xFF, 0, 0}},
+		"green":       {color: color.RGBA{G: 0xFF, A: 0xFF}, want: Green, wantRGB: [3]uint8{0, 0xFF, 0}},
+		"blue":        {color: color.RGBA{B: 0xFF, A: 0xFF}, want: Blue, wantRGB: [3]uint8{0, 0, 0xFF}},
+		"mixed":       {color: color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xFF}, want: 0x11AA, wantRGB: [3]uint8{0x10, 0x34, 0x52}},
+		"transparent": {color: color.Transparent, want: Black},
+		"rgb565":      {color: Magenta, want: Magenta, wantRGB: [3]uint8{0xFF, 0, 0xFF}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := Model.Convert(tc.color).(Color)
+			r, g, b := got.RGB()
+			// assert
+			gobottest.Assert(t, got, tc.want)
+			gobottest.Assert(t, [3]uint8{r, g, b}, tc.wantRGB)
+		})
+	}
+}
+
+func TestColorRGBA(t *testing.T) {
+	r, g, b, a := New(0xFF, 0x80, 0x00).RGBA()
+	gobottest.Assert(t, [4]uint32{r, g, b, a}, [4]uint32{0xFFFF, 0x8282, 0, 0xFFFF})
+}
@@ -0,0 +1,8 @@
+/*
+Package rgb565 provides a colour framebuffer with 16 bit RGB565 pixels, which is shared by the drivers of
+colour TFT and OLED displays, like ST7735, ILI9341 and SSD1351.
+
+The framebuffer implements draw.Image, so the functions of the "image/draw" package can be used. Drivers
+transfer only the changed region to the display, see Framebuffer.Changed().
+*/
+package rgb565 // import "gobot.io/x/gobot/drivers/common/rgb565"
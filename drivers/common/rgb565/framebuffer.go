@@ -0,0 +1,109 @@
+package rgb565
+
+import (
+	"image"
+	"image/color"
+)
+
+// Framebuffer is a RGB565 image, which implements the draw.Image interface, so it can be used with the
+// "image/draw" package.
+//
+// The framebuffer tracks the region, which was changed since the last transfer to the display. Drivers
+// transfer only this region and call Commit() afterwards.
+type Framebuffer struct {
+	width  int
+	height int
+	pix    []Color
+	dirty  image.Rectangle
+}
+
+// NewFramebuffer creates a new black framebuffer, which is completely marked as changed.
+func NewFramebuffer(width, height int) *Framebuffer {
+	return &Framebuffer{
+		width:  width,
+		height: height,
+		pix:    make([]Color, width*height),
+		dirty:  image.Rect(0, 0, width, height),
+	}
+}
+
+// ColorModel implements the image.Image interface.
+func (f *Framebuffer) ColorModel() color.Model { return Model }
+
+// Bounds implements the image.Image interface.
+func (f *Framebuffer) Bounds() image.Rectangle { return image.Rect(0, 0, f.width, f.height) }
+
+// At implements the image.Image interface.
+func (f *Framebuffer) At(x, y int) color.Color { return f.Pixel(x, y) }
+
+// Set implements the draw.Image interface.
+func (f *Framebuffer) Set(x, y int, c color.Color) { f.SetPixel(x, y, FromColor(c)) }
+
+// Pixel returns the colour of the pixel, outside pixels are black
+func (f *Framebuffer) Pixel(x, y int) Color {
+	if !f.inside(x, y) {
+		return Black
+	}
+	return f.pix[y*f.width+x]
+}
+
+// SetPixel sets the colour of the pixel, outside pixels are ignored
+func (f *Framebuffer) SetPixel(x, y int, c Color) {
+	if !f.inside(x, y) {
+		return
+	}
+	i := y*f.width + x
+	if f.pix[i] == c {
+		return
+	}
+	f.pix[i] = c
+	f.dirty = f.dirty.Union(image.Rect(x, y, x+1, y+1))
+}
+
+// FillRect fills the rectangle with the colour, the rectangle is clipped to the bounds
+func (f *Framebuffer) FillRect(r image.Rectangle, c Color) {
+	r = r.Intersect(f.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			f.SetPixel(x, y, c)
+		}
+	}
+}
+
+// Fill sets all pixels to the colour
+func (f *Framebuffer) Fill(c Color) { f.FillRect(f.Bounds(), c) }
+
+// Clear sets all pixels to black
+func (f *Framebuffer) Clear() { f.Fill(Black) }
+
+// Invalidate marks the whole framebuffer as changed, e.g. after the display was reset
+func (f *Framebuffer) Invalidate() { f.dirty = f.Bounds() }
+
+// Changed returns the smallest rectangle, which contains all pixels changed since the last commit. The
+// rectangle is empty, if nothing was changed.
+func (f *Framebuffer) Changed() image.Rectangle { return f.dirty }
+
+// Commit marks the given region as transferred to the display. The changed region is only reset, if the given
+// region contains the complete changed region.
+func (f *Framebuffer) Commit(r image.Rectangle) {
+	if f.dirty.In(r) {
+		f.dirty = image.Rectangle{}
+	}
+}
+
+// Bytes returns the content of the rectangle row by row with two bytes per pixel, high byte first, as expected
+// by the display controllers. The rectangle is clipped to the bounds.
+func (f *Framebuffer) Bytes(r image.Rectangle) []byte {
+	r = r.Intersect(f.Bounds())
+	data := make([]byte, 0, 2*r.Dx()*r.Dy())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for _, c := range f.pix[y*f.width+r.Min.X : y*f.width+r.Max.X] {
+			data = append(data, byte(c>>8), byte(c))
+		}
+	}
+	return data
+}
+
+func (f *Framebuffer) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < f.width && y < f.height
+}
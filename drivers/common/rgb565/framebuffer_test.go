@@ -0,0 +1,61 @@
+package rgb565
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ draw.Image = (*Framebuffer)(nil)
+
+func TestFramebufferSetAt(t *testing.T) {
+	f := NewFramebuffer(16, 8)
+	gobottest.Assert(t, f.Bounds(), image.Rect(0, 0, 16, 8))
+	gobottest.Assert(t, f.Changed(), image.Rect(0, 0, 16, 8))
+	f.Commit(f.Bounds())
+	gobottest.Assert(t, f.Changed().Empty(), true)
+
+	f.Set(3, 4, color.RGBA{R: 0xFF, A: 0xFF})
+	gobottest.Assert(t, f.At(3, 4), Red)
+	gobottest.Assert(t, f.At(4, 4), Black)
+	// outside is ignored
+	f.SetPixel(16, 0, White)
+	gobottest.Assert(t, f.Pixel(16, 0), Black)
+	gobottest.Assert(t, f.Changed(), image.Rect(3, 4, 4, 5))
+
+	draw.Draw(f, image.Rect(0, 0, 2, 2), image.NewUniform(color.White), image.Point{}, draw.Src)
+	gobottest.Assert(t, f.Pixel(1, 1), White)
+	gobottest.Assert(t, f.Changed(), image.Rect(0, 0, 4, 5))
+}
+
+func TestFramebufferChanged(t *testing.T) {
+	// arrange
+	f := NewFramebuffer(16, 8)
+	f.Commit(f.Bounds())
+	// act & assert: unchanged pixels are not marked
+	f.Fill(Black)
+	gobottest.Assert(t, f.Changed().Empty(), true)
+	f.FillRect(image.Rect(14, 6, 20, 10), Blue)
+	gobottest.Assert(t, f.Changed(), image.Rect(14, 6, 16, 8))
+	// a partial commit does not reset
+	f.Commit(image.Rect(15, 6, 16, 8))
+	gobottest.Assert(t, f.Changed(), image.Rect(14, 6, 16, 8))
+	f.Commit(image.Rect(0, 0, 16, 8))
+	gobottest.Assert(t, f.Changed().Empty(), true)
+	f.Invalidate()
+	gobottest.Assert(t, f.Changed(), f.Bounds())
+	f.Clear()
+	gobottest.Assert(t, f.Pixel(15, 7), Black)
+}
+
+func TestFramebufferBytes(t *testing.T) {
+	f := NewFramebuffer(4, 3)
+	f.SetPixel(1, 1, Red)
+	f.SetPixel(2, 1, Blue)
+	f.SetPixel(1, 2, 0x1234)
+	gobottest.Assert(t, f.Bytes(image.Rect(1, 1, 3, 3)), []byte{0xF8, 0x00, 0x00, 0x1F, 0x12, 0x34, 0x00, 0x00})
+	gobottest.Assert(t, len(f.Bytes(image.Rect(-2, -2, 10, 10))), 24)
+}
@@ -0,0 +1,10 @@
+/*
+Package rtc provides a common interface for real-time clocks like DS3231, DS1307 and PCF8583 and a Synchronizer to
+bring the time of the RTC to a headless board without network.
+
+The Synchronizer can be added as device to a robot after the RTC driver, so it reads the RTC on Robot.Start.
+Depending on the mode, the system clock is set (needs root privileges on Linux) or an offset is kept, which is
+applied by Synchronizer.Now(). Drift corrections are written back to the RTC periodically and on Halt, when the
+system clock is synchronized from a better source later, e.g. by NTP or GPS.
+*/
+package rtc // import "gobot.io/x/gobot/drivers/common/rtc"
@@ -0,0 +1,11 @@
+package rtc
+
+import "time"
+
+// RTC is the interface of a real-time clock
+type RTC interface {
+	// ReadTime reads the current time of the clock
+	ReadTime() (time.Time, error)
+	// WriteTime sets the clock to the given time
+	WriteTime(val time.Time) error
+}
@@ -0,0 +1,194 @@
+package rtc
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+// Synchronizer brings the time of a RTC to the process, either by setting the system clock or by keeping an offset
+type Synchronizer struct {
+	name          string
+	rtc           RTC
+	mutex         sync.Mutex
+	systemClock   bool
+	interval      time.Duration
+	maxDrift      time.Duration
+	offset        time.Duration
+	loop          *ticker.Loop
+	now           func() time.Time
+	setSystemTime func(time.Time) error
+}
+
+// NewSynchronizer creates a new synchronizer for the given RTC. By default the system clock is not changed, but
+// an offset is kept and no drift corrections are written back.
+func NewSynchronizer(rtc RTC) *Synchronizer {
+	return &Synchronizer{
+		name:          gobot.DefaultName("RTCSynchronizer"),
+		rtc:           rtc,
+		maxDrift:      time.Second,
+		loop:          ticker.NewLoop(),
+		now:           time.Now,
+		setSystemTime: setSystemTime,
+	}
+}
+
+// Name returns the name of the synchronizer.
+func (s *Synchronizer) Name() string { return s.name }
+
+// SetName sets the name of the synchronizer.
+func (s *Synchronizer) SetName(n string) { s.name = n }
+
+// Connection returns the connection of the RTC, if the RTC is a gobot driver, otherwise nil.
+func (s *Synchronizer) Connection() gobot.Connection {
+	if d, ok := s.rtc.(gobot.Driver); ok {
+		return d.Connection()
+	}
+	return nil
+}
+
+// SetSystemClock activates the setting of the system clock instead of keeping an offset. In this mode the system
+// clock is the reference for the drift correction, which is useful when the system clock is synchronized later
+// by NTP or GPS.
+func (s *Synchronizer) SetSystemClock(enable bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.systemClock = enable
+}
+
+// SetWriteBack configures the interval of the drift check and the maximum drift between the RTC and the system
+// clock, before the RTC is corrected. An interval of zero deactivates the periodic check, but the check is still
+// done on Halt.
+func (s *Synchronizer) SetWriteBack(interval time.Duration, maxDrift time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.interval = interval
+	s.maxDrift = maxDrift
+}
+
+// Start reads the RTC and sets the system clock or the offset. The periodic drift check is started, if configured.
+func (s *Synchronizer) Start() error {
+	if err := s.Sync(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	interval := s.interval
+	s.mutex.Unlock()
+
+	if interval > 0 && !s.loop.Running() {
+		s.loop.Start(interval, s.check)
+	}
+	return nil
+}
+
+// Halt stops the periodic drift check and does a last check.
+func (s *Synchronizer) Halt() error {
+	s.loop.Stop()
+
+	_, err := s.Check()
+	return err
+}
+
+// Sync reads the RTC and sets the system clock or the offset.
+func (s *Synchronizer) Sync() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t, err := s.rtc.ReadTime()
+	if err != nil {
+		return err
+	}
+	if s.systemClock {
+		if err := s.setSystemTime(t); err != nil {
+			return err
+		}
+		s.offset = 0
+		return nil
+	}
+	s.offset = t.Sub(s.now())
+	return nil
+}
+
+// Now returns the current time, which is the system time plus the offset to the RTC.
+func (s *Synchronizer) Now() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.now().Add(s.offset)
+}
+
+// Offset returns the difference between the RTC and the system clock, measured on the last synchronization.
+func (s *Synchronizer) Offset() time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.offset
+}
+
+// Drift returns the difference between the RTC and the current time.
+func (s *Synchronizer) Drift() (time.Duration, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.drift()
+}
+
+// Correct writes the given reference time to the RTC, e.g. a time received by GPS. Without system clock mode the
+// offset is adjusted too.
+func (s *Synchronizer) Correct(reference time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the RTC has no time zone, so it is always written in UTC
+	if err := s.rtc.WriteTime(reference.UTC()); err != nil {
+		return err
+	}
+	if !s.systemClock {
+		s.offset = reference.Sub(s.now())
+	}
+	return nil
+}
+
+// Check compares the RTC with the system clock in system clock mode and writes the system time back to the RTC,
+// when the drift exceeds the maximum. It returns true, if the RTC was corrected. Without system clock mode there
+// is no reference, so nothing is done.
+func (s *Synchronizer) Check() (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.systemClock {
+		return false, nil
+	}
+	drift, err := s.drift()
+	if err != nil {
+		return false, err
+	}
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= s.maxDrift {
+		return false, nil
+	}
+	if err := s.rtc.WriteTime(s.now().UTC()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Synchronizer) drift() (time.Duration, error) {
+	t, err := s.rtc.ReadTime()
+	if err != nil {
+		return 0, err
+	}
+	return t.Sub(s.now().Add(s.offset)), nil
+}
+
+func (s *Synchronizer) check() {
+	// errors are ignored here, the check is repeated on the next tick and on Halt()
+	_, _ = s.Check()
+}
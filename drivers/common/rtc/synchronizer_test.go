@@ -0,0 +1,180 @@
+package rtc
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*Synchronizer)(nil)
+
+type testRTC struct {
+	mutex    sync.Mutex
+	time     time.Time
+	written  []time.Time
+	readErr  error
+	writeErr error
+}
+
+func (r *testRTC) ReadTime() (time.Time, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.time, r.readErr
+}
+
+func (r *testRTC) WriteTime(val time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.writeErr != nil {
+		return r.writeErr
+	}
+	r.time = val
+	r.written = append(r.written, val)
+	return nil
+}
+
+func (r *testRTC) writes() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.written)
+}
+
+var testSystemTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestSynchronizer(rtcTime time.Time) (*Synchronizer, *testRTC, *[]time.Time) {
+	r := &testRTC{time: rtcTime}
+	s := NewSynchronizer(r)
+	s.now = func() time.Time { return testSystemTime }
+	var systemTimes []time.Time
+	s.setSystemTime = func(t time.Time) error {
+		systemTimes = append(systemTimes, t)
+		return nil
+	}
+	return s, r, &systemTimes
+}
+
+func TestNewSynchronizer(t *testing.T) {
+	s := NewSynchronizer(&testRTC{})
+	gobottest.Assert(t, s.Name()[:15], "RTCSynchronizer")
+	s.SetName("clock")
+	gobottest.Assert(t, s.Name(), "clock")
+	gobottest.Assert(t, s.Connection(), nil)
+	gobottest.Assert(t, s.maxDrift, time.Second)
+	gobottest.Assert(t, s.systemClock, false)
+}
+
+func TestSynchronizerOffset(t *testing.T) {
+	rtcTime := time.Date(2022, 11, 5, 10, 20, 30, 0, time.UTC)
+	s, r, systemTimes := newTestSynchronizer(rtcTime)
+	gobottest.Assert(t, s.Start(), nil)
+	gobottest.Assert(t, len(*systemTimes), 0)
+	gobottest.Assert(t, s.Offset(), rtcTime.Sub(testSystemTime))
+	gobottest.Assert(t, s.Now(), rtcTime)
+
+	drift, err := s.Drift()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, drift, time.Duration(0))
+
+	// no reference without system clock, so nothing is written back
+	r.time = rtcTime.Add(5 * time.Second)
+	corrected, err := s.Check()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, corrected, false)
+	gobottest.Assert(t, s.Halt(), nil)
+	gobottest.Assert(t, r.writes(), 0)
+
+	// a correction by an external reference adjusts the offset
+	reference := rtcTime.Add(time.Minute)
+	gobottest.Assert(t, s.Correct(reference), nil)
+	gobottest.Assert(t, r.time, reference)
+	gobottest.Assert(t, s.Now(), reference)
+}
+
+func TestSynchronizerSystemClock(t *testing.T) {
+	rtcTime := time.Date(2022, 11, 5, 10, 20, 30, 0, time.UTC)
+	s, r, systemTimes := newTestSynchronizer(rtcTime)
+	s.SetSystemClock(true)
+	gobottest.Assert(t, s.Start(), nil)
+	gobottest.Assert(t, *systemTimes, []time.Time{rtcTime})
+	gobottest.Assert(t, s.Offset(), time.Duration(0))
+
+	// the system clock is synchronized later by NTP, small drifts are ignored
+	s.now = func() time.Time { return rtcTime.Add(500 * time.Millisecond) }
+	corrected, err := s.Check()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, corrected, false)
+
+	s.now = func() time.Time { return rtcTime.Add(3 * time.Second) }
+	drift, _ := s.Drift()
+	gobottest.Assert(t, drift, -3*time.Second)
+	corrected, err = s.Check()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, corrected, true)
+	gobottest.Assert(t, r.time, rtcTime.Add(3*time.Second))
+}
+
+func TestSynchronizerWriteBack(t *testing.T) {
+	rtcTime := time.Now().Add(-time.Hour)
+	r := &testRTC{time: rtcTime}
+	s := NewSynchronizer(r)
+	s.setSystemTime = func(time.Time) error { return nil }
+	s.SetSystemClock(true)
+	s.SetWriteBack(5*time.Millisecond, 10*time.Millisecond)
+	gobottest.Assert(t, s.Start(), nil)
+
+	// the system clock was not really set, so the drift is one hour and the RTC is corrected
+	deadline := time.Now().Add(time.Second)
+	for r.writes() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	gobottest.Assert(t, r.writes() > 0, true)
+	gobottest.Assert(t, s.Halt(), nil)
+}
+
+func TestSynchronizerErrors(t *testing.T) {
+	s, r, _ := newTestSynchronizer(testSystemTime)
+	r.readErr = errors.New("read error")
+	gobottest.Assert(t, s.Start(), errors.New("read error"))
+
+	s, r, _ = newTestSynchronizer(testSystemTime)
+	s.SetSystemClock(true)
+	s.setSystemTime = func(time.Time) error { return errors.New("operation not permitted") }
+	gobottest.Assert(t, s.Start(), errors.New("operation not permitted"))
+
+	s.setSystemTime = func(time.Time) error { return nil }
+	gobottest.Assert(t, s.Start(), nil)
+	s.now = func() time.Time { return testSystemTime.Add(time.Hour) }
+	r.writeErr = errors.New("write error")
+	gobottest.Assert(t, s.Halt(), errors.New("write error"))
+	gobottest.Assert(t, s.Correct(testSystemTime), errors.New("write error"))
+}
+
+func TestSynchronizerWritesUTC(t *testing.T) {
+	// arrange
+	zone := time.FixedZone("UTC+9", 9*60*60)
+	rtcTime := time.Date(2022, 11, 5, 10, 20, 30, 0, time.UTC)
+	s, r, systemTimes := newTestSynchronizer(rtcTime)
+	s.SetSystemClock(true)
+	gobottest.Assert(t, s.Start(), nil)
+	// act: the system clock is in local time
+	local := rtcTime.Add(time.Minute).In(zone)
+	s.now = func() time.Time { return local }
+	corrected, err := s.Check()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, corrected, true)
+	gobottest.Assert(t, r.time, rtcTime.Add(time.Minute))
+	// act: the reference is in local time too
+	reference := rtcTime.Add(time.Hour).In(zone)
+	err = s.Correct(reference)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, r.time, rtcTime.Add(time.Hour))
+	// a later synchronization sets the system clock to the same instant
+	gobottest.Assert(t, s.Sync(), nil)
+	gobottest.Assert(t, (*systemTimes)[len(*systemTimes)-1].Equal(reference), true)
+}
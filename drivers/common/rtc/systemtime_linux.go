@@ -0,0 +1,15 @@
+//go:build linux
+// +build linux
+
+package rtc
+
+import (
+	"syscall"
+	"time"
+)
+
+// setSystemTime sets the system clock, this needs the capability CAP_SYS_TIME
+func setSystemTime(t time.Time) error {
+	tv := syscall.NsecToTimeval(t.UnixNano())
+	return syscall.Settimeofday(&tv)
+}
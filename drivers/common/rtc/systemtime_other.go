@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package rtc
+
+import (
+	"errors"
+	"time"
+)
+
+// setSystemTime is only supported on Linux
+func setSystemTime(t time.Time) error {
+	return errors.New("setting the system clock is not supported on this platform")
+}
@@ -0,0 +1,5 @@
+/*
+Package ticker provides the Loop, which calls a function periodically in its own goroutine. It is used by the
+samplers, monitors and render loops of the common driver packages, so start, restart and stop behave the same.
+*/
+package ticker // import "gobot.io/x/gobot/drivers/common/ticker"
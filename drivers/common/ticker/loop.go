@@ -0,0 +1,70 @@
+package ticker
+
+import (
+	"sync"
+	"time"
+)
+
+// Loop calls a function periodically in its own goroutine until it is stopped.
+type Loop struct {
+	halt  chan bool
+	done  chan bool
+	mutex *sync.Mutex
+}
+
+// NewLoop creates a new stopped loop.
+func NewLoop() *Loop {
+	return &Loop{mutex: &sync.Mutex{}}
+}
+
+// Start starts the loop, which calls the function after each interval. A running loop is stopped before.
+func (l *Loop) Start(interval time.Duration, fn func()) {
+	l.Stop()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	halt := make(chan bool)
+	done := make(chan bool)
+	l.halt = halt
+	l.done = done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-halt:
+				return
+			case <-ticker.C:
+				fn()
+			}
+		}
+	}()
+}
+
+// Stop stops the loop and waits for the end of a running call of the function, so the function is not called
+// anymore after return. Stop must not be called by the function itself and not with a lock held, which is needed
+// by the function.
+func (l *Loop) Stop() {
+	l.mutex.Lock()
+	halt := l.halt
+	done := l.done
+	l.halt = nil
+	l.done = nil
+	l.mutex.Unlock()
+
+	if halt != nil {
+		close(halt)
+		<-done
+	}
+}
+
+// Running returns true, if the loop is started.
+func (l *Loop) Running() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.halt != nil
+}
@@ -0,0 +1,77 @@
+package ticker
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestLoop(t *testing.T) {
+	// arrange
+	l := NewLoop()
+	var calls int32
+	called := make(chan bool, 1)
+	gobottest.Assert(t, l.Running(), false)
+	// act
+	l.Start(time.Millisecond, func() {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case called <- true:
+		default:
+		}
+	})
+	// assert
+	gobottest.Assert(t, l.Running(), true)
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Errorf("function was not called")
+	}
+	// act
+	l.Stop()
+	// assert
+	gobottest.Assert(t, l.Running(), false)
+	stopped := atomic.LoadInt32(&calls)
+	time.Sleep(5 * time.Millisecond)
+	gobottest.Assert(t, atomic.LoadInt32(&calls), stopped)
+	// stopping a stopped loop is possible
+	l.Stop()
+}
+
+func TestLoopStopWaitsForRunningCall(t *testing.T) {
+	// arrange
+	l := NewLoop()
+	entered := make(chan bool)
+	var finished int32
+	l.Start(time.Millisecond, func() {
+		select {
+		case entered <- true:
+		default:
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.StoreInt32(&finished, 1)
+	})
+	<-entered
+	// act
+	l.Stop()
+	// assert
+	gobottest.Assert(t, atomic.LoadInt32(&finished), int32(1))
+}
+
+func TestLoopRestart(t *testing.T) {
+	// arrange
+	l := NewLoop()
+	var first, second int32
+	l.Start(time.Millisecond, func() { atomic.AddInt32(&first, 1) })
+	// act
+	l.Start(time.Millisecond, func() { atomic.AddInt32(&second, 1) })
+	firstCalls := atomic.LoadInt32(&first)
+	time.Sleep(10 * time.Millisecond)
+	l.Stop()
+	// assert
+	gobottest.Assert(t, atomic.LoadInt32(&first), firstCalls)
+	gobottest.Assert(t, atomic.LoadInt32(&second) > 0, true)
+}
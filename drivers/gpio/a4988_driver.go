@@ -0,0 +1,34 @@
+package gpio
+
+var a4988Model = stepStickModel{
+	name: "A4988",
+	microsteps: map[uint][3]byte{
+		1:  {0, 0, 0},
+		2:  {1, 0, 0},
+		4:  {0, 1, 0},
+		8:  {1, 1, 0},
+		16: {1, 1, 1},
+	},
+}
+
+// A4988Driver is a driver for the A4988 stepper motor driver from Allegro, usually used as "StepStick" module.
+// It supports full step and 2, 4, 8 and 16 microsteps by the pins MS1, MS2 and MS3.
+//
+// Datasheet: https://www.allegromicro.com/-/media/files/datasheets/a4988-datasheet.pdf
+type A4988Driver struct {
+	*stepStickDriver
+}
+
+// NewA4988Driver returns a new driver for the A4988 stepper motor driver
+// A - DigitalWriter
+// angle - Full step angle of motor
+// stepPin - Pin corresponding to step input
+// dirPin - Pin corresponding to dir input.  Optional
+// enPin - Pin corresponding to enable input.  Optional
+// sleepPin - Pin corresponding to sleep input.  Optional
+// msPins - Pins corresponding to MS1, MS2 and MS3 inputs.  Optional, needed for microstepping
+func NewA4988Driver(a DigitalWriter, angle float32, stepPin string, dirPin string, enPin string, sleepPin string,
+	msPins [3]string) *A4988Driver {
+	return &A4988Driver{stepStickDriver: newStepStickDriver(&a4988Model, a, angle, stepPin, dirPin, enPin, sleepPin,
+		msPins)}
+}
@@ -0,0 +1,169 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CharacterDisplay is the interface for text displays with a fixed grid of characters, like the HD44780
+// and compatible controllers. Custom characters are written by the byte values 0..7.
+type CharacterDisplay interface {
+	// Size returns the number of columns and rows of the display
+	Size() (cols int, rows int)
+	// Clear clears the display
+	Clear() error
+	// Home returns the cursor to the origin
+	Home() error
+	// SetCursor moves the cursor to the given position, starting with 0
+	SetCursor(col int, row int) error
+	// Write writes the text at the current cursor position
+	Write(message string) error
+	// CreateChar stores a custom character at the given position 0..7
+	CreateChar(pos int, charMap [8]byte) error
+}
+
+// characterDisplayUnknown marks a cell, which content on the display is not known
+const characterDisplayUnknown rune = -1
+
+// CharacterDisplayBuffer is a virtual framebuffer for a CharacterDisplay. All write functions change
+// only the buffer. Flush() transfers the changed cells to the display, so the bus traffic is reduced
+// to the minimum.
+type CharacterDisplayBuffer struct {
+	display CharacterDisplay
+	cols    int
+	rows    int
+	buffer  [][]rune
+	shown   [][]rune
+	mutex   *sync.Mutex
+}
+
+// NewCharacterDisplayBuffer creates a new buffer for the given display. The buffer is initialized with spaces.
+func NewCharacterDisplayBuffer(d CharacterDisplay) *CharacterDisplayBuffer {
+	cols, rows := d.Size()
+	b := &CharacterDisplayBuffer{
+		display: d,
+		cols:    cols,
+		rows:    rows,
+		buffer:  make([][]rune, rows),
+		shown:   make([][]rune, rows),
+		mutex:   &sync.Mutex{},
+	}
+	for row := 0; row < rows; row++ {
+		b.buffer[row] = make([]rune, cols)
+		b.shown[row] = make([]rune, cols)
+		for col := 0; col < cols; col++ {
+			b.buffer[row][col] = ' '
+			b.shown[row][col] = characterDisplayUnknown
+		}
+	}
+	return b
+}
+
+// Size returns the number of columns and rows of the buffer
+func (b *CharacterDisplayBuffer) Size() (int, int) { return b.cols, b.rows }
+
+// Clear fills the buffer with spaces
+func (b *CharacterDisplayBuffer) Clear() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for row := range b.buffer {
+		b.fill(0, row, b.cols, ' ')
+	}
+}
+
+// WriteAt writes the text to the buffer, starting at the given position. Characters outside the
+// visible area are ignored.
+func (b *CharacterDisplayBuffer) WriteAt(col int, row int, text string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.writeAt(col, row, []rune(text))
+}
+
+// WriteLine writes the text to the given row and fills the rest of the row with spaces
+func (b *CharacterDisplayBuffer) WriteLine(row int, text string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	runes := []rune(text)
+	if err := b.writeAt(0, row, runes); err != nil {
+		return err
+	}
+	b.fill(len(runes), row, b.cols-len(runes), ' ')
+	return nil
+}
+
+// Line returns the current content of the buffer for the given row
+func (b *CharacterDisplayBuffer) Line(row int) string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if row < 0 || row >= b.rows {
+		return ""
+	}
+	return string(b.buffer[row])
+}
+
+// Invalidate forces the next Flush() to transfer the whole buffer, e.g. after the display was cleared directly.
+func (b *CharacterDisplayBuffer) Invalidate() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for row := range b.shown {
+		for col := range b.shown[row] {
+			b.shown[row][col] = characterDisplayUnknown
+		}
+	}
+}
+
+// Flush writes all changed parts of the buffer to the display. Each run of changed characters
+// in a row results in one cursor positioning and one write.
+func (b *CharacterDisplayBuffer) Flush() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for row := 0; row < b.rows; row++ {
+		col := 0
+		for col < b.cols {
+			if b.buffer[row][col] == b.shown[row][col] {
+				col++
+				continue
+			}
+			start := col
+			for col < b.cols && b.buffer[row][col] != b.shown[row][col] {
+				col++
+			}
+			if err := b.display.SetCursor(start, row); err != nil {
+				return err
+			}
+			if err := b.display.Write(string(b.buffer[row][start:col])); err != nil {
+				return err
+			}
+			copy(b.shown[row][start:col], b.buffer[row][start:col])
+		}
+	}
+	return nil
+}
+
+func (b *CharacterDisplayBuffer) writeAt(col int, row int, text []rune) error {
+	if row < 0 || row >= b.rows {
+		return fmt.Errorf("Invalid row %d, range (0, %d)", row, b.rows-1)
+	}
+	for i, c := range text {
+		pos := col + i
+		if pos < 0 || pos >= b.cols {
+			continue
+		}
+		b.buffer[row][pos] = c
+	}
+	return nil
+}
+
+func (b *CharacterDisplayBuffer) fill(col int, row int, count int, c rune) {
+	for i := col; i < col+count && i < b.cols; i++ {
+		if i >= 0 {
+			b.buffer[row][i] = c
+		}
+	}
+}
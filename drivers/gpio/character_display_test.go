@@ -0,0 +1,82 @@
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type characterDisplayTest struct {
+	cols     int
+	rows     int
+	calls    []string
+	chars    map[int][8]byte
+	writeErr error
+}
+
+func newCharacterDisplayTest(cols, rows int) *characterDisplayTest {
+	return &characterDisplayTest{cols: cols, rows: rows, chars: map[int][8]byte{}}
+}
+
+func (d *characterDisplayTest) Size() (int, int) { return d.cols, d.rows }
+func (d *characterDisplayTest) Clear() error     { return nil }
+func (d *characterDisplayTest) Home() error      { return nil }
+func (d *characterDisplayTest) SetCursor(col int, row int) error {
+	d.calls = append(d.calls, fmt.Sprintf("%d,%d", col, row))
+	return nil
+}
+func (d *characterDisplayTest) Write(message string) error {
+	d.calls = append(d.calls, message)
+	return d.writeErr
+}
+func (d *characterDisplayTest) CreateChar(pos int, charMap [8]byte) error {
+	d.chars[pos] = charMap
+	return nil
+}
+
+func TestCharacterDisplayBufferFlush(t *testing.T) {
+	d := newCharacterDisplayTest(8, 2)
+	b := NewCharacterDisplayBuffer(d)
+
+	// first flush writes all
+	gobottest.Assert(t, b.WriteAt(0, 0, "Hello"), nil)
+	gobottest.Assert(t, b.Flush(), nil)
+	gobottest.Assert(t, d.calls, []string{"0,0", "Hello   ", "0,1", "        "})
+
+	// unchanged buffer writes nothing
+	d.calls = nil
+	gobottest.Assert(t, b.Flush(), nil)
+	gobottest.Assert(t, len(d.calls), 0)
+
+	// only the changed runs are written
+	gobottest.Assert(t, b.WriteAt(1, 0, "a"), nil)
+	gobottest.Assert(t, b.WriteAt(4, 0, "!!"), nil)
+	gobottest.Assert(t, b.WriteAt(6, 1, "xyz"), nil)
+	gobottest.Assert(t, b.Flush(), nil)
+	gobottest.Assert(t, d.calls, []string{"1,0", "a", "4,0", "!!", "6,1", "xy"})
+	gobottest.Assert(t, b.Line(0), "Hall!!  ")
+	gobottest.Assert(t, b.Line(2), "")
+
+	// after invalidation all is written again
+	d.calls = nil
+	b.Invalidate()
+	gobottest.Assert(t, b.Flush(), nil)
+	gobottest.Assert(t, d.calls, []string{"0,0", "Hall!!  ", "0,1", "      xy"})
+
+	d.calls = nil
+	b.Clear()
+	gobottest.Assert(t, b.WriteLine(1, "new"), nil)
+	gobottest.Assert(t, b.Flush(), nil)
+	gobottest.Assert(t, d.calls, []string{"0,0", "      ", "0,1", "new", "6,1", "  "})
+
+	gobottest.Refute(t, b.WriteAt(0, 2, "x"), nil)
+}
+
+func TestCharacterDisplayBufferFlushError(t *testing.T) {
+	d := newCharacterDisplayTest(4, 1)
+	d.writeErr = errors.New("write error")
+	b := NewCharacterDisplayBuffer(d)
+	gobottest.Assert(t, b.Flush(), errors.New("write error"))
+}
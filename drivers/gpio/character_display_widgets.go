@@ -0,0 +1,259 @@
+package gpio
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+// CharacterMarquee scrolls a text, which is longer than the display width, through one row of a
+// CharacterDisplayBuffer. Shorter texts are shown without scrolling.
+type CharacterMarquee struct {
+	buffer *CharacterDisplayBuffer
+	row    int
+	text   []rune
+	gap    int
+	offset int
+	mutex  *sync.Mutex
+}
+
+// NewCharacterMarquee creates a new marquee for the given row of the buffer
+func NewCharacterMarquee(b *CharacterDisplayBuffer, row int, text string) *CharacterMarquee {
+	return &CharacterMarquee{
+		buffer: b,
+		row:    row,
+		text:   []rune(text),
+		gap:    3,
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// SetText changes the text and restarts the scrolling
+func (m *CharacterMarquee) SetText(text string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.text = []rune(text)
+	m.offset = 0
+}
+
+// SetGap sets the number of spaces between the end and the restart of the text, default is 3
+func (m *CharacterMarquee) SetGap(gap int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.gap = gap
+}
+
+// Step writes the visible part of the text to the buffer and advances the text by one character.
+// Call it periodically, e.g. by gobot.Every(), followed by a Flush() of the buffer.
+func (m *CharacterMarquee) Step() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	cols, _ := m.buffer.Size()
+	if len(m.text) <= cols {
+		return m.buffer.WriteLine(m.row, string(m.text))
+	}
+
+	loop := append(append([]rune{}, m.text...), []rune(strings.Repeat(" ", m.gap))...)
+	visible := make([]rune, cols)
+	for i := range visible {
+		visible[i] = loop[(m.offset+i)%len(loop)]
+	}
+	m.offset = (m.offset + 1) % len(loop)
+	return m.buffer.WriteLine(m.row, string(visible))
+}
+
+// characterProgressChars are the custom characters for 1..5 filled pixel columns of a 5x8 cell
+var characterProgressChars = [5][8]byte{
+	{0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10},
+	{0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18},
+	{0x1C, 0x1C, 0x1C, 0x1C, 0x1C, 0x1C, 0x1C, 0x1C},
+	{0x1E, 0x1E, 0x1E, 0x1E, 0x1E, 0x1E, 0x1E, 0x1E},
+	{0x1F, 0x1F, 0x1F, 0x1F, 0x1F, 0x1F, 0x1F, 0x1F},
+}
+
+// CharacterProgressBar draws a horizontal bar with a resolution of 5 steps per character. It needs 5
+// consecutive custom characters, starting at the given position.
+type CharacterProgressBar struct {
+	buffer   *CharacterDisplayBuffer
+	col      int
+	row      int
+	width    int
+	charBase int
+}
+
+// NewCharacterProgressBar creates a new progress bar with the given width at the position of the buffer.
+// The custom characters are stored at charBase..charBase+4, so charBase must be in the range 0..3.
+func NewCharacterProgressBar(b *CharacterDisplayBuffer, col int, row int, width int, charBase int) *CharacterProgressBar {
+	return &CharacterProgressBar{
+		buffer:   b,
+		col:      col,
+		row:      row,
+		width:    width,
+		charBase: charBase,
+	}
+}
+
+// Init creates the needed custom characters on the display
+func (p *CharacterProgressBar) Init() error {
+	if p.charBase < 0 || p.charBase > 3 {
+		return errors.New("the progress bar needs 5 custom characters, so the base must be in range 0..3")
+	}
+	for i, charMap := range characterProgressChars {
+		if err := p.buffer.display.CreateChar(p.charBase+i, charMap); err != nil {
+			return err
+		}
+	}
+	// the shown characters maybe changed
+	p.buffer.Invalidate()
+	return nil
+}
+
+// Set writes the bar for the given fraction (0.0...1.0) to the buffer
+func (p *CharacterProgressBar) Set(fraction float64) error {
+	fraction = math.Max(0, math.Min(1, fraction))
+	steps := int(math.Round(fraction * float64(p.width*5)))
+
+	bar := make([]rune, p.width)
+	for i := range bar {
+		switch {
+		case steps >= 5:
+			bar[i] = rune(p.charBase + 4)
+			steps -= 5
+		case steps > 0:
+			bar[i] = rune(p.charBase + steps - 1)
+			steps = 0
+		default:
+			bar[i] = ' '
+		}
+	}
+	return p.buffer.WriteAt(p.col, p.row, string(bar))
+}
+
+// CharacterMenuItem is one entry of a CharacterMenu
+type CharacterMenuItem struct {
+	Label  string
+	Action func()
+}
+
+// CharacterMenu is a scrolling list of items, which uses all rows of a CharacterDisplayBuffer. The
+// selected item is marked by a leading ">". The menu can be driven by buttons, see BindButtons().
+type CharacterMenu struct {
+	buffer   *CharacterDisplayBuffer
+	items    []CharacterMenuItem
+	selected int
+	top      int
+	mutex    *sync.Mutex
+}
+
+// NewCharacterMenu creates a new menu with the given items
+func NewCharacterMenu(b *CharacterDisplayBuffer, items []CharacterMenuItem) *CharacterMenu {
+	return &CharacterMenu{
+		buffer: b,
+		items:  items,
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// Selected returns the index of the selected item
+func (m *CharacterMenu) Selected() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.selected
+}
+
+// Up selects the previous item and redraws the menu
+func (m *CharacterMenu) Up() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.selected > 0 {
+		m.selected--
+	}
+	return m.render()
+}
+
+// Down selects the next item and redraws the menu
+func (m *CharacterMenu) Down() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.selected < len(m.items)-1 {
+		m.selected++
+	}
+	return m.render()
+}
+
+// Select calls the action of the selected item
+func (m *CharacterMenu) Select() {
+	m.mutex.Lock()
+	var action func()
+	if m.selected < len(m.items) {
+		action = m.items[m.selected].Action
+	}
+	m.mutex.Unlock()
+
+	if action != nil {
+		action()
+	}
+}
+
+// Render draws the menu into the buffer and flushes it to the display
+func (m *CharacterMenu) Render() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.render()
+}
+
+// BindButtons connects the push events of the given buttons (e.g. ButtonDriver) to Up(), Down() and Select().
+// A nil value skips the binding.
+func (m *CharacterMenu) BindButtons(up, down, sel gobot.Eventer) error {
+	if up != nil {
+		if err := up.On(ButtonPush, func(interface{}) { _ = m.Up() }); err != nil {
+			return err
+		}
+	}
+	if down != nil {
+		if err := down.On(ButtonPush, func(interface{}) { _ = m.Down() }); err != nil {
+			return err
+		}
+	}
+	if sel != nil {
+		if err := sel.On(ButtonPush, func(interface{}) { m.Select() }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *CharacterMenu) render() error {
+	_, rows := m.buffer.Size()
+	if m.selected < m.top {
+		m.top = m.selected
+	}
+	if m.selected >= m.top+rows {
+		m.top = m.selected - rows + 1
+	}
+
+	for row := 0; row < rows; row++ {
+		idx := m.top + row
+		line := ""
+		if idx < len(m.items) {
+			line = " " + m.items[idx].Label
+			if idx == m.selected {
+				line = ">" + m.items[idx].Label
+			}
+		}
+		if err := m.buffer.WriteLine(row, line); err != nil {
+			return err
+		}
+	}
+	return m.buffer.Flush()
+}
@@ -0,0 +1,88 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestCharacterMarquee(t *testing.T) {
+	b := NewCharacterDisplayBuffer(newCharacterDisplayTest(4, 2))
+	m := NewCharacterMarquee(b, 1, "abcdef")
+	m.SetGap(1)
+
+	var lines []string
+	for i := 0; i < 8; i++ {
+		gobottest.Assert(t, m.Step(), nil)
+		lines = append(lines, b.Line(1))
+	}
+	gobottest.Assert(t, lines, []string{"abcd", "bcde", "cdef", "def ", "ef a", "f ab", " abc", "abcd"})
+
+	m.SetText("ab")
+	gobottest.Assert(t, m.Step(), nil)
+	gobottest.Assert(t, b.Line(1), "ab  ")
+}
+
+func TestCharacterProgressBar(t *testing.T) {
+	d := newCharacterDisplayTest(6, 1)
+	b := NewCharacterDisplayBuffer(d)
+	p := NewCharacterProgressBar(b, 1, 0, 4, 2)
+
+	gobottest.Assert(t, p.Init(), nil)
+	gobottest.Assert(t, len(d.chars), 5)
+	gobottest.Assert(t, d.chars[6], characterProgressChars[4])
+
+	// 4 chars with 5 steps each, 0.5 => 10 steps
+	gobottest.Assert(t, p.Set(0.5), nil)
+	gobottest.Assert(t, b.Line(0), string([]rune{' ', 6, 6, ' ', ' ', ' '}))
+	// 0.6 => 12 steps
+	gobottest.Assert(t, p.Set(0.6), nil)
+	gobottest.Assert(t, b.Line(0), string([]rune{' ', 6, 6, 3, ' ', ' '}))
+	gobottest.Assert(t, p.Set(2), nil)
+	gobottest.Assert(t, b.Line(0), string([]rune{' ', 6, 6, 6, 6, ' '}))
+
+	gobottest.Refute(t, NewCharacterProgressBar(b, 0, 0, 4, 4).Init(), nil)
+}
+
+func TestCharacterMenu(t *testing.T) {
+	b := NewCharacterDisplayBuffer(newCharacterDisplayTest(6, 2))
+	selected := ""
+	m := NewCharacterMenu(b, []CharacterMenuItem{
+		{Label: "one", Action: func() { selected = "one" }},
+		{Label: "two"},
+		{Label: "three", Action: func() { selected = "three" }},
+	})
+
+	gobottest.Assert(t, m.Render(), nil)
+	gobottest.Assert(t, b.Line(0), ">one  ")
+	gobottest.Assert(t, b.Line(1), " two  ")
+
+	gobottest.Assert(t, m.Down(), nil)
+	gobottest.Assert(t, m.Down(), nil)
+	gobottest.Assert(t, m.Down(), nil)
+	gobottest.Assert(t, m.Selected(), 2)
+	gobottest.Assert(t, b.Line(0), " two  ")
+	gobottest.Assert(t, b.Line(1), ">three")
+	m.Select()
+	gobottest.Assert(t, selected, "three")
+
+	gobottest.Assert(t, m.Up(), nil)
+	gobottest.Assert(t, m.Up(), nil)
+	gobottest.Assert(t, m.Up(), nil)
+	gobottest.Assert(t, m.Selected(), 0)
+	gobottest.Assert(t, b.Line(0), ">one  ")
+}
+
+func TestCharacterMenuBindButtons(t *testing.T) {
+	b := NewCharacterDisplayBuffer(newCharacterDisplayTest(6, 2))
+	m := NewCharacterMenu(b, []CharacterMenuItem{{Label: "one"}, {Label: "two"}})
+	down := gobot.NewEventer()
+	down.AddEvent(ButtonPush)
+	gobottest.Assert(t, m.BindButtons(nil, down, nil), nil)
+
+	down.Publish(ButtonPush, 1)
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, m.Selected(), 1)
+}
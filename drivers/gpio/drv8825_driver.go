@@ -0,0 +1,39 @@
+package gpio
+
+var drv8825Model = stepStickModel{
+	name: "DRV8825",
+	microsteps: map[uint][3]byte{
+		1:  {0, 0, 0},
+		2:  {1, 0, 0},
+		4:  {0, 1, 0},
+		8:  {1, 1, 0},
+		16: {0, 0, 1},
+		32: {1, 0, 1},
+	},
+}
+
+// DRV8825Driver is a driver for the DRV8825 stepper motor driver from Texas Instruments, usually used as
+// "StepStick" module. It supports full step and 2, 4, 8, 16 and 32 microsteps by the pins MODE0, MODE1 and MODE2.
+// The fault output is monitored, if a pin is given. The motor is stopped on fault.
+//
+// Datasheet: https://www.ti.com/lit/ds/symlink/drv8825.pdf
+type DRV8825Driver struct {
+	*stepStickDriver
+}
+
+// NewDRV8825Driver returns a new driver for the DRV8825 stepper motor driver
+// A - DigitalWriter, needs to be a DigitalReader too, if the fault pin is given
+// angle - Full step angle of motor
+// stepPin - Pin corresponding to step input
+// dirPin - Pin corresponding to dir input.  Optional
+// enPin - Pin corresponding to enable input.  Optional
+// sleepPin - Pin corresponding to sleep input.  Optional
+// modePins - Pins corresponding to MODE0, MODE1 and MODE2 inputs.  Optional, needed for microstepping
+// faultPin - Pin corresponding to fault output.  Optional
+func NewDRV8825Driver(a DigitalWriter, angle float32, stepPin string, dirPin string, enPin string, sleepPin string,
+	modePins [3]string, faultPin string) *DRV8825Driver {
+	d := &DRV8825Driver{stepStickDriver: newStepStickDriver(&drv8825Model, a, angle, stepPin, dirPin, enPin,
+		sleepPin, modePins)}
+	d.faultPin = faultPin
+	return d
+}
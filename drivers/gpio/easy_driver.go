@@ -3,6 +3,7 @@ package gpio
 import (
 	"errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -26,6 +27,7 @@ type EasyDriver struct {
 	stepNum  int
 	enabled  bool
 	sleeping bool
+	mutex    *sync.Mutex // guards the moving state, which is changed from other goroutines by Stop()
 }
 
 // NewEasyDriver returns a new EasyDriver from SparkFun (https://www.sparkfun.com/products/12779)
@@ -52,6 +54,7 @@ func NewEasyDriver(a DigitalWriter, angle float32, stepPin string, dirPin string
 		dir:      1,
 		enabled:  true,
 		sleeping: false,
+		mutex:    &sync.Mutex{},
 	}
 
 	// panic if step pin isn't set
@@ -99,16 +102,14 @@ func (d *EasyDriver) Halt() (err error) {
 
 // Move the motor given number of degrees at current speed.
 func (d *EasyDriver) Move(degs int) (err error) {
-	if d.moving {
+	if !d.startMoving() {
 		// don't do anything if already moving
 		return
 	}
 
-	d.moving = true
-
 	steps := int(float32(degs) / d.angle)
 	for i := 0; i < steps; i++ {
-		if !d.moving {
+		if !d.IsMoving() {
 			// don't continue to step if driver is stopped
 			break
 		}
@@ -116,7 +117,7 @@ func (d *EasyDriver) Move(degs int) (err error) {
 		d.Step()
 	}
 
-	d.moving = false
+	d.Stop()
 
 	return
 }
@@ -140,15 +141,13 @@ func (d *EasyDriver) Step() (err error) {
 
 // Run the stepper continuously
 func (d *EasyDriver) Run() (err error) {
-	if d.moving {
+	if !d.startMoving() {
 		// don't do anything if already moving
 		return
 	}
 
-	d.moving = true
-
 	go func() {
-		for d.moving {
+		for d.IsMoving() {
 			d.Step()
 		}
 	}()
@@ -158,6 +157,9 @@ func (d *EasyDriver) Run() (err error) {
 
 // Stop running the stepper
 func (d *EasyDriver) Stop() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	d.moving = false
 	return
 }
@@ -205,9 +207,24 @@ func (d *EasyDriver) GetCurrentStep() int {
 
 // IsMoving returns a bool stating whether motor is currently in motion
 func (d *EasyDriver) IsMoving() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	return d.moving
 }
 
+// startMoving sets the moving state, returns false if the motor is already moving
+func (d *EasyDriver) startMoving() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.moving {
+		return false
+	}
+	d.moving = true
+	return true
+}
+
 // Enable enables all motor output
 func (d *EasyDriver) Enable() (err error) {
 	// can't enable if enPin isn't set.  This is fine normally since it will be enabled by default
@@ -14,6 +14,9 @@ const (
 
 var adapter *gpioTestAdaptor
 
+// this ensures that the implementation is based on the Stepper interface
+var _ Stepper = (*EasyDriver)(nil)
+
 func initEasyDriver() *EasyDriver {
 	adapter = newGpioTestAdaptor()
 	return NewEasyDriver(adapter, stepAngle, "1", "2", "3", "4")
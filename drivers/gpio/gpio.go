@@ -40,6 +40,10 @@ const (
 	MotionDetected = "motion-detected"
 	// MotionStopped event
 	MotionStopped = "motion-stopped"
+	// StepperFault event
+	StepperFault = "fault"
+	// StepperFaultCleared event
+	StepperFaultCleared = "fault-cleared"
 )
 
 // PwmWriter interface represents an Adaptor which has Pwm capabilities
@@ -52,6 +56,11 @@ type ServoWriter interface {
 	ServoWrite(string, byte) (err error)
 }
 
+// ServoPulseWriter interface represents an Adaptor which can write servo pulses with a given width in microseconds
+type ServoPulseWriter interface {
+	ServoPulseWrite(string, uint16) (err error)
+}
+
 // DigitalWriter interface represents an Adaptor which has DigitalWrite capabilities
 type DigitalWriter interface {
 	DigitalWrite(string, byte) (err error)
@@ -61,3 +70,19 @@ type DigitalWriter interface {
 type DigitalReader interface {
 	DigitalRead(string) (val int, err error)
 }
+
+// Stepper interface represents a stepper motor driver with step and direction input like the EasyDriver
+type Stepper interface {
+	Move(degs int) (err error)
+	Step() (err error)
+	Run() (err error)
+	Stop() (err error)
+	SetDirection(dir string) (err error)
+	SetSpeed(rpm uint) (err error)
+	GetMaxSpeed() uint
+	GetCurrentStep() int
+	IsMoving() bool
+	Enable() (err error)
+	Disable() (err error)
+	IsEnabled() bool
+}
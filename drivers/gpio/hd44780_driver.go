@@ -278,6 +278,9 @@ func (h *HD44780Driver) Write(message string) (err error) {
 	return nil
 }
 
+// Size returns the number of columns and rows of the display
+func (h *HD44780Driver) Size() (int, int) { return h.cols, h.rows }
+
 // Clear clear the display
 func (h *HD44780Driver) Clear() (err error) {
 	h.mutex.Lock()
@@ -10,6 +10,7 @@ import (
 )
 
 var _ gobot.Driver = (*HD44780Driver)(nil)
+var _ CharacterDisplay = (*HD44780Driver)(nil)
 
 // --------- HELPERS
 func initTestHD44780Driver() (driver *HD44780Driver) {
@@ -248,3 +249,10 @@ func TestHD44780DriverCreateCharError(t *testing.T) {
 	charMap := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
 	gobottest.Assert(t, d.CreateChar(8, charMap), errors.New("can't set a custom character at a position greater than 7"))
 }
+
+func TestHD44780DriverSize(t *testing.T) {
+	d := initTestHD44780Driver()
+	cols, rows := d.Size()
+	gobottest.Assert(t, cols, 2)
+	gobottest.Assert(t, rows, 16)
+}
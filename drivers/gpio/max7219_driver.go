@@ -1,7 +1,11 @@
 package gpio
 
 import (
+	"fmt"
+	"image"
+
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mono"
 )
 
 // Access and command constants for the driver
@@ -104,6 +108,34 @@ func (a *MAX7219Driver) ClearOne(which uint) {
 	}
 }
 
+// NewFramebuffer creates a framebuffer for all chained 8x8 matrix modules, to be used with DrawFramebuffer().
+// The module 0 (see One()) shows the columns 0..7.
+func (a *MAX7219Driver) NewFramebuffer() *mono.Framebuffer {
+	return mono.NewFramebuffer(8*int(a.count), 8)
+}
+
+// DrawFramebuffer sends only the changed rows of the framebuffer to the matrix modules.
+func (a *MAX7219Driver) DrawFramebuffer(fb *mono.Framebuffer) error {
+	if w, h := fb.PhysicalSize(); w != 8*int(a.count) || h != 8 {
+		return fmt.Errorf("framebuffer must have a size of %dx8", 8*a.count)
+	}
+	r := fb.Changed()
+	if r.Empty() {
+		return nil
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		data := fb.RowBytes(y, 0, 8*int(a.count))
+		a.pinCS.Off()
+		for c := range data {
+			a.send(byte(MAX7219Digit0 + y))
+			a.send(data[c])
+		}
+		a.pinCS.On()
+	}
+	fb.Commit(image.Rect(0, r.Min.Y, 8*int(a.count), r.Max.Y))
+	return nil
+}
+
 // sendData is an auxiliary function to send data to the MAX7219Driver module
 func (a *MAX7219Driver) sendData(address byte, data byte) {
 	a.pinCS.Off()
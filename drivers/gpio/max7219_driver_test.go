@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mono"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -50,3 +51,52 @@ func TestMAX7219DriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestMAX7219DriverDrawFramebuffer(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewMAX7219Driver(a, "clk", "data", "cs", 2)
+	fb := d.NewFramebuffer()
+	gobottest.Assert(t, fb.Bounds().Dx(), 16)
+	fb.Commit(fb.Bounds())
+
+	// record the bits, shifted at each rising clock edge
+	var bits []byte
+	var data byte
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		switch pin {
+		case "data":
+			data = val
+		case "clk":
+			if val == 1 {
+				bits = append(bits, data)
+			}
+		}
+		return nil
+	})
+
+	fb.SetPixel(0, 2, true)
+	fb.SetPixel(15, 2, true)
+	gobottest.Assert(t, d.DrawFramebuffer(fb), nil)
+	// 2 modules, each with address and data
+	gobottest.Assert(t, len(bits), 32)
+	gobottest.Assert(t, bitsToBytes(bits), []byte{MAX7219Digit2, 0x80, MAX7219Digit2, 0x01})
+
+	// nothing changed
+	bits = nil
+	gobottest.Assert(t, d.DrawFramebuffer(fb), nil)
+	gobottest.Assert(t, len(bits), 0)
+
+	gobottest.Refute(t, d.DrawFramebuffer(mono.NewFramebuffer(8, 8)), nil)
+}
+
+func bitsToBytes(bits []byte) []byte {
+	var result []byte
+	for i := 0; i+8 <= len(bits); i += 8 {
+		var b byte
+		for _, bit := range bits[i : i+8] {
+			b = b<<1 | bit
+		}
+		result = append(result, b)
+	}
+	return result
+}
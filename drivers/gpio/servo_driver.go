@@ -1,12 +1,36 @@
 package gpio
 
-import "gobot.io/x/gobot"
+import (
+	"math"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// servoFrameInterval is the time between two position updates of a timed move, which matches the
+// usual 50Hz servo frame
+const servoFrameInterval = 20 * time.Millisecond
+
+// ServoCalibration contains the per servo settings to compensate mechanical differences.
+// The zero value means no calibration.
+type ServoCalibration struct {
+	// MinPulse and MaxPulse are the pulse widths in microseconds for 0 and 180 degree. They are
+	// only used, if both are set and the connection is a ServoPulseWriter.
+	MinPulse uint16 `json:"minPulse"`
+	MaxPulse uint16 `json:"maxPulse"`
+	// Trim is an offset in degree, which is added to each requested angle
+	Trim float64 `json:"trim"`
+	// Reversed mirrors the direction of movement
+	Reversed bool `json:"reversed"`
+}
 
 // ServoDriver Represents a Servo
 type ServoDriver struct {
-	name       string
-	pin        string
-	connection ServoWriter
+	name        string
+	pin         string
+	connection  ServoWriter
+	calibration ServoCalibration
+	position    float64
 	gobot.Commander
 	CurrentAngle byte
 }
@@ -63,13 +87,47 @@ func (s *ServoDriver) Start() (err error) { return }
 // Halt implements the Driver interface
 func (s *ServoDriver) Halt() (err error) { return }
 
+// SetCalibration sets the calibration, which is used for all following moves
+func (s *ServoDriver) SetCalibration(c ServoCalibration) { s.calibration = c }
+
+// Calibration returns the current calibration of the servo
+func (s *ServoDriver) Calibration() ServoCalibration { return s.calibration }
+
 // Move sets the servo to the specified angle. Acceptable angles are 0-180
 func (s *ServoDriver) Move(angle uint8) (err error) {
 	if !(angle >= 0 && angle <= 180) {
 		return ErrServoOutOfRange
 	}
-	s.CurrentAngle = angle
-	return s.connection.ServoWrite(s.Pin(), angle)
+	return s.write(float64(angle))
+}
+
+// MoveTimed moves the servo from the current angle to the given angle in the given time. The intermediate
+// positions are calculated by the given easing function, which defaults to EaseLinear when nil.
+// The function blocks until the move has finished.
+func (s *ServoDriver) MoveTimed(angle uint8, duration time.Duration, easing Easing) (err error) {
+	if angle > 180 {
+		return ErrServoOutOfRange
+	}
+	if easing == nil {
+		easing = EaseLinear
+	}
+
+	from := s.position
+	to := float64(angle)
+	start := time.Now()
+	for {
+		elapsed := time.Since(start)
+		if elapsed >= duration {
+			break
+		}
+		progress := easing(float64(elapsed) / float64(duration))
+		if err = s.write(from + (to-from)*progress); err != nil {
+			return err
+		}
+		time.Sleep(servoFrameInterval)
+	}
+
+	return s.write(to)
 }
 
 // Min sets the servo to it's minimum position
@@ -86,3 +144,23 @@ func (s *ServoDriver) Center() (err error) {
 func (s *ServoDriver) Max() (err error) {
 	return s.Move(180)
 }
+
+// write applies the calibration and writes the position to the connection
+func (s *ServoDriver) write(angle float64) (err error) {
+	angle = math.Max(0, math.Min(180, angle))
+	s.position = angle
+	s.CurrentAngle = byte(math.Round(angle))
+
+	calibrated := angle + s.calibration.Trim
+	if s.calibration.Reversed {
+		calibrated = 180 - calibrated
+	}
+	calibrated = math.Max(0, math.Min(180, calibrated))
+
+	if pw, ok := s.connection.(ServoPulseWriter); ok && s.calibration.MinPulse > 0 && s.calibration.MaxPulse > 0 {
+		pulse := gobot.Rescale(calibrated, 0, 180, float64(s.calibration.MinPulse), float64(s.calibration.MaxPulse))
+		return pw.ServoPulseWrite(s.Pin(), uint16(math.Round(pulse)))
+	}
+
+	return s.connection.ServoWrite(s.Pin(), byte(math.Round(calibrated)))
+}
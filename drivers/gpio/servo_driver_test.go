@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -87,3 +88,76 @@ func TestServoDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestServoDriverCalibration(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(a, "1")
+	var written byte
+	a.TestAdaptorServoWrite(func(pin string, val byte) (err error) {
+		written = val
+		return nil
+	})
+
+	d.SetCalibration(ServoCalibration{Trim: 5, Reversed: true})
+	gobottest.Assert(t, d.Calibration().Trim, 5.0)
+	gobottest.Assert(t, d.Move(30), nil)
+	gobottest.Assert(t, d.CurrentAngle, uint8(30))
+	gobottest.Assert(t, written, uint8(145))
+
+	// trim is limited to the range
+	gobottest.Assert(t, d.Move(0), nil)
+	gobottest.Assert(t, written, uint8(175))
+	d.SetCalibration(ServoCalibration{Trim: -5})
+	gobottest.Assert(t, d.Move(0), nil)
+	gobottest.Assert(t, written, uint8(0))
+}
+
+type gpioTestServoPulseAdaptor struct {
+	*gpioTestAdaptor
+	pulse uint16
+}
+
+func (t *gpioTestServoPulseAdaptor) ServoPulseWrite(pin string, val uint16) (err error) {
+	t.pulse = val
+	return nil
+}
+
+func TestServoDriverCalibrationPulse(t *testing.T) {
+	a := &gpioTestServoPulseAdaptor{gpioTestAdaptor: newGpioTestAdaptor()}
+	d := NewServoDriver(a, "1")
+
+	// without pulse widths the angle is used
+	gobottest.Assert(t, d.Move(90), nil)
+	gobottest.Assert(t, a.pulse, uint16(0))
+
+	d.SetCalibration(ServoCalibration{MinPulse: 500, MaxPulse: 2500})
+	gobottest.Assert(t, d.Move(90), nil)
+	gobottest.Assert(t, a.pulse, uint16(1500))
+	gobottest.Assert(t, d.Max(), nil)
+	gobottest.Assert(t, a.pulse, uint16(2500))
+}
+
+func TestServoDriverMoveTimed(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(a, "1")
+	var written []byte
+	a.TestAdaptorServoWrite(func(pin string, val byte) (err error) {
+		written = append(written, val)
+		return nil
+	})
+
+	gobottest.Assert(t, d.MoveTimed(180, 100*time.Millisecond, EaseInOutSine), nil)
+	gobottest.Assert(t, d.CurrentAngle, uint8(180))
+	gobottest.Assert(t, len(written) > 2, true)
+	gobottest.Assert(t, written[len(written)-1], uint8(180))
+	for i := 1; i < len(written); i++ {
+		gobottest.Assert(t, written[i] >= written[i-1], true)
+	}
+
+	gobottest.Assert(t, d.MoveTimed(181, time.Millisecond, nil), ErrServoOutOfRange)
+
+	a.TestAdaptorServoWrite(func(pin string, val byte) (err error) {
+		return errors.New("pwm error")
+	})
+	gobottest.Assert(t, d.MoveTimed(0, 10*time.Millisecond, nil), errors.New("pwm error"))
+}
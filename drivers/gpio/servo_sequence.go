@@ -0,0 +1,191 @@
+package gpio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Easing maps the progress of a move (0.0...1.0) to the progress of the position (0.0...1.0)
+type Easing func(t float64) float64
+
+// EaseLinear moves with constant speed
+func EaseLinear(t float64) float64 { return t }
+
+// EaseInQuad starts slow and accelerates
+func EaseInQuad(t float64) float64 { return t * t }
+
+// EaseOutQuad starts fast and decelerates
+func EaseOutQuad(t float64) float64 { return t * (2 - t) }
+
+// EaseInOutQuad accelerates until the half way and decelerates afterwards
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInOutCubic is like EaseInOutQuad, but with a stronger acceleration
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return (t-1)*(2*t-2)*(2*t-2) + 1
+}
+
+// EaseInOutSine accelerates and decelerates along a sine curve
+func EaseInOutSine(t float64) float64 { return -(math.Cos(math.Pi*t) - 1) / 2 }
+
+var easings = map[string]Easing{
+	"":           EaseLinear,
+	"linear":     EaseLinear,
+	"inQuad":     EaseInQuad,
+	"outQuad":    EaseOutQuad,
+	"inOutQuad":  EaseInOutQuad,
+	"inOutCubic": EaseInOutCubic,
+	"inOutSine":  EaseInOutSine,
+}
+
+// EasingByName returns the easing function for the given name, which is used in a ServoKeyframe.
+// Known names are "linear", "inQuad", "outQuad", "inOutQuad", "inOutCubic" and "inOutSine".
+func EasingByName(name string) (Easing, error) {
+	e, ok := easings[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown easing '%s'", name)
+	}
+	return e, nil
+}
+
+// ServoKeyframe describes the target angles of some servos, which are reached after the given duration,
+// starting at the end of the previous keyframe. Servos not mentioned keep their position.
+// In JSON the duration is given in nanoseconds, like the underlying time.Duration.
+type ServoKeyframe struct {
+	Duration time.Duration      `json:"duration"`
+	Easing   string             `json:"easing,omitempty"`
+	Angles   map[string]float64 `json:"angles"`
+}
+
+// ServoSequence is a list of keyframes, which can be saved and loaded as JSON
+type ServoSequence struct {
+	Name      string          `json:"name,omitempty"`
+	Keyframes []ServoKeyframe `json:"keyframes"`
+}
+
+// LoadServoSequence reads a sequence in JSON format from the given reader
+func LoadServoSequence(r io.Reader) (*ServoSequence, error) {
+	var seq ServoSequence
+	if err := json.NewDecoder(r).Decode(&seq); err != nil {
+		return nil, err
+	}
+	for i, kf := range seq.Keyframes {
+		if _, err := EasingByName(kf.Easing); err != nil {
+			return nil, fmt.Errorf("keyframe %d: %v", i, err)
+		}
+	}
+	return &seq, nil
+}
+
+// Save writes the sequence in JSON format to the given writer
+func (seq *ServoSequence) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(seq)
+}
+
+// ServoSequencer plays sequences synchronously on a group of named servos, e.g. the legs of a hexapod.
+type ServoSequencer struct {
+	servos map[string]*ServoDriver
+	mutex  sync.Mutex
+	stop   chan struct{}
+}
+
+// NewServoSequencer creates a new sequencer for the given servos. The key of the map is used as
+// reference in the keyframes.
+func NewServoSequencer(servos map[string]*ServoDriver) *ServoSequencer {
+	return &ServoSequencer{
+		servos: servos,
+		stop:   make(chan struct{}, 1),
+	}
+}
+
+// Play runs all keyframes of the sequence and blocks until it has finished or Stop() was called.
+func (sq *ServoSequencer) Play(seq *ServoSequence) error {
+	sq.mutex.Lock()
+	defer sq.mutex.Unlock()
+
+	// drop an outdated stop request
+	select {
+	case <-sq.stop:
+	default:
+	}
+
+	for i, kf := range seq.Keyframes {
+		if err := sq.playKeyframe(kf); err != nil {
+			if err == errServoSequenceStopped {
+				return nil
+			}
+			return fmt.Errorf("keyframe %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Stop aborts the currently playing sequence. The servos keep their current position.
+func (sq *ServoSequencer) Stop() {
+	select {
+	case sq.stop <- struct{}{}:
+	default:
+	}
+}
+
+var errServoSequenceStopped = errors.New("servo sequence stopped")
+
+func (sq *ServoSequencer) playKeyframe(kf ServoKeyframe) error {
+	easing, err := EasingByName(kf.Easing)
+	if err != nil {
+		return err
+	}
+
+	from := make(map[string]float64, len(kf.Angles))
+	for name, angle := range kf.Angles {
+		servo, ok := sq.servos[name]
+		if !ok {
+			return fmt.Errorf("unknown servo '%s'", name)
+		}
+		if angle < 0 || angle > 180 {
+			return ErrServoOutOfRange
+		}
+		from[name] = servo.position
+	}
+
+	start := time.Now()
+	for {
+		elapsed := time.Since(start)
+		if elapsed >= kf.Duration {
+			break
+		}
+		progress := easing(float64(elapsed) / float64(kf.Duration))
+		for name, to := range kf.Angles {
+			if err := sq.servos[name].write(from[name] + (to-from[name])*progress); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-sq.stop:
+			return errServoSequenceStopped
+		case <-time.After(servoFrameInterval):
+		}
+	}
+
+	for name, to := range kf.Angles {
+		if err := sq.servos[name].write(to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
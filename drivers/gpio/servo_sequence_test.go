@@ -0,0 +1,87 @@
+package gpio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestEasings(t *testing.T) {
+	for name, e := range easings {
+		gobottest.Assert(t, e(0) < 0.0001, true)
+		gobottest.Assert(t, e(1) > 0.9999, true)
+		gobottest.Assert(t, e(0.5) > 0.2 && e(0.5) < 0.8, true)
+		_, err := EasingByName(name)
+		gobottest.Assert(t, err, nil)
+	}
+	_, err := EasingByName("bounce")
+	gobottest.Refute(t, err, nil)
+}
+
+func TestServoSequenceSaveLoad(t *testing.T) {
+	seq := &ServoSequence{
+		Name: "wave",
+		Keyframes: []ServoKeyframe{
+			{Duration: 50 * time.Millisecond, Easing: "inOutSine", Angles: map[string]float64{"a": 10, "b": 170}},
+			{Duration: 20 * time.Millisecond, Angles: map[string]float64{"a": 90}},
+		},
+	}
+	var buf bytes.Buffer
+	gobottest.Assert(t, seq.Save(&buf), nil)
+
+	loaded, err := LoadServoSequence(&buf)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, loaded, seq)
+
+	_, err = LoadServoSequence(strings.NewReader(`{"keyframes":[{"easing":"bounce"}]}`))
+	gobottest.Assert(t, err.Error(), "keyframe 0: unknown easing 'bounce'")
+	_, err = LoadServoSequence(strings.NewReader(`{`))
+	gobottest.Refute(t, err, nil)
+}
+
+func TestServoSequencerPlay(t *testing.T) {
+	a := newGpioTestAdaptor()
+	written := map[string]byte{}
+	a.TestAdaptorServoWrite(func(pin string, val byte) (err error) {
+		written[pin] = val
+		return nil
+	})
+	s1 := NewServoDriver(a, "1")
+	s2 := NewServoDriver(a, "2")
+	sq := NewServoSequencer(map[string]*ServoDriver{"a": s1, "b": s2})
+
+	seq := &ServoSequence{
+		Keyframes: []ServoKeyframe{
+			{Duration: 50 * time.Millisecond, Angles: map[string]float64{"a": 10, "b": 170}},
+			{Duration: 20 * time.Millisecond, Easing: "outQuad", Angles: map[string]float64{"a": 90}},
+		},
+	}
+	gobottest.Assert(t, sq.Play(seq), nil)
+	gobottest.Assert(t, s1.CurrentAngle, uint8(90))
+	gobottest.Assert(t, s2.CurrentAngle, uint8(170))
+	gobottest.Assert(t, written["1"], uint8(90))
+	gobottest.Assert(t, written["2"], uint8(170))
+
+	err := sq.Play(&ServoSequence{Keyframes: []ServoKeyframe{{Angles: map[string]float64{"c": 10}}}})
+	gobottest.Assert(t, err.Error(), "keyframe 0: unknown servo 'c'")
+	err = sq.Play(&ServoSequence{Keyframes: []ServoKeyframe{{Angles: map[string]float64{"a": 190}}}})
+	gobottest.Assert(t, err.Error(), "keyframe 0: "+ErrServoOutOfRange.Error())
+}
+
+func TestServoSequencerStop(t *testing.T) {
+	s1 := NewServoDriver(newGpioTestAdaptor(), "1")
+	sq := NewServoSequencer(map[string]*ServoDriver{"a": s1})
+	seq := &ServoSequence{
+		Keyframes: []ServoKeyframe{{Duration: 10 * time.Second, Angles: map[string]float64{"a": 180}}},
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		sq.Stop()
+	}()
+	gobottest.Assert(t, sq.Play(seq), nil)
+	gobottest.Assert(t, s1.CurrentAngle < 180, true)
+}
@@ -0,0 +1,180 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const stepStickFaultPollInterval = 10 * time.Millisecond
+
+// stepStickModel describes the microstep modes of a step/direction driver in the "StepStick" form factor
+type stepStickModel struct {
+	name string
+	// levels of the microstep pins for each count of microsteps per full step
+	microsteps map[uint][3]byte
+}
+
+// stepStickDriver is the common implementation of the A4988 and DRV8825 drivers. The step and direction
+// handling is done by the EasyDriver, the angle of a step is adjusted to the microstep mode.
+type stepStickDriver struct {
+	*EasyDriver
+	model         *stepStickModel
+	fullStepAngle float32
+	msPins        [3]string
+	microsteps    uint
+	faultPin      string
+	fault         bool
+	halt          chan bool
+	mutex         *sync.Mutex
+	gobot.Eventer
+}
+
+func newStepStickDriver(model *stepStickModel, a DigitalWriter, angle float32, stepPin string, dirPin string,
+	enPin string, sleepPin string, msPins [3]string) *stepStickDriver {
+	d := &stepStickDriver{
+		EasyDriver:    NewEasyDriver(a, angle, stepPin, dirPin, enPin, sleepPin),
+		model:         model,
+		fullStepAngle: angle,
+		msPins:        msPins,
+		microsteps:    1,
+		mutex:         &sync.Mutex{},
+		Eventer:       gobot.NewEventer(),
+	}
+	d.SetName(gobot.DefaultName(model.name))
+	d.AddEvent(StepperFault)
+	d.AddEvent(StepperFaultCleared)
+	d.AddEvent(Error)
+	d.AddCommand("SetMicrostepping", func(params map[string]interface{}) interface{} {
+		microsteps, _ := params["microsteps"].(float64)
+		return d.SetMicrostepping(uint(microsteps))
+	})
+	return d
+}
+
+// Start implements the Driver interface, the microstep pins are set and the monitoring of the fault pin is
+// started, if configured.
+//
+// Emits the Events:
+//	fault - On activation of the fault output, the motor is stopped
+//	fault-cleared - On deactivation of the fault output
+//	error error - On error while reading the fault pin
+func (d *stepStickDriver) Start() error {
+	if err := d.writeMicrostepPins(d.microsteps); err != nil {
+		return err
+	}
+	if d.faultPin == "" {
+		return nil
+	}
+	reader, ok := d.connection.(DigitalReader)
+	if !ok {
+		return ErrDigitalReadUnsupported
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.halt != nil {
+		// the fault pin is already monitored
+		return nil
+	}
+	d.halt = make(chan bool)
+	go d.monitorFault(reader, d.halt)
+	return nil
+}
+
+// Halt implements the Driver interface, stops the stepper and the monitoring of the fault pin
+func (d *stepStickDriver) Halt() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return d.Stop()
+}
+
+// SetMicrostepping sets the count of microsteps per full step by the microstep pins. The step angle and the
+// maximum speed are adjusted, so Move() keeps its meaning. The current step counts microsteps. The mode can not
+// be changed while the motor is moving.
+func (d *stepStickDriver) SetMicrostepping(microsteps uint) error {
+	if d.IsMoving() {
+		return fmt.Errorf("microstepping can not be changed while the motor is moving")
+	}
+	if err := d.writeMicrostepPins(microsteps); err != nil {
+		return err
+	}
+	d.microsteps = microsteps
+	d.angle = d.fullStepAngle / float32(microsteps)
+	return nil
+}
+
+// Microstepping returns the count of microsteps per full step
+func (d *stepStickDriver) Microstepping() uint {
+	return d.microsteps
+}
+
+// HasFault returns true, if the fault output of the driver is active, e.g. on over-current or over-temperature
+func (d *stepStickDriver) HasFault() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.fault
+}
+
+func (d *stepStickDriver) writeMicrostepPins(microsteps uint) error {
+	levels, ok := d.model.microsteps[microsteps]
+	if !ok {
+		return fmt.Errorf("%d microsteps are not supported by %s", microsteps, d.model.name)
+	}
+	for i, pin := range d.msPins {
+		if pin == "" {
+			// the pin is hard wired, so only the default level is possible
+			if levels[i] != 0 {
+				return fmt.Errorf("microstep pin %d is not set, needed for %d microsteps", i, microsteps)
+			}
+			continue
+		}
+		if err := d.connection.DigitalWrite(pin, levels[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *stepStickDriver) monitorFault(reader DigitalReader, halt chan bool) {
+	for {
+		level, err := reader.DigitalRead(d.faultPin)
+		if err != nil {
+			d.Publish(Error, err)
+		} else {
+			// the fault output is active low
+			d.updateFault(level == 0)
+		}
+		select {
+		case <-time.After(stepStickFaultPollInterval):
+		case <-halt:
+			return
+		}
+	}
+}
+
+func (d *stepStickDriver) updateFault(fault bool) {
+	d.mutex.Lock()
+	changed := fault != d.fault
+	d.fault = fault
+	d.mutex.Unlock()
+
+	if !changed {
+		return
+	}
+	if fault {
+		d.Stop()
+		d.Publish(StepperFault, nil)
+	} else {
+		d.Publish(StepperFaultCleared, nil)
+	}
+}
@@ -0,0 +1,221 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.Driver and Stepper interfaces
+var _ gobot.Driver = (*A4988Driver)(nil)
+var _ gobot.Driver = (*DRV8825Driver)(nil)
+var _ Stepper = (*A4988Driver)(nil)
+var _ Stepper = (*DRV8825Driver)(nil)
+
+type stepStickTestPins struct {
+	mtx    sync.Mutex
+	levels map[string]byte
+}
+
+func (p *stepStickTestPins) level(pin string) byte {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.levels[pin]
+}
+
+func initTestStepStickDriverWithStubbedAdaptor(microstepper string) (Stepper, *gpioTestAdaptor,
+	*stepStickTestPins) {
+	a := newGpioTestAdaptor()
+	pins := &stepStickTestPins{levels: map[string]byte{}}
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) error {
+		pins.mtx.Lock()
+		defer pins.mtx.Unlock()
+		pins.levels[pin] = val
+		return nil
+	})
+	if microstepper == "A4988" {
+		return NewA4988Driver(a, 1.8, "1", "2", "3", "4", [3]string{"5", "6", "7"}), a, pins
+	}
+	return NewDRV8825Driver(a, 1.8, "1", "2", "3", "4", [3]string{"5", "6", "7"}, "8"), a, pins
+}
+
+func TestNewStepStickDrivers(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	// act
+	a4988 := NewA4988Driver(a, 1.8, "1", "", "", "", [3]string{})
+	drv8825 := NewDRV8825Driver(a, 1.8, "1", "", "", "", [3]string{}, "8")
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(a4988.Name(), "A4988"), true)
+	gobottest.Assert(t, a4988.Microstepping(), uint(1))
+	gobottest.Assert(t, a4988.GetMaxSpeed(), uint(200))
+	gobottest.Assert(t, a4988.faultPin, "")
+	gobottest.Assert(t, strings.HasPrefix(drv8825.Name(), "DRV8825"), true)
+	gobottest.Assert(t, drv8825.faultPin, "8")
+	gobottest.Refute(t, drv8825.Command("SetMicrostepping"), nil)
+}
+
+func TestStepStickSetMicrostepping(t *testing.T) {
+	var tests = map[string]struct {
+		driver     string
+		microsteps uint
+		want       [3]byte
+	}{
+		"A4988 full":      {driver: "A4988", microsteps: 1, want: [3]byte{0, 0, 0}},
+		"A4988 half":      {driver: "A4988", microsteps: 2, want: [3]byte{1, 0, 0}},
+		"A4988 1/8":       {driver: "A4988", microsteps: 8, want: [3]byte{1, 1, 0}},
+		"A4988 1/16":      {driver: "A4988", microsteps: 16, want: [3]byte{1, 1, 1}},
+		"DRV8825 quarter": {driver: "DRV8825", microsteps: 4, want: [3]byte{0, 1, 0}},
+		"DRV8825 1/16":    {driver: "DRV8825", microsteps: 16, want: [3]byte{0, 0, 1}},
+		"DRV8825 1/32":    {driver: "DRV8825", microsteps: 32, want: [3]byte{1, 0, 1}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			s, _, pins := initTestStepStickDriverWithStubbedAdaptor(tc.driver)
+			d := s.(interface {
+				SetMicrostepping(uint) error
+				Microstepping() uint
+			})
+			// act
+			err := d.SetMicrostepping(tc.microsteps)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, d.Microstepping(), tc.microsteps)
+			gobottest.Assert(t, [3]byte{pins.level("5"), pins.level("6"), pins.level("7")}, tc.want)
+			gobottest.Assert(t, s.GetMaxSpeed(), 200*tc.microsteps)
+		})
+	}
+}
+
+func TestStepStickSetMicrosteppingError(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	d := NewA4988Driver(a, 1.8, "1", "", "", "", [3]string{"5", "6", ""})
+	// act & assert
+	gobottest.Assert(t, d.SetMicrostepping(32).Error(), "32 microsteps are not supported by A4988")
+	gobottest.Assert(t, d.SetMicrostepping(16).Error(), "microstep pin 2 is not set, needed for 16 microsteps")
+	gobottest.Assert(t, d.SetMicrostepping(8), nil)
+	gobottest.Assert(t, d.Microstepping(), uint(8))
+}
+
+func TestStepStickSetMicrosteppingWhileMoving(t *testing.T) {
+	// arrange
+	s, _, pins := initTestStepStickDriverWithStubbedAdaptor("A4988")
+	d := s.(*A4988Driver)
+	gobottest.Assert(t, d.Run(), nil)
+	defer d.Stop()
+	// act
+	err := d.SetMicrostepping(16)
+	// assert
+	gobottest.Assert(t, err.Error(), "microstepping can not be changed while the motor is moving")
+	gobottest.Assert(t, d.Microstepping(), uint(1))
+	gobottest.Assert(t, pins.level("5"), uint8(0))
+}
+
+func TestStepStickMoveWithMicrostepping(t *testing.T) {
+	// arrange
+	s, _, _ := initTestStepStickDriverWithStubbedAdaptor("A4988")
+	d := s.(*A4988Driver)
+	d.SetSpeed(d.GetMaxSpeed())
+	d.SetMicrostepping(4)
+	// act
+	err := d.Move(9)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.GetCurrentStep(), 20)
+}
+
+func TestStepStickStartWritesMicrostepPins(t *testing.T) {
+	// arrange
+	d := NewA4988Driver(newGpioTestAdaptor(), 1.8, "1", "", "", "", [3]string{"5", "6", "7"})
+	d.microsteps = 16
+	pins := map[string]byte{}
+	d.connection.(*gpioTestAdaptor).TestAdaptorDigitalWrite(func(pin string, val byte) error {
+		pins[pin] = val
+		return nil
+	})
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pins, map[string]byte{"5": 1, "6": 1, "7": 1})
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestDRV8825Fault(t *testing.T) {
+	// arrange
+	s, a, _ := initTestStepStickDriverWithStubbedAdaptor("DRV8825")
+	d := s.(*DRV8825Driver)
+	var faultMtx sync.Mutex
+	faultLevel := 1
+	a.TestAdaptorDigitalRead(func(pin string) (int, error) {
+		faultMtx.Lock()
+		defer faultMtx.Unlock()
+		if pin != "8" {
+			return 0, errors.New("unexpected pin")
+		}
+		return faultLevel, nil
+	})
+	faults := make(chan string, 2)
+	d.On(StepperFault, func(data interface{}) { faults <- StepperFault })
+	d.On(StepperFaultCleared, func(data interface{}) { faults <- StepperFaultCleared })
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	d.Run()
+	gobottest.Assert(t, d.IsMoving(), true)
+	// act
+	faultMtx.Lock()
+	faultLevel = 0
+	faultMtx.Unlock()
+	// assert
+	select {
+	case event := <-faults:
+		gobottest.Assert(t, event, StepperFault)
+	case <-time.After(time.Second):
+		t.Errorf("fault event was not published")
+	}
+	gobottest.Assert(t, d.HasFault(), true)
+	gobottest.Assert(t, d.IsMoving(), false)
+	// act
+	faultMtx.Lock()
+	faultLevel = 1
+	faultMtx.Unlock()
+	// assert
+	select {
+	case event := <-faults:
+		gobottest.Assert(t, event, StepperFaultCleared)
+	case <-time.After(time.Second):
+		t.Errorf("fault cleared event was not published")
+	}
+	gobottest.Assert(t, d.HasFault(), false)
+}
+
+func TestDRV8825StartTwice(t *testing.T) {
+	// arrange
+	s, a, _ := initTestStepStickDriverWithStubbedAdaptor("DRV8825")
+	d := s.(*DRV8825Driver)
+	a.TestAdaptorDigitalRead(func(pin string) (int, error) { return 1, nil })
+	gobottest.Assert(t, d.Start(), nil)
+	halt := d.halt
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	// the running monitoring of the fault pin is kept
+	gobottest.Assert(t, d.halt, halt)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.halt, (chan bool)(nil))
+}
+
+func TestDRV8825FaultPinNeedsReader(t *testing.T) {
+	// arrange
+	d := NewDRV8825Driver(&gpioTestDigitalWriter{}, 1.8, "1", "", "", "", [3]string{}, "8")
+	// act & assert
+	gobottest.Assert(t, d.Start(), ErrDigitalReadUnsupported)
+}
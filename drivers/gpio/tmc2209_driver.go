@@ -0,0 +1,448 @@
+package gpio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// ErrHomingStopped is the error resulting when the homing is cancelled by Stop() or Halt()
+var ErrHomingStopped = errors.New("homing was stopped")
+
+const (
+	tmc2209Sync        = 0x05
+	tmc2209MasterAddr  = 0xFF
+	tmc2209WriteFlag   = 0x80
+	tmc2209Version     = 0x21
+	tmc2209MaxReadSize = 64
+
+	tmc2209RegGCONF      = 0x00
+	tmc2209RegGSTAT      = 0x01
+	tmc2209RegIFCNT      = 0x02
+	tmc2209RegIOIN       = 0x06
+	tmc2209RegIHOLDIRUN  = 0x10
+	tmc2209RegTPOWERDOWN = 0x11
+	tmc2209RegTSTEP      = 0x12
+	tmc2209RegTPWMTHRS   = 0x13
+	tmc2209RegTCOOLTHRS  = 0x14
+	tmc2209RegVACTUAL    = 0x22
+	tmc2209RegSGTHRS     = 0x40
+	tmc2209RegSGRESULT   = 0x41
+	tmc2209RegCOOLCONF   = 0x42
+	tmc2209RegCHOPCONF   = 0x6C
+	tmc2209RegDRVSTATUS  = 0x6F
+	tmc2209RegPWMCONF    = 0x70
+
+	// GCONF bits
+	tmc2209SpreadCycle    = 0x0004
+	tmc2209PdnDisable     = 0x0040
+	tmc2209MstepRegSelect = 0x0080
+	tmc2209MultistepFilt  = 0x0100
+
+	// CHOPCONF bits, the default is the reset value with full step (MRES=8) instead of 256 microsteps
+	tmc2209ChopConfDefault = 0x18000053
+	tmc2209Vsense          = 0x00020000
+	tmc2209MresShift       = 24
+	tmc2209MresMask        = 0x0F000000
+
+	// full scale voltages of the sense resistor comparator
+	tmc2209VfsHigh = 0.325
+	tmc2209VfsLow  = 0.180
+
+	tmc2209DefaultRSense    = 0.11
+	tmc2209HoldDelay        = 10
+	tmc2209HomingSettleTime = 100 * time.Millisecond
+)
+
+// TMC2209Status contains the diagnostic flags of the DRV_STATUS register
+type TMC2209Status struct {
+	OverTemperaturePreWarning bool
+	OverTemperature           bool
+	ShortToGroundA            bool
+	ShortToGroundB            bool
+	ShortLowSideA             bool
+	ShortLowSideB             bool
+	OpenLoadA                 bool
+	OpenLoadB                 bool
+	// temperature thresholds 120°C, 143°C, 150°C and 157°C exceeded
+	Temperature120 bool
+	Temperature143 bool
+	Temperature150 bool
+	Temperature157 bool
+	// actual current scale 0..31
+	CurrentScale uint8
+	StealthChop  bool
+	Standstill   bool
+}
+
+// TMC2209Driver is a driver for the TMC2209 stepper motor driver from Trinamic. The steps are done by the step and
+// direction pins like the EasyDriver. The configuration (current, chopper mode, microstepping, StallGuard) and the
+// diagnostics are done by the single wire UART interface.
+//
+// The UART needs to be configured with a baud rate between 9600 and 500k (e.g. 115200) and a read timeout. The TX
+// and RX lines are connected with a 1k resistor, so the driver skips the echo of the own requests. Up to 4 drivers
+// can share one UART by the address pins MS1 and MS2.
+//
+// Datasheet: https://www.trinamic.com/fileadmin/assets/Products/ICs_Documents/TMC2209_Datasheet_V103.pdf
+type TMC2209Driver struct {
+	*EasyDriver
+	uart          io.ReadWriter
+	address       uint8
+	rSense        float64
+	diagPin       string
+	fullStepAngle float32
+	microsteps    uint
+	gconf         uint32
+	chopconf      uint32
+	sgthrs        uint8
+	mutex         *sync.Mutex
+}
+
+// NewTMC2209Driver returns a new driver for the TMC2209 stepper motor driver
+// A - DigitalWriter, needs to be a DigitalReader too, if a DIAG pin is used
+// uart - The serial port connected to PDN_UART
+// address - The UART node address 0..3, given by MS1 and MS2
+// angle - Full step angle of motor
+// stepPin - Pin corresponding to step input
+// dirPin - Pin corresponding to dir input.  Optional
+// enPin - Pin corresponding to enable input.  Optional
+func NewTMC2209Driver(a DigitalWriter, uart io.ReadWriter, address uint8, angle float32, stepPin string,
+	dirPin string, enPin string) *TMC2209Driver {
+	d := &TMC2209Driver{
+		EasyDriver:    NewEasyDriver(a, angle, stepPin, dirPin, enPin, ""),
+		uart:          uart,
+		address:       address,
+		rSense:        tmc2209DefaultRSense,
+		fullStepAngle: angle,
+		microsteps:    1,
+		gconf:         tmc2209PdnDisable | tmc2209MstepRegSelect | tmc2209MultistepFilt,
+		chopconf:      tmc2209ChopConfDefault,
+		mutex:         &sync.Mutex{},
+	}
+	d.SetName(gobot.DefaultName("TMC2209"))
+	d.AddCommand("SetCurrent", func(params map[string]interface{}) interface{} {
+		current, _ := params["current"].(float64)
+		hold, _ := params["hold"].(float64)
+		return d.SetCurrent(current, hold)
+	})
+	d.AddCommand("SetMicrostepping", func(params map[string]interface{}) interface{} {
+		microsteps, _ := params["microsteps"].(float64)
+		return d.SetMicrostepping(uint(microsteps))
+	})
+	return d
+}
+
+// SetRSense sets the value of the sense resistors in Ohm, used to calculate the current scale. Default is 0.11 Ohm.
+func (d *TMC2209Driver) SetRSense(ohm float64) {
+	d.rSense = ohm
+}
+
+// SetDiagPin sets the pin connected to the DIAG output, which is used to detect a stall while homing. If not set,
+// the StallGuard result is read by the UART instead.
+func (d *TMC2209Driver) SetDiagPin(pin string) {
+	d.diagPin = pin
+}
+
+// Start implements the Driver interface, the chip version is checked and the configuration is written
+func (d *TMC2209Driver) Start() (err error) {
+	version, err := d.Version()
+	if err != nil {
+		return err
+	}
+	if version != tmc2209Version {
+		return fmt.Errorf("unexpected version 0x%02X of TMC2209", version)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.writeRegister(tmc2209RegGCONF, d.gconf); err != nil {
+		return err
+	}
+	return d.writeRegister(tmc2209RegCHOPCONF, d.chopconf)
+}
+
+// Version returns the version of the chip, 0x21 for the TMC2209
+func (d *TMC2209Driver) Version() (uint8, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	ioin, err := d.readRegister(tmc2209RegIOIN)
+	return uint8(ioin >> 24), err
+}
+
+// SetCurrent sets the RMS current of the motor in Ampere and the current at standstill, given as factor of the
+// run current (0..1).
+func (d *TMC2209Driver) SetCurrent(rms float64, holdFactor float64) error {
+	if holdFactor < 0 || holdFactor > 1 {
+		return fmt.Errorf("hold factor %.2f is out of range 0..1", holdFactor)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	chopconf := d.chopconf &^ tmc2209Vsense
+	cs := tmc2209CurrentScale(rms, d.rSense, tmc2209VfsHigh)
+	if cs < 16 {
+		// use the high sensitivity for a better resolution at low currents
+		chopconf |= tmc2209Vsense
+		cs = tmc2209CurrentScale(rms, d.rSense, tmc2209VfsLow)
+	}
+	irun := uint32(cs)
+	ihold := uint32(math.Round(float64(cs) * holdFactor))
+
+	if err := d.writeRegister(tmc2209RegCHOPCONF, chopconf); err != nil {
+		return err
+	}
+	d.chopconf = chopconf
+	return d.writeRegister(tmc2209RegIHOLDIRUN, ihold|irun<<8|tmc2209HoldDelay<<16)
+}
+
+// SetStealthChop switches between the silent StealthChop mode and the SpreadCycle chopper mode
+func (d *TMC2209Driver) SetStealthChop(enable bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	gconf := d.gconf | tmc2209SpreadCycle
+	if enable {
+		gconf = d.gconf &^ tmc2209SpreadCycle
+	}
+	if err := d.writeRegister(tmc2209RegGCONF, gconf); err != nil {
+		return err
+	}
+	d.gconf = gconf
+	return nil
+}
+
+// SetMicrostepping sets the count of microsteps per full step, valid values are powers of 2 from 1 to 256. The
+// step angle and the maximum speed are adjusted, so Move() keeps its meaning. The resolution can not be changed
+// while the motor is moving.
+func (d *TMC2209Driver) SetMicrostepping(microsteps uint) error {
+	if d.IsMoving() {
+		return fmt.Errorf("microstepping can not be changed while the motor is moving")
+	}
+	var mres uint32
+	for mres = 0; mres <= 8; mres++ {
+		if microsteps == 256>>mres {
+			break
+		}
+	}
+	if mres > 8 {
+		return fmt.Errorf("%d microsteps are not supported by TMC2209", microsteps)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	chopconf := d.chopconf&^tmc2209MresMask | mres<<tmc2209MresShift
+	if err := d.writeRegister(tmc2209RegCHOPCONF, chopconf); err != nil {
+		return err
+	}
+	d.chopconf = chopconf
+	d.microsteps = microsteps
+	d.angle = d.fullStepAngle / float32(microsteps)
+	return nil
+}
+
+// Microstepping returns the count of microsteps per full step
+func (d *TMC2209Driver) Microstepping() uint {
+	return d.microsteps
+}
+
+// SetStallGuardThreshold sets the StallGuard threshold. A stall is signaled, if the StallGuard result falls below
+// the double of this value. Higher values make the detection more sensitive.
+func (d *TMC2209Driver) SetStallGuardThreshold(threshold uint8) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.writeRegister(tmc2209RegSGTHRS, uint32(threshold)); err != nil {
+		return err
+	}
+	d.sgthrs = threshold
+	return nil
+}
+
+// SetCoolStepThreshold sets the lower velocity threshold for CoolStep and the StallGuard output at DIAG, given as
+// time between two microsteps (TSTEP, 20 bit). StallGuard and CoolStep are active, while TSTEP is below this value.
+func (d *TMC2209Driver) SetCoolStepThreshold(tstep uint32) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeRegister(tmc2209RegTCOOLTHRS, tstep&0xFFFFF)
+}
+
+// StallGuardResult returns the actual StallGuard result, a lower value means a higher mechanical load
+func (d *TMC2209Driver) StallGuardResult() (uint16, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.readRegister(tmc2209RegSGRESULT)
+	return uint16(val & 0x3FF), err
+}
+
+// DriverStatus returns the diagnostic flags of the driver
+func (d *TMC2209Driver) DriverStatus() (*TMC2209Status, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.readRegister(tmc2209RegDRVSTATUS)
+	if err != nil {
+		return nil, err
+	}
+	bit := func(n uint) bool { return val&(1<<n) != 0 }
+	return &TMC2209Status{
+		OverTemperaturePreWarning: bit(0),
+		OverTemperature:           bit(1),
+		ShortToGroundA:            bit(2),
+		ShortToGroundB:            bit(3),
+		ShortLowSideA:             bit(4),
+		ShortLowSideB:             bit(5),
+		OpenLoadA:                 bit(6),
+		OpenLoadB:                 bit(7),
+		Temperature120:            bit(8),
+		Temperature143:            bit(9),
+		Temperature150:            bit(10),
+		Temperature157:            bit(11),
+		CurrentScale:              uint8(val>>16) & 0x1F,
+		StealthChop:               bit(30),
+		Standstill:                bit(31),
+	}, nil
+}
+
+// Home moves the motor in the given direction ("cw" or "ccw") at the current speed until a stall is detected by
+// StallGuard (sensorless homing). The current step is reset to 0 afterwards. StallGuard works in StealthChop mode
+// only and needs a threshold set by SetStallGuardThreshold() and SetCoolStepThreshold(). The homing can be cancelled
+// by Stop() or Halt(), ErrHomingStopped is returned in this case.
+func (d *TMC2209Driver) Home(dir string, timeout time.Duration) error {
+	if !d.startMoving() {
+		return fmt.Errorf("motor is already moving")
+	}
+	defer d.Stop()
+
+	if err := d.SetDirection(dir); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	for d.IsMoving() {
+		if err := d.Step(); err != nil {
+			return err
+		}
+		elapsed := time.Since(start)
+		if elapsed < tmc2209HomingSettleTime {
+			// the StallGuard result is not valid while accelerating
+			continue
+		}
+		stalled, err := d.isStalled()
+		if err != nil {
+			return err
+		}
+		if stalled {
+			d.stepNum = 0
+			return nil
+		}
+		if elapsed > timeout {
+			return fmt.Errorf("no stall detected while homing within %s", timeout)
+		}
+	}
+	return ErrHomingStopped
+}
+
+func (d *TMC2209Driver) isStalled() (bool, error) {
+	if d.diagPin != "" {
+		reader, ok := d.connection.(DigitalReader)
+		if !ok {
+			return false, ErrDigitalReadUnsupported
+		}
+		// DIAG is active high on stall
+		level, err := reader.DigitalRead(d.diagPin)
+		return level == 1, err
+	}
+
+	result, err := d.StallGuardResult()
+	if err != nil {
+		return false, err
+	}
+	return result <= 2*uint16(d.sgthrs), nil
+}
+
+func (d *TMC2209Driver) writeRegister(reg uint8, val uint32) error {
+	datagram := []byte{tmc2209Sync, d.address, reg | tmc2209WriteFlag, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(datagram[3:7], val)
+	datagram[7] = tmc2209CRC(datagram[:7])
+	_, err := d.uart.Write(datagram)
+	return err
+}
+
+func (d *TMC2209Driver) readRegister(reg uint8) (uint32, error) {
+	request := []byte{tmc2209Sync, d.address, reg, 0}
+	request[3] = tmc2209CRC(request[:3])
+	if _, err := d.uart.Write(request); err != nil {
+		return 0, err
+	}
+
+	// the reply is sent to the master address, this skips the echo of the request
+	var buf []byte
+	chunk := make([]byte, 8)
+	for {
+		for len(buf) > 0 && buf[0] != tmc2209Sync || len(buf) > 1 && buf[1] != tmc2209MasterAddr {
+			buf = buf[1:]
+		}
+		if len(buf) >= 8 {
+			break
+		}
+		n, err := d.uart.Read(chunk)
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			return 0, fmt.Errorf("timeout while reading register 0x%02X of TMC2209", reg)
+		}
+		buf = append(buf, chunk[:n]...)
+		if len(buf) > tmc2209MaxReadSize {
+			return 0, fmt.Errorf("no reply for register 0x%02X of TMC2209", reg)
+		}
+	}
+
+	if crc := tmc2209CRC(buf[:7]); crc != buf[7] {
+		return 0, fmt.Errorf("wrong CRC 0x%02X of reply, expected 0x%02X", buf[7], crc)
+	}
+	if buf[2] != reg {
+		return 0, fmt.Errorf("reply for register 0x%02X instead of 0x%02X", buf[2], reg)
+	}
+	return binary.BigEndian.Uint32(buf[3:7]), nil
+}
+
+// tmc2209CurrentScale calculates the current scale 0..31 for the given RMS current
+func tmc2209CurrentScale(rms float64, rSense float64, vfs float64) uint8 {
+	cs := math.Round(32*math.Sqrt2*rms*(rSense+0.02)/vfs) - 1
+	if cs < 0 {
+		return 0
+	}
+	if cs > 31 {
+		return 31
+	}
+	return uint8(cs)
+}
+
+// tmc2209CRC calculates the CRC8 (polynomial 0x07) of a datagram, the bits of each byte are processed LSB first
+func tmc2209CRC(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		for i := 0; i < 8; i++ {
+			if (crc>>7)^(b&0x01) != 0 {
+				crc = (crc << 1) ^ 0x07
+			} else {
+				crc = crc << 1
+			}
+			b = b >> 1
+		}
+	}
+	return crc
+}
@@ -0,0 +1,399 @@
+package gpio
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.Driver and Stepper interfaces
+var _ gobot.Driver = (*TMC2209Driver)(nil)
+var _ Stepper = (*TMC2209Driver)(nil)
+
+// tmc2209TestUART simulates the single wire UART of a TMC2209, including the echo of the requests
+type tmc2209TestUART struct {
+	mtx       sync.Mutex
+	address   uint8
+	registers map[uint8]uint32
+	written   map[uint8][]uint32
+	out       []byte
+	readErr   error
+	badCRC    bool
+}
+
+func newTMC2209TestUART() *tmc2209TestUART {
+	return &tmc2209TestUART{
+		registers: map[uint8]uint32{tmc2209RegIOIN: 0x21000040, tmc2209RegSGRESULT: 0x1FF},
+		written:   map[uint8][]uint32{},
+	}
+}
+
+func (u *tmc2209TestUART) Write(b []byte) (int, error) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	// echo
+	u.out = append(u.out, b...)
+	if b[0] != tmc2209Sync || b[1] != u.address || tmc2209CRC(b[:len(b)-1]) != b[len(b)-1] {
+		// the chip ignores the datagram
+		return len(b), nil
+	}
+	reg := b[2] &^ tmc2209WriteFlag
+	if len(b) == 8 {
+		val := binary.BigEndian.Uint32(b[3:7])
+		u.registers[reg] = val
+		u.written[reg] = append(u.written[reg], val)
+		return len(b), nil
+	}
+	reply := []byte{tmc2209Sync, tmc2209MasterAddr, reg, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(reply[3:7], u.registers[reg])
+	reply[7] = tmc2209CRC(reply[:7])
+	if u.badCRC {
+		reply[7]++
+	}
+	u.out = append(u.out, reply...)
+	return len(b), nil
+}
+
+func (u *tmc2209TestUART) Read(b []byte) (int, error) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	if u.readErr != nil {
+		return 0, u.readErr
+	}
+	n := copy(b, u.out)
+	u.out = u.out[n:]
+	return n, nil
+}
+
+func (u *tmc2209TestUART) setRegister(reg uint8, val uint32) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	u.registers[reg] = val
+}
+
+func (u *tmc2209TestUART) lastWritten(reg uint8) uint32 {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	vals := u.written[reg]
+	if len(vals) == 0 {
+		return 0
+	}
+	return vals[len(vals)-1]
+}
+
+func initTestTMC2209DriverWithStubbedUART() (*TMC2209Driver, *gpioTestAdaptor, *tmc2209TestUART) {
+	a := newGpioTestAdaptor()
+	u := newTMC2209TestUART()
+	u.address = 2
+	return NewTMC2209Driver(a, u, 2, 1.8, "1", "2", "3"), a, u
+}
+
+func TestNewTMC2209Driver(t *testing.T) {
+	// arrange
+	a := newGpioTestAdaptor()
+	u := newTMC2209TestUART()
+	// act
+	d := NewTMC2209Driver(a, u, 1, 1.8, "1", "2", "3")
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "TMC2209"), true)
+	gobottest.Refute(t, NewTMC2209Driver(a, u, 2, 1.8, "4", "5", "6").Name(), d.Name())
+	gobottest.Assert(t, d.Connection(), a)
+	gobottest.Assert(t, d.address, uint8(1))
+	gobottest.Assert(t, d.rSense, tmc2209DefaultRSense)
+	gobottest.Assert(t, d.Microstepping(), uint(1))
+	// the chip is configured for full step too
+	gobottest.Assert(t, d.chopconf&tmc2209MresMask, uint32(8)<<tmc2209MresShift)
+	gobottest.Refute(t, d.Command("SetCurrent"), nil)
+	gobottest.Refute(t, d.Command("SetMicrostepping"), nil)
+}
+
+func TestTMC2209CRC(t *testing.T) {
+	// read requests for IOIN and GCONF of node 0
+	gobottest.Assert(t, tmc2209CRC([]byte{0x05, 0x00, 0x06}), byte(0x6F))
+	gobottest.Assert(t, tmc2209CRC([]byte{0x05, 0x00, 0x00}), byte(0x48))
+}
+
+func TestTMC2209Start(t *testing.T) {
+	// arrange
+	d, _, u := initTestTMC2209DriverWithStubbedUART()
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, u.lastWritten(tmc2209RegGCONF), uint32(0x1C0))
+	gobottest.Assert(t, u.lastWritten(tmc2209RegCHOPCONF), uint32(0x18000053))
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestTMC2209MoveAfterStart(t *testing.T) {
+	// arrange
+	d, a, u := initTestTMC2209DriverWithStubbedUART()
+	steps := 0
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) error {
+		if pin == "1" && val == 1 {
+			steps++
+		}
+		return nil
+	})
+	gobottest.Assert(t, d.Start(), nil)
+	d.SetSpeed(d.GetMaxSpeed())
+	// act
+	err := d.Move(18)
+	// assert
+	gobottest.Assert(t, err, nil)
+	// 18° are 10 full steps of 1.8°, which is the resolution written to the chip
+	gobottest.Assert(t, (u.lastWritten(tmc2209RegCHOPCONF)&tmc2209MresMask)>>tmc2209MresShift, uint32(8))
+	gobottest.Assert(t, steps, 10)
+	gobottest.Assert(t, d.GetCurrentStep(), 10)
+}
+
+func TestTMC2209StartError(t *testing.T) {
+	var tests = map[string]struct {
+		setup   func(u *tmc2209TestUART)
+		wantErr string
+	}{
+		"wrong_version": {
+			setup:   func(u *tmc2209TestUART) { u.registers[tmc2209RegIOIN] = 0x20000000 },
+			wantErr: "unexpected version 0x20 of TMC2209",
+		},
+		"no_reply": {
+			setup:   func(u *tmc2209TestUART) { u.address = 3 },
+			wantErr: "timeout while reading register 0x06 of TMC2209",
+		},
+		"read_error": {
+			setup:   func(u *tmc2209TestUART) { u.readErr = errors.New("read error") },
+			wantErr: "read error",
+		},
+		"wrong_crc": {
+			setup:   func(u *tmc2209TestUART) { u.badCRC = true },
+			wantErr: "wrong CRC",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, u := initTestTMC2209DriverWithStubbedUART()
+			tc.setup(u)
+			// act
+			err := d.Start()
+			// assert
+			gobottest.Refute(t, err, nil)
+			gobottest.Assert(t, strings.Contains(err.Error(), tc.wantErr), true)
+		})
+	}
+}
+
+func TestTMC2209Version(t *testing.T) {
+	// arrange
+	d, _, _ := initTestTMC2209DriverWithStubbedUART()
+	// act
+	version, err := d.Version()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, version, uint8(0x21))
+}
+
+func TestTMC2209SetCurrent(t *testing.T) {
+	var tests = map[string]struct {
+		rms        float64
+		hold       float64
+		wantIrun   uint32
+		wantIhold  uint32
+		wantVsense bool
+	}{
+		"high_current": {rms: 1.2, hold: 0.5, wantIrun: 21, wantIhold: 11, wantVsense: false},
+		"max_current":  {rms: 3, hold: 1, wantIrun: 31, wantIhold: 31, wantVsense: false},
+		"low_current":  {rms: 0.4, hold: 0.25, wantIrun: 12, wantIhold: 3, wantVsense: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, u := initTestTMC2209DriverWithStubbedUART()
+			// act
+			err := d.SetCurrent(tc.rms, tc.hold)
+			// assert
+			gobottest.Assert(t, err, nil)
+			iholdIrun := u.lastWritten(tmc2209RegIHOLDIRUN)
+			gobottest.Assert(t, iholdIrun&0x1F, tc.wantIhold)
+			gobottest.Assert(t, (iholdIrun>>8)&0x1F, tc.wantIrun)
+			gobottest.Assert(t, iholdIrun>>16, uint32(tmc2209HoldDelay))
+			gobottest.Assert(t, u.lastWritten(tmc2209RegCHOPCONF)&tmc2209Vsense != 0, tc.wantVsense)
+		})
+	}
+}
+
+func TestTMC2209SetCurrentError(t *testing.T) {
+	// arrange
+	d, _, _ := initTestTMC2209DriverWithStubbedUART()
+	// act
+	err := d.SetCurrent(1, 1.5)
+	// assert
+	gobottest.Assert(t, err.Error(), "hold factor 1.50 is out of range 0..1")
+}
+
+func TestTMC2209SetStealthChop(t *testing.T) {
+	// arrange
+	d, _, u := initTestTMC2209DriverWithStubbedUART()
+	// act & assert
+	gobottest.Assert(t, d.SetStealthChop(false), nil)
+	gobottest.Assert(t, u.lastWritten(tmc2209RegGCONF), uint32(0x1C4))
+	gobottest.Assert(t, d.SetStealthChop(true), nil)
+	gobottest.Assert(t, u.lastWritten(tmc2209RegGCONF), uint32(0x1C0))
+}
+
+func TestTMC2209SetMicrostepping(t *testing.T) {
+	var tests = map[string]struct {
+		microsteps   uint
+		wantChopconf uint32
+		wantErr      string
+	}{
+		"full_step": {microsteps: 1, wantChopconf: 0x18000053},
+		"1/16":      {microsteps: 16, wantChopconf: 0x14000053},
+		"1/256":     {microsteps: 256, wantChopconf: 0x10000053},
+		"invalid":   {microsteps: 3, wantErr: "3 microsteps are not supported by TMC2209"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, u := initTestTMC2209DriverWithStubbedUART()
+			// act
+			err := d.SetMicrostepping(tc.microsteps)
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+				gobottest.Assert(t, d.Microstepping(), uint(1))
+				return
+			}
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, u.lastWritten(tmc2209RegCHOPCONF), tc.wantChopconf)
+			gobottest.Assert(t, d.Microstepping(), tc.microsteps)
+			gobottest.Assert(t, d.GetMaxSpeed(), 200*tc.microsteps)
+		})
+	}
+}
+
+func TestTMC2209SetMicrosteppingWhileMoving(t *testing.T) {
+	// arrange
+	d, _, u := initTestTMC2209DriverWithStubbedUART()
+	gobottest.Assert(t, d.Run(), nil)
+	defer d.Stop()
+	// act
+	err := d.SetMicrostepping(16)
+	// assert
+	gobottest.Assert(t, err.Error(), "microstepping can not be changed while the motor is moving")
+	gobottest.Assert(t, d.Microstepping(), uint(1))
+	gobottest.Assert(t, u.lastWritten(tmc2209RegCHOPCONF), uint32(0))
+}
+
+func TestTMC2209StallGuard(t *testing.T) {
+	// arrange
+	d, _, u := initTestTMC2209DriverWithStubbedUART()
+	u.setRegister(tmc2209RegSGRESULT, 0x00000123)
+	// act & assert
+	gobottest.Assert(t, d.SetStallGuardThreshold(50), nil)
+	gobottest.Assert(t, u.lastWritten(tmc2209RegSGTHRS), uint32(50))
+	gobottest.Assert(t, d.SetCoolStepThreshold(0xFFFFFF), nil)
+	gobottest.Assert(t, u.lastWritten(tmc2209RegTCOOLTHRS), uint32(0xFFFFF))
+	result, err := d.StallGuardResult()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, result, uint16(0x123))
+}
+
+func TestTMC2209DriverStatus(t *testing.T) {
+	// arrange
+	d, _, u := initTestTMC2209DriverWithStubbedUART()
+	u.setRegister(tmc2209RegDRVSTATUS, 0xC01F0145)
+	// act
+	status, err := d.DriverStatus()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, *status, TMC2209Status{
+		OverTemperaturePreWarning: true,
+		ShortToGroundA:            true,
+		OpenLoadA:                 true,
+		Temperature120:            true,
+		CurrentScale:              31,
+		StealthChop:               true,
+		Standstill:                true,
+	})
+}
+
+func TestTMC2209HomeWithStallGuardResult(t *testing.T) {
+	// arrange
+	d, _, u := initTestTMC2209DriverWithStubbedUART()
+	d.SetSpeed(d.GetMaxSpeed())
+	d.SetStallGuardThreshold(10)
+	d.Step()
+	d.Step()
+	u.setRegister(tmc2209RegSGRESULT, 20)
+	// act
+	err := d.Home("ccw", time.Second)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.GetCurrentStep(), 0)
+	gobottest.Assert(t, d.IsMoving(), false)
+}
+
+func TestTMC2209HomeWithDiagPin(t *testing.T) {
+	// arrange
+	d, a, _ := initTestTMC2209DriverWithStubbedUART()
+	d.SetSpeed(d.GetMaxSpeed())
+	d.SetDiagPin("9")
+	reads := 0
+	a.TestAdaptorDigitalRead(func(pin string) (int, error) {
+		reads++
+		if reads < 3 {
+			return 0, nil
+		}
+		return 1, nil
+	})
+	// act
+	err := d.Home("cw", time.Second)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, reads, 3)
+	gobottest.Assert(t, d.GetCurrentStep(), 0)
+}
+
+func TestTMC2209HomeTimeout(t *testing.T) {
+	// arrange
+	d, _, _ := initTestTMC2209DriverWithStubbedUART()
+	d.SetSpeed(d.GetMaxSpeed())
+	d.SetStallGuardThreshold(10)
+	// act
+	err := d.Home("cw", 150*time.Millisecond)
+	// assert
+	gobottest.Assert(t, err.Error(), "no stall detected while homing within 150ms")
+	gobottest.Assert(t, d.IsMoving(), false)
+}
+
+func TestTMC2209HomeStopped(t *testing.T) {
+	// arrange
+	d, _, _ := initTestTMC2209DriverWithStubbedUART()
+	d.SetSpeed(d.GetMaxSpeed())
+	d.SetStallGuardThreshold(10)
+	result := make(chan error, 1)
+	go func() { result <- d.Home("cw", 10*time.Second) }()
+	for !d.IsMoving() {
+		time.Sleep(time.Millisecond)
+	}
+	// act
+	gobottest.Assert(t, d.Home("cw", time.Second).Error(), "motor is already moving")
+	gobottest.Assert(t, d.Halt(), nil)
+	// assert
+	select {
+	case err := <-result:
+		gobottest.Assert(t, err, ErrHomingStopped)
+	case <-time.After(time.Second):
+		t.Errorf("homing was not stopped")
+	}
+	gobottest.Assert(t, d.IsMoving(), false)
+}
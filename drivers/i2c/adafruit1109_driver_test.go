@@ -7,11 +7,15 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*Adafruit1109Driver)(nil)
 
+// and also the CharacterDisplay interface
+var _ gpio.CharacterDisplay = (*Adafruit1109Driver)(nil)
+
 func initTestAdafruit1109WithStubbedAdaptor() (*Adafruit1109Driver, *i2cTestAdaptor) {
 	adaptor := newI2cTestAdaptor()
 	return NewAdafruit1109Driver(adaptor), adaptor
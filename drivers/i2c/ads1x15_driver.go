@@ -1,13 +1,17 @@
 package i2c
 
 import (
+	"errors"
 	"log"
 	"math"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"fmt"
+
+	"gobot.io/x/gobot"
 )
 
 const ads1x15DefaultAddress = 0x48
@@ -36,6 +40,17 @@ const (
 	ads1x15ConfigOsSingle       = 0x8000 // write: set to start a single-conversion, read: wait for finished
 	ads1x15ConfigMuxOffset      = 12
 	ads1x15ConfigPgaOffset      = 9
+
+	// Threshold values for the conversion ready mode of the ALERT/RDY pin
+	ads1x15ReadyLowThreshold  = 0x0000
+	ads1x15ReadyHighThreshold = 0x8000
+)
+
+const (
+	// ADS1x15OutOfWindow event is published with the ADS1x15Sample, when the voltage leaves the window of WatchWindow()
+	ADS1x15OutOfWindow = "outOfWindow"
+	// ADS1x15InWindow event is published with the ADS1x15Sample, when the voltage is back in the window of WatchWindow()
+	ADS1x15InWindow = "inWindow"
 )
 
 type ads1x15ChanCfg struct {
@@ -48,6 +63,46 @@ type ads1x15GainCfg struct {
 	fullrange float64
 }
 
+type ads1x15Input struct {
+	pin           string
+	channel       int
+	channelOffset int
+	gain          int
+	dataRate      int
+}
+
+// ads1x15Continuous is the state of a running stream or window watch
+type ads1x15Continuous struct {
+	inputs  []ads1x15Input
+	current int
+	comp    uint16
+	stream  *ADS1x15Stream // nil for the window watch
+	low     int
+	high    int
+	outside bool
+	halt    chan bool
+}
+
+// ADS1x15Sample is one conversion result of a stream or window watch
+type ADS1x15Sample struct {
+	Time    time.Time
+	Pin     string // input like for AnalogRead(), e.g. "2" or "0-1"
+	Raw     int
+	Voltage float64 // [V]
+}
+
+// ADS1x15Stream delivers the samples of a continuous conversion. Samples are dropped, if the channel is full.
+type ADS1x15Stream struct {
+	// C delivers the samples, it is closed by Stop()
+	C       <-chan ADS1x15Sample
+	c       chan ADS1x15Sample
+	onStop  func()
+	dropped int
+	err     error
+	stopped bool
+	mutex   *sync.Mutex
+}
+
 // ADS1x15Driver is the Gobot driver for the ADS1015/ADS1115 ADC
 // datasheet:
 // https://www.ti.com/lit/gpn/ads1115
@@ -60,6 +115,10 @@ type ADS1x15Driver struct {
 	dataRates        map[int]uint16
 	channelCfgs      map[int]*ads1x15ChanCfg
 	waitOnlyOneCycle bool
+	intHost          gobot.DigitalPinnerProvider
+	intPin           string
+	continuous       *ads1x15Continuous
+	gobot.Eventer
 }
 
 var ads1x15FullScaleRange = map[int]float64{
@@ -112,12 +171,19 @@ func newADS1x15Driver(c Connector, name string, drs map[int]uint16, ddr int, opt
 		Driver:      NewDriver(c, name, ads1x15DefaultAddress),
 		dataRates:   drs,
 		channelCfgs: ccs,
+		Eventer:     gobot.NewEventer(),
 	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
 
 	for _, option := range options {
 		option(d)
 	}
 
+	d.AddEvent(ADS1x15OutOfWindow)
+	d.AddEvent(ADS1x15InWindow)
+	d.AddEvent(Error)
+
 	d.AddCommand("ReadDifferenceWithDefaults", func(params map[string]interface{}) interface{} {
 		channel := params["channel"].(int)
 		val, err := d.ReadDifferenceWithDefaults(channel)
@@ -281,6 +347,20 @@ func WithADS1x15WaitSingleCycle() func(Config) {
 	}
 }
 
+// WithADS1x15Interrupt option wires the ALERT/RDY output to the given host pin, which needs to support edge
+// detection. This is used by Stream() and WatchWindow() instead of polling.
+func WithADS1x15Interrupt(host gobot.DigitalPinnerProvider, pinID string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ADS1x15Driver)
+		if ok {
+			d.intHost = host
+			d.intPin = pinID
+		} else if ads1x15Debug {
+			log.Printf("Trying to set interrupt for non-ADS1x15Driver %v", c)
+		}
+	}
+}
+
 // ReadDifferenceWithDefaults reads the difference in V between 2 inputs. It uses the default gain and data rate
 // diff can be:
 // * 0: Channel 0 - channel 1
@@ -341,39 +421,301 @@ func (d *ADS1x15Driver) AnalogRead(pin string) (value int, err error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	var channel int
-	var channelOffset int
+	channel, channelOffset, err := ads1x15ParsePin(pin)
+	if err != nil {
+		return
+	}
+
+	if err = d.checkChannel(channel); err != nil {
+		return
+	}
 
-	// Check for the ADC is used in difference mode
-	switch pin {
-	case "0-1":
-		channel = 0
-		break
-	case "0-3":
-		channel = 1
-		break
-	case "1-3":
-		channel = 2
-		break
-	case "2-3":
-		channel = 3
-		break
-	default:
-		// read the voltage at a specific pin, compared to the ground
-		channel, err = strconv.Atoi(pin)
+	value, err = d.rawRead(channel, channelOffset, d.channelCfgs[channel].gain, d.channelCfgs[channel].dataRate)
+
+	return
+}
+
+// Stream starts the continuous conversion of the given inputs, the samples are delivered by the channel of the
+// returned stream with the given size. The inputs are named like for AnalogRead(), e.g. "2" or "0-1", and are
+// converted with the gain and data rate of the channel. A single input uses the continuous conversion mode of
+// the device, multiple inputs are converted round-robin in single shot mode. If the ALERT/RDY pin is wired by
+// WithADS1x15Interrupt(), each sample is read on the conversion ready signal, otherwise the device is polled
+// with the data rate. The streaming is stopped by Stop() of the stream or on Halt(). Single shot reads are not
+// possible meanwhile.
+func (d *ADS1x15Driver) Stream(pins []string, size int) (*ADS1x15Stream, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.continuous != nil {
+		return nil, errors.New("continuous conversion already started")
+	}
+	if len(pins) == 0 {
+		return nil, errors.New("at least one input is needed")
+	}
+	inputs, err := d.parseInputs(pins)
+	if err != nil {
+		return nil, err
+	}
+
+	var s *ADS1x15Stream
+	s = newADS1x15Stream(size, func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		if d.continuous != nil && d.continuous.stream == s {
+			_ = d.stopContinuous()
+		}
+	})
+	c := &ads1x15Continuous{inputs: inputs, stream: s, comp: ads1x15ConfigCompQueDisable}
+	if d.intHost != nil {
+		// conversion ready mode of the ALERT/RDY pin, asserted after each conversion
+		if err := d.writeWordBigEndian(ads1x15PointerLowThreshold, ads1x15ReadyLowThreshold); err != nil {
+			return nil, err
+		}
+		if err := d.writeWordBigEndian(ads1x15PointerHighThreshold, ads1x15ReadyHighThreshold); err != nil {
+			return nil, err
+		}
+		c.comp = 0
+	}
+	if err := d.startContinuous(c, d.intHost == nil); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// WatchWindow starts the continuous conversion of the given input with the window comparator of the device. The
+// event ADS1x15OutOfWindow is published when the voltage leaves the range low..high [V], ADS1x15InWindow when it
+// is back. If the ALERT/RDY pin is wired by WithADS1x15Interrupt(), leaving the window is signaled by the device
+// and only the way back is polled, otherwise all conversions are polled with the data rate. The watch is stopped
+// by StopWatch() or on Halt(). Single shot reads are not possible meanwhile.
+func (d *ADS1x15Driver) WatchWindow(pin string, low float64, high float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.continuous != nil {
+		return errors.New("continuous conversion already started")
+	}
+	if low >= high {
+		return errors.New("the low threshold must be below the high threshold")
+	}
+	inputs, err := d.parseInputs([]string{pin})
+	if err != nil {
+		return err
+	}
+	fsr, err := ads1x15GetFullScaleRange(inputs[0].gain)
+	if err != nil {
+		return err
+	}
+
+	c := &ads1x15Continuous{
+		inputs: inputs,
+		comp:   ads1x15ConfigCompWindow,
+		low:    ads1x15VoltageToRaw(low, fsr),
+		high:   ads1x15VoltageToRaw(high, fsr),
+	}
+	if err := d.writeWordBigEndian(ads1x15PointerLowThreshold, uint16(int16(c.low))); err != nil {
+		return err
+	}
+	if err := d.writeWordBigEndian(ads1x15PointerHighThreshold, uint16(int16(c.high))); err != nil {
+		return err
+	}
+	return d.startContinuous(c, true)
+}
+
+// StopWatch stops the window watch, which was started by WatchWindow()
+func (d *ADS1x15Driver) StopWatch() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.continuous == nil || d.continuous.stream != nil {
+		return nil
+	}
+	return d.stopContinuous()
+}
+
+// startContinuous configures the device for the first input and starts polling, if needed
+func (d *ADS1x15Driver) startContinuous(c *ads1x15Continuous, poll bool) error {
+	if err := d.startConversion(c); err != nil {
+		return err
+	}
+	d.continuous = c
+	if !poll {
+		return nil
+	}
+
+	// poll with the slowest data rate of all inputs
+	interval := time.Duration(0)
+	for _, in := range c.inputs {
+		if period := time.Second / time.Duration(in.dataRate); period > interval {
+			interval = period
+		}
+	}
+	if len(c.inputs) > 1 {
+		// same offset as for single shot reads
+		interval += 100 * time.Microsecond
+	}
+
+	halt := make(chan bool)
+	c.halt = halt
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-halt:
+				return
+			case <-ticker.C:
+				d.onConversionPoll()
+			}
+		}
+	}()
+	return nil
+}
+
+// startConversion writes the configuration for the current input
+func (d *ADS1x15Driver) startConversion(c *ads1x15Continuous) error {
+	in := c.inputs[c.current]
+	dataRateBits, err := ads1x15GetDataRateBits(d.dataRates, in.dataRate)
+	if err != nil {
+		return err
+	}
+	config := ads1x15Config(in.channel, in.channelOffset, in.gain, dataRateBits) | c.comp
+	if len(c.inputs) > 1 {
+		config |= ads1x15ConfigOsSingle | ads1x15ConfigModeSingle
+	} else {
+		config |= ads1x15ConfigModeContinuous
+	}
+	return d.writeWordBigEndian(ads1x15PointerConfig, config)
+}
+
+// onAlert is called on the active edge of the ALERT/RDY pin
+func (d *ADS1x15Driver) onAlert() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if c := d.continuous; c != nil {
+		d.readContinuous(c)
+	}
+}
+
+// onConversionPoll is called periodically, if the ALERT/RDY pin is not used or the way back into the window is
+// watched
+func (d *ADS1x15Driver) onConversionPoll() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	c := d.continuous
+	if c == nil {
+		return
+	}
+	if c.stream == nil && d.intHost != nil && !c.outside {
+		// leaving the window is signaled by the device
+		return
+	}
+	if len(c.inputs) > 1 {
+		// the single shot conversion may not be finished yet
+		config, err := d.readWordBigEndian(ads1x15PointerConfig)
 		if err != nil {
+			d.reportContinuousError(c, err)
+			return
+		}
+		if config&ads1x15ConfigOsSingle == 0 {
 			return
 		}
-		channelOffset = 0x04
 	}
+	d.readContinuous(c)
+}
 
-	if err = d.checkChannel(channel); err != nil {
+// readContinuous reads the result of the current input, delivers it and starts the conversion of the next input
+func (d *ADS1x15Driver) readContinuous(c *ads1x15Continuous) {
+	in := c.inputs[c.current]
+	udata, err := d.readWordBigEndian(ads1x15PointerConversion)
+	if err != nil {
+		d.reportContinuousError(c, err)
 		return
 	}
+	fsr, _ := ads1x15GetFullScaleRange(in.gain)
+	raw := int(twosComplement16Bit(udata))
+	sample := ADS1x15Sample{
+		Time:    time.Now(),
+		Pin:     in.pin,
+		Raw:     raw,
+		Voltage: float64(raw) / float64(1<<15) * fsr,
+	}
+
+	if c.stream != nil {
+		c.stream.push(sample)
+	} else {
+		outside := raw < c.low || raw > c.high
+		if outside != c.outside {
+			c.outside = outside
+			if outside {
+				d.Publish(ADS1x15OutOfWindow, sample)
+			} else {
+				d.Publish(ADS1x15InWindow, sample)
+			}
+		}
+	}
 
-	value, err = d.rawRead(channel, channelOffset, d.channelCfgs[channel].gain, d.channelCfgs[channel].dataRate)
+	if len(c.inputs) > 1 {
+		c.current = (c.current + 1) % len(c.inputs)
+		if err := d.startConversion(c); err != nil {
+			d.reportContinuousError(c, err)
+		}
+	}
+}
 
-	return
+func (d *ADS1x15Driver) reportContinuousError(c *ads1x15Continuous, err error) {
+	if c.stream != nil {
+		c.stream.reportError(err)
+		return
+	}
+	d.Publish(Error, err)
+}
+
+// stopContinuous stops polling and powers down the device
+func (d *ADS1x15Driver) stopContinuous() error {
+	if c := d.continuous; c != nil && c.halt != nil {
+		close(c.halt)
+	}
+	d.continuous = nil
+	return d.writeWordBigEndian(ads1x15PointerConfig, ads1x15ConfigModeSingle|ads1x15ConfigCompQueDisable)
+}
+
+func (d *ADS1x15Driver) parseInputs(pins []string) ([]ads1x15Input, error) {
+	inputs := make([]ads1x15Input, len(pins))
+	for i, pin := range pins {
+		channel, channelOffset, err := ads1x15ParsePin(pin)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.checkChannel(channel); err != nil {
+			return nil, err
+		}
+		cfg := d.channelCfgs[channel]
+		inputs[i] = ads1x15Input{pin: pin, channel: channel, channelOffset: channelOffset, gain: cfg.gain,
+			dataRate: cfg.dataRate}
+	}
+	return inputs, nil
+}
+
+func (d *ADS1x15Driver) initialize() error {
+	if d.intHost != nil {
+		// the ALERT/RDY pin is active low
+		return attachHostInterrupt(d.intHost, d.intPin, false, d.onAlert)
+	}
+	return nil
+}
+
+func (d *ADS1x15Driver) shutdown() error {
+	c := d.continuous
+	if c == nil {
+		return nil
+	}
+	if c.stream != nil {
+		c.stream.close()
+	}
+	return d.stopContinuous()
 }
 
 func (d *ADS1x15Driver) readVoltage(channel int, channelOffset int, gain int, dataRate int) (value float64, err error) {
@@ -397,25 +739,14 @@ func (d *ADS1x15Driver) rawRead(channel int, channelOffset int, gain int, dataRa
 		return
 	}
 
-	var config uint16
-	// Go out of power-down mode for conversion.
-	config = ads1x15ConfigOsSingle
-
-	// Specify mux value.
-	mux := channel + channelOffset
-	config |= uint16((mux & 0x07) << ads1x15ConfigMuxOffset)
-
-	// Set the programmable gain amplifier bits.
-	config |= uint16(gain) << ads1x15ConfigPgaOffset
-
-	// Set the mode (continuous or single shot).
-	config |= ads1x15ConfigModeSingle
-
-	// Set the data rate.
-	config |= dataRateBits
+	if d.continuous != nil {
+		err = errors.New("not possible while the continuous conversion is running")
+		return
+	}
 
-	// Disable comparator mode.
-	config |= ads1x15ConfigCompQueDisable
+	// Go out of power-down mode for conversion in single shot mode, disable comparator mode.
+	config := ads1x15Config(channel, channelOffset, gain, dataRateBits) |
+		ads1x15ConfigOsSingle | ads1x15ConfigModeSingle | ads1x15ConfigCompQueDisable
 
 	// Send the config value to start the ADC conversion.
 	if err = d.writeWordBigEndian(ads1x15PointerConfig, config); err != nil {
@@ -502,6 +833,100 @@ func (d *ADS1x15Driver) setChannelGains(gain int) {
 	}
 }
 
+func newADS1x15Stream(size int, stop func()) *ADS1x15Stream {
+	c := make(chan ADS1x15Sample, size)
+	return &ADS1x15Stream{C: c, c: c, onStop: stop, mutex: &sync.Mutex{}}
+}
+
+// Dropped returns the number of samples, which were dropped because the channel was full
+func (s *ADS1x15Stream) Dropped() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.dropped
+}
+
+// Err returns the last error on reading the device
+func (s *ADS1x15Stream) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.err
+}
+
+// Stop stops the continuous conversion and closes the channel, further calls are ignored
+func (s *ADS1x15Stream) Stop() {
+	if s.close() && s.onStop != nil {
+		s.onStop()
+	}
+}
+
+func (s *ADS1x15Stream) push(sample ADS1x15Sample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return
+	}
+	select {
+	case s.c <- sample:
+	default:
+		s.dropped++
+	}
+}
+
+func (s *ADS1x15Stream) reportError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.err = err
+}
+
+func (s *ADS1x15Stream) close() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopped {
+		return false
+	}
+	s.stopped = true
+	close(s.c)
+	return true
+}
+
+// ads1x15Config returns the config register value for input multiplexer, gain and data rate
+func ads1x15Config(channel int, channelOffset int, gain int, dataRateBits uint16) uint16 {
+	mux := channel + channelOffset
+	return uint16((mux&0x07)<<ads1x15ConfigMuxOffset) | uint16(gain)<<ads1x15ConfigPgaOffset | dataRateBits
+}
+
+// ads1x15ParsePin returns the channel and the offset of the multiplexer for the pin, which is "0".."3" for a
+// single ended input or "0-1", "0-3", "1-3", "2-3" for a difference
+func ads1x15ParsePin(pin string) (channel int, channelOffset int, err error) {
+	// Check for the ADC is used in difference mode
+	switch pin {
+	case "0-1":
+		channel = 0
+	case "0-3":
+		channel = 1
+	case "1-3":
+		channel = 2
+	case "2-3":
+		channel = 3
+	default:
+		// read the voltage at a specific pin, compared to the ground
+		channel, err = strconv.Atoi(pin)
+		channelOffset = 0x04
+	}
+	return
+}
+
+// ads1x15VoltageToRaw returns the raw value for the voltage, limited to the range of the register
+func ads1x15VoltageToRaw(voltage float64, fsr float64) int {
+	raw := math.Round(voltage / fsr * float64(1<<15))
+	return int(math.Max(-(1 << 15), math.Min(ads1x15FullScaleValue, raw)))
+}
+
 func ads1x15GetFullScaleRange(gain int) (fsr float64, err error) {
 	fsr, ok := ads1x15FullScaleRange[gain]
 	if ok {
@@ -2,7 +2,9 @@ package i2c
 
 import (
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/drivers/aio"
@@ -102,3 +104,233 @@ func TestADS1x15_ads1x15BestGainForVoltage(t *testing.T) {
 	g, err = ads1x15BestGainForVoltage(20.0)
 	gobottest.Assert(t, err, errors.New("The maximum voltage which can be read is 6.144000"))
 }
+
+// ads1x15TestRegs simulates the registers of the device, the conversion register returns the value for the mux of
+// the config register
+type ads1x15TestRegs struct {
+	regs       map[uint8]uint16
+	lastReg    uint8
+	conversion func(mux int) uint16
+	mutex      sync.Mutex
+}
+
+func simulateADS1x15Registers(a *i2cTestAdaptor, conversion func(mux int) uint16) *ads1x15TestRegs {
+	r := &ads1x15TestRegs{regs: map[uint8]uint16{}, conversion: conversion}
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.lastReg = b[0]
+		if len(b) == 3 {
+			r.regs[b[0]] = uint16(b[1])<<8 | uint16(b[2])
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		var val uint16
+		switch r.lastReg {
+		case ads1x15PointerConversion:
+			val = r.conversion(int(r.regs[ads1x15PointerConfig]>>ads1x15ConfigMuxOffset) & 0x07)
+		case ads1x15PointerConfig:
+			// no conversion in progress
+			val = r.regs[ads1x15PointerConfig] | ads1x15ConfigOsSingle
+		default:
+			val = r.regs[r.lastReg]
+		}
+		copy(b, []byte{byte(val >> 8), byte(val)})
+		return 2, nil
+	}
+	return r
+}
+
+func (r *ads1x15TestRegs) get(reg uint8) uint16 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.regs[reg]
+}
+
+func (r *ads1x15TestRegs) setConversion(conversion func(mux int) uint16) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.conversion = conversion
+}
+
+func receiveADS1x15Samples(t *testing.T, c <-chan ADS1x15Sample, n int) []ADS1x15Sample {
+	var samples []ADS1x15Sample
+	for len(samples) < n {
+		select {
+		case sample, ok := <-c:
+			if !ok {
+				t.Fatal("stream was closed")
+			}
+			samples = append(samples, sample)
+		case <-time.After(time.Second):
+			t.Fatalf("only %d of %d samples received", len(samples), n)
+		}
+	}
+	return samples
+}
+
+func TestADS1x15StreamContinuous(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewADS1115Driver(a, WithADS1x15DataRate(860))
+	regs := simulateADS1x15Registers(a, func(int) uint16 { return 0x4000 })
+	gobottest.Assert(t, d.Start(), nil)
+
+	s, err := d.Stream([]string{"0"}, 10)
+	gobottest.Assert(t, err, nil)
+	// continuous mode, single ended input 0, gain 1, 860 SPS, comparator disabled
+	gobottest.Assert(t, regs.get(ads1x15PointerConfig), uint16(0x42E3))
+
+	samples := receiveADS1x15Samples(t, s.C, 3)
+	gobottest.Assert(t, samples[0].Pin, "0")
+	gobottest.Assert(t, samples[0].Raw, 0x4000)
+	gobottest.Assert(t, samples[0].Voltage, 2.048)
+	gobottest.Assert(t, samples[2].Time.After(samples[0].Time), true)
+
+	// single shot reads are not possible meanwhile
+	_, err = d.Read(0, 1, 860)
+	gobottest.Assert(t, err, errors.New("not possible while the continuous conversion is running"))
+	_, err = d.Stream([]string{"1"}, 10)
+	gobottest.Assert(t, err, errors.New("continuous conversion already started"))
+
+	s.Stop()
+	gobottest.Assert(t, regs.get(ads1x15PointerConfig), uint16(ads1x15ConfigModeSingle|ads1x15ConfigCompQueDisable))
+	for range s.C {
+		// drain until closed
+	}
+	_, err = d.Read(0, 1, 860)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, s.Err(), nil)
+}
+
+func TestADS1x15StreamRoundRobinInterrupt(t *testing.T) {
+	a := newI2cTestAdaptor()
+	host := newGpioExpanderTestHost()
+	d := NewADS1115Driver(a, WithADS1x15Interrupt(host, "4"))
+	regs := simulateADS1x15Registers(a, func(mux int) uint16 { return uint16(mux) * 0x100 })
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, host.pins["4"].input, true)
+	gobottest.Assert(t, host.pins["4"].edge, 1)
+
+	s, err := d.Stream([]string{"1", "3", "0-3"}, 10)
+	gobottest.Assert(t, err, nil)
+	// conversion ready mode of the ALERT/RDY pin
+	gobottest.Assert(t, regs.get(ads1x15PointerLowThreshold), uint16(0x0000))
+	gobottest.Assert(t, regs.get(ads1x15PointerHighThreshold), uint16(0x8000))
+	// single shot of input 1, comparator enabled
+	gobottest.Assert(t, regs.get(ads1x15PointerConfig), uint16(0xD380))
+
+	for i := 0; i < 4; i++ {
+		host.pins["4"].trigger()
+	}
+	samples := receiveADS1x15Samples(t, s.C, 4)
+	gobottest.Assert(t, samples[0].Pin, "1")
+	gobottest.Assert(t, samples[0].Raw, 0x500)
+	gobottest.Assert(t, samples[1].Pin, "3")
+	gobottest.Assert(t, samples[1].Raw, 0x700)
+	gobottest.Assert(t, samples[2].Pin, "0-3")
+	gobottest.Assert(t, samples[2].Raw, 0x100)
+	gobottest.Assert(t, samples[3].Pin, "1")
+
+	// the stream is closed on halt
+	gobottest.Assert(t, d.Halt(), nil)
+	_, ok := <-s.C
+	gobottest.Assert(t, ok, false)
+	host.pins["4"].trigger()
+}
+
+func TestADS1x15StreamErrors(t *testing.T) {
+	d, _ := initTestADS1x15DriverWithStubbedAdaptor()
+	_, err := d.Stream(nil, 10)
+	gobottest.Assert(t, err, errors.New("at least one input is needed"))
+	_, err = d.Stream([]string{"4"}, 10)
+	gobottest.Assert(t, err.Error(), "Invalid channel (4), must be between 0 and 3")
+	_, err = d.Stream([]string{"x"}, 10)
+	gobottest.Refute(t, err, nil)
+}
+
+func TestADS1x15StreamReadError(t *testing.T) {
+	a := newI2cTestAdaptor()
+	host := newGpioExpanderTestHost()
+	d := NewADS1115Driver(a, WithADS1x15Interrupt(host, "4"))
+	gobottest.Assert(t, d.Start(), nil)
+	s, err := d.Stream([]string{"0"}, 10)
+	gobottest.Assert(t, err, nil)
+
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	host.pins["4"].trigger()
+	gobottest.Assert(t, s.Err(), errors.New("read error"))
+	s.Stop()
+}
+
+func TestADS1x15WatchWindow(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewADS1115Driver(a, WithADS1x15DataRate(860))
+	inside := func(int) uint16 { return 0x2000 }
+	regs := simulateADS1x15Registers(a, inside)
+	gobottest.Assert(t, d.Start(), nil)
+	events := make(chan string, 10)
+	_ = d.On(ADS1x15OutOfWindow, func(interface{}) { events <- ADS1x15OutOfWindow })
+	_ = d.On(ADS1x15InWindow, func(interface{}) { events <- ADS1x15InWindow })
+
+	gobottest.Assert(t, d.WatchWindow("2", 2.0, 2.0), errors.New("the low threshold must be below the high threshold"))
+	gobottest.Assert(t, d.WatchWindow("2", 0.512, 2.048), nil)
+	gobottest.Assert(t, regs.get(ads1x15PointerLowThreshold), uint16(0x1000))
+	gobottest.Assert(t, regs.get(ads1x15PointerHighThreshold), uint16(0x4000))
+	// continuous mode, single ended input 2, gain 1, 860 SPS, window comparator
+	gobottest.Assert(t, regs.get(ads1x15PointerConfig), uint16(0x62F0))
+
+	regs.setConversion(func(int) uint16 { return 0x5000 })
+	gobottest.Assert(t, receiveADS1x15Event(t, events), ADS1x15OutOfWindow)
+	regs.setConversion(inside)
+	gobottest.Assert(t, receiveADS1x15Event(t, events), ADS1x15InWindow)
+
+	gobottest.Assert(t, d.StopWatch(), nil)
+	gobottest.Assert(t, regs.get(ads1x15PointerConfig), uint16(ads1x15ConfigModeSingle|ads1x15ConfigCompQueDisable))
+}
+
+func TestADS1x15WatchWindowInterrupt(t *testing.T) {
+	a := newI2cTestAdaptor()
+	host := newGpioExpanderTestHost()
+	d := NewADS1115Driver(a, WithADS1x15DataRate(860), WithADS1x15Interrupt(host, "4"))
+	inside := func(int) uint16 { return 0x2000 }
+	regs := simulateADS1x15Registers(a, func(int) uint16 { return 0x0800 })
+	gobottest.Assert(t, d.Start(), nil)
+	events := make(chan ADS1x15Sample, 10)
+	_ = d.On(ADS1x15OutOfWindow, func(v interface{}) { events <- v.(ADS1x15Sample) })
+	_ = d.On(ADS1x15InWindow, func(v interface{}) { events <- v.(ADS1x15Sample) })
+
+	gobottest.Assert(t, d.WatchWindow("0-1", 0.512, 2.048), nil)
+	// leaving the window is only detected by the interrupt
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, len(events), 0)
+
+	host.pins["4"].trigger()
+	sample := <-events
+	gobottest.Assert(t, sample.Pin, "0-1")
+	gobottest.Assert(t, sample.Voltage, 0.256)
+
+	// the way back is polled
+	regs.setConversion(inside)
+	select {
+	case sample = <-events:
+		gobottest.Assert(t, sample.Raw, 0x2000)
+	case <-time.After(time.Second):
+		t.Fatal("the way back into the window was not detected")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func receiveADS1x15Event(t *testing.T, events chan string) string {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event was published")
+	}
+	return ""
+}
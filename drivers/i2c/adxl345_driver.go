@@ -3,8 +3,11 @@ package i2c
 import (
 	"encoding/binary"
 	"log"
+	"time"
 
 	"github.com/pkg/errors"
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 )
 
 const adxl345Debug = false
@@ -18,6 +21,7 @@ const (
 
 type ADXL345RateConfig uint8
 type ADXL345FsRangeConfig uint8
+type ADXL345FifoMode uint8
 
 const (
 	// registers are named according to the datasheet
@@ -75,6 +79,20 @@ const (
 	ADXL345FsRange_4G  ADXL345FsRangeConfig = 0x01 // +-4 g
 	ADXL345FsRange_8G  ADXL345FsRangeConfig = 0x02 // +-8 g
 	ADXL345FsRange_16G ADXL345FsRangeConfig = 0x03 // +-16 g)
+
+	// bits of INT_ENABLE, INT_MAP and INT_SOURCE
+	adxl345Int_DataReady = 0x80
+	adxl345Int_Watermark = 0x02
+	adxl345Int_Overrun   = 0x01
+
+	adxl345FifoCtl_ModeShift      = 6
+	adxl345FifoCtl_SamplesBits    = 0x1F
+	adxl345FifoStatus_EntriesBits = 0x3F
+
+	ADXL345Fifo_Bypass  ADXL345FifoMode = 0x00 // FIFO is not used
+	ADXL345Fifo_FIFO    ADXL345FifoMode = 0x01 // collects up to 32 values, further values are lost
+	ADXL345Fifo_Stream  ADXL345FifoMode = 0x02 // holds the latest 32 values, the oldest value is overwritten
+	ADXL345Fifo_Trigger ADXL345FifoMode = 0x03 // holds the latest values before the trigger event
 )
 
 // ADXL345Driver is the gobot driver for the digital accelerometer ADXL345
@@ -88,6 +106,11 @@ type ADXL345Driver struct {
 	powerCtl   adxl345PowerCtl
 	dataFormat adxl345DataFormat
 	bwRate     adxl345BwRate
+	intHost    gobot.DigitalPinnerProvider
+	intPin     string
+	fifoClock  *imu.SampleClock
+	stream     *imu.Stream
+	streamHalt chan bool
 }
 
 // Internal structure for the power configuration
@@ -122,6 +145,7 @@ type adxl345BwRate struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithADXL345Interrupt(gobot.DigitalPinnerProvider, string):	host pin wired to INT1
 //
 func NewADXL345Driver(c Connector, options ...func(Config)) *ADXL345Driver {
 	d := &ADXL345Driver{
@@ -183,6 +207,19 @@ func WithADXL345FullScaleRange(val ADXL345FsRangeConfig) func(Config) {
 	}
 }
 
+// WithADXL345Interrupt option wires the interrupt output INT1 to the given host pin, which needs to support
+// edge detection. This is used by Stream() to drain the FIFO on the watermark interrupt instead of polling.
+func WithADXL345Interrupt(host gobot.DigitalPinnerProvider, pinID string) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*ADXL345Driver); ok {
+			d.intHost = host
+			d.intPin = pinID
+		} else if adxl345Debug {
+			log.Printf("Trying to set interrupt for non-ADXL345Driver %v", c)
+		}
+	}
+}
+
 // UseLowPower change the current rate of the sensor
 func (d *ADXL345Driver) UseLowPower(lowPower bool) (err error) {
 	d.mutex.Lock()
@@ -204,6 +241,7 @@ func (d *ADXL345Driver) SetRate(rate ADXL345RateConfig) (err error) {
 	if err := d.connection.WriteByteData(adxl345Reg_BW_RATE, d.bwRate.toByte()); err != nil {
 		return err
 	}
+	d.fifoClock = imu.NewSampleClock(d.bwRate.period())
 	return
 }
 
@@ -232,6 +270,16 @@ func (d *ADXL345Driver) XYZ() (float64, float64, float64, error) {
 	return d.dataFormat.convertToG(xr), d.dataFormat.convertToG(yr), d.dataFormat.convertToG(zr), nil
 }
 
+// ReadAcceleration implements the imu.Accelerometer interface, unit [m/s²]
+func (d *ADXL345Driver) ReadAcceleration() (imu.Sample, error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return imu.Sample{}, err
+	}
+	v := imu.Vector{X: x, Y: y, Z: z}
+	return imu.Sample{Vector: v.Scale(imu.StandardGravity), Time: time.Now()}, nil
+}
+
 // XYZ returns the raw x,y and z axis
 func (d *ADXL345Driver) RawXYZ() (int16, int16, int16, error) {
 	d.mutex.Lock()
@@ -240,6 +288,170 @@ func (d *ADXL345Driver) RawXYZ() (int16, int16, int16, error) {
 	return d.readRawData()
 }
 
+// SetFIFO configures the FIFO mode and the number of values (0..31), which triggers the watermark interrupt.
+func (d *ADXL345Driver) SetFIFO(mode ADXL345FifoMode, watermark uint8) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setFIFO(mode, watermark)
+}
+
+// FIFOEntries returns the number of values stored in the FIFO
+func (d *ADXL345Driver) FIFOEntries() (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.connection.ReadByteData(adxl345Reg_FIFO_STATUS)
+	if err != nil {
+		return 0, err
+	}
+	return int(val & adxl345FifoStatus_EntriesBits), nil
+}
+
+// ReadFIFO drains all values of the FIFO in [m/s²]. The timestamps are calculated from the data output rate,
+// overrun is true if values were lost since the last drain.
+func (d *ADXL345Driver) ReadFIFO() ([]imu.Sample, bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readFIFO()
+}
+
+// Stream starts to drain the FIFO continuously, the values are delivered by the channel of the returned stream
+// with the given size. The FIFO is used in stream mode with the given watermark (1..31). If the interrupt is
+// wired by WithADXL345Interrupt(), the FIFO is drained on the watermark interrupt, otherwise it is polled.
+// The streaming is stopped by Stop() of the stream or on Halt().
+func (d *ADXL345Driver) Stream(watermark uint8, size int) (*imu.Stream, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stream != nil {
+		return nil, errors.New("stream already started")
+	}
+	if watermark < 1 || watermark > adxl345FifoCtl_SamplesBits {
+		return nil, errors.New("watermark must be in range 1..31")
+	}
+	if err := d.setFIFO(ADXL345Fifo_Stream, watermark); err != nil {
+		return nil, err
+	}
+
+	var s *imu.Stream
+	s = imu.NewStream(size, func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		if d.stream == s {
+			_ = d.stopStream()
+		}
+	})
+	d.fifoClock.Reset()
+
+	if d.intHost != nil {
+		// all interrupts are mapped to INT1
+		if err := d.connection.WriteByteData(adxl345Reg_INT_MAP, 0x00); err != nil {
+			return nil, err
+		}
+		if err := d.connection.WriteByteData(adxl345Reg_INT_ENABLE, adxl345Int_Watermark|adxl345Int_Overrun); err != nil {
+			return nil, err
+		}
+		d.stream = s
+		// values above the watermark are drained now, otherwise there will be no edge
+		d.drainToStream()
+		return s, nil
+	}
+
+	d.stream = s
+
+	halt := make(chan bool)
+	d.streamHalt = halt
+	interval := d.fifoClock.Period() * time.Duration(watermark)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-halt:
+				return
+			case <-ticker.C:
+				d.onFIFOInterrupt()
+			}
+		}
+	}()
+	return s, nil
+}
+
+// onFIFOInterrupt drains the FIFO to the active stream
+func (d *ADXL345Driver) onFIFOInterrupt() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stream != nil {
+		d.drainToStream()
+	}
+}
+
+func (d *ADXL345Driver) drainToStream() {
+	samples, overrun, err := d.readFIFO()
+	if err != nil {
+		d.stream.ReportError(err)
+		return
+	}
+	if overrun {
+		d.stream.ReportOverflow()
+	}
+	for i := range samples {
+		d.stream.Push(imu.Reading{Time: samples[i].Time, Acceleration: &samples[i].Vector})
+	}
+}
+
+func (d *ADXL345Driver) stopStream() error {
+	if d.streamHalt != nil {
+		close(d.streamHalt)
+		d.streamHalt = nil
+	}
+	d.stream = nil
+	if d.intHost != nil {
+		if err := d.connection.WriteByteData(adxl345Reg_INT_ENABLE, 0x00); err != nil {
+			return err
+		}
+	}
+	return d.setFIFO(ADXL345Fifo_Bypass, 0)
+}
+
+func (d *ADXL345Driver) setFIFO(mode ADXL345FifoMode, watermark uint8) error {
+	ctl := uint8(mode)<<adxl345FifoCtl_ModeShift | watermark&adxl345FifoCtl_SamplesBits
+	return d.connection.WriteByteData(adxl345Reg_FIFO_CTL, ctl)
+}
+
+func (d *ADXL345Driver) readFIFO() ([]imu.Sample, bool, error) {
+	source, err := d.connection.ReadByteData(adxl345Reg_INT_SOUCE)
+	if err != nil {
+		return nil, false, err
+	}
+	status, err := d.connection.ReadByteData(adxl345Reg_FIFO_STATUS)
+	if err != nil {
+		return nil, false, err
+	}
+	readTime := time.Now()
+	overrun := source&adxl345Int_Overrun != 0
+	if overrun {
+		d.fifoClock.Reset()
+	}
+
+	entries := int(status & adxl345FifoStatus_EntriesBits)
+	stamps := d.fifoClock.Stamp(entries, readTime)
+	samples := make([]imu.Sample, 0, entries)
+	for i := 0; i < entries; i++ {
+		x, y, z, err := d.readRawData()
+		if err != nil {
+			return samples, overrun, err
+		}
+		v := imu.Vector{X: d.dataFormat.convertToG(x), Y: d.dataFormat.convertToG(y), Z: d.dataFormat.convertToG(z)}
+		samples = append(samples, imu.Sample{Vector: v.Scale(imu.StandardGravity), Time: stamps[i]})
+	}
+	return samples, overrun, nil
+}
+
 func (d *ADXL345Driver) readRawData() (int16, int16, int16, error) {
 	buf := []byte{0, 0, 0, 0, 0, 0}
 	if err := d.connection.ReadBlockData(adxl345Reg_DATAX0, buf); err != nil {
@@ -253,6 +465,7 @@ func (d *ADXL345Driver) readRawData() (int16, int16, int16, error) {
 }
 
 func (d *ADXL345Driver) initialize() error {
+	d.fifoClock = imu.NewSampleClock(d.bwRate.period())
 	if err := d.connection.WriteByteData(adxl345Reg_BW_RATE, d.bwRate.toByte()); err != nil {
 		return err
 	}
@@ -262,6 +475,9 @@ func (d *ADXL345Driver) initialize() error {
 	if err := d.connection.WriteByteData(adxl345Reg_DATA_FORMAT, d.dataFormat.toByte()); err != nil {
 		return err
 	}
+	if d.intHost != nil {
+		return attachHostInterrupt(d.intHost, d.intPin, d.dataFormat.intInvert == 0, d.onFIFOInterrupt)
+	}
 
 	return nil
 }
@@ -271,6 +487,12 @@ func (d *ADXL345Driver) shutdown() error {
 	if d.connection == nil {
 		return errors.New("connection not available")
 	}
+	if s := d.stream; s != nil {
+		s.Close()
+		if err := d.stopStream(); err != nil {
+			return err
+		}
+	}
 	return d.connection.WriteByteData(adxl345Reg_POWER_CTL, d.powerCtl.toByte())
 }
 
@@ -317,3 +539,8 @@ func (b *adxl345BwRate) toByte() uint8 {
 	}
 	return bits
 }
+
+// period returns the time between two values of the configured data output rate (3200 Hz / 2^(15-rate))
+func (b *adxl345BwRate) period() time.Duration {
+	return time.Second * time.Duration(1<<(15-uint(b.rate&0x0F))) / 3200
+}
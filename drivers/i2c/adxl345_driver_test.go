@@ -4,14 +4,17 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*ADXL345Driver)(nil)
+var _ imu.Accelerometer = (*ADXL345Driver)(nil)
 
 func initTestADXL345WithStubbedAdaptor() (*ADXL345Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
@@ -259,6 +262,23 @@ func TestADXL345XYZ(t *testing.T) {
 	}
 }
 
+func TestADXL345ReadAcceleration(t *testing.T) {
+	// arrange
+	d, a := initTestADXL345WithStubbedAdaptor()
+	d.Start()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// 2g range, 10 bit: 256 LSB/g
+		copy(b, []byte{0x00, 0x01, 0x00, 0xFF, 0x00, 0x00})
+		return len(b), nil
+	}
+	// act
+	got, err := d.ReadAcceleration()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got.Vector, imu.Vector{X: imu.StandardGravity, Y: -imu.StandardGravity, Z: 0})
+	gobottest.Assert(t, got.Time.IsZero(), false)
+}
+
 func TestADXL345XYZError(t *testing.T) {
 	// arrange
 	d, a := initTestADXL345WithStubbedAdaptor()
@@ -321,3 +341,124 @@ func TestADXL345_shutdown(t *testing.T) {
 	gobottest.Assert(t, a.written[0], wantReg)
 	gobottest.Assert(t, a.written[1], wantVal)
 }
+
+// simulateADXL345FIFO simulates the registers and the FIFO, each read of the data registers removes one entry
+func simulateADXL345FIFO(a *i2cTestAdaptor) (map[uint8]uint8, *[][]byte) {
+	regs := make(map[uint8]uint8)
+	fifo := &[][]byte{}
+	var lastReg uint8
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		lastReg = b[0]
+		if len(b) > 1 {
+			regs[b[0]] = b[1]
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		switch {
+		case lastReg == 0x39:
+			b[0] = uint8(len(*fifo))
+		case lastReg == 0x32 && len(*fifo) > 0:
+			copy(b, (*fifo)[0])
+			*fifo = (*fifo)[1:]
+		default:
+			b[0] = regs[lastReg]
+		}
+		return len(b), nil
+	}
+	return regs, fifo
+}
+
+func TestADXL345SetFIFO(t *testing.T) {
+	d, a := initTestADXL345WithStubbedAdaptor()
+	d.Start()
+	regs, fifo := simulateADXL345FIFO(a)
+	*fifo = [][]byte{{0, 0, 0, 0, 0, 0}, {0, 0, 0, 0, 0, 0}}
+	// act
+	err := d.SetFIFO(ADXL345Fifo_Stream, 16)
+	entries, errEntries := d.FIFOEntries()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, regs[0x38], uint8(0x90))
+	gobottest.Assert(t, errEntries, nil)
+	gobottest.Assert(t, entries, 2)
+}
+
+func TestADXL345ReadFIFO(t *testing.T) {
+	// arrange
+	d, a := initTestADXL345WithStubbedAdaptor()
+	d.Start()
+	regs, fifo := simulateADXL345FIFO(a)
+	*fifo = [][]byte{{0x00, 0x01, 0, 0, 0, 0}, {0, 0, 0x00, 0xFF, 0, 0}, {0, 0, 0, 0, 0x00, 0x01}}
+	regs[0x30] = 0x01 // overrun
+	// act
+	samples, overrun, err := d.ReadFIFO()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, overrun, true)
+	gobottest.Assert(t, len(samples), 3)
+	gobottest.Assert(t, samples[0].Vector, imu.Vector{X: imu.StandardGravity, Y: 0, Z: 0})
+	gobottest.Assert(t, samples[1].Vector, imu.Vector{X: 0, Y: -imu.StandardGravity, Z: 0})
+	gobottest.Assert(t, samples[2].Vector, imu.Vector{X: 0, Y: 0, Z: imu.StandardGravity})
+	// 100 Hz
+	gobottest.Assert(t, samples[2].Time.Sub(samples[0].Time), 20*time.Millisecond)
+	gobottest.Assert(t, len(*fifo), 0)
+}
+
+func TestADXL345StreamWithInterrupt(t *testing.T) {
+	// arrange
+	host := newGpioExpanderTestHost()
+	a := newI2cTestAdaptor()
+	d := NewADXL345Driver(a, WithADXL345Interrupt(host, "7"))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, host.pins["7"].edge, 2) // rising
+	regs, fifo := simulateADXL345FIFO(a)
+	// act
+	s, err := d.Stream(4, 10)
+	gobottest.Assert(t, err, nil)
+	_, err = d.Stream(4, 10)
+	gobottest.Assert(t, err.Error(), "stream already started")
+	*fifo = [][]byte{{0x00, 0x01, 0, 0, 0, 0}, {0x00, 0x02, 0, 0, 0, 0}}
+	regs[0x30] = 0x03 // watermark and overrun
+	host.pins["7"].trigger()
+	// assert
+	gobottest.Assert(t, regs[0x38], uint8(0x84))
+	gobottest.Assert(t, regs[0x2E], uint8(0x03))
+	gobottest.Assert(t, regs[0x2F], uint8(0x00))
+	r := <-s.C
+	gobottest.Assert(t, r.Acceleration.X, imu.StandardGravity)
+	r = <-s.C
+	gobottest.Assert(t, r.Acceleration.X, 2*imu.StandardGravity)
+	gobottest.Assert(t, s.Overflows(), 1)
+	// stop
+	s.Stop()
+	gobottest.Assert(t, regs[0x38], uint8(0x00))
+	gobottest.Assert(t, regs[0x2E], uint8(0x00))
+	_, ok := <-s.C
+	gobottest.Assert(t, ok, false)
+}
+
+func TestADXL345StreamPolling(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewADXL345Driver(a, WithADXL345DataOutputRate(ADXL345Rate_3200HZ))
+	gobottest.Assert(t, d.Start(), nil)
+	_, fifo := simulateADXL345FIFO(a)
+	*fifo = [][]byte{{0x00, 0x01, 0, 0, 0, 0}}
+	// act
+	_, err := d.Stream(0, 10)
+	gobottest.Assert(t, err.Error(), "watermark must be in range 1..31")
+	s, err := d.Stream(1, 10)
+	// assert
+	gobottest.Assert(t, err, nil)
+	select {
+	case r := <-s.C:
+		gobottest.Assert(t, r.Acceleration.X, imu.StandardGravity)
+	case <-time.After(time.Second):
+		t.Errorf("no reading was streamed")
+	}
+	// halt closes the stream
+	gobottest.Assert(t, d.Halt(), nil)
+	for range s.C {
+	}
+}
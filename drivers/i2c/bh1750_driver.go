@@ -83,3 +83,12 @@ func (h *BH1750Driver) initialize() error {
 	}
 	return nil
 }
+
+// Illuminance returns the ambient light in lux, implements the LightSensor interface
+func (h *BH1750Driver) Illuminance() (float64, error) {
+	level, err := h.RawSensorData()
+	if err != nil {
+		return 0, err
+	}
+	return float64(level) / 1.2, nil
+}
@@ -109,3 +109,17 @@ func TestBH1750RawSensorDataError(t *testing.T) {
 	_, err := d.RawSensorData()
 	gobottest.Assert(t, err, errors.New("wrong number of bytes read"))
 }
+
+func TestBH1750Illuminance(t *testing.T) {
+	// arrange
+	d, a := initTestBH1750DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01, 0x2C})
+		return 2, nil
+	}
+	// act
+	lux, err := d.Illuminance()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, lux, 250.0)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"errors"
 	"log"
+
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 const bme280Debug = true
@@ -134,6 +136,21 @@ func (d *BME280Driver) Humidity() (humidity float32, err error) {
 	return
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature, pressure
+// and humidity
+func (d *BME280Driver) ReadEnvironment() (environment.Reading, error) {
+	r, err := d.BMP280Driver.ReadEnvironment()
+	if err != nil {
+		return r, err
+	}
+	hum, err := d.Humidity()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	r.Humidity = environment.Value(float64(hum))
+	return r, nil
+}
+
 func (d *BME280Driver) initializationBME280() (err error) {
 	// call the initialization routine of base class BMP280Driver, which do:
 	// * initializes temperature and pressure calibration coefficients
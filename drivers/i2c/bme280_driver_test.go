@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -14,6 +15,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*BME280Driver)(nil)
 
+var _ environment.Environmental = (*BME280Driver)(nil)
+
 func initTestBME280WithStubbedAdaptor() (*BME280Driver, *i2cTestAdaptor) {
 	adaptor := newI2cTestAdaptor()
 	return NewBME280Driver(adaptor), adaptor
@@ -76,6 +79,35 @@ func TestBME280Measurements(t *testing.T) {
 	gobottest.Assert(t, hum, float32(51.20179))
 }
 
+func TestBME280ReadEnvironment(t *testing.T) {
+	d, adaptor := initTestBME280WithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		buf := new(bytes.Buffer)
+		switch adaptor.written[len(adaptor.written)-1] {
+		case bmp280RegCalib00:
+			buf.Write([]byte{126, 109, 214, 102, 50, 0, 54, 149, 220, 213, 208, 11, 64, 30, 166, 255, 249, 255, 172, 38, 10, 216, 189, 16})
+		case bme280RegCalibDigH1:
+			buf.Write([]byte{75})
+		case bmp280RegTempData:
+			buf.Write([]byte{129, 0, 0})
+		case bmp280RegPressureData:
+			buf.Write([]byte{77, 23, 48})
+		case bme280RegCalibDigH2LSB:
+			buf.Write([]byte{112, 1, 0, 19, 1, 0, 30})
+		case bme280RegHumidityMSB:
+			buf.Write([]byte{111, 83})
+		}
+		copy(b, buf.Bytes())
+		return buf.Len(), nil
+	}
+	d.Start()
+	r, err := d.ReadEnvironment()
+	gobottest.Assert(t, err, nil)
+	gobottest.Refute(t, r.Temperature, nil)
+	gobottest.Refute(t, r.Pressure, nil)
+	gobottest.Assert(t, *r.Humidity, float64(float32(51.20179)))
+}
+
 func TestBME280InitH1Error(t *testing.T) {
 	bme280, adaptor := initTestBME280WithStubbedAdaptor()
 	adaptor.i2cReadImpl = func(b []byte) (int, error) {
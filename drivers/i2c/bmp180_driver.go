@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"log"
 	"time"
+
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 const bmp180Debug = false
@@ -121,6 +123,23 @@ func (d *BMP180Driver) Pressure() (pressure float32, err error) {
 	return d.calculatePressure(rawTemp, rawPressure, d.oversampling), nil
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature and pressure
+func (d *BMP180Driver) ReadEnvironment() (environment.Reading, error) {
+	temp, err := d.Temperature()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	press, err := d.Pressure()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	return environment.Reading{
+		Time:        time.Now(),
+		Temperature: environment.Value(float64(temp)),
+		Pressure:    environment.Value(float64(press)),
+	}, nil
+}
+
 func (d *BMP180Driver) initialization() (err error) {
 	// read the 11 calibration coefficients.
 	coefficients := make([]byte, 22)
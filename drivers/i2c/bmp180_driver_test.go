@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -16,6 +17,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*BMP180Driver)(nil)
 
+var _ environment.Environmental = (*BMP180Driver)(nil)
+
 func initTestBMP180WithStubbedAdaptor() (*BMP180Driver, *i2cTestAdaptor) {
 	adaptor := newI2cTestAdaptor()
 	return NewBMP180Driver(adaptor), adaptor
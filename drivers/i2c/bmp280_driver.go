@@ -5,6 +5,9 @@ import (
 	"encoding/binary"
 	"log"
 	"math"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 const bmp280Debug = true
@@ -199,6 +202,23 @@ func (d *BMP280Driver) Altitude() (alt float32, err error) {
 	return
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature and pressure
+func (d *BMP280Driver) ReadEnvironment() (environment.Reading, error) {
+	temp, err := d.Temperature()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	press, err := d.Pressure()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	return environment.Reading{
+		Time:        time.Now(),
+		Temperature: environment.Value(float64(temp)),
+		Pressure:    environment.Value(float64(press)),
+	}, nil
+}
+
 // initialization reads the calibration coefficients.
 func (d *BMP280Driver) initialization() (err error) {
 	coefficients := make([]byte, 24)
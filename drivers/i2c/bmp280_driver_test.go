@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -14,6 +15,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*BMP280Driver)(nil)
 
+var _ environment.Environmental = (*BMP280Driver)(nil)
+
 func initTestBMP280WithStubbedAdaptor() (*BMP280Driver, *i2cTestAdaptor) {
 	adaptor := newI2cTestAdaptor()
 	return NewBMP280Driver(adaptor), adaptor
@@ -98,6 +101,39 @@ func TestBMP280Measurements(t *testing.T) {
 	gobottest.Assert(t, alt, float32(149.22713))
 }
 
+func TestBMP280ReadEnvironment(t *testing.T) {
+	d, adaptor := initTestBMP280WithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		buf := new(bytes.Buffer)
+		if adaptor.written[len(adaptor.written)-1] == bmp280RegCalib00 {
+			buf.Write([]byte{126, 109, 214, 102, 50, 0, 54, 149, 220, 213, 208, 11, 64, 30, 166, 255, 249, 255, 172, 38, 10, 216, 189, 16})
+		} else if adaptor.written[len(adaptor.written)-1] == bmp280RegTempData {
+			buf.Write([]byte{128, 243, 0})
+		} else if adaptor.written[len(adaptor.written)-1] == bmp280RegPressureData {
+			buf.Write([]byte{77, 23, 48})
+		}
+		copy(b, buf.Bytes())
+		return buf.Len(), nil
+	}
+	d.Start()
+	r, err := d.ReadEnvironment()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, *r.Temperature, float64(float32(25.014637)))
+	gobottest.Assert(t, *r.Pressure, float64(float32(99545.414)))
+	gobottest.Assert(t, r.Humidity == nil, true)
+	gobottest.Assert(t, r.Time.IsZero(), false)
+}
+
+func TestBMP280ReadEnvironmentError(t *testing.T) {
+	d, adaptor := initTestBMP280WithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func([]byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadEnvironment()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
 func TestBMP280TemperatureWriteError(t *testing.T) {
 	d, adaptor := initTestBMP280WithStubbedAdaptor()
 	d.Start()
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 const bmp388Debug = false
@@ -191,6 +194,24 @@ func (d *BMP388Driver) Altitude(accuracy BMP388Accuracy) (alt float32, err error
 	return
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature and pressure,
+// both measured with standard accuracy
+func (d *BMP388Driver) ReadEnvironment() (environment.Reading, error) {
+	temp, err := d.Temperature(BMP388AccuracyStandard)
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	press, err := d.Pressure(BMP388AccuracyStandard)
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	return environment.Reading{
+		Time:        time.Now(),
+		Temperature: environment.Value(float64(temp)),
+		Pressure:    environment.Value(float64(press)),
+	}, nil
+}
+
 // initialization reads the calibration coefficients.
 func (d *BMP388Driver) initialization() (err error) {
 	var chipID uint8
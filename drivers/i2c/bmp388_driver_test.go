@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -15,6 +16,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*BMP388Driver)(nil)
 
+var _ environment.Environmental = (*BMP388Driver)(nil)
+
 func initTestBMP388WithStubbedAdaptor() (*BMP388Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"time"
+
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 // CCS811DriveMode type
@@ -249,6 +251,32 @@ func (d *CCS811Driver) GetGasData() (uint16, uint16, error) {
 	return eco2, tvoC, nil
 }
 
+//SetEnvironmentalData sets the relative humidity [%] and the temperature [°C] for the compensation of the
+//gas measurement. It implements the environment.Compensated interface.
+func (d *CCS811Driver) SetEnvironmentalData(humidity float64, temperature float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// both values in units of 1/512, the temperature with an offset of 25°C
+	hum := uint16(math.Round(math.Max(0, math.Min(100, humidity)) * 512))
+	temp := uint16(math.Round(math.Max(0, math.Min(127, temperature+25)) * 512))
+	data := []byte{byte(hum >> 8), byte(hum), byte(temp >> 8), byte(temp)}
+	return d.connection.WriteBlockData(ccs811RegEnvData, data)
+}
+
+//ReadEnvironment returns the latest eCO2 and TVOC values. It implements the environment.Environmental interface.
+func (d *CCS811Driver) ReadEnvironment() (environment.Reading, error) {
+	eco2, tvoc, err := d.GetGasData()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	return environment.Reading{
+		Time: time.Now(),
+		ECO2: environment.Value(float64(eco2)),
+		TVOC: environment.Value(float64(tvoc)),
+	}, nil
+}
+
 //HasData returns true if the device has not errored and temperature/gas data is available
 func (d *CCS811Driver) HasData() (bool, error) {
 	s, err := d.GetStatus()
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -13,6 +14,9 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*CCS811Driver)(nil)
 
+var _ environment.Environmental = (*CCS811Driver)(nil)
+var _ environment.Compensated = (*CCS811Driver)(nil)
+
 func initTestCCS811WithStubbedAdaptor() (*CCS811Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
 	return NewCCS811Driver(a), a
@@ -98,6 +102,54 @@ func TestCCS811GetGasData(t *testing.T) {
 	}
 }
 
+func TestCCS811ReadEnvironment(t *testing.T) {
+	d, a := initTestCCS811WithStubbedAdaptor()
+	a.i2cWriteImpl = func([]byte) (int, error) { return 0, nil }
+	d.Start()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{1, 156, 0, 86})
+		return 4, nil
+	}
+	r, err := d.ReadEnvironment()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, *r.ECO2, 412.0)
+	gobottest.Assert(t, *r.TVOC, 86.0)
+	gobottest.Assert(t, r.Temperature == nil, true)
+}
+
+func TestCCS811SetEnvironmentalData(t *testing.T) {
+	var tests = map[string]struct {
+		humidity    float64
+		temperature float64
+		want        []byte
+	}{
+		"datasheet example": {
+			humidity:    48.5,
+			temperature: 23.5,
+			want:        []byte{ccs811RegEnvData, 0x61, 0x00, 0x61, 0x00},
+		},
+		"limited to valid range": {
+			humidity:    120,
+			temperature: -40,
+			want:        []byte{ccs811RegEnvData, 0xC8, 0x00, 0x00, 0x00},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestCCS811WithStubbedAdaptor()
+			a.i2cWriteImpl = func([]byte) (int, error) { return 0, nil }
+			d.Start()
+			a.written = []byte{}
+			// act
+			err := d.SetEnvironmentalData(tc.humidity, tc.temperature)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, a.written, tc.want)
+		})
+	}
+}
+
 func TestCCS811GetTemperature(t *testing.T) {
 	var tests = map[string]struct {
 		readReturn func([]byte) (int, error)
@@ -1,5 +1,13 @@
 package i2c
 
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
 // DRV2605Mode - operating mode
 type DRV2605Mode uint8
 
@@ -15,7 +23,20 @@ const (
 	DRV2605ModeAutocal                 = 0x07
 )
 
+// Libraries of effects, for use in SelectLibrary(). The libraries A-E are tuned for different ERM actuators.
+const (
+	DRV2605LibraryEmpty = 0x00
+	DRV2605LibraryERMA  = 0x01
+	DRV2605LibraryERMB  = 0x02
+	DRV2605LibraryERMC  = 0x03
+	DRV2605LibraryERMD  = 0x04
+	DRV2605LibraryERME  = 0x05
+	DRV2605LibraryLRA   = 0x06
+)
+
 const (
+	drv2605Debug = false
+
 	drv2605DefaultAddress = 0x5A
 
 	drv2605RegStatus = 0x00
@@ -55,8 +76,79 @@ const (
 	drv2605RegControl4      = 0x1E
 	drv2605RegVBat          = 0x21
 	drv2605RegLRAResoPeriod = 0x22
+
+	drv2605StatusDiagResult      = 0x08
+	drv2605FeedbackLRA           = 0x80
+	drv2605FeedbackDefault       = 0x36 // brake factor 4x, medium loop gain, back-EMF gain 2
+	drv2605FeedbackBackEMFGain   = 0x03
+	drv2605Control1DriveTime     = 0x1F
+	drv2605Control1ACCouple      = 0x20
+	drv2605Control3NPWMAnalog    = 0x02
+	drv2605Control3DataFormatRTP = 0x08
+	drv2605Control3ERMOpenLoop   = 0x20
+	drv2605Control4AutoCalTime   = 0x30 // 1000ms - 1200ms
+
+	drv2605PollInterval    = 5 * time.Millisecond
+	drv2605AutoCalTimeout  = 2 * time.Second
+	drv2605PlaybackTimeout = 15 * time.Second
+
+	// voltage per LSB of the rated voltage and overdrive clamp registers, the overdrive clamp values are
+	// approximations for the default timing of the driver
+	drv2605ERMRatedVoltageLSB = 0.02133
+	drv2605LRARatedVoltageLSB = 0.02071
+	drv2605ERMClampVoltageLSB = 0.02159
+	drv2605LRAClampVoltageLSB = 0.02122
+	// 4 * sample time + 300us, with default sample time of 300us
+	drv2605LRASampleTime = 1500 * time.Microsecond
 )
 
+// DRV2605Actuator describes the actuator for the auto calibration.
+type DRV2605Actuator struct {
+	// LRA selects a linear resonant actuator, otherwise an eccentric rotating mass motor (ERM) is used
+	LRA bool
+	// RatedVoltage is the rated voltage in volts, RMS for LRA and average for ERM
+	RatedVoltage float64
+	// OverdriveVoltage is the peak voltage in volts, which is allowed during overdrive and braking, the rated voltage
+	// is used if not set
+	OverdriveVoltage float64
+	// ResonantFrequency is the resonant frequency of a LRA in Hz, not used for ERM
+	ResonantFrequency float64
+}
+
+// DRV2605Calibration contains the register values of the actuator configuration and the results of the auto
+// calibration. The calibration can be stored, e.g. by kvstore.Store.SetJSON(), and applied on next start by
+// WithDRV2605LCalibration(), so the auto calibration is only needed once per actuator.
+type DRV2605Calibration struct {
+	LRA            bool  `json:"lra"`
+	RatedVoltage   uint8 `json:"ratedVoltage"`
+	OverdriveClamp uint8 `json:"overdriveClamp"`
+	DriveTime      uint8 `json:"driveTime"`
+	Compensation   uint8 `json:"compensation"`
+	BackEMF        uint8 `json:"backEMF"`
+	BackEMFGain    uint8 `json:"backEMFGain"`
+}
+
+// DRV2605AudioConfig contains the parameters of the audio-to-vibe mode, see DRV2605DefaultAudioConfig for the
+// values after reset of the device.
+type DRV2605AudioConfig struct {
+	// MinInputLevel is the threshold of the input, below no vibration is played, 1.8V / 255 per LSB
+	MinInputLevel uint8
+	// MaxInputLevel is the input level, which is mapped to the maximum drive, 1.8V / 255 per LSB
+	MaxInputLevel uint8
+	// MinDrive is the drive level for the minimum input level, 0..255 for 0%..100% of full scale
+	MinDrive uint8
+	// MaxDrive is the drive level for the maximum input level, 0..255 for 0%..100% of full scale
+	MaxDrive uint8
+}
+
+// DRV2605DefaultAudioConfig contains the values of the audio-to-vibe parameters after reset of the device.
+var DRV2605DefaultAudioConfig = DRV2605AudioConfig{
+	MinInputLevel: 0x19,
+	MaxInputLevel: 0xFF,
+	MinDrive:      0x19,
+	MaxDrive:      0xFF,
+}
+
 // DRV2605LDriver is the gobot driver for the TI/Adafruit DRV2605L Haptic Controller
 //
 // Device datasheet: http://www.ti.com/lit/ds/symlink/drv2605l.pdf
@@ -69,8 +161,16 @@ const (
 //  haptic.SetSequence([]byte{1, 13})
 //  haptic.Go()
 //
+// Named effects:
+//
+//  haptic.PlayEffect(i2c.DRV2605EffectStrongClick_100, i2c.DRV2605EffectSoftFuzz_60)
+//
 type DRV2605LDriver struct {
 	*Driver
+	calibration *DRV2605Calibration
+	rtHalt      chan bool // true: the device is set to "internal trig" mode at the end of the playback
+	rtDone      chan struct{}
+	rtMutex     sync.Mutex // the driver mutex is already locked on halt
 }
 
 // NewDRV2605LDriver creates a new driver for the DRV2605L device.
@@ -81,6 +181,7 @@ type DRV2605LDriver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithDRV2605LCalibration(DRV2605Calibration):	calibration to apply on start
 //
 func NewDRV2605LDriver(c Connector, options ...func(Config)) *DRV2605LDriver {
 	d := &DRV2605LDriver{
@@ -96,6 +197,19 @@ func NewDRV2605LDriver(c Connector, options ...func(Config)) *DRV2605LDriver {
 	return d
 }
 
+// WithDRV2605LCalibration option sets a calibration, which was stored after a previous auto calibration. The
+// calibration is applied on start of the driver.
+func WithDRV2605LCalibration(calibration DRV2605Calibration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*DRV2605LDriver)
+		if ok {
+			d.calibration = &calibration
+		} else if drv2605Debug {
+			log.Printf("Trying to set calibration for non-DRV2605LDriver %v", c)
+		}
+	}
+}
+
 // SetMode sets the device in one of the eight modes as described in the
 // datasheet. Defaults to mode 0, internal trig.
 func (d *DRV2605LDriver) SetMode(newMode DRV2605Mode) (err error) {
@@ -177,6 +291,389 @@ func (d *DRV2605LDriver) Go() (err error) {
 	return err
 }
 
+// PlayEffect plays the given effects by the sequencer and waits until the playback is finished.
+func (d *DRV2605LDriver) PlayEffect(effects ...DRV2605Effect) error {
+	return d.PlayPattern(NewDRV2605Pattern(effects...))
+}
+
+// PlayPattern plays the pattern by the sequencer in "internal trig" mode and waits until the playback is finished.
+// Patterns with more than 8 effects and pauses are played in parts of 8.
+func (d *DRV2605LDriver) PlayPattern(pattern *DRV2605Pattern) error {
+	if err := pattern.validate(); err != nil {
+		return err
+	}
+
+	d.stopRealtime()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.SetMode(DRV2605ModeIntTrig); err != nil {
+		return err
+	}
+	waveforms := pattern.Waveforms()
+	for start := 0; start < len(waveforms); start += 8 {
+		end := start + 8
+		if end > len(waveforms) {
+			end = len(waveforms)
+		}
+		if err := d.SetSequence(waveforms[start:end]); err != nil {
+			return err
+		}
+		if err := d.Go(); err != nil {
+			return err
+		}
+		if err := d.waitForGoCleared(drv2605PlaybackTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops the playback of the sequencer and the real-time playback. The audio-to-vibe mode is left and
+// the device is set to "internal trig" mode.
+func (d *DRV2605LDriver) Stop() error {
+	d.stopRealtime()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.connection.WriteByteData(drv2605RegGo, 0); err != nil {
+		return err
+	}
+	return d.SetMode(DRV2605ModeIntTrig)
+}
+
+// AutoCalibrate runs the auto calibration for the given actuator, which needs to be mounted as in the final
+// application. On success the calibration is applied and returned, so it can be stored for the next start, see
+// WithDRV2605LCalibration(). For a LRA the library of LRA effects is selected.
+func (d *DRV2605LDriver) AutoCalibrate(actuator DRV2605Actuator) (DRV2605Calibration, error) {
+	d.stopRealtime()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	calibration, err := actuator.calibration()
+	if err != nil {
+		return calibration, err
+	}
+	if !calibration.LRA {
+		control1, err := d.connection.ReadByteData(drv2605RegControl1)
+		if err != nil {
+			return calibration, err
+		}
+		calibration.DriveTime = control1 & drv2605Control1DriveTime
+	}
+	calibration.BackEMFGain = drv2605FeedbackDefault & drv2605FeedbackBackEMFGain
+	if err := d.SetMode(DRV2605ModeAutocal); err != nil {
+		return calibration, err
+	}
+	if err := d.SetStandbyMode(false); err != nil {
+		return calibration, err
+	}
+	if err := d.writeCalibration(calibration); err != nil {
+		return calibration, err
+	}
+	control4, err := d.connection.ReadByteData(drv2605RegControl4)
+	if err != nil {
+		return calibration, err
+	}
+	if err := d.connection.WriteByteData(drv2605RegControl4, control4|drv2605Control4AutoCalTime); err != nil {
+		return calibration, err
+	}
+
+	if err := d.Go(); err != nil {
+		return calibration, err
+	}
+	if err := d.waitForGoCleared(drv2605AutoCalTimeout); err != nil {
+		return calibration, err
+	}
+	status, err := d.connection.ReadByteData(drv2605RegStatus)
+	if err != nil {
+		return calibration, err
+	}
+	if status&drv2605StatusDiagResult != 0 {
+		return calibration, fmt.Errorf("auto calibration failed, check the actuator and its parameters")
+	}
+
+	if calibration.Compensation, err = d.connection.ReadByteData(drv2605RegAutocalComp); err != nil {
+		return calibration, err
+	}
+	if calibration.BackEMF, err = d.connection.ReadByteData(drv2605RegAutocalEmp); err != nil {
+		return calibration, err
+	}
+	feedback, err := d.connection.ReadByteData(drv2605RegFeedback)
+	if err != nil {
+		return calibration, err
+	}
+	calibration.BackEMFGain = feedback & drv2605FeedbackBackEMFGain
+
+	if err := d.SetMode(DRV2605ModeIntTrig); err != nil {
+		return calibration, err
+	}
+	if calibration.LRA {
+		if err := d.SelectLibrary(DRV2605LibraryLRA); err != nil {
+			return calibration, err
+		}
+	}
+	d.calibration = &calibration
+	return calibration, nil
+}
+
+// Calibration returns the calibration, which was applied on start or measured by AutoCalibrate(). False is
+// returned, if the driver is not calibrated.
+func (d *DRV2605LDriver) Calibration() (DRV2605Calibration, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.calibration == nil {
+		return DRV2605Calibration{}, false
+	}
+	return *d.calibration, true
+}
+
+// SetCalibration applies a calibration, which was stored after a previous auto calibration.
+func (d *DRV2605LDriver) SetCalibration(calibration DRV2605Calibration) error {
+	d.stopRealtime()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.writeCalibration(calibration); err != nil {
+		return err
+	}
+	d.calibration = &calibration
+	return nil
+}
+
+// StartAudioToVibe switches to the audio-to-vibe mode, the audio signal is expected at the IN/TRIG pin. Use Stop()
+// to leave the mode.
+func (d *DRV2605LDriver) StartAudioToVibe(config DRV2605AudioConfig) error {
+	d.stopRealtime()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	control1, err := d.connection.ReadByteData(drv2605RegControl1)
+	if err != nil {
+		return err
+	}
+	control3, err := d.connection.ReadByteData(drv2605RegControl3)
+	if err != nil {
+		return err
+	}
+	if err := d.writeByteRegisters([]struct{ reg, val uint8 }{
+		{drv2605RegControl1, control1 | drv2605Control1ACCouple},
+		{drv2605RegControl3, control3 | drv2605Control3NPWMAnalog},
+		{drv2605RegAudioMinLevel, config.MinInputLevel},
+		{drv2605RegAudioMaxLevel, config.MaxInputLevel},
+		{drv2605RegAudioMinDrive, config.MinDrive},
+		{drv2605RegAudioMaxDrive, config.MaxDrive},
+	}); err != nil {
+		return err
+	}
+	return d.SetMode(DRV2605ModeAudioVibe)
+}
+
+// StartRealtime switches to the real-time playback mode and streams the amplitude envelope to the device. The
+// envelope function is called with the elapsed time once per interval and returns the amplitude in range 0..1.
+// The playback ends when the function returns false or Stop() is called, afterwards the device is set to
+// "internal trig" mode. The returned channel delivers the result of the playback and is closed afterwards.
+func (d *DRV2605LDriver) StartRealtime(interval time.Duration,
+	envelope func(elapsed time.Duration) (amplitude float64, ok bool)) (<-chan error, error) {
+	d.stopRealtime()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	control3, err := d.connection.ReadByteData(drv2605RegControl3)
+	if err != nil {
+		return nil, err
+	}
+	// unsigned values for the amplitude
+	if err := d.connection.WriteByteData(drv2605RegControl3, control3|drv2605Control3DataFormatRTP); err != nil {
+		return nil, err
+	}
+	if err := d.connection.WriteByteData(drv2605RegRTPin, 0); err != nil {
+		return nil, err
+	}
+	if err := d.SetMode(DRV2605ModeRealtime); err != nil {
+		return nil, err
+	}
+
+	result := make(chan error, 1)
+	halt := make(chan bool, 1)
+	done := make(chan struct{})
+	d.rtMutex.Lock()
+	d.rtHalt = halt
+	d.rtDone = done
+	d.rtMutex.Unlock()
+
+	go func() {
+		defer close(done)
+		defer close(result)
+		result <- d.playRealtime(interval, envelope, halt)
+	}()
+	return result, nil
+}
+
+// playRealtime streams the envelope until its end or until halted. Each access to the device is done with the
+// driver mutex locked, so the playback can not interfere with other functions of the driver.
+func (d *DRV2605LDriver) playRealtime(interval time.Duration,
+	envelope func(elapsed time.Duration) (float64, bool), halt chan bool) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	restore := true
+	var err error
+loop:
+	for {
+		amplitude, ok := envelope(time.Since(start))
+		if !ok {
+			break
+		}
+		value := uint8(math.Round(math.Max(0, math.Min(1, amplitude)) * 0xFF))
+		d.mutex.Lock()
+		select {
+		case restore = <-halt:
+			d.mutex.Unlock()
+			break loop
+		default:
+		}
+		err = d.connection.WriteByteData(drv2605RegRTPin, value)
+		d.mutex.Unlock()
+		if err != nil {
+			break
+		}
+		select {
+		case restore = <-halt:
+			break loop
+		case <-ticker.C:
+		}
+	}
+	if !restore {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// the driver could be halted meanwhile
+	select {
+	case restore = <-halt:
+	default:
+	}
+	if !restore {
+		return err
+	}
+	if e := d.connection.WriteByteData(drv2605RegRTPin, 0); e != nil && err == nil {
+		err = e
+	}
+	if e := d.SetMode(DRV2605ModeIntTrig); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// stopRealtime stops a running real-time playback and waits for its end, afterwards the device is in "internal
+// trig" mode. This must not be called with the driver mutex locked.
+func (d *DRV2605LDriver) stopRealtime() {
+	if done := d.haltRealtime(true); done != nil {
+		<-done
+	}
+}
+
+// haltRealtime signals a running real-time playback to end and returns the channel, which is closed at its end.
+// Without restore the playback ends without any further access to the device, this is used on halt, where the
+// driver mutex is already locked.
+func (d *DRV2605LDriver) haltRealtime(restore bool) chan struct{} {
+	d.rtMutex.Lock()
+	defer d.rtMutex.Unlock()
+
+	halt := d.rtHalt
+	done := d.rtDone
+	d.rtHalt = nil
+	d.rtDone = nil
+	if halt != nil {
+		halt <- restore
+	}
+	return done
+}
+
+func (d *DRV2605LDriver) waitForGoCleared(timeout time.Duration) error {
+	start := time.Now()
+	for {
+		val, err := d.connection.ReadByteData(drv2605RegGo)
+		if err != nil {
+			return err
+		}
+		if val&0x01 == 0 {
+			return nil
+		}
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timeout while waiting for end of DRV2605L operation")
+		}
+		time.Sleep(drv2605PollInterval)
+	}
+}
+
+func (d *DRV2605LDriver) writeCalibration(c DRV2605Calibration) error {
+	feedback := uint8(drv2605FeedbackDefault&^drv2605FeedbackBackEMFGain) | c.BackEMFGain&drv2605FeedbackBackEMFGain
+	if c.LRA {
+		feedback |= drv2605FeedbackLRA
+	}
+	control1, err := d.connection.ReadByteData(drv2605RegControl1)
+	if err != nil {
+		return err
+	}
+	control3, err := d.connection.ReadByteData(drv2605RegControl3)
+	if err != nil {
+		return err
+	}
+	return d.writeByteRegisters([]struct{ reg, val uint8 }{
+		{drv2605RegRatedV, c.RatedVoltage},
+		{drv2605RegClampV, c.OverdriveClamp},
+		{drv2605RegAutocalComp, c.Compensation},
+		{drv2605RegAutocalEmp, c.BackEMF},
+		{drv2605RegFeedback, feedback},
+		{drv2605RegControl1, control1&^drv2605Control1DriveTime | c.DriveTime&drv2605Control1DriveTime},
+		// closed loop for ERM
+		{drv2605RegControl3, control3 &^ drv2605Control3ERMOpenLoop},
+	})
+}
+
+// calibration converts the actuator parameters to register values
+func (a DRV2605Actuator) calibration() (DRV2605Calibration, error) {
+	c := DRV2605Calibration{LRA: a.LRA}
+	if a.RatedVoltage <= 0 {
+		return c, fmt.Errorf("the rated voltage needs to be greater than 0, but is %v", a.RatedVoltage)
+	}
+	overdrive := a.OverdriveVoltage
+	if overdrive <= 0 {
+		overdrive = a.RatedVoltage
+	}
+	if !a.LRA {
+		c.RatedVoltage = drv2605Register(a.RatedVoltage / drv2605ERMRatedVoltageLSB)
+		c.OverdriveClamp = drv2605Register(overdrive / drv2605ERMClampVoltageLSB)
+		return c, nil
+	}
+	if a.ResonantFrequency <= 0 {
+		return c, fmt.Errorf("the resonant frequency is needed for LRA, but is %v", a.ResonantFrequency)
+	}
+	correction := math.Sqrt(math.Max(0, 1-drv2605LRASampleTime.Seconds()*a.ResonantFrequency))
+	c.RatedVoltage = drv2605Register(a.RatedVoltage * correction / drv2605LRARatedVoltageLSB)
+	c.OverdriveClamp = drv2605Register(overdrive / drv2605LRAClampVoltageLSB)
+	// the drive time is half of the period: 0.5ms + 0.1ms * DRIVE_TIME
+	halfPeriodMs := 500 / a.ResonantFrequency
+	c.DriveTime = uint8(math.Max(0, math.Min(drv2605Control1DriveTime, math.Round((halfPeriodMs-0.5)/0.1))))
+	return c, nil
+}
+
+func drv2605Register(value float64) uint8 {
+	return uint8(math.Max(0, math.Min(0xFF, math.Round(value))))
+}
+
 func (d *DRV2605LDriver) writeByteRegisters(regValPairs []struct{ reg, val uint8 }) (err error) {
 	for _, rv := range regValPairs {
 		if err = d.connection.WriteByteData(rv.reg, rv.val); err != nil {
@@ -187,6 +684,16 @@ func (d *DRV2605LDriver) writeByteRegisters(regValPairs []struct{ reg, val uint8
 }
 
 func (d *DRV2605LDriver) initialize() error {
+	if err := d.initializeDefaults(); err != nil {
+		return err
+	}
+	if d.calibration != nil {
+		return d.writeCalibration(*d.calibration)
+	}
+	return nil
+}
+
+func (d *DRV2605LDriver) initializeDefaults() error {
 	feedback, err := d.connection.ReadByteData(drv2605RegFeedback)
 	if err != nil {
 		return err
@@ -216,7 +723,17 @@ func (d *DRV2605LDriver) initialize() error {
 }
 
 func (d *DRV2605LDriver) shutdown() (err error) {
+	realtime := d.haltRealtime(false) != nil
 	if d.connection != nil {
+		if realtime {
+			if err = d.connection.WriteByteData(drv2605RegRTPin, 0); err != nil {
+				return err
+			}
+			if err = d.SetMode(DRV2605ModeIntTrig); err != nil {
+				return err
+			}
+		}
+
 		// stop playback
 		if err = d.connection.WriteByteData(drv2605RegGo, 0); err != nil {
 			return err
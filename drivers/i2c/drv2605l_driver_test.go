@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -135,3 +137,252 @@ func TestDRV2605LGo(t *testing.T) {
 	d, _ := initTestDRV2605LDriverWithStubbedAdaptor()
 	gobottest.Assert(t, d.Go(), nil)
 }
+
+func initTestDRV2605LDriverWithSimulatedRegisters(options ...func(Config)) (*DRV2605LDriver, *i2cTestAdaptor,
+	*memoryTestDevice) {
+	a := newI2cTestAdaptor()
+	m := simulateMemory(a, drv2605RegLRAResoPeriod+1, 1)
+	// the device clears the go bit immediately
+	m.fixed[drv2605RegGo] = 0
+	d := NewDRV2605LDriver(a, options...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a, m
+}
+
+// recordDRV2605LWrites records the values written to the given register
+func recordDRV2605LWrites(a *i2cTestAdaptor, reg uint8) func() []uint8 {
+	var values []uint8
+	write := a.i2cWriteImpl
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		if len(b) == 2 && b[0] == reg {
+			values = append(values, b[1])
+		}
+		return write(b)
+	}
+	return func() []uint8 {
+		a.mtx.Lock()
+		defer a.mtx.Unlock()
+		return append([]uint8{}, values...)
+	}
+}
+
+func TestDRV2605LPlayPattern(t *testing.T) {
+	// arrange
+	d, a, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	m.mem[drv2605RegMode] = uint8(DRV2605ModeRealtime)
+	goWrites := recordDRV2605LWrites(a, drv2605RegGo)
+	pattern := NewDRV2605Pattern(DRV2605EffectStrongClick_100).Pause(10 * time.Millisecond).Repeat(5)
+	// act
+	err := d.PlayPattern(pattern)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, goWrites(), []uint8{1, 1})
+	gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeIntTrig))
+	// second part of the pattern
+	gobottest.Assert(t, m.mem[drv2605RegWaveSeq1:drv2605RegWaveSeq4], []byte{1, 0x81, 0})
+}
+
+func TestDRV2605LPlayPatternStopsRealtime(t *testing.T) {
+	// arrange
+	d, a, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	rtpWrites := recordDRV2605LWrites(a, drv2605RegRTPin)
+	started := make(chan bool, 1)
+	result, err := d.StartRealtime(time.Millisecond, func(elapsed time.Duration) (float64, bool) {
+		select {
+		case started <- true:
+		default:
+		}
+		return 1, true
+	})
+	gobottest.Assert(t, err, nil)
+	<-started
+	// act
+	err = d.PlayEffect(DRV2605EffectStrongClick_100)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, <-result, nil)
+	writes := rtpWrites()
+	gobottest.Assert(t, writes[len(writes)-1], uint8(0))
+	time.Sleep(5 * time.Millisecond)
+	gobottest.Assert(t, len(rtpWrites()), len(writes))
+	gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeIntTrig))
+	gobottest.Assert(t, m.mem[drv2605RegWaveSeq1:drv2605RegWaveSeq2+1], []byte{1, 0})
+}
+
+func TestDRV2605LPlayEffectErrors(t *testing.T) {
+	d, a, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	a.written = []byte{}
+	gobottest.Assert(t, d.PlayEffect(), fmt.Errorf("the pattern contains no effect"))
+	gobottest.Assert(t, len(a.written), 0)
+	gobottest.Assert(t, d.PlayEffect(DRV2605Effect(200)), fmt.Errorf("invalid effect 200 in pattern"))
+	m.fixed[drv2605RegGo] = 1
+	a.i2cReadImpl = func(b []byte) (int, error) { return 0, errors.New("read error") }
+	gobottest.Assert(t, d.PlayEffect(DRV2605EffectBuzz1_100), errors.New("read error"))
+}
+
+func TestDRV2605LAutoCalibrate(t *testing.T) {
+	var tests = map[string]struct {
+		actuator     DRV2605Actuator
+		status       uint8
+		want         DRV2605Calibration
+		wantFeedback uint8
+		wantLibrary  uint8
+		wantErr      error
+	}{
+		"lra": {
+			actuator:     DRV2605Actuator{LRA: true, RatedVoltage: 2.0, OverdriveVoltage: 2.5, ResonantFrequency: 175},
+			want:         DRV2605Calibration{LRA: true, RatedVoltage: 83, OverdriveClamp: 118, DriveTime: 24, Compensation: 0x0D, BackEMF: 0x6B, BackEMFGain: 3},
+			wantFeedback: 0xB6,
+			wantLibrary:  DRV2605LibraryLRA,
+		},
+		"erm": {
+			actuator:     DRV2605Actuator{RatedVoltage: 3.0},
+			want:         DRV2605Calibration{RatedVoltage: 141, OverdriveClamp: 139, DriveTime: 0x13, Compensation: 0x0D, BackEMF: 0x6B, BackEMFGain: 3},
+			wantFeedback: 0x36,
+			wantLibrary:  DRV2605LibraryERMB,
+		},
+		"failed": {
+			actuator: DRV2605Actuator{RatedVoltage: 3.0},
+			status:   drv2605StatusDiagResult,
+			wantErr:  fmt.Errorf("auto calibration failed, check the actuator and its parameters"),
+		},
+		"error_voltage": {
+			actuator: DRV2605Actuator{LRA: true, ResonantFrequency: 175},
+			wantErr:  fmt.Errorf("the rated voltage needs to be greater than 0, but is 0"),
+		},
+		"error_frequency": {
+			actuator: DRV2605Actuator{LRA: true, RatedVoltage: 2.0},
+			wantErr:  fmt.Errorf("the resonant frequency is needed for LRA, but is 0"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, m := initTestDRV2605LDriverWithSimulatedRegisters()
+			m.mem[drv2605RegControl1] = 0x93
+			m.mem[drv2605RegLibrary] = DRV2605LibraryERMB
+			m.fixed[drv2605RegStatus] = tc.status
+			m.fixed[drv2605RegAutocalComp] = 0x0D
+			m.fixed[drv2605RegAutocalEmp] = 0x6B
+			m.fixed[drv2605RegFeedback] = 0xB7
+			// act
+			got, err := d.AutoCalibrate(tc.actuator)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			stored, ok := d.Calibration()
+			if tc.wantErr != nil {
+				gobottest.Assert(t, ok, false)
+				return
+			}
+			gobottest.Assert(t, got, tc.want)
+			gobottest.Assert(t, ok, true)
+			gobottest.Assert(t, stored, tc.want)
+			gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeIntTrig))
+			gobottest.Assert(t, m.mem[drv2605RegFeedback], tc.wantFeedback)
+			gobottest.Assert(t, m.mem[drv2605RegControl3]&drv2605Control3ERMOpenLoop, uint8(0))
+			gobottest.Assert(t, m.mem[drv2605RegControl4]&drv2605Control4AutoCalTime, uint8(drv2605Control4AutoCalTime))
+			gobottest.Assert(t, m.mem[drv2605RegLibrary], tc.wantLibrary)
+		})
+	}
+}
+
+func TestDRV2605LWithCalibration(t *testing.T) {
+	// arrange
+	c := DRV2605Calibration{LRA: true, RatedVoltage: 83, OverdriveClamp: 118, DriveTime: 24, Compensation: 0x0D,
+		BackEMF: 0x6B, BackEMFGain: 3}
+	// act
+	d, _, m := initTestDRV2605LDriverWithSimulatedRegisters(WithDRV2605LCalibration(c))
+	// assert
+	got, ok := d.Calibration()
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, got, c)
+	gobottest.Assert(t, m.mem[drv2605RegRatedV:drv2605RegControl1+1], []byte{83, 118, 0x0D, 0x6B, 0xB7, 24})
+	gobottest.Assert(t, m.mem[drv2605RegControl3]&drv2605Control3ERMOpenLoop, uint8(0))
+}
+
+func TestDRV2605LSetCalibration(t *testing.T) {
+	// arrange
+	d, _, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	_, ok := d.Calibration()
+	gobottest.Assert(t, ok, false)
+	gobottest.Assert(t, m.mem[drv2605RegControl3]&drv2605Control3ERMOpenLoop, uint8(drv2605Control3ERMOpenLoop))
+	m.mem[drv2605RegControl1] = 0x93
+	c := DRV2605Calibration{RatedVoltage: 141, OverdriveClamp: 139, DriveTime: 0x10, BackEMFGain: 1}
+	// act
+	err := d.SetCalibration(c)
+	// assert
+	gobottest.Assert(t, err, nil)
+	got, ok := d.Calibration()
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, got, c)
+	gobottest.Assert(t, m.mem[drv2605RegRatedV:drv2605RegControl1+1], []byte{141, 139, 0, 0, 0x35, 0x90})
+	gobottest.Assert(t, m.mem[drv2605RegControl3]&drv2605Control3ERMOpenLoop, uint8(0))
+}
+
+func TestDRV2605LStartAudioToVibe(t *testing.T) {
+	// arrange
+	d, _, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	// act
+	err := d.StartAudioToVibe(DRV2605DefaultAudioConfig)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeAudioVibe))
+	gobottest.Assert(t, m.mem[drv2605RegControl1]&drv2605Control1ACCouple, uint8(drv2605Control1ACCouple))
+	gobottest.Assert(t, m.mem[drv2605RegControl3]&drv2605Control3NPWMAnalog, uint8(drv2605Control3NPWMAnalog))
+	gobottest.Assert(t, m.mem[drv2605RegAudioMinLevel:drv2605RegAudioMaxDrive+1], []byte{0x19, 0xFF, 0x19, 0xFF})
+	// act
+	m.mem[drv2605RegGo] = 1
+	err = d.Stop()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeIntTrig))
+	gobottest.Assert(t, m.mem[drv2605RegGo], uint8(0))
+}
+
+func TestDRV2605LStartRealtime(t *testing.T) {
+	// arrange
+	d, a, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	rtpWrites := recordDRV2605LWrites(a, drv2605RegRTPin)
+	amplitudes := []float64{0, 0.5, 1.2}
+	calls := 0
+	envelope := func(elapsed time.Duration) (float64, bool) {
+		if calls >= len(amplitudes) {
+			return 0, false
+		}
+		calls++
+		return amplitudes[calls-1], true
+	}
+	// act
+	result, err := d.StartRealtime(time.Millisecond, envelope)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, <-result, nil)
+	_, open := <-result
+	gobottest.Assert(t, open, false)
+	gobottest.Assert(t, rtpWrites(), []uint8{0, 0, 128, 255, 0})
+	gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeIntTrig))
+	gobottest.Assert(t, m.mem[drv2605RegControl3]&drv2605Control3DataFormatRTP, uint8(drv2605Control3DataFormatRTP))
+}
+
+func TestDRV2605LStopRealtime(t *testing.T) {
+	// arrange
+	d, _, m := initTestDRV2605LDriverWithSimulatedRegisters()
+	started := make(chan bool, 1)
+	result, err := d.StartRealtime(time.Millisecond, func(elapsed time.Duration) (float64, bool) {
+		select {
+		case started <- true:
+		default:
+		}
+		return 1, true
+	})
+	gobottest.Assert(t, err, nil)
+	<-started
+	// act
+	gobottest.Assert(t, d.Halt(), nil)
+	// assert
+	gobottest.Assert(t, <-result, nil)
+	gobottest.Assert(t, m.mem[drv2605RegRTPin], uint8(0))
+	gobottest.Assert(t, m.mem[drv2605RegMode], uint8(DRV2605ModeIntTrig|drv2605Standby))
+}
@@ -0,0 +1,376 @@
+package i2c
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DRV2605Effect is an effect of the ROM libraries of the DRV2605L. The effects are the same for all libraries, but
+// the waveforms are tuned for different actuators, see SelectLibrary().
+type DRV2605Effect uint8
+
+// Effects of the ROM libraries, named according to table 11.2 of the data sheet. The number after the underscore
+// is the strength in percent.
+const (
+	DRV2605EffectStrongClick_100 DRV2605Effect = iota + 1
+	DRV2605EffectStrongClick_60
+	DRV2605EffectStrongClick_30
+	DRV2605EffectSharpClick_100
+	DRV2605EffectSharpClick_60
+	DRV2605EffectSharpClick_30
+	DRV2605EffectSoftBump_100
+	DRV2605EffectSoftBump_60
+	DRV2605EffectSoftBump_30
+	DRV2605EffectDoubleClick_100
+	DRV2605EffectDoubleClick_60
+	DRV2605EffectTripleClick_100
+	DRV2605EffectSoftFuzz_60
+	DRV2605EffectStrongBuzz_100
+	DRV2605EffectAlert750ms_100
+	DRV2605EffectAlert1000ms_100
+	DRV2605EffectStrongClick1_100
+	DRV2605EffectStrongClick2_80
+	DRV2605EffectStrongClick3_60
+	DRV2605EffectStrongClick4_30
+	DRV2605EffectMediumClick1_100
+	DRV2605EffectMediumClick2_80
+	DRV2605EffectMediumClick3_60
+	DRV2605EffectSharpTick1_100
+	DRV2605EffectSharpTick2_80
+	DRV2605EffectSharpTick3_60
+	DRV2605EffectShortDoubleClickStrong1_100
+	DRV2605EffectShortDoubleClickStrong2_80
+	DRV2605EffectShortDoubleClickStrong3_60
+	DRV2605EffectShortDoubleClickStrong4_30
+	DRV2605EffectShortDoubleClickMedium1_100
+	DRV2605EffectShortDoubleClickMedium2_80
+	DRV2605EffectShortDoubleClickMedium3_60
+	DRV2605EffectShortDoubleSharpTick1_100
+	DRV2605EffectShortDoubleSharpTick2_80
+	DRV2605EffectShortDoubleSharpTick3_60
+	DRV2605EffectLongDoubleSharpClickStrong1_100
+	DRV2605EffectLongDoubleSharpClickStrong2_80
+	DRV2605EffectLongDoubleSharpClickStrong3_60
+	DRV2605EffectLongDoubleSharpClickStrong4_30
+	DRV2605EffectLongDoubleSharpClickMedium1_100
+	DRV2605EffectLongDoubleSharpClickMedium2_80
+	DRV2605EffectLongDoubleSharpClickMedium3_60
+	DRV2605EffectLongDoubleSharpTick1_100
+	DRV2605EffectLongDoubleSharpTick2_80
+	DRV2605EffectLongDoubleSharpTick3_60
+	DRV2605EffectBuzz1_100
+	DRV2605EffectBuzz2_80
+	DRV2605EffectBuzz3_60
+	DRV2605EffectBuzz4_40
+	DRV2605EffectBuzz5_20
+	DRV2605EffectPulsingStrong1_100
+	DRV2605EffectPulsingStrong2_60
+	DRV2605EffectPulsingMedium1_100
+	DRV2605EffectPulsingMedium2_60
+	DRV2605EffectPulsingSharp1_100
+	DRV2605EffectPulsingSharp2_60
+	DRV2605EffectTransitionClick1_100
+	DRV2605EffectTransitionClick2_80
+	DRV2605EffectTransitionClick3_60
+	DRV2605EffectTransitionClick4_40
+	DRV2605EffectTransitionClick5_20
+	DRV2605EffectTransitionClick6_10
+	DRV2605EffectTransitionHum1_100
+	DRV2605EffectTransitionHum2_80
+	DRV2605EffectTransitionHum3_60
+	DRV2605EffectTransitionHum4_40
+	DRV2605EffectTransitionHum5_20
+	DRV2605EffectTransitionHum6_10
+	DRV2605EffectTransitionRampDownLongSmooth1_100To0
+	DRV2605EffectTransitionRampDownLongSmooth2_100To0
+	DRV2605EffectTransitionRampDownMediumSmooth1_100To0
+	DRV2605EffectTransitionRampDownMediumSmooth2_100To0
+	DRV2605EffectTransitionRampDownShortSmooth1_100To0
+	DRV2605EffectTransitionRampDownShortSmooth2_100To0
+	DRV2605EffectTransitionRampDownLongSharp1_100To0
+	DRV2605EffectTransitionRampDownLongSharp2_100To0
+	DRV2605EffectTransitionRampDownMediumSharp1_100To0
+	DRV2605EffectTransitionRampDownMediumSharp2_100To0
+	DRV2605EffectTransitionRampDownShortSharp1_100To0
+	DRV2605EffectTransitionRampDownShortSharp2_100To0
+	DRV2605EffectTransitionRampUpLongSmooth1_0To100
+	DRV2605EffectTransitionRampUpLongSmooth2_0To100
+	DRV2605EffectTransitionRampUpMediumSmooth1_0To100
+	DRV2605EffectTransitionRampUpMediumSmooth2_0To100
+	DRV2605EffectTransitionRampUpShortSmooth1_0To100
+	DRV2605EffectTransitionRampUpShortSmooth2_0To100
+	DRV2605EffectTransitionRampUpLongSharp1_0To100
+	DRV2605EffectTransitionRampUpLongSharp2_0To100
+	DRV2605EffectTransitionRampUpMediumSharp1_0To100
+	DRV2605EffectTransitionRampUpMediumSharp2_0To100
+	DRV2605EffectTransitionRampUpShortSharp1_0To100
+	DRV2605EffectTransitionRampUpShortSharp2_0To100
+	DRV2605EffectTransitionRampDownLongSmooth1_50To0
+	DRV2605EffectTransitionRampDownLongSmooth2_50To0
+	DRV2605EffectTransitionRampDownMediumSmooth1_50To0
+	DRV2605EffectTransitionRampDownMediumSmooth2_50To0
+	DRV2605EffectTransitionRampDownShortSmooth1_50To0
+	DRV2605EffectTransitionRampDownShortSmooth2_50To0
+	DRV2605EffectTransitionRampDownLongSharp1_50To0
+	DRV2605EffectTransitionRampDownLongSharp2_50To0
+	DRV2605EffectTransitionRampDownMediumSharp1_50To0
+	DRV2605EffectTransitionRampDownMediumSharp2_50To0
+	DRV2605EffectTransitionRampDownShortSharp1_50To0
+	DRV2605EffectTransitionRampDownShortSharp2_50To0
+	DRV2605EffectTransitionRampUpLongSmooth1_0To50
+	DRV2605EffectTransitionRampUpLongSmooth2_0To50
+	DRV2605EffectTransitionRampUpMediumSmooth1_0To50
+	DRV2605EffectTransitionRampUpMediumSmooth2_0To50
+	DRV2605EffectTransitionRampUpShortSmooth1_0To50
+	DRV2605EffectTransitionRampUpShortSmooth2_0To50
+	DRV2605EffectTransitionRampUpLongSharp1_0To50
+	DRV2605EffectTransitionRampUpLongSharp2_0To50
+	DRV2605EffectTransitionRampUpMediumSharp1_0To50
+	DRV2605EffectTransitionRampUpMediumSharp2_0To50
+	DRV2605EffectTransitionRampUpShortSharp1_0To50
+	DRV2605EffectTransitionRampUpShortSharp2_0To50
+	DRV2605EffectLongBuzzForProgrammaticStopping_100
+	DRV2605EffectSmoothHum1_50
+	DRV2605EffectSmoothHum2_40
+	DRV2605EffectSmoothHum3_30
+	DRV2605EffectSmoothHum4_20
+	DRV2605EffectSmoothHum5_10
+)
+
+// drv2605EffectNames are the names of the effects according to the data sheet, the index is the effect id - 1
+var drv2605EffectNames = [...]string{
+	"Strong Click - 100%",
+	"Strong Click - 60%",
+	"Strong Click - 30%",
+	"Sharp Click - 100%",
+	"Sharp Click - 60%",
+	"Sharp Click - 30%",
+	"Soft Bump - 100%",
+	"Soft Bump - 60%",
+	"Soft Bump - 30%",
+	"Double Click - 100%",
+	"Double Click - 60%",
+	"Triple Click - 100%",
+	"Soft Fuzz - 60%",
+	"Strong Buzz - 100%",
+	"750 ms Alert 100%",
+	"1000 ms Alert 100%",
+	"Strong Click 1 - 100%",
+	"Strong Click 2 - 80%",
+	"Strong Click 3 - 60%",
+	"Strong Click 4 - 30%",
+	"Medium Click 1 - 100%",
+	"Medium Click 2 - 80%",
+	"Medium Click 3 - 60%",
+	"Sharp Tick 1 - 100%",
+	"Sharp Tick 2 - 80%",
+	"Sharp Tick 3 - 60%",
+	"Short Double Click Strong 1 - 100%",
+	"Short Double Click Strong 2 - 80%",
+	"Short Double Click Strong 3 - 60%",
+	"Short Double Click Strong 4 - 30%",
+	"Short Double Click Medium 1 - 100%",
+	"Short Double Click Medium 2 - 80%",
+	"Short Double Click Medium 3 - 60%",
+	"Short Double Sharp Tick 1 - 100%",
+	"Short Double Sharp Tick 2 - 80%",
+	"Short Double Sharp Tick 3 - 60%",
+	"Long Double Sharp Click Strong 1 - 100%",
+	"Long Double Sharp Click Strong 2 - 80%",
+	"Long Double Sharp Click Strong 3 - 60%",
+	"Long Double Sharp Click Strong 4 - 30%",
+	"Long Double Sharp Click Medium 1 - 100%",
+	"Long Double Sharp Click Medium 2 - 80%",
+	"Long Double Sharp Click Medium 3 - 60%",
+	"Long Double Sharp Tick 1 - 100%",
+	"Long Double Sharp Tick 2 - 80%",
+	"Long Double Sharp Tick 3 - 60%",
+	"Buzz 1 - 100%",
+	"Buzz 2 - 80%",
+	"Buzz 3 - 60%",
+	"Buzz 4 - 40%",
+	"Buzz 5 - 20%",
+	"Pulsing Strong 1 - 100%",
+	"Pulsing Strong 2 - 60%",
+	"Pulsing Medium 1 - 100%",
+	"Pulsing Medium 2 - 60%",
+	"Pulsing Sharp 1 - 100%",
+	"Pulsing Sharp 2 - 60%",
+	"Transition Click 1 - 100%",
+	"Transition Click 2 - 80%",
+	"Transition Click 3 - 60%",
+	"Transition Click 4 - 40%",
+	"Transition Click 5 - 20%",
+	"Transition Click 6 - 10%",
+	"Transition Hum 1 - 100%",
+	"Transition Hum 2 - 80%",
+	"Transition Hum 3 - 60%",
+	"Transition Hum 4 - 40%",
+	"Transition Hum 5 - 20%",
+	"Transition Hum 6 - 10%",
+	"Transition Ramp Down Long Smooth 1 - 100 to 0%",
+	"Transition Ramp Down Long Smooth 2 - 100 to 0%",
+	"Transition Ramp Down Medium Smooth 1 - 100 to 0%",
+	"Transition Ramp Down Medium Smooth 2 - 100 to 0%",
+	"Transition Ramp Down Short Smooth 1 - 100 to 0%",
+	"Transition Ramp Down Short Smooth 2 - 100 to 0%",
+	"Transition Ramp Down Long Sharp 1 - 100 to 0%",
+	"Transition Ramp Down Long Sharp 2 - 100 to 0%",
+	"Transition Ramp Down Medium Sharp 1 - 100 to 0%",
+	"Transition Ramp Down Medium Sharp 2 - 100 to 0%",
+	"Transition Ramp Down Short Sharp 1 - 100 to 0%",
+	"Transition Ramp Down Short Sharp 2 - 100 to 0%",
+	"Transition Ramp Up Long Smooth 1 - 0 to 100%",
+	"Transition Ramp Up Long Smooth 2 - 0 to 100%",
+	"Transition Ramp Up Medium Smooth 1 - 0 to 100%",
+	"Transition Ramp Up Medium Smooth 2 - 0 to 100%",
+	"Transition Ramp Up Short Smooth 1 - 0 to 100%",
+	"Transition Ramp Up Short Smooth 2 - 0 to 100%",
+	"Transition Ramp Up Long Sharp 1 - 0 to 100%",
+	"Transition Ramp Up Long Sharp 2 - 0 to 100%",
+	"Transition Ramp Up Medium Sharp 1 - 0 to 100%",
+	"Transition Ramp Up Medium Sharp 2 - 0 to 100%",
+	"Transition Ramp Up Short Sharp 1 - 0 to 100%",
+	"Transition Ramp Up Short Sharp 2 - 0 to 100%",
+	"Transition Ramp Down Long Smooth 1 - 50 to 0%",
+	"Transition Ramp Down Long Smooth 2 - 50 to 0%",
+	"Transition Ramp Down Medium Smooth 1 - 50 to 0%",
+	"Transition Ramp Down Medium Smooth 2 - 50 to 0%",
+	"Transition Ramp Down Short Smooth 1 - 50 to 0%",
+	"Transition Ramp Down Short Smooth 2 - 50 to 0%",
+	"Transition Ramp Down Long Sharp 1 - 50 to 0%",
+	"Transition Ramp Down Long Sharp 2 - 50 to 0%",
+	"Transition Ramp Down Medium Sharp 1 - 50 to 0%",
+	"Transition Ramp Down Medium Sharp 2 - 50 to 0%",
+	"Transition Ramp Down Short Sharp 1 - 50 to 0%",
+	"Transition Ramp Down Short Sharp 2 - 50 to 0%",
+	"Transition Ramp Up Long Smooth 1 - 0 to 50%",
+	"Transition Ramp Up Long Smooth 2 - 0 to 50%",
+	"Transition Ramp Up Medium Smooth 1 - 0 to 50%",
+	"Transition Ramp Up Medium Smooth 2 - 0 to 50%",
+	"Transition Ramp Up Short Smooth 1 - 0 to 50%",
+	"Transition Ramp Up Short Smooth 2 - 0 to 50%",
+	"Transition Ramp Up Long Sharp 1 - 0 to 50%",
+	"Transition Ramp Up Long Sharp 2 - 0 to 50%",
+	"Transition Ramp Up Medium Sharp 1 - 0 to 50%",
+	"Transition Ramp Up Medium Sharp 2 - 0 to 50%",
+	"Transition Ramp Up Short Sharp 1 - 0 to 50%",
+	"Transition Ramp Up Short Sharp 2 - 0 to 50%",
+	"Long Buzz for Programmatic Stopping - 100%",
+	"Smooth Hum 1 (No kick or brake pulse) - 50%",
+	"Smooth Hum 2 (No kick or brake pulse) - 40%",
+	"Smooth Hum 3 (No kick or brake pulse) - 30%",
+	"Smooth Hum 4 (No kick or brake pulse) - 20%",
+	"Smooth Hum 5 (No kick or brake pulse) - 10%",
+}
+
+const (
+	drv2605PauseUnit = 10 * time.Millisecond
+	drv2605MaxPause  = 0x7F * drv2605PauseUnit
+)
+
+// String returns the name of the effect according to the data sheet.
+func (e DRV2605Effect) String() string {
+	if !e.valid() {
+		return fmt.Sprintf("unknown effect %d", e)
+	}
+	return drv2605EffectNames[e-1]
+}
+
+func (e DRV2605Effect) valid() bool {
+	return e > 0 && int(e) <= len(drv2605EffectNames)
+}
+
+// DRV2605EffectByName returns the effect with the given name according to the data sheet, e.g. "Strong Click - 100%".
+// The case is ignored.
+func DRV2605EffectByName(name string) (DRV2605Effect, error) {
+	for i, n := range drv2605EffectNames {
+		if strings.EqualFold(n, name) {
+			return DRV2605Effect(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown DRV2605 effect '%s'", name)
+}
+
+// DRV2605Pattern is a composition of effects and pauses, which is played by PlayPattern(). Patterns can be longer
+// than the 8 slots of the sequencer, in this case the pattern is played in parts.
+//
+// Example:
+//
+//  pattern := i2c.NewDRV2605Pattern(i2c.DRV2605EffectStrongClick_100).
+//    Pause(100 * time.Millisecond).Effect(i2c.DRV2605EffectSoftBump_60).Repeat(3)
+//
+type DRV2605Pattern struct {
+	waveforms []uint8
+	err       error
+}
+
+// NewDRV2605Pattern creates a new pattern, which starts with the given effects.
+func NewDRV2605Pattern(effects ...DRV2605Effect) *DRV2605Pattern {
+	return (&DRV2605Pattern{}).Effect(effects...)
+}
+
+// Effect appends the effects to the pattern.
+func (p *DRV2605Pattern) Effect(effects ...DRV2605Effect) *DRV2605Pattern {
+	for _, e := range effects {
+		// effects out of range can not be stored, because they would be interpreted as pause or end of sequence
+		if !e.valid() {
+			if p.err == nil {
+				p.err = fmt.Errorf("invalid effect %d in pattern", e)
+			}
+			continue
+		}
+		p.waveforms = append(p.waveforms, uint8(e))
+	}
+	return p
+}
+
+// Pause appends a pause to the pattern. The duration is rounded to 10ms, pauses longer than 1270ms need more
+// than one slot of the sequencer.
+func (p *DRV2605Pattern) Pause(duration time.Duration) *DRV2605Pattern {
+	units := (duration + drv2605PauseUnit/2) / drv2605PauseUnit
+	for units > 0 {
+		u := units
+		if u > drv2605MaxPause/drv2605PauseUnit {
+			u = drv2605MaxPause / drv2605PauseUnit
+		}
+		p.waveforms = append(p.waveforms, uint8(u)|0x80)
+		units -= u
+	}
+	return p
+}
+
+// Repeat repeats the pattern, so it is played count times in total. Values less than 2 have no effect.
+func (p *DRV2605Pattern) Repeat(count int) *DRV2605Pattern {
+	once := p.waveforms
+	for i := 1; i < count; i++ {
+		p.waveforms = append(p.waveforms, once...)
+	}
+	return p
+}
+
+// Append appends the other pattern.
+func (p *DRV2605Pattern) Append(other *DRV2605Pattern) *DRV2605Pattern {
+	p.waveforms = append(p.waveforms, other.waveforms...)
+	if p.err == nil {
+		p.err = other.err
+	}
+	return p
+}
+
+// Waveforms returns the waveform ids of the pattern, as used by SetSequence().
+func (p *DRV2605Pattern) Waveforms() []uint8 {
+	return append([]uint8{}, p.waveforms...)
+}
+
+// validate returns an error, if an effect was added which is not in the ROM libraries
+func (p *DRV2605Pattern) validate() error {
+	if p.err != nil {
+		return p.err
+	}
+	if len(p.waveforms) == 0 {
+		return fmt.Errorf("the pattern contains no effect")
+	}
+	return nil
+}
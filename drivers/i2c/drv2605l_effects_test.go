@@ -0,0 +1,84 @@
+package i2c
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestDRV2605EffectString(t *testing.T) {
+	gobottest.Assert(t, DRV2605EffectStrongClick_100.String(), "Strong Click - 100%")
+	gobottest.Assert(t, DRV2605EffectAlert750ms_100, DRV2605Effect(15))
+	gobottest.Assert(t, DRV2605EffectTransitionRampDownLongSmooth1_100To0, DRV2605Effect(70))
+	gobottest.Assert(t, DRV2605EffectTransitionRampUpLongSmooth1_0To50.String(), "Transition Ramp Up Long Smooth 1 - 0 to 50%")
+	gobottest.Assert(t, DRV2605EffectSmoothHum5_10, DRV2605Effect(123))
+	gobottest.Assert(t, DRV2605Effect(0).String(), "unknown effect 0")
+	gobottest.Assert(t, DRV2605Effect(124).String(), "unknown effect 124")
+}
+
+func TestDRV2605EffectByName(t *testing.T) {
+	var tests = map[string]struct {
+		name    string
+		want    DRV2605Effect
+		wantErr error
+	}{
+		"first":       {name: "Strong Click - 100%", want: DRV2605EffectStrongClick_100},
+		"ignore_case": {name: "long buzz for programmatic stopping - 100%", want: DRV2605Effect(118)},
+		"unknown":     {name: "Click", wantErr: fmt.Errorf("unknown DRV2605 effect 'Click'")},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := DRV2605EffectByName(tc.name)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			gobottest.Assert(t, got, tc.want)
+		})
+	}
+}
+
+func TestDRV2605Pattern(t *testing.T) {
+	var tests = map[string]struct {
+		pattern *DRV2605Pattern
+		want    []uint8
+	}{
+		"effects": {
+			pattern: NewDRV2605Pattern(DRV2605EffectStrongClick_100, DRV2605EffectSoftBump_60),
+			want:    []uint8{1, 8},
+		},
+		"pauses": {
+			pattern: NewDRV2605Pattern().Pause(15 * time.Millisecond).Pause(4 * time.Millisecond).Pause(2 * time.Second),
+			want:    []uint8{0x82, 0xFF, 0xC9},
+		},
+		"repeat": {
+			pattern: NewDRV2605Pattern(DRV2605EffectBuzz1_100).Pause(100 * time.Millisecond).Repeat(3),
+			want:    []uint8{47, 0x8A, 47, 0x8A, 47, 0x8A},
+		},
+		"repeat_once": {
+			pattern: NewDRV2605Pattern(DRV2605EffectBuzz1_100).Repeat(0),
+			want:    []uint8{47},
+		},
+		"append": {
+			pattern: NewDRV2605Pattern(DRV2605EffectTripleClick_100).Append(NewDRV2605Pattern(DRV2605EffectSoftFuzz_60)),
+			want:    []uint8{12, 13},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			gobottest.Assert(t, tc.pattern.Waveforms(), tc.want)
+			gobottest.Assert(t, tc.pattern.validate(), nil)
+		})
+	}
+}
+
+func TestDRV2605PatternValidate(t *testing.T) {
+	gobottest.Assert(t, NewDRV2605Pattern().validate(), fmt.Errorf("the pattern contains no effect"))
+	gobottest.Assert(t, NewDRV2605Pattern(DRV2605Effect(0)).validate(), fmt.Errorf("invalid effect 0 in pattern"))
+	gobottest.Assert(t, NewDRV2605Pattern(DRV2605Effect(200)).validate(), fmt.Errorf("invalid effect 200 in pattern"))
+	p := NewDRV2605Pattern(DRV2605EffectBuzz1_100).Append(NewDRV2605Pattern(DRV2605Effect(124)))
+	gobottest.Assert(t, p.validate(), fmt.Errorf("invalid effect 124 in pattern"))
+	gobottest.Assert(t, p.Waveforms(), []uint8{47})
+}
@@ -0,0 +1,230 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	ds1307Debug = false
+
+	// DS1307 has the fixed address 0x68
+	ds1307DefaultAddress = 0x68
+
+	ds1307Reg_SECONDS = 0x00 // 0x00..0x06 time and calendar
+	ds1307Reg_CONTROL = 0x07
+
+	ds1307RamOffset = 0x08
+	ds1307RamSize   = 56
+
+	ds1307ClockHalt = 0x80 // bit 7 of the seconds register
+
+	// time and calendar registers, the layout is the same for DS1307 and DS3231
+	dsTimeDataSize = 7
+	dsHour12       = 0x40 // bit 6 of the hours register: 12 hour mode
+	dsHourPM       = 0x20 // bit 5 of the hours register in 12 hour mode
+	dsCentury      = 0x80 // bit 7 of the month register (DS3231 only)
+	dsBaseYear     = 2000
+)
+
+// DS1307SquareWave is used to set the output of the SQW/OUT pin
+type DS1307SquareWave uint8
+
+const (
+	// DS1307SquareWaveOffLow disables the square wave, the output is low
+	DS1307SquareWaveOffLow DS1307SquareWave = 0x00
+	// DS1307SquareWaveOffHigh disables the square wave, the output is high
+	DS1307SquareWaveOffHigh DS1307SquareWave = 0x80
+	// DS1307SquareWave1Hz enables the square wave with 1 Hz
+	DS1307SquareWave1Hz DS1307SquareWave = 0x10
+	// DS1307SquareWave4kHz enables the square wave with 4.096 kHz
+	DS1307SquareWave4kHz DS1307SquareWave = 0x11
+	// DS1307SquareWave8kHz enables the square wave with 8.192 kHz
+	DS1307SquareWave8kHz DS1307SquareWave = 0x12
+	// DS1307SquareWave32kHz enables the square wave with 32.768 kHz
+	DS1307SquareWave32kHz DS1307SquareWave = 0x13
+)
+
+// DS1307Driver is a Gobot Driver for the DS1307 real-time clock with 56 bytes of battery backed RAM.
+// please refer to data sheet: https://datasheets.maximintegrated.com/en/ds/DS1307.pdf
+//
+// The clock is always written in 24 hour mode, reading supports the 12 hour mode too. The years
+// 2000..2099 are supported.
+type DS1307Driver struct {
+	*Driver
+}
+
+// NewDS1307Driver creates a new driver for the DS1307 real-time clock.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+func NewDS1307Driver(c Connector, options ...func(Config)) *DS1307Driver {
+	d := &DS1307Driver{
+		Driver: NewDriver(c, "DS1307", ds1307DefaultAddress),
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	// API commands
+	d.AddCommand("WriteTime", func(params map[string]interface{}) interface{} {
+		val := params["val"].(time.Time)
+		err := d.WriteTime(val)
+		return map[string]interface{}{"err": err}
+	})
+
+	d.AddCommand("ReadTime", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadTime()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("WriteRAM", func(params map[string]interface{}) interface{} {
+		address := params["address"].(uint8)
+		val := params["val"].(uint8)
+		err := d.WriteRAM(address, val)
+		return map[string]interface{}{"err": err}
+	})
+
+	d.AddCommand("ReadRAM", func(params map[string]interface{}) interface{} {
+		address := params["address"].(uint8)
+		val, err := d.ReadRAM(address)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// WriteTime sets the clock to the given time, the clock is started if it was halted. The clock runs in UTC, so the
+// location of the given time does not matter.
+func (d *DS1307Driver) WriteTime(val time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.connection.WriteBlockData(ds1307Reg_SECONDS, dsEncodeTime(val, false))
+}
+
+// ReadTime reads the clock and returns the value
+func (d *DS1307Driver) ReadTime() (time.Time, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data := make([]byte, dsTimeDataSize)
+	if err := d.connection.ReadBlockData(ds1307Reg_SECONDS, data); err != nil {
+		return time.Time{}, err
+	}
+	if data[0]&ds1307ClockHalt != 0 {
+		return time.Time{}, fmt.Errorf("%s: the clock is halted", d.name)
+	}
+	return dsDecodeTime(data), nil
+}
+
+// SetSquareWave sets the output of the SQW/OUT pin
+func (d *DS1307Driver) SetSquareWave(val DS1307SquareWave) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.connection.WriteByteData(ds1307Reg_CONTROL, uint8(val))
+}
+
+// WriteRAM writes a value to a given address in the RAM (0x00-0x37)
+func (d *DS1307Driver) WriteRAM(address uint8, val uint8) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if address >= ds1307RamSize {
+		return fmt.Errorf("%s: RAM address overflow %d", d.name, address)
+	}
+	return d.connection.WriteByteData(ds1307RamOffset+address, val)
+}
+
+// ReadRAM reads a value from a given address in the RAM (0x00-0x37)
+func (d *DS1307Driver) ReadRAM(address uint8) (uint8, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if address >= ds1307RamSize {
+		return 0, fmt.Errorf("%s: RAM address overflow %d", d.name, address)
+	}
+	return d.connection.ReadByteData(ds1307RamOffset + address)
+}
+
+func (d *DS1307Driver) initialize() error {
+	// the clock is halted after the first power up, so it is started here
+	seconds, err := d.connection.ReadByteData(ds1307Reg_SECONDS)
+	if err != nil {
+		return err
+	}
+	if seconds&ds1307ClockHalt == 0 {
+		return nil
+	}
+	if ds1307Debug {
+		log.Printf("%s: starting the halted clock", d.name)
+	}
+	return d.connection.WriteByteData(ds1307Reg_SECONDS, seconds&^ds1307ClockHalt)
+}
+
+// dsEncodeTime returns the content of the time and calendar registers of DS1307 and DS3231 in 24 hour mode,
+// the century bit is only supported by DS3231. The time is stored in UTC, like it is returned by dsDecodeTime().
+func dsEncodeTime(val time.Time, century bool) []byte {
+	val = val.UTC()
+	year := val.Year() - dsBaseYear
+	month := dsEncodeBcd(uint8(val.Month()))
+	if century && year >= 100 {
+		month |= dsCentury
+		year -= 100
+	}
+	return []byte{
+		dsEncodeBcd(uint8(val.Second())),
+		dsEncodeBcd(uint8(val.Minute())),
+		dsEncodeBcd(uint8(val.Hour())),
+		uint8(val.Weekday()) + 1, // 1..7, Sunday = 1
+		dsEncodeBcd(uint8(val.Day())),
+		month,
+		dsEncodeBcd(uint8(year)),
+	}
+}
+
+// dsDecodeTime returns the time of the content of the time and calendar registers of DS1307 and DS3231
+func dsDecodeTime(data []byte) time.Time {
+	seconds := int(dsDecodeBcd(data[0] & 0x7F))
+	minutes := int(dsDecodeBcd(data[1] & 0x7F))
+	hours := dsDecodeHours(data[2])
+	// data[3] is the weekday, which is not needed here
+	date := int(dsDecodeBcd(data[4] & 0x3F))
+	month := time.Month(dsDecodeBcd(data[5] & 0x1F))
+	year := dsBaseYear + int(dsDecodeBcd(data[6]))
+	if data[5]&dsCentury != 0 {
+		year += 100
+	}
+	return time.Date(year, month, date, hours, minutes, seconds, 0, time.UTC)
+}
+
+// dsDecodeHours returns the hours (0..23) of the hours register in 12 or 24 hour mode
+func dsDecodeHours(val uint8) int {
+	if val&dsHour12 == 0 {
+		return int(dsDecodeBcd(val & 0x3F))
+	}
+	hours := int(dsDecodeBcd(val & 0x1F))
+	if hours == 12 {
+		hours = 0
+	}
+	if val&dsHourPM != 0 {
+		hours += 12
+	}
+	return hours
+}
+
+func dsEncodeBcd(val uint8) uint8 {
+	// decimal 12 => 0x12
+	return (val/10)<<4 | val%10
+}
+
+func dsDecodeBcd(bcd uint8) uint8 {
+	// 0x12 => decimal 12
+	return (bcd>>4)*10 + bcd&0x0F
+}
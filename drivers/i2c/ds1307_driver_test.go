@@ -0,0 +1,131 @@
+package i2c
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/rtc"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*DS1307Driver)(nil)
+
+var _ rtc.RTC = (*DS1307Driver)(nil)
+var _ rtc.RTC = (*PCF8583Driver)(nil)
+
+func initTestDS1307WithStubbedAdaptor() (*DS1307Driver, *i2cTestAdaptor, *memoryTestDevice) {
+	a := newI2cTestAdaptor()
+	m := simulateMemory(a, 64, 1)
+	d := NewDS1307Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a, m
+}
+
+func TestNewDS1307Driver(t *testing.T) {
+	var di interface{} = NewDS1307Driver(newI2cTestAdaptor())
+	d, ok := di.(*DS1307Driver)
+	if !ok {
+		t.Errorf("NewDS1307Driver() should have returned a *DS1307Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DS1307"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x68)
+}
+
+func TestDS1307Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option.
+	d := NewDS1307Driver(newI2cTestAdaptor(), WithBus(2))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+}
+
+func TestDS1307StartHaltedClock(t *testing.T) {
+	a := newI2cTestAdaptor()
+	m := simulateMemory(a, 64, 1)
+	m.mem[0] = 0x80 | 0x45
+	d := NewDS1307Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, m.mem[0], uint8(0x45))
+}
+
+func TestDS1307WriteReadTime(t *testing.T) {
+	d, _, m := initTestDS1307WithStubbedAdaptor()
+	val := time.Date(2022, time.December, 24, 18, 30, 59, 0, time.UTC)
+	gobottest.Assert(t, d.WriteTime(val), nil)
+	// Saturday is the 7th day of the week
+	gobottest.Assert(t, m.mem[0:7], []byte{0x59, 0x30, 0x18, 0x07, 0x24, 0x12, 0x22})
+
+	got, err := d.ReadTime()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got, val)
+
+	// 12 hour mode, 6 PM
+	m.mem[2] = 0x40 | 0x20 | 0x06
+	got, _ = d.ReadTime()
+	gobottest.Assert(t, got.Hour(), 18)
+	// 12 hour mode, 12 AM
+	m.mem[2] = 0x40 | 0x12
+	got, _ = d.ReadTime()
+	gobottest.Assert(t, got.Hour(), 0)
+
+	m.mem[0] |= 0x80
+	_, err = d.ReadTime()
+	gobottest.Assert(t, strings.Contains(err.Error(), "the clock is halted"), true)
+}
+
+func TestDS1307WriteReadTimeWithLocation(t *testing.T) {
+	// arrange
+	d, _, m := initTestDS1307WithStubbedAdaptor()
+	val := time.Date(2022, time.December, 25, 1, 30, 59, 0, time.FixedZone("UTC+2", 2*60*60))
+	// act
+	err := d.WriteTime(val)
+	got, errRead := d.ReadTime()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, errRead, nil)
+	// the clock runs in UTC: Saturday, 24th 23:30:59
+	gobottest.Assert(t, m.mem[0:7], []byte{0x59, 0x30, 0x23, 0x07, 0x24, 0x12, 0x22})
+	gobottest.Assert(t, got.Equal(val), true)
+	gobottest.Assert(t, got.Location(), time.UTC)
+}
+
+func TestDS1307Commands(t *testing.T) {
+	d, _, _ := initTestDS1307WithStubbedAdaptor()
+	val := time.Date(2021, time.March, 1, 1, 2, 3, 0, time.UTC)
+	result := d.Command("WriteTime")(map[string]interface{}{"val": val})
+	gobottest.Assert(t, result.(map[string]interface{})["err"], nil)
+	result = d.Command("ReadTime")(map[string]interface{}{})
+	gobottest.Assert(t, result.(map[string]interface{})["val"], val)
+
+	result = d.Command("WriteRAM")(map[string]interface{}{"address": uint8(3), "val": uint8(0x42)})
+	gobottest.Assert(t, result.(map[string]interface{})["err"], nil)
+	result = d.Command("ReadRAM")(map[string]interface{}{"address": uint8(3)})
+	gobottest.Assert(t, result.(map[string]interface{})["val"], uint8(0x42))
+}
+
+func TestDS1307RAM(t *testing.T) {
+	d, _, m := initTestDS1307WithStubbedAdaptor()
+	gobottest.Assert(t, d.WriteRAM(55, 0xAB), nil)
+	gobottest.Assert(t, m.mem[0x3F], uint8(0xAB))
+	val, err := d.ReadRAM(55)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, uint8(0xAB))
+
+	err = d.WriteRAM(56, 1)
+	gobottest.Assert(t, strings.Contains(err.Error(), "RAM address overflow 56"), true)
+	_, err = d.ReadRAM(56)
+	gobottest.Assert(t, strings.Contains(err.Error(), "RAM address overflow 56"), true)
+}
+
+func TestDS1307SetSquareWave(t *testing.T) {
+	d, _, m := initTestDS1307WithStubbedAdaptor()
+	gobottest.Assert(t, d.SetSquareWave(DS1307SquareWave32kHz), nil)
+	gobottest.Assert(t, m.mem[7], uint8(0x13))
+	gobottest.Assert(t, d.SetSquareWave(DS1307SquareWaveOffHigh), nil)
+	gobottest.Assert(t, m.mem[7], uint8(0x80))
+}
@@ -0,0 +1,239 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"gobot.io/x/gobot/drivers/onewire"
+)
+
+const (
+	ds2482Debug          = false
+	ds2482DefaultAddress = 0x18
+	ds2482MaxBusyPolls   = 100
+
+	// commands
+	ds2482CmdDeviceReset     = 0xF0
+	ds2482CmdSetReadPointer  = 0xE1
+	ds2482CmdWriteConfig     = 0xD2
+	ds2482CmdOneWireReset    = 0xB4
+	ds2482CmdOneWireWrite    = 0xA5
+	ds2482CmdOneWireRead     = 0x96
+	ds2482CmdOneWireTriplet  = 0x78
+	ds2482ReadPointerData    = 0xE1
+	ds2482ReadPointerConfig  = 0xC3
+	ds2482ReadPointerStatus  = 0xF0
+	ds2482TripletDirectionHi = 0x80
+
+	// status register bits
+	ds2482StatusBusy            = 0x01
+	ds2482StatusPresence        = 0x02
+	ds2482StatusShort           = 0x04
+	ds2482StatusDeviceReset     = 0x10
+	ds2482StatusSingleBitResult = 0x20
+	ds2482StatusTripletSecond   = 0x40
+	ds2482StatusDirection       = 0x80
+
+	// config register bits
+	ds2482ConfigActivePullup = 0x01
+	ds2482ConfigStrongPullup = 0x04
+	ds2482ConfigOverdrive    = 0x08
+)
+
+// DS2482Driver is a driver for the DS2482-100 I2C to 1-Wire bridge. The DS2482-800 is supported for the first channel.
+// The driver is a bus master for the drivers of the 1-Wire devices, e.g. the onewire.DS18B20Driver.
+//
+// Datasheet: https://www.analog.com/media/en/technical-documentation/data-sheets/DS2482-100.pdf
+type DS2482Driver struct {
+	*Driver
+	activePullup bool
+	busMutex     *sync.Mutex // ensures that 1-Wire transactions are not interrupted
+}
+
+// NewDS2482Driver creates a new driver for the DS2482 I2C to 1-Wire bridge.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver, 0x18..0x1B given by the pins AD0 and AD1
+//		i2c.WithDS2482ActivePullup(bool):	use the active pullup for long lines and many devices, default is true
+func NewDS2482Driver(c Connector, options ...func(Config)) *DS2482Driver {
+	d := &DS2482Driver{
+		Driver:       NewDriver(c, "DS2482", ds2482DefaultAddress),
+		activePullup: true,
+		busMutex:     &sync.Mutex{},
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddCommand("Search", func(params map[string]interface{}) interface{} {
+		roms, err := d.Search()
+		return map[string]interface{}{"roms": roms, "err": err}
+	})
+
+	return d
+}
+
+// WithDS2482ActivePullup option enables or disables the active pullup of the 1-Wire line.
+func WithDS2482ActivePullup(val bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*DS2482Driver)
+		if ok {
+			d.activePullup = val
+		} else if ds2482Debug {
+			log.Printf("Trying to set active pullup for non-DS2482Driver %v", c)
+		}
+	}
+}
+
+// Search returns the ROM codes of all devices on the 1-Wire bus.
+func (d *DS2482Driver) Search() ([]onewire.ROM, error) {
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	return onewire.Search(d, false)
+}
+
+// GetOneWireConnection returns a connection to the device with the given ROM code. Implements the onewire.Connector
+// interface.
+func (d *DS2482Driver) GetOneWireConnection(rom onewire.ROM) (onewire.Connection, error) {
+	if d.connection == nil {
+		return nil, fmt.Errorf("DS2482 is not started")
+	}
+	return onewire.NewConnection(d, rom, d.busMutex), nil
+}
+
+// Reset sends a reset pulse to the 1-Wire bus and checks for presence pulses. Implements the onewire.Master
+// interface. The functions of the onewire.Master interface are not protected against concurrent access, use Search()
+// or the connections instead.
+func (d *DS2482Driver) Reset() error {
+	if err := d.connection.WriteByte(ds2482CmdOneWireReset); err != nil {
+		return err
+	}
+	status, err := d.waitIdle()
+	if err != nil {
+		return err
+	}
+	if status&ds2482StatusShort != 0 {
+		return fmt.Errorf("short detected on 1-Wire bus of DS2482")
+	}
+	if status&ds2482StatusPresence == 0 {
+		return onewire.ErrNoPresence
+	}
+	return nil
+}
+
+// WriteBytes writes the given bytes to the 1-Wire bus. With strong pullup, the bus is actively driven high after the
+// last byte until the next 1-Wire command. Implements the onewire.Master interface.
+func (d *DS2482Driver) WriteBytes(data []byte, strongPullup bool) error {
+	for i, b := range data {
+		if strongPullup && i == len(data)-1 {
+			// the strong pullup is activated after the next byte and is cleared automatically by the next command
+			if err := d.writeConfig(d.config() | ds2482ConfigStrongPullup); err != nil {
+				return err
+			}
+		}
+		if err := d.connection.WriteByteData(ds2482CmdOneWireWrite, b); err != nil {
+			return err
+		}
+		if _, err := d.waitIdle(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBytes fills the given buffer with bytes read from the 1-Wire bus. Implements the onewire.Master interface.
+func (d *DS2482Driver) ReadBytes(data []byte) error {
+	for i := range data {
+		if err := d.connection.WriteByte(ds2482CmdOneWireRead); err != nil {
+			return err
+		}
+		if _, err := d.waitIdle(); err != nil {
+			return err
+		}
+		if err := d.connection.WriteByteData(ds2482CmdSetReadPointer, ds2482ReadPointerData); err != nil {
+			return err
+		}
+		val, err := d.connection.ReadByte()
+		if err != nil {
+			return err
+		}
+		data[i] = val
+	}
+	return nil
+}
+
+// Triplet does one step of the ROM search on the 1-Wire bus. Implements the onewire.Master interface.
+func (d *DS2482Driver) Triplet(direction bool) (bool, bool, bool, error) {
+	var param byte
+	if direction {
+		param = ds2482TripletDirectionHi
+	}
+	if err := d.connection.WriteByteData(ds2482CmdOneWireTriplet, param); err != nil {
+		return false, false, false, err
+	}
+	status, err := d.waitIdle()
+	if err != nil {
+		return false, false, false, err
+	}
+	return status&ds2482StatusSingleBitResult != 0, status&ds2482StatusTripletSecond != 0,
+		status&ds2482StatusDirection != 0, nil
+}
+
+func (d *DS2482Driver) initialize() error {
+	if err := d.connection.WriteByte(ds2482CmdDeviceReset); err != nil {
+		return err
+	}
+	status, err := d.connection.ReadByte()
+	if err != nil {
+		return err
+	}
+	if status&ds2482StatusDeviceReset == 0 {
+		return fmt.Errorf("DS2482 device reset failed, status 0x%02X", status)
+	}
+	return d.writeConfig(d.config())
+}
+
+func (d *DS2482Driver) config() byte {
+	if d.activePullup {
+		return ds2482ConfigActivePullup
+	}
+	return 0
+}
+
+// writeConfig writes the configuration, the upper nibble needs to be the complement of the lower nibble
+func (d *DS2482Driver) writeConfig(config byte) error {
+	if err := d.connection.WriteByteData(ds2482CmdWriteConfig, config|^config<<4); err != nil {
+		return err
+	}
+	// the read pointer is set to the configuration register, only the lower nibble is read back
+	val, err := d.connection.ReadByte()
+	if err != nil {
+		return err
+	}
+	if val != config {
+		return fmt.Errorf("DS2482 configuration 0x%02X not accepted, read 0x%02X", config, val)
+	}
+	return nil
+}
+
+// waitIdle reads the status register until the 1-Wire command is finished. The read pointer is set to the status
+// register by all 1-Wire commands.
+func (d *DS2482Driver) waitIdle() (byte, error) {
+	for i := 0; i < ds2482MaxBusyPolls; i++ {
+		status, err := d.connection.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if status&ds2482StatusBusy == 0 {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("DS2482 1-Wire bus is still busy after %d polls", ds2482MaxBusyPolls)
+}
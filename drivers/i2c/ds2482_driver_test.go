@@ -0,0 +1,272 @@
+package i2c
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/onewire"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*DS2482Driver)(nil)
+
+// this ensures that the implementation can be used as 1-Wire bus master
+var _ onewire.Master = (*DS2482Driver)(nil)
+var _ onewire.Connector = (*DS2482Driver)(nil)
+
+// ds2482TestChip simulates the DS2482 with one device on the 1-Wire bus
+type ds2482TestChip struct {
+	pointer    byte
+	status     byte
+	config     byte
+	data       byte
+	busyPolls  int
+	busy       int
+	presence   bool
+	rom        onewire.ROM
+	searchBit  int
+	written    []byte
+	pullups    []bool
+	readQueue  []byte
+	badConfig  bool
+	noResetBit bool
+}
+
+func (c *ds2482TestChip) write(b []byte) (int, error) {
+	switch b[0] {
+	case ds2482CmdDeviceReset:
+		c.status = 0x18
+		if c.noResetBit {
+			c.status = 0x08
+		}
+		c.config = 0
+		c.pointer = ds2482ReadPointerStatus
+	case ds2482CmdWriteConfig:
+		c.config = b[1] & 0x0F
+		if c.badConfig {
+			c.config = 0
+		}
+		c.pointer = ds2482ReadPointerConfig
+	case ds2482CmdSetReadPointer:
+		c.pointer = b[1]
+	case ds2482CmdOneWireReset:
+		c.status = 0
+		if c.presence {
+			c.status = ds2482StatusPresence
+		}
+		c.searchBit = 0
+		c.startCommand()
+	case ds2482CmdOneWireWrite:
+		c.written = append(c.written, b[1])
+		c.pullups = append(c.pullups, c.config&ds2482ConfigStrongPullup != 0)
+		c.config &^= ds2482ConfigStrongPullup
+		c.status = 0
+		c.startCommand()
+	case ds2482CmdOneWireRead:
+		c.data = c.readQueue[0]
+		c.readQueue = c.readQueue[1:]
+		c.status = 0
+		c.startCommand()
+	case ds2482CmdOneWireTriplet:
+		bit := c.rom&(1<<c.searchBit) != 0
+		c.status = 0
+		if bit {
+			c.status = ds2482StatusSingleBitResult | ds2482StatusDirection
+		} else {
+			c.status = ds2482StatusTripletSecond
+		}
+		c.searchBit++
+		c.startCommand()
+	}
+	return len(b), nil
+}
+
+func (c *ds2482TestChip) startCommand() {
+	c.pointer = ds2482ReadPointerStatus
+	c.busy = c.busyPolls
+}
+
+func (c *ds2482TestChip) read(b []byte) (int, error) {
+	switch c.pointer {
+	case ds2482ReadPointerStatus:
+		b[0] = c.status
+		if c.busy > 0 {
+			c.busy--
+			b[0] |= ds2482StatusBusy
+		}
+	case ds2482ReadPointerConfig:
+		b[0] = c.config
+	case ds2482ReadPointerData:
+		b[0] = c.data
+	}
+	return 1, nil
+}
+
+func initTestDS2482DriverWithStubbedAdaptor() (*DS2482Driver, *ds2482TestChip) {
+	a := newI2cTestAdaptor()
+	chip := &ds2482TestChip{presence: true, busyPolls: 2, rom: onewire.NewROM(0x28, 0x075f5ef1)}
+	a.i2cWriteImpl = chip.write
+	a.i2cReadImpl = chip.read
+	d := NewDS2482Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	chip.written = nil
+	return d, chip
+}
+
+func TestNewDS2482Driver(t *testing.T) {
+	var di interface{} = NewDS2482Driver(newI2cTestAdaptor())
+	d, ok := di.(*DS2482Driver)
+	if !ok {
+		t.Errorf("NewDS2482Driver() should have returned a *DS2482Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DS2482"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x18)
+	gobottest.Assert(t, d.activePullup, true)
+	gobottest.Refute(t, d.Command("Search"), nil)
+}
+
+func TestDS2482Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewDS2482Driver(newI2cTestAdaptor(), WithBus(2), WithDS2482ActivePullup(false))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.activePullup, false)
+}
+
+func TestDS2482Start(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	chip := &ds2482TestChip{}
+	a.i2cWriteImpl = chip.write
+	a.i2cReadImpl = chip.read
+	d := NewDS2482Driver(a)
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, chip.config, byte(ds2482ConfigActivePullup))
+	gobottest.Assert(t, a.written, []byte{ds2482CmdDeviceReset, ds2482CmdWriteConfig, 0xE1})
+}
+
+func TestDS2482StartError(t *testing.T) {
+	var tests = map[string]struct {
+		chip    *ds2482TestChip
+		wantErr string
+	}{
+		"no_reset": {
+			chip:    &ds2482TestChip{noResetBit: true},
+			wantErr: "DS2482 device reset failed, status 0x08",
+		},
+		"bad_config": {
+			chip:    &ds2482TestChip{badConfig: true},
+			wantErr: "DS2482 configuration 0x01 not accepted, read 0x00",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			a.i2cWriteImpl = tc.chip.write
+			a.i2cReadImpl = tc.chip.read
+			d := NewDS2482Driver(a)
+			// act
+			err := d.Start()
+			// assert
+			gobottest.Assert(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestDS2482Reset(t *testing.T) {
+	// arrange
+	d, chip := initTestDS2482DriverWithStubbedAdaptor()
+	// act & assert
+	gobottest.Assert(t, d.Reset(), nil)
+	chip.presence = false
+	gobottest.Assert(t, d.Reset(), onewire.ErrNoPresence)
+	chip.busyPolls = ds2482MaxBusyPolls + 1
+	gobottest.Assert(t, d.Reset().Error(), "DS2482 1-Wire bus is still busy after 100 polls")
+}
+
+func TestDS2482WriteAndReadBytes(t *testing.T) {
+	// arrange
+	d, chip := initTestDS2482DriverWithStubbedAdaptor()
+	chip.readQueue = []byte{0x12, 0x34}
+	data := make([]byte, 2)
+	// act
+	errWrite := d.WriteBytes([]byte{0xCC, 0x44}, true)
+	errRead := d.ReadBytes(data)
+	// assert
+	gobottest.Assert(t, errWrite, nil)
+	gobottest.Assert(t, errRead, nil)
+	gobottest.Assert(t, chip.written, []byte{0xCC, 0x44})
+	gobottest.Assert(t, chip.pullups, []bool{false, true})
+	gobottest.Assert(t, data, []byte{0x12, 0x34})
+}
+
+func TestDS2482Search(t *testing.T) {
+	// arrange
+	d, chip := initTestDS2482DriverWithStubbedAdaptor()
+	// act
+	roms, err := d.Search()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, roms, []onewire.ROM{chip.rom})
+	gobottest.Assert(t, chip.written, []byte{onewire.CmdSearchROM})
+}
+
+func TestDS2482Connection(t *testing.T) {
+	// arrange
+	d, chip := initTestDS2482DriverWithStubbedAdaptor()
+	chip.readQueue = []byte{0x72, 0x01}
+	c, err := d.GetOneWireConnection(chip.rom)
+	gobottest.Assert(t, err, nil)
+	read := make([]byte, 2)
+	// act
+	err = c.(onewire.Transactor).Transaction([]byte{0xBE}, read, 0)
+	// assert
+	gobottest.Assert(t, err, nil)
+	rom := chip.rom.Bytes()
+	gobottest.Assert(t, chip.written, append(append([]byte{onewire.CmdMatchROM}, rom[:]...), 0xBE))
+	gobottest.Assert(t, read, []byte{0x72, 0x01})
+	gobottest.Assert(t, c.ROM(), chip.rom)
+}
+
+func TestDS2482ConnectionNotStarted(t *testing.T) {
+	// arrange
+	d := NewDS2482Driver(newI2cTestAdaptor())
+	// act
+	_, err := d.GetOneWireConnection(onewire.NewROM(0x28, 1))
+	// assert
+	gobottest.Assert(t, err.Error(), "DS2482 is not started")
+}
+
+func TestDS2482WithDS18B20(t *testing.T) {
+	// arrange
+	d, chip := initTestDS2482DriverWithStubbedAdaptor()
+	// power supply (parasitic), scratchpad
+	chip.readQueue = []byte{0x00, 0x72, 0x01, 0x4b, 0x46, 0x1f, 0xff, 0x0e, 0x10, 0x00}
+	chip.readQueue[9] = onewire.CRC8(chip.readQueue[1:9])
+	chip.readQueue = append(chip.readQueue, chip.readQueue[1:]...)
+	s := onewire.NewDS18B20Driver(d, chip.rom)
+	gobottest.Assert(t, s.Start(), nil)
+	gobottest.Assert(t, s.IsParasitic(), true)
+	gobottest.Assert(t, s.Resolution(), uint8(9))
+	chip.pullups = nil
+	// act
+	start := time.Now()
+	temp, err := s.Temperature()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, float32(23))
+	gobottest.Assert(t, time.Since(start) >= 93*time.Millisecond, true)
+	// strong pullup after the convert command
+	gobottest.Assert(t, chip.pullups[9], true)
+}
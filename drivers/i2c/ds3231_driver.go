@@ -0,0 +1,352 @@
+package i2c
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DS3231 has the fixed address 0x68
+	ds3231DefaultAddress = 0x68
+
+	ds3231Reg_SECONDS    = 0x00 // 0x00..0x06 time and calendar
+	ds3231Reg_ALARM1     = 0x07 // 0x07..0x0A seconds, minutes, hours, day/date
+	ds3231Reg_ALARM2     = 0x0B // 0x0B..0x0D minutes, hours, day/date
+	ds3231Reg_CONTROL    = 0x0E
+	ds3231Reg_STATUS     = 0x0F
+	ds3231Reg_AGING      = 0x10
+	ds3231Reg_TEMP_MSB   = 0x11
+	ds3231Reg_TEMP_LSB   = 0x12
+	ds3231AlarmMask      = 0x80 // bit 7 of each alarm register
+	ds3231AlarmDayOfWeek = 0x40 // bit 6 of the day/date alarm register
+
+	ds3231CtrlAlarm1Enable = 0x01
+	ds3231CtrlAlarm2Enable = 0x02
+	ds3231CtrlInterrupt    = 0x04 // INTCN, 0: square wave output, 1: alarm interrupt output
+	ds3231CtrlRateMask     = 0x18 // RS2, RS1
+	ds3231CtrlConvert      = 0x20 // starts a temperature conversion and update of the aging offset
+
+	ds3231StatAlarm1Flag        = 0x01
+	ds3231StatAlarm2Flag        = 0x02
+	ds3231StatBusy              = 0x04
+	ds3231StatEnable32kHz       = 0x08
+	ds3231StatOscillatorStopped = 0x80
+)
+
+// DS3231Alarm is used to select one of the two alarms
+type DS3231Alarm uint8
+
+const (
+	// DS3231Alarm1 is the alarm with a resolution of seconds
+	DS3231Alarm1 DS3231Alarm = 1
+	// DS3231Alarm2 is the alarm with a resolution of minutes
+	DS3231Alarm2 DS3231Alarm = 2
+)
+
+// DS3231AlarmRate defines the parts of the time, which needs to match for an alarm
+type DS3231AlarmRate uint8
+
+const (
+	// DS3231AlarmEvery fires alarm 1 once per second and alarm 2 once per minute
+	DS3231AlarmEvery DS3231AlarmRate = iota
+	// DS3231AlarmMatchSeconds fires when the seconds match (alarm 1 only)
+	DS3231AlarmMatchSeconds
+	// DS3231AlarmMatchMinutes fires when the minutes and seconds match
+	DS3231AlarmMatchMinutes
+	// DS3231AlarmMatchHours fires when the hours, minutes and seconds match
+	DS3231AlarmMatchHours
+	// DS3231AlarmMatchDate fires when the date, hours, minutes and seconds match
+	DS3231AlarmMatchDate
+	// DS3231AlarmMatchWeekday fires when the weekday, hours, minutes and seconds match
+	DS3231AlarmMatchWeekday
+)
+
+// DS3231SquareWave is used to set the output of the INT/SQW pin
+type DS3231SquareWave uint8
+
+const (
+	// DS3231SquareWaveOff disables the square wave, the pin is used for the alarm interrupt
+	DS3231SquareWaveOff DS3231SquareWave = iota
+	// DS3231SquareWave1Hz enables the square wave with 1 Hz
+	DS3231SquareWave1Hz
+	// DS3231SquareWave1kHz enables the square wave with 1.024 kHz
+	DS3231SquareWave1kHz
+	// DS3231SquareWave4kHz enables the square wave with 4.096 kHz
+	DS3231SquareWave4kHz
+	// DS3231SquareWave8kHz enables the square wave with 8.192 kHz
+	DS3231SquareWave8kHz
+)
+
+// DS3231Driver is a Gobot Driver for the DS3231 extremely accurate real-time clock with integrated
+// temperature compensated crystal oscillator, two alarms and a temperature sensor.
+// please refer to data sheet: https://datasheets.maximintegrated.com/en/ds/DS3231.pdf
+//
+// The clock is always written in 24 hour mode, reading supports the 12 hour mode too. The years
+// 2000..2199 are supported.
+type DS3231Driver struct {
+	*Driver
+}
+
+// NewDS3231Driver creates a new driver for the DS3231 real-time clock.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+func NewDS3231Driver(c Connector, options ...func(Config)) *DS3231Driver {
+	d := &DS3231Driver{
+		Driver: NewDriver(c, "DS3231", ds3231DefaultAddress),
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	// API commands
+	d.AddCommand("WriteTime", func(params map[string]interface{}) interface{} {
+		val := params["val"].(time.Time)
+		err := d.WriteTime(val)
+		return map[string]interface{}{"err": err}
+	})
+
+	d.AddCommand("ReadTime", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadTime()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	d.AddCommand("ReadTemperature", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadTemperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// WriteTime sets the clock to the given time and resets the oscillator stop flag. The clock runs in UTC, so the
+// location of the given time does not matter.
+func (d *DS3231Driver) WriteTime(val time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.connection.WriteBlockData(ds3231Reg_SECONDS, dsEncodeTime(val, true)); err != nil {
+		return err
+	}
+	return d.updateStatus(ds3231StatOscillatorStopped, 0)
+}
+
+// ReadTime reads the clock and returns the value
+func (d *DS3231Driver) ReadTime() (time.Time, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data := make([]byte, dsTimeDataSize)
+	if err := d.connection.ReadBlockData(ds3231Reg_SECONDS, data); err != nil {
+		return time.Time{}, err
+	}
+	return dsDecodeTime(data), nil
+}
+
+// OscillatorStopped returns true, if the oscillator was stopped since the last call of WriteTime(), e.g. because
+// of a missing backup battery. In this case the time is not valid.
+func (d *DS3231Driver) OscillatorStopped() (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status, err := d.connection.ReadByteData(ds3231Reg_STATUS)
+	if err != nil {
+		return false, err
+	}
+	return status&ds3231StatOscillatorStopped != 0, nil
+}
+
+// SetAlarm sets and enables the given alarm, the INT/SQW pin is switched to the interrupt output. The parts of
+// the time, which are not needed for the rate, are ignored. The time is converted to UTC, like the clock.
+func (d *DS3231Driver) SetAlarm(alarm DS3231Alarm, val time.Time, rate DS3231AlarmRate) error {
+	val = val.UTC()
+	reg, enable, err := ds3231AlarmRegister(alarm)
+	if err != nil {
+		return err
+	}
+	if rate > DS3231AlarmMatchWeekday {
+		return fmt.Errorf("%s: invalid alarm rate %d", d.name, rate)
+	}
+	if alarm == DS3231Alarm2 && rate == DS3231AlarmMatchSeconds {
+		return fmt.Errorf("%s: alarm 2 does not support the match of seconds", d.name)
+	}
+
+	day := dsEncodeBcd(uint8(val.Day()))
+	if rate == DS3231AlarmMatchWeekday {
+		day = (uint8(val.Weekday()) + 1) | ds3231AlarmDayOfWeek
+	}
+	// seconds, minutes, hours, day/date, the mask bit is set for each not matching part
+	data := []byte{dsEncodeBcd(uint8(val.Second())), dsEncodeBcd(uint8(val.Minute())),
+		dsEncodeBcd(uint8(val.Hour())), day}
+	for i := range data {
+		if rate <= DS3231AlarmRate(i) {
+			data[i] |= ds3231AlarmMask
+		}
+	}
+	if alarm == DS3231Alarm2 {
+		data = data[1:]
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.connection.WriteBlockData(reg, data); err != nil {
+		return err
+	}
+	if err := d.clearAlarmFlag(alarm); err != nil {
+		return err
+	}
+	return d.updateRegister(ds3231Reg_CONTROL, ds3231CtrlInterrupt|enable, ds3231CtrlInterrupt|enable)
+}
+
+// DisableAlarm disables the given alarm
+func (d *DS3231Driver) DisableAlarm(alarm DS3231Alarm) error {
+	_, enable, err := ds3231AlarmRegister(alarm)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.updateRegister(ds3231Reg_CONTROL, enable, 0)
+}
+
+// AlarmFired returns true, if the given alarm has fired since the last call of ClearAlarm()
+func (d *DS3231Driver) AlarmFired(alarm DS3231Alarm) (bool, error) {
+	if _, _, err := ds3231AlarmRegister(alarm); err != nil {
+		return false, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status, err := d.connection.ReadByteData(ds3231Reg_STATUS)
+	if err != nil {
+		return false, err
+	}
+	return status&uint8(alarm) != 0, nil
+}
+
+// ClearAlarm resets the flag of the given alarm, which also releases the interrupt output
+func (d *DS3231Driver) ClearAlarm(alarm DS3231Alarm) error {
+	if _, _, err := ds3231AlarmRegister(alarm); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.clearAlarmFlag(alarm)
+}
+
+// SetSquareWave sets the frequency of the INT/SQW pin, if switched off, the pin is used for the alarm interrupt
+func (d *DS3231Driver) SetSquareWave(val DS3231SquareWave) error {
+	if val > DS3231SquareWave8kHz {
+		return fmt.Errorf("%s: invalid square wave %d", d.name, val)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if val == DS3231SquareWaveOff {
+		return d.updateRegister(ds3231Reg_CONTROL, ds3231CtrlInterrupt, ds3231CtrlInterrupt)
+	}
+	rate := (uint8(val) - 1) << 3
+	return d.updateRegister(ds3231Reg_CONTROL, ds3231CtrlInterrupt|ds3231CtrlRateMask, rate)
+}
+
+// Enable32kHz switches the 32kHz output on or off
+func (d *DS3231Driver) Enable32kHz(enable bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var val uint8
+	if enable {
+		val = ds3231StatEnable32kHz
+	}
+	return d.updateStatus(ds3231StatEnable32kHz, val)
+}
+
+// ReadAgingOffset reads the aging offset, one step is about 0.1 ppm, positive values slow down the clock
+func (d *DS3231Driver) ReadAgingOffset() (int8, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.connection.ReadByteData(ds3231Reg_AGING)
+	return int8(val), err
+}
+
+// WriteAgingOffset writes the aging offset and starts a conversion to apply the value immediately, one step is
+// about 0.1 ppm, positive values slow down the clock
+func (d *DS3231Driver) WriteAgingOffset(val int8) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.connection.WriteByteData(ds3231Reg_AGING, uint8(val)); err != nil {
+		return err
+	}
+	return d.convert()
+}
+
+// ReadTemperature reads the temperature in Celsius with a resolution of 0.25°C. The temperature is updated by the
+// chip every 64 seconds.
+func (d *DS3231Driver) ReadTemperature() (float32, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data := make([]byte, 2)
+	if err := d.connection.ReadBlockData(ds3231Reg_TEMP_MSB, data); err != nil {
+		return 0, err
+	}
+	raw := int16(uint16(data[0])<<8|uint16(data[1])) >> 6
+	return float32(raw) * 0.25, nil
+}
+
+// convert starts a temperature conversion, if no conversion is running
+func (d *DS3231Driver) convert() error {
+	status, err := d.connection.ReadByteData(ds3231Reg_STATUS)
+	if err != nil {
+		return err
+	}
+	if status&ds3231StatBusy != 0 {
+		// the running conversion will apply the new value
+		return nil
+	}
+	return d.updateRegister(ds3231Reg_CONTROL, ds3231CtrlConvert, ds3231CtrlConvert)
+}
+
+// clearAlarmFlag resets the flag of the alarm
+func (d *DS3231Driver) clearAlarmFlag(alarm DS3231Alarm) error {
+	return d.updateStatus(uint8(alarm), 0)
+}
+
+// updateStatus replaces the masked bits of the status register, the not masked alarm flags are written with 1,
+// which leaves them unchanged, so an alarm between read and write is not lost
+func (d *DS3231Driver) updateStatus(mask uint8, val uint8) error {
+	flags := uint8(ds3231StatAlarm1Flag|ds3231StatAlarm2Flag) &^ mask
+	return d.updateRegister(ds3231Reg_STATUS, mask|flags, val|flags)
+}
+
+// updateRegister reads the register and writes back the value with the masked bits replaced
+func (d *DS3231Driver) updateRegister(reg uint8, mask uint8, val uint8) error {
+	old, err := d.connection.ReadByteData(reg)
+	if err != nil {
+		return err
+	}
+	return d.connection.WriteByteData(reg, old&^mask|val&mask)
+}
+
+func ds3231AlarmRegister(alarm DS3231Alarm) (reg uint8, enable uint8, err error) {
+	switch alarm {
+	case DS3231Alarm1:
+		return ds3231Reg_ALARM1, ds3231CtrlAlarm1Enable, nil
+	case DS3231Alarm2:
+		return ds3231Reg_ALARM2, ds3231CtrlAlarm2Enable, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid alarm %d", alarm)
+	}
+}
@@ -0,0 +1,222 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/rtc"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*DS3231Driver)(nil)
+
+var _ rtc.RTC = (*DS3231Driver)(nil)
+
+func initTestDS3231WithStubbedAdaptor() (*DS3231Driver, *memoryTestDevice) {
+	a := newI2cTestAdaptor()
+	m := simulateMemory(a, 32, 1)
+	d := NewDS3231Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, m
+}
+
+func TestNewDS3231Driver(t *testing.T) {
+	var di interface{} = NewDS3231Driver(newI2cTestAdaptor())
+	d, ok := di.(*DS3231Driver)
+	if !ok {
+		t.Errorf("NewDS3231Driver() should have returned a *DS3231Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DS3231"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x68)
+}
+
+func TestDS3231Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option.
+	d := NewDS3231Driver(newI2cTestAdaptor(), WithBus(2))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+}
+
+func TestDS3231WriteReadTime(t *testing.T) {
+	d, m := initTestDS3231WithStubbedAdaptor()
+	m.mem[ds3231Reg_STATUS] = 0x80 | 0x08 // oscillator stopped, 32kHz enabled
+	stopped, err := d.OscillatorStopped()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, stopped, true)
+
+	val := time.Date(2123, time.July, 4, 7, 8, 9, 0, time.UTC)
+	gobottest.Assert(t, d.WriteTime(val), nil)
+	// Sunday is the 1st day of the week, the century bit is set
+	gobottest.Assert(t, m.mem[0:7], []byte{0x09, 0x08, 0x07, 0x01, 0x04, 0x87, 0x23})
+	// the alarm flags are written with 1, which leaves them unchanged
+	gobottest.Assert(t, m.mem[ds3231Reg_STATUS], uint8(0x0B))
+	stopped, _ = d.OscillatorStopped()
+	gobottest.Assert(t, stopped, false)
+
+	got, err := d.ReadTime()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got, val)
+}
+
+func TestDS3231WriteReadTimeWithLocation(t *testing.T) {
+	// arrange
+	d, m := initTestDS3231WithStubbedAdaptor()
+	val := time.Date(2023, time.July, 4, 22, 8, 9, 0, time.FixedZone("UTC-5", -5*60*60))
+	// act
+	err := d.WriteTime(val)
+	got, errRead := d.ReadTime()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, errRead, nil)
+	// the clock runs in UTC: Wednesday, 5th 03:08:09
+	gobottest.Assert(t, m.mem[0:7], []byte{0x09, 0x08, 0x03, 0x04, 0x05, 0x07, 0x23})
+	gobottest.Assert(t, got.Equal(val), true)
+	gobottest.Assert(t, got.Location(), time.UTC)
+	// the alarm is converted to UTC too
+	gobottest.Assert(t, d.SetAlarm(DS3231Alarm2, val, DS3231AlarmMatchHours), nil)
+	gobottest.Assert(t, m.mem[0x0B:0x0E], []byte{0x08, 0x03, 0x85})
+}
+
+func TestDS3231SetAlarm(t *testing.T) {
+	var tests = map[string]struct {
+		alarm DS3231Alarm
+		rate  DS3231AlarmRate
+		reg   int
+		want  []byte
+	}{
+		"alarm1_every_second": {alarm: DS3231Alarm1, rate: DS3231AlarmEvery, reg: 0x07,
+			want: []byte{0xB0, 0xA5, 0x92, 0x96}},
+		"alarm1_seconds": {alarm: DS3231Alarm1, rate: DS3231AlarmMatchSeconds, reg: 0x07,
+			want: []byte{0x30, 0xA5, 0x92, 0x96}},
+		"alarm1_hours": {alarm: DS3231Alarm1, rate: DS3231AlarmMatchHours, reg: 0x07,
+			want: []byte{0x30, 0x25, 0x12, 0x96}},
+		"alarm1_date": {alarm: DS3231Alarm1, rate: DS3231AlarmMatchDate, reg: 0x07,
+			want: []byte{0x30, 0x25, 0x12, 0x16}},
+		"alarm1_weekday": {alarm: DS3231Alarm1, rate: DS3231AlarmMatchWeekday, reg: 0x07,
+			want: []byte{0x30, 0x25, 0x12, 0x44}},
+		"alarm2_every_minute": {alarm: DS3231Alarm2, rate: DS3231AlarmEvery, reg: 0x0B,
+			want: []byte{0xA5, 0x92, 0x96}},
+		"alarm2_minutes": {alarm: DS3231Alarm2, rate: DS3231AlarmMatchMinutes, reg: 0x0B,
+			want: []byte{0x25, 0x92, 0x96}},
+	}
+	// Wednesday, 4th day of the week
+	val := time.Date(2022, time.November, 16, 12, 25, 30, 0, time.UTC)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, m := initTestDS3231WithStubbedAdaptor()
+			m.mem[ds3231Reg_STATUS] = 0x03
+			// act
+			err := d.SetAlarm(tc.alarm, val, tc.rate)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, m.mem[tc.reg:tc.reg+len(tc.want)], tc.want)
+			gobottest.Assert(t, m.mem[ds3231Reg_CONTROL], uint8(0x04)|uint8(tc.alarm))
+			// only the flag of this alarm is cleared
+			gobottest.Assert(t, m.mem[ds3231Reg_STATUS], uint8(0x03)&^uint8(tc.alarm))
+		})
+	}
+}
+
+func TestDS3231SetAlarmErrors(t *testing.T) {
+	d, _ := initTestDS3231WithStubbedAdaptor()
+	gobottest.Assert(t, d.SetAlarm(3, time.Now(), DS3231AlarmEvery), errors.New("invalid alarm 3"))
+	err := d.SetAlarm(DS3231Alarm1, time.Now(), 6)
+	gobottest.Assert(t, strings.Contains(err.Error(), "invalid alarm rate 6"), true)
+	err = d.SetAlarm(DS3231Alarm2, time.Now(), DS3231AlarmMatchSeconds)
+	gobottest.Assert(t, strings.Contains(err.Error(), "alarm 2 does not support the match of seconds"), true)
+}
+
+func TestDS3231AlarmFlags(t *testing.T) {
+	d, m := initTestDS3231WithStubbedAdaptor()
+	m.mem[ds3231Reg_CONTROL] = 0x07
+	m.mem[ds3231Reg_STATUS] = 0x02
+
+	fired, err := d.AlarmFired(DS3231Alarm1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fired, false)
+	fired, _ = d.AlarmFired(DS3231Alarm2)
+	gobottest.Assert(t, fired, true)
+
+	gobottest.Assert(t, d.ClearAlarm(DS3231Alarm2), nil)
+	fired, _ = d.AlarmFired(DS3231Alarm2)
+	gobottest.Assert(t, fired, false)
+
+	gobottest.Assert(t, d.DisableAlarm(DS3231Alarm1), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_CONTROL], uint8(0x06))
+}
+
+func TestDS3231SquareWave(t *testing.T) {
+	d, m := initTestDS3231WithStubbedAdaptor()
+	m.mem[ds3231Reg_CONTROL] = 0x1F
+	gobottest.Assert(t, d.SetSquareWave(DS3231SquareWave4kHz), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_CONTROL], uint8(0x13))
+	gobottest.Assert(t, d.SetSquareWave(DS3231SquareWaveOff), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_CONTROL], uint8(0x17))
+	err := d.SetSquareWave(5)
+	gobottest.Assert(t, strings.Contains(err.Error(), "invalid square wave 5"), true)
+
+	gobottest.Assert(t, d.Enable32kHz(false), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_STATUS], uint8(0x03))
+	gobottest.Assert(t, d.Enable32kHz(true), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_STATUS], uint8(0x0B))
+}
+
+func TestDS3231AgingOffset(t *testing.T) {
+	d, m := initTestDS3231WithStubbedAdaptor()
+	gobottest.Assert(t, d.WriteAgingOffset(-5), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_AGING], uint8(0xFB))
+	// a conversion was started
+	gobottest.Assert(t, m.mem[ds3231Reg_CONTROL], uint8(0x20))
+	val, err := d.ReadAgingOffset()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, int8(-5))
+
+	// no new conversion, while busy
+	m.mem[ds3231Reg_CONTROL] = 0
+	m.mem[ds3231Reg_STATUS] = 0x04
+	gobottest.Assert(t, d.WriteAgingOffset(3), nil)
+	gobottest.Assert(t, m.mem[ds3231Reg_CONTROL], uint8(0x00))
+}
+
+func TestDS3231ReadTemperature(t *testing.T) {
+	var tests = map[string]struct {
+		msb  uint8
+		lsb  uint8
+		want float32
+	}{
+		"positive": {msb: 0x19, lsb: 0x40, want: 25.25},
+		"negative": {msb: 0xF6, lsb: 0xC0, want: -9.25},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, m := initTestDS3231WithStubbedAdaptor()
+			m.mem[ds3231Reg_TEMP_MSB] = tc.msb
+			m.mem[ds3231Reg_TEMP_LSB] = tc.lsb
+			// act
+			got, err := d.ReadTemperature()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got, tc.want)
+		})
+	}
+}
+
+func TestDS3231ReadTimeError(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewDS3231Driver(a)
+	_ = d.Start()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadTime()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -0,0 +1,66 @@
+package i2c
+
+import (
+	"log"
+	"time"
+)
+
+const eepromDebug = false
+
+const (
+	eepromDefaultAddress    = 0x50
+	eepromDefaultWriteCycle = 5 * time.Millisecond
+)
+
+// Models of the 24Cxx EEPROM family, e.g. AT24C256 or 24LC256
+var (
+	EEPROM24C01  = MemoryModel{Name: "24C01", Size: 128, PageSize: 8, AddressBytes: 1}
+	EEPROM24C02  = MemoryModel{Name: "24C02", Size: 256, PageSize: 8, AddressBytes: 1}
+	EEPROM24C04  = MemoryModel{Name: "24C04", Size: 512, PageSize: 16, AddressBytes: 1}
+	EEPROM24C08  = MemoryModel{Name: "24C08", Size: 1024, PageSize: 16, AddressBytes: 1}
+	EEPROM24C16  = MemoryModel{Name: "24C16", Size: 2048, PageSize: 16, AddressBytes: 1}
+	EEPROM24C32  = MemoryModel{Name: "24C32", Size: 4096, PageSize: 32, AddressBytes: 2}
+	EEPROM24C64  = MemoryModel{Name: "24C64", Size: 8192, PageSize: 32, AddressBytes: 2}
+	EEPROM24C128 = MemoryModel{Name: "24C128", Size: 16384, PageSize: 64, AddressBytes: 2}
+	EEPROM24C256 = MemoryModel{Name: "24C256", Size: 32768, PageSize: 64, AddressBytes: 2}
+	EEPROM24C512 = MemoryModel{Name: "24C512", Size: 65536, PageSize: 128, AddressBytes: 2}
+)
+
+// EEPROMDriver is a driver for I2C EEPROMs of the 24Cxx family. It implements io.ReaderAt and io.WriterAt.
+// Writes are split into page writes and the driver waits for the end of each write cycle by polling the
+// device (acknowledge polling).
+type EEPROMDriver struct {
+	*memoryDriver
+}
+
+// NewEEPROMDriver creates a new driver for the given EEPROM model, e.g. EEPROM24C256.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//		model MemoryModel - the organization of the memory
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithEEPROMWriteCycle(time.Duration):	maximum time of the write cycle
+func NewEEPROMDriver(c Connector, model MemoryModel, options ...func(Config)) *EEPROMDriver {
+	d := &EEPROMDriver{memoryDriver: newMemoryDriver(c, model, eepromDefaultAddress)}
+	d.writeCycle = eepromDefaultWriteCycle
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithEEPROMWriteCycle option sets the maximum time of the internal write cycle, the default is 5 ms.
+func WithEEPROMWriteCycle(val time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*EEPROMDriver)
+		if ok {
+			d.writeCycle = val
+		} else if eepromDebug {
+			log.Printf("Trying to set write cycle for non-EEPROMDriver %v", c)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package i2c
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/kvstore"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*EEPROMDriver)(nil)
+
+var _ io.ReaderAt = (*EEPROMDriver)(nil)
+var _ io.WriterAt = (*EEPROMDriver)(nil)
+var _ kvstore.Storage = (*EEPROMDriver)(nil)
+
+func TestNewEEPROMDriver(t *testing.T) {
+	var di interface{} = NewEEPROMDriver(newI2cTestAdaptor(), EEPROM24C256)
+	d, ok := di.(*EEPROMDriver)
+	if !ok {
+		t.Errorf("NewEEPROMDriver() should have returned a *EEPROMDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "24C256"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x50)
+	gobottest.Assert(t, d.Size(), int64(32768))
+	gobottest.Assert(t, d.writeCycle, 5*time.Millisecond)
+}
+
+func TestEEPROMOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewEEPROMDriver(newI2cTestAdaptor(), EEPROM24C02, WithBus(2), WithEEPROMWriteCycle(10*time.Millisecond))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.writeCycle, 10*time.Millisecond)
+}
+
+func TestEEPROMWriteAtPages(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewEEPROMDriver(a, EEPROM24C32)
+	m := simulateMemory(a, 4096, 2)
+	gobottest.Assert(t, d.Start(), nil)
+
+	// 70 bytes starting in the middle of a page of 32 bytes
+	data := make([]byte, 70)
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+	n, err := d.WriteAt(data, 20)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 70)
+	gobottest.Assert(t, len(m.writes), 3)
+	gobottest.Assert(t, len(m.writes[0]), 12)
+	gobottest.Assert(t, len(m.writes[1]), 32)
+	gobottest.Assert(t, len(m.writes[2]), 26)
+	gobottest.Assert(t, m.mem[20:90], data)
+
+	got := make([]byte, 70)
+	n, err = d.ReadAt(got, 20)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 70)
+	gobottest.Assert(t, got, data)
+}
+
+func TestEEPROMWriteCycle(t *testing.T) {
+	a := newI2cTestAdaptor()
+	// a long write cycle, so the polling is independent of the scheduling
+	d := NewEEPROMDriver(a, EEPROM24C02, WithEEPROMWriteCycle(100*time.Millisecond))
+	m := simulateMemory(a, 256, 1)
+	gobottest.Assert(t, d.Start(), nil)
+
+	// the device does not acknowledge during the write cycle
+	m.busyWrites = 2
+	n, err := d.WriteAt([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 10)
+	gobottest.Assert(t, m.mem[:10], []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	// timeout
+	d.writeCycle = 5 * time.Millisecond
+	m.busyWrites = 1000
+	n, err = d.WriteAt([]byte{1, 2}, 100)
+	gobottest.Assert(t, err, ErrNotReady)
+	gobottest.Assert(t, n, 0)
+}
+
+func TestEEPROMLimits(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewEEPROMDriver(a, EEPROM24C02)
+	simulateMemory(a, 256, 1)
+	gobottest.Assert(t, d.Start(), nil)
+
+	buf := make([]byte, 10)
+	n, err := d.ReadAt(buf, 250)
+	gobottest.Assert(t, err, io.EOF)
+	gobottest.Assert(t, n, 6)
+
+	n, err = d.WriteAt(buf, 250)
+	gobottest.Assert(t, err, errors.New("write exceeds the size of the memory"))
+	gobottest.Assert(t, n, 6)
+
+	_, err = d.ReadAt(buf, -1)
+	gobottest.Assert(t, err, errors.New("negative offset"))
+}
+
+func TestEEPROMBlocks(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewEEPROMDriver(a, EEPROM24C16)
+	simulateMemory(a, 2048, 1)
+	gobottest.Assert(t, d.Start(), nil)
+	// one connection for each block of 256 bytes, the last one at 0x57
+	gobottest.Assert(t, len(d.connections), 8)
+	gobottest.Assert(t, a.address, 0x57)
+
+	// a read is split at the block boundary
+	a.written = nil
+	_, err := d.ReadAt(make([]byte, 4), 254)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.written, []byte{254, 0})
+}
+
+func TestEEPROMReadError(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewEEPROMDriver(a, EEPROM24C02)
+	gobottest.Assert(t, d.Start(), nil)
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadAt(make([]byte, 4), 0)
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 2, nil
+	}
+	n, err := d.ReadAt(make([]byte, 4), 0)
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+	gobottest.Assert(t, n, 2)
+}
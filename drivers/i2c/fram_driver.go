@@ -0,0 +1,38 @@
+package i2c
+
+const framDefaultAddress = 0x50
+
+// Models of the Fujitsu MB85RC FRAM family
+var (
+	FRAMMB85RC04V  = MemoryModel{Name: "MB85RC04V", Size: 512, AddressBytes: 1}
+	FRAMMB85RC16   = MemoryModel{Name: "MB85RC16", Size: 2048, AddressBytes: 1}
+	FRAMMB85RC64   = MemoryModel{Name: "MB85RC64", Size: 8192, AddressBytes: 2}
+	FRAMMB85RC128  = MemoryModel{Name: "MB85RC128", Size: 16384, AddressBytes: 2}
+	FRAMMB85RC256V = MemoryModel{Name: "MB85RC256V", Size: 32768, AddressBytes: 2}
+	FRAMMB85RC512T = MemoryModel{Name: "MB85RC512T", Size: 65536, AddressBytes: 2}
+	FRAMMB85RC1MT  = MemoryModel{Name: "MB85RC1MT", Size: 131072, AddressBytes: 2}
+)
+
+// FRAMDriver is a driver for I2C FRAMs of the MB85RC family. It implements io.ReaderAt and io.WriterAt.
+// In contrast to EEPROMs, there are no pages and no write cycle to wait for.
+type FRAMDriver struct {
+	*memoryDriver
+}
+
+// NewFRAMDriver creates a new driver for the given FRAM model, e.g. FRAMMB85RC256V.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//		model MemoryModel - the organization of the memory
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+func NewFRAMDriver(c Connector, model MemoryModel, options ...func(Config)) *FRAMDriver {
+	d := &FRAMDriver{memoryDriver: newMemoryDriver(c, model, framDefaultAddress)}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
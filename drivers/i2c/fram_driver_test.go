@@ -0,0 +1,80 @@
+package i2c
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/kvstore"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*FRAMDriver)(nil)
+
+var _ io.ReaderAt = (*FRAMDriver)(nil)
+var _ io.WriterAt = (*FRAMDriver)(nil)
+var _ kvstore.Storage = (*FRAMDriver)(nil)
+
+func TestNewFRAMDriver(t *testing.T) {
+	var di interface{} = NewFRAMDriver(newI2cTestAdaptor(), FRAMMB85RC256V)
+	d, ok := di.(*FRAMDriver)
+	if !ok {
+		t.Errorf("NewFRAMDriver() should have returned a *FRAMDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MB85RC256V"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x50)
+	gobottest.Assert(t, d.Size(), int64(32768))
+	gobottest.Assert(t, d.writeCycle.Nanoseconds(), int64(0))
+}
+
+func TestFRAMOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option.
+	d := NewFRAMDriver(newI2cTestAdaptor(), FRAMMB85RC64, WithBus(2), WithAddress(0x52))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.GetAddressOrDefault(0x50), 0x52)
+}
+
+func TestFRAMReadWrite(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewFRAMDriver(a, FRAMMB85RC256V)
+	m := simulateMemory(a, 32768, 2)
+	gobottest.Assert(t, d.Start(), nil)
+
+	// no pages, so only limited by the maximum transfer size
+	data := make([]byte, 300)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	n, err := d.WriteAt(data, 0x1234)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 300)
+	gobottest.Assert(t, len(m.writes), 2)
+	gobottest.Assert(t, len(m.writes[0]), 256)
+	gobottest.Assert(t, m.mem[0x1234:0x1234+300], data)
+
+	got := make([]byte, 300)
+	n, err = d.ReadAt(got, 0x1234)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 300)
+	gobottest.Assert(t, got, data)
+}
+
+func TestFRAMBlocks(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewFRAMDriver(a, FRAMMB85RC1MT)
+	simulateMemory(a, 65536, 2)
+	gobottest.Assert(t, d.Start(), nil)
+	// the upper 64k are addressed by the next device address
+	gobottest.Assert(t, len(d.connections), 2)
+	gobottest.Assert(t, a.address, 0x51)
+
+	a.written = nil
+	n, err := d.WriteAt([]byte{1, 2, 3, 4}, 0xFFFE)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 4)
+	gobottest.Assert(t, a.written, []byte{0xFF, 0xFE, 1, 2, 0x00, 0x00, 3, 4})
+}
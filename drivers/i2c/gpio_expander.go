@@ -0,0 +1,94 @@
+package i2c
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+var gpioExpanderPinPattern = regexp.MustCompile(`^([A-Za-z]*)_?([0-9]+)$`)
+
+// parseGpioExpanderPin splits the pin id of a GPIO expander into the upper case port and the pin number,
+// e.g. "A3", "a_3" and "B7" for MCP23017 or "5" and "P5" for single port devices.
+func parseGpioExpanderPin(id string, ports ...string) (string, uint8, error) {
+	m := gpioExpanderPinPattern.FindStringSubmatch(strings.TrimSpace(id))
+	if m == nil {
+		return "", 0, fmt.Errorf("invalid pin id '%s'", id)
+	}
+	port := strings.ToUpper(m[1])
+	pin, err := strconv.ParseUint(m[2], 10, 8)
+	if err != nil || pin > 7 {
+		return "", 0, fmt.Errorf("invalid pin number in '%s', must be 0..7", id)
+	}
+	for _, p := range ports {
+		if p == port {
+			return port, uint8(pin), nil
+		}
+	}
+	return "", 0, fmt.Errorf("invalid port in '%s', must be one of %v", id, ports)
+}
+
+// gpioExpanderInterrupt handles the interrupt output of a GPIO expander, which is wired to a host GPIO with edge
+// detection. The values of the watched input pins are cached and only refreshed on interrupt, so reading these
+// pins causes no traffic on the i2c bus.
+type gpioExpanderInterrupt struct {
+	host   gobot.DigitalPinnerProvider
+	pinIDs []string
+	values map[string]int
+	mutex  *sync.Mutex
+}
+
+func newGpioExpanderInterrupt(host gobot.DigitalPinnerProvider, pinIDs ...string) *gpioExpanderInterrupt {
+	return &gpioExpanderInterrupt{
+		host:   host,
+		pinIDs: pinIDs,
+		values: make(map[string]int),
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// attach configures the host pins as inputs and calls the handler on each active edge
+func (i *gpioExpanderInterrupt) attach(activeHigh bool, handler func()) error {
+	for _, id := range i.pinIDs {
+		if err := attachHostInterrupt(i.host, id, activeHigh, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// value returns the cached value of a watched pin
+func (i *gpioExpanderInterrupt) value(id string) (int, bool) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	val, ok := i.values[id]
+	return val, ok
+}
+
+// watch adds the pin with the given initial value to the cache
+func (i *gpioExpanderInterrupt) watch(id string, val int) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.values[id] = val
+}
+
+// update refreshes the cached values of all watched pins by the given read function and returns the changed pins
+func (i *gpioExpanderInterrupt) update(read func(id string) int) map[string]int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	changed := make(map[string]int)
+	for id, old := range i.values {
+		if val := read(id); val != old {
+			i.values[id] = val
+			changed[id] = val
+		}
+	}
+	return changed
+}
@@ -0,0 +1,124 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// gpioExpanderTestPin is a host pin, which captures the edge event handler
+type gpioExpanderTestPin struct {
+	input   bool
+	edge    int
+	handler func(lineOffset int, timestamp time.Duration, detectedEdge string, seqno uint32, lseqno uint32)
+}
+
+func (p *gpioExpanderTestPin) Export() error      { return nil }
+func (p *gpioExpanderTestPin) Unexport() error    { return nil }
+func (p *gpioExpanderTestPin) Read() (int, error) { return 0, nil }
+func (p *gpioExpanderTestPin) Write(int) error    { return nil }
+func (p *gpioExpanderTestPin) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	for _, option := range options {
+		option(p)
+	}
+	return nil
+}
+func (p *gpioExpanderTestPin) SetLabel(string) bool                  { return false }
+func (p *gpioExpanderTestPin) SetDirectionOutput(int) bool           { return false }
+func (p *gpioExpanderTestPin) SetDirectionInput() bool               { p.input = true; return true }
+func (p *gpioExpanderTestPin) SetActiveLow() bool                    { return false }
+func (p *gpioExpanderTestPin) SetBias(int) bool                      { return false }
+func (p *gpioExpanderTestPin) SetDrive(int) bool                     { return false }
+func (p *gpioExpanderTestPin) SetDebounce(period time.Duration) bool { return false }
+func (p *gpioExpanderTestPin) SetEventHandlerForEdge(handler func(int, time.Duration, string, uint32, uint32),
+	edge int) bool {
+	p.handler = handler
+	p.edge = edge
+	return true
+}
+
+// trigger simulates an edge on the interrupt line
+func (p *gpioExpanderTestPin) trigger() { p.handler(0, 0, "", 0, 0) }
+
+type gpioExpanderTestHost struct {
+	pins map[string]*gpioExpanderTestPin
+}
+
+func newGpioExpanderTestHost() *gpioExpanderTestHost {
+	return &gpioExpanderTestHost{pins: make(map[string]*gpioExpanderTestPin)}
+}
+
+func (h *gpioExpanderTestHost) DigitalPin(id string) (gobot.DigitalPinner, error) {
+	if id == "invalid" {
+		return nil, errors.New("invalid host pin")
+	}
+	if h.pins[id] == nil {
+		h.pins[id] = &gpioExpanderTestPin{}
+	}
+	return h.pins[id], nil
+}
+
+func TestParseGpioExpanderPin(t *testing.T) {
+	var tests = map[string]struct {
+		id       string
+		ports    []string
+		wantPort string
+		wantPin  uint8
+		wantErr  string
+	}{
+		"port_pin":            {id: "A3", ports: []string{"A", "B"}, wantPort: "A", wantPin: 3},
+		"lower_case":          {id: "b7", ports: []string{"A", "B"}, wantPort: "B", wantPin: 7},
+		"with_underscore":     {id: "B_0", ports: []string{"A", "B"}, wantPort: "B", wantPin: 0},
+		"number_only":         {id: "5", ports: []string{"", "P"}, wantPort: "", wantPin: 5},
+		"prefix":              {id: "LED2", ports: []string{"", "LED"}, wantPort: "LED", wantPin: 2},
+		"wrong_port":          {id: "C1", ports: []string{"A", "B"}, wantErr: "invalid port in 'C1', must be one of [A B]"},
+		"missing_port":        {id: "1", ports: []string{"A", "B"}, wantErr: "invalid port in '1', must be one of [A B]"},
+		"pin_number_too_high": {id: "A8", ports: []string{"A", "B"}, wantErr: "invalid pin number in 'A8', must be 0..7"},
+		"invalid_syntax":      {id: "A-1", ports: []string{"A", "B"}, wantErr: "invalid pin id 'A-1'"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			port, pin, err := parseGpioExpanderPin(tc.id, tc.ports...)
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+				return
+			}
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, port, tc.wantPort)
+			gobottest.Assert(t, pin, tc.wantPin)
+		})
+	}
+}
+
+func TestGpioExpanderInterrupt(t *testing.T) {
+	host := newGpioExpanderTestHost()
+	i := newGpioExpanderInterrupt(host, "7", "11")
+	calls := 0
+	gobottest.Assert(t, i.attach(false, func() { calls++ }), nil)
+	gobottest.Assert(t, host.pins["7"].input, true)
+	gobottest.Assert(t, host.pins["7"].edge, 1)
+	gobottest.Assert(t, host.pins["11"].edge, 1)
+	host.pins["11"].trigger()
+	gobottest.Assert(t, calls, 1)
+
+	gobottest.Assert(t, i.attach(true, func() {}), nil)
+	gobottest.Assert(t, host.pins["7"].edge, 2)
+
+	_, ok := i.value("A1")
+	gobottest.Assert(t, ok, false)
+	i.watch("A1", 0)
+	i.watch("A2", 1)
+	val, ok := i.value("A2")
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, val, 1)
+
+	changed := i.update(func(id string) int { return 1 })
+	gobottest.Assert(t, changed, map[string]int{"A1": 1})
+	val, _ = i.value("A1")
+	gobottest.Assert(t, val, 1)
+
+	gobottest.Assert(t, newGpioExpanderInterrupt(host, "invalid").attach(false, func() {}).Error(), "invalid host pin")
+}
@@ -214,3 +214,51 @@ func (t *i2cTestAdaptor) writeBytes(b []byte) error {
 	}
 	return nil
 }
+
+// memoryTestDevice simulates the memory of an EEPROM or FRAM, or the registers of a device with auto increment,
+// with the given count of address bytes
+type memoryTestDevice struct {
+	mem          []byte
+	addressBytes int
+	pointer      int
+	writes       [][]byte // data of all writes, without the address only writes
+	busyWrites   int      // count of not acknowledged writes after each data write
+	busy         int
+	fixed        map[int]uint8 // values of status registers, which are returned instead of the memory content
+}
+
+func simulateMemory(a *i2cTestAdaptor, size int, addressBytes int) *memoryTestDevice {
+	m := &memoryTestDevice{mem: make([]byte, size), addressBytes: addressBytes, fixed: make(map[int]uint8)}
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		if m.busy > 0 {
+			m.busy--
+			return 0, errors.New("no acknowledge")
+		}
+		m.pointer = int(b[0])
+		if addressBytes == 2 {
+			m.pointer = int(b[0])<<8 | int(b[1])
+		}
+		data := b[addressBytes:]
+		if len(data) > 0 {
+			m.writes = append(m.writes, append([]byte{}, data...))
+			for _, v := range data {
+				m.mem[m.pointer%len(m.mem)] = v
+				m.pointer++
+			}
+			m.busy = m.busyWrites
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		for i := range b {
+			pos := m.pointer % len(m.mem)
+			b[i] = m.mem[pos]
+			if v, ok := m.fixed[pos]; ok {
+				b[i] = v
+			}
+			m.pointer++
+		}
+		return len(b), nil
+	}
+	return m
+}
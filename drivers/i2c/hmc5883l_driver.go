@@ -5,6 +5,9 @@ import (
 	"log"
 	"math"
 	"sort"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/imu"
 )
 
 const (
@@ -213,6 +216,17 @@ func (h *HMC5883LDriver) Read() (x float64, y float64, z float64, err error) {
 	return float64(xr) / h.gain, float64(yr) / h.gain, float64(zr) / h.gain, nil
 }
 
+// ReadMagneticField implements the imu.Magnetometer interface, unit [µT]
+func (h *HMC5883LDriver) ReadMagneticField() (imu.Sample, error) {
+	x, y, z, err := h.Read()
+	if err != nil {
+		return imu.Sample{}, err
+	}
+	// 1 Gauss = 100 µT
+	v := imu.Vector{X: x, Y: y, Z: z}
+	return imu.Sample{Vector: v.Scale(100), Time: time.Now()}, nil
+}
+
 // Heading returns the current heading in radians
 func (h *HMC5883LDriver) Heading() (heading float64, err error) {
 	h.mutex.Lock()
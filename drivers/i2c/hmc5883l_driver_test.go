@@ -5,12 +5,14 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*HMC5883LDriver)(nil)
+var _ imu.Magnetometer = (*HMC5883LDriver)(nil)
 
 func initTestHMC5883LWithStubbedAdaptor() (*HMC5883LDriver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
@@ -129,6 +131,24 @@ func TestHMC5883LRead(t *testing.T) {
 	}
 }
 
+func TestHMC5883LReadMagneticField(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewHMC5883LDriver(a, WithHMC5883LGain(1090))
+	d.Start()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// x, z, y
+		copy(b, []byte{0x04, 0x42, 0xFB, 0xBE, 0x00, 0x00})
+		return len(b), nil
+	}
+	// act
+	got, err := d.ReadMagneticField()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got.Vector, imu.Vector{X: 100, Y: 0, Z: -100})
+	gobottest.Assert(t, got.Time.IsZero(), false)
+}
+
 func TestHMC5883L_readRawData(t *testing.T) {
 	// sequence to read:
 	// * prepare read, see test of Start()
@@ -0,0 +1,23 @@
+package i2c
+
+import (
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/system"
+)
+
+// attachHostInterrupt configures the host pin, which is wired to the interrupt output of a device, as input
+// and calls the handler on each active edge. The host pin needs to support edge detection.
+func attachHostInterrupt(host gobot.DigitalPinnerProvider, pinID string, activeHigh bool, handler func()) error {
+	edgeHandler := func(int, time.Duration, string, uint32, uint32) { handler() }
+	edgeOption := system.WithPinEventOnFallingEdge(edgeHandler)
+	if activeHigh {
+		edgeOption = system.WithPinEventOnRisingEdge(edgeHandler)
+	}
+	pin, err := host.DigitalPin(pinID)
+	if err != nil {
+		return err
+	}
+	return pin.ApplyOptions(system.WithPinDirectionInput(), edgeOption)
+}
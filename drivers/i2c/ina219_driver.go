@@ -0,0 +1,167 @@
+package i2c
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/power"
+)
+
+// INA219Driver is a driver for the Texas Instruments INA219 device. The INA219 is a current and bus voltage
+// monitor with one channel and an I2C and SMBUS compatible interface.
+//
+// INA219 data sheet and specifications can be found at http://www.ti.com/product/INA219
+
+const ina219Debug = false
+
+const (
+	ina219DefaultAddress             = 0x40   // 1000000 (A0+A1=GND)
+	ina219RegConfig          uint8   = 0x00   // CONFIGURATION REGISTER (R/W)
+	ina219RegShuntVoltage    uint8   = 0x01   // SHUNT VOLTAGE REGISTER (R)
+	ina219RegBusVoltage      uint8   = 0x02   // BUS VOLTAGE REGISTER (R)
+	ina219ConfigDefault      uint16  = 0x399F // 32V range, +-320mV shunt range, 12 bit, continuous shunt and bus
+	ina219BusVoltageOverflow uint16  = 0x0001 // Math overflow flag
+	ina219ShuntResistorValue float64 = 0.1    // default shunt resistor value of 0.1 Ohm
+)
+
+// INA219Driver is a driver for the INA219 current and bus voltage monitoring device.
+type INA219Driver struct {
+	*Driver
+	shuntResistor float64
+}
+
+// NewINA219Driver creates a new driver with the specified i2c interface.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):		bus to use with this driver
+//		i2c.WithAddress(int):		address to use with this driver
+//		i2c.WithINA219ShuntResistor(float64):	resistance of the shunt in Ohm
+func NewINA219Driver(c Connector, options ...func(Config)) *INA219Driver {
+	d := &INA219Driver{
+		Driver:        NewDriver(c, "INA219", ina219DefaultAddress),
+		shuntResistor: ina219ShuntResistorValue,
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithINA219ShuntResistor option sets the resistance of the shunt in Ohm, the default is 0.1 Ohm.
+func WithINA219ShuntResistor(ohm float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA219Driver)
+		if ok {
+			d.shuntResistor = ohm
+		} else if ina219Debug {
+			log.Printf("Trying to set shunt resistor for non-INA219Driver %v", c)
+		}
+	}
+}
+
+// GetBusVoltage gets the bus voltage in Volts
+func (d *INA219Driver) GetBusVoltage() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.busVoltage()
+}
+
+// GetShuntVoltage gets the shunt voltage in mV
+func (d *INA219Driver) GetShuntVoltage() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.shuntVoltage()
+}
+
+// GetCurrent gets the current value in mA
+func (d *INA219Driver) GetCurrent() (float64, error) {
+	sv, err := d.GetShuntVoltage()
+	if err != nil {
+		return 0, err
+	}
+
+	return sv / d.shuntResistor, nil
+}
+
+// GetLoadVoltage gets the load voltage in Volts
+func (d *INA219Driver) GetLoadVoltage() (float64, error) {
+	m, err := d.ReadChannel(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.LoadVoltage(), nil
+}
+
+// Channels returns the number of channels and implements the power.Sensor interface
+func (d *INA219Driver) Channels() int { return 1 }
+
+// ReadChannel measures the only channel 1 and implements the power.Sensor interface
+func (d *INA219Driver) ReadChannel(channel int) (power.Measurement, error) {
+	if channel != 1 {
+		return power.Measurement{}, errors.New("channel must be 1")
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	bv, err := d.busVoltage()
+	if err != nil {
+		return power.Measurement{}, err
+	}
+	sv, err := d.shuntVoltage()
+	if err != nil {
+		return power.Measurement{}, err
+	}
+	return power.Measurement{
+		Time:         time.Now(),
+		Channel:      1,
+		BusVoltage:   bv,
+		ShuntVoltage: sv,
+		Current:      sv / d.shuntResistor,
+	}, nil
+}
+
+func (d *INA219Driver) busVoltage() (float64, error) {
+	val, err := d.readWordBigEndian(ina219RegBusVoltage)
+	if err != nil {
+		return 0, err
+	}
+	if val&ina219BusVoltageOverflow != 0 {
+		return 0, errors.New("overflow of the current or power calculation")
+	}
+
+	// the lowest 3 bits are flags, 4mV per LSB
+	return float64(val>>3) * 0.004, nil
+}
+
+func (d *INA219Driver) shuntVoltage() (float64, error) {
+	val, err := d.readWordBigEndian(ina219RegShuntVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	// 10uV per LSB
+	return float64(int16(val)) * 0.01, nil
+}
+
+func (d *INA219Driver) readWordBigEndian(reg uint8) (uint16, error) {
+	val, err := d.connection.ReadWordData(reg)
+	if err != nil {
+		return 0, err
+	}
+	return swapBytes(val), nil
+}
+
+// initialize initializes the INA219 device
+func (d *INA219Driver) initialize() error {
+	return d.connection.WriteWordData(ina219RegConfig, swapBytes(ina219ConfigDefault))
+}
@@ -0,0 +1,114 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/power"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*INA219Driver)(nil)
+
+var _ power.Sensor = (*INA219Driver)(nil)
+
+func initTestINA219DriverWithStubbedAdaptor() (*INA219Driver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func simulateINA219(a *i2cTestAdaptor, bus []byte, shunt []byte) {
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		switch a.written[len(a.written)-1] {
+		case ina219RegBusVoltage:
+			copy(b, bus)
+		case ina219RegShuntVoltage:
+			copy(b, shunt)
+		}
+		return 2, nil
+	}
+}
+
+func TestNewINA219Driver(t *testing.T) {
+	var di interface{} = NewINA219Driver(newI2cTestAdaptor())
+	d, ok := di.(*INA219Driver)
+	if !ok {
+		t.Error("NewINA219Driver() should return a *INA219Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "INA219"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x40)
+	gobottest.Assert(t, d.shuntResistor, 0.1)
+}
+
+func TestINA219Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewINA219Driver(newI2cTestAdaptor(), WithBus(2), WithINA219ShuntResistor(0.01))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.shuntResistor, 0.01)
+}
+
+func TestINA219Start(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, a.written, []byte{ina219RegConfig, 0x39, 0x9F})
+}
+
+func TestINA219Measurements(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	// 12V with conversion ready flag, 50mV
+	simulateINA219(a, []byte{0x5D, 0xC2}, []byte{0x13, 0x88})
+
+	v, err := d.GetBusVoltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 12.0)
+	v, err = d.GetShuntVoltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 50.0)
+	v, err = d.GetCurrent()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 500.0)
+	v, err = d.GetLoadVoltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 12.05)
+
+	// negative shunt voltage of -10mV
+	simulateINA219(a, []byte{0x5D, 0xC2}, []byte{0xFC, 0x18})
+	m, err := d.ReadChannel(1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.Channel, 1)
+	gobottest.Assert(t, m.Current, -100.0)
+
+	_, err = d.ReadChannel(2)
+	gobottest.Assert(t, err, errors.New("channel must be 1"))
+}
+
+func TestINA219Overflow(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	simulateINA219(a, []byte{0x5D, 0xC3}, []byte{0x13, 0x88})
+
+	_, err := d.GetBusVoltage()
+	gobottest.Assert(t, err, errors.New("overflow of the current or power calculation"))
+}
+
+func TestINA219ReadError(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+
+	_, err := d.GetCurrent()
+	gobottest.Assert(t, err, errors.New("read error"))
+	_, err = d.ReadChannel(1)
+	gobottest.Assert(t, err, errors.New("read error"))
+}
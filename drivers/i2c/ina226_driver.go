@@ -0,0 +1,235 @@
+package i2c
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/power"
+)
+
+// INA226Driver is a driver for the Texas Instruments INA226 device. The INA226 is a current and bus voltage
+// monitor with one channel, an alert pin and an I2C and SMBUS compatible interface.
+//
+// INA226 data sheet and specifications can be found at http://www.ti.com/product/INA226
+
+const ina226Debug = false
+
+const (
+	ina226DefaultAddress               = 0x40   // 1000000 (A0+A1=GND)
+	ina226RegConfig            uint8   = 0x00   // CONFIGURATION REGISTER (R/W)
+	ina226RegShuntVoltage      uint8   = 0x01   // SHUNT VOLTAGE REGISTER (R)
+	ina226RegBusVoltage        uint8   = 0x02   // BUS VOLTAGE REGISTER (R)
+	ina226RegMaskEnable        uint8   = 0x06   // MASK/ENABLE REGISTER (R/W)
+	ina226RegAlertLimit        uint8   = 0x07   // ALERT LIMIT REGISTER (R/W)
+	ina226ConfigDefault        uint16  = 0x4127 // 1 average, 1.1ms conversion time, continuous shunt and bus
+	ina226MaskShuntOverVoltage uint16  = 0x8000 // Alert on shunt voltage over the limit
+	ina226MaskBusUnderVoltage  uint16  = 0x1000 // Alert on bus voltage under the limit
+	ina226MaskAlertFlag        uint16  = 0x0010 // Alert function flag
+	ina226MaskLatch            uint16  = 0x0001 // Latch the alert flag until the register is read
+	ina226ShuntResistorValue   float64 = 0.1    // default shunt resistor value of 0.1 Ohm
+)
+
+// INA226Driver is a driver for the INA226 current and bus voltage monitoring device.
+type INA226Driver struct {
+	*Driver
+	shuntResistor float64
+	alertKind     power.AlertKind
+	alertLimit    float64
+}
+
+// NewINA226Driver creates a new driver with the specified i2c interface.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):		bus to use with this driver
+//		i2c.WithAddress(int):		address to use with this driver
+//		i2c.WithINA226ShuntResistor(float64):	resistance of the shunt in Ohm
+func NewINA226Driver(c Connector, options ...func(Config)) *INA226Driver {
+	d := &INA226Driver{
+		Driver:        NewDriver(c, "INA226", ina226DefaultAddress),
+		shuntResistor: ina226ShuntResistorValue,
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithINA226ShuntResistor option sets the resistance of the shunt in Ohm, the default is 0.1 Ohm.
+func WithINA226ShuntResistor(ohm float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA226Driver)
+		if ok {
+			d.shuntResistor = ohm
+		} else if ina226Debug {
+			log.Printf("Trying to set shunt resistor for non-INA226Driver %v", c)
+		}
+	}
+}
+
+// GetBusVoltage gets the bus voltage in Volts
+func (d *INA226Driver) GetBusVoltage() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.busVoltage()
+}
+
+// GetShuntVoltage gets the shunt voltage in mV
+func (d *INA226Driver) GetShuntVoltage() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.shuntVoltage()
+}
+
+// GetCurrent gets the current value in mA
+func (d *INA226Driver) GetCurrent() (float64, error) {
+	sv, err := d.GetShuntVoltage()
+	if err != nil {
+		return 0, err
+	}
+
+	return sv / d.shuntResistor, nil
+}
+
+// GetLoadVoltage gets the load voltage in Volts
+func (d *INA226Driver) GetLoadVoltage() (float64, error) {
+	m, err := d.ReadChannel(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.LoadVoltage(), nil
+}
+
+// Channels returns the number of channels and implements the power.Sensor interface
+func (d *INA226Driver) Channels() int { return 1 }
+
+// ReadChannel measures the only channel 1 and implements the power.Sensor interface
+func (d *INA226Driver) ReadChannel(channel int) (power.Measurement, error) {
+	if channel != 1 {
+		return power.Measurement{}, errors.New("channel must be 1")
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	bv, err := d.busVoltage()
+	if err != nil {
+		return power.Measurement{}, err
+	}
+	sv, err := d.shuntVoltage()
+	if err != nil {
+		return power.Measurement{}, err
+	}
+	return power.Measurement{
+		Time:         time.Now(),
+		Channel:      1,
+		BusVoltage:   bv,
+		ShuntVoltage: sv,
+		Current:      sv / d.shuntResistor,
+	}, nil
+}
+
+// SetOverCurrentAlert configures the alert pin to be asserted, when the current in mA is above the limit.
+// Only one alert function can be active, so a former under-voltage alert is replaced.
+func (d *INA226Driver) SetOverCurrentAlert(current float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// 2.5uV per LSB
+	limit := math.Round(current * d.shuntResistor / 0.0025)
+	limit = math.Max(-32768, math.Min(32767, limit))
+	return d.setAlert(ina226MaskShuntOverVoltage, uint16(int16(limit)), power.OverCurrent, current)
+}
+
+// SetUnderVoltageAlert configures the alert pin to be asserted, when the bus voltage in Volts is below the
+// limit. Only one alert function can be active, so a former over-current alert is replaced.
+func (d *INA226Driver) SetUnderVoltageAlert(volts float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// 1.25mV per LSB
+	limit := math.Round(volts / 0.00125)
+	limit = math.Max(0, math.Min(0x7FFF, limit))
+	return d.setAlert(ina226MaskBusUnderVoltage, uint16(limit), power.UnderVoltage, volts)
+}
+
+// DisableAlert disables the alert function
+func (d *INA226Driver) DisableAlert() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setAlert(0, 0, "", 0)
+}
+
+// ReadAlerts reads the alert function flag and implements the power.AlertSource interface. The flag is
+// latched until this read.
+func (d *INA226Driver) ReadAlerts() ([]power.AlertData, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	flags, err := d.readWordBigEndian(ina226RegMaskEnable)
+	if err != nil {
+		return nil, err
+	}
+	if d.alertKind == "" || flags&ina226MaskAlertFlag == 0 {
+		return nil, nil
+	}
+	return []power.AlertData{{Time: time.Now(), Channel: 1, Kind: d.alertKind, Limit: d.alertLimit}}, nil
+}
+
+func (d *INA226Driver) setAlert(mask uint16, limit uint16, kind power.AlertKind, value float64) error {
+	if err := d.connection.WriteWordData(ina226RegAlertLimit, swapBytes(limit)); err != nil {
+		return err
+	}
+	if mask != 0 {
+		mask |= ina226MaskLatch
+	}
+	if err := d.connection.WriteWordData(ina226RegMaskEnable, swapBytes(mask)); err != nil {
+		return err
+	}
+	d.alertKind = kind
+	d.alertLimit = value
+	return nil
+}
+
+func (d *INA226Driver) busVoltage() (float64, error) {
+	val, err := d.readWordBigEndian(ina226RegBusVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	// 1.25mV per LSB
+	return float64(val) * 0.00125, nil
+}
+
+func (d *INA226Driver) shuntVoltage() (float64, error) {
+	val, err := d.readWordBigEndian(ina226RegShuntVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	// 2.5uV per LSB
+	return float64(int16(val)) * 0.0025, nil
+}
+
+func (d *INA226Driver) readWordBigEndian(reg uint8) (uint16, error) {
+	val, err := d.connection.ReadWordData(reg)
+	if err != nil {
+		return 0, err
+	}
+	return swapBytes(val), nil
+}
+
+// initialize initializes the INA226 device
+func (d *INA226Driver) initialize() error {
+	return d.connection.WriteWordData(ina226RegConfig, swapBytes(ina226ConfigDefault))
+}
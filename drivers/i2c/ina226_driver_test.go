@@ -0,0 +1,120 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/power"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*INA226Driver)(nil)
+
+var _ power.Sensor = (*INA226Driver)(nil)
+var _ power.AlertSource = (*INA226Driver)(nil)
+
+func initTestINA226DriverWithStubbedAdaptor() (*INA226Driver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	d := NewINA226Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewINA226Driver(t *testing.T) {
+	var di interface{} = NewINA226Driver(newI2cTestAdaptor())
+	d, ok := di.(*INA226Driver)
+	if !ok {
+		t.Error("NewINA226Driver() should return a *INA226Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "INA226"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x40)
+	gobottest.Assert(t, d.shuntResistor, 0.1)
+}
+
+func TestINA226Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewINA226Driver(newI2cTestAdaptor(), WithBus(2), WithINA226ShuntResistor(0.002))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.shuntResistor, 0.002)
+}
+
+func TestINA226Start(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewINA226Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, a.written, []byte{ina226RegConfig, 0x41, 0x27})
+}
+
+func TestINA226Measurements(t *testing.T) {
+	d, a := initTestINA226DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		switch a.written[len(a.written)-1] {
+		case ina226RegBusVoltage:
+			// 12V
+			copy(b, []byte{0x25, 0x80})
+		case ina226RegShuntVoltage:
+			// 5mV
+			copy(b, []byte{0x07, 0xD0})
+		}
+		return 2, nil
+	}
+
+	v, err := d.GetBusVoltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 12.0)
+	v, err = d.GetShuntVoltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 5.0)
+	v, err = d.GetCurrent()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 50.0)
+	v, err = d.GetLoadVoltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, v, 12.005)
+
+	_, err = d.ReadChannel(0)
+	gobottest.Assert(t, err, errors.New("channel must be 1"))
+}
+
+func TestINA226Alerts(t *testing.T) {
+	d, a := initTestINA226DriverWithStubbedAdaptor()
+
+	// 500mA at 0.1 Ohm is 50mV, 2.5uV per LSB
+	a.written = []byte{}
+	gobottest.Assert(t, d.SetOverCurrentAlert(500), nil)
+	gobottest.Assert(t, a.written, []byte{ina226RegAlertLimit, 0x4E, 0x20, ina226RegMaskEnable, 0x80, 0x01})
+
+	flags := []byte{0x80, 0x11}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, flags)
+		return 2, nil
+	}
+	alerts, err := d.ReadAlerts()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(alerts), 1)
+	gobottest.Assert(t, alerts[0].Kind, power.OverCurrent)
+	gobottest.Assert(t, alerts[0].Limit, 500.0)
+
+	// 11V, 1.25mV per LSB
+	a.written = []byte{}
+	gobottest.Assert(t, d.SetUnderVoltageAlert(11), nil)
+	gobottest.Assert(t, a.written, []byte{ina226RegAlertLimit, 0x22, 0x60, ina226RegMaskEnable, 0x10, 0x01})
+	flags = []byte{0x10, 0x01}
+	alerts, err = d.ReadAlerts()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(alerts), 0)
+
+	gobottest.Assert(t, d.DisableAlert(), nil)
+	flags = []byte{0x00, 0x10}
+	alerts, err = d.ReadAlerts()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(alerts), 0)
+}
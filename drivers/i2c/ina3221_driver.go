@@ -1,5 +1,14 @@
 package i2c
 
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/power"
+)
+
 // INA3221Driver is a driver for the Texas Instruments INA3221 device. The INA3221 is a three-channel
 // current and bus voltage monitor with an I2C and SMBUS compatible interface.
 //
@@ -10,6 +19,8 @@ package i2c
 // INA3221Channel type that defines which INA3221 channel to read from.
 type INA3221Channel uint8
 
+const ina3221Debug = false
+
 const (
 	ina3221DefaultAddress             = 0x40 // 1000000 (A0+A1=GND)
 	ina3221Read               uint8   = 0x01
@@ -32,6 +43,11 @@ const (
 	ina3221ConfigMode0        uint16  = 0x0001 // Operating Mode bit 0 - See table 6 spec
 	ina3221RegShuntVoltage1   uint8   = 0x01   // SHUNT VOLTAGE REGISTER (R)
 	ina3221RegBusVoltage1     uint8   = 0x02   // BUS VOLTAGE REGISTER (R)
+	ina3221RegCriticalLimit1  uint8   = 0x07   // CHANNEL-1 CRITICAL-ALERT LIMIT REGISTER (R/W)
+	ina3221RegWarningLimit1   uint8   = 0x08   // CHANNEL-1 WARNING-ALERT LIMIT REGISTER (R/W)
+	ina3221RegMaskEnable      uint8   = 0x0F   // MASK/ENABLE REGISTER (R/W)
+	ina3221MaskCriticalFlag1  uint16  = 0x0200 // Critical-alert flag indicator of channel 1
+	ina3221MaskWarningFlag1   uint16  = 0x0020 // Warning-alert flag indicator of channel 1
 	ina3221ShuntResistorValue float64 = 0.1    // default shunt resistor value of 0.1 Ohm
 
 	INA3221Channel1 INA3221Channel = 1
@@ -42,7 +58,10 @@ const (
 // INA3221Driver is a driver for the INA3221 three-channel current and bus voltage monitoring device.
 type INA3221Driver struct {
 	*Driver
-	halt chan bool
+	halt          chan bool
+	shuntResistor float64
+	criticalLimit [3]float64
+	warningLimit  [3]float64
 }
 
 // NewINA3221Driver creates a new driver with the specified i2c interface.
@@ -52,9 +71,11 @@ type INA3221Driver struct {
 // Optional params:
 //		i2c.WithBus(int):		bus to use with this driver
 //		i2c.WithAddress(int):		address to use with this driver
+//		i2c.WithINA3221ShuntResistor(float64):	resistance of the shunts in Ohm
 func NewINA3221Driver(c Connector, options ...func(Config)) *INA3221Driver {
 	i := &INA3221Driver{
-		Driver: NewDriver(c, "INA3221", ina3221DefaultAddress),
+		Driver:        NewDriver(c, "INA3221", ina3221DefaultAddress),
+		shuntResistor: ina3221ShuntResistorValue,
 	}
 	i.afterStart = i.initialize
 
@@ -65,6 +86,18 @@ func NewINA3221Driver(c Connector, options ...func(Config)) *INA3221Driver {
 	return i
 }
 
+// WithINA3221ShuntResistor option sets the resistance of the shunts in Ohm, the default is 0.1 Ohm.
+func WithINA3221ShuntResistor(ohm float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA3221Driver)
+		if ok {
+			d.shuntResistor = ohm
+		} else if ina3221Debug {
+			log.Printf("Trying to set shunt resistor for non-INA3221Driver %v", c)
+		}
+	}
+}
+
 // GetBusVoltage gets the bus voltage in Volts
 func (i *INA3221Driver) GetBusVoltage(channel INA3221Channel) (float64, error) {
 	value, err := i.getBusVoltageRaw(channel)
@@ -92,7 +125,7 @@ func (i *INA3221Driver) GetCurrent(channel INA3221Channel) (float64, error) {
 		return 0, err
 	}
 
-	ma := value / ina3221ShuntResistorValue
+	ma := value / i.shuntResistor
 	return ma, nil
 }
 
@@ -111,6 +144,88 @@ func (i *INA3221Driver) GetLoadVoltage(channel INA3221Channel) (float64, error)
 	return bv + (sv / 1000.0), nil
 }
 
+// Channels returns the number of channels and implements the power.Sensor interface
+func (i *INA3221Driver) Channels() int { return 3 }
+
+// ReadChannel measures the given channel (1..3) and implements the power.Sensor interface
+func (i *INA3221Driver) ReadChannel(channel int) (power.Measurement, error) {
+	if channel < 1 || channel > 3 {
+		return power.Measurement{}, errors.New("channel must be in range 1..3")
+	}
+	ch := INA3221Channel(channel)
+	bv, err := i.GetBusVoltage(ch)
+	if err != nil {
+		return power.Measurement{}, err
+	}
+	sv, err := i.GetShuntVoltage(ch)
+	if err != nil {
+		return power.Measurement{}, err
+	}
+	return power.Measurement{
+		Time:         time.Now(),
+		Channel:      channel,
+		BusVoltage:   bv,
+		ShuntVoltage: sv,
+		Current:      sv / i.shuntResistor,
+	}, nil
+}
+
+// SetCriticalAlertLimit sets the current limit in mA of the critical alert for the given channel. The
+// critical alert is compared with each conversion and drives the CRITICAL pin.
+func (i *INA3221Driver) SetCriticalAlertLimit(channel INA3221Channel, current float64) error {
+	if err := i.writeLimit(ina3221RegCriticalLimit1, channel, current); err != nil {
+		return err
+	}
+	i.criticalLimit[channel-1] = current
+	return nil
+}
+
+// SetWarningAlertLimit sets the current limit in mA of the warning alert for the given channel. The warning
+// alert is compared with the averaged values and drives the WARNING pin.
+func (i *INA3221Driver) SetWarningAlertLimit(channel INA3221Channel, current float64) error {
+	if err := i.writeLimit(ina3221RegWarningLimit1, channel, current); err != nil {
+		return err
+	}
+	i.warningLimit[channel-1] = current
+	return nil
+}
+
+// ReadAlerts reads the flags of the critical and warning alerts and implements the power.AlertSource
+// interface. The warning flags are cleared by the read.
+func (i *INA3221Driver) ReadAlerts() ([]power.AlertData, error) {
+	flags, err := i.readWordFromRegister(ina3221RegMaskEnable)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var alerts []power.AlertData
+	for ch := 0; ch < 3; ch++ {
+		if flags&(ina3221MaskCriticalFlag1>>uint(ch)) != 0 {
+			alerts = append(alerts, power.AlertData{Time: now, Channel: ch + 1, Kind: power.Critical,
+				Limit: i.criticalLimit[ch]})
+		}
+		if flags&(ina3221MaskWarningFlag1>>uint(ch)) != 0 {
+			alerts = append(alerts, power.AlertData{Time: now, Channel: ch + 1, Kind: power.Warning,
+				Limit: i.warningLimit[ch]})
+		}
+	}
+	return alerts, nil
+}
+
+// writeLimit writes the current limit to the limit register of the channel, the register contains the
+// shunt voltage with the same format as the shunt voltage register
+func (i *INA3221Driver) writeLimit(reg uint8, channel INA3221Channel, current float64) error {
+	if channel < INA3221Channel1 || channel > INA3221Channel3 {
+		return errors.New("channel must be in range 1..3")
+	}
+	// 40uV per LSB, the lowest 3 bits are unused
+	lsb := math.Round(current * i.shuntResistor / 0.04)
+	lsb = math.Max(-4096, math.Min(4095, lsb))
+	value := uint16(int16(lsb) << 3)
+	return i.connection.WriteBlockData(reg+(uint8(channel)-1)*2, []byte{byte(value >> 8), byte(value & 0x00FF)})
+}
+
 // getBusVoltageRaw gets the raw bus voltage (16-bit signed integer, so +-32767)
 func (i *INA3221Driver) getBusVoltageRaw(channel INA3221Channel) (int16, error) {
 	val, err := i.readWordFromRegister(ina3221RegBusVoltage1 + (uint8(channel)-1)*2)
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/power"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -15,6 +16,9 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*INA3221Driver)(nil)
 
+var _ power.Sensor = (*INA3221Driver)(nil)
+var _ power.AlertSource = (*INA3221Driver)(nil)
+
 func initTestINA3221DriverWithStubbedAdaptor() (*INA3221Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
 	d := NewINA3221Driver(a)
@@ -38,8 +42,9 @@ func TestNewINA3221Driver(t *testing.T) {
 func TestINA3221Options(t *testing.T) {
 	// This is a general test, that options are applied in constructor by using the common WithBus() option and
 	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
-	d := NewINA3221Driver(newI2cTestAdaptor(), WithBus(2))
+	d := NewINA3221Driver(newI2cTestAdaptor(), WithBus(2), WithINA3221ShuntResistor(0.05))
 	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.shuntResistor, 0.05)
 }
 
 func TestINA3221Start(t *testing.T) {
@@ -145,3 +150,68 @@ func TestINA3221GetLoadVoltageReadError(t *testing.T) {
 	_, err := d.GetLoadVoltage(INA3221Channel2)
 	gobottest.Assert(t, err, errors.New("read error"))
 }
+
+func TestINA3221ReadChannel(t *testing.T) {
+	d, a := initTestINA3221DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		switch a.written[len(a.written)-1] {
+		case ina3221RegBusVoltage1 + 2:
+			copy(b, []byte{0x36, 0x68})
+		case ina3221RegShuntVoltage1 + 2:
+			copy(b, []byte{0x05, 0xD8})
+		}
+		return 2, nil
+	}
+
+	m, err := d.ReadChannel(2)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.Channel, 2)
+	gobottest.Assert(t, m.BusVoltage, 13.928)
+	gobottest.Assert(t, m.ShuntVoltage, 7.48)
+	gobottest.Assert(t, m.Current, 74.8)
+	gobottest.Assert(t, d.Channels(), 3)
+
+	_, err = d.ReadChannel(4)
+	gobottest.Assert(t, err, errors.New("channel must be in range 1..3"))
+}
+
+func TestINA3221SetAlertLimits(t *testing.T) {
+	d, a := initTestINA3221DriverWithStubbedAdaptor()
+	a.written = []byte{}
+
+	// 500mA at 0.1 Ohm is 50mV, 40uV per LSB and shifted by 3
+	gobottest.Assert(t, d.SetCriticalAlertLimit(INA3221Channel2, 500), nil)
+	gobottest.Assert(t, a.written, []byte{0x09, 0x27, 0x10})
+
+	a.written = []byte{}
+	gobottest.Assert(t, d.SetWarningAlertLimit(INA3221Channel1, 100), nil)
+	gobottest.Assert(t, a.written, []byte{0x08, 0x07, 0xD0})
+
+	gobottest.Assert(t, d.SetWarningAlertLimit(INA3221Channel(0), 100), errors.New("channel must be in range 1..3"))
+}
+
+func TestINA3221ReadAlerts(t *testing.T) {
+	d, a := initTestINA3221DriverWithStubbedAdaptor()
+	_ = d.SetCriticalAlertLimit(INA3221Channel1, 500)
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// critical flag of channel 1 and warning flag of channel 2
+		copy(b, []byte{0x02, 0x10})
+		return 2, nil
+	}
+
+	alerts, err := d.ReadAlerts()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.written[len(a.written)-1], ina3221RegMaskEnable)
+	gobottest.Assert(t, len(alerts), 2)
+	gobottest.Assert(t, alerts[0].Channel, 1)
+	gobottest.Assert(t, alerts[0].Kind, power.Critical)
+	gobottest.Assert(t, alerts[0].Limit, 500.0)
+	gobottest.Assert(t, alerts[1].Channel, 2)
+	gobottest.Assert(t, alerts[1].Kind, power.Warning)
+
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = d.ReadAlerts()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -272,6 +272,17 @@ func (h *JHD1313M1Driver) SetPosition(pos int) (err error) {
 	return
 }
 
+// Size returns the number of columns and rows of the display, to conform to the gpio.CharacterDisplay interface.
+func (h *JHD1313M1Driver) Size() (int, int) { return 16, 2 }
+
+// SetCursor sets the cursor to the given column and row, to conform to the gpio.CharacterDisplay interface.
+func (h *JHD1313M1Driver) SetCursor(col int, row int) error {
+	if col < 0 || col > 15 || row < 0 || row > 1 {
+		return jhd1313m1ErrInvalidPosition
+	}
+	return h.SetPosition(row*16 + col)
+}
+
 // Scroll sets the scrolling direction for the display, either left to right, or
 // right to left.
 func (h *JHD1313M1Driver) Scroll(lr bool) error {
@@ -307,6 +318,11 @@ func (h *JHD1313M1Driver) SetCustomChar(pos int, charMap [8]byte) error {
 	return err
 }
 
+// CreateChar is the same as SetCustomChar, to conform to the gpio.CharacterDisplay interface.
+func (h *JHD1313M1Driver) CreateChar(pos int, charMap [8]byte) error {
+	return h.SetCustomChar(pos, charMap)
+}
+
 func (h *JHD1313M1Driver) setReg(command int, data int) error {
 	_, err := h.rgbConnection.Write([]byte{byte(command), byte(data)})
 	return err
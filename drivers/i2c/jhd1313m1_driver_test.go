@@ -6,11 +6,15 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*JHD1313M1Driver)(nil)
 
+// and also the CharacterDisplay interface
+var _ gpio.CharacterDisplay = (*JHD1313M1Driver)(nil)
+
 // --------- HELPERS
 func initTestJHD1313M1Driver() (driver *JHD1313M1Driver) {
 	driver, _ = initTestJHD1313M1DriverWithStubbedAdaptor()
@@ -166,6 +170,19 @@ func TestJHD1313MDriverSetPositionInvalid(t *testing.T) {
 	gobottest.Assert(t, d.SetPosition(32), jhd1313m1ErrInvalidPosition)
 }
 
+func TestJHD1313MDriverSetCursor(t *testing.T) {
+	d, a := initTestJHD1313M1DriverWithStubbedAdaptor()
+	d.Start()
+	a.written = []byte{}
+	gobottest.Assert(t, d.SetCursor(2, 1), nil)
+	gobottest.Assert(t, a.written, []byte{LCD_CMD, LCD_SETDDRAMADDR | LCD_2NDLINEOFFSET | 2})
+	gobottest.Assert(t, d.SetCursor(16, 0), jhd1313m1ErrInvalidPosition)
+	gobottest.Assert(t, d.SetCursor(0, 2), jhd1313m1ErrInvalidPosition)
+	cols, rows := d.Size()
+	gobottest.Assert(t, cols, 16)
+	gobottest.Assert(t, rows, 2)
+}
+
 func TestJHD1313MDriverScroll(t *testing.T) {
 	d, _ := initTestJHD1313M1DriverWithStubbedAdaptor()
 	d.Start()
@@ -185,6 +202,15 @@ func TestJHD1313MDriverSetCustomChar(t *testing.T) {
 	gobottest.Assert(t, d.SetCustomChar(0, data), nil)
 }
 
+func TestJHD1313MDriverCreateChar(t *testing.T) {
+	d, a := initTestJHD1313M1DriverWithStubbedAdaptor()
+	d.Start()
+	a.written = []byte{}
+	gobottest.Assert(t, d.CreateChar(1, CustomLCDChars["heart"]), nil)
+	gobottest.Assert(t, a.written[:2], []byte{LCD_CMD, LCD_SETCGRAMADDR | 0x08})
+	gobottest.Refute(t, d.CreateChar(8, CustomLCDChars["heart"]), nil)
+}
+
 func TestJHD1313MDriverSetCustomCharError(t *testing.T) {
 	d, _ := initTestJHD1313M1DriverWithStubbedAdaptor()
 	data := [8]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
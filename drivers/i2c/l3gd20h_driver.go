@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"log"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/imu"
 )
 
 const (
@@ -133,6 +137,16 @@ func (d *L3GD20HDriver) XYZ() (x float32, y float32, z float32, err error) {
 	return float32(rawX) * sensitivity, float32(rawY) * sensitivity, float32(rawZ) * sensitivity, nil
 }
 
+// ReadAngularRate implements the imu.Gyroscope interface, unit [rad/s]
+func (d *L3GD20HDriver) ReadAngularRate() (imu.Sample, error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return imu.Sample{}, err
+	}
+	v := imu.Vector{X: float64(x), Y: float64(y), Z: float64(z)}
+	return imu.Sample{Vector: v.Scale(math.Pi / 180), Time: time.Now()}, nil
+}
+
 func (d *L3GD20HDriver) initialize() (err error) {
 	// reset the gyroscope.
 	if err := d.connection.WriteByteData(l3gd20hReg_Ctl1, 0x00); err != nil {
@@ -2,16 +2,19 @@ package i2c
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*HMC6352Driver)(nil)
+var _ imu.Gyroscope = (*L3GD20HDriver)(nil)
 
 func initL3GD20HDriver() (driver *L3GD20HDriver) {
 	driver, _ = initL3GD20HWithStubbedAdaptor()
@@ -206,6 +209,24 @@ func TestL3GD20HMeasurement(t *testing.T) {
 	}
 }
 
+func TestL3GD20HReadAngularRate(t *testing.T) {
+	// arrange
+	d, a := initL3GD20HWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// 8 digits are 0.07 dps for FS=245 dps
+		copy(b, []byte{0x08, 0x00, 0x00, 0x00, 0x00, 0x00})
+		return len(b), nil
+	}
+	// act
+	got, err := d.ReadAngularRate()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(got.X-0.07*math.Pi/180) < 1e-8, true)
+	gobottest.Assert(t, got.Y, 0.0)
+	gobottest.Assert(t, got.Z, 0.0)
+	gobottest.Assert(t, got.Time.IsZero(), false)
+}
+
 func TestL3GD20HMeasurementError(t *testing.T) {
 	d, a := initL3GD20HWithStubbedAdaptor()
 	a.i2cReadImpl = func(b []byte) (int, error) {
@@ -2,11 +2,23 @@ package i2c
 
 import (
 	"time"
+
+	"gobot.io/x/gobot/drivers/common/ranging"
 )
 
-const lidarliteDefaultAddress = 0x62
+const (
+	lidarliteDefaultAddress = 0x62
+
+	lidarliteRegStatus         = 0x01
+	lidarliteRegSignalStrength = 0x0E
+
+	lidarliteStatusInvalidSignal = 0x08 // no peak detected in the correlation record
+	lidarliteStatusHealth        = 0x20 // reference and receiver bias are operational
+	lidarliteStatusProcessError  = 0x40
+)
 
 // LIDARLiteDriver is the Gobot driver for the LIDARLite I2C LIDAR device.
+// The driver implements the ranging.RangeFinder interface.
 type LIDARLiteDriver struct {
 	*Driver
 }
@@ -74,3 +86,49 @@ func (h *LIDARLiteDriver) Distance() (distance int, err error) {
 
 	return
 }
+
+// ReadRange implements the ranging.RangeFinder interface. The distance is measured and the status and the signal
+// strength of the measurement are read afterwards.
+func (h *LIDARLiteDriver) ReadRange() (ranging.Measurement, error) {
+	distance, err := h.Distance()
+	if err != nil {
+		return ranging.Measurement{}, err
+	}
+	status, err := h.readRegister(lidarliteRegStatus)
+	if err != nil {
+		return ranging.Measurement{}, err
+	}
+	strength, err := h.readRegister(lidarliteRegSignalStrength)
+	if err != nil {
+		return ranging.Measurement{}, err
+	}
+
+	m := ranging.Measurement{
+		Time:     time.Now(),
+		Distance: float64(distance) * 10,
+		Status:   ranging.Valid,
+		Signal:   float64(strength),
+	}
+	switch {
+	case status&lidarliteStatusProcessError != 0 || status&lidarliteStatusHealth == 0:
+		m.Status = ranging.HardwareFail
+	case status&lidarliteStatusInvalidSignal != 0:
+		m.Status = ranging.SignalFail
+	}
+	return m, nil
+}
+
+func (h *LIDARLiteDriver) readRegister(reg uint8) (uint8, error) {
+	if _, err := h.connection.Write([]byte{reg}); err != nil {
+		return 0, err
+	}
+	val := []byte{0}
+	bytesRead, err := h.connection.Read(val)
+	if err != nil {
+		return 0, err
+	}
+	if bytesRead != 1 {
+		return 0, ErrNotEnoughBytes
+	}
+	return val[0], nil
+}
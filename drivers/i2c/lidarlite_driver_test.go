@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ranging"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -14,6 +15,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*LIDARLiteDriver)(nil)
 
+var _ ranging.RangeFinder = (*LIDARLiteDriver)(nil)
+
 func initTestLIDARLiteDriver() (driver *LIDARLiteDriver) {
 	driver, _ = initTestLIDARLiteDriverWithStubbedAdaptor()
 	return
@@ -140,3 +143,116 @@ func TestLIDARLiteDriverDistanceError3(t *testing.T) {
 	gobottest.Assert(t, distance, int(0))
 	gobottest.Assert(t, err, errors.New("write error"))
 }
+
+// simulateLIDARLiteRegisters returns the value of the last addressed register on each read
+func simulateLIDARLiteRegisters(a *i2cTestAdaptor, regs map[byte]byte) {
+	var reg byte
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		reg = b[0]
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = regs[reg]
+		return 1, nil
+	}
+}
+
+func TestLIDARLiteDriverReadRange(t *testing.T) {
+	var tests = map[string]struct {
+		status     byte
+		wantStatus ranging.Status
+	}{
+		"valid": {
+			status:     0x20,
+			wantStatus: ranging.Valid,
+		},
+		"invalid_signal": {
+			status:     0x28,
+			wantStatus: ranging.SignalFail,
+		},
+		"health_not_ok": {
+			status:     0x00,
+			wantStatus: ranging.HardwareFail,
+		},
+		"process_error": {
+			status:     0x68,
+			wantStatus: ranging.HardwareFail,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestLIDARLiteDriverWithStubbedAdaptor()
+			simulateLIDARLiteRegisters(a, map[byte]byte{0x0F: 0x01, 0x10: 0x2C, 0x01: tc.status, 0x0E: 0x80})
+			// act
+			m, err := d.ReadRange()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, m.Distance, 3000.0)
+			gobottest.Assert(t, m.Signal, 128.0)
+			gobottest.Assert(t, m.Status, tc.wantStatus)
+			gobottest.Refute(t, m.Time.IsZero(), true)
+		})
+	}
+}
+
+func TestLIDARLiteDriverReadRangeError(t *testing.T) {
+	var tests = map[string]struct {
+		failReg byte
+		readErr error
+		wantErr error
+	}{
+		"distance_read_error": {
+			failReg: 0x0F,
+			readErr: errors.New("read error"),
+			wantErr: errors.New("read error"),
+		},
+		"status_read_error": {
+			failReg: 0x01,
+			readErr: errors.New("read error"),
+			wantErr: errors.New("read error"),
+		},
+		"signal_strength_not_enough_bytes": {
+			failReg: 0x0E,
+			wantErr: ErrNotEnoughBytes,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestLIDARLiteDriverWithStubbedAdaptor()
+			var reg byte
+			a.i2cWriteImpl = func(b []byte) (int, error) {
+				reg = b[0]
+				return len(b), nil
+			}
+			a.i2cReadImpl = func(b []byte) (int, error) {
+				if reg == tc.failReg {
+					return 0, tc.readErr
+				}
+				b[0] = 0x20
+				return 1, nil
+			}
+			// act
+			m, err := d.ReadRange()
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			gobottest.Assert(t, m, ranging.Measurement{})
+		})
+	}
+}
+
+func TestLIDARLiteDriverReadRangeWriteError(t *testing.T) {
+	// arrange
+	d, a := initTestLIDARLiteDriverWithStubbedAdaptor()
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		if b[0] == 0x01 {
+			return 0, errors.New("write error")
+		}
+		return len(b), nil
+	}
+	// act
+	_, err := d.ReadRange()
+	// assert
+	gobottest.Assert(t, err, errors.New("write error"))
+}
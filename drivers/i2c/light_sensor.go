@@ -0,0 +1,23 @@
+package i2c
+
+import "gobot.io/x/gobot"
+
+// LightThreshold event is published with the illuminance in lux, when the light level leaves the range of the
+// thresholds and the interrupt output of the sensor is wired to a host pin
+const LightThreshold = "threshold"
+
+// LightSensor is the interface of the ambient light sensors, implemented by the BH1750, TSL2561, TCS34725 and
+// VEML7700 drivers
+type LightSensor interface {
+	gobot.Driver
+	// Illuminance returns the ambient light in lux
+	Illuminance() (float64, error)
+}
+
+// ColorTemperatureSensor is the interface of the light sensors, which are able to measure the colour, implemented
+// by the TCS34725 driver
+type ColorTemperatureSensor interface {
+	LightSensor
+	// ColorTemperature returns the correlated colour temperature (CCT) in Kelvin
+	ColorTemperature() (float64, error)
+}
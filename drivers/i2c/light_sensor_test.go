@@ -0,0 +1,7 @@
+package i2c
+
+// this ensures that the light sensor drivers implement the common interfaces
+var _ LightSensor = (*BH1750Driver)(nil)
+var _ LightSensor = (*TSL2561Driver)(nil)
+var _ LightSensor = (*VEML7700Driver)(nil)
+var _ ColorTemperatureSensor = (*TCS34725Driver)(nil)
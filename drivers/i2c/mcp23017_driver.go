@@ -56,8 +56,9 @@ type mcp23017Behavior struct {
 // MCP23017Driver contains the driver configuration parameters.
 type MCP23017Driver struct {
 	*Driver
-	mcpConf  mcp23017Config
-	mcpBehav mcp23017Behavior
+	mcpConf   mcp23017Config
+	mcpBehav  mcp23017Behavior
+	interrupt *gpioExpanderInterrupt
 	gobot.Eventer
 }
 
@@ -75,7 +76,10 @@ type MCP23017Driver struct {
 //		i2c.WithMCP23017Haen(int):	MCP23017 haen to use with this driver
 //		i2c.WithMCP23017Odr(int):	MCP23017 odr to use with this driver
 //		i2c.WithMCP23017Intpol(int):	MCP23017 intpol to use with this driver
+//		i2c.WithMCP23017Interrupts(gobot.DigitalPinnerProvider, ...string):	host pins wired to INTA/INTB
 //
+// The driver implements the gpio.DigitalReader and gpio.DigitalWriter interface with pin ids like "A3" or "B7",
+// so it can be used as connection for e.g. gpio.LedDriver and gpio.ButtonDriver.
 func NewMCP23017Driver(c Connector, options ...func(Config)) *MCP23017Driver {
 	d := &MCP23017Driver{
 		Driver:  NewDriver(c, "MCP23017", mcp23017DefaultAddress),
@@ -224,6 +228,24 @@ func WithMCP23017AutoIODirOff(val uint8) func(Config) {
 	}
 }
 
+// WithMCP23017Interrupts option wires the interrupt outputs INTA and INTB to the given host pins, which needs to
+// support edge detection. If only one pin is given, all interrupts are mirrored to this pin. Inputs read by
+// DigitalRead() are cached afterwards and refreshed on interrupt only, so button drivers do not poll the i2c bus.
+// Each change of a cached input is published as event with the pin id as name and the new value as data.
+func WithMCP23017Interrupts(host gobot.DigitalPinnerProvider, pinIDs ...string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP23017Driver)
+		if ok {
+			d.interrupt = newGpioExpanderInterrupt(host, pinIDs...)
+			if len(pinIDs) == 1 {
+				d.mcpConf.mirror = 1
+			}
+		} else if mcp23017Debug {
+			log.Printf("Trying to set interrupts for non-MCP23017Driver %v", c)
+		}
+	}
+}
+
 // SetPinMode set pin mode of a given pin immediately, based on the value:
 // val = 0 output
 // val = 1 input
@@ -306,6 +328,54 @@ func (m *MCP23017Driver) ReadGPIO(pin uint8, portStr string) (val uint8, err err
 	return val, nil
 }
 
+// DigitalWrite implements the gpio.DigitalWriter interface, the pin id consists of port and pin, e.g. "A3".
+func (m *MCP23017Driver) DigitalWrite(id string, val byte) error {
+	port, pin, err := parseGpioExpanderPin(id, "A", "B")
+	if err != nil {
+		return err
+	}
+	return m.WriteGPIO(pin, port, val)
+}
+
+// DigitalRead implements the gpio.DigitalReader interface, the pin id consists of port and pin, e.g. "A3".
+// When interrupts are used, the interrupt on change is activated for the pin and the cached value is returned.
+func (m *MCP23017Driver) DigitalRead(id string) (int, error) {
+	port, pin, err := parseGpioExpanderPin(id, "A", "B")
+	if err != nil {
+		return 0, err
+	}
+	if m.interrupt == nil {
+		val, err := m.ReadGPIO(pin, port)
+		return int(val), err
+	}
+	if val, ok := m.interrupt.value(id); ok {
+		return val, nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	selectedPort := m.getPort(port)
+	if err := m.write(selectedPort.IODIR, pin, set); err != nil {
+		return 0, err
+	}
+	// compare with the previous value and enable interrupt on change
+	if err := m.write(selectedPort.INTCON, pin, clear); err != nil {
+		return 0, err
+	}
+	if err := m.write(selectedPort.GPINTEN, pin, set); err != nil {
+		return 0, err
+	}
+	// reading the port also clears a pending interrupt
+	portVal, err := m.read(selectedPort.GPIO)
+	if err != nil {
+		return 0, err
+	}
+	val := int(portVal>>pin) & 0x01
+	m.interrupt.watch(id, val)
+	return val, nil
+}
+
 func (m *MCP23017Driver) initialize() (err error) {
 	// Set IOCON register with MCP23017 configuration.
 	ioconReg := m.getPort("A").IOCON // IOCON address is the same for Port A or B.
@@ -313,9 +383,36 @@ func (m *MCP23017Driver) initialize() (err error) {
 	if _, err := m.connection.Write([]uint8{ioconReg, ioconVal}); err != nil {
 		return err
 	}
+	if m.interrupt != nil {
+		return m.interrupt.attach(m.mcpConf.intpol == 1, m.onInterrupt)
+	}
 	return
 }
 
+// onInterrupt reads both ports, which clears the interrupt, and publishes the changed inputs
+func (m *MCP23017Driver) onInterrupt() {
+	m.mutex.Lock()
+	portVals := make(map[string]uint8)
+	for _, port := range []string{"A", "B"} {
+		val, err := m.read(m.getPort(port).GPIO)
+		if err != nil {
+			m.mutex.Unlock()
+			m.Publish(Error, err)
+			return
+		}
+		portVals[port] = val
+	}
+	m.mutex.Unlock()
+
+	changed := m.interrupt.update(func(id string) int {
+		port, pin, _ := parseGpioExpanderPin(id, "A", "B")
+		return int(portVals[port]>>pin) & 0x01
+	})
+	for id, val := range changed {
+		m.Publish(id, val)
+	}
+}
+
 // write gets the value of the passed in register, and then sets the bit specified
 // by the pin to the given state.
 func (m *MCP23017Driver) write(reg uint8, pin uint8, state bitState) (err error) {
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -13,6 +15,10 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*MCP23017Driver)(nil)
 
+// this ensures that the driver can be used as connection for gpio drivers
+var _ gpio.DigitalReader = (*MCP23017Driver)(nil)
+var _ gpio.DigitalWriter = (*MCP23017Driver)(nil)
+
 var pinValPort = map[string]interface{}{
 	"pin":  uint8(7),
 	"val":  uint8(0),
@@ -626,3 +632,90 @@ func TestMCP23017_getPort(t *testing.T) {
 	actualPort = d.getPort("")
 	gobottest.Assert(t, expectedPort, actualPort)
 }
+
+// simulateMCP23017Registers lets the test adaptor behave like the register set of the device
+func simulateMCP23017Registers(a *i2cTestAdaptor) map[uint8]uint8 {
+	regs := make(map[uint8]uint8)
+	var lastReg uint8
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		lastReg = b[0]
+		if len(b) > 1 {
+			regs[b[0]] = b[1]
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = regs[lastReg]
+		return len(b), nil
+	}
+	return regs
+}
+
+func TestMCP23017DigitalWriteRead(t *testing.T) {
+	d, a := initTestMCP23017WithStubbedAdaptor(0)
+	regs := simulateMCP23017Registers(a)
+
+	gobottest.Assert(t, d.DigitalWrite("B3", 1), nil)
+	gobottest.Assert(t, regs[0x15], uint8(0x08)) // OLATB
+	gobottest.Assert(t, d.DigitalWrite("B_3", 0), nil)
+	gobottest.Assert(t, regs[0x15], uint8(0x00))
+
+	regs[0x12] = 0x04 // GPIOA
+	val, err := d.DigitalRead("A2")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, regs[0x00]&0x04, uint8(0x04)) // IODIRA
+
+	gobottest.Assert(t, d.DigitalWrite("C3", 1).Error(), "invalid port in 'C3', must be one of [A B]")
+	_, err = d.DigitalRead("A9")
+	gobottest.Assert(t, err.Error(), "invalid pin number in 'A9', must be 0..7")
+}
+
+func TestMCP23017DigitalReadWithInterrupts(t *testing.T) {
+	host := newGpioExpanderTestHost()
+	a := newI2cTestAdaptor()
+	regs := simulateMCP23017Registers(a)
+	d := NewMCP23017Driver(a, WithMCP23017Interrupts(host, "7", "11"))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, host.pins["7"].edge, 1)
+	gobottest.Assert(t, host.pins["11"].edge, 1)
+
+	regs[0x13] = 0x20 // GPIOB
+	val, err := d.DigitalRead("B5")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, regs[0x05], uint8(0x20)) // GPINTENB
+	gobottest.Assert(t, regs[0x01], uint8(0x20)) // IODIRB
+
+	// cached value is used, no further i2c access
+	regs[0x13] = 0x00
+	numWrites := len(a.written)
+	val, _ = d.DigitalRead("B5")
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, len(a.written), numWrites)
+
+	sem := make(chan int, 1)
+	_ = d.Once("B5", func(data interface{}) { sem <- data.(int) })
+	host.pins["11"].trigger()
+	select {
+	case v := <-sem:
+		gobottest.Assert(t, v, 0)
+	case <-time.After(1 * time.Second):
+		t.Errorf("MCP23017 event for pin \"B5\" was not published")
+	}
+	val, _ = d.DigitalRead("B5")
+	gobottest.Assert(t, val, 0)
+}
+
+func TestWithMCP23017Interrupts(t *testing.T) {
+	host := newGpioExpanderTestHost()
+	d := NewMCP23017Driver(newI2cTestAdaptor(), WithMCP23017Interrupts(host, "7"))
+	gobottest.Refute(t, d.interrupt, nil)
+	// one pin needs mirrored interrupts
+	gobottest.Assert(t, d.mcpConf.mirror, uint8(1))
+	// active high
+	d = NewMCP23017Driver(newI2cTestAdaptor(), WithMCP23017Interrupts(host, "8", "9"), WithMCP23017Intpol(1))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.mcpConf.mirror, uint8(0))
+	gobottest.Assert(t, host.pins["8"].edge, 2)
+}
@@ -0,0 +1,161 @@
+package i2c
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// memoryMaxTransfer limits the count of bytes of a single read or write transfer, if not limited by the page size
+const memoryMaxTransfer = 256
+
+// MemoryModel describes the organization of an I2C EEPROM or FRAM.
+//
+// Devices with 1 address byte, but more than 256 bytes (e.g. 24C16), and devices with 2 address bytes, but
+// more than 64k bytes (e.g. MB85RC1MT), use the lowest bits of the device address for the upper bits of the
+// memory address. This is done by the driver, so the device occupies multiple consecutive addresses.
+type MemoryModel struct {
+	Name         string
+	Size         int // [byte]
+	PageSize     int // [byte], 0 if writes are not limited to a page
+	AddressBytes int // 1 or 2
+}
+
+// memoryDriver is the common implementation of the EEPROM and FRAM drivers. It implements io.ReaderAt and
+// io.WriterAt.
+type memoryDriver struct {
+	*Driver
+	model       MemoryModel
+	writeCycle  time.Duration // maximum time to finish a page write, 0 if not needed
+	connections []Connection  // one for each block of the device address
+}
+
+func newMemoryDriver(c Connector, model MemoryModel, address int) *memoryDriver {
+	d := &memoryDriver{
+		Driver: NewDriver(c, model.Name, address),
+		model:  model,
+	}
+	d.afterStart = d.initialize
+	return d
+}
+
+// Size returns the size of the memory in bytes
+func (d *memoryDriver) Size() int64 { return int64(d.model.Size) }
+
+// ReadAt implements the io.ReaderAt interface. If the end of the memory is reached, io.EOF is returned.
+func (d *memoryDriver) ReadAt(p []byte, off int64) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n := 0
+	for n < len(p) {
+		pos := int(off) + n
+		if pos >= d.model.Size {
+			return n, io.EOF
+		}
+		conn, addr, count := d.transfer(pos, len(p)-n, false)
+		if _, err := conn.Write(addr); err != nil {
+			return n, err
+		}
+		read, err := conn.Read(p[n : n+count])
+		if err != nil {
+			return n, err
+		}
+		if read != count {
+			return n + read, ErrNotEnoughBytes
+		}
+		n += count
+	}
+	return n, nil
+}
+
+// WriteAt implements the io.WriterAt interface. Writes are split at page boundaries and each page write is
+// finished before the next one is started.
+func (d *memoryDriver) WriteAt(p []byte, off int64) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	n := 0
+	for n < len(p) {
+		pos := int(off) + n
+		if pos >= d.model.Size {
+			return n, errors.New("write exceeds the size of the memory")
+		}
+		conn, addr, count := d.transfer(pos, len(p)-n, true)
+		if _, err := conn.Write(append(addr, p[n:n+count]...)); err != nil {
+			return n, err
+		}
+		if err := d.waitWriteCycle(conn, addr); err != nil {
+			return n, err
+		}
+		n += count
+	}
+	return n, nil
+}
+
+// transfer returns the connection, the address bytes and the count of bytes for a transfer at the given
+// position, which does not cross a block, a page (only for writes) or the end of the memory
+func (d *memoryDriver) transfer(pos int, count int, write bool) (Connection, []byte, int) {
+	blockSize := 1 << (8 * uint(d.model.AddressBytes))
+	block := pos / blockSize
+	offset := pos % blockSize
+
+	limit := blockSize - offset
+	if write && d.model.PageSize > 0 {
+		limit = d.model.PageSize - pos%d.model.PageSize
+	} else if limit > memoryMaxTransfer {
+		limit = memoryMaxTransfer
+	}
+	if rest := d.model.Size - pos; rest < limit {
+		limit = rest
+	}
+	if count > limit {
+		count = limit
+	}
+
+	addr := []byte{byte(offset)}
+	if d.model.AddressBytes == 2 {
+		addr = []byte{byte(offset >> 8), byte(offset)}
+	}
+	return d.connections[block], addr, count
+}
+
+// waitWriteCycle polls the device, which does not acknowledge until the internal write cycle is finished
+func (d *memoryDriver) waitWriteCycle(conn Connection, addr []byte) error {
+	if d.writeCycle == 0 {
+		return nil
+	}
+	deadline := time.Now().Add(d.writeCycle)
+	for {
+		if _, err := conn.Write(addr); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrNotReady
+		}
+		time.Sleep(d.writeCycle / 10)
+	}
+}
+
+func (d *memoryDriver) initialize() error {
+	blockSize := 1 << (8 * uint(d.model.AddressBytes))
+	blocks := (d.model.Size + blockSize - 1) / blockSize
+	d.connections = []Connection{d.connection}
+
+	bus := d.GetBusOrDefault(d.connector.DefaultI2cBus())
+	address := d.GetAddressOrDefault(d.defaultAddress)
+	for block := 1; block < blocks; block++ {
+		conn, err := d.connector.GetI2cConnection(address+block, bus)
+		if err != nil {
+			return err
+		}
+		d.connections = append(d.connections, conn)
+	}
+	return nil
+}
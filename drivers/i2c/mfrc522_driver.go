@@ -1,16 +1,24 @@
 package i2c
 
 import (
+	"log"
+	"time"
+
 	"gobot.io/x/gobot/drivers/common/mfrc522"
 )
 
 const mfrc522DefaultAddress = 0x00
 
+const mfrc522Debug = false
+
 // MFRC522Driver is a wrapper for i2c bus usage. Please refer to the mfrc522.MFRC522Common package
-// for implementation details.
+// for implementation details. The driver publishes the events mfrc522.CardArrived and mfrc522.CardRemoved while
+// polling is active.
 type MFRC522Driver struct {
 	*Driver
 	*mfrc522.MFRC522Common
+	*mfrc522.Poller
+	pollInterval time.Duration
 }
 
 // NewMFRC522Driver creates a new Gobot Driver for MFRC522 RFID with i2c connection
@@ -21,18 +29,44 @@ type MFRC522Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithMFRC522Polling(time.Duration):	start polling for cards with the given interval
 func NewMFRC522Driver(c Connector, options ...func(Config)) *MFRC522Driver {
 	d := &MFRC522Driver{
 		Driver: NewDriver(c, "MFRC522", mfrc522DefaultAddress),
 	}
 	d.MFRC522Common = mfrc522.NewMFRC522Common()
+	d.Poller = mfrc522.NewPoller(d.MFRC522Common)
 	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
 	for _, option := range options {
 		option(d)
 	}
 	return d
 }
 
+// WithMFRC522Polling option starts polling for cards with the given interval after the driver is started.
+func WithMFRC522Polling(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MFRC522Driver)
+		if ok {
+			d.pollInterval = interval
+		} else if mfrc522Debug {
+			log.Printf("Trying to set polling interval for non-MFRC522Driver %v", c)
+		}
+	}
+}
+
 func (d *MFRC522Driver) initialize() error {
-	return d.MFRC522Common.Initialize(d.connection)
+	if err := d.MFRC522Common.Initialize(d.connection); err != nil {
+		return err
+	}
+	if d.pollInterval > 0 {
+		d.StartPolling(d.pollInterval)
+	}
+	return nil
+}
+
+func (d *MFRC522Driver) shutdown() error {
+	d.StopPolling()
+	return nil
 }
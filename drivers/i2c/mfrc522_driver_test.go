@@ -0,0 +1,42 @@
+package i2c
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mfrc522"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*MFRC522Driver)(nil)
+
+func TestNewMFRC522Driver(t *testing.T) {
+	var di interface{} = NewMFRC522Driver(newI2cTestAdaptor())
+	d, ok := di.(*MFRC522Driver)
+	if !ok {
+		t.Errorf("NewMFRC522Driver() should have returned a *MFRC522Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Refute(t, d.MFRC522Common, nil)
+	gobottest.Refute(t, d.Poller, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MFRC522"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x00)
+}
+
+func TestMFRC522DriverPolling(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewMFRC522Driver(a, WithMFRC522Polling(time.Hour))
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.pollInterval, time.Hour)
+	gobottest.Refute(t, d.Event(mfrc522.CardArrived), "")
+	gobottest.Refute(t, d.Event(mfrc522.CardRemoved), "")
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -1,5 +1,11 @@
 package i2c
 
+import (
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/imu"
+)
+
 const mma7660DefaultAddress = 0x4c
 
 const (
@@ -57,6 +63,17 @@ func (d *MMA7660Driver) Acceleration(x, y, z float64) (ax, ay, az float64) {
 	return x / 21.0, y / 21.0, z / 21.0
 }
 
+// ReadAcceleration implements the imu.Accelerometer interface, unit [m/s²]
+func (d *MMA7660Driver) ReadAcceleration() (imu.Sample, error) {
+	x, y, z, err := d.XYZ()
+	if err != nil {
+		return imu.Sample{}, err
+	}
+	ax, ay, az := d.Acceleration(x, y, z)
+	v := imu.Vector{X: ax, Y: ay, Z: az}
+	return imu.Sample{Vector: v.Scale(imu.StandardGravity), Time: time.Now()}, nil
+}
+
 // XYZ returns the raw x,y and z axis from the mma7660
 func (d *MMA7660Driver) XYZ() (x float64, y float64, z float64, err error) {
 	buf := []byte{0, 0, 0}
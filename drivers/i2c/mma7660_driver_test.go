@@ -7,12 +7,14 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*MMA7660Driver)(nil)
+var _ imu.Accelerometer = (*MMA7660Driver)(nil)
 
 func initTestMMA7660DriverWithStubbedAdaptor() (*MMA7660Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
@@ -83,6 +85,22 @@ func TestMMA7660XYZ(t *testing.T) {
 	gobottest.Assert(t, z, 19.0)
 }
 
+func TestMMA7660ReadAcceleration(t *testing.T) {
+	// arrange
+	d, a := initTestMMA7660DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		// 21 is 1g
+		copy(b, []byte{0x15, 0x00, 0x00})
+		return 3, nil
+	}
+	// act
+	got, err := d.ReadAcceleration()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got.Vector, imu.Vector{X: imu.StandardGravity, Y: 0, Z: 0})
+	gobottest.Assert(t, got.Time.IsZero(), false)
+}
+
 func TestMMA7660XYZError(t *testing.T) {
 	d, a := initTestMMA7660DriverWithStubbedAdaptor()
 	a.i2cReadImpl = func(b []byte) (int, error) {
@@ -5,13 +5,19 @@ import (
 	"encoding/binary"
 	"fmt"
 	"log"
+	"math"
 	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 )
 
 const (
 	mpu6050Debug                = false
 	mpu6050DefaultAddress       = 0x68
 	mpu6050EarthStandardGravity = 9.80665 // [m/s²] standard gravity (pole: 9.834,  equator: 9.764)
+	mpu6050FifoSampleSize       = 12      // accelerometer and gyroscope, 3 x 2 bytes each
+	mpu6050FifoBlockSize        = 24      // read 2 samples at once, the block size is limited to 32 bytes
 )
 
 type MPU6050DlpfConfig uint8
@@ -21,12 +27,31 @@ type MPU6050AccelFsConfig uint8
 type MPU6050Pwr1ClockConfig uint8
 
 const (
+	mpu6050Reg_SampleRateDiv   = 0x19 // sample rate = gyroscope output rate / (1 + divider)
 	mpu6050Reg_GeneralConfig   = 0x1A // external frame synchronization and digital low pass filter
 	mpu6050Reg_GyroConfig      = 0x1B // self test and full scale range
 	mpu6050Reg_AccelConfig     = 0x1C // self test and full scale range
+	mpu6050Reg_FifoEnable      = 0x23 // selects the values, which are written to the FIFO
+	mpu6050Reg_IntPinConfig    = 0x37 // level, latch and clear behavior of the INT pin
+	mpu6050Reg_IntEnable       = 0x38
+	mpu6050Reg_IntStatus       = 0x3A // cleared by reading
 	mpu6050Reg_AccelXoutH      = 0x3B // first data register
 	mpu6050Reg_SignalPathReset = 0x68
+	mpu6050Reg_UserCtrl        = 0x6A
 	mpu6050Reg_PwrMgmt1        = 0x6B
+	mpu6050Reg_FifoCountH      = 0x72
+	mpu6050Reg_FifoRW          = 0x74
+
+	mpu6050FifoEnable_AccelBit = 0x08
+	mpu6050FifoEnable_GyroBits = 0x70 // x, y, z
+
+	mpu6050Int_FifoOverflowBit = 0x10
+	mpu6050Int_DataReadyBit    = 0x01
+
+	mpu6050IntPin_ActiveLowBit = 0x80
+
+	mpu6050UserCtrl_FifoEnableBit = 0x40
+	mpu6050UserCtrl_FifoResetBit  = 0x04
 
 	MPU6050General_Dlpf260Hz MPU6050DlpfConfig = 0x00
 	MPU6050General_Dlpf184Hz MPU6050DlpfConfig = 0x01
@@ -99,6 +124,13 @@ type MPU6050Driver struct {
 	gyroFs        MPU6050GyroFsConfig
 	clock         MPU6050Pwr1ClockConfig
 	gravity       float64 // set to 1.0 leads to [g]
+	sampleRateDiv uint8
+	intHost       gobot.DigitalPinnerProvider
+	intPin        string
+	intActiveLow  bool
+	fifoClock     *imu.SampleClock
+	stream        *imu.Stream
+	streamHalt    chan bool
 }
 
 // mpu6050AccelGain in 1/g
@@ -125,6 +157,8 @@ var mpu6050GyroGain = map[MPU6050GyroFsConfig]float64{
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithMPU6050SampleRateDivider(uint8):	divider for the sample rate of FIFO and data ready interrupt
+//		i2c.WithMPU6050Interrupt(gobot.DigitalPinnerProvider, string, bool):	host pin wired to INT
 //
 func NewMPU6050Driver(a Connector, options ...func(Config)) *MPU6050Driver {
 	m := &MPU6050Driver{
@@ -137,6 +171,7 @@ func NewMPU6050Driver(a Connector, options ...func(Config)) *MPU6050Driver {
 		gravity:   mpu6050EarthStandardGravity,
 	}
 	m.afterStart = m.initialize
+	m.beforeHalt = m.shutdown
 
 	for _, option := range options {
 		option(m)
@@ -218,6 +253,32 @@ func WithMPU6050Gravity(val float64) func(Config) {
 	}
 }
 
+// WithMPU6050SampleRateDivider option sets the divider for the sample rate, which is the gyroscope output
+// rate (8 kHz without digital low pass filter, otherwise 1 kHz) divided by (1 + divider).
+func WithMPU6050SampleRateDivider(val uint8) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MPU6050Driver); ok {
+			d.sampleRateDiv = val
+		} else if mpu6050Debug {
+			log.Printf("Trying to set sample rate divider for non-MPU6050Driver %v", c)
+		}
+	}
+}
+
+// WithMPU6050Interrupt option wires the interrupt output INT to the given host pin, which needs to support
+// edge detection. This is used by Stream() to drain the FIFO on the data ready interrupt instead of polling.
+func WithMPU6050Interrupt(host gobot.DigitalPinnerProvider, pinID string, activeLow bool) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*MPU6050Driver); ok {
+			d.intHost = host
+			d.intPin = pinID
+			d.intActiveLow = activeLow
+		} else if mpu6050Debug {
+			log.Printf("Trying to set interrupt for non-MPU6050Driver %v", c)
+		}
+	}
+}
+
 // GetData fetches the latest data from the MPU6050
 func (m *MPU6050Driver) GetData() (err error) {
 	m.mutex.Lock()
@@ -261,6 +322,230 @@ func (m *MPU6050Driver) GetData() (err error) {
 	return
 }
 
+// ReadAcceleration implements the imu.Accelerometer interface, unit [m/s²]. The public fields
+// are updated as by GetData().
+func (m *MPU6050Driver) ReadAcceleration() (imu.Sample, error) {
+	if err := m.GetData(); err != nil {
+		return imu.Sample{}, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	v := imu.Vector{X: m.Accelerometer.X, Y: m.Accelerometer.Y, Z: m.Accelerometer.Z}
+	return imu.Sample{Vector: v.Scale(imu.StandardGravity / m.gravity), Time: time.Now()}, nil
+}
+
+// ReadAngularRate implements the imu.Gyroscope interface, unit [rad/s]. The public fields are
+// updated as by GetData().
+func (m *MPU6050Driver) ReadAngularRate() (imu.Sample, error) {
+	if err := m.GetData(); err != nil {
+		return imu.Sample{}, err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	v := imu.Vector{X: m.Gyroscope.X, Y: m.Gyroscope.Y, Z: m.Gyroscope.Z}
+	return imu.Sample{Vector: v.Scale(math.Pi / 180), Time: time.Now()}, nil
+}
+
+// EnableFIFO enables or disables the FIFO for accelerometer and gyroscope values. On enable the FIFO is reset.
+func (m *MPU6050Driver) EnableFIFO(enable bool) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.enableFIFO(enable)
+}
+
+// FIFOCount returns the number of complete samples in the FIFO
+func (m *MPU6050Driver) FIFOCount() (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	count, err := m.fifoCount()
+	return count / mpu6050FifoSampleSize, err
+}
+
+// ReadFIFO drains all samples of the FIFO, which contain accelerometer [m/s²] and gyroscope [rad/s] values.
+// The timestamps are calculated from the sample rate. On overflow the FIFO is reset, which drops all samples.
+func (m *MPU6050Driver) ReadFIFO() ([]imu.Reading, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.readFIFO()
+}
+
+// Stream enables the FIFO and starts to drain it continuously, the samples are delivered by the channel of
+// the returned stream with the given size. If the interrupt is wired by WithMPU6050Interrupt(), the FIFO is
+// drained on the data ready interrupt, otherwise it is polled. For high sample rates polling is recommended,
+// because there is no watermark interrupt. The streaming is stopped by Stop() of the stream or on Halt().
+func (m *MPU6050Driver) Stream(size int) (*imu.Stream, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stream != nil {
+		return nil, fmt.Errorf("stream already started")
+	}
+	if err := m.enableFIFO(true); err != nil {
+		return nil, err
+	}
+
+	var s *imu.Stream
+	s = imu.NewStream(size, func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		if m.stream == s {
+			_ = m.stopStream()
+		}
+	})
+
+	if m.intHost != nil {
+		var pinConf uint8
+		if m.intActiveLow {
+			pinConf = mpu6050IntPin_ActiveLowBit
+		}
+		if err := m.connection.WriteByteData(mpu6050Reg_IntPinConfig, pinConf); err != nil {
+			return nil, err
+		}
+		if err := m.connection.WriteByteData(mpu6050Reg_IntEnable, mpu6050Int_DataReadyBit|mpu6050Int_FifoOverflowBit); err != nil {
+			return nil, err
+		}
+		m.stream = s
+		return s, nil
+	}
+
+	m.stream = s
+	halt := make(chan bool)
+	m.streamHalt = halt
+	interval := m.fifoClock.Period() * 32
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-halt:
+				return
+			case <-ticker.C:
+				m.onFIFOInterrupt()
+			}
+		}
+	}()
+	return s, nil
+}
+
+// onFIFOInterrupt drains the FIFO to the active stream
+func (m *MPU6050Driver) onFIFOInterrupt() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stream == nil {
+		return
+	}
+	readings, overflow, err := m.readFIFO()
+	if err != nil {
+		m.stream.ReportError(err)
+		return
+	}
+	if overflow {
+		m.stream.ReportOverflow()
+	}
+	for _, r := range readings {
+		m.stream.Push(r)
+	}
+}
+
+func (m *MPU6050Driver) stopStream() error {
+	if m.streamHalt != nil {
+		close(m.streamHalt)
+		m.streamHalt = nil
+	}
+	m.stream = nil
+	if m.intHost != nil {
+		if err := m.connection.WriteByteData(mpu6050Reg_IntEnable, 0x00); err != nil {
+			return err
+		}
+	}
+	return m.enableFIFO(false)
+}
+
+func (m *MPU6050Driver) enableFIFO(enable bool) error {
+	if !enable {
+		if err := m.connection.WriteByteData(mpu6050Reg_UserCtrl, 0x00); err != nil {
+			return err
+		}
+		return m.connection.WriteByteData(mpu6050Reg_FifoEnable, 0x00)
+	}
+	if err := m.connection.WriteByteData(mpu6050Reg_FifoEnable, mpu6050FifoEnable_AccelBit|mpu6050FifoEnable_GyroBits); err != nil {
+		return err
+	}
+	return m.resetFIFO()
+}
+
+func (m *MPU6050Driver) resetFIFO() error {
+	m.fifoClock.Reset()
+	return m.connection.WriteByteData(mpu6050Reg_UserCtrl, mpu6050UserCtrl_FifoEnableBit|mpu6050UserCtrl_FifoResetBit)
+}
+
+func (m *MPU6050Driver) fifoCount() (int, error) {
+	buf := []byte{0, 0}
+	if err := m.connection.ReadBlockData(mpu6050Reg_FifoCountH, buf); err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(buf)), nil
+}
+
+func (m *MPU6050Driver) readFIFO() ([]imu.Reading, bool, error) {
+	status, err := m.connection.ReadByteData(mpu6050Reg_IntStatus)
+	if err != nil {
+		return nil, false, err
+	}
+	if status&mpu6050Int_FifoOverflowBit != 0 {
+		// the FIFO content is not aligned to the sample size anymore
+		return nil, true, m.resetFIFO()
+	}
+
+	count, err := m.fifoCount()
+	if err != nil {
+		return nil, false, err
+	}
+	readTime := time.Now()
+	n := count / mpu6050FifoSampleSize
+	stamps := m.fifoClock.Stamp(n, readTime)
+
+	ag := float64(mpu6050AccelGain[m.accelFs]) / imu.StandardGravity
+	gg := mpu6050GyroGain[m.gyroFs] * 180 / math.Pi
+	readings := make([]imu.Reading, 0, n)
+	for len(readings) < n {
+		samples := n - len(readings)
+		if samples > mpu6050FifoBlockSize/mpu6050FifoSampleSize {
+			samples = mpu6050FifoBlockSize / mpu6050FifoSampleSize
+		}
+		buf := make([]byte, samples*mpu6050FifoSampleSize)
+		if err := m.connection.ReadBlockData(mpu6050Reg_FifoRW, buf); err != nil {
+			return readings, false, err
+		}
+		for i := 0; i < samples; i++ {
+			var raw [6]int16
+			for j := range raw {
+				raw[j] = int16(binary.BigEndian.Uint16(buf[i*mpu6050FifoSampleSize+2*j:]))
+			}
+			accel := imu.Vector{X: float64(raw[0]) / ag, Y: float64(raw[1]) / ag, Z: float64(raw[2]) / ag}
+			gyro := imu.Vector{X: float64(raw[3]) / gg, Y: float64(raw[4]) / gg, Z: float64(raw[5]) / gg}
+			readings = append(readings, imu.Reading{Time: stamps[len(readings)], Acceleration: &accel, AngularRate: &gyro})
+		}
+	}
+	return readings, false, nil
+}
+
+// samplePeriod returns the time between two samples of FIFO and data ready interrupt
+func (m *MPU6050Driver) samplePeriod() time.Duration {
+	gyroRate := 1000
+	if m.dlpf == MPU6050General_Dlpf260Hz {
+		gyroRate = 8000
+	}
+	return time.Second * time.Duration(1+int(m.sampleRateDiv)) / time.Duration(gyroRate)
+}
+
 func (m *MPU6050Driver) waitForReset() error {
 	wait := 100 * time.Millisecond
 	start := time.Now()
@@ -291,6 +576,14 @@ func (m *MPU6050Driver) initialize() (err error) {
 	}
 	time.Sleep(100 * time.Millisecond)
 
+	// the sample rate divider is 0 after reset
+	if m.sampleRateDiv != 0 {
+		if err = m.connection.WriteByteData(mpu6050Reg_SampleRateDiv, m.sampleRateDiv); err != nil {
+			return
+		}
+	}
+	m.fifoClock = imu.NewSampleClock(m.samplePeriod())
+
 	// configure digital filter bandwidth and external frame synchronization (bits 3...5 are used)
 	generalConf := uint8(m.dlpf) | uint8(m.frameSync)<<3
 	if err = m.connection.WriteByteData(mpu6050Reg_GeneralConfig, generalConf); err != nil {
@@ -313,5 +606,17 @@ func (m *MPU6050Driver) initialize() (err error) {
 		return
 	}
 
+	if m.intHost != nil {
+		return attachHostInterrupt(m.intHost, m.intPin, !m.intActiveLow, m.onFIFOInterrupt)
+	}
+
 	return
 }
+
+func (m *MPU6050Driver) shutdown() error {
+	if s := m.stream; s != nil {
+		s.Close()
+		return m.stopStream()
+	}
+	return nil
+}
@@ -2,16 +2,21 @@ package i2c
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/imu"
 	"gobot.io/x/gobot/gobottest"
 )
 
 // this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*MPU6050Driver)(nil)
+var _ imu.Accelerometer = (*MPU6050Driver)(nil)
+var _ imu.Gyroscope = (*MPU6050Driver)(nil)
 
 func initTestMPU6050WithStubbedAdaptor() (*MPU6050Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
@@ -116,6 +121,27 @@ func TestMPU6050GetData(t *testing.T) {
 	gobottest.Assert(t, d.Temperature, wantTemp)
 }
 
+func TestMPU6050ReadAccelerationAndAngularRate(t *testing.T) {
+	// arrange
+	d, adaptor := initTestMPU6050WithStubbedAdaptor()
+	WithMPU6050Gravity(1.0)(d)
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		// 1g for z-axis, 131 is 1°/s for x-axis
+		copy(b, []byte{0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x00, 0x83, 0x00, 0x00, 0x00, 0x00})
+		return len(b), nil
+	}
+	// act
+	accel, errAccel := d.ReadAcceleration()
+	gyro, errGyro := d.ReadAngularRate()
+	// assert
+	gobottest.Assert(t, errAccel, nil)
+	gobottest.Assert(t, errGyro, nil)
+	gobottest.Assert(t, accel.Vector, imu.Vector{X: 0, Y: 0, Z: imu.StandardGravity})
+	gobottest.Assert(t, gyro.Vector, imu.Vector{X: math.Pi / 180, Y: 0, Z: 0})
+	gobottest.Assert(t, accel.Time.IsZero(), false)
+	gobottest.Assert(t, d.Accelerometer.Z, 1.0)
+}
+
 func TestMPU6050GetDataReadError(t *testing.T) {
 	d, adaptor := initTestMPU6050WithStubbedAdaptor()
 	d.Start()
@@ -189,3 +215,122 @@ func TestMPU6050_initialize(t *testing.T) {
 	gobottest.Assert(t, a.written[11], uint8(0x6B))
 	gobottest.Assert(t, a.written[12], uint8(0x01))
 }
+
+// simulateMPU6050FIFO simulates the registers and the FIFO, each read of the FIFO register removes the read bytes
+func simulateMPU6050FIFO(a *i2cTestAdaptor) (map[uint8]uint8, *[]byte) {
+	regs := make(map[uint8]uint8)
+	fifo := &[]byte{}
+	var lastReg uint8
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		lastReg = b[0]
+		if len(b) > 1 {
+			regs[b[0]] = b[1]
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		switch lastReg {
+		case 0x72:
+			b[0] = uint8(len(*fifo) >> 8)
+			b[1] = uint8(len(*fifo))
+		case 0x74:
+			n := copy(b, *fifo)
+			*fifo = (*fifo)[n:]
+		default:
+			b[0] = regs[lastReg]
+		}
+		return len(b), nil
+	}
+	return regs, fifo
+}
+
+func TestMPU6050ReadFIFO(t *testing.T) {
+	// arrange: 3 samples, accel 1g for z-axis and gyro 1°/s for x-axis, plus one incomplete sample
+	d, a := initTestMPU6050WithStubbedAdaptor()
+	regs, fifo := simulateMPU6050FIFO(a)
+	gobottest.Assert(t, d.EnableFIFO(true), nil)
+	gobottest.Assert(t, regs[0x23], uint8(0x78))
+	gobottest.Assert(t, regs[0x6A], uint8(0x44))
+	sample := []byte{0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x83, 0x00, 0x00, 0x00, 0x00}
+	for i := 0; i < 3; i++ {
+		*fifo = append(*fifo, sample...)
+	}
+	*fifo = append(*fifo, 0x01, 0x02)
+	// act
+	count, err := d.FIFOCount()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, count, 3)
+	readings, overflow, err := d.ReadFIFO()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, overflow, false)
+	gobottest.Assert(t, len(readings), 3)
+	gobottest.Assert(t, *readings[2].Acceleration, imu.Vector{X: 0, Y: 0, Z: imu.StandardGravity})
+	gobottest.Assert(t, math.Abs(readings[2].AngularRate.X-math.Pi/180) < 1e-12, true)
+	// 8 kHz without low pass filter
+	gobottest.Assert(t, readings[2].Time.Sub(readings[0].Time), 250*time.Microsecond)
+	gobottest.Assert(t, len(*fifo), 2)
+	// disable
+	gobottest.Assert(t, d.EnableFIFO(false), nil)
+	gobottest.Assert(t, regs[0x23], uint8(0x00))
+	gobottest.Assert(t, regs[0x6A], uint8(0x00))
+}
+
+func TestMPU6050ReadFIFOOverflow(t *testing.T) {
+	d, a := initTestMPU6050WithStubbedAdaptor()
+	regs, _ := simulateMPU6050FIFO(a)
+	regs[0x3A] = 0x10
+	// act
+	readings, overflow, err := d.ReadFIFO()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, overflow, true)
+	gobottest.Assert(t, len(readings), 0)
+	gobottest.Assert(t, regs[0x6A], uint8(0x44)) // FIFO reset
+}
+
+func TestMPU6050StreamWithInterrupt(t *testing.T) {
+	// arrange
+	host := newGpioExpanderTestHost()
+	a := newI2cTestAdaptor()
+	d := NewMPU6050Driver(a, WithMPU6050Interrupt(host, "11", true), WithMPU6050SampleRateDivider(9))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, host.pins["11"].edge, 1) // falling
+	regs, fifo := simulateMPU6050FIFO(a)
+	// act
+	s, err := d.Stream(10)
+	gobottest.Assert(t, err, nil)
+	_, err = d.Stream(10)
+	gobottest.Assert(t, err.Error(), "stream already started")
+	*fifo = []byte{0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	host.pins["11"].trigger()
+	// assert
+	gobottest.Assert(t, regs[0x37], uint8(0x80))
+	gobottest.Assert(t, regs[0x38], uint8(0x11))
+	r := <-s.C
+	gobottest.Assert(t, r.Acceleration.Z, imu.StandardGravity)
+	gobottest.Assert(t, d.fifoClock.Period(), 1250*time.Microsecond)
+	// halt closes the stream
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, regs[0x38], uint8(0x00))
+	_, ok := <-s.C
+	gobottest.Assert(t, ok, false)
+}
+
+func TestMPU6050StreamPolling(t *testing.T) {
+	// arrange
+	d, a := initTestMPU6050WithStubbedAdaptor()
+	_, fifo := simulateMPU6050FIFO(a)
+	*fifo = []byte{0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	// act
+	s, err := d.Stream(10)
+	// assert
+	gobottest.Assert(t, err, nil)
+	select {
+	case r := <-s.C:
+		gobottest.Assert(t, r.Acceleration.Z, imu.StandardGravity)
+	case <-time.After(time.Second):
+		t.Errorf("no reading was streamed")
+	}
+	s.Stop()
+}
@@ -1,5 +1,13 @@
 package i2c
 
+import (
+	"log"
+
+	"gobot.io/x/gobot"
+)
+
+const pca9501Debug = false // toggle debugging information
+
 // PCA9501 supports addresses from 0x00 to 0x7F
 // 0x00 - 0x3F: GPIO, 0x40 - 0x7F: EEPROM
 //
@@ -20,6 +28,8 @@ const pca9501DefaultAddress = 0x3F // this applies, if all 6 address pins left o
 type PCA9501Driver struct {
 	connectionMem Connection
 	*Driver
+	interrupt *gpioExpanderInterrupt
+	gobot.Eventer
 }
 
 // NewPCA9501Driver creates a new driver with specified i2c interface
@@ -29,13 +39,21 @@ type PCA9501Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithPCA9501Interrupt(gobot.DigitalPinnerProvider, string):	host pin wired to INT
 //
+// The driver implements the gpio.DigitalReader and gpio.DigitalWriter interface with pin ids like "3" or "P3",
+// so it can be used as connection for e.g. gpio.LedDriver and gpio.ButtonDriver.
 func NewPCA9501Driver(a Connector, options ...func(Config)) *PCA9501Driver {
 	p := &PCA9501Driver{
-		Driver: NewDriver(a, "PCA9501", pca9501DefaultAddress, options...),
+		Driver:  NewDriver(a, "PCA9501", pca9501DefaultAddress),
+		Eventer: gobot.NewEventer(),
 	}
 	p.afterStart = p.initialize
 
+	for _, option := range options {
+		option(p)
+	}
+
 	// API commands
 	p.AddCommand("WriteGPIO", func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(uint8)
@@ -65,6 +83,52 @@ func NewPCA9501Driver(a Connector, options ...func(Config)) *PCA9501Driver {
 	return p
 }
 
+// WithPCA9501Interrupt option wires the interrupt output INT to the given host pin, which needs to support edge
+// detection. Inputs read by DigitalRead() are cached afterwards and refreshed on interrupt only, so button drivers
+// do not poll the i2c bus. Each change of a cached input is published as event with the pin id as name and the
+// new value as data.
+func WithPCA9501Interrupt(host gobot.DigitalPinnerProvider, pinID string) func(Config) {
+	return func(c Config) {
+		p, ok := c.(*PCA9501Driver)
+		if ok {
+			p.interrupt = newGpioExpanderInterrupt(host, pinID)
+		} else if pca9501Debug {
+			log.Printf("Trying to set interrupt for non-PCA9501Driver %v", c)
+		}
+	}
+}
+
+// DigitalWrite implements the gpio.DigitalWriter interface, the pin id is the number of the pin, e.g. "3" or "P3".
+func (p *PCA9501Driver) DigitalWrite(id string, val byte) error {
+	_, pin, err := parseGpioExpanderPin(id, "", "P")
+	if err != nil {
+		return err
+	}
+	return p.WriteGPIO(pin, val)
+}
+
+// DigitalRead implements the gpio.DigitalReader interface, the pin id is the number of the pin, e.g. "3" or "P3".
+// When the interrupt is used, the cached value is returned after the first read.
+func (p *PCA9501Driver) DigitalRead(id string) (int, error) {
+	_, pin, err := parseGpioExpanderPin(id, "", "P")
+	if err != nil {
+		return 0, err
+	}
+	if p.interrupt != nil {
+		if val, ok := p.interrupt.value(id); ok {
+			return val, nil
+		}
+	}
+	val, err := p.ReadGPIO(pin)
+	if err != nil {
+		return 0, err
+	}
+	if p.interrupt != nil {
+		p.interrupt.watch(id, int(val))
+	}
+	return int(val), nil
+}
+
 // WriteGPIO writes a value to a gpio pin (0-7)
 func (p *PCA9501Driver) WriteGPIO(pin uint8, val uint8) error {
 	p.mutex.Lock()
@@ -153,6 +217,31 @@ func (p *PCA9501Driver) initialize() (err error) {
 	// initialize the EEPROM connection
 	bus := p.GetBusOrDefault(p.connector.DefaultI2cBus())
 	addressMem := p.GetAddressOrDefault(pca9501DefaultAddress) | 0x40
-	p.connectionMem, err = p.connector.GetI2cConnection(addressMem, bus)
+	if p.connectionMem, err = p.connector.GetI2cConnection(addressMem, bus); err != nil {
+		return
+	}
+	if p.interrupt != nil {
+		// the INT output is active low
+		return p.interrupt.attach(false, p.onInterrupt)
+	}
 	return
 }
+
+// onInterrupt reads the port, which clears the interrupt, and publishes the changed inputs
+func (p *PCA9501Driver) onInterrupt() {
+	p.mutex.Lock()
+	portVal, err := p.connection.ReadByte()
+	p.mutex.Unlock()
+	if err != nil {
+		p.Publish(Error, err)
+		return
+	}
+
+	changed := p.interrupt.update(func(id string) int {
+		_, pin, _ := parseGpioExpanderPin(id, "", "P")
+		return int(portVal>>pin) & 0x01
+	})
+	for id, val := range changed {
+		p.Publish(id, val)
+	}
+}
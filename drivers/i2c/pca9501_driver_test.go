@@ -4,8 +4,10 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -13,6 +15,10 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*PCA9501Driver)(nil)
 
+// this ensures that the driver can be used as connection for gpio drivers
+var _ gpio.DigitalReader = (*PCA9501Driver)(nil)
+var _ gpio.DigitalWriter = (*PCA9501Driver)(nil)
+
 var (
 	pinVal = map[string]interface{}{
 		"pin": uint8(7),
@@ -422,3 +428,59 @@ func TestPCA9501_initialize(t *testing.T) {
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, a.address, want)
 }
+
+func TestPCA9501DigitalWriteRead(t *testing.T) {
+	d, a := initPCA9501WithStubbedAdaptor()
+	port := uint8(0x00)
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = port
+		return len(b), nil
+	}
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		port = b[0]
+		return len(b), nil
+	}
+
+	gobottest.Assert(t, d.DigitalWrite("P2", 1), nil)
+	gobottest.Assert(t, port, uint8(0x04))
+
+	val, err := d.DigitalRead("2")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+
+	gobottest.Assert(t, d.DigitalWrite("A2", 1).Error(), "invalid port in 'A2', must be one of [ P]")
+}
+
+func TestPCA9501DigitalReadWithInterrupt(t *testing.T) {
+	host := newGpioExpanderTestHost()
+	a := newI2cTestAdaptor()
+	d := NewPCA9501Driver(a, WithPCA9501Interrupt(host, "13"))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, host.pins["13"].edge, 1)
+
+	port := uint8(0x01)
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = port
+		return len(b), nil
+	}
+	val, err := d.DigitalRead("0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+
+	// cached value is used, no further i2c access
+	port = 0x00
+	numWrites := len(a.written)
+	val, _ = d.DigitalRead("0")
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, len(a.written), numWrites)
+
+	sem := make(chan int, 1)
+	_ = d.Once("0", func(data interface{}) { sem <- data.(int) })
+	host.pins["13"].trigger()
+	select {
+	case v := <-sem:
+		gobottest.Assert(t, v, 0)
+	case <-time.After(1 * time.Second):
+		t.Errorf("PCA9501 event for pin \"0\" was not published")
+	}
+}
@@ -40,6 +40,7 @@ var errToSmallPeriod = fmt.Errorf("Given Period to small, must be at least 1/152
 var errToBigPeriod = fmt.Errorf("Given Period to high, must be max. 256/152s (~1.68s) or 152/256Hz (~0.6Hz)")
 var errToSmallDutyCycle = fmt.Errorf("Given Duty Cycle to small, must be at least 0%%")
 var errToBigDutyCycle = fmt.Errorf("Given Duty Cycle to high, must be max. 100%%")
+var errNoFreePwm = fmt.Errorf("Both PWM registers are in use by other pins with different values")
 
 // PCA953xDriver is a Gobot Driver for LED Dimmer PCA9530 (2-bit), PCA9533 (4-bit), PCA9531 (8-bit), PCA9532 (16-bit)
 // Although this is designed for LED's it can be used as a GPIO (read, write, pwm).
@@ -60,8 +61,13 @@ var errToBigDutyCycle = fmt.Errorf("Given Duty Cycle to high, must be max. 100%%
 // when AI=1 and reading, then R!=0
 // this means: do not start with reading input register, writing input register is recognized but has no effect
 // => when AI=1 in general start with R>0
+//
+// The driver implements the gpio.DigitalReader, gpio.DigitalWriter and gpio.PwmWriter interface with pin ids
+// like "3" or "LED3". The device has no interrupt output.
 type PCA953xDriver struct {
 	*Driver
+	pwmPins map[uint8]uint8 // pins driven by PwmWrite() and the used PWM register index
+	pwmVals [2]uint8        // value of the PWM registers
 }
 
 // NewPCA953xDriver creates a new driver with specified i2c interface
@@ -75,7 +81,8 @@ type PCA953xDriver struct {
 //	i2c.WithAddress(int):	address to use with this driver
 func NewPCA953xDriver(c Connector, options ...func(Config)) *PCA953xDriver {
 	d := &PCA953xDriver{
-		Driver: NewDriver(c, "PCA953x", pca953xDefaultAddress),
+		Driver:  NewDriver(c, "PCA953x", pca953xDefaultAddress),
+		pwmPins: make(map[uint8]uint8),
 	}
 
 	for _, option := range options {
@@ -91,6 +98,7 @@ func (d *PCA953xDriver) SetLED(idx uint8, mode PCA953xGPIOMode) error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	delete(d.pwmPins, idx)
 	return d.writeLED(idx, mode)
 }
 
@@ -104,6 +112,66 @@ func (d *PCA953xDriver) WriteGPIO(idx uint8, val uint8) error {
 		mode = PCA953xModeHighImpedance
 	}
 
+	delete(d.pwmPins, idx)
+	return d.writeLED(idx, mode)
+}
+
+// DigitalWrite implements the gpio.DigitalWriter interface, the pin id is the number of the output, e.g. "3" or "LED3".
+func (d *PCA953xDriver) DigitalWrite(id string, val byte) error {
+	_, idx, err := parseGpioExpanderPin(id, "", "LED")
+	if err != nil {
+		return err
+	}
+	return d.WriteGPIO(idx, val)
+}
+
+// DigitalRead implements the gpio.DigitalReader interface, the pin id is the number of the input, e.g. "3" or "LED3".
+func (d *PCA953xDriver) DigitalRead(id string) (int, error) {
+	_, idx, err := parseGpioExpanderPin(id, "", "LED")
+	if err != nil {
+		return 0, err
+	}
+	val, err := d.ReadGPIO(idx)
+	return int(val), err
+}
+
+// PwmWrite implements the gpio.PwmWriter interface, the pin id is the number of the output, e.g. "3" or "LED3".
+// The value (0..255) is the ratio of the high level, like for WriteGPIO(). The device has only two PWM registers,
+// so not more than two different values (except 0 and 255) can be used at the same time. The frequency of both
+// registers can be changed by WriteFrequency().
+func (d *PCA953xDriver) PwmWrite(id string, val byte) error {
+	_, idx, err := parseGpioExpanderPin(id, "", "LED")
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	switch val {
+	case 0:
+		delete(d.pwmPins, idx)
+		return d.writeLED(idx, PCA953xModeLowImpedance)
+	case 255:
+		delete(d.pwmPins, idx)
+		return d.writeLED(idx, PCA953xModeHighImpedance)
+	}
+
+	// the PWM register defines the ratio of the low level (LED on)
+	pwm := 255 - val
+	reg, err := d.allocatePwm(idx, pwm)
+	if err != nil {
+		return err
+	}
+	regPwm, mode := pca953xRegPwm0, PCA953xModePwm0
+	if reg > 0 {
+		regPwm, mode = pca953xRegPwm1, PCA953xModePwm1
+	}
+	if err := d.writeRegister(regPwm, pwm); err != nil {
+		return err
+	}
+	d.pwmVals[reg] = pwm
+	d.pwmPins[idx] = reg
 	return d.writeLED(idx, mode)
 }
 
@@ -225,6 +293,27 @@ func (d *PCA953xDriver) ReadDutyCyclePercent(idx uint8) (float32, error) {
 	return pca953xCalcDutyCyclePercent(pwm), nil
 }
 
+// allocatePwm returns the index of a PWM register, which already has the value or is not used by other pins
+func (d *PCA953xDriver) allocatePwm(idx uint8, pwm uint8) (uint8, error) {
+	var used [2]bool
+	for pin, reg := range d.pwmPins {
+		if pin != idx {
+			used[reg] = true
+		}
+	}
+	for reg := uint8(0); reg < 2; reg++ {
+		if used[reg] && d.pwmVals[reg] == pwm {
+			return reg, nil
+		}
+	}
+	for reg := uint8(0); reg < 2; reg++ {
+		if !used[reg] {
+			return reg, nil
+		}
+	}
+	return 0, errNoFreePwm
+}
+
 func (d *PCA953xDriver) writeLED(idx uint8, mode PCA953xGPIOMode) error {
 	// prepare
 	regLs := pca953xRegLs0
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -14,6 +15,11 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*PCA953xDriver)(nil)
 
+// this ensures that the driver can be used as connection for gpio drivers
+var _ gpio.DigitalReader = (*PCA953xDriver)(nil)
+var _ gpio.DigitalWriter = (*PCA953xDriver)(nil)
+var _ gpio.PwmWriter = (*PCA953xDriver)(nil)
+
 func initPCA953xTestDriverWithStubbedAdaptor() (*PCA953xDriver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
 	d := NewPCA953xDriver(a)
@@ -589,3 +595,50 @@ func TestPCA953x_pca953xCalcDutyCyclePercent(t *testing.T) {
 		})
 	}
 }
+
+func TestPCA953xDigitalWriteRead(t *testing.T) {
+	d, a := initPCA953xTestDriverWithStubbedAdaptor()
+	regs := simulateMCP23017Registers(a)
+
+	gobottest.Assert(t, d.DigitalWrite("LED1", 0), nil)
+	gobottest.Assert(t, regs[0x05], uint8(0x04))
+	gobottest.Assert(t, d.DigitalWrite("1", 1), nil)
+	gobottest.Assert(t, regs[0x05], uint8(0x00))
+
+	regs[0x00] = 0x08
+	val, err := d.DigitalRead("3")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+
+	gobottest.Assert(t, d.DigitalWrite("A1", 0).Error(), "invalid port in 'A1', must be one of [ LED]")
+}
+
+func TestPCA953xPwmWrite(t *testing.T) {
+	d, a := initPCA953xTestDriverWithStubbedAdaptor()
+	regs := simulateMCP23017Registers(a)
+
+	gobottest.Assert(t, d.PwmWrite("1", 128), nil)
+	gobottest.Assert(t, regs[0x02], uint8(127))
+	gobottest.Assert(t, regs[0x05], uint8(0x08))
+	// same value shares the register
+	gobottest.Assert(t, d.PwmWrite("2", 128), nil)
+	gobottest.Assert(t, regs[0x05], uint8(0x28))
+	// second register
+	gobottest.Assert(t, d.PwmWrite("3", 64), nil)
+	gobottest.Assert(t, regs[0x04], uint8(191))
+	gobottest.Assert(t, regs[0x05], uint8(0xE8))
+	// no free register
+	gobottest.Assert(t, d.PwmWrite("4", 10), errNoFreePwm)
+	// release the first register
+	gobottest.Assert(t, d.DigitalWrite("1", 1), nil)
+	gobottest.Assert(t, d.PwmWrite("2", 0), nil)
+	gobottest.Assert(t, regs[0x05], uint8(0xD0))
+	gobottest.Assert(t, d.PwmWrite("4", 10), nil)
+	gobottest.Assert(t, regs[0x02], uint8(245))
+	gobottest.Assert(t, regs[0x06], uint8(0x02))
+	// the only user of a register can change the value
+	gobottest.Assert(t, d.PwmWrite("4", 20), nil)
+	gobottest.Assert(t, regs[0x02], uint8(235))
+	gobottest.Assert(t, d.PwmWrite("4", 255), nil)
+	gobottest.Assert(t, regs[0x06], uint8(0x00))
+}
@@ -9,6 +9,12 @@ import (
 
 const pca9685DefaultAddress = 0x40
 
+// the IC oscillator frequency is 25 MHz, the default prescaler after reset is 0x1E
+const (
+	pca9685OscillatorFrequency = 25000000
+	pca9685DefaultFrequency    = pca9685OscillatorFrequency / 4096 / (0x1E + 1)
+)
+
 const (
 	PCA9685_MODE1        = 0x00
 	PCA9685_MODE2        = 0x01
@@ -39,6 +45,7 @@ const (
 //
 type PCA9685Driver struct {
 	*Driver
+	frequency float32
 }
 
 // NewPCA9685Driver creates a new driver with specified i2c interface
@@ -51,7 +58,8 @@ type PCA9685Driver struct {
 //
 func NewPCA9685Driver(c Connector, options ...func(Config)) *PCA9685Driver {
 	p := &PCA9685Driver{
-		Driver: NewDriver(c, "PCA9685", pca9685DefaultAddress),
+		Driver:    NewDriver(c, "PCA9685", pca9685DefaultAddress),
+		frequency: pca9685DefaultFrequency,
 	}
 	p.afterStart = p.initialize
 	p.beforeHalt = p.shutdown
@@ -70,6 +78,11 @@ func NewPCA9685Driver(c Connector, options ...func(Config)) *PCA9685Driver {
 		val, _ := strconv.Atoi(params["val"].(string))
 		return p.ServoWrite(pin, byte(val))
 	})
+	p.AddCommand("ServoPulseWrite", func(params map[string]interface{}) interface{} {
+		pin := params["pin"].(string)
+		val, _ := strconv.Atoi(params["val"].(string))
+		return p.ServoPulseWrite(pin, uint16(val))
+	})
 	p.AddCommand("SetPWM", func(params map[string]interface{}) interface{} {
 		channel, _ := strconv.Atoi(params["channel"].(string))
 		on, _ := strconv.Atoi(params["on"].(string))
@@ -141,8 +154,7 @@ func (p *PCA9685Driver) SetAllPWM(on uint16, off uint16) (err error) {
 
 // SetPWMFreq sets the PWM frequency in Hz
 func (p *PCA9685Driver) SetPWMFreq(freq float32) error {
-	// IC oscillator frequency is 25 MHz
-	var prescalevel float32 = 25000000
+	var prescalevel float32 = pca9685OscillatorFrequency
 	// Find frequency of PWM waveform
 	prescalevel /= 4096
 	// Ratio between desired frequency and maximum
@@ -176,6 +188,9 @@ func (p *PCA9685Driver) SetPWMFreq(freq float32) error {
 
 	time.Sleep(5 * time.Millisecond)
 
+	// the real frequency differs slightly from the requested one, caused by rounding of the prescaler
+	p.frequency = float32(pca9685OscillatorFrequency) / 4096 / float32(int(prescale)+1)
+
 	// Enable response to All Call address, enable auto-increment, clear restart
 	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode | 0x80)}); err != nil {
 		return err
@@ -210,6 +225,20 @@ func (p *PCA9685Driver) ServoWrite(pin string, val byte) (err error) {
 	return p.SetPWM(i, 0, uint16(v))
 }
 
+// ServoPulseWrite writes a servo signal with the given pulse width in microseconds to the specified
+// channel aka "pin", to conform to the ServoPulseWriter interface. The width is converted by using the
+// PWM frequency, which was set by SetPWMFreq().
+//
+func (p *PCA9685Driver) ServoPulseWrite(pin string, microseconds uint16) (err error) {
+	i, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+	period := 1000000 / float64(p.frequency)
+	v := gobot.ToScale(gobot.FromScale(float64(microseconds), 0, period), 0, 4095)
+	return p.SetPWM(i, 0, uint16(v))
+}
+
 func (p *PCA9685Driver) initialize() error {
 	if err := p.SetAllPWM(0, 0); err != nil {
 		return err
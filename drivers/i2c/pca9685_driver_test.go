@@ -17,6 +17,7 @@ var _ gobot.Driver = (*PCA9685Driver)(nil)
 // and also the PwmWriter and ServoWriter interfaces
 var _ gpio.PwmWriter = (*PCA9685Driver)(nil)
 var _ gpio.ServoWriter = (*PCA9685Driver)(nil)
+var _ gpio.ServoPulseWriter = (*PCA9685Driver)(nil)
 
 func initTestPCA9685DriverWithStubbedAdaptor() (*PCA9685Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
@@ -145,9 +146,32 @@ func TestPCA9685Commands(t *testing.T) {
 	err = d.Command("ServoWrite")(map[string]interface{}{"pin": "1", "val": "1"})
 	gobottest.Assert(t, err, nil)
 
+	err = d.Command("ServoPulseWrite")(map[string]interface{}{"pin": "1", "val": "1500"})
+	gobottest.Assert(t, err, nil)
+
 	err = d.Command("SetPWM")(map[string]interface{}{"channel": "1", "on": "0", "off": "1024"})
 	gobottest.Assert(t, err, nil)
 
 	err = d.Command("SetPWMFreq")(map[string]interface{}{"freq": "60"})
 	gobottest.Assert(t, err, nil)
 }
+
+func TestPCA9685ServoPulseWrite(t *testing.T) {
+	d, a := initTestPCA9685DriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01})
+		return 1, nil
+	}
+	// 50Hz results in a prescaler of 121 and a real frequency of ~50.03Hz
+	gobottest.Assert(t, d.SetPWMFreq(50), nil)
+	a.written = []byte{}
+	// 1.5ms of ~20ms is 307 counts
+	gobottest.Assert(t, d.ServoPulseWrite("3", 1500), nil)
+	gobottest.Assert(t, a.written, []byte{
+		PCA9685_LED0_ON_L + 12, 0x00,
+		PCA9685_LED0_ON_H + 12, 0x00,
+		PCA9685_LED0_OFF_L + 12, 0x33,
+		PCA9685_LED0_OFF_H + 12, 0x01,
+	})
+	gobottest.Refute(t, d.ServoPulseWrite("x", 1500), nil)
+}
@@ -0,0 +1,102 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+
+	"gobot.io/x/gobot/drivers/common/regmap"
+)
+
+const regmapDebug = false
+
+// RegisterMapDriver is a driver for devices, which are described by a register map. It allows the usage of devices
+// without writing a specific driver, see package regmap for the description format.
+type RegisterMapDriver struct {
+	*Driver
+	description *regmap.Description
+	mapOptions  []regmap.Option
+	regs        *regmap.Map
+}
+
+// NewRegisterMapDriver creates a new driver for a device with the given register map description. The address of
+// the description is used as default address.
+//
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//		desc *regmap.Description - the description of the registers
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithRegisterMapOptions(...regmap.Option):	options for the register map
+func NewRegisterMapDriver(c Connector, desc *regmap.Description, options ...func(Config)) *RegisterMapDriver {
+	name := desc.Name
+	if name == "" {
+		name = "RegisterMap"
+	}
+	d := &RegisterMapDriver{
+		Driver:      NewDriver(c, name, desc.Address),
+		description: desc,
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithRegisterMapOptions option sets the options for the register map, e.g. regmap.WithReadFlag().
+func WithRegisterMapOptions(opts ...regmap.Option) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*RegisterMapDriver)
+		if ok {
+			d.mapOptions = append(d.mapOptions, opts...)
+		} else if regmapDebug {
+			log.Printf("Trying to set register map options for non-RegisterMapDriver %v", c)
+		}
+	}
+}
+
+// Map returns the register map for typed access to the registers and fields, nil before the driver is started.
+func (d *RegisterMapDriver) Map() *regmap.Map {
+	return d.regs
+}
+
+// Read reads the raw value of the register or field with the given name. For backward compatibility to
+// Driver.Read(), a numeric name is used as register address.
+func (d *RegisterMapDriver) Read(pin string) (int, error) {
+	if _, err := driverParseRegister(pin); err == nil {
+		return d.Driver.Read(pin)
+	}
+	if d.regs == nil {
+		return 0, fmt.Errorf("register map of '%s' not available, driver not started", d.name)
+	}
+	val, err := d.regs.Read(pin)
+	return int(val), err
+}
+
+// Write writes the raw value to the register or field with the given name. For backward compatibility to
+// Driver.Write(), a numeric name is used as register address.
+func (d *RegisterMapDriver) Write(pin string, val int) error {
+	if _, err := driverParseRegister(pin); err == nil {
+		return d.Driver.Write(pin, val)
+	}
+	if d.regs == nil {
+		return fmt.Errorf("register map of '%s' not available, driver not started", d.name)
+	}
+	if val < 0 {
+		return fmt.Errorf("negative value %d not allowed for '%s'", val, pin)
+	}
+	return d.regs.Write(pin, uint64(val))
+}
+
+func (d *RegisterMapDriver) initialize() error {
+	regs, err := regmap.New(d.connection, d.description, d.mapOptions...)
+	if err != nil {
+		return err
+	}
+	d.regs = regs
+	return nil
+}
@@ -0,0 +1,103 @@
+package i2c
+
+import (
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/regmap"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*RegisterMapDriver)(nil)
+
+func regmapTestDescription() *regmap.Description {
+	return &regmap.Description{
+		Name:    "MCP9808",
+		Address: 0x18,
+		Registers: []regmap.Register{
+			{Name: "CONFIG", Address: 0x01, Size: 2, Fields: []regmap.Field{
+				{Name: "SHDN", Bit: 8},
+				{Name: "HYST", Bit: 9, Width: 2, Enum: map[string]uint64{"0C": 0, "1.5C": 1, "3C": 2, "6C": 3}},
+			}},
+			{Name: "TA", Address: 0x05, Size: 2, Access: regmap.ReadOnly, Fields: []regmap.Field{
+				{Name: "TEMP", Width: 13, Signed: true, Scale: 0.0625},
+			}},
+		},
+	}
+}
+
+func TestNewRegisterMapDriver(t *testing.T) {
+	var di interface{} = NewRegisterMapDriver(newI2cTestAdaptor(), regmapTestDescription())
+	d, ok := di.(*RegisterMapDriver)
+	if !ok {
+		t.Errorf("NewRegisterMapDriver() should have returned a *RegisterMapDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MCP9808"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x18)
+	gobottest.Assert(t, d.Map() == nil, true)
+}
+
+func TestRegisterMapDriverOptions(t *testing.T) {
+	d := NewRegisterMapDriver(newI2cTestAdaptor(), &regmap.Description{}, WithBus(2),
+		WithRegisterMapOptions(regmap.WithReadFlag(0x80)))
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "RegisterMap"), true)
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, len(d.mapOptions), 1)
+}
+
+func TestRegisterMapDriverStart(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewRegisterMapDriver(a, &regmap.Description{Name: "invalid"})
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, strings.Contains(err.Error(), "has no registers"), true)
+	gobottest.Assert(t, d.Map() == nil, true)
+}
+
+func TestRegisterMapDriverMap(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewRegisterMapDriver(a, regmapTestDescription())
+	m := simulateMemory(a, 16, 1)
+	m.mem[5] = 0x01
+	m.mem[6] = 0x94
+	gobottest.Assert(t, d.Start(), nil)
+	// act
+	temp, err1 := d.Map().ReadValue("TEMP")
+	err2 := d.Map().WriteEnum("HYST", "3C")
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, temp, 25.25)
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, m.mem[1:3], []byte{0x04, 0x00})
+}
+
+func TestRegisterMapDriverReadWrite(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	d := NewRegisterMapDriver(a, regmapTestDescription())
+	m := simulateMemory(a, 16, 1)
+	m.mem[3] = 0x42
+	// act & assert
+	_, err := d.Read("SHDN")
+	gobottest.Assert(t, strings.Contains(err.Error(), "driver not started"), true)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Write("SHDN", 1), nil)
+	gobottest.Assert(t, m.mem[1:3], []byte{0x01, 0x00})
+	val, err := d.Read("CONFIG")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0x0100)
+	val, err = d.Read("3")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0x42)
+	gobottest.Assert(t, d.Write("4", 0x11), nil)
+	gobottest.Assert(t, m.mem[4], uint8(0x11))
+	gobottest.Assert(t, d.Write("TEMP", 1).Error(), "field 'TEMP' is read only")
+	gobottest.Assert(t, d.Write("SHDN", -1).Error(), "negative value -1 not allowed for 'SHDN'")
+}
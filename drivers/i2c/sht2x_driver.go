@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/sigurn/crc8"
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 const sht2xDefaultAddress = 0x40
@@ -160,6 +161,23 @@ func (d *SHT2xDriver) Humidity() (humidity float32, err error) {
 	return
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature and humidity
+func (d *SHT2xDriver) ReadEnvironment() (environment.Reading, error) {
+	temp, err := d.Temperature()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	hum, err := d.Humidity()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	return environment.Reading{
+		Time:        time.Now(),
+		Temperature: environment.Value(float64(temp)),
+		Humidity:    environment.Value(float64(hum)),
+	}, nil
+}
+
 // sendCommandDelayGetResponse is a helper function to reduce duplicated code
 func (d *SHT2xDriver) readSensor(cmd byte) (read uint16, err error) {
 	if err = d.connection.WriteByte(cmd); err != nil {
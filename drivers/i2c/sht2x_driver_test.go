@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -14,6 +15,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*SHT2xDriver)(nil)
 
+var _ environment.Environmental = (*SHT2xDriver)(nil)
+
 func initTestSHT2xDriverWithStubbedAdaptor() (*SHT2xDriver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
 	d := NewSHT2xDriver(a)
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/sigurn/crc8"
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 // SHT3xAddressA is the default address of device
@@ -164,6 +165,24 @@ func (s *SHT3xDriver) Sample() (temp float32, rh float32, err error) {
 	return
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature (always in
+// celsius) and humidity
+func (s *SHT3xDriver) ReadEnvironment() (environment.Reading, error) {
+	temp, rh, err := s.Sample()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	t := float64(temp)
+	if s.Units == "F" {
+		t = (t - 32) * 5 / 9
+	}
+	return environment.Reading{
+		Time:        time.Now(),
+		Temperature: environment.Value(t),
+		Humidity:    environment.Value(float64(rh)),
+	}, nil
+}
+
 // getStatusRegister returns the device status register
 func (s *SHT3xDriver) getStatusRegister() (status uint16, err error) {
 	ret, err := s.sendCommandDelayGetResponse([]byte{0xf3, 0x2d}, nil, 1)
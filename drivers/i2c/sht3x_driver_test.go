@@ -2,10 +2,12 @@ package i2c
 
 import (
 	"errors"
+	"math"
 	"strings"
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -13,6 +15,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*SHT3xDriver)(nil)
 
+var _ environment.Environmental = (*SHT3xDriver)(nil)
+
 func initTestSHT3xDriverWithStubbedAdaptor() (*SHT3xDriver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
 	d := NewSHT3xDriver(a)
@@ -114,6 +118,25 @@ func TestSHT3xSampleUnits(t *testing.T) {
 }
 
 // Test internal sendCommandDelayGetResponse
+func TestSHT3xReadEnvironment(t *testing.T) {
+	d, a := initTestSHT3xDriverWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xbe, 0xef, 0x92, 0xbe, 0xef, 0x92})
+		return 6, nil
+	}
+
+	r, err := d.ReadEnvironment()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, *r.Temperature, float64(float32(85.523003)))
+	gobottest.Assert(t, *r.Humidity, float64(float32(74.5845)))
+
+	// the reading is always in celsius
+	d.Units = "F"
+	r, err = d.ReadEnvironment()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(*r.Temperature-85.523003) < 1e-3, true)
+}
+
 func TestSHT3xSCDGRIoFailures(t *testing.T) {
 	d, a := initTestSHT3xDriverWithStubbedAdaptor()
 	invalidRead := errors.New("Read error")
@@ -3,6 +3,8 @@ package i2c
 import (
 	"fmt"
 	"image"
+
+	"gobot.io/x/gobot/drivers/common/mono"
 )
 
 const ssd1306DefaultAddress = 0x3c
@@ -358,6 +360,49 @@ func (s *SSD1306Driver) ShowImage(img image.Image) (err error) {
 	return s.Display()
 }
 
+// NewFramebuffer creates a framebuffer with the size of the display, to be used with DrawFramebuffer().
+func (s *SSD1306Driver) NewFramebuffer() *mono.Framebuffer {
+	return mono.NewFramebuffer(s.displayWidth, s.displayHeight)
+}
+
+// DrawFramebuffer sends only the changed region of the framebuffer to the display, the memory buffer is
+// updated accordingly.
+func (s *SSD1306Driver) DrawFramebuffer(fb *mono.Framebuffer) (err error) {
+	if w, h := fb.PhysicalSize(); w != s.displayWidth || h != s.displayHeight {
+		return fmt.Errorf("framebuffer must match display width and height: %dx%d", s.displayWidth, s.displayHeight)
+	}
+	r := fb.Changed()
+	if r.Empty() {
+		return nil
+	}
+	firstPage := r.Min.Y / s.pageSize
+	lastPage := (r.Max.Y - 1) / s.pageSize
+
+	data := []byte{0x40}
+	for page := firstPage; page <= lastPage; page++ {
+		pageData := fb.PageBytes(page, r.Min.X, r.Max.X)
+		copy(s.buffer.buffer[page*s.displayWidth+r.Min.X:], pageData)
+		data = append(data, pageData...)
+	}
+
+	if err = s.commands([]byte{ssd1306ColumnAddr, byte(r.Min.X), byte(r.Max.X - 1)}); err != nil {
+		return err
+	}
+	if err = s.commands([]byte{ssd1306PageAddr, byte(firstPage), byte(lastPage)}); err != nil {
+		return err
+	}
+	if _, err = s.connection.Write(data); err != nil {
+		return err
+	}
+	fb.Commit(image.Rect(r.Min.X, firstPage*s.pageSize, r.Max.X, (lastPage+1)*s.pageSize))
+
+	// restore the full window for Display()
+	if err = s.commands([]byte{ssd1306ColumnAddr, 0, byte(s.displayWidth - 1)}); err != nil {
+		return err
+	}
+	return s.commands([]byte{ssd1306PageAddr, 0, byte(s.displayHeight/s.pageSize - 1)})
+}
+
 // command sends a command to the ssd1306
 func (s *SSD1306Driver) command(b byte) (err error) {
 	_, err = s.connection.Write([]byte{0x80, b})
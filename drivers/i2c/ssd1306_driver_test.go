@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mono"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -119,6 +120,32 @@ func TestSSD1306ShowImage(t *testing.T) {
 	gobottest.Assert(t, s.ShowImage(img), nil)
 }
 
+func TestSSD1306DrawFramebuffer(t *testing.T) {
+	s, a := initTestSSD1306DriverWithStubbedAdaptor(128, 64, false)
+	s.Start()
+	fb := s.NewFramebuffer()
+	// initial all is changed
+	gobottest.Assert(t, s.DrawFramebuffer(fb), nil)
+	a.written = []byte{}
+	gobottest.Assert(t, s.DrawFramebuffer(fb), nil)
+	gobottest.Assert(t, len(a.written), 0)
+
+	fb.SetPixel(10, 9, true)
+	fb.SetPixel(11, 17, true)
+	gobottest.Assert(t, s.DrawFramebuffer(fb), nil)
+	gobottest.Assert(t, a.written, []byte{
+		0x80, ssd1306ColumnAddr, 0x80, 10, 0x80, 11,
+		0x80, ssd1306PageAddr, 0x80, 1, 0x80, 2,
+		0x40, 0x02, 0x00, 0x00, 0x02,
+		0x80, ssd1306ColumnAddr, 0x80, 0, 0x80, 127,
+		0x80, ssd1306PageAddr, 0x80, 0, 0x80, 7,
+	})
+	gobottest.Assert(t, s.buffer.buffer[128+10], byte(0x02))
+	gobottest.Assert(t, fb.Changed().Empty(), true)
+
+	gobottest.Assert(t, s.DrawFramebuffer(mono.NewFramebuffer(96, 16)), errors.New("framebuffer must match display width and height: 128x64"))
+}
+
 func TestSSD1306Command(t *testing.T) {
 	s, a := initTestSSD1306DriverWithStubbedAdaptor(128, 64, false)
 	s.Start()
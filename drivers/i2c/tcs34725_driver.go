@@ -0,0 +1,452 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const tcs34725Debug = false
+
+// TCS34725DefaultAddress is the address of the device
+const TCS34725DefaultAddress = 0x29
+
+const (
+	tcs34725CommandBit     = 0x80
+	tcs34725AutoIncrement  = 0x20
+	tcs34725ClearInterrupt = 0x66 // special function, the command type bits 6:5 are part of the value
+
+	tcs34725RegEnable  = 0x00
+	tcs34725RegATime   = 0x01
+	tcs34725RegAILTL   = 0x04 // clear channel low threshold, followed by the high threshold, 2 bytes each
+	tcs34725RegPers    = 0x0C
+	tcs34725RegControl = 0x0F
+	tcs34725RegID      = 0x12
+	tcs34725RegCData   = 0x14 // clear, red, green and blue data, 2 bytes each
+
+	tcs34725EnablePON  = 0x01 // power on
+	tcs34725EnableAEN  = 0x02 // RGBC enable
+	tcs34725EnableAIEN = 0x10 // RGBC interrupt enable
+
+	tcs34725PersOne = 0x01 // interrupt on the first value outside of the thresholds
+
+	tcs34725CycleTime = 2400 * time.Microsecond
+
+	// coefficients of the lux and CCT calculation of the ams design note DN40 for the TCS34725 without glass
+	tcs34725DN40GA       = 1.0
+	tcs34725DN40DF       = 310.0
+	tcs34725DN40RCoef    = 0.136
+	tcs34725DN40GCoef    = 1.0
+	tcs34725DN40BCoef    = -0.444
+	tcs34725DN40CTCoef   = 3810.0
+	tcs34725DN40CTOffset = 1391.0
+)
+
+// TCS34725Gain is the type of the analog gain of the RGBC channels
+type TCS34725Gain uint8
+
+const (
+	// TCS34725Gain1X gain == 1x
+	TCS34725Gain1X TCS34725Gain = 0x00
+	// TCS34725Gain4X gain == 4x
+	TCS34725Gain4X TCS34725Gain = 0x01
+	// TCS34725Gain16X gain == 16x
+	TCS34725Gain16X TCS34725Gain = 0x02
+	// TCS34725Gain60X gain == 60x
+	TCS34725Gain60X TCS34725Gain = 0x03
+)
+
+var tcs34725GainFactor = map[TCS34725Gain]float64{
+	TCS34725Gain1X:  1,
+	TCS34725Gain4X:  4,
+	TCS34725Gain16X: 16,
+	TCS34725Gain60X: 60,
+}
+
+// TCS34725Driver is a driver for the TCS34725 RGB colour light-to-digital converter with IR filter.
+//
+// Datasheet: https://cdn-shop.adafruit.com/datasheets/TCS34725.pdf
+//
+// The lux and colour temperature calculation is done according to the design note DN40 of ams.
+type TCS34725Driver struct {
+	*Driver
+	gain          TCS34725Gain
+	atime         uint8
+	autoGain      bool
+	interruptHost gobot.DigitalPinnerProvider
+	interruptPin  string
+	enable        uint8
+	gobot.Eventer
+}
+
+// NewTCS34725Driver creates a new driver for the TCS34725 device.
+//
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithTCS34725Gain(TCS34725Gain):	sets the gain, default 4x
+//		i2c.WithTCS34725IntegrationTime(time.Duration):	sets the integration time 2.4ms..614ms, default 154ms
+//		i2c.WithTCS34725AutoGain(bool):	turns on auto gain
+//		i2c.WithTCS34725Interrupt(gobot.DigitalPinnerProvider, string):	host pin wired to the INT output
+//
+// Emits the Events:
+//		"threshold" - illuminance in lux, when the clear channel leaves the range of SetThresholds()
+//		"error" - error on handling the interrupt
+//
+func NewTCS34725Driver(c Connector, options ...func(Config)) *TCS34725Driver {
+	d := &TCS34725Driver{
+		Driver:  NewDriver(c, "TCS34725", TCS34725DefaultAddress),
+		gain:    TCS34725Gain4X,
+		atime:   tcs34725ATime(154 * time.Millisecond),
+		Eventer: gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(LightThreshold)
+	d.AddEvent(Error)
+
+	return d
+}
+
+// WithTCS34725Gain option sets the gain. Valid settings are of type "TCS34725Gain".
+func WithTCS34725Gain(gain TCS34725Gain) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TCS34725Driver)
+		if ok {
+			d.gain = gain
+		} else if tcs34725Debug {
+			log.Printf("Trying to set gain for non-TCS34725Driver %v", c)
+		}
+	}
+}
+
+// WithTCS34725IntegrationTime option sets the integration time, which is rounded to a multiple of 2.4ms.
+// Valid settings are of type "time.Duration" in the range 2.4ms..614.4ms.
+func WithTCS34725IntegrationTime(integrationTime time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TCS34725Driver)
+		if ok {
+			d.atime = tcs34725ATime(integrationTime)
+		} else if tcs34725Debug {
+			log.Printf("Trying to set integration time for non-TCS34725Driver %v", c)
+		}
+	}
+}
+
+// WithTCS34725AutoGain option turns on the auto gain. The gain is adjusted before the measurement to avoid saturation.
+func WithTCS34725AutoGain(on bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TCS34725Driver)
+		if ok {
+			d.autoGain = on
+		} else if tcs34725Debug {
+			log.Printf("Trying to set auto gain for non-TCS34725Driver %v", c)
+		}
+	}
+}
+
+// WithTCS34725Interrupt option sets the host pin, which is wired to the INT output of the TCS34725. The pin needs to
+// support edge detection.
+func WithTCS34725Interrupt(host gobot.DigitalPinnerProvider, pinID string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TCS34725Driver)
+		if ok {
+			d.interruptHost = host
+			d.interruptPin = pinID
+		} else if tcs34725Debug {
+			log.Printf("Trying to set interrupt for non-TCS34725Driver %v", c)
+		}
+	}
+}
+
+// Gain returns the current gain
+func (d *TCS34725Driver) Gain() TCS34725Gain {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.gain
+}
+
+// SetGain sets the gain of the RGBC channels
+func (d *TCS34725Driver) SetGain(gain TCS34725Gain) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeGain(gain)
+}
+
+// IntegrationTime returns the current integration time
+func (d *TCS34725Driver) IntegrationTime() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.integrationTime()
+}
+
+// SetIntegrationTime sets the integration time, which is rounded to a multiple of 2.4ms, range 2.4ms..614.4ms
+func (d *TCS34725Driver) SetIntegrationTime(integrationTime time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	atime := tcs34725ATime(integrationTime)
+	if err := d.connection.WriteByteData(tcs34725CommandBit|tcs34725RegATime, atime); err != nil {
+		return err
+	}
+	d.atime = atime
+	return nil
+}
+
+// RGBC returns the raw values of the red, green, blue and clear channel. If auto gain is on, the gain is adjusted
+// before.
+func (d *TCS34725Driver) RGBC() (r, g, b, c uint16, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readRGBC()
+}
+
+// Illuminance returns the ambient light in lux, implements the LightSensor interface
+func (d *TCS34725Driver) Illuminance() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	lux, _, err := d.readLuxAndCCT()
+	return lux, err
+}
+
+// ColorTemperature returns the correlated colour temperature in Kelvin, implements the ColorTemperatureSensor
+// interface
+func (d *TCS34725Driver) ColorTemperature() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	_, cct, err := d.readLuxAndCCT()
+	if err == nil && cct == 0 {
+		err = fmt.Errorf("TCS34725 colour temperature not available, too little red light")
+	}
+	return cct, err
+}
+
+// SetThresholds activates the interrupt for clear channel values below low or above high. The values are raw counts
+// for the current gain and integration time, so this should not be combined with auto gain. With
+// WithTCS34725Interrupt() the "threshold" event is published on each interrupt.
+func (d *TCS34725Driver) SetThresholds(low uint16, high uint16) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if low >= high {
+		return fmt.Errorf("low threshold %d needs to be less than high threshold %d", low, high)
+	}
+	thresholds := []byte{byte(low), byte(low >> 8), byte(high), byte(high >> 8)}
+	for i, val := range thresholds {
+		if err := d.connection.WriteByteData(tcs34725CommandBit|(tcs34725RegAILTL+uint8(i)), val); err != nil {
+			return err
+		}
+	}
+	if err := d.connection.WriteByteData(tcs34725CommandBit|tcs34725RegPers, tcs34725PersOne); err != nil {
+		return err
+	}
+	if err := d.writeEnable(d.enable | tcs34725EnableAIEN); err != nil {
+		return err
+	}
+	return d.clearInterrupt()
+}
+
+// DisableThresholds deactivates the interrupt
+func (d *TCS34725Driver) DisableThresholds() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.writeEnable(d.enable &^ tcs34725EnableAIEN); err != nil {
+		return err
+	}
+	return d.clearInterrupt()
+}
+
+func (d *TCS34725Driver) initialize() error {
+	id, err := d.connection.ReadByteData(tcs34725CommandBit | tcs34725RegID)
+	if err != nil {
+		return err
+	}
+	if id != 0x44 && id != 0x4D {
+		return fmt.Errorf("TCS34725 device not found (0x%X)", id)
+	}
+	if err := d.connection.WriteByteData(tcs34725CommandBit|tcs34725RegATime, d.atime); err != nil {
+		return err
+	}
+	if err := d.writeGain(d.gain); err != nil {
+		return err
+	}
+	// the oscillator needs 2.4ms after power on, before the RGBC can be enabled
+	if err := d.writeEnable(tcs34725EnablePON); err != nil {
+		return err
+	}
+	time.Sleep(3 * time.Millisecond)
+	if err := d.writeEnable(tcs34725EnablePON | tcs34725EnableAEN); err != nil {
+		return err
+	}
+	time.Sleep(d.integrationTime())
+
+	if d.interruptHost != nil {
+		return attachHostInterrupt(d.interruptHost, d.interruptPin, false, d.onInterrupt)
+	}
+	return nil
+}
+
+func (d *TCS34725Driver) shutdown() error {
+	return d.writeEnable(0)
+}
+
+func (d *TCS34725Driver) writeEnable(val uint8) error {
+	if err := d.connection.WriteByteData(tcs34725CommandBit|tcs34725RegEnable, val); err != nil {
+		return err
+	}
+	d.enable = val
+	return nil
+}
+
+func (d *TCS34725Driver) writeGain(gain TCS34725Gain) error {
+	if _, ok := tcs34725GainFactor[gain]; !ok {
+		return fmt.Errorf("invalid gain %d for TCS34725", gain)
+	}
+	if err := d.connection.WriteByteData(tcs34725CommandBit|tcs34725RegControl, uint8(gain)); err != nil {
+		return err
+	}
+	d.gain = gain
+	return nil
+}
+
+func (d *TCS34725Driver) clearInterrupt() error {
+	return d.connection.WriteByte(tcs34725CommandBit | tcs34725ClearInterrupt)
+}
+
+// onInterrupt reads the illuminance, clears the interrupt and publishes the value
+func (d *TCS34725Driver) onInterrupt() {
+	d.mutex.Lock()
+	lux, _, err := d.readLuxAndCCT()
+	if clearErr := d.clearInterrupt(); err == nil {
+		err = clearErr
+	}
+	d.mutex.Unlock()
+
+	if err != nil {
+		d.Publish(d.Event(Error), err)
+		return
+	}
+	d.Publish(d.Event(LightThreshold), lux)
+}
+
+// readRGBC reads all channels with one block read, the gain is adjusted before, if auto gain is on
+func (d *TCS34725Driver) readRGBC() (r, g, b, c uint16, err error) {
+	if r, g, b, c, err = d.readChannels(); err != nil || !d.autoGain {
+		return
+	}
+	// at most 3 steps are needed from 60x to 1x
+	for i := 0; i < 3; i++ {
+		next := d.gain
+		if c > d.saturation()*8/10 && d.gain > TCS34725Gain1X {
+			next = d.gain - 1
+		} else if c < d.saturation()/100 && d.gain < TCS34725Gain60X {
+			next = d.gain + 1
+		}
+		if next == d.gain {
+			return
+		}
+		if err = d.writeGain(next); err != nil {
+			return
+		}
+		// the values with the new gain are available after the next cycle
+		time.Sleep(2*d.integrationTime() + tcs34725CycleTime)
+		if r, g, b, c, err = d.readChannels(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (d *TCS34725Driver) readChannels() (r, g, b, c uint16, err error) {
+	buf := make([]byte, 8)
+	if err = d.connection.ReadBlockData(tcs34725CommandBit|tcs34725AutoIncrement|tcs34725RegCData, buf); err != nil {
+		return
+	}
+	c = uint16(buf[1])<<8 | uint16(buf[0])
+	r = uint16(buf[3])<<8 | uint16(buf[2])
+	g = uint16(buf[5])<<8 | uint16(buf[4])
+	b = uint16(buf[7])<<8 | uint16(buf[6])
+	return
+}
+
+// readLuxAndCCT calculates the illuminance and the colour temperature according to DN40, the colour temperature
+// is 0, if not available
+func (d *TCS34725Driver) readLuxAndCCT() (float64, float64, error) {
+	r, g, b, c, err := d.readRGBC()
+	if err != nil {
+		return 0, 0, err
+	}
+	if c >= d.saturation() {
+		return 0, 0, fmt.Errorf("TCS34725 is saturated (clear: %d)", c)
+	}
+	// remove the IR component
+	ir := (float64(r) + float64(g) + float64(b) - float64(c)) / 2
+	if ir < 0 {
+		ir = 0
+	}
+	rc := float64(r) - ir
+	gc := float64(g) - ir
+	bc := float64(b) - ir
+
+	cpl := float64(d.integrationTime().Microseconds()) / 1000 * tcs34725GainFactor[d.gain] /
+		(tcs34725DN40GA * tcs34725DN40DF)
+	lux := (tcs34725DN40RCoef*rc + tcs34725DN40GCoef*gc + tcs34725DN40BCoef*bc) / cpl
+	if lux < 0 {
+		lux = 0
+	}
+
+	if rc <= 0 {
+		// colour temperature not available
+		return lux, 0, nil
+	}
+	cct := tcs34725DN40CTCoef*bc/rc + tcs34725DN40CTOffset
+	return lux, cct, nil
+}
+
+// saturation returns the maximum count of the clear channel, for short integration times the analog saturation
+// is reached at 75% of the digital saturation
+func (d *TCS34725Driver) saturation() uint16 {
+	cycles := 256 - int(d.atime)
+	sat := cycles * 1024
+	if sat > math.MaxUint16 {
+		sat = math.MaxUint16
+	}
+	if cycles < 64 {
+		sat = sat * 3 / 4
+	}
+	return uint16(sat)
+}
+
+func (d *TCS34725Driver) integrationTime() time.Duration {
+	return time.Duration(256-int(d.atime)) * tcs34725CycleTime
+}
+
+// tcs34725ATime converts the integration time to the register value, the number of cycles is 256 - ATIME
+func tcs34725ATime(integrationTime time.Duration) uint8 {
+	cycles := int(math.Round(float64(integrationTime) / float64(tcs34725CycleTime)))
+	if cycles < 1 {
+		cycles = 1
+	}
+	if cycles > 256 {
+		cycles = 256
+	}
+	return uint8(256 - cycles)
+}
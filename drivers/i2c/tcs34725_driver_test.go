@@ -0,0 +1,253 @@
+package i2c
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*TCS34725Driver)(nil)
+
+type tcs34725TestRegisters struct {
+	mtx              sync.Mutex
+	regs             [32]byte
+	interruptCleared int
+}
+
+// simulateTCS34725Registers simulates the register access with command byte, auto increment and special functions
+func simulateTCS34725Registers(a *i2cTestAdaptor) *tcs34725TestRegisters {
+	r := &tcs34725TestRegisters{}
+	r.regs[tcs34725RegID] = 0x44
+	var ptr int
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		if b[0]&0x60 == 0x60 {
+			r.interruptCleared++
+			return len(b), nil
+		}
+		ptr = int(b[0] & 0x1F)
+		copy(r.regs[ptr:], b[1:])
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		return copy(b, r.regs[ptr:]), nil
+	}
+	return r
+}
+
+func (r *tcs34725TestRegisters) setRGBC(red, green, blue, clear uint16) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for i, v := range []uint16{clear, red, green, blue} {
+		r.regs[tcs34725RegCData+2*i] = byte(v)
+		r.regs[tcs34725RegCData+2*i+1] = byte(v >> 8)
+	}
+}
+
+func initTestTCS34725DriverWithRegisters(options ...func(Config)) (*TCS34725Driver, *tcs34725TestRegisters) {
+	a := newI2cTestAdaptor()
+	regs := simulateTCS34725Registers(a)
+	options = append([]func(Config){WithTCS34725IntegrationTime(24 * time.Millisecond)}, options...)
+	d := NewTCS34725Driver(a, options...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, regs
+}
+
+func TestNewTCS34725Driver(t *testing.T) {
+	var di interface{} = NewTCS34725Driver(newI2cTestAdaptor())
+	d, ok := di.(*TCS34725Driver)
+	if !ok {
+		t.Errorf("NewTCS34725Driver() should have returned a *TCS34725Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "TCS34725"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x29)
+	gobottest.Assert(t, d.gain, TCS34725Gain4X)
+	gobottest.Assert(t, d.atime, uint8(0xC0))
+	gobottest.Assert(t, d.autoGain, false)
+}
+
+func TestTCS34725Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewTCS34725Driver(newI2cTestAdaptor(), WithBus(2), WithTCS34725Gain(TCS34725Gain60X),
+		WithTCS34725IntegrationTime(700*time.Millisecond), WithTCS34725AutoGain(true))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.gain, TCS34725Gain60X)
+	gobottest.Assert(t, d.atime, uint8(0x00))
+	gobottest.Assert(t, d.IntegrationTime(), 614400*time.Microsecond)
+	gobottest.Assert(t, d.autoGain, true)
+}
+
+func TestTCS34725Start(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	regs := simulateTCS34725Registers(a)
+	d := NewTCS34725Driver(a, WithTCS34725IntegrationTime(2400*time.Microsecond), WithTCS34725Gain(TCS34725Gain16X))
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, regs.regs[tcs34725RegATime], uint8(0xFF))
+	gobottest.Assert(t, regs.regs[tcs34725RegControl], uint8(0x02))
+	gobottest.Assert(t, regs.regs[tcs34725RegEnable], uint8(0x03))
+	// act & assert: halt powers off
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, regs.regs[tcs34725RegEnable], uint8(0x00))
+}
+
+func TestTCS34725StartNotFound(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	regs := simulateTCS34725Registers(a)
+	regs.regs[tcs34725RegID] = 0x10
+	d := NewTCS34725Driver(a)
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err.Error(), "TCS34725 device not found (0x10)")
+}
+
+func TestTCS34725RGBC(t *testing.T) {
+	// arrange
+	d, regs := initTestTCS34725DriverWithRegisters()
+	regs.setRGBC(400, 401, 300, 1000)
+	// act
+	r, g, b, c, err := d.RGBC()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, []uint16{r, g, b, c}, []uint16{400, 401, 300, 1000})
+}
+
+func TestTCS34725IlluminanceAndColorTemperature(t *testing.T) {
+	// arrange
+	d, regs := initTestTCS34725DriverWithRegisters()
+	regs.setRGBC(400, 400, 300, 1000)
+	// act
+	lux, errLux := d.Illuminance()
+	cct, errCCT := d.ColorTemperature()
+	// assert
+	gobottest.Assert(t, errLux, nil)
+	gobottest.Assert(t, errCCT, nil)
+	// ir = 50, r' = 350, g' = 350, b' = 250, CPL = 24ms * 4 / 310
+	gobottest.Assert(t, math.Abs(lux-286.6/(96.0/310.0)) < 1e-9, true)
+	gobottest.Assert(t, math.Abs(cct-(3810.0*250/350+1391)) < 1e-9, true)
+}
+
+func TestTCS34725Saturated(t *testing.T) {
+	// arrange
+	d, regs := initTestTCS34725DriverWithRegisters()
+	// 10 cycles, analog saturation 75% of 10240
+	regs.setRGBC(1000, 1000, 1000, 7680)
+	// act
+	_, err := d.Illuminance()
+	// assert
+	gobottest.Assert(t, err.Error(), "TCS34725 is saturated (clear: 7680)")
+}
+
+func TestTCS34725ColorTemperatureNotAvailable(t *testing.T) {
+	// arrange
+	d, regs := initTestTCS34725DriverWithRegisters()
+	regs.setRGBC(0, 100, 100, 100)
+	// act
+	_, err := d.ColorTemperature()
+	// assert
+	gobottest.Assert(t, err.Error(), "TCS34725 colour temperature not available, too little red light")
+}
+
+func TestTCS34725AutoGain(t *testing.T) {
+	var tests = map[string]struct {
+		clear    uint16
+		gain     TCS34725Gain
+		wantGain TCS34725Gain
+	}{
+		"bright_to_1x": {clear: 7000, gain: TCS34725Gain16X, wantGain: TCS34725Gain1X},
+		"dim_to_60x":   {clear: 20, gain: TCS34725Gain4X, wantGain: TCS34725Gain60X},
+		"valid":        {clear: 3000, gain: TCS34725Gain4X, wantGain: TCS34725Gain4X},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, regs := initTestTCS34725DriverWithRegisters(WithTCS34725AutoGain(true), WithTCS34725Gain(tc.gain))
+			regs.setRGBC(tc.clear/3, tc.clear/3, tc.clear/3, tc.clear)
+			// act
+			_, _, _, c, err := d.RGBC()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, c, tc.clear)
+			gobottest.Assert(t, d.Gain(), tc.wantGain)
+			gobottest.Assert(t, regs.regs[tcs34725RegControl], uint8(tc.wantGain))
+		})
+	}
+}
+
+func TestTCS34725SetGainAndIntegrationTime(t *testing.T) {
+	// arrange
+	d, regs := initTestTCS34725DriverWithRegisters()
+	// act & assert
+	gobottest.Assert(t, d.SetGain(TCS34725Gain60X), nil)
+	gobottest.Assert(t, regs.regs[tcs34725RegControl], uint8(0x03))
+	gobottest.Assert(t, d.SetGain(TCS34725Gain(4)).Error(), "invalid gain 4 for TCS34725")
+	gobottest.Assert(t, d.SetIntegrationTime(101*time.Millisecond), nil)
+	gobottest.Assert(t, regs.regs[tcs34725RegATime], uint8(0xD6))
+	gobottest.Assert(t, d.IntegrationTime(), 100800*time.Microsecond)
+}
+
+func TestTCS34725SetThresholds(t *testing.T) {
+	// arrange
+	d, regs := initTestTCS34725DriverWithRegisters()
+	// act
+	err := d.SetThresholds(0x0102, 0x0A0B)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, regs.regs[tcs34725RegAILTL:tcs34725RegAILTL+4], []byte{0x02, 0x01, 0x0B, 0x0A})
+	gobottest.Assert(t, regs.regs[tcs34725RegPers], uint8(0x01))
+	gobottest.Assert(t, regs.regs[tcs34725RegEnable], uint8(0x13))
+	gobottest.Assert(t, regs.interruptCleared, 1)
+	// act & assert: disable
+	gobottest.Assert(t, d.DisableThresholds(), nil)
+	gobottest.Assert(t, regs.regs[tcs34725RegEnable], uint8(0x03))
+	gobottest.Assert(t, regs.interruptCleared, 2)
+	gobottest.Assert(t, d.SetThresholds(5, 1).Error(), "low threshold 5 needs to be less than high threshold 1")
+}
+
+func TestTCS34725Interrupt(t *testing.T) {
+	// arrange
+	host := newGpioExpanderTestHost()
+	d, regs := initTestTCS34725DriverWithRegisters(WithTCS34725Interrupt(host, "13"))
+	gobottest.Assert(t, host.pins["13"].input, true)
+	gobottest.Assert(t, host.pins["13"].edge, 1)
+	regs.setRGBC(400, 400, 300, 1000)
+	sem := make(chan float64, 1)
+	_ = d.Once(LightThreshold, func(data interface{}) { sem <- data.(float64) })
+	// act
+	host.pins["13"].trigger()
+	// assert
+	select {
+	case lux := <-sem:
+		gobottest.Assert(t, math.Abs(lux-286.6/(96.0/310.0)) < 1e-9, true)
+	case <-time.After(time.Second):
+		t.Errorf("TCS34725 threshold event was not published")
+	}
+	gobottest.Assert(t, regs.interruptCleared, 1)
+}
+
+func TestTCS34725ATime(t *testing.T) {
+	gobottest.Assert(t, tcs34725ATime(0), uint8(0xFF))
+	gobottest.Assert(t, tcs34725ATime(2400*time.Microsecond), uint8(0xFF))
+	gobottest.Assert(t, tcs34725ATime(24*time.Millisecond), uint8(0xF6))
+	gobottest.Assert(t, tcs34725ATime(154*time.Millisecond), uint8(0xC0))
+	gobottest.Assert(t, tcs34725ATime(time.Second), uint8(0x00))
+}
@@ -26,6 +26,8 @@ import (
 	"fmt"
 	"log"
 	"time"
+
+	"gobot.io/x/gobot/drivers/common/environment"
 )
 
 const (
@@ -186,6 +188,24 @@ func (s *TH02Driver) Sample() (temperature float32, relhumidity float32, _ error
 
 }
 
+// ReadEnvironment implements the environment.Environmental interface and returns temperature (always in
+// celsius) and humidity
+func (s *TH02Driver) ReadEnvironment() (environment.Reading, error) {
+	temp, rh, err := s.Sample()
+	if err != nil {
+		return environment.Reading{}, err
+	}
+	t := float64(temp)
+	if s.Units == "F" {
+		t = (t - 32) * 5 / 9
+	}
+	return environment.Reading{
+		Time:        time.Now(),
+		Temperature: environment.Value(t),
+		Humidity:    environment.Value(float64(rh)),
+	}, nil
+}
+
 func (s *TH02Driver) createConfig(measurement bool, readTemp bool) byte {
 	cfg := byte(0x00)
 	if measurement {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/environment"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -14,6 +15,8 @@ import (
 // and tests all implementations, so no further tests needed here for gobot.Driver interface
 var _ gobot.Driver = (*TH02Driver)(nil)
 
+var _ environment.Environmental = (*TH02Driver)(nil)
+
 func initTestTH02DriverWithStubbedAdaptor() (*TH02Driver, *i2cTestAdaptor) {
 	adaptor := newI2cTestAdaptor()
 	driver := NewTH02Driver(adaptor)
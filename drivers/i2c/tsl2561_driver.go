@@ -3,6 +3,8 @@ package i2c
 import (
 	"fmt"
 	"time"
+
+	"gobot.io/x/gobot"
 )
 
 const (
@@ -21,6 +23,9 @@ const (
 	tsl2561ControlPowerOn  = 0x03
 	tsl2561ControlPowerOff = 0x00
 
+	tsl2561InterruptLevel   = 0x10 // level interrupt, active low
+	tsl2561InterruptPersist = 0x01 // interrupt on the first value outside of the thresholds
+
 	tsl2561LuxLuxScale     = 14     // Scale by 2^14
 	tsl2561LuxRatioScale   = 9      // Scale ratio by 2^9
 	tsl2561LuxChScale      = 10     // Scale channel values by 2^10
@@ -113,9 +118,27 @@ const (
 // K. Townsend
 type TSL2561Driver struct {
 	*Driver
-	autoGain        bool
+	autoGain          bool
+	autoRange         bool
+	gain              TSL2561Gain
+	integrationTime   TSL2561IntegrationTime
+	interruptHost     gobot.DigitalPinnerProvider
+	interruptPin      string
+	thresholdsEnabled bool
+	gobot.Eventer
+}
+
+// tsl2561Ranges contains all combinations of gain and integration time, ordered by the sensitivity
+var tsl2561Ranges = []struct {
 	gain            TSL2561Gain
 	integrationTime TSL2561IntegrationTime
+}{
+	{gain: TSL2561Gain1X, integrationTime: TSL2561IntegrationTime13MS},
+	{gain: TSL2561Gain1X, integrationTime: TSL2561IntegrationTime101MS},
+	{gain: TSL2561Gain16X, integrationTime: TSL2561IntegrationTime13MS},
+	{gain: TSL2561Gain1X, integrationTime: TSL2561IntegrationTime402MS},
+	{gain: TSL2561Gain16X, integrationTime: TSL2561IntegrationTime101MS},
+	{gain: TSL2561Gain16X, integrationTime: TSL2561IntegrationTime402MS},
 }
 
 // NewTSL2561Driver creates a new driver for the TSL2561 device.
@@ -129,16 +152,23 @@ type TSL2561Driver struct {
 //		i2c.WithTSL2561Gain1X:		sets the gain to 1X
 //		i2c.WithTSL2561Gain16X:		sets the gain to 16X
 //		i2c.WithTSL2561AutoGain:	turns on auto gain
+//		i2c.WithTSL2561AutoRange:	turns on auto ranging of gain and integration time
+//		i2c.WithTSL2561Interrupt(gobot.DigitalPinnerProvider, string):	host pin wired to the INT output
 //		i2c.WithTSL2561IntegrationTime13MS:	sets integration time to 13ms
 //		i2c.WithTSL2561IntegrationTime101MS: 	sets integration time to 101ms
 //		i2c.WithTSL2561IntegrationTime402MS: 	sets integration time to 402ms
 //
+// Emits the Events:
+//		"threshold" - illuminance in lux, when the broadband value leaves the range of SetThresholds()
+//		"error" - error on handling the interrupt
+//
 func NewTSL2561Driver(c Connector, options ...func(Config)) *TSL2561Driver {
 	d := &TSL2561Driver{
 		Driver:          NewDriver(c, "TSL2561", TSL2561AddressFloat),
 		integrationTime: TSL2561IntegrationTime402MS,
 		gain:            TSL2561Gain1X,
 		autoGain:        false,
+		Eventer:         gobot.NewEventer(),
 	}
 	d.afterStart = d.initialize
 
@@ -146,6 +176,9 @@ func NewTSL2561Driver(c Connector, options ...func(Config)) *TSL2561Driver {
 		option(d)
 	}
 
+	d.AddEvent(LightThreshold)
+	d.AddEvent(Error)
+
 	return d
 }
 
@@ -179,6 +212,31 @@ func WithTSL2561AutoGain(c Config) {
 	// TODO: return errors.New("Trying to set Auto Gain for non-TSL2561Driver")
 }
 
+// WithTSL2561AutoRange option turns on TSL2561Driver auto ranging. In contrast to auto gain, also the integration
+// time is adjusted, so saturation is avoided in bright light and the resolution is increased in dim light.
+func WithTSL2561AutoRange(c Config) {
+	d, ok := c.(*TSL2561Driver)
+	if ok {
+		d.autoRange = true
+		return
+	}
+	// TODO: return errors.New("Trying to set Auto Range for non-TSL2561Driver")
+}
+
+// WithTSL2561Interrupt option sets the host pin, which is wired to the INT output of the TSL2561. The pin needs to
+// support edge detection.
+func WithTSL2561Interrupt(host gobot.DigitalPinnerProvider, pinID string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TSL2561Driver)
+		if ok {
+			d.interruptHost = host
+			d.interruptPin = pinID
+			return
+		}
+		// TODO: return errors.New("Trying to set interrupt for non-TSL2561Driver")
+	}
+}
+
 func withTSL2561IntegrationTime(iTime TSL2561IntegrationTime) func(Config) {
 	return func(c Config) {
 		d, ok := c.(*TSL2561Driver)
@@ -210,37 +268,107 @@ func WithTSL2561IntegrationTime402MS(c Config) {
 
 // SetIntegrationTime sets integrations time for the TSL2561
 func (d *TSL2561Driver) SetIntegrationTime(time TSL2561IntegrationTime) error {
-	if err := d.enable(); err != nil {
-		return err
-	}
-
-	timeGainVal := uint8(time) | uint8(d.gain)
-	if err := d.connection.WriteByteData(tsl2561CommandBit|tsl2561RegisterTiming, timeGainVal); err != nil {
-		return err
-	}
-	d.integrationTime = time
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
-	return d.disable()
+	return d.writeIntegrationTime(time)
 }
 
 // SetGain adjusts the TSL2561 gain (sensitivity to light)
 func (d *TSL2561Driver) SetGain(gain TSL2561Gain) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeGain(gain)
+}
+
+// SetThresholds activates the interrupt for broadband values (channel 0) below low or above high. The values are
+// raw counts for the current gain and integration time, so this should not be combined with auto ranging. The
+// device stays powered on afterwards, which is needed for the interrupt. With WithTSL2561Interrupt() the
+// "threshold" event is published on each interrupt.
+func (d *TSL2561Driver) SetThresholds(low uint16, high uint16) error {
+	if low >= high {
+		return fmt.Errorf("low threshold %d needs to be less than high threshold %d", low, high)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	if err := d.enable(); err != nil {
 		return err
 	}
-
-	timeGainVal := uint8(d.integrationTime) | uint8(gain)
-	if err := d.connection.WriteByteData(tsl2561CommandBit|tsl2561RegisterTiming, timeGainVal); err != nil {
+	if err := d.connection.WriteWordData(tsl2561CommandBit|tsl2561WordBit|tsl2561RegisterThreshholdLLow, low); err != nil {
 		return err
 	}
-	d.gain = gain
+	if err := d.connection.WriteWordData(tsl2561CommandBit|tsl2561WordBit|tsl2561RegisterThreshholdHLow, high); err != nil {
+		return err
+	}
+	interrupt := uint8(tsl2561InterruptLevel | tsl2561InterruptPersist)
+	if err := d.connection.WriteByteData(tsl2561CommandBit|tsl2561RegisterInterrupt, interrupt); err != nil {
+		return err
+	}
+	d.thresholdsEnabled = true
+	return d.clearInterrupt()
+}
+
+// DisableThresholds deactivates the interrupt and powers off the device
+func (d *TSL2561Driver) DisableThresholds() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 
+	if err := d.connection.WriteByteData(tsl2561CommandBit|tsl2561RegisterInterrupt, 0); err != nil {
+		return err
+	}
+	if err := d.clearInterrupt(); err != nil {
+		return err
+	}
+	d.thresholdsEnabled = false
 	return d.disable()
 }
 
+// Illuminance returns the ambient light in lux, implements the LightSensor interface
+func (d *TSL2561Driver) Illuminance() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readIlluminance()
+}
+
 // GetLuminocity gets the broadband and IR only values from the TSL2561,
-// adjusting gain if auto-gain is enabled
+// adjusting gain if auto-gain is enabled, or gain and integration time if auto-range is enabled
 func (d *TSL2561Driver) GetLuminocity() (broadband uint16, ir uint16, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readLuminocity()
+}
+
+// CalculateLux converts raw sensor values to the standard SI Lux equivalent.
+// Returns 65536 if the sensor is saturated.
+func (d *TSL2561Driver) CalculateLux(broadband uint16, ir uint16) (lux uint32) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.calculateLux(broadband, ir)
+}
+
+func (d *TSL2561Driver) readIlluminance() (float64, error) {
+	broadband, ir, err := d.readLuminocity()
+	if err != nil {
+		return 0, err
+	}
+	lux := d.calculateLux(broadband, ir)
+	if lux == 65536 {
+		return 0, fmt.Errorf("TSL2561 is saturated (broadband: %d, ir: %d)", broadband, ir)
+	}
+	return float64(lux), nil
+}
+
+func (d *TSL2561Driver) readLuminocity() (broadband uint16, ir uint16, err error) {
+	if d.autoRange {
+		return d.getDataAutoRange()
+	}
+
 	// if auto gain disabled get a single reading and continue
 	if !d.autoGain {
 		broadband, ir, err = d.getData()
@@ -262,14 +390,14 @@ func (d *TSL2561Driver) GetLuminocity() (broadband uint16, ir uint16, err error)
 		if !agcCheck {
 			if (broadband < lo) && (d.gain == TSL2561Gain1X) {
 				// increase gain and try again
-				err = d.SetGain(TSL2561Gain16X)
+				err = d.writeGain(TSL2561Gain16X)
 				if err != nil {
 					return
 				}
 				agcCheck = true
 			} else if (broadband > hi) && (d.gain == TSL2561Gain16X) {
 				// drop gain and try again
-				err = d.SetGain(TSL2561Gain1X)
+				err = d.writeGain(TSL2561Gain1X)
 				if err != nil {
 					return
 				}
@@ -294,9 +422,7 @@ func (d *TSL2561Driver) GetLuminocity() (broadband uint16, ir uint16, err error)
 	return
 }
 
-// CalculateLux converts raw sensor values to the standard SI Lux equivalent.
-// Returns 65536 if the sensor is saturated.
-func (d *TSL2561Driver) CalculateLux(broadband uint16, ir uint16) (lux uint32) {
+func (d *TSL2561Driver) calculateLux(broadband uint16, ir uint16) (lux uint32) {
 	var channel1 uint32
 	var channel0 uint32
 
@@ -342,12 +468,44 @@ func (d *TSL2561Driver) CalculateLux(broadband uint16, ir uint16) (lux uint32) {
 	return lux
 }
 
+func (d *TSL2561Driver) writeIntegrationTime(time TSL2561IntegrationTime) error {
+	if err := d.enable(); err != nil {
+		return err
+	}
+
+	timeGainVal := uint8(time) | uint8(d.gain)
+	if err := d.connection.WriteByteData(tsl2561CommandBit|tsl2561RegisterTiming, timeGainVal); err != nil {
+		return err
+	}
+	d.integrationTime = time
+
+	return d.disable()
+}
+
+func (d *TSL2561Driver) writeGain(gain TSL2561Gain) error {
+	if err := d.enable(); err != nil {
+		return err
+	}
+
+	timeGainVal := uint8(d.integrationTime) | uint8(gain)
+	if err := d.connection.WriteByteData(tsl2561CommandBit|tsl2561RegisterTiming, timeGainVal); err != nil {
+		return err
+	}
+	d.gain = gain
+
+	return d.disable()
+}
+
 func (d *TSL2561Driver) enable() (err error) {
 	err = d.connection.WriteByteData(uint8(tsl2561CommandBit|tsl2561RegisterControl), tsl2561ControlPowerOn)
 	return err
 }
 
 func (d *TSL2561Driver) disable() (err error) {
+	if d.thresholdsEnabled {
+		// the interrupt needs a powered device
+		return nil
+	}
 	err = d.connection.WriteByteData(uint8(tsl2561CommandBit|tsl2561RegisterControl), tsl2561ControlPowerOff)
 	return err
 }
@@ -376,6 +534,66 @@ func (d *TSL2561Driver) getData() (broadband uint16, ir uint16, err error) {
 	return
 }
 
+// getDataAutoRange reads the data and steps through the ranges, until the broadband value is in the valid range of
+// the integration time or the end of the ranges is reached
+func (d *TSL2561Driver) getDataAutoRange() (broadband uint16, ir uint16, err error) {
+	idx := d.rangeIndex()
+	prevIdx := -1
+	for i := 0; i < len(tsl2561Ranges); i++ {
+		if broadband, ir, err = d.getData(); err != nil {
+			return
+		}
+		hi, lo := d.getHiLo()
+		next := idx
+		if broadband > hi && idx > 0 {
+			next = idx - 1
+		} else if broadband < lo && idx < len(tsl2561Ranges)-1 {
+			next = idx + 1
+		}
+		// the second condition avoids toggling between two ranges
+		if next == idx || next == prevIdx {
+			return
+		}
+		prevIdx = idx
+		idx = next
+		d.gain = tsl2561Ranges[idx].gain
+		if err = d.writeIntegrationTime(tsl2561Ranges[idx].integrationTime); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// rangeIndex returns the index of the current gain and integration time in the ranges
+func (d *TSL2561Driver) rangeIndex() int {
+	for i, r := range tsl2561Ranges {
+		if r.gain == d.gain && r.integrationTime == d.integrationTime {
+			return i
+		}
+	}
+	return 0
+}
+
+func (d *TSL2561Driver) clearInterrupt() error {
+	return d.connection.WriteByte(tsl2561CommandBit | tsl2561ClearBit)
+}
+
+// onInterrupt reads the illuminance, clears the interrupt and publishes the value
+func (d *TSL2561Driver) onInterrupt() {
+	d.mutex.Lock()
+	lux, err := d.readIlluminance()
+	if clearErr := d.clearInterrupt(); err == nil {
+		err = clearErr
+	}
+	d.mutex.Unlock()
+
+	if err != nil {
+		d.Publish(d.Event(Error), err)
+		return
+	}
+	d.Publish(d.Event(LightThreshold), lux)
+}
+
 func (d *TSL2561Driver) getHiLo() (hi, lo uint16) {
 	switch d.integrationTime {
 	case TSL2561IntegrationTime13MS:
@@ -459,11 +677,11 @@ func (d *TSL2561Driver) initialize() error {
 		return fmt.Errorf("TSL2561 device not found (0x%X)", initialized)
 	}
 
-	if err := d.SetIntegrationTime(d.integrationTime); err != nil {
+	if err := d.writeIntegrationTime(d.integrationTime); err != nil {
 		return err
 	}
 
-	if err := d.SetGain(d.gain); err != nil {
+	if err := d.writeGain(d.gain); err != nil {
 		return err
 	}
 
@@ -471,5 +689,9 @@ func (d *TSL2561Driver) initialize() error {
 		return err
 	}
 
+	if d.interruptHost != nil {
+		return attachHostInterrupt(d.interruptHost, d.interruptPin, false, d.onInterrupt)
+	}
+
 	return nil
 }
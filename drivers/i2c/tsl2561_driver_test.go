@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -331,3 +333,164 @@ func TestTSL2561getBM(t *testing.T) {
 	gobottest.Assert(t, b, uint32(tsl2561LuxB8T))
 	gobottest.Assert(t, m, uint32(tsl2561LuxM8T))
 }
+
+// simulateTSL2561 returns channel values for the given light level, which depend on the gain and integration time
+// written to the timing register. The light level is given in counts for gain 1x and 402ms.
+func simulateTSL2561(a *i2cTestAdaptor, level float64) {
+	var timing, reg uint8
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		reg = b[0]
+		if len(b) == 2 && b[0] == tsl2561CommandBit|tsl2561RegisterTiming {
+			timing = b[1]
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		if reg == tsl2561RegisterID {
+			b[0] = 0x0A
+			return 1, nil
+		}
+		factor, max := map[uint8]float64{0: 0.034, 1: 0.252, 2: 1}[timing&0x03], map[uint8]float64{0: 5047, 1: 37177, 2: 65535}[timing&0x03]
+		if timing&0x10 != 0 {
+			factor *= 16
+		}
+		val := math.Min(level*factor, max)
+		if reg == tsl2561CommandBit|tsl2561WordBit|tsl2561RegisterChan1Low {
+			val = val / 2
+		}
+		binary.LittleEndian.PutUint16(b, uint16(val))
+		return 2, nil
+	}
+}
+
+func TestTSL2561DriverGetLuminocityAutoRange(t *testing.T) {
+	var tests = map[string]struct {
+		level     float64
+		wantGain  TSL2561Gain
+		wantTime  TSL2561IntegrationTime
+		wantBroad uint16
+	}{
+		"bright": {level: 100000, wantGain: TSL2561Gain1X, wantTime: TSL2561IntegrationTime101MS, wantBroad: 25200},
+		"dim":    {level: 10, wantGain: TSL2561Gain16X, wantTime: TSL2561IntegrationTime402MS, wantBroad: 160},
+		"valid":  {level: 30000, wantGain: TSL2561Gain1X, wantTime: TSL2561IntegrationTime402MS, wantBroad: 30000},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newI2cTestAdaptor()
+			simulateTSL2561(a, tc.level)
+			d := NewTSL2561Driver(a, WithTSL2561AutoRange)
+			gobottest.Assert(t, d.Start(), nil)
+			// act
+			broadband, ir, err := d.GetLuminocity()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, d.gain, tc.wantGain)
+			gobottest.Assert(t, d.integrationTime, tc.wantTime)
+			gobottest.Assert(t, broadband, tc.wantBroad)
+			gobottest.Assert(t, ir, tc.wantBroad/2)
+		})
+	}
+}
+
+func TestTSL2561DriverIlluminance(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	simulateTSL2561(a, 30000)
+	d := NewTSL2561Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	// act
+	lux, err := d.Illuminance()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, lux, float64(d.CalculateLux(30000, 15000)))
+}
+
+func TestTSL2561DriverIlluminanceSaturated(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	simulateTSL2561(a, 70000)
+	d := NewTSL2561Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	// act
+	_, err := d.Illuminance()
+	// assert
+	gobottest.Assert(t, err.Error(), "TSL2561 is saturated (broadband: 65535, ir: 32767)")
+}
+
+func TestTSL2561DriverSetThresholds(t *testing.T) {
+	// arrange
+	d, a := initTestTSL2561Driver()
+	a.written = []byte{}
+	// act
+	err := d.SetThresholds(100, 2000)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.written, []byte{
+		0x80, 0x03, // power on
+		0xA2, 0x64, 0x00, // low threshold
+		0xA4, 0xD0, 0x07, // high threshold
+		0x86, 0x11, // level interrupt, persist 1
+		0xC0, // clear interrupt
+	})
+	// device stays powered on
+	a.written = []byte{}
+	gobottest.Assert(t, d.disable(), nil)
+	gobottest.Assert(t, len(a.written), 0)
+	// act & assert: disable
+	gobottest.Assert(t, d.DisableThresholds(), nil)
+	gobottest.Assert(t, a.written, []byte{0x86, 0x00, 0xC0, 0x80, 0x00})
+	gobottest.Assert(t, d.SetThresholds(10, 10).Error(), "low threshold 10 needs to be less than high threshold 10")
+}
+
+func TestTSL2561DriverInterrupt(t *testing.T) {
+	// arrange
+	host := newGpioExpanderTestHost()
+	a := newI2cTestAdaptor()
+	simulateTSL2561(a, 30000)
+	d := NewTSL2561Driver(a, WithTSL2561IntegrationTime13MS, WithTSL2561Interrupt(host, "7"))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, host.pins["7"].input, true)
+	gobottest.Assert(t, host.pins["7"].edge, 1)
+	sem := make(chan float64, 1)
+	_ = d.Once(LightThreshold, func(data interface{}) { sem <- data.(float64) })
+	a.written = []byte{}
+	// act
+	host.pins["7"].trigger()
+	// assert
+	select {
+	case lux := <-sem:
+		gobottest.Assert(t, lux, float64(d.CalculateLux(1020, 510)))
+	case <-time.After(time.Second):
+		t.Errorf("TSL2561 threshold event was not published")
+	}
+	gobottest.Assert(t, a.written[len(a.written)-1], uint8(0xC0))
+}
+
+func TestTSL2561DriverInterruptWaitsForDriver(t *testing.T) {
+	// arrange
+	host := newGpioExpanderTestHost()
+	a := newI2cTestAdaptor()
+	simulateTSL2561(a, 30000)
+	d := NewTSL2561Driver(a, WithTSL2561IntegrationTime13MS, WithTSL2561Interrupt(host, "7"))
+	gobottest.Assert(t, d.Start(), nil)
+	sem := make(chan float64, 1)
+	_ = d.Once(LightThreshold, func(data interface{}) { sem <- data.(float64) })
+	a.written = []byte{}
+	// act: the driver is in use, e.g. by SetGain()
+	d.mutex.Lock()
+	go host.pins["7"].trigger()
+	time.Sleep(50 * time.Millisecond)
+	a.mtx.Lock()
+	writtenWhileLocked := len(a.written)
+	a.mtx.Unlock()
+	d.mutex.Unlock()
+	// assert
+	gobottest.Assert(t, writtenWhileLocked, 0)
+	select {
+	case lux := <-sem:
+		gobottest.Assert(t, lux, float64(d.CalculateLux(1020, 510)))
+	case <-time.After(time.Second):
+		t.Errorf("TSL2561 threshold event was not published")
+	}
+}
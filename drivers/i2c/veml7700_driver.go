@@ -0,0 +1,390 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+const veml7700Debug = false
+
+// VEML7700DefaultAddress is the address of the device
+const VEML7700DefaultAddress = 0x10
+
+const (
+	veml7700RegConf = 0x00
+	veml7700RegWH   = 0x01 // high threshold
+	veml7700RegWL   = 0x02 // low threshold
+	veml7700RegALS  = 0x04
+	veml7700RegW    = 0x05 // white channel
+	veml7700RegInt  = 0x06 // interrupt status
+	veml7700RegID   = 0x07
+
+	veml7700ConfShutdown = 0x0001
+	veml7700ConfIntEn    = 0x0002
+
+	veml7700IntHigh = 0x4000
+	veml7700IntLow  = 0x8000
+
+	// resolution in lux per count for gain 2x and integration time 800ms
+	veml7700MaxResolution = 0.0042
+
+	// auto ranging thresholds for the raw ALS value
+	veml7700AutoRangeLo = 100
+	veml7700AutoRangeHi = 50000
+)
+
+// VEML7700Gain is the type of the gain settings
+type VEML7700Gain uint16
+
+const (
+	// VEML7700Gain1X gain == 1x
+	VEML7700Gain1X VEML7700Gain = 0x00
+	// VEML7700Gain2X gain == 2x
+	VEML7700Gain2X VEML7700Gain = 0x01
+	// VEML7700GainOneEighth gain == 1/8x
+	VEML7700GainOneEighth VEML7700Gain = 0x02
+	// VEML7700GainOneQuarter gain == 1/4x
+	VEML7700GainOneQuarter VEML7700Gain = 0x03
+)
+
+var veml7700GainFactor = map[VEML7700Gain]float64{
+	VEML7700GainOneEighth:  0.125,
+	VEML7700GainOneQuarter: 0.25,
+	VEML7700Gain1X:         1,
+	VEML7700Gain2X:         2,
+}
+
+// VEML7700IntegrationTime is the type of the integration time settings
+type VEML7700IntegrationTime uint16
+
+const (
+	// VEML7700IntegrationTime25MS integration time 25ms
+	VEML7700IntegrationTime25MS VEML7700IntegrationTime = 0x0C
+	// VEML7700IntegrationTime50MS integration time 50ms
+	VEML7700IntegrationTime50MS VEML7700IntegrationTime = 0x08
+	// VEML7700IntegrationTime100MS integration time 100ms
+	VEML7700IntegrationTime100MS VEML7700IntegrationTime = 0x00
+	// VEML7700IntegrationTime200MS integration time 200ms
+	VEML7700IntegrationTime200MS VEML7700IntegrationTime = 0x01
+	// VEML7700IntegrationTime400MS integration time 400ms
+	VEML7700IntegrationTime400MS VEML7700IntegrationTime = 0x02
+	// VEML7700IntegrationTime800MS integration time 800ms
+	VEML7700IntegrationTime800MS VEML7700IntegrationTime = 0x03
+)
+
+var veml7700IntegrationTimeDuration = map[VEML7700IntegrationTime]time.Duration{
+	VEML7700IntegrationTime25MS:  25 * time.Millisecond,
+	VEML7700IntegrationTime50MS:  50 * time.Millisecond,
+	VEML7700IntegrationTime100MS: 100 * time.Millisecond,
+	VEML7700IntegrationTime200MS: 200 * time.Millisecond,
+	VEML7700IntegrationTime400MS: 400 * time.Millisecond,
+	VEML7700IntegrationTime800MS: 800 * time.Millisecond,
+}
+
+// veml7700Ranges contains the combinations of gain and integration time for auto ranging, ordered by the
+// sensitivity, according to the application note "Designing the VEML7700 Into an Application"
+var veml7700Ranges = []struct {
+	gain            VEML7700Gain
+	integrationTime VEML7700IntegrationTime
+}{
+	{gain: VEML7700GainOneEighth, integrationTime: VEML7700IntegrationTime25MS},
+	{gain: VEML7700GainOneEighth, integrationTime: VEML7700IntegrationTime50MS},
+	{gain: VEML7700GainOneEighth, integrationTime: VEML7700IntegrationTime100MS},
+	{gain: VEML7700GainOneQuarter, integrationTime: VEML7700IntegrationTime100MS},
+	{gain: VEML7700Gain1X, integrationTime: VEML7700IntegrationTime100MS},
+	{gain: VEML7700Gain2X, integrationTime: VEML7700IntegrationTime100MS},
+	{gain: VEML7700Gain2X, integrationTime: VEML7700IntegrationTime200MS},
+	{gain: VEML7700Gain2X, integrationTime: VEML7700IntegrationTime400MS},
+	{gain: VEML7700Gain2X, integrationTime: VEML7700IntegrationTime800MS},
+}
+
+// VEML7700Driver is a driver for the VEML7700 high accuracy ambient light sensor of Vishay.
+//
+// Datasheet: https://www.vishay.com/docs/84286/veml7700.pdf
+// Application note: https://www.vishay.com/docs/84323/designingveml7700.pdf
+//
+// The VEML7700 has no interrupt output, so the status of the threshold interrupt needs to be polled by
+// InterruptStatus().
+type VEML7700Driver struct {
+	*Driver
+	gain              VEML7700Gain
+	integrationTime   VEML7700IntegrationTime
+	autoRange         bool
+	thresholdsEnabled bool
+}
+
+// NewVEML7700Driver creates a new driver for the VEML7700 device.
+//
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithVEML7700Gain(VEML7700Gain):	sets the gain, default 1/8x
+//		i2c.WithVEML7700IntegrationTime(VEML7700IntegrationTime):	sets the integration time, default 100ms
+//		i2c.WithVEML7700AutoRange(bool):	turns on auto ranging of gain and integration time
+//
+func NewVEML7700Driver(c Connector, options ...func(Config)) *VEML7700Driver {
+	d := &VEML7700Driver{
+		Driver:          NewDriver(c, "VEML7700", VEML7700DefaultAddress),
+		gain:            VEML7700GainOneEighth,
+		integrationTime: VEML7700IntegrationTime100MS,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithVEML7700Gain option sets the gain. Valid settings are of type "VEML7700Gain".
+func WithVEML7700Gain(gain VEML7700Gain) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VEML7700Driver)
+		if ok {
+			d.gain = gain
+		} else if veml7700Debug {
+			log.Printf("Trying to set gain for non-VEML7700Driver %v", c)
+		}
+	}
+}
+
+// WithVEML7700IntegrationTime option sets the integration time. Valid settings are of type "VEML7700IntegrationTime".
+func WithVEML7700IntegrationTime(integrationTime VEML7700IntegrationTime) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VEML7700Driver)
+		if ok {
+			d.integrationTime = integrationTime
+		} else if veml7700Debug {
+			log.Printf("Trying to set integration time for non-VEML7700Driver %v", c)
+		}
+	}
+}
+
+// WithVEML7700AutoRange option turns on the auto ranging. Gain and integration time are adjusted before the
+// measurement to avoid saturation in bright light and increase the resolution in dim light.
+func WithVEML7700AutoRange(on bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VEML7700Driver)
+		if ok {
+			d.autoRange = on
+		} else if veml7700Debug {
+			log.Printf("Trying to set auto range for non-VEML7700Driver %v", c)
+		}
+	}
+}
+
+// Gain returns the current gain
+func (d *VEML7700Driver) Gain() VEML7700Gain {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.gain
+}
+
+// SetGain sets the gain
+func (d *VEML7700Driver) SetGain(gain VEML7700Gain) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setRange(gain, d.integrationTime)
+}
+
+// IntegrationTime returns the current integration time
+func (d *VEML7700Driver) IntegrationTime() VEML7700IntegrationTime {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.integrationTime
+}
+
+// SetIntegrationTime sets the integration time
+func (d *VEML7700Driver) SetIntegrationTime(integrationTime VEML7700IntegrationTime) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setRange(d.gain, integrationTime)
+}
+
+// ALS returns the raw value of the ambient light channel. If auto range is on, gain and integration time are
+// adjusted before.
+func (d *VEML7700Driver) ALS() (uint16, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readALS()
+}
+
+// White returns the raw value of the white channel
+func (d *VEML7700Driver) White() (uint16, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.connection.ReadWordData(veml7700RegW)
+}
+
+// Illuminance returns the ambient light in lux, implements the LightSensor interface
+func (d *VEML7700Driver) Illuminance() (float64, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	als, err := d.readALS()
+	if err != nil {
+		return 0, err
+	}
+	return d.calculateLux(als), nil
+}
+
+// SetThresholds activates the threshold interrupt for raw ALS values below low or above high. The values are raw
+// counts for the current gain and integration time, so this should not be combined with auto ranging.
+func (d *VEML7700Driver) SetThresholds(low uint16, high uint16) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if low >= high {
+		return fmt.Errorf("low threshold %d needs to be less than high threshold %d", low, high)
+	}
+	if err := d.connection.WriteWordData(veml7700RegWL, low); err != nil {
+		return err
+	}
+	if err := d.connection.WriteWordData(veml7700RegWH, high); err != nil {
+		return err
+	}
+	d.thresholdsEnabled = true
+	return d.writeConfig(false)
+}
+
+// DisableThresholds deactivates the threshold interrupt
+func (d *VEML7700Driver) DisableThresholds() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.thresholdsEnabled = false
+	return d.writeConfig(false)
+}
+
+// InterruptStatus returns, whether the low or the high threshold was crossed, the status is cleared by reading
+func (d *VEML7700Driver) InterruptStatus() (low bool, high bool, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status, err := d.connection.ReadWordData(veml7700RegInt)
+	if err != nil {
+		return false, false, err
+	}
+	return status&veml7700IntLow != 0, status&veml7700IntHigh != 0, nil
+}
+
+func (d *VEML7700Driver) initialize() error {
+	id, err := d.connection.ReadWordData(veml7700RegID)
+	if err != nil {
+		return err
+	}
+	if id&0xFF != 0x81 {
+		return fmt.Errorf("VEML7700 device not found (0x%X)", id)
+	}
+	if err := d.setRange(d.gain, d.integrationTime); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *VEML7700Driver) shutdown() error {
+	return d.writeConfig(true)
+}
+
+// setRange writes the configuration and waits for the first measurement with the new settings
+func (d *VEML7700Driver) setRange(gain VEML7700Gain, integrationTime VEML7700IntegrationTime) error {
+	if _, ok := veml7700GainFactor[gain]; !ok {
+		return fmt.Errorf("invalid gain %d for VEML7700", gain)
+	}
+	if _, ok := veml7700IntegrationTimeDuration[integrationTime]; !ok {
+		return fmt.Errorf("invalid integration time %d for VEML7700", integrationTime)
+	}
+	d.gain = gain
+	d.integrationTime = integrationTime
+	if err := d.writeConfig(false); err != nil {
+		return err
+	}
+	// the value of the running measurement can still belong to the previous settings
+	time.Sleep(2*veml7700IntegrationTimeDuration[integrationTime] + 3*time.Millisecond)
+	return nil
+}
+
+func (d *VEML7700Driver) writeConfig(shutdown bool) error {
+	conf := uint16(d.gain)<<11 | uint16(d.integrationTime)<<6
+	if d.thresholdsEnabled {
+		conf |= veml7700ConfIntEn
+	}
+	if shutdown {
+		conf |= veml7700ConfShutdown
+	}
+	return d.connection.WriteWordData(veml7700RegConf, conf)
+}
+
+// readALS reads the ambient light channel and steps through the ranges, if auto ranging is on, until the value is
+// in the valid range or the end of the ranges is reached
+func (d *VEML7700Driver) readALS() (uint16, error) {
+	als, err := d.connection.ReadWordData(veml7700RegALS)
+	if err != nil || !d.autoRange {
+		return als, err
+	}
+	idx := d.rangeIndex()
+	prevIdx := -1
+	for i := 0; i < len(veml7700Ranges); i++ {
+		next := idx
+		if als > veml7700AutoRangeHi && idx > 0 {
+			next = idx - 1
+		} else if als < veml7700AutoRangeLo && idx < len(veml7700Ranges)-1 {
+			next = idx + 1
+		}
+		// the second condition avoids toggling between two ranges
+		if next == idx || next == prevIdx {
+			break
+		}
+		prevIdx = idx
+		idx = next
+		if err := d.setRange(veml7700Ranges[idx].gain, veml7700Ranges[idx].integrationTime); err != nil {
+			return 0, err
+		}
+		if als, err = d.connection.ReadWordData(veml7700RegALS); err != nil {
+			return 0, err
+		}
+	}
+	return als, nil
+}
+
+// rangeIndex returns the index of the nearest range for the current gain and integration time
+func (d *VEML7700Driver) rangeIndex() int {
+	res := d.resolution()
+	for i, r := range veml7700Ranges {
+		if veml7700Resolution(r.gain, r.integrationTime) <= res {
+			return i
+		}
+	}
+	return len(veml7700Ranges) - 1
+}
+
+// calculateLux converts the raw ALS value to lux, for the low gains a correction of the non-linearity is applied
+func (d *VEML7700Driver) calculateLux(als uint16) float64 {
+	lux := float64(als) * d.resolution()
+	if d.gain == VEML7700GainOneEighth || d.gain == VEML7700GainOneQuarter {
+		lux = ((6.0135e-13*lux-9.3924e-9)*lux+8.1488e-5)*lux*lux + 1.0023*lux
+	}
+	return lux
+}
+
+func (d *VEML7700Driver) resolution() float64 {
+	return veml7700Resolution(d.gain, d.integrationTime)
+}
+
+// veml7700Resolution returns the lux per count for the given settings
+func veml7700Resolution(gain VEML7700Gain, integrationTime VEML7700IntegrationTime) float64 {
+	it := veml7700IntegrationTimeDuration[integrationTime]
+	return veml7700MaxResolution * float64(800*time.Millisecond) / float64(it) * 2 / veml7700GainFactor[gain]
+}
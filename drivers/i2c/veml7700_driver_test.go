@@ -0,0 +1,208 @@
+package i2c
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*VEML7700Driver)(nil)
+
+type veml7700TestRegisters struct {
+	mtx  sync.Mutex
+	regs map[uint8]uint16
+	// lux is used to calculate the ALS value for the current configuration, if not zero
+	lux float64
+}
+
+// simulateVEML7700Registers simulates the 16 bit registers, written and read in little endian order
+func simulateVEML7700Registers(a *i2cTestAdaptor) *veml7700TestRegisters {
+	r := &veml7700TestRegisters{regs: map[uint8]uint16{veml7700RegID: 0xC481}}
+	var ptr uint8
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		ptr = b[0]
+		if len(b) == 3 {
+			r.regs[ptr] = uint16(b[1]) | uint16(b[2])<<8
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		val := r.regs[ptr]
+		if ptr == veml7700RegALS && r.lux > 0 {
+			conf := r.regs[veml7700RegConf]
+			res := veml7700Resolution(VEML7700Gain(conf>>11&0x03), VEML7700IntegrationTime(conf>>6&0x0F))
+			val = uint16(math.Min(r.lux/res, 0xFFFF))
+		}
+		b[0] = byte(val)
+		b[1] = byte(val >> 8)
+		return 2, nil
+	}
+	return r
+}
+
+func initTestVEML7700DriverWithRegisters(options ...func(Config)) (*VEML7700Driver, *veml7700TestRegisters) {
+	a := newI2cTestAdaptor()
+	regs := simulateVEML7700Registers(a)
+	d := NewVEML7700Driver(a, options...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, regs
+}
+
+func TestNewVEML7700Driver(t *testing.T) {
+	var di interface{} = NewVEML7700Driver(newI2cTestAdaptor())
+	d, ok := di.(*VEML7700Driver)
+	if !ok {
+		t.Errorf("NewVEML7700Driver() should have returned a *VEML7700Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "VEML7700"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x10)
+	gobottest.Assert(t, d.gain, VEML7700GainOneEighth)
+	gobottest.Assert(t, d.integrationTime, VEML7700IntegrationTime100MS)
+	gobottest.Assert(t, d.autoRange, false)
+}
+
+func TestVEML7700Options(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewVEML7700Driver(newI2cTestAdaptor(), WithBus(2), WithVEML7700Gain(VEML7700Gain2X),
+		WithVEML7700IntegrationTime(VEML7700IntegrationTime400MS), WithVEML7700AutoRange(true))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.gain, VEML7700Gain2X)
+	gobottest.Assert(t, d.integrationTime, VEML7700IntegrationTime400MS)
+	gobottest.Assert(t, d.autoRange, true)
+}
+
+func TestVEML7700Start(t *testing.T) {
+	// arrange, act
+	d, regs := initTestVEML7700DriverWithRegisters(WithVEML7700Gain(VEML7700Gain2X),
+		WithVEML7700IntegrationTime(VEML7700IntegrationTime25MS))
+	// assert
+	gobottest.Assert(t, regs.regs[veml7700RegConf], uint16(0x0B00))
+	// act & assert: halt shuts down
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, regs.regs[veml7700RegConf], uint16(0x0B01))
+}
+
+func TestVEML7700StartNotFound(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	regs := simulateVEML7700Registers(a)
+	regs.regs[veml7700RegID] = 0x1234
+	d := NewVEML7700Driver(a)
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err.Error(), "VEML7700 device not found (0x1234)")
+}
+
+func TestVEML7700Illuminance(t *testing.T) {
+	var tests = map[string]struct {
+		gain    VEML7700Gain
+		it      VEML7700IntegrationTime
+		als     uint16
+		wantLux float64
+	}{
+		"gain_1x_25ms": {
+			gain:    VEML7700Gain1X,
+			it:      VEML7700IntegrationTime25MS,
+			als:     1000,
+			wantLux: 268.8,
+		},
+		"gain_1/8_25ms_corrected": {
+			gain:    VEML7700GainOneEighth,
+			it:      VEML7700IntegrationTime25MS,
+			als:     10000,
+			wantLux: 94427.15034554263,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, regs := initTestVEML7700DriverWithRegisters(WithVEML7700Gain(tc.gain),
+				WithVEML7700IntegrationTime(tc.it))
+			regs.regs[veml7700RegALS] = tc.als
+			// act
+			lux, err := d.Illuminance()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, math.Abs(lux-tc.wantLux) < 1e-6, true)
+		})
+	}
+}
+
+func TestVEML7700ALSAndWhite(t *testing.T) {
+	// arrange
+	d, regs := initTestVEML7700DriverWithRegisters(WithVEML7700IntegrationTime(VEML7700IntegrationTime25MS))
+	regs.regs[veml7700RegALS] = 0x1234
+	regs.regs[veml7700RegW] = 0x2345
+	// act
+	als, errALS := d.ALS()
+	white, errWhite := d.White()
+	// assert
+	gobottest.Assert(t, errALS, nil)
+	gobottest.Assert(t, errWhite, nil)
+	gobottest.Assert(t, als, uint16(0x1234))
+	gobottest.Assert(t, white, uint16(0x2345))
+}
+
+func TestVEML7700AutoRange(t *testing.T) {
+	// arrange
+	d, regs := initTestVEML7700DriverWithRegisters(WithVEML7700AutoRange(true), WithVEML7700Gain(VEML7700Gain2X),
+		WithVEML7700IntegrationTime(VEML7700IntegrationTime100MS))
+	regs.lux = 10000
+	// act
+	als, err := d.ALS()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.Gain(), VEML7700GainOneQuarter)
+	gobottest.Assert(t, d.IntegrationTime(), VEML7700IntegrationTime100MS)
+	gobottest.Assert(t, als, uint16(37202))
+}
+
+func TestVEML7700SetGainAndIntegrationTime(t *testing.T) {
+	// arrange
+	d, regs := initTestVEML7700DriverWithRegisters(WithVEML7700IntegrationTime(VEML7700IntegrationTime25MS))
+	// act & assert
+	gobottest.Assert(t, d.SetGain(VEML7700GainOneQuarter), nil)
+	gobottest.Assert(t, regs.regs[veml7700RegConf], uint16(0x1B00))
+	gobottest.Assert(t, d.SetGain(VEML7700Gain(4)).Error(), "invalid gain 4 for VEML7700")
+	gobottest.Assert(t, d.SetIntegrationTime(VEML7700IntegrationTime50MS), nil)
+	gobottest.Assert(t, regs.regs[veml7700RegConf], uint16(0x1A00))
+	gobottest.Assert(t, d.SetIntegrationTime(VEML7700IntegrationTime(5)).Error(),
+		"invalid integration time 5 for VEML7700")
+}
+
+func TestVEML7700Thresholds(t *testing.T) {
+	// arrange
+	d, regs := initTestVEML7700DriverWithRegisters(WithVEML7700IntegrationTime(VEML7700IntegrationTime25MS))
+	// act
+	err := d.SetThresholds(100, 20000)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, regs.regs[veml7700RegWL], uint16(100))
+	gobottest.Assert(t, regs.regs[veml7700RegWH], uint16(20000))
+	gobottest.Assert(t, regs.regs[veml7700RegConf], uint16(0x1302))
+	// act & assert: status
+	regs.regs[veml7700RegInt] = 0x4000
+	low, high, err := d.InterruptStatus()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, low, false)
+	gobottest.Assert(t, high, true)
+	// act & assert: disable
+	gobottest.Assert(t, d.DisableThresholds(), nil)
+	gobottest.Assert(t, regs.regs[veml7700RegConf], uint16(0x1300))
+	gobottest.Assert(t, d.SetThresholds(5, 5).Error(), "low threshold 5 needs to be less than high threshold 5")
+}
@@ -0,0 +1,885 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/ranging"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	vl53l0xDebug = false
+
+	// VL53L0X has the default address 0x29 after each power up, it can be changed by software
+	vl53l0xDefaultAddress = 0x29
+
+	vl53l0xModelID  = 0xEE
+	vl53l0xBootTime = 2 * time.Millisecond
+	vl53l0xTimeout  = 500 * time.Millisecond
+
+	// registers are named according to the API of ST, registers without a name are not documented
+	vl53l0xReg_SYSRANGE_START                          = 0x00
+	vl53l0xReg_SYSTEM_SEQUENCE_CONFIG                  = 0x01
+	vl53l0xReg_SYSTEM_INTERMEASUREMENT_PERIOD          = 0x04
+	vl53l0xReg_SYSTEM_INTERRUPT_CONFIG_GPIO            = 0x0A
+	vl53l0xReg_SYSTEM_INTERRUPT_CLEAR                  = 0x0B
+	vl53l0xReg_RESULT_INTERRUPT_STATUS                 = 0x13
+	vl53l0xReg_RESULT_RANGE_STATUS                     = 0x14
+	vl53l0xReg_ALGO_PHASECAL_LIM                       = 0x30 // on page 1
+	vl53l0xReg_ALGO_PHASECAL_CONFIG_TIMEOUT            = 0x30
+	vl53l0xReg_GLOBAL_CONFIG_VCSEL_WIDTH               = 0x32
+	vl53l0xReg_FINAL_RANGE_CONFIG_MIN_COUNT_RATE_LIMIT = 0x44
+	vl53l0xReg_MSRC_CONFIG_TIMEOUT_MACROP              = 0x46
+	vl53l0xReg_FINAL_RANGE_CONFIG_VALID_PHASE_LOW      = 0x47
+	vl53l0xReg_FINAL_RANGE_CONFIG_VALID_PHASE_HIGH     = 0x48
+	vl53l0xReg_DYNAMIC_SPAD_NUM_REQUESTED_REF_SPAD     = 0x4E
+	vl53l0xReg_DYNAMIC_SPAD_REF_EN_START_OFFSET        = 0x4F
+	vl53l0xReg_PRE_RANGE_CONFIG_VCSEL_PERIOD           = 0x50
+	vl53l0xReg_PRE_RANGE_CONFIG_TIMEOUT_MACROP_HI      = 0x51
+	vl53l0xReg_PRE_RANGE_CONFIG_VALID_PHASE_LOW        = 0x56
+	vl53l0xReg_PRE_RANGE_CONFIG_VALID_PHASE_HIGH       = 0x57
+	vl53l0xReg_MSRC_CONFIG_CONTROL                     = 0x60
+	vl53l0xReg_FINAL_RANGE_CONFIG_VCSEL_PERIOD         = 0x70
+	vl53l0xReg_FINAL_RANGE_CONFIG_TIMEOUT_MACROP_HI    = 0x71
+	vl53l0xReg_GPIO_HV_MUX_ACTIVE_HIGH                 = 0x84
+	vl53l0xReg_VHV_CONFIG_PAD_SCL_SDA_EXTSUP_HV        = 0x89
+	vl53l0xReg_I2C_SLAVE_DEVICE_ADDRESS                = 0x8A
+	vl53l0xReg_STOP_VARIABLE                           = 0x91
+	vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0        = 0xB0
+	vl53l0xReg_GLOBAL_CONFIG_REF_EN_START_SELECT       = 0xB6
+	vl53l0xReg_IDENTIFICATION_MODEL_ID                 = 0xC0
+	vl53l0xReg_OSC_CALIBRATE_VAL                       = 0xF8
+	vl53l0xReg_PAGE_SELECT                             = 0xFF
+
+	// sequence steps of SYSTEM_SEQUENCE_CONFIG
+	vl53l0xStepTCC        = 0x10
+	vl53l0xStepDSS        = 0x08
+	vl53l0xStepMSRC       = 0x04
+	vl53l0xStepPreRange   = 0x40
+	vl53l0xStepFinalRange = 0x80
+
+	// overhead of the sequence steps in [us] for the calculation of the timing budget
+	vl53l0xStartOverhead      = 1910
+	vl53l0xEndOverhead        = 960
+	vl53l0xMsrcOverhead       = 660
+	vl53l0xTccOverhead        = 590
+	vl53l0xDssOverhead        = 690
+	vl53l0xPreRangeOverhead   = 660
+	vl53l0xFinalRangeOverhead = 550
+	vl53l0xMinTimingBudget    = 20000
+
+	vl53l0xOutOfRange = 8190 // distance reported without target
+)
+
+// VL53L0XMode is used to select a predefined ranging mode
+type VL53L0XMode uint8
+
+const (
+	// VL53L0XModeDefault ranges up to 1.2m with a timing budget of 33ms
+	VL53L0XModeDefault VL53L0XMode = iota
+	// VL53L0XModeHighSpeed ranges up to 1.2m with a timing budget of 20ms and reduced accuracy
+	VL53L0XModeHighSpeed
+	// VL53L0XModeHighAccuracy ranges up to 1.2m with a timing budget of 200ms
+	VL53L0XModeHighAccuracy
+	// VL53L0XModeLongRange ranges up to 2m with a timing budget of 33ms, but is sensitive to ambient light
+	VL53L0XModeLongRange
+)
+
+type vl53l0xModeSettings struct {
+	signalRateLimit float64 // [MCPS]
+	preRangePeriod  uint8   // VCSEL pulse period in PCLKs
+	finalPeriod     uint8
+	timingBudget    uint32 // [us]
+}
+
+var vl53l0xModes = map[VL53L0XMode]vl53l0xModeSettings{
+	VL53L0XModeDefault:      {signalRateLimit: 0.25, preRangePeriod: 14, finalPeriod: 10, timingBudget: 33000},
+	VL53L0XModeHighSpeed:    {signalRateLimit: 0.25, preRangePeriod: 14, finalPeriod: 10, timingBudget: 20000},
+	VL53L0XModeHighAccuracy: {signalRateLimit: 0.25, preRangePeriod: 14, finalPeriod: 10, timingBudget: 200000},
+	VL53L0XModeLongRange:    {signalRateLimit: 0.1, preRangePeriod: 18, finalPeriod: 14, timingBudget: 33000},
+}
+
+// vl53l0xTuningSettings are the default tuning settings of the API of ST, the register 0xFF selects the page
+var vl53l0xTuningSettings = [][2]uint8{
+	{0xFF, 0x01}, {0x00, 0x00},
+	{0xFF, 0x00}, {0x09, 0x00}, {0x10, 0x00}, {0x11, 0x00}, {0x24, 0x01}, {0x25, 0xFF}, {0x75, 0x00},
+	{0xFF, 0x01}, {0x4E, 0x2C}, {0x48, 0x00}, {0x30, 0x20},
+	{0xFF, 0x00}, {0x30, 0x09}, {0x54, 0x00}, {0x31, 0x04}, {0x32, 0x03}, {0x40, 0x83}, {0x46, 0x25},
+	{0x60, 0x00}, {0x27, 0x00}, {0x50, 0x06}, {0x51, 0x00}, {0x52, 0x96}, {0x56, 0x08}, {0x57, 0x30},
+	{0x61, 0x00}, {0x62, 0x00}, {0x64, 0x00}, {0x65, 0x00}, {0x66, 0xA0},
+	{0xFF, 0x01}, {0x22, 0x32}, {0x47, 0x14}, {0x49, 0xFF}, {0x4A, 0x00},
+	{0xFF, 0x00}, {0x7A, 0x0A}, {0x7B, 0x00}, {0x78, 0x21},
+	{0xFF, 0x01}, {0x23, 0x34}, {0x42, 0x00}, {0x44, 0xFF}, {0x45, 0x26}, {0x46, 0x05}, {0x40, 0x40},
+	{0x0E, 0x06}, {0x20, 0x1A}, {0x43, 0x40},
+	{0xFF, 0x00}, {0x34, 0x03}, {0x35, 0x44},
+	{0xFF, 0x01}, {0x31, 0x04}, {0x4B, 0x09}, {0x4C, 0x05}, {0x4D, 0x04},
+	{0xFF, 0x00}, {0x44, 0x00}, {0x45, 0x20}, {0x47, 0x08}, {0x48, 0x28}, {0x67, 0x00}, {0x70, 0x04},
+	{0x71, 0x01}, {0x72, 0xFE}, {0x76, 0x00}, {0x77, 0x00},
+	{0xFF, 0x01}, {0x0D, 0x01},
+	{0xFF, 0x00}, {0x80, 0x01}, {0x01, 0xF8},
+	{0xFF, 0x01}, {0x8E, 0x01}, {0x00, 0x01},
+	{0xFF, 0x00}, {0x80, 0x00},
+}
+
+// VL53L0XDriver is a Gobot Driver for the VL53L0X time-of-flight ranging sensor with a range up to 2m.
+// please refer to data sheet: https://www.st.com/resource/en/datasheet/vl53l0x.pdf
+//
+// The initialization follows the API of ST, which is not documented in the data sheet. Several sensors can share
+// a bus, when each sensor has a XSHUT pin and a different address. Before the first sensor is started, all sensors
+// needs to be in standby, e.g. by calling Shutdown() of all drivers. The sensors are started one after another and
+// get the new address during the start.
+//
+// The driver implements the ranging.RangeFinder interface.
+type VL53L0XDriver struct {
+	*Driver
+	xshut        vl53lxxShutdown
+	mode         VL53L0XMode
+	stopVariable uint8
+	timingBudget uint32 // [us]
+	continuous   bool
+}
+
+// NewVL53L0XDriver creates a new driver for the VL53L0X time-of-flight ranging sensor.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver, it is assigned to the sensor on start
+//		i2c.WithVL53L0XShutdownPin(gpio.DigitalWriter, string):	XSHUT pin of the sensor
+//		i2c.WithVL53L0XMode(VL53L0XMode):	ranging mode, default is VL53L0XModeDefault
+func NewVL53L0XDriver(c Connector, options ...func(Config)) *VL53L0XDriver {
+	d := &VL53L0XDriver{
+		Driver: NewDriver(c, "VL53L0X", vl53l0xDefaultAddress),
+		mode:   VL53L0XModeDefault,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	// API commands
+	d.AddCommand("ReadRange", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadRange()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// WithVL53L0XShutdownPin option sets the pin, which is wired to the XSHUT input of the sensor. This is needed, when
+// several sensors share a bus.
+func WithVL53L0XShutdownPin(writer gpio.DigitalWriter, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VL53L0XDriver)
+		if ok {
+			d.xshut = vl53lxxShutdown{writer: writer, pin: pin}
+		} else if vl53l0xDebug {
+			log.Printf("Trying to set shutdown pin for non-VL53L0XDriver %v", c)
+		}
+	}
+}
+
+// WithVL53L0XMode option sets the ranging mode, which is applied on start.
+func WithVL53L0XMode(mode VL53L0XMode) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VL53L0XDriver)
+		if ok {
+			d.mode = mode
+		} else if vl53l0xDebug {
+			log.Printf("Trying to set mode for non-VL53L0XDriver %v", c)
+		}
+	}
+}
+
+// Shutdown puts the sensor in hardware standby by the XSHUT pin. The address is reset to the default, so the sensor
+// needs to be started again.
+func (d *VL53L0XDriver) Shutdown() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.xshut.configured() {
+		return fmt.Errorf("%s: no shutdown pin configured", d.name)
+	}
+	return d.xshut.shutdown()
+}
+
+// SetMode applies one of the predefined ranging modes.
+func (d *VL53L0XDriver) SetMode(mode VL53L0XMode) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setMode(mode)
+}
+
+// SetSignalRateLimit sets the minimum return signal rate in MCPS for a valid measurement. Lower values increase the
+// range, but also the chance of wrong measurements. The default is 0.25 MCPS.
+func (d *VL53L0XDriver) SetSignalRateLimit(limit float64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setSignalRateLimit(limit)
+}
+
+// SetTimingBudget sets the time for one measurement, the minimum is 20ms. Longer budgets increase the accuracy.
+func (d *VL53L0XDriver) SetTimingBudget(budget time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setTimingBudget(uint32(budget / time.Microsecond))
+}
+
+// TimingBudget returns the time for one measurement.
+func (d *VL53L0XDriver) TimingBudget() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return time.Duration(d.timingBudget) * time.Microsecond
+}
+
+// StartContinuous starts the continuous ranging with the given period between measurements. For a period of zero
+// the measurements are done back to back as fast as possible. ReadRange() returns the next measurement afterwards.
+func (d *VL53L0XDriver) StartContinuous(period time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.restoreStopVariable(); err != nil {
+		return err
+	}
+	if period == 0 {
+		if err := d.connection.WriteByteData(vl53l0xReg_SYSRANGE_START, 0x02); err != nil {
+			return err
+		}
+		d.continuous = true
+		return nil
+	}
+
+	periodMs := uint32(period / time.Millisecond)
+	oscCalibrate, err := d.readWord(vl53l0xReg_OSC_CALIBRATE_VAL)
+	if err != nil {
+		return err
+	}
+	if oscCalibrate != 0 {
+		periodMs *= uint32(oscCalibrate)
+	}
+	data := []byte{byte(periodMs >> 24), byte(periodMs >> 16), byte(periodMs >> 8), byte(periodMs)}
+	if err := d.connection.WriteBlockData(vl53l0xReg_SYSTEM_INTERMEASUREMENT_PERIOD, data); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSRANGE_START, 0x04); err != nil {
+		return err
+	}
+	d.continuous = true
+	return nil
+}
+
+// StopContinuous stops the continuous ranging.
+func (d *VL53L0XDriver) StopContinuous() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.stopContinuous()
+}
+
+// ReadRange implements the ranging.RangeFinder interface. Without continuous ranging a single measurement is
+// started, which takes about the timing budget.
+func (d *VL53L0XDriver) ReadRange() (ranging.Measurement, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.continuous {
+		if err := d.restoreStopVariable(); err != nil {
+			return ranging.Measurement{}, err
+		}
+		if err := d.connection.WriteByteData(vl53l0xReg_SYSRANGE_START, 0x01); err != nil {
+			return ranging.Measurement{}, err
+		}
+		if err := d.waitFor(vl53l0xReg_SYSRANGE_START, 0x01, false); err != nil {
+			return ranging.Measurement{}, err
+		}
+	}
+	if err := d.waitFor(vl53l0xReg_RESULT_INTERRUPT_STATUS, 0x07, true); err != nil {
+		return ranging.Measurement{}, err
+	}
+
+	// range status, (reserved), effective SPAD count (2), (2), signal rate (2), ambient rate (2), range (2)
+	data := make([]byte, 12)
+	if err := d.connection.ReadBlockData(vl53l0xReg_RESULT_RANGE_STATUS, data); err != nil {
+		return ranging.Measurement{}, err
+	}
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_INTERRUPT_CLEAR, 0x01); err != nil {
+		return ranging.Measurement{}, err
+	}
+
+	distance := uint16(data[10])<<8 | uint16(data[11])
+	m := ranging.Measurement{
+		Time:     time.Now(),
+		Distance: float64(distance),
+		Status:   vl53l0xStatus(data[0]&0x78>>3, distance),
+		Signal:   float64(uint16(data[6])<<8|uint16(data[7])) / 128, // fixed point 9.7
+		Ambient:  float64(uint16(data[8])<<8|uint16(data[9])) / 128,
+	}
+	return m, nil
+}
+
+func (d *VL53L0XDriver) initialize() error {
+	reset := d.xshut.configured()
+	if reset {
+		if err := d.xshut.reset(vl53l0xBootTime); err != nil {
+			return err
+		}
+	}
+	present := func(conn Connection) bool {
+		id, err := conn.ReadByteData(vl53l0xReg_IDENTIFICATION_MODEL_ID)
+		return err == nil && id == vl53l0xModelID
+	}
+	writeAddress := func(conn Connection, address uint8) error {
+		return conn.WriteByteData(vl53l0xReg_I2C_SLAVE_DEVICE_ADDRESS, address)
+	}
+	if err := vl53lxxAssignAddress(d.Driver, reset, present, writeAddress); err != nil {
+		return err
+	}
+
+	id, err := d.connection.ReadByteData(vl53l0xReg_IDENTIFICATION_MODEL_ID)
+	if err != nil {
+		return err
+	}
+	if id != vl53l0xModelID {
+		return fmt.Errorf("%s: unexpected model id 0x%02x", d.name, id)
+	}
+	d.continuous = false
+
+	if err := d.dataInit(); err != nil {
+		return err
+	}
+	if err := d.staticInit(); err != nil {
+		return err
+	}
+	if err := d.referenceCalibration(); err != nil {
+		return err
+	}
+	if d.mode != VL53L0XModeDefault {
+		return d.setMode(d.mode)
+	}
+	return nil
+}
+
+// dataInit switches the IO to 2.8V, the I2C to standard mode and sets the default signal rate limit
+func (d *VL53L0XDriver) dataInit() error {
+	if err := d.updateRegister(vl53l0xReg_VHV_CONFIG_PAD_SCL_SDA_EXTSUP_HV, 0x01, 0x01); err != nil {
+		return err
+	}
+	if err := d.writeRegisters([][2]uint8{{0x88, 0x00}, {0x80, 0x01}, {0xFF, 0x01}, {0x00, 0x00}}); err != nil {
+		return err
+	}
+	stopVariable, err := d.connection.ReadByteData(vl53l0xReg_STOP_VARIABLE)
+	if err != nil {
+		return err
+	}
+	d.stopVariable = stopVariable
+	if err := d.writeRegisters([][2]uint8{{0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00}}); err != nil {
+		return err
+	}
+	// disable the limit checks of the signal rate for MSRC and pre range
+	if err := d.updateRegister(vl53l0xReg_MSRC_CONFIG_CONTROL, 0x12, 0x12); err != nil {
+		return err
+	}
+	if err := d.setSignalRateLimit(0.25); err != nil {
+		return err
+	}
+	return d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, 0xFF)
+}
+
+// staticInit configures the reference SPADs, loads the tuning settings and configures the interrupt
+func (d *VL53L0XDriver) staticInit() error {
+	spadCount, isAperture, err := d.spadInfo()
+	if err != nil {
+		return err
+	}
+	spadMap := make([]byte, 6)
+	if err := d.connection.ReadBlockData(vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0, spadMap); err != nil {
+		return err
+	}
+	if err := d.writeRegisters([][2]uint8{
+		{vl53l0xReg_PAGE_SELECT, 0x01},
+		{vl53l0xReg_DYNAMIC_SPAD_REF_EN_START_OFFSET, 0x00},
+		{vl53l0xReg_DYNAMIC_SPAD_NUM_REQUESTED_REF_SPAD, 0x2C},
+		{vl53l0xReg_PAGE_SELECT, 0x00},
+		{vl53l0xReg_GLOBAL_CONFIG_REF_EN_START_SELECT, 0xB4},
+	}); err != nil {
+		return err
+	}
+	// the first 12 SPADs are non-aperture SPADs
+	firstSpad := 0
+	if isAperture {
+		firstSpad = 12
+	}
+	var enabled uint8
+	for i := 0; i < 48; i++ {
+		if i < firstSpad || enabled == spadCount {
+			spadMap[i/8] &^= 1 << uint(i%8)
+		} else if spadMap[i/8]>>uint(i%8)&0x01 != 0 {
+			enabled++
+		}
+	}
+	if err := d.connection.WriteBlockData(vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0, spadMap); err != nil {
+		return err
+	}
+
+	if err := d.writeRegisters(vl53l0xTuningSettings); err != nil {
+		return err
+	}
+
+	// interrupt on new sample ready, active low
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_INTERRUPT_CONFIG_GPIO, 0x04); err != nil {
+		return err
+	}
+	if err := d.updateRegister(vl53l0xReg_GPIO_HV_MUX_ACTIVE_HIGH, 0x10, 0x00); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_INTERRUPT_CLEAR, 0x01); err != nil {
+		return err
+	}
+
+	budget, err := d.readTimingBudget()
+	if err != nil {
+		return err
+	}
+	d.timingBudget = budget
+	// disable MSRC and TCC by default
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, 0xE8); err != nil {
+		return err
+	}
+	// the timing budget needs to be recalculated, because of the changed sequence steps
+	return d.setTimingBudget(d.timingBudget)
+}
+
+// referenceCalibration does the VHV and the phase calibration
+func (d *VL53L0XDriver) referenceCalibration() error {
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, 0x01); err != nil {
+		return err
+	}
+	if err := d.singleReferenceCalibration(0x40); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, 0x02); err != nil {
+		return err
+	}
+	if err := d.singleReferenceCalibration(0x00); err != nil {
+		return err
+	}
+	return d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, 0xE8)
+}
+
+func (d *VL53L0XDriver) singleReferenceCalibration(vhvInit uint8) error {
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSRANGE_START, 0x01|vhvInit); err != nil {
+		return err
+	}
+	if err := d.waitFor(vl53l0xReg_RESULT_INTERRUPT_STATUS, 0x07, true); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_INTERRUPT_CLEAR, 0x01); err != nil {
+		return err
+	}
+	return d.connection.WriteByteData(vl53l0xReg_SYSRANGE_START, 0x00)
+}
+
+// spadInfo reads the count and type of the reference SPADs from the NVM of the sensor
+func (d *VL53L0XDriver) spadInfo() (count uint8, isAperture bool, err error) {
+	if err = d.writeRegisters([][2]uint8{{0x80, 0x01}, {0xFF, 0x01}, {0x00, 0x00}, {0xFF, 0x06}}); err != nil {
+		return
+	}
+	if err = d.updateRegister(0x83, 0x04, 0x04); err != nil {
+		return
+	}
+	if err = d.writeRegisters([][2]uint8{{0xFF, 0x07}, {0x81, 0x01}, {0x80, 0x01}, {0x94, 0x6B},
+		{0x83, 0x00}}); err != nil {
+		return
+	}
+	if err = d.waitFor(0x83, 0xFF, true); err != nil {
+		return
+	}
+	if err = d.connection.WriteByteData(0x83, 0x01); err != nil {
+		return
+	}
+	val, err := d.connection.ReadByteData(0x92)
+	if err != nil {
+		return
+	}
+	if err = d.writeRegisters([][2]uint8{{0x81, 0x00}, {0xFF, 0x06}}); err != nil {
+		return
+	}
+	if err = d.updateRegister(0x83, 0x04, 0x00); err != nil {
+		return
+	}
+	if err = d.writeRegisters([][2]uint8{{0xFF, 0x01}, {0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00}}); err != nil {
+		return
+	}
+	return val & 0x7F, val&0x80 != 0, nil
+}
+
+func (d *VL53L0XDriver) setMode(mode VL53L0XMode) error {
+	settings, ok := vl53l0xModes[mode]
+	if !ok {
+		return fmt.Errorf("%s: invalid mode %d", d.name, mode)
+	}
+	if err := d.setSignalRateLimit(settings.signalRateLimit); err != nil {
+		return err
+	}
+	if err := d.setVcselPulsePeriod(true, settings.preRangePeriod); err != nil {
+		return err
+	}
+	if err := d.setVcselPulsePeriod(false, settings.finalPeriod); err != nil {
+		return err
+	}
+	if err := d.setTimingBudget(settings.timingBudget); err != nil {
+		return err
+	}
+	d.mode = mode
+	return nil
+}
+
+func (d *VL53L0XDriver) setSignalRateLimit(limit float64) error {
+	if limit < 0 || limit > 511.99 {
+		return fmt.Errorf("%s: signal rate limit %.2f out of range 0..511.99", d.name, limit)
+	}
+	// fixed point 9.7
+	return d.writeWord(vl53l0xReg_FINAL_RANGE_CONFIG_MIN_COUNT_RATE_LIMIT, uint16(limit*(1<<7)))
+}
+
+// vl53l0xTimeouts are the timeouts of the sequence steps
+type vl53l0xTimeouts struct {
+	steps            uint8
+	preRangePeriod   uint8 // [PCLKs]
+	finalRangePeriod uint8 // [PCLKs]
+	msrcDssTccMclks  uint32
+	msrcDssTccUs     uint32
+	preRangeMclks    uint32
+	preRangeUs       uint32
+	finalRangeMclks  uint32
+	finalRangeUs     uint32
+}
+
+func (d *VL53L0XDriver) readTimeouts() (*vl53l0xTimeouts, error) {
+	t := &vl53l0xTimeouts{}
+	steps, err := d.connection.ReadByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG)
+	if err != nil {
+		return nil, err
+	}
+	t.steps = steps
+	if t.preRangePeriod, err = d.readVcselPulsePeriod(vl53l0xReg_PRE_RANGE_CONFIG_VCSEL_PERIOD); err != nil {
+		return nil, err
+	}
+	if t.finalRangePeriod, err = d.readVcselPulsePeriod(vl53l0xReg_FINAL_RANGE_CONFIG_VCSEL_PERIOD); err != nil {
+		return nil, err
+	}
+	msrc, err := d.connection.ReadByteData(vl53l0xReg_MSRC_CONFIG_TIMEOUT_MACROP)
+	if err != nil {
+		return nil, err
+	}
+	t.msrcDssTccMclks = uint32(msrc) + 1
+	t.msrcDssTccUs = vl53l0xMclksToMicroseconds(t.msrcDssTccMclks, t.preRangePeriod)
+
+	preRange, err := d.readWord(vl53l0xReg_PRE_RANGE_CONFIG_TIMEOUT_MACROP_HI)
+	if err != nil {
+		return nil, err
+	}
+	t.preRangeMclks = vl53l0xDecodeTimeout(preRange)
+	t.preRangeUs = vl53l0xMclksToMicroseconds(t.preRangeMclks, t.preRangePeriod)
+
+	finalRange, err := d.readWord(vl53l0xReg_FINAL_RANGE_CONFIG_TIMEOUT_MACROP_HI)
+	if err != nil {
+		return nil, err
+	}
+	t.finalRangeMclks = vl53l0xDecodeTimeout(finalRange)
+	// the final range timeout includes the pre range timeout
+	if t.steps&vl53l0xStepPreRange != 0 {
+		t.finalRangeMclks -= t.preRangeMclks
+	}
+	t.finalRangeUs = vl53l0xMclksToMicroseconds(t.finalRangeMclks, t.finalRangePeriod)
+	return t, nil
+}
+
+// usedBudget returns the time of all sequence steps except the final range in [us]
+func (t *vl53l0xTimeouts) usedBudget() uint32 {
+	used := uint32(vl53l0xStartOverhead + vl53l0xEndOverhead)
+	if t.steps&vl53l0xStepTCC != 0 {
+		used += t.msrcDssTccUs + vl53l0xTccOverhead
+	}
+	if t.steps&vl53l0xStepDSS != 0 {
+		used += 2 * (t.msrcDssTccUs + vl53l0xDssOverhead)
+	} else if t.steps&vl53l0xStepMSRC != 0 {
+		used += t.msrcDssTccUs + vl53l0xMsrcOverhead
+	}
+	if t.steps&vl53l0xStepPreRange != 0 {
+		used += t.preRangeUs + vl53l0xPreRangeOverhead
+	}
+	return used
+}
+
+func (d *VL53L0XDriver) readTimingBudget() (uint32, error) {
+	t, err := d.readTimeouts()
+	if err != nil {
+		return 0, err
+	}
+	budget := t.usedBudget()
+	if t.steps&vl53l0xStepFinalRange != 0 {
+		budget += t.finalRangeUs + vl53l0xFinalRangeOverhead
+	}
+	return budget, nil
+}
+
+func (d *VL53L0XDriver) setTimingBudget(budget uint32) error {
+	if budget < vl53l0xMinTimingBudget {
+		return fmt.Errorf("%s: timing budget %dus is lower than the minimum of %dus", d.name, budget,
+			vl53l0xMinTimingBudget)
+	}
+	t, err := d.readTimeouts()
+	if err != nil {
+		return err
+	}
+	if t.steps&vl53l0xStepFinalRange == 0 {
+		d.timingBudget = budget
+		return nil
+	}
+	used := t.usedBudget() + vl53l0xFinalRangeOverhead
+	if used > budget {
+		return fmt.Errorf("%s: timing budget %dus is too short, %dus needed", d.name, budget, used)
+	}
+	finalRangeMclks := vl53l0xMicrosecondsToMclks(budget-used, t.finalRangePeriod)
+	if t.steps&vl53l0xStepPreRange != 0 {
+		finalRangeMclks += t.preRangeMclks
+	}
+	if err := d.writeWord(vl53l0xReg_FINAL_RANGE_CONFIG_TIMEOUT_MACROP_HI,
+		vl53l0xEncodeTimeout(finalRangeMclks)); err != nil {
+		return err
+	}
+	d.timingBudget = budget
+	return nil
+}
+
+// setVcselPulsePeriod sets the pulse period of the laser for the pre range (12..18 PCLKs) or the final range
+// (8..14 PCLKs), the timeouts are recalculated and a phase calibration is done afterwards
+func (d *VL53L0XDriver) setVcselPulsePeriod(preRange bool, period uint8) error {
+	t, err := d.readTimeouts()
+	if err != nil {
+		return err
+	}
+	periodReg := period>>1 - 1
+	if preRange {
+		if t.preRangePeriod == period {
+			return nil
+		}
+		phaseHigh := map[uint8]uint8{12: 0x18, 14: 0x30, 16: 0x40, 18: 0x50}
+		high, ok := phaseHigh[period]
+		if !ok {
+			return fmt.Errorf("%s: invalid pre range pulse period %d", d.name, period)
+		}
+		msrcMclks := vl53l0xMicrosecondsToMclks(t.msrcDssTccUs, period)
+		if msrcMclks > 256 {
+			msrcMclks = 256
+		}
+		if err := d.writeRegisters([][2]uint8{
+			{vl53l0xReg_PRE_RANGE_CONFIG_VALID_PHASE_HIGH, high},
+			{vl53l0xReg_PRE_RANGE_CONFIG_VALID_PHASE_LOW, 0x08},
+			{vl53l0xReg_PRE_RANGE_CONFIG_VCSEL_PERIOD, periodReg},
+		}); err != nil {
+			return err
+		}
+		preRangeMclks := vl53l0xMicrosecondsToMclks(t.preRangeUs, period)
+		if err := d.writeWord(vl53l0xReg_PRE_RANGE_CONFIG_TIMEOUT_MACROP_HI,
+			vl53l0xEncodeTimeout(preRangeMclks)); err != nil {
+			return err
+		}
+		if err := d.connection.WriteByteData(vl53l0xReg_MSRC_CONFIG_TIMEOUT_MACROP, uint8(msrcMclks-1)); err != nil {
+			return err
+		}
+	} else {
+		if t.finalRangePeriod == period {
+			return nil
+		}
+		// phase high, phase low, VCSEL width, phase calibration timeout, phase calibration limit
+		settings := map[uint8][5]uint8{
+			8:  {0x10, 0x08, 0x02, 0x0C, 0x30},
+			10: {0x28, 0x08, 0x03, 0x09, 0x20},
+			12: {0x38, 0x08, 0x03, 0x08, 0x20},
+			14: {0x48, 0x08, 0x03, 0x07, 0x20},
+		}
+		s, ok := settings[period]
+		if !ok {
+			return fmt.Errorf("%s: invalid final range pulse period %d", d.name, period)
+		}
+		if err := d.writeRegisters([][2]uint8{
+			{vl53l0xReg_FINAL_RANGE_CONFIG_VALID_PHASE_HIGH, s[0]},
+			{vl53l0xReg_FINAL_RANGE_CONFIG_VALID_PHASE_LOW, s[1]},
+			{vl53l0xReg_GLOBAL_CONFIG_VCSEL_WIDTH, s[2]},
+			{vl53l0xReg_ALGO_PHASECAL_CONFIG_TIMEOUT, s[3]},
+			{vl53l0xReg_PAGE_SELECT, 0x01},
+			{vl53l0xReg_ALGO_PHASECAL_LIM, s[4]},
+			{vl53l0xReg_PAGE_SELECT, 0x00},
+			{vl53l0xReg_FINAL_RANGE_CONFIG_VCSEL_PERIOD, periodReg},
+		}); err != nil {
+			return err
+		}
+		finalRangeMclks := vl53l0xMicrosecondsToMclks(t.finalRangeUs, period)
+		if t.steps&vl53l0xStepPreRange != 0 {
+			finalRangeMclks += t.preRangeMclks
+		}
+		if err := d.writeWord(vl53l0xReg_FINAL_RANGE_CONFIG_TIMEOUT_MACROP_HI,
+			vl53l0xEncodeTimeout(finalRangeMclks)); err != nil {
+			return err
+		}
+	}
+
+	// the timing budget is given by the final range timeout, which depends on the other timeouts
+	if err := d.setTimingBudget(d.timingBudget); err != nil {
+		return err
+	}
+	if err := d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, 0x02); err != nil {
+		return err
+	}
+	if err := d.singleReferenceCalibration(0x00); err != nil {
+		return err
+	}
+	return d.connection.WriteByteData(vl53l0xReg_SYSTEM_SEQUENCE_CONFIG, t.steps)
+}
+
+func (d *VL53L0XDriver) readVcselPulsePeriod(reg uint8) (uint8, error) {
+	val, err := d.connection.ReadByteData(reg)
+	if err != nil {
+		return 0, err
+	}
+	return (val + 1) << 1, nil
+}
+
+func (d *VL53L0XDriver) stopContinuous() error {
+	if !d.continuous {
+		return nil
+	}
+	d.continuous = false
+	return d.writeRegisters([][2]uint8{{vl53l0xReg_SYSRANGE_START, 0x01}, {0xFF, 0x01}, {0x00, 0x00},
+		{vl53l0xReg_STOP_VARIABLE, 0x00}, {0x00, 0x01}, {0xFF, 0x00}})
+}
+
+// restoreStopVariable needs to be done before each start of ranging
+func (d *VL53L0XDriver) restoreStopVariable() error {
+	return d.writeRegisters([][2]uint8{{0x80, 0x01}, {0xFF, 0x01}, {0x00, 0x00},
+		{vl53l0xReg_STOP_VARIABLE, d.stopVariable}, {0x00, 0x01}, {0xFF, 0x00}, {0x80, 0x00}})
+}
+
+func (d *VL53L0XDriver) shutdown() error {
+	if d.connection == nil {
+		return nil
+	}
+	if err := d.stopContinuous(); err != nil {
+		return err
+	}
+	if d.xshut.configured() {
+		return d.xshut.shutdown()
+	}
+	return nil
+}
+
+// waitFor polls the register, until the masked bits are set (or cleared)
+func (d *VL53L0XDriver) waitFor(reg uint8, mask uint8, set bool) error {
+	deadline := time.Now().Add(vl53l0xTimeout)
+	for {
+		val, err := d.connection.ReadByteData(reg)
+		if err != nil {
+			return err
+		}
+		if (val&mask != 0) == set {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrNotReady
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (d *VL53L0XDriver) writeRegisters(values [][2]uint8) error {
+	for _, v := range values {
+		if err := d.connection.WriteByteData(v[0], v[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *VL53L0XDriver) updateRegister(reg uint8, mask uint8, val uint8) error {
+	old, err := d.connection.ReadByteData(reg)
+	if err != nil {
+		return err
+	}
+	return d.connection.WriteByteData(reg, old&^mask|val&mask)
+}
+
+func (d *VL53L0XDriver) readWord(reg uint8) (uint16, error) {
+	data := make([]byte, 2)
+	if err := d.connection.ReadBlockData(reg, data); err != nil {
+		return 0, err
+	}
+	return uint16(data[0])<<8 | uint16(data[1]), nil
+}
+
+func (d *VL53L0XDriver) writeWord(reg uint8, val uint16) error {
+	return d.connection.WriteBlockData(reg, []byte{byte(val >> 8), byte(val)})
+}
+
+// vl53l0xStatus converts the device range status to the ranging status
+func vl53l0xStatus(deviceStatus uint8, distance uint16) ranging.Status {
+	switch {
+	case deviceStatus == 11 && distance < vl53l0xOutOfRange:
+		return ranging.Valid
+	case deviceStatus == 11:
+		return ranging.OutOfRange
+	case deviceStatus >= 1 && deviceStatus <= 5:
+		// VCSEL continuity, VCSEL watchdog, PLL lock
+		return ranging.HardwareFail
+	case deviceStatus >= 6 && deviceStatus <= 8:
+		// convergence failures and no target
+		return ranging.SignalFail
+	case deviceStatus >= 12:
+		// underflow or overflow of the ranging algorithm
+		return ranging.OutOfRange
+	default:
+		return ranging.SignalFail
+	}
+}
+
+// vl53l0xMacroPeriod returns the macro period in [ns] for the given VCSEL period in PCLKs
+func vl53l0xMacroPeriod(vcselPeriod uint8) uint32 {
+	return (2304*uint32(vcselPeriod)*1655 + 500) / 1000
+}
+
+func vl53l0xMclksToMicroseconds(mclks uint32, vcselPeriod uint8) uint32 {
+	macroPeriod := vl53l0xMacroPeriod(vcselPeriod)
+	return (mclks*macroPeriod + 500) / 1000
+}
+
+func vl53l0xMicrosecondsToMclks(us uint32, vcselPeriod uint8) uint32 {
+	macroPeriod := vl53l0xMacroPeriod(vcselPeriod)
+	return (us*1000 + macroPeriod/2) / macroPeriod
+}
+
+// vl53l0xDecodeTimeout decodes the timeout register format "(LSByte * 2^MSByte) + 1"
+func vl53l0xDecodeTimeout(val uint16) uint32 {
+	return uint32(val&0xFF)<<(val>>8) + 1
+}
+
+func vl53l0xEncodeTimeout(mclks uint32) uint16 {
+	if mclks == 0 {
+		return 0
+	}
+	lsb := mclks - 1
+	var msb uint16
+	for lsb&0xFFFFFF00 != 0 {
+		lsb >>= 1
+		msb++
+	}
+	return msb<<8 | uint16(lsb&0xFF)
+}
@@ -0,0 +1,240 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ranging"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*VL53L0XDriver)(nil)
+
+var _ ranging.RangeFinder = (*VL53L0XDriver)(nil)
+
+var _ gpio.DigitalWriter = (*vl53lxxTestShutdownPin)(nil)
+
+// vl53lxxTestShutdownPin records the values written to the XSHUT pin
+type vl53lxxTestShutdownPin struct {
+	pin    string
+	values []byte
+}
+
+func (p *vl53lxxTestShutdownPin) DigitalWrite(pin string, val byte) error {
+	p.pin = pin
+	p.values = append(p.values, val)
+	return nil
+}
+
+// simulateVL53L0X simulates the registers with the model id and a ready sensor
+func simulateVL53L0X(a *i2cTestAdaptor) *memoryTestDevice {
+	m := simulateMemory(a, 256, 1)
+	m.mem[vl53l0xReg_IDENTIFICATION_MODEL_ID] = vl53l0xModelID
+	m.mem[vl53l0xReg_STOP_VARIABLE] = 0x3C
+	m.mem[0x92] = 0x85 // 5 aperture SPADs
+	m.mem[vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0+1] = 0xFF
+	m.mem[vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0+2] = 0xFF
+	m.fixed[vl53l0xReg_SYSRANGE_START] = 0x00          // measurement started
+	m.fixed[vl53l0xReg_RESULT_INTERRUPT_STATUS] = 0x07 // measurement done
+	m.fixed[0x83] = 0x01                               // SPAD info ready
+	return m
+}
+
+func initTestVL53L0XWithStubbedAdaptor() (*VL53L0XDriver, *i2cTestAdaptor, *memoryTestDevice) {
+	a := newI2cTestAdaptor()
+	m := simulateVL53L0X(a)
+	d := NewVL53L0XDriver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a, m
+}
+
+func TestNewVL53L0XDriver(t *testing.T) {
+	var di interface{} = NewVL53L0XDriver(newI2cTestAdaptor())
+	d, ok := di.(*VL53L0XDriver)
+	if !ok {
+		t.Errorf("NewVL53L0XDriver() should have returned a *VL53L0XDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "VL53L0X"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x29)
+	gobottest.Assert(t, d.mode, VL53L0XModeDefault)
+}
+
+func TestVL53L0XOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	pin := &vl53lxxTestShutdownPin{}
+	d := NewVL53L0XDriver(newI2cTestAdaptor(), WithBus(2), WithVL53L0XMode(VL53L0XModeLongRange),
+		WithVL53L0XShutdownPin(pin, "7"))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.mode, VL53L0XModeLongRange)
+	gobottest.Assert(t, d.xshut.pin, "7")
+}
+
+func TestVL53L0XStart(t *testing.T) {
+	d, _, m := initTestVL53L0XWithStubbedAdaptor()
+	gobottest.Assert(t, d.stopVariable, uint8(0x3C))
+	// only the first 5 SPADs are kept, starting at SPAD 12 for aperture SPADs
+	gobottest.Assert(t, m.mem[vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0:vl53l0xReg_GLOBAL_CONFIG_SPAD_ENABLES_REF_0+6],
+		[]byte{0x00, 0xF0, 0x01, 0x00, 0x00, 0x00})
+	gobottest.Assert(t, m.mem[vl53l0xReg_SYSTEM_SEQUENCE_CONFIG], uint8(0xE8))
+	gobottest.Assert(t, m.mem[vl53l0xReg_SYSTEM_INTERRUPT_CONFIG_GPIO], uint8(0x04))
+	// 0.25 MCPS in 9.7 fixed point
+	gobottest.Assert(t, m.mem[vl53l0xReg_FINAL_RANGE_CONFIG_MIN_COUNT_RATE_LIMIT:vl53l0xReg_FINAL_RANGE_CONFIG_MIN_COUNT_RATE_LIMIT+2],
+		[]byte{0x00, 0x20})
+	gobottest.Assert(t, d.TimingBudget() > 20*time.Millisecond, true)
+}
+
+func TestVL53L0XStartWrongModel(t *testing.T) {
+	a := newI2cTestAdaptor()
+	m := simulateVL53L0X(a)
+	m.mem[vl53l0xReg_IDENTIFICATION_MODEL_ID] = 0x11
+	d := NewVL53L0XDriver(a)
+	err := d.Start()
+	gobottest.Assert(t, strings.Contains(err.Error(), "unexpected model id 0x11"), true)
+}
+
+func TestVL53L0XAssignAddress(t *testing.T) {
+	// with shutdown pin the sensor is reset and gets the new address
+	a := newI2cTestAdaptor()
+	m := simulateVL53L0X(a)
+	pin := &vl53lxxTestShutdownPin{}
+	d := NewVL53L0XDriver(a, WithAddress(0x30), WithVL53L0XShutdownPin(pin, "7"))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, pin.values, []byte{0, 1})
+	gobottest.Assert(t, m.mem[vl53l0xReg_I2C_SLAVE_DEVICE_ADDRESS], uint8(0x30))
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, pin.values, []byte{0, 1, 0})
+	gobottest.Assert(t, d.Shutdown(), nil)
+
+	// without shutdown pin, the sensor is already present at the new address
+	a = newI2cTestAdaptor()
+	m = simulateVL53L0X(a)
+	d = NewVL53L0XDriver(a, WithAddress(0x30))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, m.mem[vl53l0xReg_I2C_SLAVE_DEVICE_ADDRESS], uint8(0x00))
+	err := d.Shutdown()
+	gobottest.Assert(t, strings.Contains(err.Error(), "no shutdown pin configured"), true)
+}
+
+func TestVL53L0XReadRange(t *testing.T) {
+	var tests = map[string]struct {
+		status uint8
+		dist   uint16
+		want   ranging.Status
+	}{
+		"valid":         {status: 11 << 3, dist: 500, want: ranging.Valid},
+		"no_target":     {status: 11 << 3, dist: 8190, want: ranging.OutOfRange},
+		"signal_fail":   {status: 8 << 3, dist: 20, want: ranging.SignalFail},
+		"hardware":      {status: 1 << 3, dist: 20, want: ranging.HardwareFail},
+		"algo_overflow": {status: 15 << 3, dist: 20, want: ranging.OutOfRange},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, m := initTestVL53L0XWithStubbedAdaptor()
+			copy(m.mem[vl53l0xReg_RESULT_RANGE_STATUS:], []byte{tc.status, 0, 0, 0, 0, 0,
+				0x02, 0x80, 0x00, 0x40, byte(tc.dist >> 8), byte(tc.dist)})
+			// act
+			got, err := d.ReadRange()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got.Distance, float64(tc.dist))
+			gobottest.Assert(t, got.Status, tc.want)
+			gobottest.Assert(t, got.Signal, 5.0)
+			gobottest.Assert(t, got.Ambient, 0.5)
+			gobottest.Assert(t, m.mem[vl53l0xReg_SYSRANGE_START], uint8(0x01))
+		})
+	}
+}
+
+func TestVL53L0XReadRangeTimeout(t *testing.T) {
+	d, _, m := initTestVL53L0XWithStubbedAdaptor()
+	m.fixed[vl53l0xReg_RESULT_INTERRUPT_STATUS] = 0x00
+	_, err := d.ReadRange()
+	gobottest.Assert(t, err, ErrNotReady)
+}
+
+func TestVL53L0XContinuous(t *testing.T) {
+	d, _, m := initTestVL53L0XWithStubbedAdaptor()
+	m.mem[vl53l0xReg_OSC_CALIBRATE_VAL] = 0x00
+	m.mem[vl53l0xReg_OSC_CALIBRATE_VAL+1] = 0x02
+	gobottest.Assert(t, d.StartContinuous(100*time.Millisecond), nil)
+	gobottest.Assert(t, m.mem[vl53l0xReg_SYSTEM_INTERMEASUREMENT_PERIOD:vl53l0xReg_SYSTEM_INTERMEASUREMENT_PERIOD+4],
+		[]byte{0, 0, 0, 200})
+	gobottest.Assert(t, m.mem[vl53l0xReg_SYSRANGE_START], uint8(0x04))
+
+	// no new measurement is started
+	m.mem[vl53l0xReg_SYSRANGE_START] = 0x00
+	_, err := d.ReadRange()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.mem[vl53l0xReg_SYSRANGE_START], uint8(0x00))
+
+	gobottest.Assert(t, d.StopContinuous(), nil)
+	gobottest.Assert(t, m.mem[vl53l0xReg_STOP_VARIABLE], uint8(0x00))
+
+	// back to back
+	gobottest.Assert(t, d.StartContinuous(0), nil)
+	gobottest.Assert(t, m.mem[vl53l0xReg_SYSRANGE_START], uint8(0x02))
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.continuous, false)
+}
+
+func TestVL53L0XSetMode(t *testing.T) {
+	d, _, m := initTestVL53L0XWithStubbedAdaptor()
+	gobottest.Assert(t, d.SetMode(VL53L0XModeLongRange), nil)
+	// 0.1 MCPS, pre range period 18 and final range period 14
+	gobottest.Assert(t, m.mem[vl53l0xReg_FINAL_RANGE_CONFIG_MIN_COUNT_RATE_LIMIT+1], uint8(0x0C))
+	gobottest.Assert(t, m.mem[vl53l0xReg_PRE_RANGE_CONFIG_VCSEL_PERIOD], uint8(8))
+	gobottest.Assert(t, m.mem[vl53l0xReg_FINAL_RANGE_CONFIG_VCSEL_PERIOD], uint8(6))
+	gobottest.Assert(t, d.TimingBudget(), 33*time.Millisecond)
+
+	gobottest.Assert(t, d.SetMode(VL53L0XModeHighAccuracy), nil)
+	gobottest.Assert(t, m.mem[vl53l0xReg_PRE_RANGE_CONFIG_VCSEL_PERIOD], uint8(6))
+	gobottest.Assert(t, m.mem[vl53l0xReg_FINAL_RANGE_CONFIG_VCSEL_PERIOD], uint8(4))
+	gobottest.Assert(t, d.TimingBudget(), 200*time.Millisecond)
+
+	err := d.SetMode(9)
+	gobottest.Assert(t, strings.Contains(err.Error(), "invalid mode 9"), true)
+}
+
+func TestVL53L0XSetTimingBudget(t *testing.T) {
+	d, _, m := initTestVL53L0XWithStubbedAdaptor()
+	reg := vl53l0xReg_FINAL_RANGE_CONFIG_TIMEOUT_MACROP_HI
+	gobottest.Assert(t, d.SetTimingBudget(50*time.Millisecond), nil)
+	long := vl53l0xDecodeTimeout(uint16(m.mem[reg])<<8 | uint16(m.mem[reg+1]))
+	gobottest.Assert(t, d.SetTimingBudget(25*time.Millisecond), nil)
+	short := vl53l0xDecodeTimeout(uint16(m.mem[reg])<<8 | uint16(m.mem[reg+1]))
+	gobottest.Assert(t, long > short, true)
+	gobottest.Assert(t, d.TimingBudget(), 25*time.Millisecond)
+
+	err := d.SetTimingBudget(10 * time.Millisecond)
+	gobottest.Assert(t, strings.Contains(err.Error(), "lower than the minimum"), true)
+}
+
+func TestVL53L0XTimeoutConversion(t *testing.T) {
+	gobottest.Assert(t, vl53l0xDecodeTimeout(0x01FE), uint32(509))
+	gobottest.Assert(t, vl53l0xEncodeTimeout(509), uint16(0x01FE))
+	gobottest.Assert(t, vl53l0xEncodeTimeout(0), uint16(0))
+	gobottest.Assert(t, vl53l0xDecodeTimeout(vl53l0xEncodeTimeout(100)), uint32(100))
+	// macro period for 14 PCLKs is 53384ns
+	gobottest.Assert(t, vl53l0xMclksToMicroseconds(1000, 14), uint32(53384))
+	gobottest.Assert(t, vl53l0xMicrosecondsToMclks(53384, 14), uint32(1000))
+}
+
+func TestVL53L0XReadError(t *testing.T) {
+	d, a, _ := initTestVL53L0XWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadRange()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -0,0 +1,572 @@
+package i2c
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/ranging"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	vl53l1xDebug = false
+
+	// VL53L1X has the default address 0x29 after each power up, it can be changed by software
+	vl53l1xDefaultAddress = 0x29
+
+	vl53l1xModelID  = 0xEACC
+	vl53l1xBootTime = 2 * time.Millisecond
+	vl53l1xTimeout  = 1000 * time.Millisecond
+
+	// registers are named according to the ultra lite driver (ULD) of ST, the register index has 16 bits
+	vl53l1xReg_I2C_SLAVE_DEVICE_ADDRESS         = 0x0001
+	vl53l1xReg_VHV_CONFIG_TIMEOUT_MACROP_LOOP   = 0x0008
+	vl53l1xReg_VHV_CONFIG_INIT                  = 0x000B
+	vl53l1xReg_DEFAULT_CONFIG_START             = 0x002D // 0x2D..0x87
+	vl53l1xReg_GPIO_HV_MUX_CTRL                 = 0x0030
+	vl53l1xReg_GPIO_TIO_HV_STATUS               = 0x0031
+	vl53l1xReg_PHASECAL_CONFIG_TIMEOUT_MACROP   = 0x004B
+	vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_A_HI = 0x005E
+	vl53l1xReg_RANGE_CONFIG_VCSEL_PERIOD_A      = 0x0060
+	vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_B_HI = 0x0061
+	vl53l1xReg_RANGE_CONFIG_VCSEL_PERIOD_B      = 0x0063
+	vl53l1xReg_RANGE_CONFIG_VALID_PHASE_HIGH    = 0x0069
+	vl53l1xReg_SYSTEM_INTERMEASUREMENT_PERIOD   = 0x006C
+	vl53l1xReg_SD_CONFIG_WOI_SD0                = 0x0078
+	vl53l1xReg_SD_CONFIG_INITIAL_PHASE_SD0      = 0x007A
+	vl53l1xReg_ROI_CONFIG_USER_ROI_CENTRE_SPAD  = 0x007F
+	vl53l1xReg_ROI_CONFIG_USER_ROI_REQUESTED_XY = 0x0080
+	vl53l1xReg_SYSTEM_INTERRUPT_CLEAR           = 0x0086
+	vl53l1xReg_SYSTEM_MODE_START                = 0x0087
+	vl53l1xReg_RESULT_RANGE_STATUS              = 0x0089
+	vl53l1xReg_RESULT_OSC_CALIBRATE_VAL         = 0x00DE
+	vl53l1xReg_FIRMWARE_SYSTEM_STATUS           = 0x00E5
+	vl53l1xReg_IDENTIFICATION_MODEL_ID          = 0x010F
+	vl53l1xReg_ROI_CONFIG_MODE_ROI_CENTRE_SPAD  = 0x013E
+
+	vl53l1xDefaultTimingBudget = 100 * time.Millisecond
+)
+
+// VL53L1XDistanceMode is used to select the maximum distance
+type VL53L1XDistanceMode uint8
+
+const (
+	// VL53L1XDistanceModeShort ranges up to 1.3m and is more immune to ambient light
+	VL53L1XDistanceModeShort VL53L1XDistanceMode = 1
+	// VL53L1XDistanceModeLong ranges up to 4m in the dark (default)
+	VL53L1XDistanceModeLong VL53L1XDistanceMode = 2
+)
+
+// vl53l1xDefaultConfiguration is written to the registers 0x2D..0x87 on initialization, the values are taken from
+// the ultra lite driver of ST
+var vl53l1xDefaultConfiguration = []byte{
+	0x00, 0x00, 0x00, 0x01, 0x02, 0x00, 0x02, 0x08, 0x00, 0x08, 0x10, 0x01, 0x01, 0x00, 0x00, 0x00, // 0x2D..0x3C
+	0x00, 0xFF, 0x00, 0x0F, 0x00, 0x00, 0x00, 0x00, 0x00, 0x20, 0x0B, 0x00, 0x00, 0x02, 0x0A, 0x21, // 0x3D..0x4C
+	0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0xC8, 0x00, 0x00, 0x38, 0xFF, 0x01, 0x00, 0x08, 0x00, // 0x4D..0x5C
+	0x00, 0x01, 0xCC, 0x0F, 0x01, 0xF1, 0x0D, 0x01, 0x68, 0x00, 0x80, 0x08, 0xB8, 0x00, 0x00, 0x00, // 0x5D..0x6C
+	0x00, 0x0F, 0x89, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x0F, 0x0D, 0x0E, 0x0E, 0x00, // 0x6D..0x7C
+	0x00, 0x02, 0xC7, 0xFF, 0x9B, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, // 0x7D..0x87
+}
+
+// vl53l1xDistanceModeSettings are the values of the registers for the distance modes: phase calibration timeout,
+// VCSEL period A, VCSEL period B, valid phase high, WOI SD0 (2 bytes), initial phase SD0 (2 bytes)
+var vl53l1xDistanceModeSettings = map[VL53L1XDistanceMode][8]uint8{
+	VL53L1XDistanceModeShort: {0x14, 0x07, 0x05, 0x38, 0x07, 0x05, 0x06, 0x06},
+	VL53L1XDistanceModeLong:  {0x0A, 0x0F, 0x0D, 0xB8, 0x0F, 0x0D, 0x0E, 0x0E},
+}
+
+// vl53l1xTimingBudgets are the values of the timeout registers A and B for the supported timing budgets
+var vl53l1xTimingBudgets = map[VL53L1XDistanceMode]map[time.Duration][2]uint16{
+	VL53L1XDistanceModeShort: {
+		15 * time.Millisecond:  {0x001D, 0x0027},
+		20 * time.Millisecond:  {0x0051, 0x006E},
+		33 * time.Millisecond:  {0x00D6, 0x006E},
+		50 * time.Millisecond:  {0x01AE, 0x01E8},
+		100 * time.Millisecond: {0x02E1, 0x0388},
+		200 * time.Millisecond: {0x03E1, 0x0496},
+		500 * time.Millisecond: {0x0591, 0x05C1},
+	},
+	VL53L1XDistanceModeLong: {
+		20 * time.Millisecond:  {0x001E, 0x0022},
+		33 * time.Millisecond:  {0x0060, 0x006E},
+		50 * time.Millisecond:  {0x00AD, 0x00C6},
+		100 * time.Millisecond: {0x01CC, 0x01EA},
+		200 * time.Millisecond: {0x02D9, 0x02F8},
+		500 * time.Millisecond: {0x048F, 0x04A4},
+	},
+}
+
+// vl53l1xRangeStatus converts the range status of the device to the status of the ULD
+var vl53l1xRangeStatus = []uint8{255, 255, 255, 5, 2, 4, 1, 7, 3, 0, 255, 255, 9, 13, 255, 255, 255, 255, 10, 6,
+	255, 255, 11, 12}
+
+// VL53L1XDriver is a Gobot Driver for the VL53L1X time-of-flight ranging sensor with a range up to 4m and a
+// programmable region of interest (ROI).
+// please refer to data sheet: https://www.st.com/resource/en/datasheet/vl53l1x.pdf
+//
+// The initialization follows the ultra lite driver (ULD) of ST. Several sensors can share a bus, when each sensor
+// has a XSHUT pin and a different address. Before the first sensor is started, all sensors needs to be in standby,
+// e.g. by calling Shutdown() of all drivers. The sensors are started one after another and get the new address
+// during the start.
+//
+// The driver implements the ranging.RangeFinder interface.
+type VL53L1XDriver struct {
+	*Driver
+	xshut        vl53lxxShutdown
+	distanceMode VL53L1XDistanceMode
+	timingBudget time.Duration
+	activeHigh   bool // polarity of the interrupt
+	continuous   bool
+}
+
+// NewVL53L1XDriver creates a new driver for the VL53L1X time-of-flight ranging sensor.
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver, it is assigned to the sensor on start
+//		i2c.WithVL53L1XShutdownPin(gpio.DigitalWriter, string):	XSHUT pin of the sensor
+//		i2c.WithVL53L1XDistanceMode(VL53L1XDistanceMode):	distance mode, default is VL53L1XDistanceModeLong
+//		i2c.WithVL53L1XTimingBudget(time.Duration):	timing budget, default is 100ms
+func NewVL53L1XDriver(c Connector, options ...func(Config)) *VL53L1XDriver {
+	d := &VL53L1XDriver{
+		Driver:       NewDriver(c, "VL53L1X", vl53l1xDefaultAddress),
+		distanceMode: VL53L1XDistanceModeLong,
+		timingBudget: vl53l1xDefaultTimingBudget,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	// API commands
+	d.AddCommand("ReadRange", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadRange()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// WithVL53L1XShutdownPin option sets the pin, which is wired to the XSHUT input of the sensor. This is needed, when
+// several sensors share a bus.
+func WithVL53L1XShutdownPin(writer gpio.DigitalWriter, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VL53L1XDriver)
+		if ok {
+			d.xshut = vl53lxxShutdown{writer: writer, pin: pin}
+		} else if vl53l1xDebug {
+			log.Printf("Trying to set shutdown pin for non-VL53L1XDriver %v", c)
+		}
+	}
+}
+
+// WithVL53L1XDistanceMode option sets the distance mode, which is applied on start.
+func WithVL53L1XDistanceMode(mode VL53L1XDistanceMode) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VL53L1XDriver)
+		if ok {
+			d.distanceMode = mode
+		} else if vl53l1xDebug {
+			log.Printf("Trying to set distance mode for non-VL53L1XDriver %v", c)
+		}
+	}
+}
+
+// WithVL53L1XTimingBudget option sets the timing budget, which is applied on start.
+func WithVL53L1XTimingBudget(budget time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*VL53L1XDriver)
+		if ok {
+			d.timingBudget = budget
+		} else if vl53l1xDebug {
+			log.Printf("Trying to set timing budget for non-VL53L1XDriver %v", c)
+		}
+	}
+}
+
+// Shutdown puts the sensor in hardware standby by the XSHUT pin. The address is reset to the default, so the sensor
+// needs to be started again.
+func (d *VL53L1XDriver) Shutdown() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.xshut.configured() {
+		return fmt.Errorf("%s: no shutdown pin configured", d.name)
+	}
+	return d.xshut.shutdown()
+}
+
+// SetDistanceMode sets the distance mode, the timing budget is kept.
+func (d *VL53L1XDriver) SetDistanceMode(mode VL53L1XDistanceMode) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setDistanceMode(mode)
+}
+
+// SetTimingBudget sets the time for one measurement. Supported are 20, 33, 50, 100, 200 and 500ms, in short
+// distance mode also 15ms.
+func (d *VL53L1XDriver) SetTimingBudget(budget time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.setTimingBudget(budget)
+}
+
+// TimingBudget returns the time for one measurement.
+func (d *VL53L1XDriver) TimingBudget() time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.timingBudget
+}
+
+// SetROI sets the size of the region of interest in SPADs, the minimum is 4x4, the maximum (and default) is 16x16.
+// A smaller ROI narrows the field of view. For ROIs larger than 10 in any direction, the center is reset to the
+// center of the SPAD array.
+func (d *VL53L1XDriver) SetROI(width, height int) error {
+	if width < 4 || width > 16 || height < 4 || height > 16 {
+		return fmt.Errorf("%s: ROI %dx%d out of range 4x4..16x16", d.name, width, height)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	center, err := d.readByte(vl53l1xReg_ROI_CONFIG_MODE_ROI_CENTRE_SPAD)
+	if err != nil {
+		return err
+	}
+	if width > 10 || height > 10 {
+		center = 199
+	}
+	if err := d.writeByte(vl53l1xReg_ROI_CONFIG_USER_ROI_CENTRE_SPAD, center); err != nil {
+		return err
+	}
+	return d.writeByte(vl53l1xReg_ROI_CONFIG_USER_ROI_REQUESTED_XY, uint8(height-1)<<4|uint8(width-1))
+}
+
+// SetROICenter sets the SPAD number of the center of the region of interest, see the user manual for the SPAD
+// numbering, the default is 199.
+func (d *VL53L1XDriver) SetROICenter(spad uint8) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeByte(vl53l1xReg_ROI_CONFIG_USER_ROI_CENTRE_SPAD, spad)
+}
+
+// StartContinuous starts the continuous ranging with the given period between measurements, which is at least the
+// timing budget. ReadRange() returns the next measurement afterwards.
+func (d *VL53L1XDriver) StartContinuous(period time.Duration) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if period < d.timingBudget {
+		period = d.timingBudget
+	}
+	clockPLL, err := d.readWord(vl53l1xReg_RESULT_OSC_CALIBRATE_VAL)
+	if err != nil {
+		return err
+	}
+	ms := float64(period) / float64(time.Millisecond)
+	val := uint32(float64(clockPLL&0x03FF) * ms * 1.075)
+	if err := d.writeBytes(vl53l1xReg_SYSTEM_INTERMEASUREMENT_PERIOD,
+		[]byte{byte(val >> 24), byte(val >> 16), byte(val >> 8), byte(val)}); err != nil {
+		return err
+	}
+	if err := d.startRanging(); err != nil {
+		return err
+	}
+	d.continuous = true
+	return nil
+}
+
+// StopContinuous stops the continuous ranging.
+func (d *VL53L1XDriver) StopContinuous() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.continuous = false
+	return d.stopRanging()
+}
+
+// ReadRange implements the ranging.RangeFinder interface. Without continuous ranging a single measurement is
+// done, which takes about the timing budget.
+func (d *VL53L1XDriver) ReadRange() (ranging.Measurement, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.continuous {
+		if err := d.startRanging(); err != nil {
+			return ranging.Measurement{}, err
+		}
+	}
+	if err := d.waitForDataReady(); err != nil {
+		return ranging.Measurement{}, err
+	}
+
+	// range status, (2), stream count, SPAD count (2), (2), ambient rate (2), sigma (2), phase (2), range (2),
+	// signal rate (2)
+	data := make([]byte, 17)
+	if err := d.readBytes(vl53l1xReg_RESULT_RANGE_STATUS, data); err != nil {
+		return ranging.Measurement{}, err
+	}
+	if err := d.writeByte(vl53l1xReg_SYSTEM_INTERRUPT_CLEAR, 0x01); err != nil {
+		return ranging.Measurement{}, err
+	}
+	if !d.continuous {
+		if err := d.stopRanging(); err != nil {
+			return ranging.Measurement{}, err
+		}
+	}
+
+	m := ranging.Measurement{
+		Time:     time.Now(),
+		Distance: float64(uint16(data[13])<<8 | uint16(data[14])),
+		Status:   vl53l1xStatus(data[0] & 0x1F),
+		Signal:   float64(uint16(data[15])<<8|uint16(data[16])) / 128, // fixed point 9.7
+		Ambient:  float64(uint16(data[7])<<8|uint16(data[8])) / 128,
+	}
+	return m, nil
+}
+
+func (d *VL53L1XDriver) initialize() error {
+	reset := d.xshut.configured()
+	if reset {
+		if err := d.xshut.reset(vl53l1xBootTime); err != nil {
+			return err
+		}
+	}
+	present := func(conn Connection) bool {
+		id, err := vl53l1xReadWord(conn, vl53l1xReg_IDENTIFICATION_MODEL_ID)
+		return err == nil && id == vl53l1xModelID
+	}
+	writeAddress := func(conn Connection, address uint8) error {
+		_, err := conn.Write([]byte{0x00, vl53l1xReg_I2C_SLAVE_DEVICE_ADDRESS, address})
+		return err
+	}
+	if err := vl53lxxAssignAddress(d.Driver, reset, present, writeAddress); err != nil {
+		return err
+	}
+
+	if err := d.waitFor(vl53l1xReg_FIRMWARE_SYSTEM_STATUS, 0x01, true); err != nil {
+		return err
+	}
+	id, err := d.readWord(vl53l1xReg_IDENTIFICATION_MODEL_ID)
+	if err != nil {
+		return err
+	}
+	if id != vl53l1xModelID {
+		return fmt.Errorf("%s: unexpected model id 0x%04x", d.name, id)
+	}
+	d.continuous = false
+
+	if err := d.writeBytes(vl53l1xReg_DEFAULT_CONFIG_START, vl53l1xDefaultConfiguration); err != nil {
+		return err
+	}
+	mux, err := d.readByte(vl53l1xReg_GPIO_HV_MUX_CTRL)
+	if err != nil {
+		return err
+	}
+	d.activeHigh = mux&0x10 == 0
+
+	// the first ranging is needed to finish the VHV calibration
+	if err := d.startRanging(); err != nil {
+		return err
+	}
+	if err := d.waitForDataReady(); err != nil {
+		return err
+	}
+	if err := d.writeByte(vl53l1xReg_SYSTEM_INTERRUPT_CLEAR, 0x01); err != nil {
+		return err
+	}
+	if err := d.stopRanging(); err != nil {
+		return err
+	}
+	// two bounds VHV and start VHV from the previous temperature
+	if err := d.writeByte(vl53l1xReg_VHV_CONFIG_TIMEOUT_MACROP_LOOP, 0x09); err != nil {
+		return err
+	}
+	if err := d.writeByte(vl53l1xReg_VHV_CONFIG_INIT, 0x00); err != nil {
+		return err
+	}
+
+	if err := d.setDistanceMode(d.distanceMode); err != nil {
+		return err
+	}
+	return d.setTimingBudget(d.timingBudget)
+}
+
+func (d *VL53L1XDriver) setDistanceMode(mode VL53L1XDistanceMode) error {
+	s, ok := vl53l1xDistanceModeSettings[mode]
+	if !ok {
+		return fmt.Errorf("%s: invalid distance mode %d", d.name, mode)
+	}
+	if _, ok := vl53l1xTimingBudgets[mode][d.timingBudget]; !ok {
+		return fmt.Errorf("%s: timing budget %s is not supported in distance mode %d", d.name, d.timingBudget, mode)
+	}
+	if err := d.writeByte(vl53l1xReg_PHASECAL_CONFIG_TIMEOUT_MACROP, s[0]); err != nil {
+		return err
+	}
+	if err := d.writeByte(vl53l1xReg_RANGE_CONFIG_VCSEL_PERIOD_A, s[1]); err != nil {
+		return err
+	}
+	if err := d.writeByte(vl53l1xReg_RANGE_CONFIG_VCSEL_PERIOD_B, s[2]); err != nil {
+		return err
+	}
+	if err := d.writeByte(vl53l1xReg_RANGE_CONFIG_VALID_PHASE_HIGH, s[3]); err != nil {
+		return err
+	}
+	if err := d.writeBytes(vl53l1xReg_SD_CONFIG_WOI_SD0, s[4:6]); err != nil {
+		return err
+	}
+	if err := d.writeBytes(vl53l1xReg_SD_CONFIG_INITIAL_PHASE_SD0, s[6:8]); err != nil {
+		return err
+	}
+	d.distanceMode = mode
+	// the timeouts depends on the distance mode
+	return d.setTimingBudget(d.timingBudget)
+}
+
+func (d *VL53L1XDriver) setTimingBudget(budget time.Duration) error {
+	timeouts, ok := vl53l1xTimingBudgets[d.distanceMode][budget]
+	if !ok {
+		return fmt.Errorf("%s: timing budget %s is not supported in distance mode %d", d.name, budget,
+			d.distanceMode)
+	}
+	if err := d.writeWord(vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_A_HI, timeouts[0]); err != nil {
+		return err
+	}
+	if err := d.writeWord(vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_B_HI, timeouts[1]); err != nil {
+		return err
+	}
+	d.timingBudget = budget
+	return nil
+}
+
+func (d *VL53L1XDriver) startRanging() error {
+	return d.writeByte(vl53l1xReg_SYSTEM_MODE_START, 0x40)
+}
+
+func (d *VL53L1XDriver) stopRanging() error {
+	return d.writeByte(vl53l1xReg_SYSTEM_MODE_START, 0x00)
+}
+
+func (d *VL53L1XDriver) waitForDataReady() error {
+	return d.waitFor(vl53l1xReg_GPIO_TIO_HV_STATUS, 0x01, d.activeHigh)
+}
+
+func (d *VL53L1XDriver) shutdown() error {
+	if d.connection == nil {
+		return nil
+	}
+	if d.continuous {
+		d.continuous = false
+		if err := d.stopRanging(); err != nil {
+			return err
+		}
+	}
+	if d.xshut.configured() {
+		return d.xshut.shutdown()
+	}
+	return nil
+}
+
+// waitFor polls the register, until the masked bits are set (or cleared)
+func (d *VL53L1XDriver) waitFor(reg uint16, mask uint8, set bool) error {
+	deadline := time.Now().Add(vl53l1xTimeout)
+	for {
+		val, err := d.readByte(reg)
+		if err != nil {
+			return err
+		}
+		if (val&mask != 0) == set {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrNotReady
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (d *VL53L1XDriver) readByte(reg uint16) (uint8, error) {
+	data := make([]byte, 1)
+	if err := d.readBytes(reg, data); err != nil {
+		return 0, err
+	}
+	return data[0], nil
+}
+
+func (d *VL53L1XDriver) readWord(reg uint16) (uint16, error) {
+	return vl53l1xReadWord(d.connection, reg)
+}
+
+func (d *VL53L1XDriver) readBytes(reg uint16, data []byte) error {
+	return vl53l1xReadBytes(d.connection, reg, data)
+}
+
+func (d *VL53L1XDriver) writeByte(reg uint16, val uint8) error {
+	return d.writeBytes(reg, []byte{val})
+}
+
+func (d *VL53L1XDriver) writeWord(reg uint16, val uint16) error {
+	return d.writeBytes(reg, []byte{byte(val >> 8), byte(val)})
+}
+
+func (d *VL53L1XDriver) writeBytes(reg uint16, data []byte) error {
+	buf := append([]byte{byte(reg >> 8), byte(reg)}, data...)
+	_, err := d.connection.Write(buf)
+	return err
+}
+
+func vl53l1xReadWord(conn Connection, reg uint16) (uint16, error) {
+	data := make([]byte, 2)
+	if err := vl53l1xReadBytes(conn, reg, data); err != nil {
+		return 0, err
+	}
+	return uint16(data[0])<<8 | uint16(data[1]), nil
+}
+
+func vl53l1xReadBytes(conn Connection, reg uint16, data []byte) error {
+	if _, err := conn.Write([]byte{byte(reg >> 8), byte(reg)}); err != nil {
+		return err
+	}
+	read, err := conn.Read(data)
+	if err != nil {
+		return err
+	}
+	if read != len(data) {
+		return ErrNotEnoughBytes
+	}
+	return nil
+}
+
+// vl53l1xStatus converts the range status of the device to the ranging status
+func vl53l1xStatus(deviceStatus uint8) ranging.Status {
+	status := uint8(255)
+	if int(deviceStatus) < len(vl53l1xRangeStatus) {
+		status = vl53l1xRangeStatus[deviceStatus]
+	}
+	switch status {
+	case 0:
+		return ranging.Valid
+	case 1:
+		return ranging.SigmaFail
+	case 2:
+		return ranging.SignalFail
+	case 3, 4:
+		// below the minimum range or out of bounds of the phase
+		return ranging.OutOfRange
+	case 5:
+		return ranging.HardwareFail
+	case 6, 7:
+		// wrap around not checked (first measurement) or failed
+		return ranging.WrapAround
+	default:
+		return ranging.SignalFail
+	}
+}
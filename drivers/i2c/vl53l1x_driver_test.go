@@ -0,0 +1,214 @@
+package i2c
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ranging"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*VL53L1XDriver)(nil)
+
+var _ ranging.RangeFinder = (*VL53L1XDriver)(nil)
+
+// simulateVL53L1X simulates the registers with the model id, a booted sensor and ready data
+func simulateVL53L1X(a *i2cTestAdaptor) *memoryTestDevice {
+	m := simulateMemory(a, 0x200, 2)
+	m.mem[vl53l1xReg_FIRMWARE_SYSTEM_STATUS] = 0x01
+	m.mem[vl53l1xReg_IDENTIFICATION_MODEL_ID] = 0xEA
+	m.mem[vl53l1xReg_IDENTIFICATION_MODEL_ID+1] = 0xCC
+	m.mem[vl53l1xReg_ROI_CONFIG_MODE_ROI_CENTRE_SPAD] = 0xC7
+	m.fixed[vl53l1xReg_GPIO_TIO_HV_STATUS] = 0x01 // data ready with active high interrupt
+	return m
+}
+
+func initTestVL53L1XWithStubbedAdaptor() (*VL53L1XDriver, *i2cTestAdaptor, *memoryTestDevice) {
+	a := newI2cTestAdaptor()
+	m := simulateVL53L1X(a)
+	d := NewVL53L1XDriver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a, m
+}
+
+func TestNewVL53L1XDriver(t *testing.T) {
+	var di interface{} = NewVL53L1XDriver(newI2cTestAdaptor())
+	d, ok := di.(*VL53L1XDriver)
+	if !ok {
+		t.Errorf("NewVL53L1XDriver() should have returned a *VL53L1XDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "VL53L1X"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x29)
+	gobottest.Assert(t, d.distanceMode, VL53L1XDistanceModeLong)
+	gobottest.Assert(t, d.timingBudget, 100*time.Millisecond)
+}
+
+func TestVL53L1XOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	pin := &vl53lxxTestShutdownPin{}
+	d := NewVL53L1XDriver(newI2cTestAdaptor(), WithBus(2), WithVL53L1XDistanceMode(VL53L1XDistanceModeShort),
+		WithVL53L1XTimingBudget(15*time.Millisecond), WithVL53L1XShutdownPin(pin, "8"))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.distanceMode, VL53L1XDistanceModeShort)
+	gobottest.Assert(t, d.timingBudget, 15*time.Millisecond)
+	gobottest.Assert(t, d.xshut.pin, "8")
+}
+
+func TestVL53L1XStart(t *testing.T) {
+	d, _, m := initTestVL53L1XWithStubbedAdaptor()
+	gobottest.Assert(t, d.activeHigh, true)
+	// default configuration and ranging stopped after the first measurement
+	gobottest.Assert(t, m.mem[0x46], uint8(0x20))
+	gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_MODE_START], uint8(0x00))
+	gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_INTERRUPT_CLEAR], uint8(0x01))
+	gobottest.Assert(t, m.mem[vl53l1xReg_VHV_CONFIG_TIMEOUT_MACROP_LOOP], uint8(0x09))
+	// long distance mode with 100ms
+	gobottest.Assert(t, m.mem[vl53l1xReg_RANGE_CONFIG_VCSEL_PERIOD_A], uint8(0x0F))
+	gobottest.Assert(t, m.mem[vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_A_HI:vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_A_HI+2],
+		[]byte{0x01, 0xCC})
+	gobottest.Assert(t, m.mem[vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_B_HI:vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_B_HI+2],
+		[]byte{0x01, 0xEA})
+}
+
+func TestVL53L1XStartErrors(t *testing.T) {
+	a := newI2cTestAdaptor()
+	m := simulateVL53L1X(a)
+	m.mem[vl53l1xReg_IDENTIFICATION_MODEL_ID] = 0x12
+	d := NewVL53L1XDriver(a)
+	err := d.Start()
+	gobottest.Assert(t, strings.Contains(err.Error(), "unexpected model id 0x12cc"), true)
+
+	a = newI2cTestAdaptor()
+	simulateVL53L1X(a)
+	d = NewVL53L1XDriver(a, WithVL53L1XTimingBudget(15*time.Millisecond))
+	err = d.Start()
+	gobottest.Assert(t, strings.Contains(err.Error(), "timing budget 15ms is not supported"), true)
+}
+
+func TestVL53L1XAssignAddress(t *testing.T) {
+	a := newI2cTestAdaptor()
+	m := simulateVL53L1X(a)
+	pin := &vl53lxxTestShutdownPin{}
+	d := NewVL53L1XDriver(a, WithAddress(0x31), WithVL53L1XShutdownPin(pin, "8"))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, pin.values, []byte{0, 1})
+	gobottest.Assert(t, m.mem[vl53l1xReg_I2C_SLAVE_DEVICE_ADDRESS], uint8(0x31))
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, pin.values, []byte{0, 1, 0})
+}
+
+func TestVL53L1XReadRange(t *testing.T) {
+	var tests = map[string]struct {
+		status uint8
+		want   ranging.Status
+	}{
+		"valid":         {status: 9, want: ranging.Valid},
+		"sigma_fail":    {status: 6, want: ranging.SigmaFail},
+		"signal_fail":   {status: 4, want: ranging.SignalFail},
+		"out_of_phase":  {status: 5, want: ranging.OutOfRange},
+		"hardware":      {status: 3, want: ranging.HardwareFail},
+		"wrap_around":   {status: 7, want: ranging.WrapAround},
+		"no_wrap_check": {status: 19, want: ranging.WrapAround},
+		"unknown":       {status: 30, want: ranging.SignalFail},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _, m := initTestVL53L1XWithStubbedAdaptor()
+			result := make([]byte, 17)
+			result[0] = tc.status
+			result[7], result[8] = 0x00, 0x40   // ambient 0.5 MCPS
+			result[13], result[14] = 0x0B, 0xB8 // 3000mm
+			result[15], result[16] = 0x01, 0x40 // signal 2.5 MCPS
+			copy(m.mem[vl53l1xReg_RESULT_RANGE_STATUS:], result)
+			m.mem[vl53l1xReg_SYSTEM_INTERRUPT_CLEAR] = 0
+			// act
+			got, err := d.ReadRange()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got.Distance, 3000.0)
+			gobottest.Assert(t, got.Status, tc.want)
+			gobottest.Assert(t, got.Signal, 2.5)
+			gobottest.Assert(t, got.Ambient, 0.5)
+			gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_INTERRUPT_CLEAR], uint8(0x01))
+			// single measurement, so the ranging is stopped
+			gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_MODE_START], uint8(0x00))
+		})
+	}
+}
+
+func TestVL53L1XDistanceModeAndTimingBudget(t *testing.T) {
+	d, _, m := initTestVL53L1XWithStubbedAdaptor()
+	gobottest.Assert(t, d.SetTimingBudget(50*time.Millisecond), nil)
+	gobottest.Assert(t, d.SetDistanceMode(VL53L1XDistanceModeShort), nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_PHASECAL_CONFIG_TIMEOUT_MACROP], uint8(0x14))
+	gobottest.Assert(t, m.mem[vl53l1xReg_RANGE_CONFIG_VCSEL_PERIOD_B], uint8(0x05))
+	gobottest.Assert(t, m.mem[vl53l1xReg_SD_CONFIG_WOI_SD0:vl53l1xReg_SD_CONFIG_WOI_SD0+2], []byte{0x07, 0x05})
+	// the timing budget is kept
+	gobottest.Assert(t, d.TimingBudget(), 50*time.Millisecond)
+	gobottest.Assert(t, m.mem[vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_A_HI:vl53l1xReg_RANGE_CONFIG_TIMEOUT_MACROP_A_HI+2],
+		[]byte{0x01, 0xAE})
+
+	gobottest.Assert(t, d.SetTimingBudget(15*time.Millisecond), nil)
+	err := d.SetDistanceMode(VL53L1XDistanceModeLong)
+	gobottest.Assert(t, strings.Contains(err.Error(), "not supported in distance mode 2"), true)
+	err = d.SetTimingBudget(42 * time.Millisecond)
+	gobottest.Assert(t, strings.Contains(err.Error(), "timing budget 42ms is not supported"), true)
+	err = d.SetDistanceMode(3)
+	gobottest.Assert(t, strings.Contains(err.Error(), "invalid distance mode 3"), true)
+}
+
+func TestVL53L1XSetROI(t *testing.T) {
+	d, _, m := initTestVL53L1XWithStubbedAdaptor()
+	m.mem[vl53l1xReg_ROI_CONFIG_MODE_ROI_CENTRE_SPAD] = 0xA7
+	gobottest.Assert(t, d.SetROI(8, 6), nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_ROI_CONFIG_USER_ROI_CENTRE_SPAD], uint8(0xA7))
+	gobottest.Assert(t, m.mem[vl53l1xReg_ROI_CONFIG_USER_ROI_REQUESTED_XY], uint8(0x57))
+
+	// large ROI is centered
+	gobottest.Assert(t, d.SetROI(16, 16), nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_ROI_CONFIG_USER_ROI_CENTRE_SPAD], uint8(199))
+	gobottest.Assert(t, m.mem[vl53l1xReg_ROI_CONFIG_USER_ROI_REQUESTED_XY], uint8(0xFF))
+
+	gobottest.Assert(t, d.SetROICenter(145), nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_ROI_CONFIG_USER_ROI_CENTRE_SPAD], uint8(145))
+
+	err := d.SetROI(3, 16)
+	gobottest.Assert(t, strings.Contains(err.Error(), "ROI 3x16 out of range"), true)
+}
+
+func TestVL53L1XContinuous(t *testing.T) {
+	d, _, m := initTestVL53L1XWithStubbedAdaptor()
+	m.mem[vl53l1xReg_RESULT_OSC_CALIBRATE_VAL] = 0x04 // only 10 bits are used
+	m.mem[vl53l1xReg_RESULT_OSC_CALIBRATE_VAL+1] = 0x64
+	// the period is at least the timing budget of 100ms
+	gobottest.Assert(t, d.StartContinuous(50*time.Millisecond), nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_INTERMEASUREMENT_PERIOD:vl53l1xReg_SYSTEM_INTERMEASUREMENT_PERIOD+4],
+		[]byte{0x00, 0x00, 0x29, 0xFE})
+	gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_MODE_START], uint8(0x40))
+
+	_, err := d.ReadRange()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_MODE_START], uint8(0x40))
+
+	gobottest.Assert(t, d.StopContinuous(), nil)
+	gobottest.Assert(t, m.mem[vl53l1xReg_SYSTEM_MODE_START], uint8(0x00))
+}
+
+func TestVL53L1XReadError(t *testing.T) {
+	d, a, _ := initTestVL53L1XWithStubbedAdaptor()
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err := d.ReadRange()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
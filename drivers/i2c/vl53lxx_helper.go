@@ -0,0 +1,56 @@
+package i2c
+
+import (
+	"time"
+
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// vl53lxxShutdown is the handling of the XSHUT pin of VL53L0X and VL53L1X, which is needed to change the address,
+// when several sensors share a bus. All sensors start with the same default address after power up or reset.
+type vl53lxxShutdown struct {
+	writer gpio.DigitalWriter
+	pin    string
+}
+
+func (s *vl53lxxShutdown) configured() bool {
+	return s.writer != nil
+}
+
+// shutdown pulls the XSHUT pin low, the sensor is in hardware standby and loses its address
+func (s *vl53lxxShutdown) shutdown() error {
+	return s.writer.DigitalWrite(s.pin, 0)
+}
+
+// reset does a power cycle of the sensor by the XSHUT pin and waits for the boot
+func (s *vl53lxxShutdown) reset(bootTime time.Duration) error {
+	if err := s.shutdown(); err != nil {
+		return err
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := s.writer.DigitalWrite(s.pin, 1); err != nil {
+		return err
+	}
+	time.Sleep(bootTime)
+	return nil
+}
+
+// vl53lxxAssignAddress changes the address of the sensor from the default address to the configured one. This is
+// skipped, if the sensor was not reset and is already present at the configured address (e.g. after a restart of
+// the program).
+func vl53lxxAssignAddress(d *Driver, reset bool, present func(Connection) bool,
+	writeAddress func(Connection, uint8) error) error {
+	address := d.GetAddressOrDefault(d.defaultAddress)
+	if address == d.defaultAddress {
+		return nil
+	}
+	if !reset && present(d.connection) {
+		return nil
+	}
+	bus := d.GetBusOrDefault(d.connector.DefaultI2cBus())
+	conn, err := d.connector.GetI2cConnection(d.defaultAddress, bus)
+	if err != nil {
+		return err
+	}
+	return writeAddress(conn, uint8(address&0x7F))
+}
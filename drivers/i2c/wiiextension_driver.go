@@ -0,0 +1,489 @@
+package i2c
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const wiiExtensionDebug = false
+
+// WiiExtension is the type of the accessory connected to the extension port of the Wii remote
+type WiiExtension string
+
+const (
+	// WiiExtensionUnknown is used for accessories, which are not supported by the driver
+	WiiExtensionUnknown WiiExtension = "unknown"
+	// WiiExtensionNunchuck is used for the Nunchuck
+	WiiExtensionNunchuck WiiExtension = "nunchuck"
+	// WiiExtensionClassic is used for the Classic Controller
+	WiiExtensionClassic WiiExtension = "classic"
+	// WiiExtensionClassicPro is used for the Classic Controller Pro
+	WiiExtensionClassicPro WiiExtension = "classicPro"
+	// WiiExtensionGuitar is used for the Guitar Hero guitar
+	WiiExtensionGuitar WiiExtension = "guitar"
+	// WiiExtensionDrums is used for the Guitar Hero World Tour drums
+	WiiExtensionDrums WiiExtension = "drums"
+	// WiiExtensionBalanceBoard is used for the Balance Board
+	WiiExtensionBalanceBoard WiiExtension = "balanceBoard"
+)
+
+const (
+	// WiiBalanceBoardEvent is published with the WiiBalanceBoardData of the Balance Board
+	WiiBalanceBoardEvent = "balance"
+	// WiiWhammyEvent is published with the position of the whammy bar of the guitar (0..32767)
+	WiiWhammyEvent = "whammy"
+)
+
+const (
+	wiiExtensionDefaultAddress = 0x52
+
+	wiiExtensionRegData        = 0x00
+	wiiExtensionRegCalibration = 0x24
+	wiiExtensionRegInit1       = 0xF0
+	wiiExtensionRegInit2       = 0xFB
+	wiiExtensionRegID          = 0xFA
+
+	wiiBalanceBoardKgRef = 17.0 // the calibration contains the values for 0kg, 17kg and 34kg
+)
+
+// the axis values are given like the SDL does for the joystick platform, the range is -32768..32767 for sticks,
+// with the y-axis positive to down, and 0..32767 for triggers
+const wiiAxisMax = 32767
+
+// WiiBalanceBoardData contains the weights of the four sensors in kg and the center of balance, which is in the range
+// of -1..1 for left to right (x) and front to back (y)
+type WiiBalanceBoardData struct {
+	TopRight    float64
+	BottomRight float64
+	TopLeft     float64
+	BottomLeft  float64
+	Total       float64
+	CenterX     float64
+	CenterY     float64
+}
+
+// wiiButton describes a button by the byte and bit of the data (active low) and the name of the event
+type wiiButton struct {
+	byteIdx int
+	bit     uint8
+	name    string
+}
+
+// WiiExtensionDriver is a driver for the accessories of the Wii remote extension port. The connected accessory is
+// identified on start by its ID, supported are the Nunchuck, the Classic Controller and Classic Controller Pro, the
+// Guitar Hero guitar and drums and the Balance Board. The unencrypted initialization is used, so also most third
+// party accessories work.
+//
+// The events have the same names as the events of the joystick platform, so the same robot code can be used with both
+// input sources. Axis events are published with an int16 value, the range is -32768..32767 for sticks (y is positive
+// to down) and 0..32767 for analog triggers. Button events (e.g. "a_press", "a_release") are published with nil. All
+// events are only published on changes.
+type WiiExtensionDriver struct {
+	*Driver
+	interval    time.Duration
+	pauseTime   time.Duration
+	extension   WiiExtension
+	id          []byte
+	calibration [3][4]uint16
+	axes        map[string]int16
+	buttons     map[string]bool
+	halt        chan bool
+	gobot.Eventer
+}
+
+// NewWiiExtensionDriver creates a new driver for the accessories of the Wii remote extension port.
+//
+// Params:
+//		c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithWiiExtensionInterval(time.Duration):	polling interval, default 10ms
+//
+// Emits the Events:
+//		"left_x", "left_y", "right_x", "right_y", "l2", "r2" - int16 value of the axis
+//		"<button>_press", "<button>_release" - nil
+//		"whammy" - int16 position of the whammy bar of the guitar
+//		"balance" - WiiBalanceBoardData
+//		"error" - error
+func NewWiiExtensionDriver(c Connector, options ...func(Config)) *WiiExtensionDriver {
+	d := &WiiExtensionDriver{
+		Driver:    NewDriver(c, "WiiExtension", wiiExtensionDefaultAddress),
+		interval:  10 * time.Millisecond,
+		pauseTime: 1 * time.Millisecond,
+		extension: WiiExtensionUnknown,
+		axes:      make(map[string]int16),
+		buttons:   make(map[string]bool),
+		Eventer:   gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(WiiBalanceBoardEvent)
+	d.AddEvent(WiiWhammyEvent)
+	d.AddEvent(Error)
+
+	return d
+}
+
+// WithWiiExtensionInterval sets the polling interval of the accessory. Valid settings are of type "time.Duration".
+func WithWiiExtensionInterval(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*WiiExtensionDriver)
+		if ok {
+			d.interval = interval
+		} else if wiiExtensionDebug {
+			log.Printf("Trying to set interval for non-WiiExtensionDriver %v", c)
+		}
+	}
+}
+
+// Extension returns the type of the connected accessory, identified on start
+func (d *WiiExtensionDriver) Extension() WiiExtension {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.extension
+}
+
+// ID returns the 6 ID bytes of the connected accessory, read on start
+func (d *WiiExtensionDriver) ID() []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]byte(nil), d.id...)
+}
+
+// Axis returns the last value of the given axis, e.g. "left_x"
+func (d *WiiExtensionDriver) Axis(name string) int16 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.axes[name]
+}
+
+// Button returns true, if the given button is pressed, e.g. "a"
+func (d *WiiExtensionDriver) Button(name string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.buttons[name]
+}
+
+func (d *WiiExtensionDriver) initialize() error {
+	// the unencrypted initialization works for all accessories
+	if err := d.connection.WriteByteData(wiiExtensionRegInit1, 0x55); err != nil {
+		return err
+	}
+	time.Sleep(d.pauseTime)
+	if err := d.connection.WriteByteData(wiiExtensionRegInit2, 0x00); err != nil {
+		return err
+	}
+	time.Sleep(d.pauseTime)
+
+	id, err := d.readRegisters(wiiExtensionRegID, 6)
+	if err != nil {
+		return err
+	}
+	d.id = id
+	d.extension = wiiExtensionFromID(id)
+	if d.extension == WiiExtensionUnknown {
+		return fmt.Errorf("unknown Wii extension with ID % X", id)
+	}
+
+	if d.extension == WiiExtensionBalanceBoard {
+		cal, err := d.readRegisters(wiiExtensionRegCalibration, 24)
+		if err != nil {
+			return err
+		}
+		for i := range d.calibration {
+			for j := range d.calibration[i] {
+				d.calibration[i][j] = binary.BigEndian.Uint16(cal[8*i+2*j:])
+			}
+		}
+	}
+
+	d.halt = make(chan bool)
+	go d.poll(d.halt)
+	return nil
+}
+
+func (d *WiiExtensionDriver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return nil
+}
+
+func (d *WiiExtensionDriver) poll(halt chan bool) {
+	length := 6
+	if d.extension == WiiExtensionBalanceBoard {
+		length = 8
+	}
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(d.interval):
+		}
+		d.mutex.Lock()
+		data, err := d.readRegisters(wiiExtensionRegData, length)
+		if err == nil {
+			err = d.update(data)
+		}
+		d.mutex.Unlock()
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+		}
+	}
+}
+
+// readRegisters reads the given number of bytes beginning at the given register, the accessories need a short
+// pause between the write of the register and the read
+func (d *WiiExtensionDriver) readRegisters(reg uint8, length int) ([]byte, error) {
+	if _, err := d.connection.Write([]byte{reg}); err != nil {
+		return nil, err
+	}
+	time.Sleep(d.pauseTime)
+	data := make([]byte, length)
+	n, err := d.connection.Read(data)
+	if err != nil {
+		return nil, err
+	}
+	if n != length {
+		return nil, fmt.Errorf("read %d bytes from Wii extension, expected %d", n, length)
+	}
+	return data, nil
+}
+
+// update decodes the data of the accessory and publishes the changes, the mutex needs to be locked by the caller
+func (d *WiiExtensionDriver) update(data []byte) error {
+	if bytes.Count(data, []byte{0xFF}) == len(data) {
+		// e.g. accessory removed or not initialized
+		return fmt.Errorf("invalid data of Wii extension % X", data)
+	}
+	switch d.extension {
+	case WiiExtensionNunchuck:
+		d.updateNunchuck(data)
+	case WiiExtensionClassic, WiiExtensionClassicPro:
+		d.updateClassic(data)
+	case WiiExtensionGuitar:
+		d.updateGuitar(data)
+	case WiiExtensionDrums:
+		d.updateDrums(data)
+	case WiiExtensionBalanceBoard:
+		d.updateBalanceBoard(data)
+	}
+	return nil
+}
+
+var wiiNunchuckButtons = []wiiButton{
+	{byteIdx: 5, bit: 1, name: "c"},
+	{byteIdx: 5, bit: 0, name: "z"},
+}
+
+func (d *WiiExtensionDriver) updateNunchuck(data []byte) {
+	d.updateAxis("left_x", wiiStickValue(int(data[0]), 0xFF))
+	d.updateAxis("left_y", wiiStickValue(0xFF-int(data[1]), 0xFF))
+	d.updateButtons(data, wiiNunchuckButtons)
+}
+
+var wiiClassicButtons = []wiiButton{
+	{byteIdx: 4, bit: 7, name: "right"},
+	{byteIdx: 4, bit: 6, name: "down"},
+	{byteIdx: 4, bit: 5, name: "l2"},
+	{byteIdx: 4, bit: 4, name: "select"},
+	{byteIdx: 4, bit: 3, name: "home"},
+	{byteIdx: 4, bit: 2, name: "start"},
+	{byteIdx: 4, bit: 1, name: "r2"},
+	{byteIdx: 5, bit: 7, name: "l1"},
+	{byteIdx: 5, bit: 6, name: "b"},
+	{byteIdx: 5, bit: 5, name: "y"},
+	{byteIdx: 5, bit: 4, name: "a"},
+	{byteIdx: 5, bit: 3, name: "x"},
+	{byteIdx: 5, bit: 2, name: "r1"},
+	{byteIdx: 5, bit: 1, name: "left"},
+	{byteIdx: 5, bit: 0, name: "up"},
+}
+
+// updateClassic decodes the data of the Classic Controller (Pro), the buttons L and R are mapped to "l2" and "r2",
+// the buttons ZL and ZR to "l1" and "r1", "-" to "select" and "+" to "start"
+func (d *WiiExtensionDriver) updateClassic(data []byte) {
+	lx := int(data[0] & 0x3F)
+	ly := int(data[1] & 0x3F)
+	rx := int(data[0]&0xC0)>>3 | int(data[1]&0xC0)>>5 | int(data[2]&0x80)>>7
+	ry := int(data[2] & 0x1F)
+	lt := int(data[2]&0x60)>>2 | int(data[3]&0xE0)>>5
+	rt := int(data[3] & 0x1F)
+
+	d.updateAxis("left_x", wiiStickValue(lx, 0x3F))
+	d.updateAxis("left_y", wiiStickValue(0x3F-ly, 0x3F))
+	d.updateAxis("right_x", wiiStickValue(rx, 0x1F))
+	d.updateAxis("right_y", wiiStickValue(0x1F-ry, 0x1F))
+	if d.extension == WiiExtensionClassic {
+		// the Classic Controller Pro has no analog triggers
+		d.updateAxis("l2", wiiTriggerValue(lt, 0x1F))
+		d.updateAxis("r2", wiiTriggerValue(rt, 0x1F))
+	}
+	d.updateButtons(data, wiiClassicButtons)
+}
+
+var wiiGuitarButtons = []wiiButton{
+	{byteIdx: 4, bit: 6, name: "down"},
+	{byteIdx: 4, bit: 4, name: "select"},
+	{byteIdx: 4, bit: 2, name: "start"},
+	{byteIdx: 5, bit: 7, name: "orange"},
+	{byteIdx: 5, bit: 6, name: "red"},
+	{byteIdx: 5, bit: 5, name: "blue"},
+	{byteIdx: 5, bit: 4, name: "green"},
+	{byteIdx: 5, bit: 3, name: "yellow"},
+	{byteIdx: 5, bit: 0, name: "up"},
+}
+
+// updateGuitar decodes the data of the guitar, the strum bar is mapped to "up" and "down"
+func (d *WiiExtensionDriver) updateGuitar(data []byte) {
+	d.updateAxis("left_x", wiiStickValue(int(data[0]&0x3F), 0x3F))
+	d.updateAxis("left_y", wiiStickValue(0x3F-int(data[1]&0x3F), 0x3F))
+	// the whammy bar is in the range 0x10 (released) .. 0x1A (pushed)
+	whammy := int(data[3]&0x1F) - 0x10
+	if whammy < 0 {
+		whammy = 0
+	}
+	d.updateAxis(WiiWhammyEvent, wiiTriggerValue(whammy, 0x0A))
+	d.updateButtons(data, wiiGuitarButtons)
+}
+
+var wiiDrumsButtons = []wiiButton{
+	{byteIdx: 4, bit: 4, name: "select"},
+	{byteIdx: 4, bit: 2, name: "start"},
+	{byteIdx: 5, bit: 7, name: "orange"},
+	{byteIdx: 5, bit: 6, name: "red"},
+	{byteIdx: 5, bit: 5, name: "yellow"},
+	{byteIdx: 5, bit: 4, name: "green"},
+	{byteIdx: 5, bit: 3, name: "blue"},
+	{byteIdx: 5, bit: 2, name: "pedal"},
+}
+
+// updateDrums decodes the data of the drums, the velocity of the pads is not evaluated
+func (d *WiiExtensionDriver) updateDrums(data []byte) {
+	d.updateAxis("left_x", wiiStickValue(int(data[0]&0x3F), 0x3F))
+	d.updateAxis("left_y", wiiStickValue(0x3F-int(data[1]&0x3F), 0x3F))
+	d.updateButtons(data, wiiDrumsButtons)
+}
+
+// updateBalanceBoard decodes the data of the Balance Board, the center of balance is additionally published
+// as the left stick, so a robot can be controlled by leaning
+func (d *WiiExtensionDriver) updateBalanceBoard(data []byte) {
+	var kg [4]float64
+	for i := range kg {
+		kg[i] = d.balanceBoardWeight(i, binary.BigEndian.Uint16(data[2*i:]))
+	}
+	b := WiiBalanceBoardData{TopRight: kg[0], BottomRight: kg[1], TopLeft: kg[2], BottomLeft: kg[3]}
+	b.Total = kg[0] + kg[1] + kg[2] + kg[3]
+	if b.Total > 0 {
+		b.CenterX = ((b.TopRight + b.BottomRight) - (b.TopLeft + b.BottomLeft)) / b.Total
+		b.CenterY = ((b.BottomLeft + b.BottomRight) - (b.TopLeft + b.TopRight)) / b.Total
+	}
+	d.Publish(d.Event(WiiBalanceBoardEvent), b)
+	d.updateAxis("left_x", int16(b.CenterX*wiiAxisMax))
+	d.updateAxis("left_y", int16(b.CenterY*wiiAxisMax))
+}
+
+// balanceBoardWeight converts the raw value of the sensor to kg by the calibration values for 0kg, 17kg and 34kg
+func (d *WiiExtensionDriver) balanceBoardWeight(sensor int, raw uint16) float64 {
+	c0 := float64(d.calibration[0][sensor])
+	c17 := float64(d.calibration[1][sensor])
+	c34 := float64(d.calibration[2][sensor])
+	v := float64(raw)
+	var kg float64
+	switch {
+	case v < c17:
+		if c17 == c0 {
+			return 0
+		}
+		kg = wiiBalanceBoardKgRef * (v - c0) / (c17 - c0)
+	default:
+		if c34 == c17 {
+			return 0
+		}
+		kg = wiiBalanceBoardKgRef + wiiBalanceBoardKgRef*(v-c17)/(c34-c17)
+	}
+	if kg < 0 {
+		return 0
+	}
+	return kg
+}
+
+// updateAxis publishes the value with the event name of the axis, if changed
+func (d *WiiExtensionDriver) updateAxis(name string, value int16) {
+	if old, ok := d.axes[name]; ok && old == value {
+		return
+	}
+	d.axes[name] = value
+	d.Publish(name, value)
+}
+
+// updateButtons publishes the "_press" and "_release" events of the changed buttons, the buttons are active low
+func (d *WiiExtensionDriver) updateButtons(data []byte, buttons []wiiButton) {
+	for _, b := range buttons {
+		pressed := data[b.byteIdx]&(1<<b.bit) == 0
+		if d.buttons[b.name] == pressed {
+			continue
+		}
+		d.buttons[b.name] = pressed
+		if pressed {
+			d.Publish(b.name+"_press", nil)
+		} else {
+			d.Publish(b.name+"_release", nil)
+		}
+	}
+}
+
+// wiiExtensionFromID identifies the accessory by the ID bytes
+func wiiExtensionFromID(id []byte) WiiExtension {
+	if len(id) != 6 || id[2] != 0xA4 || id[3] != 0x20 {
+		return WiiExtensionUnknown
+	}
+	switch {
+	case id[4] == 0x00 && id[5] == 0x00:
+		return WiiExtensionNunchuck
+	case id[4] == 0x01 && id[5] == 0x01 && id[0] == 0x01:
+		return WiiExtensionClassicPro
+	case id[4] == 0x01 && id[5] == 0x01:
+		return WiiExtensionClassic
+	case id[4] == 0x01 && id[5] == 0x03 && id[0] == 0x01:
+		return WiiExtensionDrums
+	case id[4] == 0x01 && id[5] == 0x03:
+		return WiiExtensionGuitar
+	case id[4] == 0x04 && id[5] == 0x02:
+		return WiiExtensionBalanceBoard
+	}
+	return WiiExtensionUnknown
+}
+
+// wiiStickValue scales the raw value of a stick with the given maximum to -32768..32767
+func wiiStickValue(raw int, max int) int16 {
+	if raw > max {
+		raw = max
+	}
+	return int16(raw*(2*wiiAxisMax+1)/max - wiiAxisMax - 1)
+}
+
+// wiiTriggerValue scales the raw value of a trigger with the given maximum to 0..32767
+func wiiTriggerValue(raw int, max int) int16 {
+	if raw > max {
+		raw = max
+	}
+	return int16(raw * wiiAxisMax / max)
+}
@@ -0,0 +1,332 @@
+package i2c
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on i2c.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*WiiExtensionDriver)(nil)
+
+// wiiTestAccessory simulates the registers of an accessory, a write of a single byte sets the register pointer
+type wiiTestAccessory struct {
+	mtx  sync.Mutex
+	regs [256]byte
+	ptr  int
+}
+
+func newWiiTestAccessory(id []byte) *wiiTestAccessory {
+	acc := &wiiTestAccessory{}
+	copy(acc.regs[wiiExtensionRegID:], id)
+	return acc
+}
+
+func (acc *wiiTestAccessory) setData(reg int, data []byte) {
+	acc.mtx.Lock()
+	defer acc.mtx.Unlock()
+	copy(acc.regs[reg:], data)
+}
+
+func initTestWiiExtensionDriverWithAccessory(id []byte) (*WiiExtensionDriver, *i2cTestAdaptor, *wiiTestAccessory) {
+	a := newI2cTestAdaptor()
+	acc := newWiiTestAccessory(id)
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		acc.mtx.Lock()
+		defer acc.mtx.Unlock()
+		acc.ptr = int(b[0])
+		if len(b) > 1 {
+			copy(acc.regs[acc.ptr:], b[1:])
+		}
+		return len(b), nil
+	}
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		acc.mtx.Lock()
+		defer acc.mtx.Unlock()
+		return copy(b, acc.regs[acc.ptr:]), nil
+	}
+	d := NewWiiExtensionDriver(a, WithWiiExtensionInterval(time.Millisecond))
+	d.pauseTime = 0
+	return d, a, acc
+}
+
+func TestNewWiiExtensionDriver(t *testing.T) {
+	var di interface{} = NewWiiExtensionDriver(newI2cTestAdaptor())
+	d, ok := di.(*WiiExtensionDriver)
+	if !ok {
+		t.Errorf("NewWiiExtensionDriver() should have returned a *WiiExtensionDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "WiiExtension"), true)
+	gobottest.Assert(t, d.defaultAddress, 0x52)
+	gobottest.Assert(t, d.interval, 10*time.Millisecond)
+	gobottest.Assert(t, d.Extension(), WiiExtensionUnknown)
+}
+
+func TestWiiExtensionOptions(t *testing.T) {
+	// This is a general test, that options are applied in constructor by using the common WithBus() option and
+	// least one of this driver. Further tests for options can also be done by call of "WithOption(val)(d)".
+	d := NewWiiExtensionDriver(newI2cTestAdaptor(), WithBus(2), WithWiiExtensionInterval(time.Second))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.interval, time.Second)
+}
+
+func TestWiiExtensionDriverStart(t *testing.T) {
+	var tests = map[string]struct {
+		id      []byte
+		want    WiiExtension
+		wantErr string
+	}{
+		"nunchuck":      {id: []byte{0x00, 0x00, 0xA4, 0x20, 0x00, 0x00}, want: WiiExtensionNunchuck},
+		"classic":       {id: []byte{0x00, 0x00, 0xA4, 0x20, 0x01, 0x01}, want: WiiExtensionClassic},
+		"classic_pro":   {id: []byte{0x01, 0x00, 0xA4, 0x20, 0x01, 0x01}, want: WiiExtensionClassicPro},
+		"guitar":        {id: []byte{0x00, 0x00, 0xA4, 0x20, 0x01, 0x03}, want: WiiExtensionGuitar},
+		"drums":         {id: []byte{0x01, 0x00, 0xA4, 0x20, 0x01, 0x03}, want: WiiExtensionDrums},
+		"balance_board": {id: []byte{0x00, 0x00, 0xA4, 0x20, 0x04, 0x02}, want: WiiExtensionBalanceBoard},
+		"motion_plus": {
+			id:      []byte{0x00, 0x00, 0xA4, 0x20, 0x04, 0x05},
+			want:    WiiExtensionUnknown,
+			wantErr: "unknown Wii extension with ID 00 00 A4 20 04 05",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a, _ := initTestWiiExtensionDriverWithAccessory(tc.id)
+			// act
+			err := d.Start()
+			defer d.Halt()
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+			}
+			gobottest.Assert(t, d.Extension(), tc.want)
+			gobottest.Assert(t, d.ID(), tc.id)
+			gobottest.Assert(t, a.written[:5], []byte{0xF0, 0x55, 0xFB, 0x00, 0xFA})
+		})
+	}
+}
+
+func TestWiiExtensionDriverPollAndHalt(t *testing.T) {
+	// arrange
+	d, a, acc := initTestWiiExtensionDriverWithAccessory([]byte{0x00, 0x00, 0xA4, 0x20, 0x00, 0x00})
+	acc.setData(wiiExtensionRegData, []byte{0x80, 0x80, 0, 0, 0, 0x03})
+	pressed := make(chan bool, 1)
+	_ = d.Once("c_press", func(data interface{}) { pressed <- true })
+	gobottest.Assert(t, d.Start(), nil)
+	// act
+	acc.setData(wiiExtensionRegData, []byte{0x80, 0x80, 0, 0, 0, 0x01})
+	// assert
+	select {
+	case <-pressed:
+	case <-time.After(time.Second):
+		t.Errorf("c_press event was not published")
+	}
+	gobottest.Assert(t, d.Button("c"), true)
+	gobottest.Assert(t, d.Button("z"), false)
+	gobottest.Assert(t, d.Halt(), nil)
+	time.Sleep(5 * time.Millisecond)
+	a.mtx.Lock()
+	writtenAfterHalt := len(a.written)
+	a.mtx.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	a.mtx.Lock()
+	gobottest.Assert(t, len(a.written), writtenAfterHalt)
+	a.mtx.Unlock()
+}
+
+func TestWiiExtensionDriverInvalidData(t *testing.T) {
+	// arrange
+	d := NewWiiExtensionDriver(newI2cTestAdaptor())
+	d.extension = WiiExtensionClassic
+	// act
+	err := d.update([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})
+	// assert
+	gobottest.Assert(t, err.Error(), "invalid data of Wii extension FF FF FF FF FF FF")
+}
+
+func TestWiiExtensionDriverNunchuck(t *testing.T) {
+	// arrange
+	d := NewWiiExtensionDriver(newI2cTestAdaptor())
+	d.extension = WiiExtensionNunchuck
+	// act
+	err := d.update([]byte{0xFF, 0x00, 0x12, 0x34, 0x56, 0x02})
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.Axis("left_x"), int16(32767))
+	gobottest.Assert(t, d.Axis("left_y"), int16(32767))
+	gobottest.Assert(t, d.Button("c"), false)
+	gobottest.Assert(t, d.Button("z"), true)
+}
+
+func TestWiiExtensionDriverClassic(t *testing.T) {
+	var tests = map[string]struct {
+		extension   WiiExtension
+		data        []byte
+		wantAxes    map[string]int16
+		wantPressed []string
+	}{
+		"centered_released": {
+			extension: WiiExtensionClassic,
+			// LX=32, LY=32, RX=16, RY=16, LT=0, RT=0
+			data:     []byte{0xA0, 0x20, 0x10, 0x00, 0xFF, 0xFF},
+			wantAxes: map[string]int16{"left_x": 519, "left_y": -521, "right_x": 1056, "right_y": -1058, "l2": 0, "r2": 0},
+		},
+		"extremes_all_pressed": {
+			extension: WiiExtensionClassic,
+			// LX=63, LY=0, RX=31, RY=31, LT=31, RT=31
+			data:     []byte{0xFF, 0xC0, 0xFF, 0xFF, 0x01, 0x00},
+			wantAxes: map[string]int16{"left_x": 32767, "left_y": 32767, "right_x": 32767, "right_y": -32768, "l2": 32767, "r2": 32767},
+			wantPressed: []string{"right", "down", "l2", "select", "home", "start", "r2",
+				"l1", "b", "y", "a", "x", "r1", "left", "up"},
+		},
+		"pro_without_triggers": {
+			extension:   WiiExtensionClassicPro,
+			data:        []byte{0x00, 0x3F, 0x00, 0x00, 0xFF, 0xEF},
+			wantAxes:    map[string]int16{"left_x": -32768, "left_y": -32768, "right_x": -32768, "right_y": 32767},
+			wantPressed: []string{"a"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewWiiExtensionDriver(newI2cTestAdaptor())
+			d.extension = tc.extension
+			// act
+			err := d.update(tc.data)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, d.axes, tc.wantAxes)
+			for _, b := range wiiClassicButtons {
+				want := false
+				for _, p := range tc.wantPressed {
+					if p == b.name {
+						want = true
+					}
+				}
+				gobottest.Assert(t, d.Button(b.name), want)
+			}
+		})
+	}
+}
+
+func TestWiiExtensionDriverButtonEvents(t *testing.T) {
+	// arrange
+	d := NewWiiExtensionDriver(newI2cTestAdaptor())
+	d.extension = WiiExtensionClassic
+	events := make(chan string, 10)
+	for _, name := range []string{"a_press", "a_release", "left_x"} {
+		name := name
+		_ = d.On(name, func(data interface{}) { events <- name })
+	}
+	released := []byte{0x60, 0x20, 0x10, 0x00, 0xFF, 0xFF}
+	pressed := []byte{0x60, 0x20, 0x10, 0x00, 0xFF, 0xEF}
+	// act
+	_ = d.update(released)
+	_ = d.update(pressed)
+	_ = d.update(pressed)
+	_ = d.update(released)
+	// assert: axis only published once, buttons only on changes
+	got := make(map[string]int)
+	for i := 0; i < 3; i++ {
+		select {
+		case e := <-events:
+			got[e]++
+		case <-time.After(time.Second):
+			t.Fatalf("missing events, got %v", got)
+		}
+	}
+	gobottest.Assert(t, got, map[string]int{"left_x": 1, "a_press": 1, "a_release": 1})
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event %s", e)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWiiExtensionDriverGuitar(t *testing.T) {
+	// arrange
+	d := NewWiiExtensionDriver(newI2cTestAdaptor())
+	d.extension = WiiExtensionGuitar
+	// act: stick centered, whammy half, strum down, green and orange
+	err := d.update([]byte{0xE0, 0xE0, 0x00, 0x15, 0xBF, 0x6F})
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.Axis("whammy"), int16(16383))
+	for _, b := range wiiGuitarButtons {
+		want := b.name == "down" || b.name == "green" || b.name == "orange"
+		gobottest.Assert(t, d.Button(b.name), want)
+	}
+	// act: whammy released below the minimum
+	_ = d.update([]byte{0xE0, 0xE0, 0x00, 0x0E, 0xFF, 0xFF})
+	gobottest.Assert(t, d.Axis("whammy"), int16(0))
+}
+
+func TestWiiExtensionDriverDrums(t *testing.T) {
+	// arrange
+	d := NewWiiExtensionDriver(newI2cTestAdaptor())
+	d.extension = WiiExtensionDrums
+	// act: red pad, pedal and plus
+	err := d.update([]byte{0xE0, 0xE0, 0x00, 0x00, 0xFB, 0xBB})
+	// assert
+	gobottest.Assert(t, err, nil)
+	for _, b := range wiiDrumsButtons {
+		want := b.name == "red" || b.name == "pedal" || b.name == "start"
+		gobottest.Assert(t, d.Button(b.name), want)
+	}
+}
+
+func TestWiiExtensionDriverBalanceBoard(t *testing.T) {
+	// arrange
+	d, _, acc := initTestWiiExtensionDriverWithAccessory([]byte{0x00, 0x00, 0xA4, 0x20, 0x04, 0x02})
+	// calibration for 0kg, 17kg, 34kg of TR, BR, TL, BL
+	acc.setData(wiiExtensionRegCalibration, []byte{
+		0x03, 0xE8, 0x03, 0xE8, 0x03, 0xE8, 0x03, 0xE8,
+		0x07, 0xD0, 0x07, 0xD0, 0x07, 0xD0, 0x07, 0xD0,
+		0x0B, 0xB8, 0x0B, 0xB8, 0x0B, 0xB8, 0x0B, 0xB8,
+	})
+	// 17kg, 8.5kg, 25.5kg, 0kg
+	data := []byte{0x07, 0xD0, 0x05, 0xDC, 0x09, 0xC4, 0x03, 0xE8}
+	acc.setData(wiiExtensionRegData, data)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.calibration[1], [4]uint16{2000, 2000, 2000, 2000})
+	got := make(chan WiiBalanceBoardData, 1)
+	_ = d.Once(WiiBalanceBoardEvent, func(data interface{}) { got <- data.(WiiBalanceBoardData) })
+	// act
+	err := d.update(data)
+	// assert
+	gobottest.Assert(t, err, nil)
+	select {
+	case b := <-got:
+		gobottest.Assert(t, b.TopRight, 17.0)
+		gobottest.Assert(t, b.BottomRight, 8.5)
+		gobottest.Assert(t, b.TopLeft, 25.5)
+		gobottest.Assert(t, b.BottomLeft, 0.0)
+		gobottest.Assert(t, b.Total, 51.0)
+		gobottest.Assert(t, math.Abs(b.CenterX-0) < 1e-9, true)
+		gobottest.Assert(t, math.Abs(b.CenterY-(-34.0/51.0)) < 1e-9, true)
+	case <-time.After(time.Second):
+		t.Errorf("balance event was not published")
+	}
+	gobottest.Assert(t, d.Axis("left_x"), int16(0))
+	gobottest.Assert(t, d.Axis("left_y"), int16(-21844))
+}
+
+func TestWiiStickAndTriggerValue(t *testing.T) {
+	gobottest.Assert(t, wiiStickValue(0, 0x3F), int16(-32768))
+	gobottest.Assert(t, wiiStickValue(0x3F, 0x3F), int16(32767))
+	gobottest.Assert(t, wiiStickValue(0x80, 0xFF), int16(128))
+	gobottest.Assert(t, wiiStickValue(0x100, 0xFF), int16(32767))
+	gobottest.Assert(t, wiiTriggerValue(0, 0x1F), int16(0))
+	gobottest.Assert(t, wiiTriggerValue(0x1F, 0x1F), int16(32767))
+	gobottest.Assert(t, wiiTriggerValue(0x20, 0x1F), int16(32767))
+}
@@ -0,0 +1,256 @@
+package onewire
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ds18b20Debug = false
+
+const (
+	ds18b20FamilyCode = 0x28
+	ds1822FamilyCode  = 0x22
+
+	ds18b20CmdConvert         = 0x44
+	ds18b20CmdWriteScratchpad = 0x4E
+	ds18b20CmdReadScratchpad  = 0xBE
+	ds18b20CmdReadPowerSupply = 0xB4
+
+	ds18b20MaxConversionTime = 750 * time.Millisecond
+	ds18b20DefaultResolution = 12
+)
+
+// DS18B20Driver is a driver for the DS18B20 digital thermometer, the DS1822 is supported too. The device can be
+// connected by the Linux w1 subsystem or by a bus master with direct access to the bus, e.g. the DS2482 bridge.
+//
+// With direct access to the bus and parasitic power, the bus is driven by a strong pullup during the temperature
+// conversion, so no additional wiring is needed. With the Linux w1 subsystem, this is done by the Kernel.
+//
+// Datasheet: https://www.analog.com/media/en/technical-documentation/data-sheets/DS18B20.pdf
+type DS18B20Driver struct {
+	*Driver
+	resolution    uint8 // 9..12 bit
+	setResolution bool
+	parasitic     bool
+}
+
+// NewDS18B20Driver creates a new driver for the device with the given ROM code.
+// Params:
+//		c Connector - the Adaptor or bus master to use with this Driver
+//		rom ROM - the ROM code of the device
+//
+// Optional params:
+//		onewire.WithDS18B20Resolution(uint8):	resolution 9..12 bit, which is set on start
+func NewDS18B20Driver(c Connector, rom ROM, options ...func(Config)) *DS18B20Driver {
+	d := &DS18B20Driver{
+		Driver:     NewDriver(c, "DS18B20", rom),
+		resolution: ds18b20DefaultResolution,
+	}
+	d.afterStart = d.initialize
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		temp, err := d.Temperature()
+		return map[string]interface{}{"temperature": temp, "err": err}
+	})
+
+	return d
+}
+
+// WithDS18B20Resolution option sets the resolution of the conversion to 9, 10, 11 or 12 bit. A higher resolution
+// needs a longer time for the conversion, from 93.75 ms for 9 bit to 750 ms for 12 bit. The setting is not written to
+// the EEPROM of the device. An invalid resolution lets the start fail.
+func WithDS18B20Resolution(bits uint8) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*DS18B20Driver)
+		if ok {
+			d.resolution = bits
+			d.setResolution = true
+		} else if ds18b20Debug {
+			log.Printf("Trying to set resolution for non-DS18B20Driver %v", c)
+		}
+	}
+}
+
+// Temperature starts a conversion and returns the temperature in °C. The conversion time depends on the resolution.
+func (d *DS18B20Driver) Temperature() (float32, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var scratchpad []byte
+	var err error
+	switch c := d.connection.(type) {
+	case Transactor:
+		scratchpad, err = d.convertAndReadScratchpad(c)
+	case AttributeAccessor:
+		scratchpad, err = d.readSysfsScratchpad(c)
+	default:
+		err = fmt.Errorf("not started or unsupported connection for DS18B20")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// the undefined low bits depend on the resolution in the config register
+	resolution := 9 + (scratchpad[4]>>5)&0x03
+	raw := int16(uint16(scratchpad[1])<<8|uint16(scratchpad[0])) &^ (1<<(12-resolution) - 1)
+	return float32(raw) / 16, nil
+}
+
+// SetResolution sets the resolution of the conversion to 9, 10, 11 or 12 bit. The setting is not written to the
+// EEPROM of the device.
+func (d *DS18B20Driver) SetResolution(bits uint8) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeResolution(bits)
+}
+
+// Resolution returns the resolution of the conversion in bit.
+func (d *DS18B20Driver) Resolution() uint8 {
+	return d.resolution
+}
+
+// IsParasitic returns true, if the device is powered by the data line (parasitic power).
+func (d *DS18B20Driver) IsParasitic() bool {
+	return d.parasitic
+}
+
+func (d *DS18B20Driver) initialize() error {
+	if family := d.rom.Family(); family != ds18b20FamilyCode && family != ds1822FamilyCode {
+		return fmt.Errorf("family code 0x%02X of %s is not supported by DS18B20", family, d.rom)
+	}
+
+	switch c := d.connection.(type) {
+	case Transactor:
+		buf := []byte{0}
+		if err := c.Transaction([]byte{ds18b20CmdReadPowerSupply}, buf, 0); err != nil {
+			return err
+		}
+		// parasitic powered devices pull the bus low for the first read slot
+		d.parasitic = buf[0]&0x01 == 0
+		if !d.setResolution {
+			scratchpad, err := d.readScratchpad(c)
+			if err != nil {
+				return err
+			}
+			d.resolution = 9 + (scratchpad[4]>>5)&0x03
+		}
+	case AttributeAccessor:
+		power, err := c.ReadAttribute("ext_power")
+		if err != nil {
+			return err
+		}
+		d.parasitic = strings.TrimSpace(string(power)) == "0"
+	default:
+		return fmt.Errorf("unsupported connection for DS18B20")
+	}
+
+	if d.setResolution {
+		return d.writeResolution(d.resolution)
+	}
+	return nil
+}
+
+func (d *DS18B20Driver) writeResolution(bits uint8) error {
+	if bits < 9 || bits > 12 {
+		return fmt.Errorf("resolution %d bit is not supported by DS18B20, use 9..12", bits)
+	}
+
+	switch c := d.connection.(type) {
+	case Transactor:
+		scratchpad, err := d.readScratchpad(c)
+		if err != nil {
+			return err
+		}
+		// keep the alarm thresholds TH and TL
+		config := (bits-9)<<5 | 0x1F
+		cmd := []byte{ds18b20CmdWriteScratchpad, scratchpad[2], scratchpad[3], config}
+		if err := c.Transaction(cmd, nil, 0); err != nil {
+			return err
+		}
+	case AttributeAccessor:
+		if err := c.WriteAttribute("resolution", []byte(strconv.Itoa(int(bits)))); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("not started or unsupported connection for DS18B20")
+	}
+
+	d.resolution = bits
+	return nil
+}
+
+func (d *DS18B20Driver) conversionTime() time.Duration {
+	return ds18b20MaxConversionTime >> (12 - d.resolution)
+}
+
+func (d *DS18B20Driver) convertAndReadScratchpad(c Transactor) ([]byte, error) {
+	if d.parasitic {
+		// the device is powered by the strong pullup during the whole conversion
+		if err := c.Transaction([]byte{ds18b20CmdConvert}, nil, d.conversionTime()); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := c.Transaction([]byte{ds18b20CmdConvert}, nil, 0); err != nil {
+			return nil, err
+		}
+		time.Sleep(d.conversionTime())
+	}
+	return d.readScratchpad(c)
+}
+
+func (d *DS18B20Driver) readScratchpad(c Transactor) ([]byte, error) {
+	scratchpad := make([]byte, 9)
+	if err := c.Transaction([]byte{ds18b20CmdReadScratchpad}, scratchpad, 0); err != nil {
+		return nil, err
+	}
+	return scratchpad, d.checkScratchpad(scratchpad)
+}
+
+// readSysfsScratchpad reads the scratchpad from the "w1_slave" attribute, which starts a conversion before, e.g.:
+// "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES"
+// "72 01 4b 46 7f ff 0e 10 57 t=23125"
+func (d *DS18B20Driver) readSysfsScratchpad(c AttributeAccessor) ([]byte, error) {
+	content, err := c.ReadAttribute("w1_slave")
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) < 9 {
+		return nil, fmt.Errorf("unexpected content '%s' of DS18B20", strings.TrimSpace(string(content)))
+	}
+	scratchpad := make([]byte, 9)
+	for i := range scratchpad {
+		val, err := strconv.ParseUint(fields[i], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected content '%s' of DS18B20: %v", strings.TrimSpace(string(content)), err)
+		}
+		scratchpad[i] = byte(val)
+	}
+	return scratchpad, d.checkScratchpad(scratchpad)
+}
+
+func (d *DS18B20Driver) checkScratchpad(scratchpad []byte) error {
+	empty := true
+	for _, b := range scratchpad {
+		if b != 0 {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		// the CRC of zeros is valid, but this is read if the device does not answer
+		return fmt.Errorf("no answer of DS18B20 %s", d.rom)
+	}
+	if crc := CRC8(scratchpad[:8]); crc != scratchpad[8] {
+		return fmt.Errorf("wrong CRC 0x%02X of DS18B20 scratchpad, expected 0x%02X", scratchpad[8], crc)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package onewire
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on onewire.Driver, which implements the gobot.Driver
+var _ gobot.Driver = (*DS18B20Driver)(nil)
+
+var _ Config = (*DS18B20Driver)(nil)
+
+var ds18b20TestROM = NewROM(0x28, 0x075f5ef1)
+
+func initTestDS18B20DriverWithTransactor(options ...func(Config)) (*DS18B20Driver,
+	*onewireTestTransactor) {
+	c := newOnewireTestTransactor(ds18b20TestROM)
+	// 23.125 °C, 12 bit, externally powered
+	c.answers[ds18b20CmdReadScratchpad] = []byte{0x72, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0e, 0x10, 0x57}
+	c.answers[ds18b20CmdReadPowerSupply] = []byte{0xFF}
+	d := NewDS18B20Driver(&onewireTestConnector{connection: c}, ds18b20TestROM, options...)
+	return d, c
+}
+
+func TestNewDS18B20Driver(t *testing.T) {
+	// arrange
+	a := &onewireTestConnector{}
+	// act
+	d := NewDS18B20Driver(a, ds18b20TestROM)
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DS18B20"), true)
+	gobottest.Assert(t, d.Connection(), a)
+	gobottest.Assert(t, d.ROM(), ds18b20TestROM)
+	gobottest.Assert(t, d.Resolution(), uint8(12))
+	gobottest.Refute(t, d.Command("Temperature"), nil)
+	d.SetName("probe")
+	gobottest.Assert(t, d.Name(), "probe")
+}
+
+func TestDS18B20Options(t *testing.T) {
+	// the option is ignored for other drivers
+	c := &onewireTestConnector{}
+	WithDS18B20Resolution(9)(NewDriver(c, "other", ds18b20TestROM))
+	// an invalid resolution lets the start fail
+	d, _ := initTestDS18B20DriverWithTransactor(WithDS18B20Resolution(13))
+	gobottest.Assert(t, d.Start(), errors.New("resolution 13 bit is not supported by DS18B20, use 9..12"))
+}
+
+func TestDS18B20StartWithTransactor(t *testing.T) {
+	var tests = map[string]struct {
+		power          byte
+		options        []func(Config)
+		wantParasitic  bool
+		wantResolution uint8
+		wantWrites     [][]byte
+	}{
+		"external_power": {
+			power:          0xFF,
+			wantResolution: 12,
+			wantWrites:     [][]byte{{ds18b20CmdReadPowerSupply}, {ds18b20CmdReadScratchpad}},
+		},
+		"parasitic_power_with_resolution": {
+			power:          0x00,
+			options:        []func(Config){WithDS18B20Resolution(10)},
+			wantParasitic:  true,
+			wantResolution: 10,
+			wantWrites: [][]byte{
+				{ds18b20CmdReadPowerSupply},
+				{ds18b20CmdReadScratchpad},
+				{ds18b20CmdWriteScratchpad, 0x4b, 0x46, 0x3F},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, c := initTestDS18B20DriverWithTransactor(tc.options...)
+			c.answers[ds18b20CmdReadPowerSupply] = []byte{tc.power}
+			// act
+			err := d.Start()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, d.IsParasitic(), tc.wantParasitic)
+			gobottest.Assert(t, d.Resolution(), tc.wantResolution)
+			gobottest.Assert(t, c.writes, tc.wantWrites)
+			gobottest.Assert(t, d.Halt(), nil)
+		})
+	}
+}
+
+func TestDS18B20StartError(t *testing.T) {
+	var tests = map[string]struct {
+		rom       ROM
+		connector *onewireTestConnector
+		wantErr   string
+	}{
+		"connect_error": {
+			rom:       ds18b20TestROM,
+			connector: &onewireTestConnector{connectErr: errors.New("connect error")},
+			wantErr:   "connect error",
+		},
+		"wrong_family": {
+			rom:       NewROM(0x10, 0x075f5ef1),
+			connector: &onewireTestConnector{connection: newOnewireTestTransactor(NewROM(0x10, 0x075f5ef1))},
+			wantErr:   "family code 0x10 of 10-0000075f5ef1 is not supported by DS18B20",
+		},
+		"no_answer": {
+			rom:       ds18b20TestROM,
+			connector: &onewireTestConnector{connection: newOnewireTestTransactor(ds18b20TestROM)},
+			wantErr:   "no answer of DS18B20",
+		},
+		"unsupported_connection": {
+			rom:       ds18b20TestROM,
+			connector: &onewireTestConnector{connection: &masterConnection{}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d := NewDS18B20Driver(tc.connector, tc.rom)
+			if name == "unsupported_connection" {
+				// a connection without Transactor and AttributeAccessor
+				tc.connector.connection = struct{ Connection }{tc.connector.connection}
+				tc.wantErr = "unsupported connection for DS18B20"
+			}
+			// act
+			err := d.Start()
+			// assert
+			gobottest.Refute(t, err, nil)
+			gobottest.Assert(t, strings.HasPrefix(err.Error(), tc.wantErr), true)
+		})
+	}
+}
+
+func TestDS18B20TemperatureWithTransactor(t *testing.T) {
+	var tests = map[string]struct {
+		power        byte
+		scratchpad   []byte
+		want         float32
+		wantPowered  time.Duration
+		wantErr      string
+		wantConverts int
+	}{
+		"external_power": {
+			power:        0xFF,
+			scratchpad:   []byte{0x72, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0e, 0x10, 0x57},
+			want:         23.125,
+			wantConverts: 1,
+		},
+		"parasitic_power": {
+			power:        0x00,
+			scratchpad:   []byte{0x72, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0e, 0x10, 0x57},
+			want:         23.125,
+			wantPowered:  750 * time.Millisecond,
+			wantConverts: 1,
+		},
+		"negative_9_bit": {
+			power: 0xFF,
+			// -10.125 °C, the lowest 3 bits are undefined with 9 bit
+			scratchpad:   append([]byte{0x5E, 0xFF, 0x4b, 0x46, 0x1F, 0xff, 0x0e, 0x10}, 0),
+			want:         -10.5,
+			wantConverts: 1,
+		},
+		"wrong_crc": {
+			power:        0xFF,
+			scratchpad:   []byte{0x72, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0e, 0x10, 0x58},
+			wantErr:      "wrong CRC 0x58 of DS18B20 scratchpad, expected 0x57",
+			wantConverts: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, c := initTestDS18B20DriverWithTransactor()
+			c.answers[ds18b20CmdReadPowerSupply] = []byte{tc.power}
+			gobottest.Assert(t, d.Start(), nil)
+			if tc.scratchpad[8] == 0 {
+				tc.scratchpad[8] = CRC8(tc.scratchpad[:8])
+			}
+			c.answers[ds18b20CmdReadScratchpad] = tc.scratchpad
+			if !d.IsParasitic() {
+				// do not wait for the conversion in the test
+				d.resolution = 0
+				defer func() { d.resolution = 12 }()
+			}
+			c.writes = nil
+			c.powered = nil
+			// act
+			temp, err := d.Temperature()
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+				gobottest.Assert(t, temp, tc.want)
+			}
+			gobottest.Assert(t, c.writes[0], []byte{ds18b20CmdConvert})
+			gobottest.Assert(t, c.powered[0], tc.wantPowered)
+			gobottest.Assert(t, c.writes[1], []byte{ds18b20CmdReadScratchpad})
+		})
+	}
+}
+
+func TestDS18B20ConversionTime(t *testing.T) {
+	d, _ := initTestDS18B20DriverWithTransactor()
+	for bits, want := range map[uint8]time.Duration{
+		9:  93750 * time.Microsecond,
+		10: 187500 * time.Microsecond,
+		11: 375 * time.Millisecond,
+		12: 750 * time.Millisecond,
+	} {
+		d.resolution = bits
+		gobottest.Assert(t, d.conversionTime(), want)
+	}
+}
+
+func TestDS18B20SetResolution(t *testing.T) {
+	// arrange
+	d, c := initTestDS18B20DriverWithTransactor()
+	gobottest.Assert(t, d.Start(), nil)
+	c.writes = nil
+	// act & assert
+	gobottest.Assert(t, d.SetResolution(11), nil)
+	gobottest.Assert(t, d.Resolution(), uint8(11))
+	gobottest.Assert(t, c.writes[1], []byte{ds18b20CmdWriteScratchpad, 0x4b, 0x46, 0x5F})
+	gobottest.Assert(t, d.SetResolution(8).Error(), "resolution 8 bit is not supported by DS18B20, use 9..12")
+	gobottest.Assert(t, d.Resolution(), uint8(11))
+}
+
+func TestDS18B20NotStarted(t *testing.T) {
+	// arrange
+	d, _ := initTestDS18B20DriverWithTransactor()
+	// act
+	_, err := d.Temperature()
+	// assert
+	gobottest.Assert(t, err.Error(), "not started or unsupported connection for DS18B20")
+}
+
+func initTestDS18B20DriverWithSystemDevice() (*DS18B20Driver, *onewireTestSystemDevice) {
+	dev := &onewireTestSystemDevice{
+		id: "28-0000075f5ef1",
+		attributes: map[string]string{
+			"w1_slave":   "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n72 01 4b 46 7f ff 0e 10 57 t=23125\n",
+			"ext_power":  "1\n",
+			"resolution": "12\n",
+		},
+	}
+	c, _ := NewSystemConnection(dev)
+	d := NewDS18B20Driver(&onewireTestConnector{connection: c}, ds18b20TestROM)
+	return d, dev
+}
+
+func TestDS18B20WithSystemDevice(t *testing.T) {
+	// arrange
+	d, dev := initTestDS18B20DriverWithSystemDevice()
+	dev.attributes["ext_power"] = "0\n"
+	// act & assert
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.IsParasitic(), true)
+	temp, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, float32(23.125))
+	gobottest.Assert(t, d.SetResolution(9), nil)
+	gobottest.Assert(t, dev.attributes["resolution"], "9")
+}
+
+func TestDS18B20WithSystemDeviceError(t *testing.T) {
+	var tests = map[string]struct {
+		content string
+		wantErr string
+	}{
+		"too_short": {
+			content: "72 01 4b 46 7f ff\n",
+			wantErr: "unexpected content '72 01 4b 46 7f ff' of DS18B20",
+		},
+		"no_hex": {
+			content: "72 01 4b 46 7f ff 0e 10 zz : crc=57 NO\n",
+			wantErr: "unexpected content '72 01 4b 46 7f ff 0e 10 zz : crc=57 NO' of DS18B20",
+		},
+		"wrong_crc": {
+			content: "ff ff ff ff ff ff ff ff ff : crc=c9 NO\n",
+			wantErr: "wrong CRC 0xFF of DS18B20 scratchpad, expected 0xC9",
+		},
+		"no_answer": {
+			content: "00 00 00 00 00 00 00 00 00 : crc=00 YES\n",
+			wantErr: "no answer of DS18B20 28-0000075f5ef1",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, dev := initTestDS18B20DriverWithSystemDevice()
+			gobottest.Assert(t, d.Start(), nil)
+			dev.attributes["w1_slave"] = tc.content
+			// act
+			_, err := d.Temperature()
+			// assert
+			gobottest.Refute(t, err, nil)
+			gobottest.Assert(t, strings.HasPrefix(err.Error(), tc.wantErr), true)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package onewire
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// onewireTestBus simulates a bus master with the given devices, the ROM search is answered by the devices
+type onewireTestBus struct {
+	roms        []ROM
+	active      []ROM
+	bit         int
+	resetErr    error
+	readData    []byte
+	written     [][]byte
+	pullups     []bool
+	resets      int
+	tripletErr  error
+	corruptROMs bool
+	silent      bool // devices answer the reset, but not the search
+}
+
+func (b *onewireTestBus) Reset() error {
+	b.resets++
+	b.bit = 0
+	b.active = append([]ROM(nil), b.roms...)
+	if b.silent {
+		b.active = nil
+	}
+	if b.resetErr != nil {
+		return b.resetErr
+	}
+	if len(b.roms) == 0 {
+		return ErrNoPresence
+	}
+	return nil
+}
+
+func (b *onewireTestBus) WriteBytes(data []byte, strongPullup bool) error {
+	b.written = append(b.written, append([]byte(nil), data...))
+	b.pullups = append(b.pullups, strongPullup)
+	return nil
+}
+
+func (b *onewireTestBus) ReadBytes(data []byte) error {
+	n := copy(data, b.readData)
+	b.readData = b.readData[n:]
+	return nil
+}
+
+func (b *onewireTestBus) Triplet(direction bool) (bool, bool, bool, error) {
+	if b.tripletErr != nil {
+		return false, false, false, b.tripletErr
+	}
+	// wired-AND of all active devices
+	bit, complement := true, true
+	for _, rom := range b.active {
+		if rom&(1<<b.bit) != 0 {
+			complement = false
+		} else {
+			bit = false
+		}
+	}
+	taken := direction
+	if bit != complement {
+		taken = bit
+	}
+	var active []ROM
+	for _, rom := range b.active {
+		if (rom&(1<<b.bit) != 0) == taken {
+			active = append(active, rom)
+		}
+	}
+	if b.corruptROMs && b.bit == 60 {
+		taken = !taken
+	}
+	b.active = active
+	b.bit++
+	return bit, complement, taken, nil
+}
+
+// onewireTestTransactor records the transactions and returns the prepared answers
+type onewireTestTransactor struct {
+	rom          ROM
+	mtx          sync.Mutex
+	writes       [][]byte
+	powered      []time.Duration
+	answers      map[byte][]byte
+	transactErr  error
+	closeCounter int
+}
+
+func newOnewireTestTransactor(rom ROM) *onewireTestTransactor {
+	return &onewireTestTransactor{rom: rom, answers: map[byte][]byte{}}
+}
+
+func (c *onewireTestTransactor) ROM() ROM { return c.rom }
+
+func (c *onewireTestTransactor) Close() error {
+	c.closeCounter++
+	return nil
+}
+
+func (c *onewireTestTransactor) Transaction(write []byte, read []byte, powered time.Duration) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.transactErr != nil {
+		return c.transactErr
+	}
+	c.writes = append(c.writes, append([]byte(nil), write...))
+	c.powered = append(c.powered, powered)
+	copy(read, c.answers[write[0]])
+	return nil
+}
+
+// onewireTestSystemDevice simulates a device of the Linux w1 subsystem by its attributes
+type onewireTestSystemDevice struct {
+	id         string
+	attributes map[string]string
+	closed     bool
+}
+
+func (d *onewireTestSystemDevice) ID() string { return d.id }
+
+func (d *onewireTestSystemDevice) ReadData(attribute string) ([]byte, error) {
+	content, ok := d.attributes[attribute]
+	if !ok {
+		return nil, errors.New("no such attribute")
+	}
+	return []byte(content), nil
+}
+
+func (d *onewireTestSystemDevice) WriteData(attribute string, data []byte) error {
+	if _, ok := d.attributes[attribute]; !ok {
+		return errors.New("no such attribute")
+	}
+	d.attributes[attribute] = string(data)
+	return nil
+}
+
+func (d *onewireTestSystemDevice) Close() error {
+	d.closed = true
+	return nil
+}
+
+// onewireTestConnector provides the given connection
+type onewireTestConnector struct {
+	name       string
+	connection Connection
+	connectErr error
+}
+
+func (a *onewireTestConnector) GetOneWireConnection(rom ROM) (Connection, error) {
+	return a.connection, a.connectErr
+}
+
+func (a *onewireTestConnector) Name() string     { return a.name }
+func (a *onewireTestConnector) SetName(n string) { a.name = n }
+func (a *onewireTestConnector) Connect() error   { return nil }
+func (a *onewireTestConnector) Finalize() error  { return nil }
@@ -0,0 +1,164 @@
+package onewire
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ROM commands, used to address the devices on the bus
+const (
+	CmdSearchROM   = 0xF0
+	CmdReadROM     = 0x33
+	CmdMatchROM    = 0x55
+	CmdSkipROM     = 0xCC
+	CmdAlarmSearch = 0xEC
+)
+
+// ErrNoPresence is returned, if no device answers the reset pulse with a presence pulse
+var ErrNoPresence = errors.New("no 1-wire device present")
+
+// ROM is the unique 64 bit identifier of a 1-Wire device. The lowest byte contains the family code, followed by the
+// 48 bit serial number and the CRC. This is also the order of transmission on the bus (LSB first).
+type ROM uint64
+
+// NewROM creates the ROM code for the given family code and serial number, the CRC is calculated.
+func NewROM(family byte, serial uint64) ROM {
+	rom := uint64(family) | (serial&0xFFFFFFFFFFFF)<<8
+	b := ROM(rom).Bytes()
+	return ROM(rom | uint64(CRC8(b[:7]))<<56)
+}
+
+// ParseROM parses the device identifier of the Linux w1 subsystem, e.g. "28-0000075f5ef1", or the hexadecimal
+// representation of the ROM code in transmission order, e.g. "28F15E5F07000057".
+func ParseROM(id string) (ROM, error) {
+	if parts := strings.Split(id, "-"); len(parts) == 2 {
+		family, err := strconv.ParseUint(parts[0], 16, 8)
+		if err != nil {
+			return 0, fmt.Errorf("invalid family code in 1-wire id '%s': %v", id, err)
+		}
+		serial, err := strconv.ParseUint(parts[1], 16, 48)
+		if err != nil {
+			return 0, fmt.Errorf("invalid serial number in 1-wire id '%s': %v", id, err)
+		}
+		return NewROM(byte(family), serial), nil
+	}
+
+	if len(id) != 16 {
+		return 0, fmt.Errorf("invalid 1-wire id '%s'", id)
+	}
+	var rom uint64
+	for i := 0; i < 8; i++ {
+		b, err := strconv.ParseUint(id[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return 0, fmt.Errorf("invalid 1-wire id '%s': %v", id, err)
+		}
+		rom |= b << (8 * i)
+	}
+	if !ROM(rom).Valid() {
+		return 0, fmt.Errorf("wrong CRC of 1-wire id '%s'", id)
+	}
+	return ROM(rom), nil
+}
+
+// Family returns the family code, which identifies the type of the device, e.g. 0x28 for the DS18B20.
+func (r ROM) Family() byte {
+	return byte(r)
+}
+
+// Serial returns the 48 bit serial number.
+func (r ROM) Serial() uint64 {
+	return uint64(r>>8) & 0xFFFFFFFFFFFF
+}
+
+// CRC returns the CRC byte of the ROM code.
+func (r ROM) CRC() byte {
+	return byte(r >> 56)
+}
+
+// Valid returns true, if the CRC matches to the family code and serial number.
+func (r ROM) Valid() bool {
+	b := r.Bytes()
+	return CRC8(b[:7]) == r.CRC()
+}
+
+// Bytes returns the ROM code in transmission order.
+func (r ROM) Bytes() [8]byte {
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(r >> (8 * i))
+	}
+	return b
+}
+
+// String returns the ROM code in the form of the Linux w1 subsystem, e.g. "28-0000075f5ef1".
+func (r ROM) String() string {
+	return fmt.Sprintf("%02x-%012x", r.Family(), r.Serial())
+}
+
+// CRC8 calculates the Dallas/Maxim CRC (polynomial x^8 + x^5 + x^4 + 1, LSB first), used for the ROM code and the
+// data of many devices. The CRC over the data including the CRC byte is 0.
+func CRC8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		for i := 0; i < 8; i++ {
+			mix := (crc ^ b) & 0x01
+			crc >>= 1
+			if mix != 0 {
+				crc ^= 0x8C
+			}
+			b >>= 1
+		}
+	}
+	return crc
+}
+
+// Master is the interface to a 1-Wire bus master with bit and byte level access, e.g. the DS2482 I2C bridge.
+type Master interface {
+	// Reset sends a reset pulse and returns ErrNoPresence, if no device answers with a presence pulse.
+	Reset() error
+	// WriteBytes writes the given bytes to the bus. With strong pullup the bus is actively driven high after the last
+	// byte until the next command, e.g. to power parasitic devices during a temperature conversion.
+	WriteBytes(data []byte, strongPullup bool) error
+	// ReadBytes fills the given buffer with bytes read from the bus.
+	ReadBytes(data []byte) error
+	// Triplet does one step of the ROM search. The bit and the complement bit are read and the direction is written.
+	// The given direction is used only on a discrepancy (both read bits are 0), otherwise the direction of the read
+	// bit is taken.
+	Triplet(direction bool) (bit bool, complement bool, taken bool, err error)
+}
+
+// Connector lets adaptors (platforms) or bus masters provide the interface for Drivers to get access to the devices
+// on a 1-Wire bus.
+type Connector interface {
+	// GetOneWireConnection returns a connection to the device with the given ROM code.
+	GetOneWireConnection(rom ROM) (Connection, error)
+}
+
+// Connection is the connection to a single device on a 1-Wire bus. Additionally, each connection implements the
+// Transactor or the AttributeAccessor interface, depending on the bus master.
+type Connection interface {
+	// ROM returns the ROM code of the connected device.
+	ROM() ROM
+	// Close the connection.
+	Close() error
+}
+
+// Transactor is implemented by connections with direct access to the bus, e.g. by a DS2482 bridge.
+type Transactor interface {
+	// Transaction resets the bus, selects the device and writes the given function command and data. If powered is
+	// not 0, the bus is driven by a strong pullup for the given time afterwards. At least the answer of the device is
+	// read into the given buffer.
+	Transaction(write []byte, read []byte, powered time.Duration) error
+}
+
+// AttributeAccessor is implemented by connections to devices, which are handled by a kernel driver of its family,
+// e.g. the Linux w1 subsystem. The device is accessed by the attributes of the kernel driver.
+type AttributeAccessor interface {
+	// ReadAttribute reads the content of the given attribute.
+	ReadAttribute(name string) ([]byte, error)
+	// WriteAttribute writes the given data to the given attribute.
+	WriteAttribute(name string, data []byte) error
+}
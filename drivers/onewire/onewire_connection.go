@@ -0,0 +1,96 @@
+package onewire
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// masterConnection is the connection to a device by a bus master with bit and byte level access.
+type masterConnection struct {
+	master Master
+	rom    ROM
+	mutex  *sync.Mutex
+}
+
+// NewConnection returns a connection to the device with the given ROM code by the given bus master. The mutex is
+// shared by all connections of the bus master, so the transactions of different devices are not interrupted.
+// Implements the Connection and the Transactor interface.
+func NewConnection(m Master, rom ROM, mutex *sync.Mutex) *masterConnection {
+	return &masterConnection{master: m, rom: rom, mutex: mutex}
+}
+
+// ROM returns the ROM code of the connected device.
+func (c *masterConnection) ROM() ROM {
+	return c.rom
+}
+
+// Close implements the Connection interface, there is nothing to do.
+func (c *masterConnection) Close() error {
+	return nil
+}
+
+// Transaction resets the bus, selects the device by "Match ROM" and writes the given function command and data.
+// If powered is not 0, the bus is driven by a strong pullup for the given time afterwards. At least the answer of the
+// device is read into the given buffer.
+func (c *masterConnection) Transaction(write []byte, read []byte, powered time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.master.Reset(); err != nil {
+		return err
+	}
+	rom := c.rom.Bytes()
+	if err := c.master.WriteBytes(append([]byte{CmdMatchROM}, rom[:]...), false); err != nil {
+		return err
+	}
+	if len(write) > 0 {
+		if err := c.master.WriteBytes(write, powered > 0); err != nil {
+			return err
+		}
+	}
+	if powered > 0 {
+		time.Sleep(powered)
+	}
+	if len(read) > 0 {
+		return c.master.ReadBytes(read)
+	}
+	return nil
+}
+
+// systemConnection is the connection to a device by the 1-wire system device of the Kernel.
+type systemConnection struct {
+	device gobot.OneWireSystemDevicer
+	rom    ROM
+}
+
+// NewSystemConnection returns a connection to the device by the given system device, e.g. of the Linux w1
+// subsystem. Implements the Connection and the AttributeAccessor interface.
+func NewSystemConnection(device gobot.OneWireSystemDevicer) (*systemConnection, error) {
+	rom, err := ParseROM(device.ID())
+	if err != nil {
+		return nil, err
+	}
+	return &systemConnection{device: device, rom: rom}, nil
+}
+
+// ROM returns the ROM code of the connected device.
+func (c *systemConnection) ROM() ROM {
+	return c.rom
+}
+
+// Close closes the system device.
+func (c *systemConnection) Close() error {
+	return c.device.Close()
+}
+
+// ReadAttribute reads the content of the given attribute.
+func (c *systemConnection) ReadAttribute(name string) ([]byte, error) {
+	return c.device.ReadData(name)
+}
+
+// WriteAttribute writes the given data to the given attribute.
+func (c *systemConnection) WriteAttribute(name string, data []byte) error {
+	return c.device.WriteData(name, data)
+}
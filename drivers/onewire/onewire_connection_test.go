@@ -0,0 +1,101 @@
+package onewire
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementations are based on the Connection, Transactor and AttributeAccessor interfaces
+var _ Connection = (*masterConnection)(nil)
+var _ Transactor = (*masterConnection)(nil)
+var _ Connection = (*systemConnection)(nil)
+var _ AttributeAccessor = (*systemConnection)(nil)
+
+func TestMasterConnectionTransaction(t *testing.T) {
+	var tests = map[string]struct {
+		write       []byte
+		read        []byte
+		powered     time.Duration
+		wantWritten [][]byte
+		wantPullups []bool
+	}{
+		"write_and_read": {
+			write: []byte{0xBE},
+			read:  make([]byte, 2),
+			wantWritten: [][]byte{
+				{CmdMatchROM, 0x02, 0x1C, 0xB8, 0x01, 0x00, 0x00, 0x00, 0xA2},
+				{0xBE},
+			},
+			wantPullups: []bool{false, false},
+		},
+		"powered": {
+			write:   []byte{0x44},
+			powered: time.Millisecond,
+			wantWritten: [][]byte{
+				{CmdMatchROM, 0x02, 0x1C, 0xB8, 0x01, 0x00, 0x00, 0x00, 0xA2},
+				{0x44},
+			},
+			wantPullups: []bool{false, true},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			rom := ROM(0xA200000001B81C02)
+			bus := &onewireTestBus{roms: []ROM{rom}, readData: []byte{0x12, 0x34}}
+			c := NewConnection(bus, rom, &sync.Mutex{})
+			// act
+			err := c.Transaction(tc.write, tc.read, tc.powered)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, c.ROM(), rom)
+			gobottest.Assert(t, bus.resets, 1)
+			gobottest.Assert(t, bus.written, tc.wantWritten)
+			gobottest.Assert(t, bus.pullups, tc.wantPullups)
+			if len(tc.read) > 0 {
+				gobottest.Assert(t, tc.read, []byte{0x12, 0x34})
+			}
+			gobottest.Assert(t, c.Close(), nil)
+		})
+	}
+}
+
+func TestMasterConnectionTransactionNoPresence(t *testing.T) {
+	// arrange
+	bus := &onewireTestBus{}
+	c := NewConnection(bus, NewROM(0x28, 1), &sync.Mutex{})
+	// act
+	err := c.Transaction([]byte{0xBE}, make([]byte, 9), 0)
+	// assert
+	gobottest.Assert(t, err, ErrNoPresence)
+	gobottest.Assert(t, len(bus.written), 0)
+}
+
+func TestSystemConnection(t *testing.T) {
+	// arrange
+	dev := &onewireTestSystemDevice{id: "28-0000075f5ef1", attributes: map[string]string{"resolution": "12"}}
+	// act
+	c, err := NewSystemConnection(dev)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, c.ROM(), NewROM(0x28, 0x075f5ef1))
+	gobottest.Assert(t, c.WriteAttribute("resolution", []byte("10")), nil)
+	content, err := c.ReadAttribute("resolution")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, string(content), "10")
+	gobottest.Assert(t, c.Close(), nil)
+	gobottest.Assert(t, dev.closed, true)
+}
+
+func TestSystemConnectionInvalidID(t *testing.T) {
+	// arrange
+	dev := &onewireTestSystemDevice{id: "w1_bus_master1"}
+	// act
+	c, err := NewSystemConnection(dev)
+	// assert
+	gobottest.Assert(t, err.Error(), "invalid 1-wire id 'w1_bus_master1'")
+	gobottest.Assert(t, c, (*systemConnection)(nil))
+}
@@ -0,0 +1,90 @@
+package onewire
+
+import (
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+// Config is the interface which describes how a Driver can specify optional 1-Wire params. Options of the drivers
+// are functions of this interface, the driver specific options are applied by a type assertion.
+type Config interface {
+	// ROM returns the ROM code of the device
+	ROM() ROM
+}
+
+// Driver implements the interface gobot.Driver.
+type Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	rom        ROM
+	afterStart func() error
+	beforeHalt func() error
+	gobot.Commander
+	mutex *sync.Mutex // mutex often needed to ensure that write-read sequences are not interrupted
+}
+
+// NewDriver creates a new generic and basic 1-Wire gobot driver for the device with the given ROM code.
+func NewDriver(c Connector, name string, rom ROM) *Driver {
+	d := &Driver{
+		name:       gobot.DefaultName(name),
+		connector:  c,
+		rom:        rom,
+		afterStart: func() error { return nil },
+		beforeHalt: func() error { return nil },
+		Commander:  gobot.NewCommander(),
+		mutex:      &sync.Mutex{},
+	}
+	return d
+}
+
+// Name returns the name of the 1-Wire device.
+func (d *Driver) Name() string {
+	return d.name
+}
+
+// SetName sets the name of the 1-Wire device.
+func (d *Driver) SetName(name string) {
+	d.name = name
+}
+
+// Connection returns the connection of the 1-Wire device, nil if the connector is no gobot.Connection, e.g. a bus
+// master driver.
+func (d *Driver) Connection() gobot.Connection {
+	if c, ok := d.connector.(gobot.Connection); ok {
+		return c
+	}
+	return nil
+}
+
+// ROM returns the ROM code of the 1-Wire device.
+func (d *Driver) ROM() ROM {
+	return d.rom
+}
+
+// Start initializes the 1-Wire device.
+func (d *Driver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var err error
+	if d.connection, err = d.connector.GetOneWireConnection(d.rom); err != nil {
+		return err
+	}
+
+	return d.afterStart()
+}
+
+// Halt halts the 1-Wire device.
+func (d *Driver) Halt() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.beforeHalt(); err != nil {
+		return err
+	}
+
+	// currently there is nothing to do here for the driver, the connection is closed by the connector
+	return nil
+}
@@ -0,0 +1,79 @@
+package onewire
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestCRC8(t *testing.T) {
+	// example of Maxim application note 27
+	gobottest.Assert(t, CRC8([]byte{0x02, 0x1C, 0xB8, 0x01, 0x00, 0x00, 0x00}), byte(0xA2))
+	gobottest.Assert(t, CRC8([]byte{0x02, 0x1C, 0xB8, 0x01, 0x00, 0x00, 0x00, 0xA2}), byte(0x00))
+	// scratchpad of a DS18B20, read by the Linux w1 subsystem
+	gobottest.Assert(t, CRC8([]byte{0x72, 0x01, 0x4b, 0x46, 0x7f, 0xff, 0x0e, 0x10}), byte(0x57))
+}
+
+func TestNewROM(t *testing.T) {
+	// act
+	rom := NewROM(0x02, 0x000001B81C)
+	// assert
+	gobottest.Assert(t, rom, ROM(0xA200000001B81C02))
+	gobottest.Assert(t, rom.Family(), byte(0x02))
+	gobottest.Assert(t, rom.Serial(), uint64(0x000001B81C))
+	gobottest.Assert(t, rom.CRC(), byte(0xA2))
+	gobottest.Assert(t, rom.Valid(), true)
+	gobottest.Assert(t, rom.Bytes(), [8]byte{0x02, 0x1C, 0xB8, 0x01, 0x00, 0x00, 0x00, 0xA2})
+	gobottest.Assert(t, rom.String(), "02-00000001b81c")
+}
+
+func TestParseROM(t *testing.T) {
+	var tests = map[string]struct {
+		id      string
+		want    ROM
+		wantErr string
+	}{
+		"linux_id": {
+			id:   "02-00000001b81c",
+			want: ROM(0xA200000001B81C02),
+		},
+		"hex": {
+			id:   "021CB801000000A2",
+			want: ROM(0xA200000001B81C02),
+		},
+		"invalid_family": {
+			id:      "xx-000001b81c",
+			wantErr: "invalid family code in 1-wire id 'xx-000001b81c'",
+		},
+		"invalid_serial": {
+			id:      "28-10000001b81c0",
+			wantErr: "invalid serial number in 1-wire id '28-10000001b81c0'",
+		},
+		"invalid_length": {
+			id:      "021CB801",
+			wantErr: "invalid 1-wire id '021CB801'",
+		},
+		"invalid_hex": {
+			id:      "021CB80100000XA2",
+			wantErr: "invalid 1-wire id '021CB80100000XA2'",
+		},
+		"wrong_crc": {
+			id:      "021CB801000000A3",
+			wantErr: "wrong CRC of 1-wire id '021CB801000000A3'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			rom, err := ParseROM(tc.id)
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Refute(t, err, nil)
+				gobottest.Assert(t, err.Error()[:len(tc.wantErr)], tc.wantErr)
+				return
+			}
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, rom, tc.want)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package onewire
+
+import "fmt"
+
+// Search finds the ROM codes of all devices on the bus by the ROM search algorithm (Maxim application note 187).
+// With alarmOnly, only the devices with an alarm condition answer the search. An empty list is returned, if no
+// device is present.
+func Search(m Master, alarmOnly bool) ([]ROM, error) {
+	cmd := byte(CmdSearchROM)
+	if alarmOnly {
+		cmd = CmdAlarmSearch
+	}
+
+	var roms []ROM
+	var last uint64
+	lastDiscrepancy := -1
+	for {
+		if err := m.Reset(); err != nil {
+			if err == ErrNoPresence && len(roms) == 0 {
+				return roms, nil
+			}
+			return nil, err
+		}
+		if err := m.WriteBytes([]byte{cmd}, false); err != nil {
+			return nil, err
+		}
+
+		var rom uint64
+		lastZero := -1
+		for i := 0; i < 64; i++ {
+			var direction bool
+			if i < lastDiscrepancy {
+				direction = last&(1<<i) != 0
+			} else {
+				direction = i == lastDiscrepancy
+			}
+			bit, complement, taken, err := m.Triplet(direction)
+			if err != nil {
+				return nil, err
+			}
+			if bit && complement {
+				if alarmOnly && i == 0 && len(roms) == 0 {
+					// no device with alarm condition
+					return roms, nil
+				}
+				return nil, fmt.Errorf("1-wire ROM search aborted at bit %d, no device answered", i)
+			}
+			if !bit && !complement && !taken {
+				lastZero = i
+			}
+			if taken {
+				rom |= 1 << i
+			}
+		}
+
+		if !ROM(rom).Valid() {
+			return nil, fmt.Errorf("wrong CRC of 1-wire ROM code 0x%016X found by search", rom)
+		}
+		roms = append(roms, ROM(rom))
+		last = rom
+		lastDiscrepancy = lastZero
+		if lastDiscrepancy < 0 {
+			return roms, nil
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package onewire
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestSearch(t *testing.T) {
+	var tests = map[string]struct {
+		roms []ROM
+	}{
+		"no_device": {},
+		"one_device": {
+			roms: []ROM{NewROM(0x28, 0x075f5ef1)},
+		},
+		"many_devices": {
+			roms: []ROM{
+				NewROM(0x28, 0x075f5ef1),
+				NewROM(0x28, 0x075f5ef0),
+				NewROM(0x10, 0x075f5ef1),
+				NewROM(0x28, 0x0a1b2c3d),
+				NewROM(0x22, 0x800000000001),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			bus := &onewireTestBus{roms: tc.roms}
+			// act
+			roms, err := Search(bus, false)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, len(roms), len(tc.roms))
+			found := map[ROM]bool{}
+			for _, rom := range roms {
+				found[rom] = true
+			}
+			for _, rom := range tc.roms {
+				gobottest.Assert(t, found[rom], true)
+			}
+			if len(tc.roms) > 0 {
+				gobottest.Assert(t, bus.written[0], []byte{CmdSearchROM})
+			}
+		})
+	}
+}
+
+func TestSearchAlarm(t *testing.T) {
+	// arrange
+	bus := &onewireTestBus{roms: []ROM{NewROM(0x28, 0x075f5ef1)}}
+	// act
+	roms, err := Search(bus, true)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, roms, []ROM{NewROM(0x28, 0x075f5ef1)})
+	gobottest.Assert(t, bus.written[0], []byte{CmdAlarmSearch})
+}
+
+func TestSearchError(t *testing.T) {
+	var tests = map[string]struct {
+		bus     *onewireTestBus
+		wantErr string
+	}{
+		"reset_error": {
+			bus:     &onewireTestBus{roms: []ROM{NewROM(0x28, 1)}, resetErr: errors.New("reset error")},
+			wantErr: "reset error",
+		},
+		"triplet_error": {
+			bus:     &onewireTestBus{roms: []ROM{NewROM(0x28, 1)}, tripletErr: errors.New("triplet error")},
+			wantErr: "triplet error",
+		},
+		"wrong_crc": {
+			bus:     &onewireTestBus{roms: []ROM{NewROM(0x28, 1)}, corruptROMs: true},
+			wantErr: "wrong CRC of 1-wire ROM code",
+		},
+		"device_removed": {
+			bus:     &onewireTestBus{roms: []ROM{NewROM(0x28, 1)}, silent: true},
+			wantErr: "1-wire ROM search aborted at bit 0, no device answered",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			roms, err := Search(tc.bus, false)
+			// assert
+			gobottest.Refute(t, err, nil)
+			gobottest.Assert(t, err.Error()[:len(tc.wantErr)], tc.wantErr)
+			gobottest.Assert(t, len(roms), 0)
+		})
+	}
+}
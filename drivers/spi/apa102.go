@@ -38,6 +38,11 @@ func NewAPA102Driver(a Connector, count int, bright uint8, options ...func(Confi
 	return d
 }
 
+// Len returns the number of LEDs in the array.
+func (d *APA102Driver) Len() int {
+	return len(d.vals)
+}
+
 // SetRGBA sets the ith LED's color to the given RGBA value.
 // A subsequent call to Draw is required to transmit values
 // to the LED strip.
@@ -42,3 +42,8 @@ func TestDriverLEDs(t *testing.T) {
 
 	gobottest.Assert(t, d.Draw(), nil)
 }
+
+func TestAPA102DriverLen(t *testing.T) {
+	d := NewAPA102Driver(newSpiTestAdaptor(), 7, 31)
+	gobottest.Assert(t, d.Len(), 7)
+}
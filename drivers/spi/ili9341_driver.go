@@ -0,0 +1,106 @@
+package spi
+
+import (
+	"time"
+
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	ili9341PowerControlB      = 0xCF
+	ili9341PowerOnSequence    = 0xED
+	ili9341DriverTimingA      = 0xE8
+	ili9341PowerControlA      = 0xCB
+	ili9341PumpRatioControl   = 0xF7
+	ili9341DriverTimingB      = 0xEA
+	ili9341PowerControl1      = 0xC0
+	ili9341PowerControl2      = 0xC1
+	ili9341VCOMControl1       = 0xC5
+	ili9341VCOMControl2       = 0xC7
+	ili9341FrameRateControl   = 0xB1
+	ili9341FunctionControl    = 0xB6
+	ili9341Enable3Gamma       = 0xF2
+	ili9341GammaSet           = 0x26
+	ili9341GammaCorrectionPos = 0xE0
+	ili9341GammaCorrectionNeg = 0xE1
+)
+
+var ili9341Model = tftModel{
+	name:        "ILI9341",
+	width:       240,
+	height:      320,
+	memoryLines: 320,
+	columnCmd:   mipiColumnAddressSet,
+	rowCmd:      mipiRowAddressSet,
+	writeCmd:    mipiMemoryWrite,
+	bgr:         true,
+	initCommands: func(d *tftDriver) []tftCommand {
+		// according to the initialization of the Adafruit library
+		return []tftCommand{
+			{cmd: mipiSoftwareReset, delay: 150 * time.Millisecond},
+			{cmd: ili9341PowerControlB, data: []byte{0x00, 0xC1, 0x30}},
+			{cmd: ili9341PowerOnSequence, data: []byte{0x64, 0x03, 0x12, 0x81}},
+			{cmd: ili9341DriverTimingA, data: []byte{0x85, 0x00, 0x78}},
+			{cmd: ili9341PowerControlA, data: []byte{0x39, 0x2C, 0x00, 0x34, 0x02}},
+			{cmd: ili9341PumpRatioControl, data: []byte{0x20}},
+			{cmd: ili9341DriverTimingB, data: []byte{0x00, 0x00}},
+			{cmd: ili9341PowerControl1, data: []byte{0x23}},
+			{cmd: ili9341PowerControl2, data: []byte{0x10}},
+			{cmd: ili9341VCOMControl1, data: []byte{0x3E, 0x28}},
+			{cmd: ili9341VCOMControl2, data: []byte{0x86}},
+			{cmd: mipiPixelFormatSet, data: []byte{mipiPixelFormat16Bit}},
+			{cmd: ili9341FrameRateControl, data: []byte{0x00, 0x18}},
+			{cmd: ili9341FunctionControl, data: []byte{0x08, 0x82, 0x27}},
+			{cmd: ili9341Enable3Gamma, data: []byte{0x00}},
+			{cmd: ili9341GammaSet, data: []byte{0x01}},
+			{cmd: ili9341GammaCorrectionPos, data: []byte{0x0F, 0x31, 0x2B, 0x0C, 0x0E, 0x08, 0x4E, 0xF1, 0x37, 0x07,
+				0x10, 0x03, 0x0E, 0x09, 0x00}},
+			{cmd: ili9341GammaCorrectionNeg, data: []byte{0x00, 0x0E, 0x14, 0x03, 0x11, 0x07, 0x31, 0xC1, 0x48, 0x08,
+				0x0F, 0x0C, 0x31, 0x36, 0x0F}},
+			{cmd: mipiSleepOut, delay: 150 * time.Millisecond},
+		}
+	},
+	rotation: func(d *tftDriver) []tftCommand {
+		return mipiRotation(d, [4]byte{
+			mipiMADCTLColumnOrder,
+			mipiMADCTLExchange,
+			mipiMADCTLRowOrder,
+			mipiMADCTLColumnOrder | mipiMADCTLRowOrder | mipiMADCTLExchange,
+		})
+	},
+	display:    mipiDisplay,
+	inversion:  mipiInversion,
+	scrollArea: mipiScrollArea,
+	scroll:     mipiScroll,
+}
+
+// ILI9341Driver is a driver for colour TFT displays with the ILI9341 controller and a size of 240x320.
+//
+// The driver implements the draw.Image interface, the changed content is transferred by Display().
+//
+// Datasheet: https://cdn-shop.adafruit.com/datasheets/ILI9341.pdf
+type ILI9341Driver struct {
+	*tftDriver
+}
+
+// NewILI9341Driver creates a new driver for a ILI9341 colour display.
+//
+// Params:
+//      a Connector - the Adaptor to use with this Driver
+//      dcWriter gpio.DigitalWriter - the Adaptor to use for the data/command and reset pin
+//      dcPin string - the pin connected to the data/command input
+//
+// Optional params:
+//      spi.WithBusNumber(int):  bus to use with this driver
+//      spi.WithChipNumber(int): chip to use with this driver
+//      spi.WithMode(int):       mode to use with this driver
+//      spi.WithBitCount(int):   number of bits to use with this driver
+//      spi.WithSpeed(int64):    speed in Hz to use with this driver
+//      spi.WithTFTResetPin(string):    pin connected to the reset input
+//      spi.WithTFTRotation(TFTRotation): rotation to apply on start
+//      spi.WithTFTInversion(bool):     invert the colours
+//      spi.WithTFTBGR(bool):           swap red and blue, true by default
+//
+func NewILI9341Driver(a Connector, dcWriter gpio.DigitalWriter, dcPin string, options ...func(Config)) *ILI9341Driver {
+	return &ILI9341Driver{tftDriver: newTFTDriver(a, &ili9341Model, dcWriter, dcPin, options...)}
+}
@@ -0,0 +1,35 @@
+package spi
+
+import (
+	"image"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestILI9341DriverRotation(t *testing.T) {
+	var tests = map[string]struct {
+		rotation   TFTRotation
+		wantMADCTL byte
+		wantBounds image.Rectangle
+	}{
+		"rotation_0":   {rotation: TFTRotate0, wantMADCTL: 0x48, wantBounds: image.Rect(0, 0, 240, 320)},
+		"rotation_90":  {rotation: TFTRotate90, wantMADCTL: 0x28, wantBounds: image.Rect(0, 0, 320, 240)},
+		"rotation_180": {rotation: TFTRotate180, wantMADCTL: 0x88, wantBounds: image.Rect(0, 0, 240, 320)},
+		"rotation_270": {rotation: TFTRotate270, wantMADCTL: 0xE8, wantBounds: image.Rect(0, 0, 320, 240)},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, w := initTestTFTDriverWithStubbedAdaptor(func(a Connector, w *tftTestDCWriter) *tftDriver {
+				return NewILI9341Driver(a, w, "dc").tftDriver
+			})
+			// act
+			err := d.SetRotation(tc.rotation)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, w.commands(), []tftCommand{{cmd: mipiMemoryAccessControl, data: []byte{tc.wantMADCTL}}})
+			gobottest.Assert(t, d.Bounds(), tc.wantBounds)
+		})
+	}
+}
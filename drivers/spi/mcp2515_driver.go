@@ -0,0 +1,598 @@
+package spi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/can"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// MCP2515Mode is the operation mode of the MCP2515
+type MCP2515Mode uint8
+
+const (
+	// MCP2515ModeNormal is the mode for normal operation on the bus
+	MCP2515ModeNormal MCP2515Mode = 0x00
+	// MCP2515ModeSleep stops the oscillator, the controller wakes up on bus activity
+	MCP2515ModeSleep MCP2515Mode = 0x20
+	// MCP2515ModeLoopback delivers the sent frames to the receive buffers without access to the bus
+	MCP2515ModeLoopback MCP2515Mode = 0x40
+	// MCP2515ModeListenOnly receives all frames without acknowledge and without error frames
+	MCP2515ModeListenOnly MCP2515Mode = 0x60
+	// MCP2515ModeConfiguration is needed for changes of the bit timing and the acceptance filters
+	MCP2515ModeConfiguration MCP2515Mode = 0x80
+
+	// MCP2515Error is the event name, which is published when the polling of the controller fails
+	MCP2515Error = "error"
+
+	mcp2515DefaultOscillator   = 8000000
+	mcp2515DefaultBitrate      = 500000
+	mcp2515DefaultPollInterval = time.Millisecond
+	mcp2515ResetTime           = 5 * time.Millisecond
+	mcp2515ModeChangeTries     = 10
+
+	// instructions
+	mcp2515Reset         = 0xC0
+	mcp2515Read          = 0x03
+	mcp2515Write         = 0x02
+	mcp2515BitModify     = 0x05
+	mcp2515ReadRxBuffer  = 0x90 // | buffer << 2, starts with RXBnSIDH
+	mcp2515LoadTxBuffer  = 0x40 // | buffer << 1, starts with TXBnSIDH
+	mcp2515RequestToSend = 0x80 // | 1 << buffer
+	mcp2515ReadStatus    = 0xA0
+
+	// registers
+	mcp2515RegCANSTAT  = 0x0E
+	mcp2515RegCANCTRL  = 0x0F
+	mcp2515RegTEC      = 0x1C
+	mcp2515RegRXM0SIDH = 0x20
+	mcp2515RegRXM1SIDH = 0x24
+	mcp2515RegCNF3     = 0x28
+	mcp2515RegCANINTE  = 0x2B
+	mcp2515RegCANINTF  = 0x2C
+	mcp2515RegEFLG     = 0x2D
+	mcp2515RegRXB0CTRL = 0x60
+	mcp2515RegRXB1CTRL = 0x70
+
+	// bits of CANINTE and CANINTF
+	mcp2515RX0IF = 0x01
+	mcp2515RX1IF = 0x02
+	mcp2515ERRIF = 0x20
+	mcp2515MERRF = 0x80
+
+	// bits of EFLG
+	mcp2515EWARN  = 0x01
+	mcp2515RXEP   = 0x08
+	mcp2515TXEP   = 0x10
+	mcp2515TXBO   = 0x20
+	mcp2515RX0OVR = 0x40
+	mcp2515RX1OVR = 0x80
+
+	// bits of RXBnCTRL
+	mcp2515RXMAny = 0x60 // receive any message, masks and filters are off
+	mcp2515BUKT   = 0x04 // rollover from RXB0 to RXB1 if RXB0 is full
+
+	// bits of the identifier registers
+	mcp2515EXIDE = 0x08
+	mcp2515SRR   = 0x10
+	mcp2515RTR   = 0x40
+
+	mcp2515OpModeMask = 0xE0
+	mcp2515TxBuffers  = 3
+)
+
+// addresses of the acceptance filters RXF0..RXF5, RXF0 and RXF1 belong to RXB0, the others to RXB1
+var mcp2515FilterRegisters = [][]byte{{0x00, 0x04}, {0x08, 0x10, 0x14, 0x18}}
+
+// MCP2515Acceptance is the configuration of the acceptance mask and filters of a receive buffer. A frame is
+// accepted, if its identifier equals one of the identifiers in all bits of the mask. Standard and extended
+// frames can not be accepted by the same configuration.
+type MCP2515Acceptance struct {
+	Mask     uint32
+	Extended bool
+	IDs      []uint32
+}
+
+// MCP2515Driver is a driver for the MCP2515 stand-alone CAN controller. Received frames are read by polling the
+// controller, or the interrupt pin if configured, and are delivered by the receivers of the can.CanBus interface.
+// Errors while polling are published by the event "error".
+//
+// Datasheet: https://ww1.microchip.com/downloads/en/DeviceDoc/MCP2515-Stand-Alone-CAN-Controller-with-SPI-20001801J.pdf
+type MCP2515Driver struct {
+	*Driver
+	oscillator   int
+	bitrate      int
+	mode         MCP2515Mode
+	intReader    gpio.DigitalReader
+	intPin       string
+	pollInterval time.Duration
+	acceptance   [2]*MCP2515Acceptance
+	dispatcher   *can.Dispatcher
+	overflows    uint64
+	halt         chan bool
+	done         chan bool
+	busMutex     *sync.Mutex // for sequences of SPI transactions
+	gobot.Eventer
+}
+
+// NewMCP2515Driver creates a new driver for the MCP2515 CAN controller. By default an oscillator of 8MHz is
+// assumed, the bitrate is 500kbit/s and all frames are received.
+//
+// Params:
+//      a Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBusNumber(int):  bus to use with this driver
+//      spi.WithChipNumber(int): chip to use with this driver
+//      spi.WithMode(int):       mode to use with this driver
+//      spi.WithBitCount(int):   number of bits to use with this driver
+//      spi.WithSpeed(int64):    speed in Hz to use with this driver
+//      spi.WithMCP2515Oscillator(int):    frequency of the oscillator in Hz
+//      spi.WithMCP2515Bitrate(int):       bitrate of the bus in bit/s
+//      spi.WithMCP2515Mode(MCP2515Mode):  operation mode after start
+//      spi.WithMCP2515InterruptPin(gpio.DigitalReader, string): pin connected to the interrupt output
+//      spi.WithMCP2515PollInterval(time.Duration): interval for polling the interrupt pin or the controller
+//
+func NewMCP2515Driver(a Connector, options ...func(Config)) *MCP2515Driver {
+	d := &MCP2515Driver{
+		Driver:       NewDriver(a, "MCP2515"),
+		oscillator:   mcp2515DefaultOscillator,
+		bitrate:      mcp2515DefaultBitrate,
+		mode:         MCP2515ModeNormal,
+		pollInterval: mcp2515DefaultPollInterval,
+		dispatcher:   can.NewDispatcher(can.DefaultReceiveBufferSize),
+		busMutex:     &sync.Mutex{},
+		Eventer:      gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+	d.AddEvent(MCP2515Error)
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// WithMCP2515Oscillator option sets the frequency of the oscillator in Hz, e.g. 8MHz or 16MHz on most modules.
+func WithMCP2515Oscillator(hz int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.oscillator = hz
+		} else {
+			panic("unable to set oscillator for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515Bitrate option sets the bitrate of the bus in bit/s, e.g. 125000, 250000, 500000 or 1000000.
+func WithMCP2515Bitrate(bitrate int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.bitrate = bitrate
+		} else {
+			panic("unable to set bitrate for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515Mode option sets the operation mode after start, e.g. MCP2515ModeLoopback for tests without bus.
+func WithMCP2515Mode(mode MCP2515Mode) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.mode = mode
+		} else {
+			panic("unable to set mode for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515InterruptPin option sets the pin connected to the interrupt output of the controller. The
+// controller is only accessed by SPI, if the pin is low, so the load of the SPI bus is reduced while idle.
+func WithMCP2515InterruptPin(reader gpio.DigitalReader, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.intReader = reader
+			d.intPin = pin
+		} else {
+			panic("unable to set interrupt pin for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515PollInterval option sets the interval for polling the interrupt pin or the controller.
+func WithMCP2515PollInterval(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.pollInterval = interval
+		} else {
+			panic("unable to set poll interval for mcp2515")
+		}
+	}
+}
+
+// Send loads the frame into a free transmit buffer and requests the transmission. Implements the can.CanBus
+// interface.
+func (d *MCP2515Driver) Send(frame can.Frame) error {
+	if err := frame.Validate(); err != nil {
+		return err
+	}
+	if d.connection == nil {
+		return fmt.Errorf("connection of %s not available, driver not started", d.name)
+	}
+
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	rx := make([]byte, 2)
+	if err := d.connection.ReadCommandData([]byte{mcp2515ReadStatus, 0x00}, rx); err != nil {
+		return err
+	}
+	for n := 0; n < mcp2515TxBuffers; n++ {
+		// the status contains TXREQ of the buffers at the bits 2, 4 and 6
+		if rx[1]&(0x04<<(2*n)) != 0 {
+			continue
+		}
+		dlc := frame.DLC
+		if frame.Remote {
+			dlc |= mcp2515RTR
+		}
+		id := mcp2515EncodeID(frame.ID, frame.Extended)
+		tx := append([]byte{mcp2515LoadTxBuffer | byte(n<<1)}, id[:]...)
+		tx = append(tx, dlc)
+		if !frame.Remote {
+			tx = append(tx, frame.Data...)
+		}
+		if err := d.connection.WriteBytes(tx); err != nil {
+			return err
+		}
+		return d.connection.WriteByte(mcp2515RequestToSend | 1<<n)
+	}
+	return fmt.Errorf("no free transmit buffer of %s", d.name)
+}
+
+// Receive creates a new receiver for all received frames, which match one of the filters, or all frames without
+// filter. The filters are applied by software, see SetAcceptance() for the filters of the controller. Implements
+// the can.CanBus interface.
+func (d *MCP2515Driver) Receive(filters ...can.Filter) *can.Receiver {
+	return d.dispatcher.Receive(filters...)
+}
+
+// ErrorCounters reads the error counters and the error state from the controller. Implements the can.CanBus
+// interface.
+func (d *MCP2515Driver) ErrorCounters() (can.ErrorCounters, error) {
+	if d.connection == nil {
+		return can.ErrorCounters{}, fmt.Errorf("connection of %s not available, driver not started", d.name)
+	}
+
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	counters, err := d.readRegisters(mcp2515RegTEC, 2)
+	if err != nil {
+		return can.ErrorCounters{}, err
+	}
+	eflg, err := d.readRegisters(mcp2515RegEFLG, 1)
+	if err != nil {
+		return can.ErrorCounters{}, err
+	}
+	state := can.ErrorActive
+	switch {
+	case eflg[0]&mcp2515TXBO != 0:
+		state = can.BusOff
+	case eflg[0]&(mcp2515TXEP|mcp2515RXEP) != 0:
+		state = can.ErrorPassive
+	case eflg[0]&mcp2515EWARN != 0:
+		state = can.ErrorWarning
+	}
+	return can.ErrorCounters{
+		Transmit:  counters[0],
+		Receive:   counters[1],
+		State:     state,
+		Overflows: d.overflows,
+		Dropped:   d.dispatcher.Dropped(),
+	}, nil
+}
+
+// SetAcceptance configures the acceptance masks and filters of the controller for both receive buffers, nil
+// accepts all frames. RXB0 supports up to 2 and RXB1 up to 4 identifiers. Frames, which are accepted by RXB0,
+// roll over to RXB1 if RXB0 is full, so normally both buffers should get the same configuration or RXB1 should
+// be more permissive. If the driver is not started, the configuration is applied on start.
+func (d *MCP2515Driver) SetAcceptance(rxb0, rxb1 *MCP2515Acceptance) error {
+	for n, a := range []*MCP2515Acceptance{rxb0, rxb1} {
+		if a == nil {
+			continue
+		}
+		if len(a.IDs) == 0 || len(a.IDs) > len(mcp2515FilterRegisters[n]) {
+			return fmt.Errorf("%d identifiers are given for RXB%d, but 1..%d are supported", len(a.IDs), n,
+				len(mcp2515FilterRegisters[n]))
+		}
+	}
+
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	d.acceptance = [2]*MCP2515Acceptance{rxb0, rxb1}
+	if d.connection == nil {
+		return nil
+	}
+	if err := d.setMode(MCP2515ModeConfiguration); err != nil {
+		return err
+	}
+	if err := d.writeAcceptance(); err != nil {
+		return err
+	}
+	return d.setMode(d.mode)
+}
+
+// SetOperationMode changes the operation mode of the controller.
+func (d *MCP2515Driver) SetOperationMode(mode MCP2515Mode) error {
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	d.mode = mode
+	if d.connection == nil {
+		return nil
+	}
+	return d.setMode(mode)
+}
+
+func (d *MCP2515Driver) initialize() error {
+	cnf, err := mcp2515BitTiming(d.oscillator, d.bitrate)
+	if err != nil {
+		return err
+	}
+
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	if err := d.connection.WriteByte(mcp2515Reset); err != nil {
+		return err
+	}
+	time.Sleep(mcp2515ResetTime)
+	stat, err := d.readRegisters(mcp2515RegCANSTAT, 1)
+	if err != nil {
+		return err
+	}
+	if MCP2515Mode(stat[0]&mcp2515OpModeMask) != MCP2515ModeConfiguration {
+		return fmt.Errorf("%s not found, CANSTAT is 0x%02X after reset", d.name, stat[0])
+	}
+	// CNF3, CNF2 and CNF1 are consecutive registers
+	if err := d.writeRegisters(mcp2515RegCNF3, cnf[:]...); err != nil {
+		return err
+	}
+	if err := d.writeAcceptance(); err != nil {
+		return err
+	}
+	if err := d.writeRegisters(mcp2515RegCANINTE, mcp2515RX0IF|mcp2515RX1IF|mcp2515ERRIF, 0x00); err != nil {
+		return err
+	}
+	if err := d.setMode(d.mode); err != nil {
+		return err
+	}
+
+	d.dispatcher.Reopen()
+	d.halt = make(chan bool)
+	d.done = make(chan bool)
+	go d.pollLoop(d.halt, d.done)
+	return nil
+}
+
+func (d *MCP2515Driver) shutdown() error {
+	if d.halt != nil {
+		close(d.halt)
+		<-d.done
+		d.halt = nil
+	}
+	d.dispatcher.Close()
+	if d.connection == nil {
+		return nil
+	}
+
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	// leave the bus
+	return d.setMode(MCP2515ModeConfiguration)
+}
+
+func (d *MCP2515Driver) pollLoop(halt chan bool, done chan bool) {
+	defer close(done)
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-halt:
+			return
+		case <-ticker.C:
+			if err := d.poll(); err != nil {
+				d.Publish(MCP2515Error, err)
+			}
+		}
+	}
+}
+
+// poll reads the received frames and handles the error interrupt
+func (d *MCP2515Driver) poll() error {
+	if d.intReader != nil {
+		level, err := d.intReader.DigitalRead(d.intPin)
+		if err != nil {
+			return err
+		}
+		if level != 0 {
+			// the interrupt output is active low
+			return nil
+		}
+	}
+
+	d.busMutex.Lock()
+	defer d.busMutex.Unlock()
+
+	flags, err := d.readRegisters(mcp2515RegCANINTF, 1)
+	if err != nil {
+		return err
+	}
+	for n, flag := range []byte{mcp2515RX0IF, mcp2515RX1IF} {
+		if flags[0]&flag == 0 {
+			continue
+		}
+		// reading by this instruction clears the interrupt flag of the buffer
+		rx := make([]byte, 14)
+		if err := d.connection.ReadCommandData(append([]byte{mcp2515ReadRxBuffer | byte(n<<2)}, make([]byte, 13)...),
+			rx); err != nil {
+			return err
+		}
+		d.dispatcher.Dispatch(mcp2515DecodeFrame(rx[1:]))
+	}
+	if flags[0]&mcp2515ERRIF != 0 {
+		eflg, err := d.readRegisters(mcp2515RegEFLG, 1)
+		if err != nil {
+			return err
+		}
+		for _, ovr := range []byte{mcp2515RX0OVR, mcp2515RX1OVR} {
+			if eflg[0]&ovr != 0 {
+				d.overflows++
+			}
+		}
+		if err := d.modifyRegister(mcp2515RegEFLG, mcp2515RX0OVR|mcp2515RX1OVR, 0x00); err != nil {
+			return err
+		}
+	}
+	if clear := flags[0] & (mcp2515ERRIF | mcp2515MERRF); clear != 0 {
+		return d.modifyRegister(mcp2515RegCANINTF, clear, 0x00)
+	}
+	return nil
+}
+
+// writeAcceptance writes the masks and filters, the controller needs to be in configuration mode
+func (d *MCP2515Driver) writeAcceptance() error {
+	masks := []byte{mcp2515RegRXM0SIDH, mcp2515RegRXM1SIDH}
+	ctrls := []byte{mcp2515RegRXB0CTRL, mcp2515RegRXB1CTRL}
+	for n, a := range d.acceptance {
+		ctrl := byte(mcp2515RXMAny)
+		if a != nil {
+			ctrl = 0x00
+			// for standard frames the extended part of the mask is applied to the first two data bytes, so it
+			// needs to be cleared
+			mask := mcp2515EncodeID(a.Mask, a.Extended)
+			if err := d.writeRegisters(masks[n], mask[:]...); err != nil {
+				return err
+			}
+			for i, reg := range mcp2515FilterRegisters[n] {
+				// unused filters get the first identifier, so no additional frames are accepted
+				id := a.IDs[0]
+				if i < len(a.IDs) {
+					id = a.IDs[i]
+				}
+				filter := mcp2515EncodeID(id, a.Extended)
+				if err := d.writeRegisters(reg, filter[:]...); err != nil {
+					return err
+				}
+			}
+		}
+		if n == 0 {
+			ctrl |= mcp2515BUKT
+		}
+		if err := d.writeRegisters(ctrls[n], ctrl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *MCP2515Driver) setMode(mode MCP2515Mode) error {
+	if err := d.modifyRegister(mcp2515RegCANCTRL, mcp2515OpModeMask, byte(mode)); err != nil {
+		return err
+	}
+	for i := 0; i < mcp2515ModeChangeTries; i++ {
+		stat, err := d.readRegisters(mcp2515RegCANSTAT, 1)
+		if err != nil {
+			return err
+		}
+		if MCP2515Mode(stat[0]&mcp2515OpModeMask) == mode {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return fmt.Errorf("%s does not enter the mode 0x%02X", d.name, byte(mode))
+}
+
+func (d *MCP2515Driver) readRegisters(reg byte, count int) ([]byte, error) {
+	tx := make([]byte, count+2)
+	tx[0] = mcp2515Read
+	tx[1] = reg
+	rx := make([]byte, len(tx))
+	if err := d.connection.ReadCommandData(tx, rx); err != nil {
+		return nil, err
+	}
+	return rx[2:], nil
+}
+
+func (d *MCP2515Driver) writeRegisters(reg byte, data ...byte) error {
+	return d.connection.WriteBytes(append([]byte{mcp2515Write, reg}, data...))
+}
+
+func (d *MCP2515Driver) modifyRegister(reg byte, mask byte, data byte) error {
+	return d.connection.WriteBytes([]byte{mcp2515BitModify, reg, mask, data})
+}
+
+// mcp2515BitTiming calculates the values of CNF3, CNF2 and CNF1 for the bitrate with a sample point of about 75%
+// and a synchronization jump width of 1 time quantum
+func mcp2515BitTiming(oscillator, bitrate int) ([3]byte, error) {
+	if bitrate > 0 {
+		// the bit consists of 8..16 time quanta, prefer more quanta for better resynchronization
+		for tq := 16; tq >= 8; tq-- {
+			if oscillator%(2*bitrate*tq) != 0 {
+				continue
+			}
+			brp := oscillator / (2 * bitrate * tq)
+			if brp < 1 || brp > 64 {
+				continue
+			}
+			ps2 := tq - (3*tq+2)/4
+			ps1 := (tq - 1 - ps2) / 2
+			prop := tq - 1 - ps2 - ps1
+			// CNF2 contains BTLMODE, so PS2 is taken from CNF3
+			return [3]byte{byte(ps2 - 1), 0x80 | byte(ps1-1)<<3 | byte(prop-1), byte(brp - 1)}, nil
+		}
+	}
+	return [3]byte{}, fmt.Errorf("bitrate %d is not possible with an oscillator of %d Hz", bitrate, oscillator)
+}
+
+// mcp2515EncodeID returns the values of the registers SIDH, SIDL, EID8 and EID0 for the identifier
+func mcp2515EncodeID(id uint32, extended bool) [4]byte {
+	if !extended {
+		return [4]byte{byte(id >> 3), byte(id << 5), 0x00, 0x00}
+	}
+	sid := id >> 18
+	return [4]byte{byte(sid >> 3), byte(sid<<5) | mcp2515EXIDE | byte(id>>16)&0x03, byte(id >> 8), byte(id)}
+}
+
+// mcp2515DecodeFrame creates the frame from the content of a receive buffer, starting with SIDH
+func mcp2515DecodeFrame(buf []byte) can.Frame {
+	var frame can.Frame
+	sid := uint32(buf[0])<<3 | uint32(buf[1])>>5
+	if buf[1]&mcp2515EXIDE != 0 {
+		frame.Extended = true
+		frame.ID = sid<<18 | uint32(buf[1]&0x03)<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+		frame.Remote = buf[4]&mcp2515RTR != 0
+	} else {
+		frame.ID = sid
+		frame.Remote = buf[1]&mcp2515SRR != 0
+	}
+	frame.DLC = buf[4] & 0x0F
+	if frame.DLC > can.MaxDataLength {
+		frame.DLC = can.MaxDataLength
+	}
+	if !frame.Remote {
+		frame.Data = append([]byte(nil), buf[5:5+frame.DLC]...)
+	}
+	return frame
+}
@@ -0,0 +1,474 @@
+package spi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/can"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on spi.Driver, and provides the can.CanBus interface
+var _ gobot.Driver = (*MCP2515Driver)(nil)
+var _ can.CanBus = (*MCP2515Driver)(nil)
+
+// mcp2515TestChip simulates the registers and instructions of the MCP2515
+type mcp2515TestChip struct {
+	regs        [128]byte
+	transmitted [][]byte
+	stuckMode   bool
+	mtx         sync.Mutex
+}
+
+func newMCP2515TestChip() *mcp2515TestChip {
+	c := &mcp2515TestChip{}
+	c.reset()
+	return c
+}
+
+func (c *mcp2515TestChip) reset() {
+	c.regs = [128]byte{}
+	c.regs[mcp2515RegCANSTAT] = 0x80
+	c.regs[mcp2515RegCANCTRL] = 0x87
+}
+
+// receive puts the raw frame starting with SIDH into the receive buffer and sets the interrupt flag
+func (c *mcp2515TestChip) receive(buffer int, raw ...byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	copy(c.regs[mcp2515RegRXB0CTRL+1+0x10*buffer:], raw)
+	c.regs[mcp2515RegCANINTF] |= 1 << buffer
+}
+
+func (c *mcp2515TestChip) setRegisters(reg int, data ...byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	copy(c.regs[reg:], data)
+}
+
+func (c *mcp2515TestChip) register(reg int) byte {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.regs[reg]
+}
+
+func (c *mcp2515TestChip) TxRx(tx []byte, rx []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	switch {
+	case tx[0] == mcp2515Reset:
+		c.reset()
+	case tx[0] == mcp2515Read:
+		copy(rx[2:], c.regs[tx[1]:])
+	case tx[0] == mcp2515Write:
+		copy(c.regs[tx[1]:], tx[2:])
+		c.updateMode()
+	case tx[0] == mcp2515BitModify:
+		c.regs[tx[1]] = c.regs[tx[1]]&^tx[2] | tx[3]&tx[2]
+		c.updateMode()
+	case tx[0] == mcp2515ReadStatus:
+		var status byte
+		for n := 0; n < mcp2515TxBuffers; n++ {
+			if c.regs[0x30+0x10*n]&0x08 != 0 {
+				status |= 0x04 << (2 * n)
+			}
+		}
+		rx[1] = status
+	case tx[0]&0xF9 == mcp2515ReadRxBuffer:
+		n := int(tx[0]>>2) & 0x01
+		copy(rx[1:], c.regs[mcp2515RegRXB0CTRL+1+0x10*n:])
+		c.regs[mcp2515RegCANINTF] &^= 1 << n
+	case tx[0]&0xF8 == mcp2515LoadTxBuffer:
+		n := int(tx[0]>>1) & 0x03
+		copy(c.regs[0x31+0x10*n:], tx[1:])
+	case tx[0]&0xF8 == mcp2515RequestToSend:
+		for n := 0; n < mcp2515TxBuffers; n++ {
+			if tx[0]&(1<<n) != 0 {
+				c.regs[0x30+0x10*n] |= 0x08
+				length := 5
+				if c.regs[0x35+0x10*n]&mcp2515RTR == 0 {
+					length += int(c.regs[0x35+0x10*n] & 0x0F)
+				}
+				c.transmitted = append(c.transmitted, append([]byte{}, c.regs[0x31+0x10*n:0x31+0x10*n+length]...))
+			}
+		}
+	default:
+		return fmt.Errorf("unknown instruction 0x%02X", tx[0])
+	}
+	return nil
+}
+
+func (c *mcp2515TestChip) updateMode() {
+	if !c.stuckMode {
+		c.regs[mcp2515RegCANSTAT] = c.regs[mcp2515RegCANCTRL] & mcp2515OpModeMask
+	}
+}
+
+func (c *mcp2515TestChip) Close() error { return nil }
+
+type mcp2515TestAdaptor struct {
+	*spiTestAdaptor
+	chip *mcp2515TestChip
+}
+
+func (a *mcp2515TestAdaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (Connection, error) {
+	return NewConnection(a.chip), nil
+}
+
+type mcp2515TestPin struct {
+	level int
+	mtx   sync.Mutex
+}
+
+func (p *mcp2515TestPin) DigitalRead(string) (int, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.level, nil
+}
+
+func (p *mcp2515TestPin) setLevel(level int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.level = level
+}
+
+func initTestMCP2515DriverWithSimulatedChip(options ...func(Config)) (*MCP2515Driver, *mcp2515TestChip) {
+	chip := newMCP2515TestChip()
+	d := NewMCP2515Driver(&mcp2515TestAdaptor{spiTestAdaptor: newSpiTestAdaptor(), chip: chip}, options...)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, chip
+}
+
+func TestNewMCP2515Driver(t *testing.T) {
+	var di interface{} = NewMCP2515Driver(newSpiTestAdaptor())
+	d, ok := di.(*MCP2515Driver)
+	if !ok {
+		t.Errorf("NewMCP2515Driver() should have returned a *MCP2515Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MCP2515"), true)
+	gobottest.Assert(t, d.oscillator, 8000000)
+	gobottest.Assert(t, d.bitrate, 500000)
+	gobottest.Assert(t, d.mode, MCP2515ModeNormal)
+}
+
+func TestMCP2515DriverOptions(t *testing.T) {
+	pin := &mcp2515TestPin{}
+	d := NewMCP2515Driver(newSpiTestAdaptor(), WithMCP2515Oscillator(16000000), WithMCP2515Bitrate(250000),
+		WithMCP2515Mode(MCP2515ModeLoopback), WithMCP2515InterruptPin(pin, "int"),
+		WithMCP2515PollInterval(5*time.Millisecond))
+	gobottest.Assert(t, d.oscillator, 16000000)
+	gobottest.Assert(t, d.bitrate, 250000)
+	gobottest.Assert(t, d.mode, MCP2515ModeLoopback)
+	gobottest.Assert(t, d.intReader, pin)
+	gobottest.Assert(t, d.intPin, "int")
+	gobottest.Assert(t, d.pollInterval, 5*time.Millisecond)
+}
+
+func TestMCP2515BitTiming(t *testing.T) {
+	var tests = map[string]struct {
+		oscillator int
+		bitrate    int
+		want       [3]byte
+		wantErr    error
+	}{
+		"8MHz_500k":  {oscillator: 8000000, bitrate: 500000, want: [3]byte{0x01, 0x8A, 0x00}},
+		"8MHz_125k":  {oscillator: 8000000, bitrate: 125000, want: [3]byte{0x03, 0xA5, 0x01}},
+		"16MHz_500k": {oscillator: 16000000, bitrate: 500000, want: [3]byte{0x03, 0xA5, 0x00}},
+		"16MHz_1M":   {oscillator: 16000000, bitrate: 1000000, want: [3]byte{0x01, 0x8A, 0x00}},
+		"16MHz_250k": {oscillator: 16000000, bitrate: 250000, want: [3]byte{0x03, 0xA5, 0x01}},
+		"8MHz_1M_error": {
+			oscillator: 8000000,
+			bitrate:    1000000,
+			wantErr:    fmt.Errorf("bitrate 1000000 is not possible with an oscillator of 8000000 Hz"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := mcp2515BitTiming(tc.oscillator, tc.bitrate)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			gobottest.Assert(t, got, tc.want)
+		})
+	}
+}
+
+func TestMCP2515DriverStart(t *testing.T) {
+	// arrange & act
+	d, chip := initTestMCP2515DriverWithSimulatedChip(WithMCP2515Mode(MCP2515ModeListenOnly))
+	defer d.Halt()
+	// assert
+	gobottest.Assert(t, chip.register(mcp2515RegCNF3), byte(0x01))
+	gobottest.Assert(t, chip.register(mcp2515RegCNF3+1), byte(0x8A))
+	gobottest.Assert(t, chip.register(mcp2515RegCNF3+2), byte(0x00))
+	gobottest.Assert(t, chip.register(mcp2515RegCANINTE), byte(0x23))
+	gobottest.Assert(t, chip.register(mcp2515RegRXB0CTRL), byte(0x64))
+	gobottest.Assert(t, chip.register(mcp2515RegRXB1CTRL), byte(0x60))
+	gobottest.Assert(t, chip.register(mcp2515RegCANCTRL), byte(0x67))
+	gobottest.Assert(t, chip.register(mcp2515RegCANSTAT), byte(0x60))
+}
+
+func TestMCP2515DriverStartError(t *testing.T) {
+	var tests = map[string]struct {
+		chip    func(c *mcp2515TestChip)
+		options []func(Config)
+		wantErr error
+	}{
+		"bitrate": {
+			options: []func(Config){WithMCP2515Bitrate(0)},
+			wantErr: fmt.Errorf("bitrate 0 is not possible with an oscillator of 8000000 Hz"),
+		},
+		"mode": {
+			chip:    func(c *mcp2515TestChip) { c.stuckMode = true },
+			wantErr: fmt.Errorf("MCP2515 does not enter the mode 0x00"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			chip := newMCP2515TestChip()
+			if tc.chip != nil {
+				tc.chip(chip)
+			}
+			d := NewMCP2515Driver(&mcp2515TestAdaptor{spiTestAdaptor: newSpiTestAdaptor(), chip: chip}, tc.options...)
+			d.SetName("MCP2515")
+			// act & assert
+			gobottest.Assert(t, d.Start(), tc.wantErr)
+		})
+	}
+}
+
+func TestMCP2515DriverStartNotFound(t *testing.T) {
+	// arrange
+	a := newSpiTestAdaptor()
+	d := NewMCP2515Driver(a)
+	d.SetName("MCP2515")
+	// act & assert
+	gobottest.Assert(t, d.Start(), fmt.Errorf("MCP2515 not found, CANSTAT is 0x00 after reset"))
+}
+
+func TestMCP2515DriverHalt(t *testing.T) {
+	// arrange
+	d, chip := initTestMCP2515DriverWithSimulatedChip()
+	r := d.Receive()
+	// act
+	err := d.Halt()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, chip.register(mcp2515RegCANSTAT), byte(MCP2515ModeConfiguration))
+	_, ok := <-r.C
+	gobottest.Assert(t, ok, false)
+}
+
+func TestMCP2515DriverSend(t *testing.T) {
+	var tests = map[string]struct {
+		frame   can.Frame
+		busy    []int
+		want    []byte
+		wantReg int
+		wantErr error
+	}{
+		"standard": {
+			frame:   can.NewFrame(0x123, 0xDE, 0xAD),
+			want:    []byte{0x24, 0x60, 0x00, 0x00, 0x02, 0xDE, 0xAD},
+			wantReg: 0x30,
+		},
+		"extended_remote": {
+			frame:   can.Frame{ID: 0x18FEF100, Extended: true, Remote: true, DLC: 8},
+			want:    []byte{0xC7, 0xEA, 0xF1, 0x00, 0x48},
+			wantReg: 0x30,
+		},
+		"third_buffer": {
+			frame:   can.NewFrame(0x7FF),
+			busy:    []int{0, 1},
+			want:    []byte{0xFF, 0xE0, 0x00, 0x00, 0x00},
+			wantReg: 0x50,
+		},
+		"no_free_buffer": {
+			frame:   can.NewFrame(0x7FF),
+			busy:    []int{0, 1, 2},
+			wantErr: fmt.Errorf("no free transmit buffer of MCP2515"),
+		},
+		"invalid_frame": {
+			frame:   can.NewFrame(0x01, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+			wantErr: fmt.Errorf("data length 9 is out of range"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, chip := initTestMCP2515DriverWithSimulatedChip()
+			defer d.Halt()
+			d.SetName("MCP2515")
+			for _, n := range tc.busy {
+				chip.setRegisters(0x30+0x10*n, 0x08)
+			}
+			// act
+			err := d.Send(tc.frame)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				gobottest.Assert(t, chip.transmitted, [][]byte{tc.want})
+				gobottest.Assert(t, chip.register(tc.wantReg), byte(0x08))
+			}
+		})
+	}
+}
+
+func TestMCP2515DriverNotStarted(t *testing.T) {
+	d := NewMCP2515Driver(newSpiTestAdaptor())
+	d.SetName("MCP2515")
+	gobottest.Assert(t, d.Send(can.NewFrame(0x01)), fmt.Errorf("connection of MCP2515 not available, driver not started"))
+	_, err := d.ErrorCounters()
+	gobottest.Assert(t, err, fmt.Errorf("connection of MCP2515 not available, driver not started"))
+	gobottest.Assert(t, d.SetAcceptance(&MCP2515Acceptance{Mask: 0x7FF, IDs: []uint32{0x100}}, nil), nil)
+	gobottest.Assert(t, d.acceptance[0].IDs, []uint32{0x100})
+	gobottest.Assert(t, d.SetOperationMode(MCP2515ModeLoopback), nil)
+	gobottest.Assert(t, d.mode, MCP2515ModeLoopback)
+}
+
+func TestMCP2515DriverReceive(t *testing.T) {
+	// arrange
+	d, chip := initTestMCP2515DriverWithSimulatedChip()
+	defer d.Halt()
+	all := d.Receive()
+	filtered := d.Receive(can.NewFilter(0x18FEF100))
+	// act
+	chip.receive(0, 0x24, 0x60, 0x00, 0x00, 0x02, 0xDE, 0xAD)
+	chip.receive(1, 0xC7, 0xEA, 0xF1, 0x00, 0x01, 0x55)
+	// assert
+	for _, want := range []can.Frame{can.NewFrame(0x123, 0xDE, 0xAD), can.NewFrame(0x18FEF100, 0x55)} {
+		select {
+		case frame := <-all.C:
+			gobottest.Assert(t, frame, want)
+		case <-time.After(time.Second):
+			t.Errorf("frame %s not received", want)
+		}
+	}
+	select {
+	case frame := <-filtered.C:
+		gobottest.Assert(t, frame, can.NewFrame(0x18FEF100, 0x55))
+	case <-time.After(time.Second):
+		t.Errorf("filtered frame not received")
+	}
+	gobottest.Assert(t, chip.register(mcp2515RegCANINTF), byte(0x00))
+}
+
+func TestMCP2515DriverInterruptPin(t *testing.T) {
+	// arrange
+	pin := &mcp2515TestPin{level: 1}
+	d, chip := initTestMCP2515DriverWithSimulatedChip(WithMCP2515InterruptPin(pin, "int"))
+	defer d.Halt()
+	r := d.Receive()
+	// act
+	chip.receive(0, 0x24, 0x70, 0x00, 0x00, 0x04)
+	time.Sleep(10 * time.Millisecond)
+	// assert
+	gobottest.Assert(t, len(r.C), 0)
+	pin.setLevel(0)
+	select {
+	case frame := <-r.C:
+		gobottest.Assert(t, frame, can.Frame{ID: 0x123, Remote: true, DLC: 4})
+	case <-time.After(time.Second):
+		t.Errorf("frame not received")
+	}
+}
+
+func TestMCP2515DriverErrorCounters(t *testing.T) {
+	var tests = map[string]struct {
+		eflg byte
+		want can.ErrorState
+	}{
+		"active":  {want: can.ErrorActive},
+		"warning": {eflg: 0x01, want: can.ErrorWarning},
+		"passive": {eflg: 0x11, want: can.ErrorPassive},
+		"bus_off": {eflg: 0x31, want: can.BusOff},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, chip := initTestMCP2515DriverWithSimulatedChip()
+			defer d.Halt()
+			chip.setRegisters(mcp2515RegTEC, 130, 5)
+			chip.setRegisters(mcp2515RegEFLG, tc.eflg)
+			// act
+			got, err := d.ErrorCounters()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got, can.ErrorCounters{Transmit: 130, Receive: 5, State: tc.want})
+		})
+	}
+}
+
+func TestMCP2515DriverOverflow(t *testing.T) {
+	// arrange
+	d, chip := initTestMCP2515DriverWithSimulatedChip(WithMCP2515PollInterval(time.Hour))
+	defer d.Halt()
+	chip.setRegisters(mcp2515RegEFLG, mcp2515RX0OVR|mcp2515RX1OVR)
+	chip.setRegisters(mcp2515RegCANINTF, mcp2515ERRIF|mcp2515MERRF)
+	// act
+	err := d.poll()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, chip.register(mcp2515RegEFLG), byte(0x00))
+	gobottest.Assert(t, chip.register(mcp2515RegCANINTF), byte(0x00))
+	counters, _ := d.ErrorCounters()
+	gobottest.Assert(t, counters.Overflows, uint64(2))
+}
+
+func TestMCP2515DriverSetAcceptance(t *testing.T) {
+	// arrange
+	d, chip := initTestMCP2515DriverWithSimulatedChip(WithMCP2515Mode(MCP2515ModeLoopback))
+	defer d.Halt()
+	// act
+	err := d.SetAcceptance(&MCP2515Acceptance{Mask: 0x7F0, IDs: []uint32{0x120}},
+		&MCP2515Acceptance{Mask: 0x1FFFFFFF, Extended: true, IDs: []uint32{0x18FEF100, 0x18FEF200}})
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, chip.register(mcp2515RegRXB0CTRL), byte(0x04))
+	gobottest.Assert(t, chip.register(mcp2515RegRXB1CTRL), byte(0x00))
+	gobottest.Assert(t, chip.regs[mcp2515RegRXM0SIDH:mcp2515RegRXM0SIDH+4], []byte{0xFE, 0x00, 0x00, 0x00})
+	gobottest.Assert(t, chip.regs[0x00:0x08], []byte{0x24, 0x00, 0x00, 0x00, 0x24, 0x00, 0x00, 0x00})
+	gobottest.Assert(t, chip.regs[mcp2515RegRXM1SIDH:mcp2515RegRXM1SIDH+4], []byte{0xFF, 0xEB, 0xFF, 0xFF})
+	gobottest.Assert(t, chip.regs[0x08:0x0C], []byte{0xC7, 0xEA, 0xF1, 0x00})
+	gobottest.Assert(t, chip.regs[0x10:0x14], []byte{0xC7, 0xEA, 0xF2, 0x00})
+	gobottest.Assert(t, chip.regs[0x18:0x1C], []byte{0xC7, 0xEA, 0xF1, 0x00})
+	gobottest.Assert(t, chip.register(mcp2515RegCANSTAT), byte(MCP2515ModeLoopback))
+	// reset to receive any frame
+	gobottest.Assert(t, d.SetAcceptance(nil, nil), nil)
+	gobottest.Assert(t, chip.register(mcp2515RegRXB0CTRL), byte(0x64))
+	gobottest.Assert(t, d.SetAcceptance(nil, &MCP2515Acceptance{}),
+		fmt.Errorf("0 identifiers are given for RXB1, but 1..4 are supported"))
+}
+
+func TestMCP2515DriverPollErrorEvent(t *testing.T) {
+	// arrange
+	d, _ := initTestMCP2515DriverWithSimulatedChip(WithMCP2515InterruptPin(&mcp2515FailingPin{}, "int"))
+	defer d.Halt()
+	errs := make(chan error, 1)
+	_ = d.Once(MCP2515Error, func(data interface{}) { errs <- data.(error) })
+	// assert
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, fmt.Errorf("pin read error"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+}
+
+type mcp2515FailingPin struct{}
+
+func (p *mcp2515FailingPin) DigitalRead(string) (int, error) { return 0, fmt.Errorf("pin read error") }
@@ -1,7 +1,6 @@
 package spi
 
 import (
-	"fmt"
 	"strconv"
 )
 
@@ -37,21 +36,12 @@ func NewMCP3002Driver(a Connector, options ...func(Config)) *MCP3002Driver {
 
 // Read reads the current analog data for the desired channel.
 func (d *MCP3002Driver) Read(channel int) (int, error) {
-	if channel < 0 || channel > MCP3002DriverMaxChannel-1 {
-		return 0, fmt.Errorf("Invalid channel '%d' for read", channel)
-	}
-
-	tx := make([]byte, 2)
-	tx[0] = 0x68 + (byte(channel) << 4)
-	tx[1] = 0x00
-
-	rx := make([]byte, 2)
-
-	if err := d.connection.ReadCommandData(tx, rx); err != nil {
-		return 0, err
-	}
+	return mcp3002Model.read(d.connection, channel, false)
+}
 
-	return int((rx[0]&0x3))<<8 + int(rx[1]), nil
+// ReadDifferential reads the current analog data for the desired differential channel (pair of inputs).
+func (d *MCP3002Driver) ReadDifferential(channel int) (int, error) {
+	return mcp3002Model.read(d.connection, channel, true)
 }
 
 // AnalogRead returns value from analog reading of specified pin
@@ -61,3 +51,7 @@ func (d *MCP3002Driver) AnalogRead(pin string) (value int, err error) {
 
 	return
 }
+
+func (d *MCP3002Driver) mcp3xxx() (Connection, *mcp3xxxModel) {
+	return d.connection, &mcp3002Model
+}
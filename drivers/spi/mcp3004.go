@@ -1,7 +1,6 @@
 package spi
 
 import (
-	"fmt"
 	"strconv"
 )
 
@@ -36,24 +35,13 @@ func NewMCP3004Driver(a Connector, options ...func(Config)) *MCP3004Driver {
 }
 
 // Read reads the current analog data for the desired channel.
-func (d *MCP3004Driver) Read(channel int) (result int, err error) {
-	if channel < 0 || channel > MCP3004DriverMaxChannel-1 {
-		return 0, fmt.Errorf("Invalid channel '%d' for read", channel)
-	}
-
-	tx := make([]byte, 3)
-	tx[0] = 0x01
-	tx[1] = byte(8+channel) << 4
-	tx[2] = 0x00
-
-	rx := make([]byte, 3)
-
-	err = d.connection.ReadCommandData(tx, rx)
-	if err == nil && len(rx) == 3 {
-		result = int((rx[1]&0x3))<<8 + int(rx[2])
-	}
+func (d *MCP3004Driver) Read(channel int) (int, error) {
+	return mcp3004Model.read(d.connection, channel, false)
+}
 
-	return result, err
+// ReadDifferential reads the current analog data for the desired differential channel (pair of inputs).
+func (d *MCP3004Driver) ReadDifferential(channel int) (int, error) {
+	return mcp3004Model.read(d.connection, channel, true)
 }
 
 // AnalogRead returns value from analog reading of specified pin
@@ -63,3 +51,7 @@ func (d *MCP3004Driver) AnalogRead(pin string) (value int, err error) {
 
 	return
 }
+
+func (d *MCP3004Driver) mcp3xxx() (Connection, *mcp3xxxModel) {
+	return d.connection, &mcp3004Model
+}
@@ -1,7 +1,6 @@
 package spi
 
 import (
-	"fmt"
 	"strconv"
 
 	"gobot.io/x/gobot"
@@ -38,24 +37,13 @@ func NewMCP3202Driver(a Connector, options ...func(Config)) *MCP3202Driver {
 }
 
 // Read reads the current analog data for the desired channel.
-func (d *MCP3202Driver) Read(channel int) (result int, err error) {
-	if channel < 0 || channel > MCP3202DriverMaxChannel-1 {
-		return 0, fmt.Errorf("Invalid channel '%d' for read", channel)
-	}
-
-	tx := make([]byte, 3)
-	tx[0] = 0x01
-	tx[1] = 0xa0 + byte(channel)<<6
-	tx[2] = 0x00
-
-	rx := make([]byte, 3)
-
-	err = d.connection.ReadCommandData(tx, rx)
-	if err == nil && len(rx) == 3 {
-		result = int((rx[1]&0xf))<<8 + int(rx[2])
-	}
+func (d *MCP3202Driver) Read(channel int) (int, error) {
+	return mcp3202Model.read(d.connection, channel, false)
+}
 
-	return result, err
+// ReadDifferential reads the current analog data for the desired differential channel (pair of inputs).
+func (d *MCP3202Driver) ReadDifferential(channel int) (int, error) {
+	return mcp3202Model.read(d.connection, channel, true)
 }
 
 // AnalogRead returns value from analog reading of specified pin, scaled to 0-1023 value.
@@ -68,3 +56,7 @@ func (d *MCP3202Driver) AnalogRead(pin string) (value int, err error) {
 
 	return
 }
+
+func (d *MCP3202Driver) mcp3xxx() (Connection, *mcp3xxxModel) {
+	return d.connection, &mcp3202Model
+}
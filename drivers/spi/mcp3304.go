@@ -1,7 +1,6 @@
 package spi
 
 import (
-	"fmt"
 	"strconv"
 
 	"gobot.io/x/gobot"
@@ -38,24 +37,13 @@ func NewMCP3304Driver(a Connector, options ...func(Config)) *MCP3304Driver {
 }
 
 // Read reads the current analog data for the desired channel.
-func (d *MCP3304Driver) Read(channel int) (result int, err error) {
-	if channel < 0 || channel > MCP3304DriverMaxChannel-1 {
-		return 0, fmt.Errorf("Invalid channel '%d' for read", channel)
-	}
-
-	tx := make([]byte, 3)
-	tx[0] = 0x0c + (byte(channel) >> 1)
-	tx[1] = (byte(channel) & 0x01) << 7
-	tx[2] = 0x00
-
-	rx := make([]byte, 3)
-
-	err = d.connection.ReadCommandData(tx, rx)
-	if err == nil && len(rx) == 3 {
-		result = int((rx[1]&0xf))<<8 + int(rx[2])
-	}
+func (d *MCP3304Driver) Read(channel int) (int, error) {
+	return mcp3304Model.read(d.connection, channel, false)
+}
 
-	return result, err
+// ReadDifferential reads the current analog data for the desired differential channel (pair of inputs).
+func (d *MCP3304Driver) ReadDifferential(channel int) (int, error) {
+	return mcp3304Model.read(d.connection, channel, true)
 }
 
 // AnalogRead returns value from analog reading of specified pin, scaled to 0-1023 value.
@@ -68,3 +56,7 @@ func (d *MCP3304Driver) AnalogRead(pin string) (value int, err error) {
 
 	return
 }
+
+func (d *MCP3304Driver) mcp3xxx() (Connection, *mcp3xxxModel) {
+	return d.connection, &mcp3304Model
+}
@@ -0,0 +1,147 @@
+package spi
+
+import "fmt"
+
+// mcp3xxxModel describes the differences of the MCP3xxx A/D converter family.
+type mcp3xxxModel struct {
+	name     string
+	channels int
+	// bits is the resolution, without the sign bit of the MCP3304 in differential mode
+	bits int
+	// command returns the transmit frame for a conversion of the given channel
+	command func(channel byte, differential bool) []byte
+	// value extracts the result from the received frame
+	value func(rx []byte, differential bool) int
+}
+
+var (
+	mcp3002Model = mcp3xxxModel{
+		name:     "MCP3002",
+		channels: MCP3002DriverMaxChannel,
+		bits:     10,
+		command: func(channel byte, differential bool) []byte {
+			// start bit, SGL/DIFF, ODD/SIGN, MSBF
+			if differential {
+				return []byte{0x48 + (channel << 4), 0x00}
+			}
+			return []byte{0x68 + (channel << 4), 0x00}
+		},
+		value: func(rx []byte, differential bool) int {
+			return int((rx[0]&0x3))<<8 + int(rx[1])
+		},
+	}
+	mcp3004Model = mcp3xxxModel{
+		name:     "MCP3004",
+		channels: MCP3004DriverMaxChannel,
+		bits:     10,
+		command:  mcp300xCommand,
+		value:    mcp300xValue,
+	}
+	mcp3008Model = mcp3xxxModel{
+		name:     "MCP3008",
+		channels: MCP3008DriverMaxChannel,
+		bits:     10,
+		command:  mcp300xCommand,
+		value:    mcp300xValue,
+	}
+	mcp3202Model = mcp3xxxModel{
+		name:     "MCP3202",
+		channels: MCP3202DriverMaxChannel,
+		bits:     12,
+		command: func(channel byte, differential bool) []byte {
+			// start bit, SGL/DIFF, ODD/SIGN, MSBF
+			if differential {
+				return []byte{0x01, 0x20 + channel<<6, 0x00}
+			}
+			return []byte{0x01, 0xa0 + channel<<6, 0x00}
+		},
+		value: mcp320xValue,
+	}
+	mcp3204Model = mcp3xxxModel{
+		name:     "MCP3204",
+		channels: MCP3204DriverMaxChannel,
+		bits:     12,
+		command:  mcp320xCommand,
+		value:    mcp320xValue,
+	}
+	mcp3208Model = mcp3xxxModel{
+		name:     "MCP3208",
+		channels: MCP3208DriverMaxChannel,
+		bits:     12,
+		command:  mcp320xCommand,
+		value:    mcp320xValue,
+	}
+	mcp3304Model = mcp3xxxModel{
+		name:     "MCP3304",
+		channels: MCP3304DriverMaxChannel,
+		bits:     12,
+		command: func(channel byte, differential bool) []byte {
+			// start bit, SGL/DIFF, D2..D0
+			if differential {
+				return []byte{0x08 + (channel >> 1), (channel & 0x01) << 7, 0x00}
+			}
+			return []byte{0x0c + (channel >> 1), (channel & 0x01) << 7, 0x00}
+		},
+		value: func(rx []byte, differential bool) int {
+			if !differential {
+				return int((rx[1]&0xf))<<8 + int(rx[2])
+			}
+			// 13 bit two's complement
+			value := int((rx[1]&0x1f))<<8 + int(rx[2])
+			if value&0x1000 != 0 {
+				value -= 0x2000
+			}
+			return value
+		},
+	}
+)
+
+func mcp300xCommand(channel byte, differential bool) []byte {
+	// start bit in first byte, SGL/DIFF and D2..D0 in second byte
+	if differential {
+		return []byte{0x01, channel << 4, 0x00}
+	}
+	return []byte{0x01, (8 + channel) << 4, 0x00}
+}
+
+func mcp300xValue(rx []byte, differential bool) int {
+	return int((rx[1]&0x3))<<8 + int(rx[2])
+}
+
+func mcp320xCommand(channel byte, differential bool) []byte {
+	// start bit, SGL/DIFF and D2 in first byte, D1..D0 in second byte
+	if differential {
+		return []byte{0x04 + (channel >> 2), (channel & 0x03) << 6, 0x00}
+	}
+	return []byte{0x06 + (channel >> 2), (channel & 0x03) << 6, 0x00}
+}
+
+func mcp320xValue(rx []byte, differential bool) int {
+	return int((rx[1]&0xf))<<8 + int(rx[2])
+}
+
+// checkChannel returns an error, if the channel is not available for this model.
+func (m *mcp3xxxModel) checkChannel(channel int) error {
+	if channel < 0 || channel > m.channels-1 {
+		return fmt.Errorf("Invalid channel '%d' for read", channel)
+	}
+	return nil
+}
+
+// read does a single conversion of the channel by one SPI transaction.
+func (m *mcp3xxxModel) read(connection Connection, channel int, differential bool) (int, error) {
+	if err := m.checkChannel(channel); err != nil {
+		return 0, err
+	}
+	if connection == nil {
+		return 0, fmt.Errorf("connection of %s not available, driver not started", m.name)
+	}
+
+	tx := m.command(byte(channel), differential)
+	rx := make([]byte, len(tx))
+	if err := connection.ReadCommandData(tx, rx); err != nil {
+		return 0, err
+	}
+
+	return m.value(rx, differential), nil
+}
@@ -0,0 +1,284 @@
+package spi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/ticker"
+)
+
+const (
+	// MCP3xxxSamplerError is the event name, which is published when a scan of the sampler fails
+	MCP3xxxSamplerError = "error"
+
+	mcp3xxxSamplerDefaultRate      = 100.0
+	mcp3xxxSamplerDefaultReference = 3.3
+	mcp3xxxSamplerBufferSize       = 16
+)
+
+// MCP3xxxADC is implemented by the drivers of the MCP3xxx family, which can be used by the MCP3xxxSampler:
+// MCP3002Driver, MCP3004Driver, MCP3008Driver, MCP3202Driver, MCP3204Driver, MCP3208Driver and MCP3304Driver.
+type MCP3xxxADC interface {
+	mcp3xxx() (Connection, *mcp3xxxModel)
+}
+
+// MCP3xxxChannel is an input of the A/D converter. For differential channels the number selects the pair of
+// inputs according to the data sheet, e.g. 0 is "CH0 = IN+, CH1 = IN-" and 1 is "CH0 = IN-, CH1 = IN+".
+type MCP3xxxChannel struct {
+	Number       int
+	Differential bool
+}
+
+// String returns the pin name of the channel, e.g. "2" for a single ended and "d2" for a differential channel.
+func (c MCP3xxxChannel) String() string {
+	if c.Differential {
+		return "d" + strconv.Itoa(c.Number)
+	}
+	return strconv.Itoa(c.Number)
+}
+
+// ParseMCP3xxxChannel converts a pin name like "2" or "d2" to a channel.
+func ParseMCP3xxxChannel(pin string) (MCP3xxxChannel, error) {
+	var c MCP3xxxChannel
+	number := pin
+	if strings.HasPrefix(pin, "d") {
+		c.Differential = true
+		number = pin[1:]
+	}
+	var err error
+	if c.Number, err = strconv.Atoi(number); err != nil {
+		return MCP3xxxChannel{}, fmt.Errorf("invalid pin '%s' for MCP3xxx channel", pin)
+	}
+	return c, nil
+}
+
+// MCP3xxxSample is a single conversion result of a channel.
+type MCP3xxxSample struct {
+	Channel MCP3xxxChannel
+	// Raw is the value of the converter, negative values are possible for differential channels of the MCP3304
+	Raw int
+	// Volts is the voltage calculated from the raw value and the reference voltage
+	Volts float64
+	Time  time.Time
+}
+
+// MCP3xxxSampler scans a list of channels of a MCP3xxx A/D converter with a target rate. The samples of a
+// configurable count of scans are delivered together as one batch by the channel C. Batches are dropped, if the
+// channel is full. The last sample of each channel can be read by AnalogRead(), so the sampler can be used as
+// aio.AnalogReader by the existing analog drivers.
+//
+// Each conversion needs its own chip select cycle, so a scan is done by one SPI transaction per channel. The
+// transmit frames are prepared once on creation of the sampler.
+type MCP3xxxSampler struct {
+	// C delivers the batches of samples
+	C         <-chan []MCP3xxxSample
+	c         chan []MCP3xxxSample
+	adc       MCP3xxxADC
+	model     *mcp3xxxModel
+	channels  []MCP3xxxChannel
+	frames    [][]byte
+	rx        []byte
+	interval  time.Duration
+	reference float64
+	batchSize int
+	batch     []MCP3xxxSample
+	latest    map[MCP3xxxChannel]MCP3xxxSample
+	dropped   int
+	loop      *ticker.Loop
+	mutex     *sync.Mutex
+	gobot.Eventer
+}
+
+// NewMCP3xxxSampler creates a new sampler for the given channels of the A/D converter, which is driven by the
+// given driver. The default rate is 100 scans per second, the default reference voltage is 3.3V and each scan
+// is delivered as its own batch.
+func NewMCP3xxxSampler(adc MCP3xxxADC, channels ...MCP3xxxChannel) (*MCP3xxxSampler, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("at least one channel is needed for sampling")
+	}
+	_, model := adc.mcp3xxx()
+	s := &MCP3xxxSampler{
+		adc:       adc,
+		model:     model,
+		channels:  make([]MCP3xxxChannel, len(channels)),
+		frames:    make([][]byte, len(channels)),
+		interval:  time.Duration(float64(time.Second) / mcp3xxxSamplerDefaultRate),
+		reference: mcp3xxxSamplerDefaultReference,
+		batchSize: 1,
+		latest:    make(map[MCP3xxxChannel]MCP3xxxSample),
+		loop:      ticker.NewLoop(),
+		mutex:     &sync.Mutex{},
+		Eventer:   gobot.NewEventer(),
+	}
+	copy(s.channels, channels)
+	for i, channel := range channels {
+		if err := model.checkChannel(channel.Number); err != nil {
+			return nil, err
+		}
+		s.frames[i] = model.command(byte(channel.Number), channel.Differential)
+	}
+	s.rx = make([]byte, len(s.frames[0]))
+	s.c = make(chan []MCP3xxxSample, mcp3xxxSamplerBufferSize)
+	s.C = s.c
+	s.AddEvent(MCP3xxxSamplerError)
+	return s, nil
+}
+
+// SetRate sets the target rate of scans per second, a running sample loop is not affected until restart.
+func (s *MCP3xxxSampler) SetRate(scansPerSecond float64) error {
+	if scansPerSecond <= 0 {
+		return fmt.Errorf("the rate needs to be greater than 0, but is %v", scansPerSecond)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.interval = time.Duration(float64(time.Second) / scansPerSecond)
+	return nil
+}
+
+// SetReference sets the reference voltage of the A/D converter, which is used for conversion to volts.
+func (s *MCP3xxxSampler) SetReference(volts float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.reference = volts
+}
+
+// SetBatchSize sets the count of scans, which are delivered together by the channel C.
+func (s *MCP3xxxSampler) SetBatchSize(scans int) error {
+	if scans < 1 {
+		return fmt.Errorf("the batch size needs to be at least 1, but is %d", scans)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.batchSize = scans
+	s.batch = nil
+	return nil
+}
+
+// Channels returns the scanned channels.
+func (s *MCP3xxxSampler) Channels() []MCP3xxxChannel {
+	return append([]MCP3xxxChannel{}, s.channels...)
+}
+
+// Dropped returns the number of batches, which were dropped because the channel C was full.
+func (s *MCP3xxxSampler) Dropped() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.dropped
+}
+
+// Latest returns the last sample of the channel, false if there is none.
+func (s *MCP3xxxSampler) Latest(channel MCP3xxxChannel) (MCP3xxxSample, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sample, ok := s.latest[channel]
+	return sample, ok
+}
+
+// AnalogRead returns the raw value of the last sample of the channel given by the pin name, e.g. "2" or "d2".
+// Implements the aio.AnalogReader interface.
+func (s *MCP3xxxSampler) AnalogRead(pin string) (int, error) {
+	channel, err := ParseMCP3xxxChannel(pin)
+	if err != nil {
+		return 0, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sample, ok := s.latest[channel]
+	if !ok {
+		for _, c := range s.channels {
+			if c == channel {
+				return 0, fmt.Errorf("no sample of channel '%s' available", pin)
+			}
+		}
+		return 0, fmt.Errorf("channel '%s' is not sampled", pin)
+	}
+	return sample.Raw, nil
+}
+
+// Scan reads all channels once and returns the samples. The samples are not delivered by the channel C, but are
+// available by Latest() and AnalogRead().
+func (s *MCP3xxxSampler) Scan() ([]MCP3xxxSample, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.scan()
+}
+
+// Start starts to scan the channels with the target rate and to deliver the batches by the channel C.
+func (s *MCP3xxxSampler) Start() {
+	s.mutex.Lock()
+	interval := s.interval
+	s.mutex.Unlock()
+
+	s.loop.Start(interval, func() {
+		if err := s.sample(); err != nil {
+			s.Publish(MCP3xxxSamplerError, err)
+		}
+	})
+}
+
+// Stop stops the sample loop, an incomplete batch is discarded.
+func (s *MCP3xxxSampler) Stop() {
+	s.loop.Stop()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.batch = nil
+}
+
+// sample does one scan and delivers the batch without blocking, if it is complete
+func (s *MCP3xxxSampler) sample() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	samples, err := s.scan()
+	if err != nil {
+		return err
+	}
+	s.batch = append(s.batch, samples...)
+	if len(s.batch) < s.batchSize*len(s.channels) {
+		return nil
+	}
+	select {
+	case s.c <- s.batch:
+	default:
+		s.dropped++
+	}
+	s.batch = nil
+	return nil
+}
+
+func (s *MCP3xxxSampler) scan() ([]MCP3xxxSample, error) {
+	connection, _ := s.adc.mcp3xxx()
+	if connection == nil {
+		return nil, fmt.Errorf("connection of %s not available, driver not started", s.model.name)
+	}
+
+	fullScale := float64(int(1) << s.model.bits)
+	samples := make([]MCP3xxxSample, len(s.channels))
+	for i, channel := range s.channels {
+		if err := connection.ReadCommandData(s.frames[i], s.rx); err != nil {
+			return nil, err
+		}
+		raw := s.model.value(s.rx, channel.Differential)
+		samples[i] = MCP3xxxSample{
+			Channel: channel,
+			Raw:     raw,
+			Volts:   float64(raw) * s.reference / fullScale,
+			Time:    time.Now(),
+		}
+		s.latest[channel] = samples[i]
+	}
+	return samples, nil
+}
@@ -0,0 +1,185 @@
+package spi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/drivers/aio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// must implement the AnalogReader interface
+var _ aio.AnalogReader = (*MCP3xxxSampler)(nil)
+
+func initTestMCP3xxxSamplerWithStubbedAdaptor(channels ...MCP3xxxChannel) (*MCP3xxxSampler, *spiTestAdaptor) {
+	a := newSpiTestAdaptor()
+	d := NewMCP3208Driver(a)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	s, err := NewMCP3xxxSampler(d, channels...)
+	if err != nil {
+		panic(err)
+	}
+	return s, a
+}
+
+func TestNewMCP3xxxSampler(t *testing.T) {
+	var tests = map[string]struct {
+		channels []MCP3xxxChannel
+		wantErr  error
+	}{
+		"ok": {
+			channels: []MCP3xxxChannel{{Number: 0}, {Number: 7, Differential: true}},
+		},
+		"no_channel_error": {
+			wantErr: fmt.Errorf("at least one channel is needed for sampling"),
+		},
+		"channel_error": {
+			channels: []MCP3xxxChannel{{Number: 0}, {Number: 8}},
+			wantErr:  fmt.Errorf("Invalid channel '8' for read"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			s, err := NewMCP3xxxSampler(NewMCP3208Driver(newSpiTestAdaptor()), tc.channels...)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				gobottest.Assert(t, s.Channels(), tc.channels)
+			}
+		})
+	}
+}
+
+func TestMCP3xxxSamplerScan(t *testing.T) {
+	// arrange
+	s, a := initTestMCP3xxxSamplerWithStubbedAdaptor(MCP3xxxChannel{Number: 1},
+		MCP3xxxChannel{Number: 2, Differential: true})
+	s.SetReference(4.096)
+	a.spi.SetSimRead([]byte{0xFF, 0xF8, 0x00})
+	// act
+	samples, err := s.Scan()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.spi.Written(), []byte{0x06, 0x40, 0x00, 0x04, 0x80, 0x00})
+	gobottest.Assert(t, len(samples), 2)
+	gobottest.Assert(t, samples[0].Channel, MCP3xxxChannel{Number: 1})
+	gobottest.Assert(t, samples[0].Raw, 0x0800)
+	gobottest.Assert(t, samples[0].Volts, 2.048)
+	gobottest.Assert(t, samples[0].Time.IsZero(), false)
+	gobottest.Assert(t, samples[1].Channel, MCP3xxxChannel{Number: 2, Differential: true})
+	latest, ok := s.Latest(MCP3xxxChannel{Number: 2, Differential: true})
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, latest, samples[1])
+}
+
+func TestMCP3xxxSamplerScanError(t *testing.T) {
+	// arrange
+	s, err := NewMCP3xxxSampler(NewMCP3008Driver(newSpiTestAdaptor()), MCP3xxxChannel{Number: 0})
+	gobottest.Assert(t, err, nil)
+	// act
+	_, err = s.Scan()
+	// assert
+	gobottest.Assert(t, err, fmt.Errorf("connection of MCP3008 not available, driver not started"))
+}
+
+func TestMCP3xxxSamplerAnalogRead(t *testing.T) {
+	// arrange
+	s, a := initTestMCP3xxxSamplerWithStubbedAdaptor(MCP3xxxChannel{Number: 3},
+		MCP3xxxChannel{Number: 0, Differential: true})
+	_, errBefore := s.AnalogRead("3")
+	a.spi.SetSimRead([]byte{0xFF, 0xF1, 0x23})
+	_, _ = s.Scan()
+	var tests = map[string]struct {
+		pin     string
+		want    int
+		wantErr error
+	}{
+		"single_ended": {pin: "3", want: 0x0123},
+		"differential": {pin: "d0", want: 0x0123},
+		"not_sampled":  {pin: "0", wantErr: fmt.Errorf("channel '0' is not sampled")},
+		"invalid_pin":  {pin: "x1", wantErr: fmt.Errorf("invalid pin 'x1' for MCP3xxx channel")},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := s.AnalogRead(tc.pin)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			gobottest.Assert(t, got, tc.want)
+		})
+	}
+	gobottest.Assert(t, errBefore, fmt.Errorf("no sample of channel '3' available"))
+}
+
+func TestMCP3xxxSamplerSettings(t *testing.T) {
+	s, _ := initTestMCP3xxxSamplerWithStubbedAdaptor(MCP3xxxChannel{Number: 0})
+	gobottest.Assert(t, s.SetRate(0), fmt.Errorf("the rate needs to be greater than 0, but is 0"))
+	gobottest.Assert(t, s.SetRate(1000), nil)
+	gobottest.Assert(t, s.interval, time.Millisecond)
+	gobottest.Assert(t, s.SetBatchSize(0), fmt.Errorf("the batch size needs to be at least 1, but is 0"))
+	gobottest.Assert(t, s.SetBatchSize(3), nil)
+	gobottest.Assert(t, MCP3xxxChannel{Number: 4, Differential: true}.String(), "d4")
+}
+
+func TestMCP3xxxSamplerBatches(t *testing.T) {
+	// arrange
+	s, a := initTestMCP3xxxSamplerWithStubbedAdaptor(MCP3xxxChannel{Number: 0}, MCP3xxxChannel{Number: 1})
+	a.spi.SetSimRead([]byte{0xFF, 0xF0, 0x10})
+	_ = s.SetBatchSize(2)
+	// act
+	for i := 0; i < 2*(mcp3xxxSamplerBufferSize+1)+1; i++ {
+		gobottest.Assert(t, s.sample(), nil)
+	}
+	// assert
+	batch := <-s.C
+	gobottest.Assert(t, len(batch), 4)
+	gobottest.Assert(t, batch[3].Channel, MCP3xxxChannel{Number: 1})
+	gobottest.Assert(t, batch[3].Raw, 0x0010)
+	gobottest.Assert(t, len(s.C), mcp3xxxSamplerBufferSize-1)
+	gobottest.Assert(t, s.Dropped(), 1)
+	// the incomplete batch is discarded
+	s.Stop()
+	gobottest.Assert(t, len(s.batch), 0)
+}
+
+func TestMCP3xxxSamplerStartStop(t *testing.T) {
+	// arrange
+	s, a := initTestMCP3xxxSamplerWithStubbedAdaptor(MCP3xxxChannel{Number: 0})
+	a.spi.SetSimRead([]byte{0xFF, 0xF0, 0x10})
+	_ = s.SetRate(1000)
+	// act
+	s.Start()
+	defer s.Stop()
+	// assert
+	select {
+	case batch := <-s.C:
+		gobottest.Assert(t, batch[0].Raw, 0x0010)
+	case <-time.After(time.Second):
+		t.Errorf("no samples delivered")
+	}
+	s.Stop()
+	s.Stop()
+}
+
+func TestMCP3xxxSamplerErrorEvent(t *testing.T) {
+	// arrange
+	s, a := initTestMCP3xxxSamplerWithStubbedAdaptor(MCP3xxxChannel{Number: 0})
+	a.spi.SetReadError(true)
+	_ = s.SetRate(1000)
+	errs := make(chan error, 1)
+	_ = s.Once(MCP3xxxSamplerError, func(data interface{}) { errs <- data.(error) })
+	// act
+	s.Start()
+	defer s.Stop()
+	// assert
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, fmt.Errorf("error while SPI read in mock"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+}
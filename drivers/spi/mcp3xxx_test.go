@@ -0,0 +1,109 @@
+package spi
+
+import (
+	"fmt"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type mcp3xxxDifferentialReader interface {
+	Start() error
+	ReadDifferential(channel int) (int, error)
+}
+
+func TestMCP3xxxReadDifferential(t *testing.T) {
+	var tests = map[string]struct {
+		driver      func(a Connector) mcp3xxxDifferentialReader
+		chanNum     int
+		simRead     []byte
+		want        int
+		wantWritten []byte
+		wantErr     error
+	}{
+		"mcp3002": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3002Driver(a) },
+			chanNum:     1,
+			simRead:     []byte{0xF2, 0x11},
+			wantWritten: []byte{0x58, 0x00},
+			want:        0x0211,
+		},
+		"mcp3004": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3004Driver(a) },
+			chanNum:     3,
+			simRead:     []byte{0xFF, 0xF2, 0x11},
+			wantWritten: []byte{0x01, 0x30, 0x00},
+			want:        0x0211,
+		},
+		"mcp3008": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3008Driver(a) },
+			chanNum:     7,
+			simRead:     []byte{0xFF, 0xF2, 0x11},
+			wantWritten: []byte{0x01, 0x70, 0x00},
+			want:        0x0211,
+		},
+		"mcp3202": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3202Driver(a) },
+			chanNum:     1,
+			simRead:     []byte{0xFF, 0xF2, 0x11},
+			wantWritten: []byte{0x01, 0x60, 0x00},
+			want:        0x0211,
+		},
+		"mcp3204": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3204Driver(a) },
+			chanNum:     2,
+			simRead:     []byte{0xFF, 0xF2, 0x11},
+			wantWritten: []byte{0x04, 0x80, 0x00},
+			want:        0x0211,
+		},
+		"mcp3208": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3208Driver(a) },
+			chanNum:     5,
+			simRead:     []byte{0xFF, 0xF2, 0x11},
+			wantWritten: []byte{0x05, 0x40, 0x00},
+			want:        0x0211,
+		},
+		"mcp3304_positive": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3304Driver(a) },
+			chanNum:     3,
+			simRead:     []byte{0xFF, 0xE2, 0x11},
+			wantWritten: []byte{0x09, 0x80, 0x00},
+			want:        0x0211,
+		},
+		"mcp3304_negative": {
+			driver:      func(a Connector) mcp3xxxDifferentialReader { return NewMCP3304Driver(a) },
+			chanNum:     0,
+			simRead:     []byte{0xFF, 0xFF, 0xFE},
+			wantWritten: []byte{0x08, 0x00, 0x00},
+			want:        -2,
+		},
+		"mcp3004_channel_error": {
+			driver:  func(a Connector) mcp3xxxDifferentialReader { return NewMCP3004Driver(a) },
+			chanNum: 4,
+			wantErr: fmt.Errorf("Invalid channel '4' for read"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := newSpiTestAdaptor()
+			d := tc.driver(a)
+			if err := d.Start(); err != nil {
+				panic(err)
+			}
+			a.spi.SetSimRead(tc.simRead)
+			// act
+			got, err := d.ReadDifferential(tc.chanNum)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			gobottest.Assert(t, got, tc.want)
+			gobottest.Assert(t, a.spi.Written(), tc.wantWritten)
+		})
+	}
+}
+
+func TestMCP3xxxReadNotStarted(t *testing.T) {
+	d := NewMCP3008Driver(newSpiTestAdaptor())
+	_, err := d.Read(0)
+	gobottest.Assert(t, err, fmt.Errorf("connection of MCP3008 not available, driver not started"))
+}
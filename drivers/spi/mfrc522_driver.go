@@ -1,14 +1,19 @@
 package spi
 
 import (
+	"time"
+
 	"gobot.io/x/gobot/drivers/common/mfrc522"
 )
 
 // MFRC522Driver is a wrapper for SPI bus usage. Please refer to the mfrc522.MFRC522Common package
-// for implementation details.
+// for implementation details. The driver publishes the events mfrc522.CardArrived and mfrc522.CardRemoved while
+// polling is active.
 type MFRC522Driver struct {
 	*Driver
 	*mfrc522.MFRC522Common
+	*mfrc522.Poller
+	pollInterval time.Duration
 }
 
 // NewMFRC522Driver creates a new Gobot Driver for MFRC522 RFID with SPI connection
@@ -22,22 +27,48 @@ type MFRC522Driver struct {
 //      spi.WithMode(int):    	 mode to use with this driver
 //      spi.WithBitCount(int):   number of bits to use with this driver
 //      spi.WithSpeed(int64):    speed in Hz to use with this driver
+//      spi.WithMFRC522Polling(time.Duration): start polling for cards with the given interval
 //
 func NewMFRC522Driver(a Connector, options ...func(Config)) *MFRC522Driver {
 	d := &MFRC522Driver{
 		Driver: NewDriver(a, "MFRC522"),
 	}
 	d.MFRC522Common = mfrc522.NewMFRC522Common()
+	d.Poller = mfrc522.NewPoller(d.MFRC522Common)
 	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
 	for _, option := range options {
 		option(d)
 	}
 	return d
 }
 
+// WithMFRC522Polling option starts polling for cards with the given interval after the driver is started.
+func WithMFRC522Polling(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MFRC522Driver)
+		if ok {
+			d.pollInterval = interval
+		} else {
+			panic("unable to set polling interval for mfrc522")
+		}
+	}
+}
+
 func (d *MFRC522Driver) initialize() error {
 	wrapper := &conWrapper{origCon: d.connection}
-	return d.MFRC522Common.Initialize(wrapper)
+	if err := d.MFRC522Common.Initialize(wrapper); err != nil {
+		return err
+	}
+	if d.pollInterval > 0 {
+		d.StartPolling(d.pollInterval)
+	}
+	return nil
+}
+
+func (d *MFRC522Driver) shutdown() error {
+	d.StopPolling()
+	return nil
 }
 
 // this is necessary due to special behavior of shift bytes and set first bit
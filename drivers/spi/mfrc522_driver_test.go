@@ -3,8 +3,10 @@ package spi
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mfrc522"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -42,3 +44,17 @@ func TestMFRC522WriteByteData(t *testing.T) {
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, a.spi.Written(), []byte{0x00, 0x00})
 }
+
+func TestMFRC522DriverPolling(t *testing.T) {
+	// arrange
+	a := newSpiTestAdaptor()
+	d := NewMFRC522Driver(a, WithMFRC522Polling(time.Hour))
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.pollInterval, time.Hour)
+	gobottest.Refute(t, d.Event(mfrc522.CardArrived), "")
+	gobottest.Refute(t, d.Event(mfrc522.CardRemoved), "")
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,94 @@
+package spi
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot/drivers/common/regmap"
+)
+
+// RegisterMapDriver is a driver for devices, which are described by a register map. It allows the usage of devices
+// without writing a specific driver and shares the description with the i2c variant, see package regmap for the
+// description format.
+type RegisterMapDriver struct {
+	*Driver
+	description *regmap.Description
+	mapOptions  []regmap.Option
+	regs        *regmap.Map
+}
+
+// NewRegisterMapDriver creates a new driver for a device with the given register map description. Most SPI devices
+// need a flag in the register address for reading, which can be set by spi.WithRegisterMapOptions().
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver
+//      desc *regmap.Description - the description of the registers
+//
+// Optional params:
+//      spi.WithBusNumber(int):  bus to use with this driver
+//      spi.WithChipNumber(int): chip to use with this driver
+//      spi.WithMode(int):       mode to use with this driver
+//      spi.WithBitCount(int):   number of bits to use with this driver
+//      spi.WithSpeed(int64):    speed in Hz to use with this driver
+//      spi.WithRegisterMapOptions(...regmap.Option): options for the register map, e.g. regmap.WithReadFlag(0x80)
+//
+func NewRegisterMapDriver(a Connector, desc *regmap.Description, options ...func(Config)) *RegisterMapDriver {
+	name := desc.Name
+	if name == "" {
+		name = "RegisterMap"
+	}
+	d := &RegisterMapDriver{
+		Driver:      NewDriver(a, name),
+		description: desc,
+	}
+	d.afterStart = d.initialize
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// WithRegisterMapOptions option sets the options for the register map of the RegisterMapDriver.
+func WithRegisterMapOptions(opts ...regmap.Option) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*RegisterMapDriver)
+		if ok {
+			d.mapOptions = append(d.mapOptions, opts...)
+		} else {
+			panic("unable to set register map options for non-RegisterMapDriver")
+		}
+	}
+}
+
+// Map returns the register map for typed access to the registers and fields, nil before the driver is started.
+func (d *RegisterMapDriver) Map() *regmap.Map {
+	return d.regs
+}
+
+// Read reads the raw value of the register or field with the given name.
+func (d *RegisterMapDriver) Read(name string) (int, error) {
+	if d.regs == nil {
+		return 0, fmt.Errorf("register map of '%s' not available, driver not started", d.name)
+	}
+	val, err := d.regs.Read(name)
+	return int(val), err
+}
+
+// Write writes the raw value to the register or field with the given name.
+func (d *RegisterMapDriver) Write(name string, val int) error {
+	if d.regs == nil {
+		return fmt.Errorf("register map of '%s' not available, driver not started", d.name)
+	}
+	if val < 0 {
+		return fmt.Errorf("negative value %d not allowed for '%s'", val, name)
+	}
+	return d.regs.Write(name, uint64(val))
+}
+
+func (d *RegisterMapDriver) initialize() error {
+	regs, err := regmap.New(d.connection, d.description, d.mapOptions...)
+	if err != nil {
+		return err
+	}
+	d.regs = regs
+	return nil
+}
@@ -0,0 +1,81 @@
+package spi
+
+import (
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/regmap"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on spi.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*RegisterMapDriver)(nil)
+
+func regmapTestDescription() *regmap.Description {
+	return &regmap.Description{
+		Name: "BMP280",
+		Registers: []regmap.Register{
+			{Name: "ID", Address: 0x50, Access: regmap.ReadOnly},
+			{Name: "CTRL_MEAS", Address: 0x74, Fields: []regmap.Field{
+				{Name: "MODE", Width: 2, Enum: map[string]uint64{"sleep": 0, "forced": 1, "normal": 3}},
+				{Name: "OSRS_P", Bit: 2, Width: 3},
+				{Name: "OSRS_T", Bit: 5, Width: 3},
+			}},
+		},
+	}
+}
+
+func initTestRegisterMapDriverWithStubbedAdaptor() (*RegisterMapDriver, *spiTestAdaptor) {
+	a := newSpiTestAdaptor()
+	d := NewRegisterMapDriver(a, regmapTestDescription(), WithRegisterMapOptions(regmap.WithReadFlag(0x80)))
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewRegisterMapDriver(t *testing.T) {
+	var di interface{} = NewRegisterMapDriver(newSpiTestAdaptor(), regmapTestDescription())
+	d, ok := di.(*RegisterMapDriver)
+	if !ok {
+		t.Errorf("NewRegisterMapDriver() should have returned a *RegisterMapDriver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "BMP280"), true)
+	gobottest.Assert(t, d.Map() == nil, true)
+	_, err := d.Read("ID")
+	gobottest.Assert(t, strings.Contains(err.Error(), "driver not started"), true)
+}
+
+func TestRegisterMapDriverStart(t *testing.T) {
+	d := NewRegisterMapDriver(newSpiTestAdaptor(), &regmap.Description{})
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "RegisterMap"), true)
+	gobottest.Assert(t, strings.Contains(d.Start().Error(), "has no registers"), true)
+}
+
+func TestRegisterMapDriverRead(t *testing.T) {
+	// arrange
+	d, a := initTestRegisterMapDriverWithStubbedAdaptor()
+	a.spi.SetSimRead([]byte{0x00, 0x58})
+	// act
+	id, err := d.Read("ID")
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, id, 0x58)
+	gobottest.Assert(t, a.spi.Written(), []byte{0xD0, 0x00})
+}
+
+func TestRegisterMapDriverWrite(t *testing.T) {
+	// arrange
+	d, a := initTestRegisterMapDriverWithStubbedAdaptor()
+	a.spi.SetSimRead([]byte{0x00, 0x24})
+	// act
+	err := d.Map().WriteEnum("MODE", "normal")
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.spi.Written(), []byte{0xF4, 0x00, 0x74, 0x27})
+	gobottest.Assert(t, d.Write("ID", 1).Error(), "register 'ID' is read only")
+	gobottest.Assert(t, d.Write("MODE", -1).Error(), "negative value -1 not allowed for 'MODE'")
+}
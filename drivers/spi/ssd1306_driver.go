@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mono"
 	"gobot.io/x/gobot/drivers/gpio"
 )
 
@@ -323,6 +324,46 @@ func (s *SSD1306Driver) ShowImage(img image.Image) error {
 	return s.Display()
 }
 
+// NewFramebuffer creates a framebuffer with the size of the display, to be used with DrawFramebuffer().
+func (s *SSD1306Driver) NewFramebuffer() *mono.Framebuffer {
+	return mono.NewFramebuffer(s.DisplayWidth, s.DisplayHeight)
+}
+
+// DrawFramebuffer sends only the changed region of the framebuffer to the display, the memory buffer is
+// updated accordingly.
+func (s *SSD1306Driver) DrawFramebuffer(fb *mono.Framebuffer) error {
+	if w, h := fb.PhysicalSize(); w != s.DisplayWidth || h != s.DisplayHeight {
+		return fmt.Errorf("Framebuffer must match the display width and height")
+	}
+	r := fb.Changed()
+	if r.Empty() {
+		return nil
+	}
+	firstPage := r.Min.Y / 8
+	lastPage := (r.Max.Y - 1) / 8
+
+	var data []byte
+	for page := firstPage; page <= lastPage; page++ {
+		pageData := fb.PageBytes(page, r.Min.X, r.Max.X)
+		copy(s.buffer.buffer[page*s.DisplayWidth+r.Min.X:], pageData)
+		data = append(data, pageData...)
+	}
+
+	for _, c := range []byte{ssd1306ColumnAddr, byte(r.Min.X), byte(r.Max.X - 1), ssd1306PageAddr, byte(firstPage), byte(lastPage)} {
+		if err := s.command(c); err != nil {
+			return err
+		}
+	}
+	if err := s.dcDriver.DigitalWrite(1); err != nil {
+		return err
+	}
+	if err := s.connection.WriteBytes(data); err != nil {
+		return err
+	}
+	fb.Commit(image.Rect(r.Min.X, firstPage*8, r.Max.X, (lastPage+1)*8))
+	return nil
+}
+
 // command sends a unique command
 func (s *SSD1306Driver) command(b byte) error {
 	if err := s.dcDriver.DigitalWrite(0); err != nil {
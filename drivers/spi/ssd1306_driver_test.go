@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/mono"
 	"gobot.io/x/gobot/gobottest"
 )
 
@@ -45,6 +46,27 @@ func TestSSD1306DriverShowImage(t *testing.T) {
 	gobottest.Assert(t, d.ShowImage(img), nil)
 }
 
+func TestSSD1306DriverDrawFramebuffer(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewSSD1306Driver(a)
+	d.Start()
+	fb := d.NewFramebuffer()
+	fb.Commit(fb.Bounds())
+	fb.SetPixel(3, 0, true)
+	fb.SetPixel(4, 8, true)
+
+	spi := a.Connector.(*spiTestAdaptor).spi
+	before := len(spi.Written())
+	gobottest.Assert(t, d.DrawFramebuffer(fb), nil)
+	gobottest.Assert(t, spi.Written()[before:], []byte{
+		ssd1306ColumnAddr, 3, 4, ssd1306PageAddr, 0, 1,
+		0x01, 0x00, 0x00, 0x01,
+	})
+	gobottest.Assert(t, fb.Changed().Empty(), true)
+
+	gobottest.Assert(t, d.DrawFramebuffer(mono.NewFramebuffer(10, 10)), errors.New("Framebuffer must match the display width and height"))
+}
+
 type gpioTestAdaptor struct {
 	name string
 	port string
@@ -0,0 +1,137 @@
+package spi
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	ssd1351ColumnAddress      = 0x15
+	ssd1351RowAddress         = 0x75
+	ssd1351WriteRAM           = 0x5C
+	ssd1351Remap              = 0xA0
+	ssd1351StartLine          = 0xA1
+	ssd1351DisplayOffset      = 0xA2
+	ssd1351NormalDisplay      = 0xA6
+	ssd1351InverseDisplay     = 0xA7
+	ssd1351FunctionSelect     = 0xAB
+	ssd1351DisplayOff         = 0xAE
+	ssd1351DisplayOn          = 0xAF
+	ssd1351Precharge          = 0xB1
+	ssd1351ClockDiv           = 0xB3
+	ssd1351SetVSL             = 0xB4
+	ssd1351SetGPIO            = 0xB5
+	ssd1351Precharge2         = 0xB6
+	ssd1351VCOMH              = 0xBE
+	ssd1351ContrastABC        = 0xC1
+	ssd1351ContrastMaster     = 0xC7
+	ssd1351MuxRatio           = 0xCA
+	ssd1351CommandLock        = 0xFD
+	ssd1351RemapDefault       = 0x64 // 65k colours, COM split odd even, colour sequence C-B-A
+	ssd1351RemapColorSequence = 0x04
+)
+
+var ssd1351Model = tftModel{
+	name:              "SSD1351",
+	width:             128,
+	height:            128,
+	memoryLines:       128,
+	columnCmd:         ssd1351ColumnAddress,
+	rowCmd:            ssd1351RowAddress,
+	writeCmd:          ssd1351WriteRAM,
+	byteAddresses:     true,
+	exchangeAddresses: true,
+	initCommands: func(d *tftDriver) []tftCommand {
+		// according to the initialization of the Adafruit library
+		return []tftCommand{
+			{cmd: ssd1351CommandLock, data: []byte{0x12}},
+			{cmd: ssd1351CommandLock, data: []byte{0xB1}},
+			{cmd: ssd1351DisplayOff},
+			{cmd: ssd1351ClockDiv, data: []byte{0xF1}},
+			{cmd: ssd1351MuxRatio, data: []byte{byte(d.height - 1)}},
+			{cmd: ssd1351DisplayOffset, data: []byte{0x00}},
+			{cmd: ssd1351SetGPIO, data: []byte{0x00}},
+			{cmd: ssd1351FunctionSelect, data: []byte{0x01}},
+			{cmd: ssd1351Precharge, data: []byte{0x32}},
+			{cmd: ssd1351VCOMH, data: []byte{0x05}},
+			{cmd: ssd1351ContrastABC, data: []byte{0xC8, 0x80, 0xC8}},
+			{cmd: ssd1351ContrastMaster, data: []byte{0x0F}},
+			{cmd: ssd1351SetVSL, data: []byte{0xA0, 0xB5, 0x55}},
+			{cmd: ssd1351Precharge2, data: []byte{0x01}},
+		}
+	},
+	rotation: func(d *tftDriver) []tftCommand {
+		// COM scan direction, column address remap and vertical address increment for each rotation
+		remap := ssd1351RemapDefault | [4]byte{0x10, 0x13, 0x02, 0x01}[d.rotation&0x03]
+		if d.bgr {
+			remap &^= ssd1351RemapColorSequence
+		}
+		return []tftCommand{
+			{cmd: ssd1351Remap, data: []byte{remap}},
+			ssd1351Scroll(d, 0),
+		}
+	},
+	display: func(on bool) tftCommand {
+		if on {
+			return tftCommand{cmd: ssd1351DisplayOn}
+		}
+		return tftCommand{cmd: ssd1351DisplayOff}
+	},
+	inversion: func(on bool) tftCommand {
+		if on {
+			return tftCommand{cmd: ssd1351InverseDisplay}
+		}
+		return tftCommand{cmd: ssd1351NormalDisplay}
+	},
+	scrollArea: func(d *tftDriver, top, lines, bottom int) ([]tftCommand, error) {
+		if top != 0 || bottom != 0 {
+			return nil, fmt.Errorf("fixed lines are not supported by %s", d.name)
+		}
+		return nil, nil
+	},
+	scroll: ssd1351Scroll,
+}
+
+// ssd1351Scroll sets the display start line, the start line needs an offset of the height for the reversed COM
+// scan direction of the rotations 0 and 90
+func ssd1351Scroll(d *tftDriver, lines int) tftCommand {
+	if d.rotation == TFTRotate0 || d.rotation == TFTRotate90 {
+		lines += d.height
+	}
+	return tftCommand{cmd: ssd1351StartLine, data: []byte{byte(d.scrollPosition(lines))}}
+}
+
+// SSD1351Driver is a driver for colour OLED displays with the SSD1351 controller. The default size is 128x128,
+// for 128x96 displays the option WithTFTSize() is needed.
+//
+// The driver implements the draw.Image interface, the changed content is transferred by Display(). Fixed lines
+// for hardware scrolling are not supported by this controller.
+//
+// Datasheet: https://cdn-shop.adafruit.com/datasheets/SSD1351-Revision+1.3.pdf
+type SSD1351Driver struct {
+	*tftDriver
+}
+
+// NewSSD1351Driver creates a new driver for a SSD1351 colour display.
+//
+// Params:
+//      a Connector - the Adaptor to use with this Driver
+//      dcWriter gpio.DigitalWriter - the Adaptor to use for the data/command and reset pin
+//      dcPin string - the pin connected to the data/command input
+//
+// Optional params:
+//      spi.WithBusNumber(int):  bus to use with this driver
+//      spi.WithChipNumber(int): chip to use with this driver
+//      spi.WithMode(int):       mode to use with this driver
+//      spi.WithBitCount(int):   number of bits to use with this driver
+//      spi.WithSpeed(int64):    speed in Hz to use with this driver
+//      spi.WithTFTResetPin(string):    pin connected to the reset input
+//      spi.WithTFTSize(int, int):      width and height
+//      spi.WithTFTRotation(TFTRotation): rotation to apply on start
+//      spi.WithTFTInversion(bool):     invert the colours
+//      spi.WithTFTBGR(bool):           swap red and blue
+//
+func NewSSD1351Driver(a Connector, dcWriter gpio.DigitalWriter, dcPin string, options ...func(Config)) *SSD1351Driver {
+	return &SSD1351Driver{tftDriver: newTFTDriver(a, &ssd1351Model, dcWriter, dcPin, options...)}
+}
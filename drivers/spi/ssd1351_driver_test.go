@@ -0,0 +1,87 @@
+package spi
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func newTestSSD1351(options ...func(Config)) func(a Connector, w *tftTestDCWriter) *tftDriver {
+	return func(a Connector, w *tftTestDCWriter) *tftDriver {
+		return NewSSD1351Driver(a, w, "dc", options...).tftDriver
+	}
+}
+
+func TestSSD1351DriverStart(t *testing.T) {
+	// arrange
+	a := newSpiTestAdaptor()
+	w := &tftTestDCWriter{spi: a.spi, dcChanges: make(map[int]byte)}
+	d := NewSSD1351Driver(a, w, "dc", WithTFTSize(128, 96))
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	cmds := w.commands()
+	gobottest.Assert(t, cmds[0], tftCommand{cmd: ssd1351CommandLock, data: []byte{0x12}})
+	gobottest.Assert(t, cmds[4], tftCommand{cmd: ssd1351MuxRatio, data: []byte{95}})
+	gobottest.Assert(t, cmds[len(cmds)-4:], []tftCommand{
+		{cmd: ssd1351Remap, data: []byte{0x74}},
+		{cmd: ssd1351StartLine, data: []byte{96}},
+		{cmd: ssd1351NormalDisplay},
+		{cmd: ssd1351DisplayOn},
+	})
+}
+
+func TestSSD1351DriverDisplay(t *testing.T) {
+	var tests = map[string]struct {
+		rotation TFTRotation
+		want     []tftCommand
+	}{
+		"rotation_0": {
+			rotation: TFTRotate0,
+			want: []tftCommand{
+				{cmd: ssd1351ColumnAddress, data: []byte{1, 2}},
+				{cmd: ssd1351RowAddress, data: []byte{3, 3}},
+				{cmd: ssd1351WriteRAM, data: []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+			},
+		},
+		"rotation_90": {
+			rotation: TFTRotate90,
+			want: []tftCommand{
+				{cmd: ssd1351ColumnAddress, data: []byte{3, 3}},
+				{cmd: ssd1351RowAddress, data: []byte{1, 2}},
+				{cmd: ssd1351WriteRAM, data: []byte{0xFF, 0xFF, 0xFF, 0xFF}},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, w := initTestTFTDriverWithStubbedAdaptor(newTestSSD1351(WithTFTRotation(tc.rotation)))
+			d.framebuffer.Commit(d.framebuffer.Bounds())
+			d.framebuffer.FillRect(image.Rect(1, 3, 3, 4), 0xFFFF)
+			// act
+			err := d.Display()
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, w.commands(), tc.want)
+		})
+	}
+}
+
+func TestSSD1351DriverScroll(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestSSD1351(WithTFTRotation(TFTRotate180), WithTFTBGR(true)))
+	// act & assert
+	gobottest.Assert(t, d.SetScrollArea(0, 0), nil)
+	gobottest.Assert(t, d.Scroll(-3), nil)
+	gobottest.Assert(t, d.SetRotation(TFTRotate0), nil)
+	gobottest.Assert(t, w.commands(), []tftCommand{
+		{cmd: ssd1351StartLine, data: []byte{125}},
+		{cmd: ssd1351Remap, data: []byte{0x70}},
+		{cmd: ssd1351StartLine, data: []byte{0}},
+	})
+	gobottest.Assert(t, d.SetScrollArea(10, 0), fmt.Errorf("fixed lines are not supported by %s", d.Name()))
+}
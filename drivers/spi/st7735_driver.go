@@ -0,0 +1,102 @@
+package spi
+
+import (
+	"time"
+
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	st7735FrameRateControl1  = 0xB1
+	st7735FrameRateControl2  = 0xB2
+	st7735FrameRateControl3  = 0xB3
+	st7735InversionControl   = 0xB4
+	st7735PowerControl1      = 0xC0
+	st7735PowerControl2      = 0xC1
+	st7735PowerControl3      = 0xC2
+	st7735PowerControl4      = 0xC3
+	st7735PowerControl5      = 0xC4
+	st7735VCOMControl1       = 0xC5
+	st7735GammaCorrectionPos = 0xE0
+	st7735GammaCorrectionNeg = 0xE1
+)
+
+var st7735Model = tftModel{
+	name:   "ST7735",
+	width:  128,
+	height: 160,
+	// TFA + VSA + BFA needs to be 162
+	memoryLines: 162,
+	columnCmd:   mipiColumnAddressSet,
+	rowCmd:      mipiRowAddressSet,
+	writeCmd:    mipiMemoryWrite,
+	initCommands: func(d *tftDriver) []tftCommand {
+		// according to the initialization of the Adafruit library for ST7735R
+		return []tftCommand{
+			{cmd: mipiSoftwareReset, delay: 150 * time.Millisecond},
+			{cmd: mipiSleepOut, delay: 500 * time.Millisecond},
+			{cmd: st7735FrameRateControl1, data: []byte{0x01, 0x2C, 0x2D}},
+			{cmd: st7735FrameRateControl2, data: []byte{0x01, 0x2C, 0x2D}},
+			{cmd: st7735FrameRateControl3, data: []byte{0x01, 0x2C, 0x2D, 0x01, 0x2C, 0x2D}},
+			{cmd: st7735InversionControl, data: []byte{0x07}},
+			{cmd: st7735PowerControl1, data: []byte{0xA2, 0x02, 0x84}},
+			{cmd: st7735PowerControl2, data: []byte{0xC5}},
+			{cmd: st7735PowerControl3, data: []byte{0x0A, 0x00}},
+			{cmd: st7735PowerControl4, data: []byte{0x8A, 0x2A}},
+			{cmd: st7735PowerControl5, data: []byte{0x8A, 0xEE}},
+			{cmd: st7735VCOMControl1, data: []byte{0x0E}},
+			{cmd: mipiPixelFormatSet, data: []byte{mipiPixelFormat16Bit}},
+			{cmd: st7735GammaCorrectionPos, data: []byte{0x02, 0x1C, 0x07, 0x12, 0x37, 0x32, 0x29, 0x2D, 0x29, 0x25,
+				0x2B, 0x39, 0x00, 0x01, 0x03, 0x10}},
+			{cmd: st7735GammaCorrectionNeg, data: []byte{0x03, 0x1D, 0x07, 0x06, 0x2E, 0x2C, 0x29, 0x2D, 0x2E, 0x2E,
+				0x37, 0x3F, 0x00, 0x00, 0x02, 0x10}},
+			{cmd: mipiNormalDisplayMode, delay: 10 * time.Millisecond},
+		}
+	},
+	rotation: func(d *tftDriver) []tftCommand {
+		return mipiRotation(d, [4]byte{
+			mipiMADCTLColumnOrder | mipiMADCTLRowOrder,
+			mipiMADCTLRowOrder | mipiMADCTLExchange,
+			0,
+			mipiMADCTLColumnOrder | mipiMADCTLExchange,
+		})
+	},
+	display:    mipiDisplay,
+	inversion:  mipiInversion,
+	scrollArea: mipiScrollArea,
+	scroll:     mipiScroll,
+}
+
+// ST7735Driver is a driver for colour TFT displays with the ST7735 controller. The default size is 128x160, other
+// variants need the options WithTFTSize() and WithTFTOffset(), e.g. 80x160 with offset 26, 1.
+//
+// The driver implements the draw.Image interface, the changed content is transferred by Display().
+//
+// Datasheet: https://www.displayfuture.com/Display/datasheet/controller/ST7735.pdf
+type ST7735Driver struct {
+	*tftDriver
+}
+
+// NewST7735Driver creates a new driver for a ST7735 colour display.
+//
+// Params:
+//      a Connector - the Adaptor to use with this Driver
+//      dcWriter gpio.DigitalWriter - the Adaptor to use for the data/command and reset pin
+//      dcPin string - the pin connected to the data/command input
+//
+// Optional params:
+//      spi.WithBusNumber(int):  bus to use with this driver
+//      spi.WithChipNumber(int): chip to use with this driver
+//      spi.WithMode(int):       mode to use with this driver
+//      spi.WithBitCount(int):   number of bits to use with this driver
+//      spi.WithSpeed(int64):    speed in Hz to use with this driver
+//      spi.WithTFTResetPin(string):    pin connected to the reset input
+//      spi.WithTFTSize(int, int):      width and height for rotation 0
+//      spi.WithTFTOffset(int, int):    position in the memory of the controller for rotation 0
+//      spi.WithTFTRotation(TFTRotation): rotation to apply on start
+//      spi.WithTFTInversion(bool):     invert the colours
+//      spi.WithTFTBGR(bool):           swap red and blue
+//
+func NewST7735Driver(a Connector, dcWriter gpio.DigitalWriter, dcPin string, options ...func(Config)) *ST7735Driver {
+	return &ST7735Driver{tftDriver: newTFTDriver(a, &st7735Model, dcWriter, dcPin, options...)}
+}
@@ -0,0 +1,543 @@
+package spi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"gobot.io/x/gobot/drivers/common/rgb565"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// TFTRotation is the clockwise rotation of the content of a colour display, done by the display controller.
+type TFTRotation int
+
+// Supported rotations
+const (
+	TFTRotate0 TFTRotation = iota
+	TFTRotate90
+	TFTRotate180
+	TFTRotate270
+)
+
+func (r TFTRotation) validate() error {
+	if r < TFTRotate0 || r > TFTRotate270 {
+		return fmt.Errorf("invalid rotation %d, valid values are TFTRotate0..TFTRotate270", r)
+	}
+	return nil
+}
+
+const (
+	// the default buffer size of the Linux spidev driver
+	tftMaxTransfer = 4096
+
+	tftResetTime = 10 * time.Millisecond
+
+	// MIPI DCS commands, used by ST7735 and ILI9341
+	mipiSoftwareReset         = 0x01
+	mipiSleepOut              = 0x11
+	mipiNormalDisplayMode     = 0x13
+	mipiInversionOff          = 0x20
+	mipiInversionOn           = 0x21
+	mipiDisplayOff            = 0x28
+	mipiDisplayOn             = 0x29
+	mipiColumnAddressSet      = 0x2A
+	mipiRowAddressSet         = 0x2B
+	mipiMemoryWrite           = 0x2C
+	mipiVerticalScrollDef     = 0x33
+	mipiMemoryAccessControl   = 0x36
+	mipiVerticalScrollAddress = 0x37
+	mipiPixelFormatSet        = 0x3A
+
+	mipiPixelFormat16Bit = 0x55
+	// bits of memory access control
+	mipiMADCTLRowOrder    = 0x80 // MY
+	mipiMADCTLColumnOrder = 0x40 // MX
+	mipiMADCTLExchange    = 0x20 // MV
+	mipiMADCTLBGR         = 0x08
+)
+
+// tftCommand is a command with its parameters, followed by an optional delay
+type tftCommand struct {
+	cmd   byte
+	data  []byte
+	delay time.Duration
+}
+
+// tftModel describes the differences of the colour display controllers
+type tftModel struct {
+	name   string
+	width  int // default width for rotation 0
+	height int // default height for rotation 0
+	// lines of the display memory, used for vertical scrolling
+	memoryLines int
+	// commands for column and row address and for writing the memory
+	columnCmd byte
+	rowCmd    byte
+	writeCmd  byte
+	// true for controllers with 8 bit addresses like SSD1351, MIPI DCS controllers use 16 bit addresses
+	byteAddresses bool
+	// true for controllers, which exchange the column and row addresses for rotation 90 and 270
+	exchangeAddresses bool
+	bgr               bool
+	initCommands      func(d *tftDriver) []tftCommand
+	rotation          func(d *tftDriver) []tftCommand
+	display           func(on bool) tftCommand
+	inversion         func(on bool) tftCommand
+	scrollArea        func(d *tftDriver, top, lines, bottom int) ([]tftCommand, error)
+	scroll            func(d *tftDriver, line int) tftCommand
+}
+
+// tftDriver is the common implementation of the colour display drivers ST7735, ILI9341 and SSD1351. The
+// drivers implement the draw.Image interface, the content is transferred by Display().
+type tftDriver struct {
+	*Driver
+	model       *tftModel
+	dcWriter    gpio.DigitalWriter
+	dcPin       string
+	rstPin      string
+	width       int // for rotation 0
+	height      int // for rotation 0
+	offsetX     int // for rotation 0
+	offsetY     int // for rotation 0
+	rotation    TFTRotation
+	inverted    bool
+	bgr         bool
+	scrollTop   int
+	scrollLines int
+	framebuffer *rgb565.Framebuffer
+}
+
+// tftConfigurer is implemented by all drivers, which embed the tftDriver, needed for the options
+type tftConfigurer interface {
+	tft() *tftDriver
+}
+
+func newTFTDriver(a Connector, model *tftModel, dcWriter gpio.DigitalWriter, dcPin string,
+	options ...func(Config)) *tftDriver {
+	d := &tftDriver{
+		Driver:      NewDriver(a, model.name),
+		model:       model,
+		dcWriter:    dcWriter,
+		dcPin:       dcPin,
+		width:       model.width,
+		height:      model.height,
+		bgr:         model.bgr,
+		scrollLines: model.memoryLines,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, option := range options {
+		option(d)
+	}
+	d.framebuffer = rgb565.NewFramebuffer(d.logicalSize())
+	return d
+}
+
+// WithTFTResetPin option sets the pin connected to the reset input of the display, the same digital writer is
+// used as for the data/command pin. Without this option, the reset pin needs to be tied high.
+func WithTFTResetPin(pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(tftConfigurer)
+		if ok {
+			d.tft().rstPin = pin
+		} else {
+			panic("unable to set reset pin for non colour display driver")
+		}
+	}
+}
+
+// WithTFTSize option sets the size of the display for rotation 0, e.g. for the different variants of ST7735.
+func WithTFTSize(width, height int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(tftConfigurer)
+		if ok {
+			d.tft().width = width
+			d.tft().height = height
+		} else {
+			panic("unable to set size for non colour display driver")
+		}
+	}
+}
+
+// WithTFTOffset option sets the position of the display in the memory of the controller for rotation 0, e.g. for
+// the different variants of ST7735.
+func WithTFTOffset(x, y int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(tftConfigurer)
+		if ok {
+			d.tft().offsetX = x
+			d.tft().offsetY = y
+		} else {
+			panic("unable to set offset for non colour display driver")
+		}
+	}
+}
+
+// WithTFTRotation option sets the rotation, which is applied on start. An invalid rotation lets the start fail.
+func WithTFTRotation(rotation TFTRotation) func(Config) {
+	return func(c Config) {
+		d, ok := c.(tftConfigurer)
+		if ok {
+			d.tft().rotation = rotation
+		} else {
+			panic("unable to set rotation for non colour display driver")
+		}
+	}
+}
+
+// WithTFTInversion option inverts the colours on start, needed by some displays with IPS panel.
+func WithTFTInversion(inverted bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(tftConfigurer)
+		if ok {
+			d.tft().inverted = inverted
+		} else {
+			panic("unable to set inversion for non colour display driver")
+		}
+	}
+}
+
+// WithTFTBGR option swaps the red and blue colour channels, needed by some panels.
+func WithTFTBGR(bgr bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(tftConfigurer)
+		if ok {
+			d.tft().bgr = bgr
+		} else {
+			panic("unable to set colour order for non colour display driver")
+		}
+	}
+}
+
+func (d *tftDriver) tft() *tftDriver { return d }
+
+// ColorModel implements the image.Image interface.
+func (d *tftDriver) ColorModel() color.Model { return rgb565.Model }
+
+// Bounds implements the image.Image interface, the size depends on the rotation.
+func (d *tftDriver) Bounds() image.Rectangle {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.framebuffer.Bounds()
+}
+
+// At implements the image.Image interface and returns the colour of the pixel in the framebuffer.
+func (d *tftDriver) At(x, y int) color.Color {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.framebuffer.Pixel(x, y)
+}
+
+// Set implements the draw.Image interface and sets the colour of the pixel in the framebuffer. The content is
+// transferred to the display by Display().
+func (d *tftDriver) Set(x, y int, c color.Color) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.framebuffer.Set(x, y, c)
+}
+
+// Framebuffer returns the framebuffer of the driver, which can be used for faster drawing. The framebuffer is
+// replaced on rotation.
+func (d *tftDriver) Framebuffer() *rgb565.Framebuffer {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.framebuffer
+}
+
+// Fill sets all pixels of the framebuffer to the colour.
+func (d *tftDriver) Fill(c color.Color) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.framebuffer.Fill(rgb565.FromColor(c))
+}
+
+// Clear sets all pixels of the framebuffer to black.
+func (d *tftDriver) Clear() {
+	d.Fill(rgb565.Black)
+}
+
+// Display transfers only the changed region of the framebuffer to the display.
+func (d *tftDriver) Display() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	r := d.framebuffer.Changed()
+	if r.Empty() {
+		return nil
+	}
+	if err := d.writeWindow(r, d.framebuffer.Bytes(r)); err != nil {
+		return err
+	}
+	d.framebuffer.Commit(r)
+	return nil
+}
+
+// DisplayImage draws the image at the given position into the framebuffer and transfers the changed region to
+// the display.
+func (d *tftDriver) DisplayImage(img image.Image, at image.Point) error {
+	d.mutex.Lock()
+	r := img.Bounds().Sub(img.Bounds().Min).Add(at).Intersect(d.framebuffer.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			d.framebuffer.Set(x, y, img.At(img.Bounds().Min.X+x-at.X, img.Bounds().Min.Y+y-at.Y))
+		}
+	}
+	d.mutex.Unlock()
+
+	return d.Display()
+}
+
+// Rotation returns the current rotation of the display.
+func (d *tftDriver) Rotation() TFTRotation {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.rotation
+}
+
+// SetRotation rotates the content of the display by the controller. The framebuffer is replaced by a new one
+// with the rotated size, so the content needs to be redrawn.
+func (d *tftDriver) SetRotation(rotation TFTRotation) error {
+	if err := rotation.validate(); err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.rotation = rotation
+	d.framebuffer = rgb565.NewFramebuffer(d.logicalSize())
+	return d.commands(d.model.rotation(d)...)
+}
+
+// On switches the display on.
+func (d *tftDriver) On() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.commands(d.model.display(true))
+}
+
+// Off switches the display off, the content of the display memory is kept.
+func (d *tftDriver) Off() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.commands(d.model.display(false))
+}
+
+// SetInversion inverts the colours of the display.
+func (d *tftDriver) SetInversion(inverted bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.inverted = inverted
+	return d.commands(d.model.inversion(inverted))
+}
+
+// SetScrollArea defines the area for hardware scrolling by the count of fixed lines at the top and the bottom
+// of the display memory. The lines are counted in the direction of rotation 0, independent of the current
+// rotation.
+func (d *tftDriver) SetScrollArea(top, bottom int) error {
+	lines := d.model.memoryLines - top - bottom
+	if top < 0 || bottom < 0 || lines < 1 {
+		return fmt.Errorf("invalid scroll area with %d top and %d bottom lines for %d lines", top, bottom,
+			d.model.memoryLines)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	cmds, err := d.model.scrollArea(d, top, lines, bottom)
+	if err != nil {
+		return err
+	}
+	d.scrollTop = top
+	d.scrollLines = lines
+	return d.commands(cmds...)
+}
+
+// Scroll scrolls the content of the scroll area by the given count of lines by the controller, without transfer
+// of the content. The position is absolute, 0 means not scrolled. The lines are counted in the direction of
+// rotation 0, independent of the current rotation.
+func (d *tftDriver) Scroll(lines int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.commands(d.model.scroll(d, lines))
+}
+
+// Reset resets the display by the reset pin, afterwards the driver needs to be started again.
+func (d *tftDriver) Reset() error {
+	if d.rstPin == "" {
+		return fmt.Errorf("no reset pin configured for %s", d.name)
+	}
+	for _, level := range []byte{1, 0, 1} {
+		if err := d.dcWriter.DigitalWrite(d.rstPin, level); err != nil {
+			return err
+		}
+		time.Sleep(tftResetTime)
+	}
+	return nil
+}
+
+func (d *tftDriver) initialize() error {
+	if err := d.rotation.validate(); err != nil {
+		return err
+	}
+	if d.rstPin != "" {
+		if err := d.Reset(); err != nil {
+			return err
+		}
+		// some controllers need up to 120ms after reset
+		time.Sleep(12 * tftResetTime)
+	}
+	if err := d.commands(d.model.initCommands(d)...); err != nil {
+		return err
+	}
+	if err := d.commands(d.model.rotation(d)...); err != nil {
+		return err
+	}
+	if err := d.commands(d.model.inversion(d.inverted)); err != nil {
+		return err
+	}
+	if err := d.commands(d.model.display(true)); err != nil {
+		return err
+	}
+	d.framebuffer.Invalidate()
+	return nil
+}
+
+func (d *tftDriver) shutdown() error {
+	if d.connection == nil {
+		return nil
+	}
+	return d.commands(d.model.display(false))
+}
+
+// logicalSize returns the size of the display for the current rotation
+func (d *tftDriver) logicalSize() (int, int) {
+	if d.rotation == TFTRotate90 || d.rotation == TFTRotate270 {
+		return d.height, d.width
+	}
+	return d.width, d.height
+}
+
+// offset returns the position of the display in the memory of the controller for the current rotation
+func (d *tftDriver) offset() (int, int) {
+	if d.rotation == TFTRotate90 || d.rotation == TFTRotate270 {
+		return d.offsetY, d.offsetX
+	}
+	return d.offsetX, d.offsetY
+}
+
+// writeWindow sets the address window to the rectangle and writes the pixel data
+func (d *tftDriver) writeWindow(r image.Rectangle, data []byte) error {
+	x, y := d.offset()
+	x0, x1 := r.Min.X+x, r.Max.X-1+x
+	y0, y1 := r.Min.Y+y, r.Max.Y-1+y
+	if d.model.exchangeAddresses && (d.rotation == TFTRotate90 || d.rotation == TFTRotate270) {
+		x0, x1, y0, y1 = y0, y1, x0, x1
+	}
+	var columns, rows []byte
+	if d.model.byteAddresses {
+		columns = []byte{byte(x0), byte(x1)}
+		rows = []byte{byte(y0), byte(y1)}
+	} else {
+		columns = []byte{byte(x0 >> 8), byte(x0), byte(x1 >> 8), byte(x1)}
+		rows = []byte{byte(y0 >> 8), byte(y0), byte(y1 >> 8), byte(y1)}
+	}
+	if err := d.commands(
+		tftCommand{cmd: d.model.columnCmd, data: columns},
+		tftCommand{cmd: d.model.rowCmd, data: rows},
+		tftCommand{cmd: d.model.writeCmd},
+	); err != nil {
+		return err
+	}
+	return d.data(data)
+}
+
+// commands sends the commands with its parameters to the controller
+func (d *tftDriver) commands(cmds ...tftCommand) error {
+	for _, c := range cmds {
+		if err := d.dcWriter.DigitalWrite(d.dcPin, 0); err != nil {
+			return err
+		}
+		if err := d.connection.WriteByte(c.cmd); err != nil {
+			return err
+		}
+		if len(c.data) > 0 {
+			if err := d.data(c.data); err != nil {
+				return err
+			}
+		}
+		if c.delay > 0 {
+			time.Sleep(c.delay)
+		}
+	}
+	return nil
+}
+
+// data sends the data in chunks, which fit into the buffer of the SPI driver
+func (d *tftDriver) data(data []byte) error {
+	if err := d.dcWriter.DigitalWrite(d.dcPin, 1); err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		n := len(data)
+		if n > tftMaxTransfer {
+			n = tftMaxTransfer
+		}
+		if err := d.connection.WriteBytes(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// scrollPosition returns the position in the scroll area for the given count of lines
+func (d *tftDriver) scrollPosition(lines int) int {
+	return ((lines % d.scrollLines) + d.scrollLines) % d.scrollLines
+}
+
+func mipiDisplay(on bool) tftCommand {
+	if on {
+		return tftCommand{cmd: mipiDisplayOn}
+	}
+	return tftCommand{cmd: mipiDisplayOff}
+}
+
+func mipiInversion(on bool) tftCommand {
+	if on {
+		return tftCommand{cmd: mipiInversionOn}
+	}
+	return tftCommand{cmd: mipiInversionOff}
+}
+
+// mipiRotation returns the memory access control command for the rotation with the given bits for each rotation
+func mipiRotation(d *tftDriver, bits [4]byte) []tftCommand {
+	madctl := bits[d.rotation&0x03]
+	if d.bgr {
+		madctl |= mipiMADCTLBGR
+	}
+	return []tftCommand{{cmd: mipiMemoryAccessControl, data: []byte{madctl}}}
+}
+
+func mipiScrollArea(d *tftDriver, top, lines, bottom int) ([]tftCommand, error) {
+	data := []byte{byte(top >> 8), byte(top), byte(lines >> 8), byte(lines), byte(bottom >> 8), byte(bottom)}
+	return []tftCommand{{cmd: mipiVerticalScrollDef, data: data}}, nil
+}
+
+func mipiScroll(d *tftDriver, lines int) tftCommand {
+	line := d.scrollTop + d.scrollPosition(lines)
+	return tftCommand{cmd: mipiVerticalScrollAddress, data: []byte{byte(line >> 8), byte(line)}}
+}
@@ -0,0 +1,318 @@
+package spi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/rgb565"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/system"
+)
+
+// this ensures that the implementation is based on spi.Driver, and provides draw.Image
+var _ gobot.Driver = (*ST7735Driver)(nil)
+var _ gobot.Driver = (*ILI9341Driver)(nil)
+var _ gobot.Driver = (*SSD1351Driver)(nil)
+var _ draw.Image = (*ST7735Driver)(nil)
+var _ draw.Image = (*ILI9341Driver)(nil)
+var _ draw.Image = (*SSD1351Driver)(nil)
+
+// tftTestDCWriter records the level of the data/command pin for each position of the written SPI data
+type tftTestDCWriter struct {
+	spi       *system.MockSpiAccess
+	dcChanges map[int]byte
+	resets    []byte
+	writeErr  error
+}
+
+func (w *tftTestDCWriter) DigitalWrite(pin string, level byte) error {
+	if w.writeErr != nil {
+		return w.writeErr
+	}
+	if pin == "rst" {
+		w.resets = append(w.resets, level)
+		return nil
+	}
+	w.dcChanges[len(w.spi.Written())] = level
+	return nil
+}
+
+// commands reconstructs the written commands with its parameters from the data/command level
+func (w *tftTestDCWriter) commands() []tftCommand {
+	var cmds []tftCommand
+	var level byte
+	for i, b := range w.spi.Written() {
+		if l, ok := w.dcChanges[i]; ok {
+			level = l
+		}
+		if level == 0 {
+			cmds = append(cmds, tftCommand{cmd: b})
+		} else if len(cmds) > 0 {
+			cmds[len(cmds)-1].data = append(cmds[len(cmds)-1].data, b)
+		}
+	}
+	return cmds
+}
+
+func (w *tftTestDCWriter) reset() {
+	w.spi.Reset()
+	w.dcChanges = make(map[int]byte)
+}
+
+func initTestTFTDriverWithStubbedAdaptor(create func(a Connector, w *tftTestDCWriter) *tftDriver) (*tftDriver,
+	*tftTestDCWriter) {
+	a := newSpiTestAdaptor()
+	w := &tftTestDCWriter{spi: a.spi, dcChanges: make(map[int]byte)}
+	d := create(a, w)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	w.reset()
+	return d, w
+}
+
+func newTestST7735(options ...func(Config)) func(a Connector, w *tftTestDCWriter) *tftDriver {
+	return func(a Connector, w *tftTestDCWriter) *tftDriver {
+		return NewST7735Driver(a, w, "dc", options...).tftDriver
+	}
+}
+
+func TestTFTDriverOptions(t *testing.T) {
+	// arrange & act
+	d := NewST7735Driver(newSpiTestAdaptor(), &tftTestDCWriter{}, "dc", WithTFTResetPin("rst"),
+		WithTFTSize(80, 160), WithTFTOffset(26, 1), WithTFTRotation(TFTRotate90), WithTFTInversion(true),
+		WithTFTBGR(true))
+	// assert
+	gobottest.Assert(t, d.Name()[:6], "ST7735")
+	gobottest.Assert(t, d.rstPin, "rst")
+	gobottest.Assert(t, d.inverted, true)
+	gobottest.Assert(t, d.bgr, true)
+	gobottest.Assert(t, d.Rotation(), TFTRotate90)
+	gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 160, 80))
+	x, y := d.offset()
+	gobottest.Assert(t, x, 1)
+	gobottest.Assert(t, y, 26)
+	gobottest.Assert(t, NewILI9341Driver(newSpiTestAdaptor(), &tftTestDCWriter{}, "dc").bgr, true)
+}
+
+func TestTFTDriverOptionPanic(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover(), "unable to set rotation for non colour display driver")
+	}()
+	WithTFTRotation(TFTRotate180)(NewDriver(newSpiTestAdaptor(), "SPI"))
+}
+
+func TestTFTDriverStart(t *testing.T) {
+	// arrange
+	a := newSpiTestAdaptor()
+	w := &tftTestDCWriter{spi: a.spi, dcChanges: make(map[int]byte)}
+	d := NewILI9341Driver(a, w, "dc", WithTFTResetPin("rst"), WithTFTInversion(true))
+	// act
+	err := d.Start()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, w.resets, []byte{1, 0, 1})
+	cmds := w.commands()
+	gobottest.Assert(t, cmds[0], tftCommand{cmd: mipiSoftwareReset})
+	gobottest.Assert(t, cmds[len(cmds)-3:], []tftCommand{
+		{cmd: mipiMemoryAccessControl, data: []byte{mipiMADCTLColumnOrder | mipiMADCTLBGR}},
+		{cmd: mipiInversionOn},
+		{cmd: mipiDisplayOn},
+	})
+	gobottest.Assert(t, d.Framebuffer().Changed(), image.Rect(0, 0, 240, 320))
+}
+
+func TestTFTDriverStartError(t *testing.T) {
+	// arrange
+	a := newSpiTestAdaptor()
+	d := NewST7735Driver(a, &tftTestDCWriter{spi: a.spi, writeErr: fmt.Errorf("write error")}, "dc")
+	// act & assert
+	gobottest.Assert(t, d.Start(), fmt.Errorf("write error"))
+}
+
+func TestTFTDriverHalt(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+	// act
+	err := d.Halt()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, w.commands(), []tftCommand{{cmd: mipiDisplayOff}})
+}
+
+func TestTFTDriverDisplay(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735(WithTFTOffset(2, 1)))
+	d.framebuffer.Commit(d.framebuffer.Bounds())
+	d.Set(3, 4, color.RGBA{R: 0xFF, A: 0xFF})
+	d.Set(4, 5, color.White)
+	// act
+	err := d.Display()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, w.commands(), []tftCommand{
+		{cmd: mipiColumnAddressSet, data: []byte{0x00, 5, 0x00, 6}},
+		{cmd: mipiRowAddressSet, data: []byte{0x00, 5, 0x00, 6}},
+		{cmd: mipiMemoryWrite, data: []byte{0xF8, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF}},
+	})
+	gobottest.Assert(t, d.At(3, 4), rgb565.Red)
+	gobottest.Assert(t, d.Framebuffer().Changed().Empty(), true)
+	// nothing changed
+	w.reset()
+	gobottest.Assert(t, d.Display(), nil)
+	gobottest.Assert(t, len(w.commands()), 0)
+}
+
+func TestTFTDriverDisplayChunks(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+	// act
+	err := d.Display()
+	// assert
+	gobottest.Assert(t, err, nil)
+	cmds := w.commands()
+	gobottest.Assert(t, len(cmds), 3)
+	gobottest.Assert(t, cmds[0].data, []byte{0x00, 0x00, 0x00, 127})
+	gobottest.Assert(t, cmds[1].data, []byte{0x00, 0x00, 0x00, 159})
+	gobottest.Assert(t, len(cmds[2].data), 128*160*2)
+}
+
+func TestTFTDriverDisplayImage(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+	d.framebuffer.Commit(d.framebuffer.Bounds())
+	img := image.NewRGBA(image.Rect(10, 10, 12, 11))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{B: 0xFF, A: 0xFF}), image.Point{}, draw.Src)
+	// act
+	err := d.DisplayImage(img, image.Pt(127, 0))
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, w.commands(), []tftCommand{
+		{cmd: mipiColumnAddressSet, data: []byte{0x00, 127, 0x00, 127}},
+		{cmd: mipiRowAddressSet, data: []byte{0x00, 0, 0x00, 0}},
+		{cmd: mipiMemoryWrite, data: []byte{0x00, 0x1F}},
+	})
+}
+
+func TestTFTDriverSetRotation(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735(WithTFTBGR(true)))
+	// act
+	err := d.SetRotation(TFTRotate270)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, w.commands(), []tftCommand{
+		{cmd: mipiMemoryAccessControl, data: []byte{mipiMADCTLColumnOrder | mipiMADCTLExchange | mipiMADCTLBGR}},
+	})
+	gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 160, 128))
+	gobottest.Assert(t, d.Framebuffer().Changed(), image.Rect(0, 0, 160, 128))
+}
+
+func TestTFTDriverInvalidRotation(t *testing.T) {
+	var tests = map[string]struct {
+		rotation TFTRotation
+	}{
+		"negative":  {rotation: TFTRotation(-1)},
+		"too_large": {rotation: TFTRotation(7)},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+			wantErr := fmt.Errorf("invalid rotation %d, valid values are TFTRotate0..TFTRotate270", tc.rotation)
+			// act
+			err := d.SetRotation(tc.rotation)
+			// assert
+			gobottest.Assert(t, err, wantErr)
+			gobottest.Assert(t, len(w.commands()), 0)
+			gobottest.Assert(t, d.Rotation(), TFTRotate0)
+			gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 128, 160))
+			// act & assert: option lets the start fail
+			a := newSpiTestAdaptor()
+			st := NewST7735Driver(a, &tftTestDCWriter{spi: a.spi, dcChanges: make(map[int]byte)}, "dc",
+				WithTFTRotation(tc.rotation))
+			gobottest.Assert(t, st.Start(), wantErr)
+		})
+	}
+}
+
+func TestTFTDriverOnOffInversion(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+	// act
+	gobottest.Assert(t, d.Off(), nil)
+	gobottest.Assert(t, d.On(), nil)
+	gobottest.Assert(t, d.SetInversion(true), nil)
+	// assert
+	gobottest.Assert(t, w.commands(), []tftCommand{{cmd: mipiDisplayOff}, {cmd: mipiDisplayOn}, {cmd: mipiInversionOn}})
+}
+
+func TestTFTDriverScroll(t *testing.T) {
+	var tests = map[string]struct {
+		top      int
+		bottom   int
+		lines    int
+		want     []tftCommand
+		wantErr  error
+		wantArea bool
+	}{
+		"full_area": {
+			lines: 10,
+			want: []tftCommand{
+				{cmd: mipiVerticalScrollDef, data: []byte{0, 0, 0, 162, 0, 0}},
+				{cmd: mipiVerticalScrollAddress, data: []byte{0, 10}},
+			},
+		},
+		"fixed_lines": {
+			top:    20,
+			bottom: 2,
+			lines:  -1,
+			want: []tftCommand{
+				{cmd: mipiVerticalScrollDef, data: []byte{0, 20, 0, 140, 0, 2}},
+				{cmd: mipiVerticalScrollAddress, data: []byte{0, 159}},
+			},
+		},
+		"wrap": {
+			top:   100,
+			lines: 70,
+			want: []tftCommand{
+				{cmd: mipiVerticalScrollDef, data: []byte{0, 100, 0, 62, 0, 0}},
+				{cmd: mipiVerticalScrollAddress, data: []byte{0, 108}},
+			},
+		},
+		"error": {
+			top:     100,
+			bottom:  62,
+			wantErr: fmt.Errorf("invalid scroll area with 100 top and 62 bottom lines for 162 lines"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+			// act
+			err := d.SetScrollArea(tc.top, tc.bottom)
+			if err == nil {
+				err = d.Scroll(tc.lines)
+			}
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			gobottest.Assert(t, w.commands(), tc.want)
+		})
+	}
+}
+
+func TestTFTDriverReset(t *testing.T) {
+	// arrange
+	d, w := initTestTFTDriverWithStubbedAdaptor(newTestST7735(WithTFTResetPin("rst")))
+	w.resets = nil
+	// act & assert
+	gobottest.Assert(t, d.Reset(), nil)
+	gobottest.Assert(t, w.resets, []byte{1, 0, 1})
+	d, _ = initTestTFTDriverWithStubbedAdaptor(newTestST7735())
+	gobottest.Assert(t, d.Reset(), fmt.Errorf("no reset pin configured for %s", d.Name()))
+}
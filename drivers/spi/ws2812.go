@@ -0,0 +1,101 @@
+package spi
+
+import (
+	"image/color"
+)
+
+const (
+	// ws2812SpiSpeed results in 3 SPI bits per WS2812 bit with ~417ns each
+	ws2812SpiSpeed = 2400000
+	// ws2812Bit0 and ws2812Bit1 are the SPI patterns for one WS2812 bit (short/long high pulse)
+	ws2812Bit0 = 0x4 // 100
+	ws2812Bit1 = 0x6 // 110
+	// ws2812ResetBytes keeps MOSI low for >280us to latch the data (also for newer WS2812B)
+	ws2812ResetBytes = 84
+)
+
+// WS2812Driver is a driver for WS2812 (NeoPixel) and compatible LEDs like SK6812, which have a single
+// wire interface. The timing of this interface is generated by the MOSI line of a SPI bus, so only this
+// pin needs to be connected to the data input of the strip.
+type WS2812Driver struct {
+	*Driver
+	vals       []color.RGBA
+	brightness uint8
+}
+
+// NewWS2812Driver creates a new Gobot Driver for WS2812 RGB LEDs. The speed of the SPI bus is
+// set to 2.4MHz, which is needed for the timing of the encoded bits.
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver.
+//      count int - how many LEDs are in the array controlled by this driver.
+//
+// Optional params:
+//      spi.WithBusNumber(int):  bus to use with this driver.
+//      spi.WithChipNumber(int): chip to use with this driver.
+//
+func NewWS2812Driver(a Connector, count int, options ...func(Config)) *WS2812Driver {
+	d := &WS2812Driver{
+		Driver:     NewDriver(a, "WS2812"),
+		vals:       make([]color.RGBA, count),
+		brightness: 255,
+	}
+	for _, option := range options {
+		option(d)
+	}
+	d.SetMode(0)
+	d.SetBitCount(8)
+	d.SetSpeed(ws2812SpiSpeed)
+	return d
+}
+
+// Len returns the number of LEDs in the array.
+func (d *WS2812Driver) Len() int {
+	return len(d.vals)
+}
+
+// SetRGBA sets the ith LED's color to the given RGBA value. The alpha value is ignored.
+// A subsequent call to Draw is required to transmit values to the LED strip.
+func (d *WS2812Driver) SetRGBA(i int, v color.RGBA) {
+	d.vals[i] = v
+}
+
+// SetBrightness sets the brightness for all LEDs, which scales the color values (0..255).
+func (d *WS2812Driver) SetBrightness(b uint8) {
+	d.brightness = b
+}
+
+// Brightness return driver brightness value.
+func (d *WS2812Driver) Brightness() uint8 {
+	return d.brightness
+}
+
+// Draw displays the RGBA values set on the actual LED strip.
+func (d *WS2812Driver) Draw() error {
+	return d.connection.WriteBytes(d.encode())
+}
+
+// encode converts the colors to the SPI data stream, the LEDs expect the order green, red, blue
+func (d *WS2812Driver) encode() []byte {
+	// 24 bits per LED, 3 SPI bits each
+	tx := make([]byte, len(d.vals)*9+ws2812ResetBytes)
+	pos := 0
+	for _, c := range d.vals {
+		for _, v := range []uint8{c.G, c.R, c.B} {
+			v = uint8(uint16(v) * (uint16(d.brightness) + 1) >> 8)
+			for bit := 7; bit >= 0; bit-- {
+				pattern := ws2812Bit0
+				if v&(1<<uint(bit)) != 0 {
+					pattern = ws2812Bit1
+				}
+				for k := 2; k >= 0; k-- {
+					if pattern&(1<<uint(k)) != 0 {
+						tx[pos/8] |= 0x80 >> uint(pos%8)
+					}
+					pos++
+				}
+			}
+		}
+	}
+	return tx
+}
@@ -0,0 +1,67 @@
+package spi
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on spi.Driver, which implements the gobot.Driver
+// and tests all implementations, so no further tests needed here for gobot.Driver interface
+var _ gobot.Driver = (*WS2812Driver)(nil)
+
+func initTestWS2812DriverWithStubbedAdaptor() (*WS2812Driver, *spiTestAdaptor) {
+	a := newSpiTestAdaptor()
+	d := NewWS2812Driver(a, 2)
+	if err := d.Start(); err != nil {
+		panic(err)
+	}
+	return d, a
+}
+
+func TestNewWS2812Driver(t *testing.T) {
+	var di interface{} = NewWS2812Driver(newSpiTestAdaptor(), 10, WithSpeed(1000), WithBusNumber(2))
+	d, ok := di.(*WS2812Driver)
+	if !ok {
+		t.Errorf("NewWS2812Driver() should have returned a *WS2812Driver")
+	}
+	gobottest.Refute(t, d.Driver, nil)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "WS2812"), true)
+	gobottest.Assert(t, d.Len(), 10)
+	gobottest.Assert(t, d.Brightness(), uint8(255))
+	gobottest.Assert(t, d.GetSpeedOrDefault(0), int64(2400000))
+	gobottest.Assert(t, d.GetBusNumberOrDefault(0), 2)
+}
+
+func TestWS2812DriverDraw(t *testing.T) {
+	d, a := initTestWS2812DriverWithStubbedAdaptor()
+	d.SetRGBA(0, color.RGBA{R: 0xff, G: 0x00, B: 0x80})
+	d.SetRGBA(1, color.RGBA{R: 0x00, G: 0x01, B: 0x00})
+
+	gobottest.Assert(t, d.Draw(), nil)
+	tx := a.spi.Written()
+	gobottest.Assert(t, len(tx), 2*9+84)
+	// green 0x00: 100 100 100 100 100 100 100 100
+	gobottest.Assert(t, tx[0:3], []byte{0x92, 0x49, 0x24})
+	// red 0xff: 110 110 110 110 110 110 110 110
+	gobottest.Assert(t, tx[3:6], []byte{0xdb, 0x6d, 0xb6})
+	// blue 0x80: 110 100 100 100 100 100 100 100
+	gobottest.Assert(t, tx[6:9], []byte{0xd2, 0x49, 0x24})
+	// green 0x01 of 2nd LED: 100 100 100 100 100 100 100 110
+	gobottest.Assert(t, tx[9:12], []byte{0x92, 0x49, 0x26})
+	// reset
+	gobottest.Assert(t, tx[18:], make([]byte, 84))
+}
+
+func TestWS2812DriverBrightness(t *testing.T) {
+	d, a := initTestWS2812DriverWithStubbedAdaptor()
+	d.SetRGBA(0, color.RGBA{R: 0xff})
+	d.SetBrightness(0)
+
+	gobottest.Assert(t, d.Draw(), nil)
+	// red is off now
+	gobottest.Assert(t, a.spi.Written()[3:6], []byte{0x92, 0x49, 0x24})
+}
@@ -0,0 +1,93 @@
+package adaptors
+
+import (
+	"fmt"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"gobot.io/x/gobot/drivers/onewire"
+	"gobot.io/x/gobot/system"
+)
+
+// OneWireBusAdaptor is a adaptor for the 1-wire bus of the Linux w1 subsystem, normally used for composition in
+// platforms.
+type OneWireBusAdaptor struct {
+	sys         *system.Accesser
+	mutex       sync.Mutex
+	connections map[onewire.ROM]onewire.Connection
+}
+
+// NewOneWireBusAdaptor provides the access to 1-wire devices of the board, which are detected by the Kernel.
+func NewOneWireBusAdaptor(sys *system.Accesser) *OneWireBusAdaptor {
+	a := &OneWireBusAdaptor{sys: sys}
+	return a
+}
+
+// Connect prepares the connection to 1-wire devices.
+func (a *OneWireBusAdaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.connections = make(map[onewire.ROM]onewire.Connection)
+	return nil
+}
+
+// Finalize closes all 1-wire connections.
+func (a *OneWireBusAdaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for _, con := range a.connections {
+		if con != nil {
+			if e := con.Close(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+	a.connections = nil
+	return err
+}
+
+// GetOneWireConnection returns a connection to the 1-wire device with the given ROM code.
+func (a *OneWireBusAdaptor) GetOneWireConnection(rom onewire.ROM) (onewire.Connection, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.connections == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	con := a.connections[rom]
+	if con == nil {
+		dev, err := a.sys.NewOneWireDevice(rom.String())
+		if err != nil {
+			return nil, err
+		}
+		if con, err = onewire.NewSystemConnection(dev); err != nil {
+			return nil, err
+		}
+		a.connections[rom] = con
+	}
+
+	return con, nil
+}
+
+// FindOneWireDevices returns the ROM codes of all devices, which are detected by the given bus master of the Kernel,
+// the first bus master has the number 1.
+func (a *OneWireBusAdaptor) FindOneWireDevices(busNum int) ([]onewire.ROM, error) {
+	ids, err := a.sys.FindOneWireDevices(busNum)
+	if err != nil {
+		return nil, err
+	}
+
+	var roms []onewire.ROM
+	for _, id := range ids {
+		rom, err := onewire.ParseROM(id)
+		if err != nil {
+			return nil, err
+		}
+		roms = append(roms, rom)
+	}
+	return roms, nil
+}
@@ -0,0 +1,91 @@
+package adaptors
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/drivers/onewire"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/system"
+)
+
+// make sure that this OneWireBusAdaptor fulfills all the required interfaces
+var _ onewire.Connector = (*OneWireBusAdaptor)(nil)
+
+const (
+	oneWireTestDevicePath = "/sys/bus/w1/devices/28-0000075f5ef1"
+	oneWireTestSlavesFile = "/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves"
+)
+
+func initTestOneWireBusAdaptorWithMockedFilesystem() (*OneWireBusAdaptor, *system.MockFilesystem) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem([]string{oneWireTestDevicePath + "/w1_slave", oneWireTestSlavesFile})
+	a := NewOneWireBusAdaptor(sys)
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, fs
+}
+
+func TestNewOneWireBusAdaptor(t *testing.T) {
+	// arrange
+	a := NewOneWireBusAdaptor(nil)
+	// act
+	_, err := a.GetOneWireConnection(onewire.NewROM(0x28, 0x075f5ef1))
+	// assert
+	gobottest.Assert(t, err.Error(), "not connected")
+}
+
+func TestGetOneWireConnection(t *testing.T) {
+	// arrange
+	a, fs := initTestOneWireBusAdaptorWithMockedFilesystem()
+	fs.Files[oneWireTestDevicePath+"/w1_slave"].Contents = "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n"
+	rom := onewire.NewROM(0x28, 0x075f5ef1)
+	// act
+	con1, err1 := a.GetOneWireConnection(rom)
+	con2, err2 := a.GetOneWireConnection(rom)
+	// assert
+	gobottest.Assert(t, err1, nil)
+	gobottest.Assert(t, err2, nil)
+	gobottest.Assert(t, con1, con2)
+	gobottest.Assert(t, len(a.connections), 1)
+	gobottest.Assert(t, con1.ROM(), rom)
+	content, err := con1.(onewire.AttributeAccessor).ReadAttribute("w1_slave")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, string(content), "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n")
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, a.connections == nil, true)
+}
+
+func TestGetOneWireConnectionNotFound(t *testing.T) {
+	// arrange
+	a, _ := initTestOneWireBusAdaptorWithMockedFilesystem()
+	// act
+	con, err := a.GetOneWireConnection(onewire.NewROM(0x28, 0x0a1b2c3d))
+	// assert
+	gobottest.Refute(t, err, nil)
+	gobottest.Assert(t, con, nil)
+	gobottest.Assert(t, len(a.connections), 0)
+}
+
+func TestFindOneWireDevices(t *testing.T) {
+	// arrange
+	a, fs := initTestOneWireBusAdaptorWithMockedFilesystem()
+	fs.Files[oneWireTestSlavesFile].Contents = "28-0000075f5ef1\n22-00000a1b2c3d\n"
+	// act
+	roms, err := a.FindOneWireDevices(1)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, roms, []onewire.ROM{onewire.NewROM(0x28, 0x075f5ef1), onewire.NewROM(0x22, 0x0a1b2c3d)})
+}
+
+func TestFindOneWireDevicesError(t *testing.T) {
+	// arrange
+	a, fs := initTestOneWireBusAdaptorWithMockedFilesystem()
+	fs.Files[oneWireTestSlavesFile].Contents = "28-0000075f5ef1\nxx-00000a1b2c3d\n"
+	// act
+	_, err := a.FindOneWireDevices(1)
+	_, errBus := a.FindOneWireDevices(2)
+	// assert
+	gobottest.Refute(t, err, nil)
+	gobottest.Refute(t, errBus, nil)
+}
@@ -34,6 +34,7 @@ type Adaptor struct {
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.OneWireBusAdaptor
 	spiDefaultMaxSpeed int64
 	PiBlasterPeriod    uint32
 }
@@ -59,6 +60,7 @@ func NewAdaptor(opts ...func(adaptors.Optioner)) *Adaptor {
 	c.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, c.validateI2cBusNumber, 1)
 	c.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, c.validateSpiBusNumber, defaultSpiBusNumber, defaultSpiChipNumber,
 		defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed)
+	c.OneWireBusAdaptor = adaptors.NewOneWireBusAdaptor(sys)
 	return c
 }
 
@@ -91,6 +93,10 @@ func (c *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := c.OneWireBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	c.pwmPins = make(map[string]gobot.PWMPinner)
 	return c.DigitalPinsAdaptor.Connect()
 }
@@ -118,6 +124,10 @@ func (c *Adaptor) Finalize() error {
 	if e := c.SpiBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
+
+	if e := c.OneWireBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
 	return err
 }
 
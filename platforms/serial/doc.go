@@ -0,0 +1,14 @@
+/*
+Package serial provides the Gobot adaptor for serial ports (UART), e.g. USB to serial converters or the UART of
+a single board computer.
+
+It also includes drivers for several serial peripherals:
+
+- NMEA GPS modules
+- PMS5003 particulate matter sensor
+- MH-Z19 CO2 sensor
+
+For more information refer to the README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/serial/README.md
+*/
+package serial // import "gobot.io/x/gobot/platforms/serial"
@@ -0,0 +1,310 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// GPSPositionEvent is published with a GPSPosition on each valid GGA sentence
+	GPSPositionEvent = "position"
+	// GPSVelocityEvent is published with a GPSVelocity on each valid RMC sentence
+	GPSVelocityEvent = "velocity"
+	// GPSTimeEvent is published with the UTC time.Time on each valid RMC sentence
+	GPSTimeEvent = "time"
+
+	knotsToMeterPerSecond = 1852.0 / 3600.0
+)
+
+// GPSPosition contains the position of a GGA sentence
+type GPSPosition struct {
+	// Latitude in degrees, negative values are south
+	Latitude float64
+	// Longitude in degrees, negative values are west
+	Longitude float64
+	// Altitude above mean sea level in meters
+	Altitude float64
+	// Quality of the fix, 1 = GPS, 2 = DGPS, 4 = RTK fixed, 5 = RTK float
+	Quality int
+	// Satellites in use
+	Satellites int
+	// HDOP is the horizontal dilution of precision
+	HDOP float64
+}
+
+// GPSVelocity contains the velocity of a RMC sentence
+type GPSVelocity struct {
+	// Speed over ground in m/s
+	Speed float64
+	// Course over ground in degrees true
+	Course float64
+}
+
+// GPSDriver is a driver for GPS modules with NMEA 0183 output, e.g. u-blox NEO-6M or NEO-M8N. The sentences
+// GGA and RMC are evaluated for all talkers (GP, GN, GL, GA, BD).
+type GPSDriver struct {
+	name       string
+	connection SerialConnector
+	lineReader *LineReader
+	position   GPSPosition
+	velocity   GPSVelocity
+	time       time.Time
+	halt       chan bool
+	done       chan bool
+	mutex      sync.Mutex
+	gobot.Eventer
+}
+
+// NewGPSDriver creates a new driver for a NMEA GPS module. The common baud rate of the modules is 9600.
+//
+// Emits the Events:
+//	"position" - GPSPosition
+//	"velocity" - GPSVelocity
+//	"time" - time.Time
+//	"error" - error on reading or parsing a sentence
+func NewGPSDriver(a SerialConnector) *GPSDriver {
+	d := &GPSDriver{
+		name:       gobot.DefaultName("GPS"),
+		connection: a,
+		lineReader: NewLineReader(a),
+		Eventer:    gobot.NewEventer(),
+	}
+	d.AddEvent(GPSPositionEvent)
+	d.AddEvent(GPSVelocityEvent)
+	d.AddEvent(GPSTimeEvent)
+	d.AddEvent(Error)
+	return d
+}
+
+// Name returns the Driver name
+func (d *GPSDriver) Name() string { return d.name }
+
+// SetName sets the Driver name
+func (d *GPSDriver) SetName(n string) { d.name = n }
+
+// Connection returns the Driver's Connection to the associated Adaptor
+func (d *GPSDriver) Connection() gobot.Connection { return d.connection }
+
+// Start starts reading the sentences
+func (d *GPSDriver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.halt != nil {
+		return nil
+	}
+	d.halt = make(chan bool)
+	d.done = make(chan bool)
+	go d.readLoop(d.halt, d.done)
+	return nil
+}
+
+// Halt stops reading the sentences
+func (d *GPSDriver) Halt() error {
+	d.mutex.Lock()
+	halt, done := d.halt, d.done
+	d.halt = nil
+	d.mutex.Unlock()
+
+	if halt == nil {
+		return nil
+	}
+	close(halt)
+	<-done
+	return nil
+}
+
+// Position returns the last received position
+func (d *GPSDriver) Position() GPSPosition {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.position
+}
+
+// Velocity returns the last received velocity
+func (d *GPSDriver) Velocity() GPSVelocity {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.velocity
+}
+
+// Time returns the last received UTC time, zero if not received yet
+func (d *GPSDriver) Time() time.Time {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.time
+}
+
+func (d *GPSDriver) readLoop(halt chan bool, done chan bool) {
+	defer close(done)
+	for {
+		select {
+		case <-halt:
+			return
+		default:
+		}
+		line, err := d.lineReader.ReadLine(loopReadTimeout)
+		if err != nil {
+			if err == ErrTimeout {
+				continue
+			}
+			if err != ErrDisconnected {
+				d.Publish(Error, err)
+			}
+			// avoid a busy loop while disconnected
+			time.Sleep(loopReadTimeout)
+			continue
+		}
+		if err := d.handleSentence(line); err != nil {
+			d.Publish(Error, err)
+		}
+	}
+}
+
+func (d *GPSDriver) handleSentence(line string) error {
+	fields, err := splitNMEA(line)
+	if err != nil || fields == nil {
+		return err
+	}
+	if len(fields[0]) != 5 {
+		return nil
+	}
+	switch fields[0][2:] {
+	case "GGA":
+		return d.handleGGA(fields)
+	case "RMC":
+		return d.handleRMC(fields)
+	}
+	return nil
+}
+
+// handleGGA evaluates e.g. "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47"
+func (d *GPSDriver) handleGGA(fields []string) error {
+	if len(fields) < 10 {
+		return fmt.Errorf("GGA sentence has only %d fields", len(fields))
+	}
+	quality, _ := strconv.Atoi(fields[6])
+	if quality == 0 {
+		// no fix
+		return nil
+	}
+	lat, err := parseNMEACoordinate(fields[2], fields[3])
+	if err != nil {
+		return err
+	}
+	lon, err := parseNMEACoordinate(fields[4], fields[5])
+	if err != nil {
+		return err
+	}
+	p := GPSPosition{Latitude: lat, Longitude: lon, Quality: quality}
+	p.Satellites, _ = strconv.Atoi(fields[7])
+	p.HDOP, _ = strconv.ParseFloat(fields[8], 64)
+	p.Altitude, _ = strconv.ParseFloat(fields[9], 64)
+
+	d.mutex.Lock()
+	d.position = p
+	d.mutex.Unlock()
+
+	d.Publish(GPSPositionEvent, p)
+	return nil
+}
+
+// handleRMC evaluates e.g. "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A"
+func (d *GPSDriver) handleRMC(fields []string) error {
+	if len(fields) < 10 {
+		return fmt.Errorf("RMC sentence has only %d fields", len(fields))
+	}
+	if fields[2] != "A" {
+		// no valid fix
+		return nil
+	}
+	t, err := parseNMEATime(fields[9], fields[1])
+	if err != nil {
+		return err
+	}
+	v := GPSVelocity{}
+	if knots, err := strconv.ParseFloat(fields[7], 64); err == nil {
+		v.Speed = knots * knotsToMeterPerSecond
+	}
+	v.Course, _ = strconv.ParseFloat(fields[8], 64)
+
+	d.mutex.Lock()
+	d.velocity = v
+	d.time = t
+	d.mutex.Unlock()
+
+	d.Publish(GPSVelocityEvent, v)
+	d.Publish(GPSTimeEvent, t)
+	return nil
+}
+
+// splitNMEA verifies the checksum and returns the fields of the sentence, the first field is the address without "$"
+// e.g. "GPGGA". Returns nil for lines which are not a sentence.
+func splitNMEA(line string) ([]string, error) {
+	start := strings.IndexByte(line, '$')
+	if start < 0 {
+		return nil, nil
+	}
+	line = line[start+1:]
+	if idx := strings.IndexByte(line, '*'); idx >= 0 {
+		want, err := strconv.ParseUint(line[idx+1:], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NMEA checksum '%s'", line[idx+1:])
+		}
+		line = line[:idx]
+		var sum byte
+		for i := 0; i < len(line); i++ {
+			sum ^= line[i]
+		}
+		if sum != byte(want) {
+			return nil, fmt.Errorf("NMEA checksum mismatch for '%s', got 0x%02X, want 0x%02X", line, sum, want)
+		}
+	}
+	return strings.Split(line, ","), nil
+}
+
+// parseNMEACoordinate converts e.g. "4807.038", "N" to 48.1173
+func parseNMEACoordinate(value string, hemisphere string) (float64, error) {
+	if value == "" {
+		return 0, errors.New("empty NMEA coordinate")
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid NMEA coordinate '%s'", value)
+	}
+	deg := math.Floor(v / 100)
+	deg += (v - deg*100) / 60
+	switch hemisphere {
+	case "N", "E":
+		return deg, nil
+	case "S", "W":
+		return -deg, nil
+	}
+	return 0, fmt.Errorf("invalid NMEA hemisphere '%s'", hemisphere)
+}
+
+// parseNMEATime converts e.g. "230394", "123519.00" to the UTC time
+func parseNMEATime(date string, clock string) (time.Time, error) {
+	if len(date) != 6 || len(clock) < 6 {
+		return time.Time{}, fmt.Errorf("invalid NMEA date '%s' or time '%s'", date, clock)
+	}
+	layout := "020106150405"
+	if len(clock) > 6 {
+		layout += clock[6:7] + strings.Repeat("0", len(clock)-7)
+	}
+	t, err := time.Parse(layout, date+clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid NMEA date '%s' or time '%s'", date, clock)
+	}
+	return t, nil
+}
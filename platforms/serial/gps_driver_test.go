@@ -0,0 +1,152 @@
+package serial
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.Driver, which makes it possible to use it in a robot
+var _ gobot.Driver = (*GPSDriver)(nil)
+
+func nmeaSentence(body string) string {
+	var sum byte
+	for i := 0; i < len(body); i++ {
+		sum ^= body[i]
+	}
+	return fmt.Sprintf("$%s*%02X\r\n", body, sum)
+}
+
+func TestNewGPSDriver(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithMockPort()
+	// act
+	d := NewGPSDriver(a)
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "GPS"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Time().IsZero(), true)
+	d.SetName("myGPS")
+	gobottest.Assert(t, d.Name(), "myGPS")
+}
+
+func TestGPSDriverEvents(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	d := NewGPSDriver(a)
+	positions := make(chan GPSPosition, 1)
+	velocities := make(chan GPSVelocity, 1)
+	times := make(chan time.Time, 1)
+	_ = d.On(GPSPositionEvent, func(data interface{}) { positions <- data.(GPSPosition) })
+	_ = d.On(GPSVelocityEvent, func(data interface{}) { velocities <- data.(GPSVelocity) })
+	_ = d.On(GPSTimeEvent, func(data interface{}) { times <- data.(time.Time) })
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	// act
+	mp.Simulate([]byte("garbage from startup\r\n"))
+	mp.Simulate([]byte(nmeaSentence("GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00")))
+	mp.Simulate([]byte(nmeaSentence("GPGGA,123519,4807.038,N,01131.000,W,1,08,0.9,545.4,M,46.9,M,,")))
+	mp.Simulate([]byte(nmeaSentence("GNRMC,123519.50,A,4807.038,S,01131.000,E,022.4,084.4,230394,003.1,W")))
+	// assert
+	select {
+	case p := <-positions:
+		gobottest.Assert(t, math.Abs(p.Latitude-48.1173) < 1e-9, true)
+		gobottest.Assert(t, math.Abs(p.Longitude+11.516666666) < 1e-6, true)
+		gobottest.Assert(t, p.Altitude, 545.4)
+		gobottest.Assert(t, p.Quality, 1)
+		gobottest.Assert(t, p.Satellites, 8)
+		gobottest.Assert(t, p.HDOP, 0.9)
+		gobottest.Assert(t, d.Position(), p)
+	case <-time.After(time.Second):
+		t.Fatalf("position event was not published")
+	}
+	select {
+	case v := <-velocities:
+		gobottest.Assert(t, math.Abs(v.Speed-11.523555555) < 1e-6, true)
+		gobottest.Assert(t, v.Course, 84.4)
+		gobottest.Assert(t, d.Velocity(), v)
+	case <-time.After(time.Second):
+		t.Fatalf("velocity event was not published")
+	}
+	select {
+	case tm := <-times:
+		gobottest.Assert(t, tm, time.Date(1994, 3, 23, 12, 35, 19, 500000000, time.UTC))
+		gobottest.Assert(t, d.Time(), tm)
+	case <-time.After(time.Second):
+		t.Fatalf("time event was not published")
+	}
+}
+
+func TestGPSDriverChecksumError(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	d := NewGPSDriver(a)
+	errs := make(chan error, 1)
+	_ = d.Once(Error, func(data interface{}) { errs <- data.(error) })
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	// act
+	mp.Simulate([]byte("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48\r\n"))
+	// assert
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, strings.Contains(err.Error(), "NMEA checksum mismatch"), true)
+	case <-time.After(time.Second):
+		t.Fatalf("error event was not published")
+	}
+}
+
+func TestGPSDriverHandleSentence(t *testing.T) {
+	var tests = map[string]struct {
+		sentence string
+		wantErr  string
+		wantPos  GPSPosition
+	}{
+		"no_fix": {
+			sentence: "$GPGGA,123519,,,,,0,00,,,M,,M,,*6B",
+		},
+		"rmc_void": {
+			sentence: "$GPRMC,123519,V,,,,,,,230394,,*33",
+		},
+		"unknown_sentence": {
+			sentence: "$GPVTG,054.7,T,034.4,M,005.5,N,010.2,K*48",
+		},
+		"without_checksum": {
+			sentence: "$GPGGA,123519,0030.000,N,00015.000,E,2,05,1.5,10.0,M,,M,,",
+			wantPos:  GPSPosition{Latitude: 0.5, Longitude: 0.25, Altitude: 10, Quality: 2, Satellites: 5, HDOP: 1.5},
+		},
+		"too_few_fields": {
+			sentence: "$GPGGA,123519,4807.038",
+			wantErr:  "GGA sentence has only 3 fields",
+		},
+		"invalid_hemisphere": {
+			sentence: "$GPGGA,123519,4807.038,X,01131.000,E,1,08,0.9,545.4,M,46.9,M,,",
+			wantErr:  "invalid NMEA hemisphere 'X'",
+		},
+		"invalid_date": {
+			sentence: "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,2303,003.1,W",
+			wantErr:  "invalid NMEA date '2303' or time '123519'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, _ := initTestAdaptorWithMockPort()
+			d := NewGPSDriver(a)
+			// act
+			err := d.handleSentence(tc.sentence)
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+			}
+			gobottest.Assert(t, d.Position(), tc.wantPos)
+		})
+	}
+}
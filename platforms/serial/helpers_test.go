@@ -0,0 +1,15 @@
+package serial
+
+import (
+	"time"
+)
+
+func initTestAdaptorWithMockPort() (*Adaptor, *MockPort) {
+	a := NewAdaptor("/dev/ttyUSB0", 9600)
+	mp := a.UseMockPort()
+	_ = a.SetReadTimeout(5 * time.Millisecond)
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, mp
+}
@@ -0,0 +1,134 @@
+package serial
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	mhz19CmdReadCO2       = 0x86
+	mhz19CmdCalibrateZero = 0x87
+	mhz19CmdCalibrateSpan = 0x88
+	mhz19CmdAutoCalibrate = 0x79
+	mhz19CmdRange         = 0x99
+
+	mhz19ResponseTimeout = time.Second
+)
+
+// MHZ19Driver is a driver for the MH-Z19 (B, C) NDIR CO2 sensor of Winsen. The baud rate is 9600.
+type MHZ19Driver struct {
+	name       string
+	connection SerialConnector
+	mutex      sync.Mutex
+}
+
+// NewMHZ19Driver creates a new driver for the MH-Z19 sensor.
+func NewMHZ19Driver(a SerialConnector) *MHZ19Driver {
+	return &MHZ19Driver{
+		name:       gobot.DefaultName("MH-Z19"),
+		connection: a,
+	}
+}
+
+// Name returns the Driver name
+func (d *MHZ19Driver) Name() string { return d.name }
+
+// SetName sets the Driver name
+func (d *MHZ19Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Driver's Connection to the associated Adaptor
+func (d *MHZ19Driver) Connection() gobot.Connection { return d.connection }
+
+// Start initializes the driver
+func (d *MHZ19Driver) Start() error { return nil }
+
+// Halt halts the driver
+func (d *MHZ19Driver) Halt() error { return nil }
+
+// CO2 returns the CO2 concentration in ppm
+func (d *MHZ19Driver) CO2() (int, error) {
+	co2, _, err := d.Read()
+	return co2, err
+}
+
+// Read returns the CO2 concentration in ppm and the temperature in °C. The temperature is measured inside the sensor
+// with 1°C resolution, so it is not very accurate.
+func (d *MHZ19Driver) Read() (int, int, error) {
+	resp, err := d.command(mhz19CmdReadCO2, nil, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	co2 := int(resp[2])<<8 | int(resp[3])
+	temperature := int(resp[4]) - 40
+	return co2, temperature, nil
+}
+
+// CalibrateZero calibrates the zero point (400ppm), the sensor needs to be in fresh air for more than 20 minutes
+func (d *MHZ19Driver) CalibrateZero() error {
+	_, err := d.command(mhz19CmdCalibrateZero, nil, false)
+	return err
+}
+
+// CalibrateSpan calibrates the span point with the given CO2 concentration in ppm (should be 2000ppm or more),
+// a zero calibration needs to be done before
+func (d *MHZ19Driver) CalibrateSpan(ppm int) error {
+	_, err := d.command(mhz19CmdCalibrateSpan, []byte{byte(ppm >> 8), byte(ppm)}, false)
+	return err
+}
+
+// SetAutoCalibration switches the automatic baseline correction (ABC) on or off, which is on by default
+func (d *MHZ19Driver) SetAutoCalibration(on bool) error {
+	var val byte
+	if on {
+		val = 0xA0
+	}
+	_, err := d.command(mhz19CmdAutoCalibrate, []byte{val}, false)
+	return err
+}
+
+// SetDetectionRange sets the measurement range, e.g. 2000 or 5000 ppm
+func (d *MHZ19Driver) SetDetectionRange(ppm int) error {
+	if ppm <= 0 || ppm > 0xFFFF {
+		return fmt.Errorf("detection range %d ppm is out of range", ppm)
+	}
+	// the range is given by the bytes 6 and 7 of the command
+	_, err := d.command(mhz19CmdRange, []byte{0, 0, 0, byte(ppm >> 8), byte(ppm)}, false)
+	return err
+}
+
+// command writes the command with the data of the bytes 3..7 and returns the response, if requested
+func (d *MHZ19Driver) command(cmd byte, data []byte, withResponse bool) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	buf := []byte{0xFF, 0x01, cmd, 0, 0, 0, 0, 0, 0}
+	copy(buf[3:8], data)
+	buf[8] = mhz19Checksum(buf)
+	if _, err := d.connection.Write(buf); err != nil {
+		return nil, err
+	}
+	if !withResponse {
+		return nil, nil
+	}
+	reader := NewFrameReader(d.connection, []byte{0xFF, cmd}, 2, func([]byte) int { return 9 })
+	resp, err := reader.ReadFrame(mhz19ResponseTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if want := mhz19Checksum(resp); resp[8] != want {
+		return nil, fmt.Errorf("MH-Z19 checksum mismatch, got 0x%02X, want 0x%02X", resp[8], want)
+	}
+	return resp, nil
+}
+
+// mhz19Checksum calculates the checksum of the bytes 1..7
+func mhz19Checksum(buf []byte) byte {
+	var sum byte
+	for _, b := range buf[1:8] {
+		sum += b
+	}
+	return 0xFF - sum + 1
+}
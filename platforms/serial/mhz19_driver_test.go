@@ -0,0 +1,133 @@
+package serial
+
+import (
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.Driver, which makes it possible to use it in a robot
+var _ gobot.Driver = (*MHZ19Driver)(nil)
+
+func TestNewMHZ19Driver(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithMockPort()
+	// act
+	d := NewMHZ19Driver(a)
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MH-Z19"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	d.SetName("myCO2")
+	gobottest.Assert(t, d.Name(), "myCO2")
+}
+
+func TestMHZ19DriverRead(t *testing.T) {
+	var tests = map[string]struct {
+		response []byte
+		wantCO2  int
+		wantTemp int
+		wantErr  string
+	}{
+		"ok": {
+			response: []byte{0xFF, 0x86, 0x02, 0x60, 0x47, 0x00, 0x00, 0x00, 0xD1},
+			wantCO2:  608,
+			wantTemp: 31,
+		},
+		"skip_stale_data": {
+			response: []byte{0x00, 0xFF, 0x99, 0xFF, 0x86, 0x01, 0x90, 0x3C, 0x00, 0x00, 0x00, 0xAD},
+			wantCO2:  400,
+			wantTemp: 20,
+		},
+		"checksum_error": {
+			response: []byte{0xFF, 0x86, 0x02, 0x60, 0x47, 0x00, 0x00, 0x00, 0xD2},
+			wantErr:  "MH-Z19 checksum mismatch, got 0xD2, want 0xD1",
+		},
+		"no_response": {
+			wantErr: "serial read timeout",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, mp := initTestAdaptorWithMockPort()
+			d := NewMHZ19Driver(a)
+			mp.Simulate(tc.response)
+			// act
+			co2, temp, err := d.Read()
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+			}
+			gobottest.Assert(t, co2, tc.wantCO2)
+			gobottest.Assert(t, temp, tc.wantTemp)
+			gobottest.Assert(t, mp.Written(), []byte{0xFF, 0x01, 0x86, 0x00, 0x00, 0x00, 0x00, 0x00, 0x79})
+		})
+	}
+}
+
+func TestMHZ19DriverCO2(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	d := NewMHZ19Driver(a)
+	mp.Simulate([]byte{0xFF, 0x86, 0x02, 0x60, 0x47, 0x00, 0x00, 0x00, 0xD1})
+	// act
+	co2, err := d.CO2()
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, co2, 608)
+}
+
+func TestMHZ19DriverCommands(t *testing.T) {
+	var tests = map[string]struct {
+		cmd     func(d *MHZ19Driver) error
+		want    []byte
+		wantErr string
+	}{
+		"calibrate_zero": {
+			cmd:  func(d *MHZ19Driver) error { return d.CalibrateZero() },
+			want: []byte{0xFF, 0x01, 0x87, 0x00, 0x00, 0x00, 0x00, 0x00, 0x78},
+		},
+		"calibrate_span": {
+			cmd:  func(d *MHZ19Driver) error { return d.CalibrateSpan(2000) },
+			want: []byte{0xFF, 0x01, 0x88, 0x07, 0xD0, 0x00, 0x00, 0x00, 0xA0},
+		},
+		"abc_on": {
+			cmd:  func(d *MHZ19Driver) error { return d.SetAutoCalibration(true) },
+			want: []byte{0xFF, 0x01, 0x79, 0xA0, 0x00, 0x00, 0x00, 0x00, 0xE6},
+		},
+		"abc_off": {
+			cmd:  func(d *MHZ19Driver) error { return d.SetAutoCalibration(false) },
+			want: []byte{0xFF, 0x01, 0x79, 0x00, 0x00, 0x00, 0x00, 0x00, 0x86},
+		},
+		"range_5000": {
+			cmd:  func(d *MHZ19Driver) error { return d.SetDetectionRange(5000) },
+			want: []byte{0xFF, 0x01, 0x99, 0x00, 0x00, 0x00, 0x13, 0x88, 0xCB},
+		},
+		"range_invalid": {
+			cmd:     func(d *MHZ19Driver) error { return d.SetDetectionRange(0) },
+			wantErr: "detection range 0 ppm is out of range",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, mp := initTestAdaptorWithMockPort()
+			d := NewMHZ19Driver(a)
+			// act
+			err := tc.cmd(d)
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Assert(t, err.Error(), tc.wantErr)
+			} else {
+				gobottest.Assert(t, err, nil)
+			}
+			gobottest.Assert(t, mp.Written(), tc.want)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package serial
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var errMockUnplugged = errors.New("mocked serial port is unplugged")
+
+// MockPort represents a mocked serial port, the received data are given by Simulate().
+type MockPort struct {
+	mutex       sync.Mutex
+	rx          []byte
+	written     []byte
+	readTimeout time.Duration
+	unplugged   bool
+	opened      int
+	closed      bool
+}
+
+// NewMockPort returns a new mocked serial port.
+func NewMockPort() *MockPort {
+	return &MockPort{readTimeout: defaultReadTimeout}
+}
+
+// Simulate adds the given data to the received data.
+func (p *MockPort) Simulate(data []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.rx = append(p.rx, data...)
+}
+
+// Written returns all data written to the port.
+func (p *MockPort) Written() []byte {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]byte(nil), p.written...)
+}
+
+// Unplug simulates the removal of the device, all reads, writes and openings fail until Plug() is called.
+func (p *MockPort) Unplug() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.unplugged = true
+}
+
+// Plug simulates the reconnection of the device.
+func (p *MockPort) Plug() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.unplugged = false
+}
+
+// OpenCount returns how often the port was opened.
+func (p *MockPort) OpenCount() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.opened
+}
+
+// Read reads the simulated data, returns 0 bytes after the read timeout, if no data are available.
+func (p *MockPort) Read(b []byte) (int, error) {
+	deadline := time.Now().Add(p.timeout())
+	for {
+		p.mutex.Lock()
+		if p.unplugged || p.closed {
+			p.mutex.Unlock()
+			return 0, errMockUnplugged
+		}
+		if len(p.rx) > 0 {
+			n := copy(b, p.rx)
+			p.rx = p.rx[n:]
+			p.mutex.Unlock()
+			return n, nil
+		}
+		p.mutex.Unlock()
+		if !time.Now().Before(deadline) {
+			return 0, nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Write records the written data.
+func (p *MockPort) Write(b []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.unplugged || p.closed {
+		return 0, errMockUnplugged
+	}
+	p.written = append(p.written, b...)
+	return len(b), nil
+}
+
+// SetReadTimeout sets the timeout of a read.
+func (p *MockPort) SetReadTimeout(t time.Duration) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.readTimeout = t
+	return nil
+}
+
+// Close closes the port.
+func (p *MockPort) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.closed = true
+	return nil
+}
+
+func (p *MockPort) open() (*MockPort, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.unplugged {
+		return nil, errMockUnplugged
+	}
+	p.opened++
+	p.closed = false
+	return p, nil
+}
+
+func (p *MockPort) timeout() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.readTimeout
+}
@@ -0,0 +1,218 @@
+package serial
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// PMS5003DataEvent is published with PMS5003Data on each received measurement
+	PMS5003DataEvent = "data"
+
+	pms5003DataLength = 28
+
+	pms5003CmdRead  = 0xE2
+	pms5003CmdMode  = 0xE1
+	pms5003CmdSleep = 0xE4
+)
+
+// PMS5003Data contains the measurement values of the sensor
+type PMS5003Data struct {
+	// PM1, PM25, PM10 are the concentrations of PM1.0, PM2.5 and PM10 in µg/m³ (CF=1, standard particle)
+	PM1, PM25, PM10 uint16
+	// PM1Atmospheric, PM25Atmospheric, PM10Atmospheric are the concentrations in µg/m³ (under atmospheric environment)
+	PM1Atmospheric, PM25Atmospheric, PM10Atmospheric uint16
+	// Particles03 .. Particles10 are the number of particles beyond 0.3, 0.5, 1.0, 2.5, 5.0 and 10 µm in 0.1 l air
+	Particles03, Particles05, Particles1, Particles25, Particles5, Particles10 uint16
+}
+
+// PMS5003Driver is a driver for the PMS5003 particulate matter sensor of Plantower. Also the PMS7003 and PMSA003 use
+// the same protocol. The baud rate is 9600.
+type PMS5003Driver struct {
+	name        string
+	connection  SerialConnector
+	frameReader *FrameReader
+	data        PMS5003Data
+	received    bool
+	interval    time.Duration // interval of requests in passive mode, 0 in active mode
+	halt        chan bool
+	done        chan bool
+	mutex       sync.Mutex
+	gobot.Eventer
+}
+
+// NewPMS5003Driver creates a new driver for the PMS5003 sensor, which starts in active mode.
+//
+// Emits the Events:
+//	"data" - PMS5003Data
+//	"error" - error on reading or a checksum mismatch
+func NewPMS5003Driver(a SerialConnector) *PMS5003Driver {
+	d := &PMS5003Driver{
+		name:       gobot.DefaultName("PMS5003"),
+		connection: a,
+		frameReader: NewFrameReader(a, []byte{0x42, 0x4D}, 4, func(header []byte) int {
+			return 4 + int(binary.BigEndian.Uint16(header[2:4]))
+		}),
+		Eventer: gobot.NewEventer(),
+	}
+	d.AddEvent(PMS5003DataEvent)
+	d.AddEvent(Error)
+	return d
+}
+
+// Name returns the Driver name
+func (d *PMS5003Driver) Name() string { return d.name }
+
+// SetName sets the Driver name
+func (d *PMS5003Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Driver's Connection to the associated Adaptor
+func (d *PMS5003Driver) Connection() gobot.Connection { return d.connection }
+
+// Start starts reading the measurements
+func (d *PMS5003Driver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.halt != nil {
+		return nil
+	}
+	d.halt = make(chan bool)
+	d.done = make(chan bool)
+	go d.readLoop(d.halt, d.done)
+	return nil
+}
+
+// Halt stops reading the measurements
+func (d *PMS5003Driver) Halt() error {
+	d.mutex.Lock()
+	halt, done := d.halt, d.done
+	d.halt = nil
+	d.mutex.Unlock()
+
+	if halt == nil {
+		return nil
+	}
+	close(halt)
+	<-done
+	return nil
+}
+
+// Data returns the last received measurement, false if nothing was received yet
+func (d *PMS5003Driver) Data() (PMS5003Data, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.data, d.received
+}
+
+// SetActiveMode switches the sensor to active mode, the sensor sends the measurements by itself
+func (d *PMS5003Driver) SetActiveMode() error {
+	d.mutex.Lock()
+	d.interval = 0
+	d.mutex.Unlock()
+
+	return d.writeCommand(pms5003CmdMode, 1)
+}
+
+// SetPassiveMode switches the sensor to passive mode, the measurements are requested with the given interval
+func (d *PMS5003Driver) SetPassiveMode(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval needs to be greater than 0, got %s", interval)
+	}
+	d.mutex.Lock()
+	d.interval = interval
+	d.mutex.Unlock()
+
+	return d.writeCommand(pms5003CmdMode, 0)
+}
+
+// Sleep switches off the fan and laser of the sensor
+func (d *PMS5003Driver) Sleep() error {
+	return d.writeCommand(pms5003CmdSleep, 0)
+}
+
+// Wakeup switches on the fan and laser of the sensor, the values are stable after 30 seconds
+func (d *PMS5003Driver) Wakeup() error {
+	return d.writeCommand(pms5003CmdSleep, 1)
+}
+
+func (d *PMS5003Driver) readLoop(halt chan bool, done chan bool) {
+	defer close(done)
+	var lastRequest time.Time
+	for {
+		select {
+		case <-halt:
+			return
+		default:
+		}
+		d.mutex.Lock()
+		interval := d.interval
+		d.mutex.Unlock()
+		if interval > 0 && time.Since(lastRequest) >= interval {
+			lastRequest = time.Now()
+			if err := d.writeCommand(pms5003CmdRead, 0); err != nil {
+				d.Publish(Error, err)
+			}
+		}
+		frame, err := d.frameReader.ReadFrame(loopReadTimeout)
+		if err != nil {
+			if err == ErrTimeout {
+				continue
+			}
+			if err != ErrDisconnected {
+				d.Publish(Error, err)
+			}
+			// avoid a busy loop while disconnected
+			time.Sleep(loopReadTimeout)
+			continue
+		}
+		if err := d.handleFrame(frame); err != nil {
+			d.Publish(Error, err)
+		}
+	}
+}
+
+func (d *PMS5003Driver) handleFrame(frame []byte) error {
+	var sum uint16
+	for _, b := range frame[:len(frame)-2] {
+		sum += uint16(b)
+	}
+	if want := binary.BigEndian.Uint16(frame[len(frame)-2:]); sum != want {
+		return fmt.Errorf("PMS5003 checksum mismatch, got 0x%04X, want 0x%04X", sum, want)
+	}
+	if len(frame)-4 != pms5003DataLength {
+		// answer to a command
+		return nil
+	}
+	w := func(idx int) uint16 { return binary.BigEndian.Uint16(frame[4+2*idx:]) }
+	data := PMS5003Data{
+		PM1: w(0), PM25: w(1), PM10: w(2),
+		PM1Atmospheric: w(3), PM25Atmospheric: w(4), PM10Atmospheric: w(5),
+		Particles03: w(6), Particles05: w(7), Particles1: w(8),
+		Particles25: w(9), Particles5: w(10), Particles10: w(11),
+	}
+
+	d.mutex.Lock()
+	d.data = data
+	d.received = true
+	d.mutex.Unlock()
+
+	d.Publish(PMS5003DataEvent, data)
+	return nil
+}
+
+func (d *PMS5003Driver) writeCommand(cmd byte, data uint16) error {
+	buf := []byte{0x42, 0x4D, cmd, byte(data >> 8), byte(data)}
+	var sum uint16
+	for _, b := range buf {
+		sum += uint16(b)
+	}
+	buf = append(buf, byte(sum>>8), byte(sum))
+	_, err := d.connection.Write(buf)
+	return err
+}
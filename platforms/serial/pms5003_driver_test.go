@@ -0,0 +1,143 @@
+package serial
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.Driver, which makes it possible to use it in a robot
+var _ gobot.Driver = (*PMS5003Driver)(nil)
+
+func pms5003Frame(payload ...uint16) []byte {
+	length := 2*len(payload) + 2
+	frame := []byte{0x42, 0x4D, byte(length >> 8), byte(length)}
+	for _, w := range payload {
+		frame = append(frame, byte(w>>8), byte(w))
+	}
+	var sum uint16
+	for _, b := range frame {
+		sum += uint16(b)
+	}
+	return append(frame, byte(sum>>8), byte(sum))
+}
+
+func TestNewPMS5003Driver(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithMockPort()
+	// act
+	d := NewPMS5003Driver(a)
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "PMS5003"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	_, ok := d.Data()
+	gobottest.Assert(t, ok, false)
+	d.SetName("myPMS")
+	gobottest.Assert(t, d.Name(), "myPMS")
+}
+
+func TestPMS5003DriverData(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	d := NewPMS5003Driver(a)
+	events := make(chan PMS5003Data, 1)
+	_ = d.Once(PMS5003DataEvent, func(data interface{}) { events <- data.(PMS5003Data) })
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	want := PMS5003Data{
+		PM1: 1, PM25: 2, PM10: 3,
+		PM1Atmospheric: 4, PM25Atmospheric: 5, PM10Atmospheric: 6,
+		Particles03: 700, Particles05: 800, Particles1: 9,
+		Particles25: 10, Particles5: 11, Particles10: 12,
+	}
+	// act: answer of mode command is ignored, garbage is skipped
+	mp.Simulate(pms5003Frame(0xE100))
+	mp.Simulate([]byte{0x00, 0x42})
+	mp.Simulate(pms5003Frame(1, 2, 3, 4, 5, 6, 700, 800, 9, 10, 11, 12, 0x9700))
+	// assert
+	select {
+	case got := <-events:
+		gobottest.Assert(t, got, want)
+		data, ok := d.Data()
+		gobottest.Assert(t, ok, true)
+		gobottest.Assert(t, data, want)
+	case <-time.After(time.Second):
+		t.Fatalf("data event was not published")
+	}
+}
+
+func TestPMS5003DriverChecksumError(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	d := NewPMS5003Driver(a)
+	errs := make(chan error, 1)
+	_ = d.Once(Error, func(data interface{}) { errs <- data.(error) })
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	frame := pms5003Frame(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 0)
+	frame[len(frame)-1]++
+	// act
+	mp.Simulate(frame)
+	// assert
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err.Error(), "PMS5003 checksum mismatch, got 0x00F9, want 0x00FA")
+	case <-time.After(time.Second):
+		t.Fatalf("error event was not published")
+	}
+	_, ok := d.Data()
+	gobottest.Assert(t, ok, false)
+}
+
+func TestPMS5003DriverCommands(t *testing.T) {
+	var tests = map[string]struct {
+		cmd  func(d *PMS5003Driver) error
+		want []byte
+	}{
+		"active_mode":  {cmd: func(d *PMS5003Driver) error { return d.SetActiveMode() }, want: []byte{0x42, 0x4D, 0xE1, 0x00, 0x01, 0x01, 0x71}},
+		"passive_mode": {cmd: func(d *PMS5003Driver) error { return d.SetPassiveMode(time.Hour) }, want: []byte{0x42, 0x4D, 0xE1, 0x00, 0x00, 0x01, 0x70}},
+		"sleep":        {cmd: func(d *PMS5003Driver) error { return d.Sleep() }, want: []byte{0x42, 0x4D, 0xE4, 0x00, 0x00, 0x01, 0x73}},
+		"wakeup":       {cmd: func(d *PMS5003Driver) error { return d.Wakeup() }, want: []byte{0x42, 0x4D, 0xE4, 0x00, 0x01, 0x01, 0x74}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, mp := initTestAdaptorWithMockPort()
+			d := NewPMS5003Driver(a)
+			// act
+			err := tc.cmd(d)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, mp.Written(), tc.want)
+		})
+	}
+}
+
+func TestPMS5003DriverPassiveModeRequest(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	d := NewPMS5003Driver(a)
+	gobottest.Assert(t, d.SetPassiveMode(time.Hour), nil)
+	// act
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, d.Halt(), nil)
+	// assert: mode command followed by a single read request
+	gobottest.Assert(t, mp.Written(), []byte{
+		0x42, 0x4D, 0xE1, 0x00, 0x00, 0x01, 0x70,
+		0x42, 0x4D, 0xE2, 0x00, 0x00, 0x01, 0x71,
+	})
+}
+
+func TestPMS5003DriverPassiveModeInvalidInterval(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithMockPort()
+	d := NewPMS5003Driver(a)
+	// act
+	err := d.SetPassiveMode(0)
+	// assert
+	gobottest.Assert(t, err.Error(), "interval needs to be greater than 0, got 0s")
+}
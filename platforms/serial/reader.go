@@ -0,0 +1,129 @@
+package serial
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	maxLineLength  = 1024
+	maxFrameLength = 1024
+	readChunkSize  = 64
+)
+
+// ErrTimeout is returned if no complete line or frame was received in time
+var ErrTimeout = errors.New("serial read timeout")
+
+// LineReader reads lines, terminated by "\n", from a serial port. A read of the underlying reader needs to return
+// after some time, also if no data are received, which is done by the read timeout of the Adaptor.
+type LineReader struct {
+	r     io.Reader
+	buf   []byte
+	chunk []byte
+}
+
+// NewLineReader creates a new line reader for the given serial port
+func NewLineReader(r io.Reader) *LineReader {
+	return &LineReader{r: r, chunk: make([]byte, readChunkSize)}
+}
+
+// ReadLine returns the next line without the line ending "\n" or "\r\n". Received data are kept on timeout.
+func (lr *LineReader) ReadLine(timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if idx := bytes.IndexByte(lr.buf, '\n'); idx >= 0 {
+			line := string(bytes.TrimRight(lr.buf[:idx], "\r"))
+			lr.buf = lr.buf[idx+1:]
+			return line, nil
+		}
+		if len(lr.buf) > maxLineLength {
+			lr.buf = nil
+			return "", fmt.Errorf("line is longer than %d bytes", maxLineLength)
+		}
+		if !time.Now().Before(deadline) {
+			return "", ErrTimeout
+		}
+		n, err := lr.r.Read(lr.chunk)
+		lr.buf = append(lr.buf, lr.chunk[:n]...)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// FrameReader reads binary frames from a serial port. A frame begins with the start bytes, followed by the rest of
+// the header, which contains the information about the frame length. Data before the start bytes are skipped.
+type FrameReader struct {
+	r            io.Reader
+	start        []byte
+	headerLength int
+	frameLength  func(header []byte) int
+	buf          []byte
+	chunk        []byte
+}
+
+// NewFrameReader creates a new frame reader for the given serial port. The function frameLength returns the total
+// length of the frame, including the header, for the given header of the size headerLength.
+func NewFrameReader(r io.Reader, start []byte, headerLength int, frameLength func(header []byte) int) *FrameReader {
+	if headerLength < len(start) {
+		headerLength = len(start)
+	}
+	return &FrameReader{
+		r:            r,
+		start:        start,
+		headerLength: headerLength,
+		frameLength:  frameLength,
+		chunk:        make([]byte, readChunkSize),
+	}
+}
+
+// ReadFrame returns the next complete frame. Received data are kept on timeout.
+func (fr *FrameReader) ReadFrame(timeout time.Duration) ([]byte, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if frame := fr.nextFrame(); frame != nil {
+			return frame, nil
+		}
+		if !time.Now().Before(deadline) {
+			return nil, ErrTimeout
+		}
+		n, err := fr.r.Read(fr.chunk)
+		fr.buf = append(fr.buf, fr.chunk[:n]...)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// nextFrame returns the next frame from the buffer or nil, if not complete
+func (fr *FrameReader) nextFrame() []byte {
+	for {
+		idx := bytes.Index(fr.buf, fr.start)
+		if idx < 0 {
+			// keep a possible part of the start bytes
+			if keep := len(fr.start) - 1; len(fr.buf) > keep {
+				fr.buf = fr.buf[len(fr.buf)-keep:]
+			}
+			return nil
+		}
+		fr.buf = fr.buf[idx:]
+		if len(fr.buf) < fr.headerLength {
+			return nil
+		}
+		length := fr.frameLength(fr.buf[:fr.headerLength])
+		if length < fr.headerLength || length > maxFrameLength {
+			// invalid header, resynchronize behind the current start
+			fr.buf = fr.buf[1:]
+			continue
+		}
+		if len(fr.buf) < length {
+			return nil
+		}
+		frame := append([]byte(nil), fr.buf[:length]...)
+		fr.buf = fr.buf[length:]
+		return frame
+	}
+}
@@ -0,0 +1,138 @@
+package serial
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestLineReader(t *testing.T) {
+	var tests = map[string]struct {
+		data    string
+		want    []string
+		wantErr error
+	}{
+		"single_line":        {data: "hello\n", want: []string{"hello"}},
+		"crlf":               {data: "hello\r\nworld\r\n", want: []string{"hello", "world"}},
+		"empty_line":         {data: "\nnext\n", want: []string{"", "next"}},
+		"incomplete_timeout": {data: "first\nincompl", want: []string{"first"}, wantErr: ErrTimeout},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, mp := initTestAdaptorWithMockPort()
+			mp.Simulate([]byte(tc.data))
+			lr := NewLineReader(a)
+			// act & assert
+			for _, want := range tc.want {
+				got, err := lr.ReadLine(50 * time.Millisecond)
+				gobottest.Assert(t, err, nil)
+				gobottest.Assert(t, got, want)
+			}
+			if tc.wantErr != nil {
+				_, err := lr.ReadLine(20 * time.Millisecond)
+				gobottest.Assert(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLineReaderContinueAfterTimeout(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	lr := NewLineReader(a)
+	mp.Simulate([]byte("$GPG"))
+	_, err := lr.ReadLine(20 * time.Millisecond)
+	gobottest.Assert(t, err, ErrTimeout)
+	mp.Simulate([]byte("GA\n"))
+	// act
+	got, err := lr.ReadLine(20 * time.Millisecond)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got, "$GPGGA")
+}
+
+func TestLineReaderTooLong(t *testing.T) {
+	// arrange
+	lr := NewLineReader(bytes.NewReader(bytes.Repeat([]byte{'a'}, maxLineLength+100)))
+	// act
+	_, err := lr.ReadLine(time.Second)
+	// assert
+	gobottest.Assert(t, err.Error(), "line is longer than 1024 bytes")
+}
+
+func TestLineReaderError(t *testing.T) {
+	// arrange
+	lr := NewLineReader(bytes.NewReader([]byte("abc")))
+	// act
+	_, err := lr.ReadLine(time.Second)
+	// assert
+	gobottest.Assert(t, err, io.EOF)
+}
+
+func TestFrameReader(t *testing.T) {
+	lengthFromHeader := func(header []byte) int { return 3 + int(header[2]) }
+	var tests = map[string]struct {
+		data    []byte
+		want    [][]byte
+		wantErr error
+	}{
+		"single_frame": {
+			data: []byte{0xAA, 0x55, 0x02, 0x01, 0x02},
+			want: [][]byte{{0xAA, 0x55, 0x02, 0x01, 0x02}},
+		},
+		"skip_garbage": {
+			data: []byte{0x01, 0xAA, 0x02, 0xAA, 0x55, 0x01, 0x03, 0xAA, 0x55, 0x00},
+			want: [][]byte{{0xAA, 0x55, 0x01, 0x03}, {0xAA, 0x55, 0x00}},
+		},
+		"invalid_length_resync": {
+			data: []byte{0xAA, 0x55, 0xFF, 0xAA, 0x55, 0x00},
+			want: [][]byte{{0xAA, 0x55, 0x00}},
+		},
+		"incomplete_timeout": {
+			data:    []byte{0xAA, 0x55, 0x03, 0x01},
+			wantErr: ErrTimeout,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, mp := initTestAdaptorWithMockPort()
+			mp.Simulate(tc.data)
+			fr := NewFrameReader(a, []byte{0xAA, 0x55}, 3, func(header []byte) int {
+				if header[2] > 10 {
+					return 0
+				}
+				return lengthFromHeader(header)
+			})
+			// act & assert
+			for _, want := range tc.want {
+				got, err := fr.ReadFrame(50 * time.Millisecond)
+				gobottest.Assert(t, err, nil)
+				gobottest.Assert(t, got, want)
+			}
+			if tc.wantErr != nil {
+				_, err := fr.ReadFrame(20 * time.Millisecond)
+				gobottest.Assert(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFrameReaderSplitStart(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	fr := NewFrameReader(a, []byte{0xAA, 0x55}, 2, func([]byte) int { return 3 })
+	mp.Simulate([]byte{0x00, 0x01, 0xAA})
+	_, err := fr.ReadFrame(20 * time.Millisecond)
+	gobottest.Assert(t, err, ErrTimeout)
+	mp.Simulate([]byte{0x55, 0x07})
+	// act
+	got, err := fr.ReadFrame(20 * time.Millisecond)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, got, []byte{0xAA, 0x55, 0x07})
+}
@@ -0,0 +1,23 @@
+package serial
+
+import (
+	"io"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// Error event
+const Error = "error"
+
+// loopReadTimeout is used by the read loops of the drivers, received data are kept by the readers on timeout,
+// so the timeout only determines how fast the loop reacts on halt
+const loopReadTimeout = 100 * time.Millisecond
+
+// SerialConnector is the interface that a serial adaptor needs to implement for the drivers of this package
+type SerialConnector interface {
+	gobot.Adaptor
+	io.ReadWriter
+}
+
+var _ SerialConnector = (*Adaptor)(nil)
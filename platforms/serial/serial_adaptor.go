@@ -0,0 +1,217 @@
+package serial
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+
+	bugst "go.bug.st/serial"
+)
+
+const (
+	// Disconnected event, the port is closed after an error, e.g. the device was unplugged
+	Disconnected = "disconnected"
+	// Reconnected event, the port is opened again
+	Reconnected = "reconnected"
+
+	defaultReadTimeout       = 100 * time.Millisecond
+	defaultReconnectInterval = time.Second
+)
+
+// ErrDisconnected is returned on read or write, while the port is disconnected
+var ErrDisconnected = errors.New("serial port is disconnected")
+
+// Port is the interface to a serial port, implemented by go.bug.st/serial and the MockPort.
+type Port interface {
+	io.ReadWriteCloser
+	// SetReadTimeout sets the timeout of a read, a read returns 0 bytes on timeout.
+	SetReadTimeout(t time.Duration) error
+}
+
+// Adaptor is the Gobot adaptor for a serial port. A read returns after the read timeout (default 100ms) with 0 bytes,
+// if no data are received. If a read or write fails, e.g. because the device was unplugged, the port is closed and
+// reopened on the next read or write, at most once per reconnect interval (default 1s).
+type Adaptor struct {
+	name              string
+	port              string
+	baudRate          int
+	readTimeout       time.Duration
+	reconnectInterval time.Duration
+	sp                Port
+	connected         bool
+	lastOpen          time.Time
+	openPort          func(port string, baudRate int) (Port, error)
+	mutex             sync.Mutex
+	gobot.Eventer
+}
+
+// NewAdaptor returns a new serial adaptor for the given port, e.g. "/dev/ttyUSB0" or "COM3", with the given baud rate.
+// The data format is 8 data bits, no parity, one stop bit (8N1).
+func NewAdaptor(port string, baudRate int) *Adaptor {
+	a := &Adaptor{
+		name:              gobot.DefaultName("Serial"),
+		port:              port,
+		baudRate:          baudRate,
+		readTimeout:       defaultReadTimeout,
+		reconnectInterval: defaultReconnectInterval,
+		openPort: func(port string, baudRate int) (Port, error) {
+			return bugst.Open(port, &bugst.Mode{BaudRate: baudRate})
+		},
+		Eventer: gobot.NewEventer(),
+	}
+	a.AddEvent(Disconnected)
+	a.AddEvent(Reconnected)
+	return a
+}
+
+// Name returns the Adaptor's name
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the Adaptor's name
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Port returns the Adaptor's port
+func (a *Adaptor) Port() string { return a.port }
+
+// BaudRate returns the baud rate of the port
+func (a *Adaptor) BaudRate() int { return a.baudRate }
+
+// SetReconnectInterval sets the minimal time between two attempts to reopen the port after an error
+func (a *Adaptor) SetReconnectInterval(t time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.reconnectInterval = t
+}
+
+// Connect opens the serial port.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.connected = true
+	return a.open()
+}
+
+// Finalize closes the serial port.
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.connected = false
+	if a.sp == nil {
+		return nil
+	}
+	err := a.sp.Close()
+	a.sp = nil
+	return err
+}
+
+// IsOpen returns true, if the port is open. It is closed after an error until the next successful reconnect.
+func (a *Adaptor) IsOpen() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.sp != nil
+}
+
+// SetReadTimeout sets the time, after a read returns with 0 bytes, if no data are received.
+func (a *Adaptor) SetReadTimeout(t time.Duration) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.readTimeout = t
+	if a.sp == nil {
+		return nil
+	}
+	return a.sp.SetReadTimeout(t)
+}
+
+// Read reads from the serial port. Returns 0 bytes after the read timeout, if no data are received.
+func (a *Adaptor) Read(b []byte) (int, error) {
+	sp, err := a.port4Access()
+	if err != nil {
+		return 0, err
+	}
+	n, err := sp.Read(b)
+	if err != nil {
+		a.disconnect(sp, err)
+	}
+	return n, err
+}
+
+// Write writes to the serial port.
+func (a *Adaptor) Write(b []byte) (int, error) {
+	sp, err := a.port4Access()
+	if err != nil {
+		return 0, err
+	}
+	n, err := sp.Write(b)
+	if err != nil {
+		a.disconnect(sp, err)
+	}
+	return n, err
+}
+
+// UseMockPort replaces the serial port by a mocked one. Used only for tests.
+func (a *Adaptor) UseMockPort() *MockPort {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	mp := NewMockPort()
+	a.openPort = func(port string, baudRate int) (Port, error) {
+		return mp.open()
+	}
+	return mp
+}
+
+// port4Access returns the open port, a reconnect is done if needed
+func (a *Adaptor) port4Access() (Port, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.connected {
+		return nil, errors.New("serial adaptor is not connected")
+	}
+	if a.sp != nil {
+		return a.sp, nil
+	}
+	if time.Since(a.lastOpen) < a.reconnectInterval {
+		return nil, ErrDisconnected
+	}
+	if err := a.open(); err != nil {
+		return nil, ErrDisconnected
+	}
+	a.Publish(Reconnected, a.port)
+	return a.sp, nil
+}
+
+func (a *Adaptor) open() error {
+	a.lastOpen = time.Now()
+	sp, err := a.openPort(a.port, a.baudRate)
+	if err != nil {
+		return err
+	}
+	if err := sp.SetReadTimeout(a.readTimeout); err != nil {
+		sp.Close()
+		return err
+	}
+	a.sp = sp
+	return nil
+}
+
+func (a *Adaptor) disconnect(sp Port, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.sp != sp {
+		// already closed by a concurrent access
+		return
+	}
+	a.sp.Close()
+	a.sp = nil
+	a.Publish(Disconnected, err)
+}
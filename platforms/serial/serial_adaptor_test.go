@@ -0,0 +1,131 @@
+package serial
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.Adaptor, which makes it possible to use it in a robot
+var _ gobot.Adaptor = (*Adaptor)(nil)
+
+func TestNewAdaptor(t *testing.T) {
+	// arrange & act
+	a := NewAdaptor("/dev/ttyS0", 115200)
+	// assert
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "Serial"), true)
+	gobottest.Assert(t, a.Port(), "/dev/ttyS0")
+	gobottest.Assert(t, a.BaudRate(), 115200)
+	gobottest.Assert(t, a.IsOpen(), false)
+	a.SetName("mySerial")
+	gobottest.Assert(t, a.Name(), "mySerial")
+}
+
+func TestAdaptorConnectFinalize(t *testing.T) {
+	// arrange
+	a := NewAdaptor("/dev/ttyUSB0", 9600)
+	mp := a.UseMockPort()
+	// act & assert
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.IsOpen(), true)
+	gobottest.Assert(t, mp.OpenCount(), 1)
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, a.IsOpen(), false)
+	_, err := a.Write([]byte{1})
+	gobottest.Assert(t, err.Error(), "serial adaptor is not connected")
+}
+
+func TestAdaptorConnectError(t *testing.T) {
+	// arrange
+	a := NewAdaptor("/dev/ttyUSB0", 9600)
+	mp := a.UseMockPort()
+	mp.Unplug()
+	// act
+	err := a.Connect()
+	// assert
+	gobottest.Assert(t, err, errMockUnplugged)
+	gobottest.Assert(t, a.IsOpen(), false)
+}
+
+func TestAdaptorReadWrite(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	mp.Simulate([]byte{0x11, 0x22})
+	buf := make([]byte, 10)
+	// act
+	n, err := a.Read(buf)
+	wn, werr := a.Write([]byte{0x33, 0x44, 0x55})
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, buf[:n], []byte{0x11, 0x22})
+	gobottest.Assert(t, werr, nil)
+	gobottest.Assert(t, wn, 3)
+	gobottest.Assert(t, mp.Written(), []byte{0x33, 0x44, 0x55})
+}
+
+func TestAdaptorReadTimeout(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithMockPort()
+	buf := make([]byte, 10)
+	start := time.Now()
+	// act
+	n, err := a.Read(buf)
+	// assert
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 0)
+	gobottest.Assert(t, time.Since(start) >= 5*time.Millisecond, true)
+}
+
+func TestAdaptorReconnect(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	a.SetReconnectInterval(0)
+	disconnected := make(chan error, 1)
+	reconnected := make(chan interface{}, 1)
+	_ = a.Once(Disconnected, func(data interface{}) { disconnected <- data.(error) })
+	_ = a.Once(Reconnected, func(data interface{}) { reconnected <- data })
+	mp.Unplug()
+	// act & assert: the failing write closes the port
+	_, err := a.Write([]byte{0x01})
+	gobottest.Assert(t, err, errMockUnplugged)
+	gobottest.Assert(t, a.IsOpen(), false)
+	select {
+	case err := <-disconnected:
+		gobottest.Assert(t, err, errMockUnplugged)
+	case <-time.After(time.Second):
+		t.Errorf("disconnected event was not published")
+	}
+	// act & assert: reopen fails, while unplugged
+	_, err = a.Write([]byte{0x02})
+	gobottest.Assert(t, err, ErrDisconnected)
+	// act & assert: reopen on next access
+	mp.Plug()
+	_, err = a.Write([]byte{0x03})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, a.IsOpen(), true)
+	gobottest.Assert(t, mp.OpenCount(), 2)
+	gobottest.Assert(t, mp.Written(), []byte{0x03})
+	select {
+	case port := <-reconnected:
+		gobottest.Assert(t, port, "/dev/ttyUSB0")
+	case <-time.After(time.Second):
+		t.Errorf("reconnected event was not published")
+	}
+}
+
+func TestAdaptorReconnectInterval(t *testing.T) {
+	// arrange
+	a, mp := initTestAdaptorWithMockPort()
+	a.SetReconnectInterval(time.Hour)
+	mp.Unplug()
+	_, _ = a.Read(make([]byte, 1))
+	mp.Plug()
+	// act
+	_, err := a.Read(make([]byte, 1))
+	// assert
+	gobottest.Assert(t, err, ErrDisconnected)
+	gobottest.Assert(t, mp.OpenCount(), 1)
+}
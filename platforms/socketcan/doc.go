@@ -0,0 +1,16 @@
+/*
+Package socketcan provides the Gobot adaptor for CAN interfaces of the Linux SocketCAN subsystem, like USB-CAN
+adapters, the CAN controllers of many SoCs, or the MCP2515 with the kernel driver, and virtual "vcan" interfaces.
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot/...
+
+The interface needs to be configured and enabled before use, e.g. with:
+
+	sudo ip link set can0 up type can bitrate 500000
+
+For further information refer to socketcan README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/socketcan/README.md
+*/
+package socketcan // import "gobot.io/x/gobot/platforms/socketcan"
@@ -0,0 +1,40 @@
+//go:build linux
+// +build linux
+
+package socketcan
+
+import (
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// canRawErrFilter is CAN_RAW_ERR_FILTER of linux/can/raw.h
+const canRawErrFilter = 2
+
+// openSocket opens a raw CAN socket for the interface, which also receives the error frames
+func openSocket(iface string) (canSocket, error) {
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := unix.Socket(unix.AF_CAN, unix.SOCK_RAW, unix.CAN_RAW)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.SetsockoptInt(fd, unix.SOL_CAN_RAW, canRawErrFilter, unix.CAN_ERR_MASK); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrCAN{Ifindex: ifi.Index}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	// with non-blocking mode the file uses the poller of the runtime, so a pending read returns on close
+	if err := unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), iface), nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package socketcan
+
+import (
+	"errors"
+)
+
+// openSocket is only supported on Linux
+func openSocket(iface string) (canSocket, error) {
+	return nil, errors.New("SocketCAN is only supported on Linux")
+}
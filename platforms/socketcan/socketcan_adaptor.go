@@ -0,0 +1,249 @@
+package socketcan
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/can"
+)
+
+const (
+	// Error is the event name, which is published when reading from the socket fails
+	Error = "error"
+
+	// according to linux/can.h and linux/can/error.h
+	frameSize       = 16
+	effFlag         = 0x80000000
+	rtrFlag         = 0x40000000
+	errFlag         = 0x20000000
+	errClassCtrl    = 0x00000004
+	errClassBusOff  = 0x00000040
+	errClassRestart = 0x00000100
+	errClassCounter = 0x00000200
+	errCtrlRxOver   = 0x01
+	errCtrlTxOver   = 0x02
+	errCtrlWarning  = 0x04 | 0x08
+	errCtrlPassive  = 0x10 | 0x20
+	errCtrlActive   = 0x40
+)
+
+// hostByteOrder is used for the identifier of the frames, which is in the byte order of the host
+var hostByteOrder binary.ByteOrder = binary.LittleEndian
+
+func init() {
+	i := uint16(1)
+	if *(*byte)(unsafe.Pointer(&i)) == 0 {
+		hostByteOrder = binary.BigEndian
+	}
+}
+
+// canSocket is the raw CAN socket, implemented by an os.File on Linux
+type canSocket interface {
+	Read(b []byte) (n int, err error)
+	Write(b []byte) (n int, err error)
+	Close() error
+}
+
+// Adaptor is a connection to a CAN interface of SocketCAN by a raw socket. It implements the can.CanBus
+// interface. The error counters are taken from the error frames of the interface, so they are only available
+// for controllers, which report them.
+type Adaptor struct {
+	name       string
+	iface      string
+	socket     canSocket
+	openSocket func(iface string) (canSocket, error)
+	dispatcher *can.Dispatcher
+	counters   can.ErrorCounters
+	closing    bool
+	done       chan bool
+	mutex      *sync.Mutex
+	gobot.Eventer
+}
+
+// NewAdaptor returns a new SocketCAN adaptor for the given interface, e.g. "can0" or "vcan0".
+func NewAdaptor(iface string) *Adaptor {
+	a := &Adaptor{
+		name:       gobot.DefaultName("SocketCAN"),
+		iface:      iface,
+		openSocket: openSocket,
+		dispatcher: can.NewDispatcher(can.DefaultReceiveBufferSize),
+		mutex:      &sync.Mutex{},
+		Eventer:    gobot.NewEventer(),
+	}
+	a.AddEvent(Error)
+	return a
+}
+
+// Name returns the name of the adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Interface returns the name of the CAN interface
+func (a *Adaptor) Interface() string { return a.iface }
+
+// Connect opens the raw socket for the interface and starts receiving
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.socket != nil {
+		return fmt.Errorf("%s is already connected to %s", a.name, a.iface)
+	}
+	socket, err := a.openSocket(a.iface)
+	if err != nil {
+		return err
+	}
+	a.socket = socket
+	a.closing = false
+	a.done = make(chan bool)
+	a.dispatcher.Reopen()
+	go a.readLoop(socket, a.done)
+	return nil
+}
+
+// Finalize closes the socket and all receivers
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	socket := a.socket
+	done := a.done
+	a.socket = nil
+	a.closing = true
+	a.mutex.Unlock()
+
+	if socket == nil {
+		return nil
+	}
+	err := socket.Close()
+	<-done
+	a.dispatcher.Close()
+	return err
+}
+
+// Send transmits the frame. Implements the can.CanBus interface.
+func (a *Adaptor) Send(frame can.Frame) error {
+	if err := frame.Validate(); err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	socket := a.socket
+	a.mutex.Unlock()
+
+	if socket == nil {
+		return fmt.Errorf("%s is not connected", a.name)
+	}
+	_, err := socket.Write(encodeFrame(frame))
+	return err
+}
+
+// Receive creates a new receiver for the received frames, which match one of the filters, or all frames without
+// filter. Implements the can.CanBus interface.
+func (a *Adaptor) Receive(filters ...can.Filter) *can.Receiver {
+	return a.dispatcher.Receive(filters...)
+}
+
+// ErrorCounters returns the error counters and state of the last error frames of the interface. Implements the
+// can.CanBus interface.
+func (a *Adaptor) ErrorCounters() (can.ErrorCounters, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	counters := a.counters
+	counters.Dropped = a.dispatcher.Dropped()
+	return counters, nil
+}
+
+func (a *Adaptor) readLoop(socket canSocket, done chan bool) {
+	defer close(done)
+	buf := make([]byte, frameSize)
+	for {
+		n, err := socket.Read(buf)
+		if err == nil && n != frameSize {
+			err = fmt.Errorf("read %d bytes from %s, but a frame has %d bytes", n, a.iface, frameSize)
+		}
+		if err != nil {
+			a.mutex.Lock()
+			closing := a.closing
+			a.mutex.Unlock()
+			if !closing {
+				a.Publish(Error, err)
+			}
+			return
+		}
+		id := hostByteOrder.Uint32(buf[0:4])
+		if id&errFlag != 0 {
+			a.handleErrorFrame(id, buf[8:])
+			continue
+		}
+		a.dispatcher.Dispatch(decodeFrame(buf))
+	}
+}
+
+// handleErrorFrame updates the error counters from an error frame of the interface
+func (a *Adaptor) handleErrorFrame(id uint32, data []byte) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if id&errClassCtrl != 0 {
+		ctrl := data[1]
+		if ctrl&(errCtrlRxOver|errCtrlTxOver) != 0 {
+			a.counters.Overflows++
+		}
+		switch {
+		case ctrl&errCtrlPassive != 0:
+			a.counters.State = can.ErrorPassive
+		case ctrl&errCtrlWarning != 0:
+			a.counters.State = can.ErrorWarning
+		case ctrl&errCtrlActive != 0:
+			a.counters.State = can.ErrorActive
+		}
+	}
+	if id&errClassBusOff != 0 {
+		a.counters.State = can.BusOff
+	}
+	if id&errClassRestart != 0 {
+		a.counters.State = can.ErrorActive
+	}
+	if id&errClassCounter != 0 {
+		a.counters.Transmit = data[6]
+		a.counters.Receive = data[7]
+	}
+}
+
+// encodeFrame creates the content of the struct can_frame
+func encodeFrame(frame can.Frame) []byte {
+	buf := make([]byte, frameSize)
+	id := frame.ID
+	if frame.Extended {
+		id |= effFlag
+	}
+	if frame.Remote {
+		id |= rtrFlag
+	}
+	hostByteOrder.PutUint32(buf[0:4], id)
+	buf[4] = frame.DLC
+	copy(buf[8:], frame.Data)
+	return buf
+}
+
+// decodeFrame creates the frame from the content of the struct can_frame
+func decodeFrame(buf []byte) can.Frame {
+	id := hostByteOrder.Uint32(buf[0:4])
+	frame := can.Frame{Extended: id&effFlag != 0, Remote: id&rtrFlag != 0, DLC: buf[4]}
+	if frame.Extended {
+		frame.ID = id & can.MaxExtendedID
+	} else {
+		frame.ID = id & can.MaxStandardID
+	}
+	if frame.DLC > can.MaxDataLength {
+		frame.DLC = can.MaxDataLength
+	}
+	if !frame.Remote {
+		frame.Data = append([]byte(nil), buf[8:8+frame.DLC]...)
+	}
+	return frame
+}
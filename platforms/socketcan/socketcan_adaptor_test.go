@@ -0,0 +1,231 @@
+package socketcan
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/common/can"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Adaptor = (*Adaptor)(nil)
+var _ can.CanBus = (*Adaptor)(nil)
+
+// testSocket simulates the raw socket, the frames to read are given by the channel
+type testSocket struct {
+	frames  chan []byte
+	closed  chan bool
+	written [][]byte
+	readErr error
+	mutex   sync.Mutex
+}
+
+func newTestSocket() *testSocket {
+	return &testSocket{frames: make(chan []byte, 10), closed: make(chan bool)}
+}
+
+func (s *testSocket) Read(b []byte) (int, error) {
+	if s.readErr != nil {
+		return 0, s.readErr
+	}
+	select {
+	case frame := <-s.frames:
+		return copy(b, frame), nil
+	case <-s.closed:
+		return 0, os.ErrClosed
+	}
+}
+
+func (s *testSocket) Write(b []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.written = append(s.written, append([]byte{}, b...))
+	return len(b), nil
+}
+
+func (s *testSocket) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func initTestAdaptorWithSocket() (*Adaptor, *testSocket) {
+	a := NewAdaptor("vcan0")
+	socket := newTestSocket()
+	a.openSocket = func(iface string) (canSocket, error) { return socket, nil }
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, socket
+}
+
+func rawFrame(id uint32, dlc byte, data ...byte) []byte {
+	buf := make([]byte, frameSize)
+	hostByteOrder.PutUint32(buf, id)
+	buf[4] = dlc
+	copy(buf[8:], data)
+	return buf
+}
+
+func TestSocketCANAdaptorName(t *testing.T) {
+	a := NewAdaptor("can0")
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "SocketCAN"), true)
+	gobottest.Assert(t, a.Interface(), "can0")
+	a.SetName("NewName")
+	gobottest.Assert(t, a.Name(), "NewName")
+}
+
+func TestSocketCANAdaptorConnect(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithSocket()
+	defer a.Finalize()
+	// act & assert
+	gobottest.Assert(t, strings.HasSuffix(a.Connect().Error(), "is already connected to vcan0"), true)
+	b := NewAdaptor("can0")
+	b.openSocket = func(iface string) (canSocket, error) { return nil, errors.New("no such device") }
+	gobottest.Assert(t, b.Connect(), errors.New("no such device"))
+}
+
+func TestSocketCANAdaptorFinalize(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithSocket()
+	r := a.Receive()
+	// act
+	err := a.Finalize()
+	// assert
+	gobottest.Assert(t, err, nil)
+	_, ok := <-r.C
+	gobottest.Assert(t, ok, false)
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, strings.HasSuffix(a.Send(can.NewFrame(0x01)).Error(), "is not connected"), true)
+}
+
+func TestSocketCANAdaptorSend(t *testing.T) {
+	var tests = map[string]struct {
+		frame   can.Frame
+		want    []byte
+		wantErr error
+	}{
+		"standard": {
+			frame: can.NewFrame(0x123, 0xDE, 0xAD),
+			want:  rawFrame(0x123, 2, 0xDE, 0xAD),
+		},
+		"extended_remote": {
+			frame: can.Frame{ID: 0x18FEF100, Extended: true, Remote: true, DLC: 8},
+			want:  rawFrame(0x18FEF100|effFlag|rtrFlag, 8),
+		},
+		"invalid": {
+			frame:   can.Frame{ID: 0x800},
+			wantErr: errors.New("identifier 0x800 is out of range"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, socket := initTestAdaptorWithSocket()
+			defer a.Finalize()
+			// act
+			err := a.Send(tc.frame)
+			// assert
+			gobottest.Assert(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				gobottest.Assert(t, socket.written, [][]byte{tc.want})
+			}
+		})
+	}
+}
+
+func TestSocketCANAdaptorReceive(t *testing.T) {
+	// arrange
+	a, socket := initTestAdaptorWithSocket()
+	defer a.Finalize()
+	all := a.Receive()
+	filtered := a.Receive(can.Filter{ID: 0x100, Mask: 0x700})
+	// act
+	socket.frames <- rawFrame(0x18FEF100|effFlag, 1, 0x55)
+	socket.frames <- rawFrame(0x123|rtrFlag, 4)
+	socket.frames <- rawFrame(0x7FF, 0)
+	// assert
+	for _, want := range []can.Frame{
+		can.NewFrame(0x18FEF100, 0x55),
+		{ID: 0x123, Remote: true, DLC: 4},
+		can.NewFrame(0x7FF),
+	} {
+		select {
+		case frame := <-all.C:
+			gobottest.Assert(t, frame, want)
+		case <-time.After(time.Second):
+			t.Errorf("frame %s not received", want)
+		}
+	}
+	gobottest.Assert(t, (<-filtered.C).ID, uint32(0x123))
+	gobottest.Assert(t, len(filtered.C), 0)
+}
+
+func TestSocketCANAdaptorErrorFrames(t *testing.T) {
+	var tests = map[string]struct {
+		frames [][]byte
+		want   can.ErrorCounters
+	}{
+		"counters_and_warning": {
+			frames: [][]byte{rawFrame(errFlag|errClassCtrl|errClassCounter, 8, 0, 0x08, 0, 0, 0, 0, 97, 3)},
+			want:   can.ErrorCounters{Transmit: 97, Receive: 3, State: can.ErrorWarning},
+		},
+		"passive_and_overflow": {
+			frames: [][]byte{rawFrame(errFlag|errClassCtrl, 8, 0, 0x11)},
+			want:   can.ErrorCounters{State: can.ErrorPassive, Overflows: 1},
+		},
+		"bus_off_and_restart": {
+			frames: [][]byte{rawFrame(errFlag|errClassBusOff, 8), rawFrame(errFlag|errClassRestart, 8)},
+			want:   can.ErrorCounters{State: can.ErrorActive},
+		},
+		"bus_off": {
+			frames: [][]byte{rawFrame(errFlag|errClassBusOff, 8)},
+			want:   can.ErrorCounters{State: can.BusOff},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, socket := initTestAdaptorWithSocket()
+			r := a.Receive()
+			// act
+			for _, frame := range tc.frames {
+				socket.frames <- frame
+			}
+			// the following data frame is received after the error frames are handled
+			socket.frames <- rawFrame(0x01, 0)
+			<-r.C
+			// assert
+			got, err := a.ErrorCounters()
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, got, tc.want)
+			_ = a.Finalize()
+		})
+	}
+}
+
+func TestSocketCANAdaptorReadError(t *testing.T) {
+	// arrange
+	a := NewAdaptor("vcan0")
+	socket := newTestSocket()
+	socket.readErr = errors.New("network is down")
+	a.openSocket = func(iface string) (canSocket, error) { return socket, nil }
+	errs := make(chan error, 1)
+	_ = a.Once(Error, func(data interface{}) { errs <- data.(error) })
+	// act
+	_ = a.Connect()
+	// assert
+	select {
+	case err := <-errs:
+		gobottest.Assert(t, err, errors.New("network is down"))
+	case <-time.After(time.Second):
+		t.Errorf("error was not published")
+	}
+	_ = a.Finalize()
+}
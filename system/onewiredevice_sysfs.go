@@ -0,0 +1,77 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// oneWireDevicesPath default linux sysfs path of the w1 subsystem
+const oneWireDevicesPath = "/sys/bus/w1/devices"
+
+type onewireDeviceSysfs struct {
+	id   string
+	path string
+	fs   filesystem
+}
+
+// NewOneWireDevice returns a Linux Kernel access to the 1-wire device with the given id, e.g. "28-0000075f5ef1". The
+// device needs to be detected by the w1 subsystem, e.g. by the "w1-gpio" overlay on a Raspberry Pi.
+func (a *Accesser) NewOneWireDevice(id string) (*onewireDeviceSysfs, error) {
+	p := path.Join(oneWireDevicesPath, id)
+	if _, err := a.fs.stat(p); err != nil {
+		return nil, fmt.Errorf("1-wire device '%s' not found: %v", id, err)
+	}
+	return &onewireDeviceSysfs{id: id, path: p, fs: a.fs}, nil
+}
+
+// FindOneWireDevices returns the ids of all devices, which are detected by the given bus master of the w1 subsystem.
+// The bus is searched for devices periodically by the Kernel.
+func (a *Accesser) FindOneWireDevices(busNum int) ([]string, error) {
+	p := path.Join(oneWireDevicesPath, fmt.Sprintf("w1_bus_master%d", busNum), "w1_master_slaves")
+	content, err := a.fs.readFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, line := range strings.Split(string(content), "\n") {
+		id := strings.TrimSpace(line)
+		// the Kernel reports "not found." if no device is on the bus
+		if id == "" || id == "not found." {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ID returns the identifier of the device in the form "family-serial".
+func (d *onewireDeviceSysfs) ID() string {
+	return d.id
+}
+
+// ReadData reads the content of the given attribute of the device.
+func (d *onewireDeviceSysfs) ReadData(attribute string) ([]byte, error) {
+	return d.fs.readFile(path.Join(d.path, attribute))
+}
+
+// WriteData writes the given data to the given attribute of the device.
+func (d *onewireDeviceSysfs) WriteData(attribute string, data []byte) error {
+	f, err := d.fs.openFile(path.Join(d.path, attribute), os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Close implements the gobot.OneWireSystemDevicer interface. The attribute files are opened on each access, so
+// there is nothing to do.
+func (d *onewireDeviceSysfs) Close() error {
+	return nil
+}
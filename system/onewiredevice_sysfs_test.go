@@ -0,0 +1,122 @@
+package system
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// this ensures that the implementation is based on gobot.OneWireSystemDevicer interface
+var _ gobot.OneWireSystemDevicer = (*onewireDeviceSysfs)(nil)
+
+func TestNewOneWireDevice(t *testing.T) {
+	var tests = map[string]struct {
+		id      string
+		wantErr string
+	}{
+		"ok": {
+			id: "28-0000075f5ef1",
+		},
+		"not_found": {
+			id:      "28-000000000001",
+			wantErr: "1-wire device '28-000000000001' not found",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			a.UseMockFilesystem([]string{"/sys/bus/w1/devices/28-0000075f5ef1/w1_slave"})
+			// act
+			d, err := a.NewOneWireDevice(tc.id)
+			// assert
+			if tc.wantErr != "" {
+				gobottest.Refute(t, err, nil)
+				gobottest.Assert(t, err.Error()[:len(tc.wantErr)], tc.wantErr)
+				gobottest.Assert(t, d, (*onewireDeviceSysfs)(nil))
+				return
+			}
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, d.ID(), tc.id)
+			gobottest.Assert(t, d.path, "/sys/bus/w1/devices/28-0000075f5ef1")
+			gobottest.Assert(t, d.Close(), nil)
+		})
+	}
+}
+
+func TestFindOneWireDevices(t *testing.T) {
+	var tests = map[string]struct {
+		content string
+		want    []string
+	}{
+		"two_devices": {
+			content: "28-0000075f5ef1\n28-00000a1b2c3d\n",
+			want:    []string{"28-0000075f5ef1", "28-00000a1b2c3d"},
+		},
+		"no_device": {
+			content: "not found.\n",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			const slaves = "/sys/bus/w1/devices/w1_bus_master1/w1_master_slaves"
+			a := NewAccesser()
+			fs := a.UseMockFilesystem([]string{slaves})
+			fs.Files[slaves].Contents = tc.content
+			// act
+			ids, err := a.FindOneWireDevices(1)
+			// assert
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, ids, tc.want)
+		})
+	}
+}
+
+func TestFindOneWireDevicesError(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.UseMockFilesystem([]string{})
+	// act
+	ids, err := a.FindOneWireDevices(1)
+	// assert
+	gobottest.Refute(t, err, nil)
+	gobottest.Assert(t, len(ids), 0)
+}
+
+func TestOneWireDeviceReadWriteData(t *testing.T) {
+	// arrange
+	const (
+		slaveFile      = "/sys/bus/w1/devices/28-0000075f5ef1/w1_slave"
+		resolutionFile = "/sys/bus/w1/devices/28-0000075f5ef1/resolution"
+	)
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{slaveFile, resolutionFile})
+	fs.Files[slaveFile].Contents = "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n"
+	d, _ := a.NewOneWireDevice("28-0000075f5ef1")
+	// act
+	data, errRead := d.ReadData("w1_slave")
+	errWrite := d.WriteData("resolution", []byte("10"))
+	// assert
+	gobottest.Assert(t, errRead, nil)
+	gobottest.Assert(t, string(data), "72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n")
+	gobottest.Assert(t, errWrite, nil)
+	gobottest.Assert(t, fs.Files[resolutionFile].Contents, "10")
+	gobottest.Assert(t, fs.Files[resolutionFile].Closed, true)
+}
+
+func TestOneWireDeviceReadWriteDataError(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{"/sys/bus/w1/devices/28-0000075f5ef1/resolution"})
+	d, _ := a.NewOneWireDevice("28-0000075f5ef1")
+	// act & assert
+	_, err := d.ReadData("w1_slave")
+	gobottest.Refute(t, err, nil)
+	err = d.WriteData("alarms", []byte("0 30"))
+	gobottest.Refute(t, err, nil)
+	fs.WithWriteError = true
+	err = d.WriteData("resolution", []byte("10"))
+	gobottest.Assert(t, err.Error(), "write error")
+}